@@ -7,8 +7,10 @@ import (
 	"sync"
 	"time"
 
+	"github.com/edsonmichaque/bazaruto/internal/authorization"
 	"github.com/edsonmichaque/bazaruto/internal/config"
 	"github.com/edsonmichaque/bazaruto/internal/database"
+	"github.com/edsonmichaque/bazaruto/internal/events"
 	"github.com/edsonmichaque/bazaruto/internal/events/handlers"
 	"github.com/edsonmichaque/bazaruto/internal/jobs"
 	"github.com/edsonmichaque/bazaruto/internal/logger"
@@ -33,6 +35,7 @@ type Application struct {
 	// Event system
 	EventBus     event.EventBus
 	EventService *services.EventService
+	EventSchemas *events.SchemaRegistry
 
 	// Job system
 	JobManager    *job.Manager
@@ -52,6 +55,20 @@ type Application struct {
 	BeneficiaryStore  store.BeneficiaryStore
 	CoverageStore     store.CoverageStore
 	WebhookStore      store.WebhookStore
+	EventStore        store.EventStore
+	ClaimReserveStore store.ClaimReserveStore
+	RecoveryStore     store.ClaimRecoveryStore
+	AuditLogStore     store.AuditLogStore
+	EndorsementStore  store.EndorsementStore
+	AppealStore       store.AppealStore
+	CommissionStore   store.CommissionStore
+	StatementStore    store.PartnerStatementStore
+	TaxReportStore    store.PremiumTaxReportStore
+	SARStore          store.SARStore
+	SequenceStore     store.SequenceStore
+	DecisionStore     store.UnderwritingDecisionStore
+	WorkflowStore     store.ClaimWorkflowStore
+	ConfigStore       store.ConfigSnapshotStore
 
 	// Business services
 	ProductService         *services.ProductService
@@ -69,6 +86,24 @@ type Application struct {
 	ComplianceService      *services.ComplianceService
 	PolicyLifecycleService *services.PolicyLifecycleService
 	ClaimProcessingService *services.ClaimProcessingService
+	ClaimReserveService    *services.ClaimReserveService
+	RecoveryService        *services.RecoveryService
+	AuditService           *services.AuditService
+	Authorizer             authorization.Authorizer
+	InvoiceService         *services.InvoiceService
+	AppealService          *services.AppealService
+	StatementService       *services.PartnerStatementService
+	TaxReportService       *services.PremiumTaxReportService
+	QuoteDocumentService   *services.QuoteDocumentService
+	ClaimLetterService     *services.ClaimLetterService
+	NotificationService    *services.NotificationService
+	SARService             *services.SARService
+	KYCService             *services.KYCService
+	BeneficiaryService     *services.BeneficiaryService
+	ClaimsAnalyticsService *services.ClaimsAnalyticsService
+
+	// Document storage
+	BlobStore services.BlobStore
 
 	// Configuration management
 	ConfigManager *config.Manager
@@ -174,8 +209,19 @@ func (app *Application) initializeInfrastructure(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to connect to database: %w", err)
 	}
+	if err := db.Instrument(app.Logger, app.Metrics, app.Config.DB.SlowQueryThreshold); err != nil {
+		return fmt.Errorf("failed to instrument database: %w", err)
+	}
 	app.Database = db
 
+	// Fail fast on pending or tampered migrations when migrating at startup
+	if app.Config.DB.AutoMigrate {
+		if err := database.RunMigrations(db.DB); err != nil {
+			return fmt.Errorf("failed to run database migrations: %w", err)
+		}
+		app.Logger.Info("Database migrations applied")
+	}
+
 	app.Logger.Info("Infrastructure initialized successfully")
 	return nil
 }
@@ -183,10 +229,16 @@ func (app *Application) initializeInfrastructure(ctx context.Context) error {
 // initializeEventSystem initializes the event system
 func (app *Application) initializeEventSystem(ctx context.Context) error {
 	// Create event bus
-	app.EventBus = event.NewBus()
+	bus := event.NewBus()
+	app.EventBus = bus
+	app.EventSchemas = events.NewDefaultSchemaRegistry()
+
+	// Create event log store so published events can be replayed
+	app.EventStore = store.NewEventStore(app.Database.DB)
 
 	// Create event service
-	app.EventService = services.NewEventService(app.EventBus, app.Logger)
+	app.EventService = services.NewEventService(app.EventBus, app.Logger, app.EventStore)
+	bus.SetDeadLetterHandler(app.EventService.RecordDeadLetter)
 
 	app.Logger.Info("Event system initialized successfully")
 	return nil
@@ -196,18 +248,27 @@ func (app *Application) initializeEventSystem(ctx context.Context) error {
 func (app *Application) initializeJobSystem(ctx context.Context) error {
 	// Create job manager configuration
 	jobConfig := job.ManagerConfig{
-		Adapter: job.AdapterTypeMemory, // Use memory adapter for now
-		Queues: []string{
+		Adapter:          job.AdapterTypeMemory, // Use memory adapter for now
+		Queues:           app.Config.Jobs.Queues,
+		Concurrency:      app.Config.Jobs.Concurrency,
+		PollInterval:     5,
+		Timeout:          30,
+		MaxRetries:       3,
+		QueueConcurrency: app.Config.Jobs.QueueConcurrency,
+		QueueWeights:     app.Config.Jobs.QueueWeights,
+		UniqueWindow:     app.Config.Jobs.UniqueWindow,
+	}
+	if len(jobConfig.Queues) == 0 {
+		jobConfig.Queues = []string{
 			job.QueueMailers,
 			job.QueueProcessing,
 			job.QueuePayments,
 			job.QueueNotifications,
 			job.QueueClaims,
-		},
-		Concurrency:  5,
-		PollInterval: 5,
-		Timeout:      30,
-		MaxRetries:   3,
+		}
+	}
+	if jobConfig.Concurrency <= 0 {
+		jobConfig.Concurrency = job.DefaultWorkerCount
 	}
 
 	// Create job manager
@@ -218,6 +279,12 @@ func (app *Application) initializeJobSystem(ctx context.Context) error {
 	app.JobManager = manager
 	app.JobDispatcher = *manager.Dispatcher()
 
+	// Deliver asynchronous event subscriptions as jobs so the workers retry them
+	app.EventService.SetJobDispatcher(app.JobDispatcher, app.EventSchemas)
+	if bus, ok := app.EventBus.(*event.Bus); ok {
+		bus.SetAsyncDelivery(app.EventService.EnqueueDelivery)
+	}
+
 	// Note: Job manager will be started via StartWorkers() method
 
 	app.Logger.Info("Job system initialized successfully")
@@ -230,6 +297,10 @@ func (app *Application) initializeStores(ctx context.Context) error {
 	app.CustomerStore = store.NewCustomerStore(app.Database.DB)
 	app.PartnerStore = store.NewPartnerStore(app.Database.DB)
 	app.ProductStore = store.NewProductStore(app.Database.DB)
+	if app.Config.Cache.Enabled {
+		app.PartnerStore = store.NewCachedPartnerStore(app.PartnerStore, app.Config.Cache.TTL, app.Config.Cache.MaxEntries)
+		app.ProductStore = store.NewCachedProductStore(app.ProductStore, app.Config.Cache.TTL, app.Config.Cache.MaxEntries)
+	}
 	app.QuoteStore = store.NewQuoteStore(app.Database.DB)
 	app.PolicyStore = store.NewPolicyStore(app.Database.DB)
 	app.ClaimStore = store.NewClaimStore(app.Database.DB)
@@ -239,6 +310,19 @@ func (app *Application) initializeStores(ctx context.Context) error {
 	app.BeneficiaryStore = store.NewBeneficiaryStore(app.Database.DB)
 	app.CoverageStore = store.NewCoverageStore(app.Database.DB)
 	app.WebhookStore = store.NewWebhookStore(app.Database.DB)
+	app.ClaimReserveStore = store.NewClaimReserveStore(app.Database.DB)
+	app.RecoveryStore = store.NewClaimRecoveryStore(app.Database.DB)
+	app.AuditLogStore = store.NewAuditLogStore(app.Database.DB)
+	app.EndorsementStore = store.NewEndorsementStore(app.Database.DB)
+	app.AppealStore = store.NewAppealStore(app.Database.DB)
+	app.CommissionStore = store.NewCommissionStore(app.Database.DB)
+	app.StatementStore = store.NewPartnerStatementStore(app.Database.DB)
+	app.TaxReportStore = store.NewPremiumTaxReportStore(app.Database.DB)
+	app.SARStore = store.NewSARStore(app.Database.DB)
+	app.SequenceStore = store.NewSequenceStore(app.Database.DB)
+	app.DecisionStore = store.NewUnderwritingDecisionStore(app.Database.DB)
+	app.WorkflowStore = store.NewClaimWorkflowStore(app.Database.DB)
+	app.ConfigStore = store.NewConfigSnapshotStore(app.Database.DB)
 
 	app.Logger.Info("Stores initialized successfully")
 	return nil
@@ -250,6 +334,11 @@ func (app *Application) initializeConfigManagement(ctx context.Context) error {
 	configPath := "business_rules.json"
 	app.ConfigManager = config.NewManager(app.Logger, configPath)
 
+	// Load the configuration history so the configuration loaded below is checked against it
+	if err := app.ConfigManager.SetSnapshotStore(ctx, app.ConfigStore); err != nil {
+		return fmt.Errorf("failed to load configuration history: %w", err)
+	}
+
 	// Load initial configuration
 	if err := app.ConfigManager.LoadConfig(ctx); err != nil {
 		app.Logger.Warn("Failed to load business rules config, using defaults", zap.Error(err))
@@ -264,8 +353,33 @@ func (app *Application) initializeBusinessServices(ctx context.Context) error {
 	// Basic CRUD services
 	app.ProductService = services.NewProductService(app.ProductStore)
 	app.QuoteService = services.NewQuoteService(app.QuoteStore)
-	app.PolicyService = services.NewPolicyService(app.PolicyStore)
-	app.ClaimService = services.NewClaimService(app.ClaimStore, app.PolicyStore)
+	app.BlobStore = services.NewLocalBlobStore(app.Config.Storage.Path, app.Config.Storage.BaseURL)
+	app.QuoteDocumentService = services.NewQuoteDocumentService(app.Logger, app.QuoteService, app.BlobStore)
+	app.ClaimLetterService = services.NewClaimLetterService(app.Logger, app.ConfigManager, app.ClaimStore, app.BlobStore)
+	app.NotificationService = services.NewNotificationService(app.Logger, app.CustomerStore, app.UserStore, app.JobDispatcher)
+	app.NotificationService.SetSender(services.NotificationChannelEmail, &jobs.EmailNotificationSender{})
+	app.NotificationService.SetSender(services.NotificationChannelSMS, &jobs.SMSNotificationSender{})
+	app.NotificationService.SetSender(services.NotificationChannelPush, &jobs.PushNotificationSender{})
+	app.InvoiceService = services.NewInvoiceService(
+		app.Logger,
+		app.ConfigManager,
+		app.InvoiceStore,
+		app.PolicyStore,
+		app.EventService,
+	)
+	app.KYCService = services.NewKYCService(app.Logger, app.ConfigManager, app.CustomerStore)
+	app.PolicyService = services.NewPolicyService(app.PolicyStore, app.InvoiceService)
+	app.PolicyService.SetKYCService(app.KYCService)
+	app.ClaimService = services.NewClaimService(app.ClaimStore, app.PolicyStore, services.NewDocumentValidator(app.ConfigManager, nil))
+	app.BeneficiaryService = services.NewBeneficiaryService(app.ConfigManager, app.BeneficiaryStore, app.PolicyStore, app.ProductStore)
+	app.PolicyService.SetBeneficiaryService(app.BeneficiaryService)
+	numberGenerator := services.NewNumberGenerator(app.ConfigManager, app.SequenceStore)
+	app.PolicyService.SetNumberGenerator(numberGenerator)
+	app.PolicyService.SetQuoteStore(app.QuoteStore)
+	app.ClaimService.SetNumberGenerator(numberGenerator)
+	if app.Config.DocumentAnalysis.Endpoint != "" {
+		app.ClaimService.SetDocumentAnalyzer(jobs.NewHTTPDocumentAnalyzer(app.Config.DocumentAnalysis.Endpoint, app.Config.DocumentAnalysis.APIKey, app.Config.DocumentAnalysis.Timeout))
+	}
 	app.UserService = services.NewUserService(app.UserStore)
 	app.PaymentService = services.NewPaymentService(app.PaymentStore, app.EventService)
 	app.WebhookService = services.NewWebhookService(app.WebhookStore)
@@ -278,43 +392,75 @@ func (app *Application) initializeBusinessServices(ctx context.Context) error {
 		app.PolicyStore,
 		app.CustomerStore,
 		app.EventService,
+		app.Metrics,
 	)
+	app.FraudDetectionService.SetEndorsementStore(app.EndorsementStore)
+
+	app.AuditService = services.NewAuditService(app.Logger, app.AuditLogStore)
+	app.Authorizer = authorization.NewRBACAuthorizer(authorization.NewRBACService())
 
 	app.RiskAssessmentService = services.NewRiskAssessmentService(
+		app.Logger,
+		app.ConfigManager,
 		app.UserStore,
 		app.PolicyStore,
 		app.ClaimStore,
 	)
 
 	app.UnderwritingService = services.NewUnderwritingService(
+		app.Logger,
+		app.ConfigManager,
 		app.UserStore,
 		app.PolicyStore,
 		app.ClaimStore,
 		app.RiskAssessmentService,
 		app.FraudDetectionService,
 		nil, // PricingEngineService - will be created below
+		app.Authorizer,
+		app.AuditService,
 	)
 
 	app.PricingEngineService = services.NewPricingEngineService(
+		app.Logger,
+		app.ConfigManager,
 		app.ProductStore,
 		app.PolicyStore,
 		app.ClaimStore,
 		app.UserStore,
 	)
+	app.QuoteService.SetPricingService(app.PricingEngineService)
 
 	app.CommissionService = services.NewCommissionService(
+		app.Logger,
+		app.ConfigManager,
 		app.PartnerStore,
 		app.PolicyStore,
 		app.PaymentStore,
-		nil, // commissionStore - placeholder
+		app.CommissionStore,
+		app.Authorizer,
+		services.NewCurrencyConverter(app.ConfigManager),
 	)
 
+	app.SARService = services.NewSARService(app.Logger, app.SARStore, app.CustomerStore, app.JobDispatcher)
+	if app.Config.SAR.Endpoint != "" {
+		app.SARService.SetSubmitter(jobs.NewHTTPSARSubmitter(app.Config.SAR.Endpoint, app.Config.SAR.APIKey, app.Config.SAR.Timeout))
+	}
+	app.SARService.SetNumberGenerator(numberGenerator)
+
 	app.ComplianceService = services.NewComplianceService(
+		app.Logger,
+		app.ConfigManager,
 		app.UserStore,
 		app.PolicyStore,
 		app.ClaimStore,
 		app.PaymentStore,
 	)
+	app.ComplianceService.SetCurrencyConverter(services.NewCurrencyConverter(app.ConfigManager))
+	app.ComplianceService.SetSARService(app.SARService)
+	if !app.ComplianceService.SanctionsScreeningEnabled() {
+		app.Logger.Warn("No sanctions screener is configured, sanctions and PEP screening is disabled")
+	}
+	app.FraudDetectionService.SetSARService(app.SARService)
 
 	app.PolicyLifecycleService = services.NewPolicyLifecycleService(
 		app.Logger,
@@ -323,27 +469,88 @@ func (app *Application) initializeBusinessServices(ctx context.Context) error {
 		app.PaymentStore,
 		app.SubscriptionStore,
 		app.UserStore,
+		app.EndorsementStore,
+		app.PricingEngineService,
+		app.InvoiceService,
+		app.EventService,
+		app.CommissionService,
+		app.QuoteService,
+		app.ClaimStore,
+	)
+	app.PolicyLifecycleService.SetNotificationService(app.NotificationService)
+	app.PolicyLifecycleService.SetNumberGenerator(numberGenerator)
+
+	app.ClaimReserveService = services.NewClaimReserveService(
+		app.Logger,
+		app.ClaimReserveStore,
+		app.ClaimStore,
+		app.EventService,
+	)
+
+	app.RecoveryService = services.NewRecoveryService(
+		app.Logger,
+		app.RecoveryStore,
+		app.ClaimStore,
 		app.EventService,
 	)
 
 	app.ClaimProcessingService = services.NewClaimProcessingService(
+		app.ConfigManager,
 		app.ClaimStore,
 		app.PolicyStore,
 		app.UserStore,
+		app.WorkflowStore,
 		app.FraudDetectionService,
 		app.RiskAssessmentService,
+		app.ClaimReserveService,
 		app.EventService,
 		app.JobDispatcher,
+		app.Authorizer,
+		app.AuditService,
 	)
+	app.ClaimProcessingService.SetCoverageStore(app.CoverageStore)
+	app.ClaimsAnalyticsService = services.NewClaimsAnalyticsService(app.ConfigManager, app.ClaimStore, app.PolicyStore, app.ClaimReserveStore, app.WorkflowStore)
 
 	// Update underwriting service with pricing service
 	app.UnderwritingService = services.NewUnderwritingService(
+		app.Logger,
+		app.ConfigManager,
 		app.UserStore,
 		app.PolicyStore,
 		app.ClaimStore,
 		app.RiskAssessmentService,
 		app.FraudDetectionService,
 		app.PricingEngineService,
+		app.Authorizer,
+		app.AuditService,
+	)
+	app.UnderwritingService.SetDecisionStore(app.DecisionStore)
+
+	app.AppealService = services.NewAppealService(
+		app.Logger,
+		app.ConfigManager,
+		app.AppealStore,
+		app.ClaimStore,
+		app.UnderwritingService,
+		app.EventService,
+		app.Authorizer,
+		app.AuditService,
+	)
+
+	app.StatementService = services.NewPartnerStatementService(
+		app.Logger,
+		app.PartnerStore,
+		app.CommissionStore,
+		app.StatementStore,
+		services.NewCurrencyConverter(app.ConfigManager),
+		app.EventService,
+	)
+
+	app.TaxReportService = services.NewPremiumTaxReportService(
+		app.Logger,
+		app.InvoiceStore,
+		app.PolicyStore,
+		app.TaxReportStore,
 	)
 
 	app.Logger.Info("Business services initialized successfully")
@@ -354,7 +561,7 @@ func (app *Application) initializeBusinessServices(ctx context.Context) error {
 func (app *Application) initializeEventHandlers(ctx context.Context) error {
 	// User event handlers
 	app.UserEventHandlers = []event.EventHandler{
-		handlers.NewUserRegisteredHandler(app.UserService, app.JobDispatcher, app.Logger),
+		handlers.NewUserRegisteredHandler(app.UserService, app.CustomerStore, app.ComplianceService, app.JobDispatcher, app.Logger),
 		handlers.NewUserLoggedInHandler(app.UserService, app.Logger),
 	}
 
@@ -367,7 +574,7 @@ func (app *Application) initializeEventHandlers(ctx context.Context) error {
 	// Payment event handlers
 	app.PaymentEventHandlers = []event.EventHandler{
 		handlers.NewPaymentInitiatedHandler(app.PaymentService, app.JobDispatcher, app.Logger),
-		handlers.NewPaymentCompletedHandler(app.JobDispatcher, app.Logger),
+		handlers.NewPaymentCompletedHandler(app.CommissionService, app.InvoiceService, app.JobDispatcher, app.Logger),
 		handlers.NewPaymentFailedHandler(app.JobDispatcher, app.Logger),
 	}
 
@@ -383,7 +590,7 @@ func (app *Application) initializeEventHandlers(ctx context.Context) error {
 
 	// Webhook event handlers
 	app.WebhookEventHandlers = []event.EventHandler{
-		handlers.NewWebhookEventHandler(app.WebhookService, app.JobDispatcher, app.Logger),
+		handlers.NewWebhookEventHandler(app.WebhookService, app.JobDispatcher, app.EventSchemas, app.Logger),
 	}
 
 	app.Logger.Info("Event handlers initialized successfully")
@@ -392,9 +599,14 @@ func (app *Application) initializeEventHandlers(ctx context.Context) error {
 
 // wireEventHandlers wires all event handlers to the event bus
 func (app *Application) wireEventHandlers(ctx context.Context) error {
+	// Handlers that notify (emails, notifications, webhooks) or only queue follow-up work
+	// are delivered asynchronously so they never add latency to the publisher. Handlers
+	// that update state the caller relies on, or queue checks that must not be skipped,
+	// run synchronously.
+
 	// Wire user event handlers
 	for _, handler := range app.UserEventHandlers {
-		if err := app.EventService.SubscribeHandler(handler, "user.registered", "user.logged_in"); err != nil {
+		if err := app.EventService.SubscribeHandlerWithMode(handler, event.DeliveryModeSync, "user.registered", "user.logged_in"); err != nil {
 			return fmt.Errorf("failed to subscribe user event handler: %w", err)
 		}
 	}
@@ -422,7 +634,7 @@ func (app *Application) wireEventHandlers(ctx context.Context) error {
 
 	// Wire claim event handlers
 	for _, handler := range app.ClaimEventHandlers {
-		if err := app.EventService.SubscribeHandler(handler, "claim.submitted"); err != nil {
+		if err := app.EventService.SubscribeHandlerWithMode(handler, event.DeliveryModeSync, "claim.submitted"); err != nil {
 			return fmt.Errorf("failed to subscribe claim event handler: %w", err)
 		}
 	}
@@ -442,10 +654,17 @@ func (app *Application) wireEventHandlers(ctx context.Context) error {
 func (app *Application) registerJobTypes(ctx context.Context) error {
 	// Email jobs
 	app.JobManager.Registry().RegisterJob(&jobs.SendEmailJob{})
-	app.JobManager.Registry().RegisterJob(&jobs.WelcomeEmailJob{})
+	app.JobManager.Registry().Register("welcomeemailjob", func() job.Job {
+		return &jobs.WelcomeEmailJob{UserService: app.UserService, Customers: app.CustomerStore}
+	})
 
 	// PDF jobs
-	app.JobManager.Registry().RegisterJob(&jobs.GenerateQuotePDFJob{})
+	app.JobManager.Registry().Register("generatequotepdfjob", func() job.Job {
+		return &jobs.GenerateQuotePDFJob{DocumentService: app.QuoteDocumentService, Logger: app.Logger}
+	})
+	app.JobManager.Registry().Register("generateclaimletterjob", func() job.Job {
+		return &services.GenerateClaimLetterJob{LetterService: app.ClaimLetterService}
+	})
 
 	// Payment jobs
 	app.JobManager.Registry().RegisterJob(&jobs.ProcessPaymentJob{})
@@ -454,8 +673,52 @@ func (app *Application) registerJobTypes(ctx context.Context) error {
 	app.JobManager.Registry().RegisterJob(&jobs.CalculatePremiumJob{})
 	app.JobManager.Registry().RegisterJob(&jobs.FraudDetectionJob{})
 
+	// Event jobs
+	app.JobManager.Registry().Register("eventdeliveryjob", func() job.Job {
+		return &services.EventDeliveryJob{Service: app.EventService}
+	})
+
 	// Notification jobs
 	app.JobManager.Registry().RegisterJob(&jobs.PushNotificationJob{})
+	app.JobManager.Registry().Register("notificationjob", func() job.Job {
+		return &services.NotificationJob{Service: app.NotificationService}
+	})
+
+	// Compliance jobs
+	app.JobManager.Registry().Register("submitsarjob", func() job.Job {
+		return &services.SubmitSARJob{Service: app.SARService}
+	})
+	app.JobManager.Registry().Register("expirekycverificationsjob", func() job.Job {
+		return &jobs.ExpireKYCVerificationsJob{
+			KYCService: app.KYCService,
+			Dispatcher: &app.JobDispatcher,
+			Logger:     app.Logger,
+		}
+	})
+	app.JobManager.Registry().Register("rescreensanctionsjob", func() job.Job {
+		return &jobs.RescreenSanctionsJob{
+			ComplianceService: app.ComplianceService,
+			Dispatcher:        &app.JobDispatcher,
+			Logger:            app.Logger,
+		}
+	})
+
+	// Billing jobs are registered with a factory so deserialized jobs get their dependencies.
+	// The name must match the registry's type name for the job.
+	app.JobManager.Registry().Register("recordpolicycommissionsjob", func() job.Job {
+		return &jobs.RecordPolicyCommissionsJob{
+			CommissionService: app.CommissionService,
+			Logger:            app.Logger,
+		}
+	})
+	app.JobManager.Registry().Register("processoverdueinvoicesjob", func() job.Job {
+		return &jobs.ProcessOverdueInvoicesJob{
+			InvoiceService:      app.InvoiceService,
+			NotificationService: app.NotificationService,
+			Dispatcher:          &app.JobDispatcher,
+			Logger:              app.Logger,
+		}
+	})
 
 	app.Logger.Info("Job types registered successfully")
 	return nil
@@ -545,6 +808,23 @@ func (app *Application) StartWorkers(ctx context.Context) error {
 		return fmt.Errorf("failed to start job manager: %w", err)
 	}
 
+	// Schedule recurring jobs
+	overdueJob := &jobs.ProcessOverdueInvoicesJob{Interval: jobs.DefaultOverdueInvoiceInterval}
+	if err := app.JobDispatcher.PerformWithContext(ctx, overdueJob); err != nil {
+		app.Logger.Error("Failed to schedule overdue invoice job", zap.Error(err))
+	}
+	kycJob := &jobs.ExpireKYCVerificationsJob{Interval: jobs.DefaultKYCExpiryInterval}
+	if err := app.JobDispatcher.PerformWithContext(ctx, kycJob); err != nil {
+		app.Logger.Error("Failed to schedule KYC expiry job", zap.Error(err))
+	}
+	// Re-screening is only scheduled when there is a screener to run it against
+	if app.ComplianceService.SanctionsScreeningEnabled() {
+		sanctionsJob := &jobs.RescreenSanctionsJob{Interval: jobs.DefaultSanctionsRescreenInterval}
+		if err := app.JobDispatcher.PerformWithContext(ctx, sanctionsJob); err != nil {
+			app.Logger.Error("Failed to schedule sanctions re-screening job", zap.Error(err))
+		}
+	}
+
 	app.workersStarted = true
 	app.Logger.Info("Job workers started")
 	return nil
@@ -622,6 +902,8 @@ func (app *Application) GetService(name string) interface{} {
 		return app.PolicyService
 	case "claim":
 		return app.ClaimService
+	case "beneficiary":
+		return app.BeneficiaryService
 	case "user":
 		return app.UserService
 	case "payment":
@@ -644,6 +926,22 @@ func (app *Application) GetService(name string) interface{} {
 		return app.PolicyLifecycleService
 	case "claim_processing":
 		return app.ClaimProcessingService
+	case "claims_analytics":
+		return app.ClaimsAnalyticsService
+	case "claim_reserve":
+		return app.ClaimReserveService
+	case "recovery":
+		return app.RecoveryService
+	case "invoice":
+		return app.InvoiceService
+	case "appeal":
+		return app.AppealService
+	case "partner_statement":
+		return app.StatementService
+	case "premium_tax_report":
+		return app.TaxReportService
+	case "audit":
+		return app.AuditService
 	case "event":
 		return app.EventService
 	case "config":
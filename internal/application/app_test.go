@@ -0,0 +1,84 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/database"
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/edsonmichaque/bazaruto/internal/services"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// newTestApplication wires stores and business services the same way NewApplication does,
+// against an in-memory database.
+func newTestApplication(t *testing.T) *Application {
+	t.Helper()
+	ctx := context.Background()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	require.NoError(t, database.RunMigrations(db))
+
+	app := &Application{
+		Config:   &config.Config{},
+		Logger:   logger.NewLogger("error", "json"),
+		Database: &database.Database{DB: db},
+	}
+	require.NoError(t, app.initializeEventSystem(ctx))
+	require.NoError(t, app.initializeStores(ctx))
+	app.ConfigManager = config.NewManager(app.Logger, "")
+	require.NoError(t, app.initializeBusinessServices(ctx))
+	return app
+}
+
+func TestPolicyLifecycleServiceWiring(t *testing.T) {
+	ctx := context.Background()
+	app := newTestApplication(t)
+	require.NotNil(t, app.PolicyLifecycleService)
+	assert.Same(t, app.PolicyLifecycleService, app.GetService("policy_lifecycle").(*services.PolicyLifecycleService))
+
+	user := &models.User{Email: "holder@example.com", FullName: "Policy Holder", PasswordHash: "x"}
+	require.NoError(t, app.UserStore.Create(ctx, user))
+	product := &models.Product{Name: "Home", Category: "home", PartnerID: uuid.New(), BasePrice: 100}
+	require.NoError(t, app.ProductStore.CreateProduct(ctx, product))
+	policy := &models.Policy{
+		PolicyNumber:     "POL-WIRING",
+		ProductID:        product.ID,
+		UserID:           user.ID,
+		Premium:          800,
+		Currency:         "USD",
+		CoverageAmount:   100000,
+		Status:           models.PolicyStatusActive,
+		EffectiveDate:    time.Now().AddDate(-1, 0, 10),
+		ExpirationDate:   time.Now().AddDate(0, 0, 10),
+		PaymentFrequency: "annually",
+	}
+	require.NoError(t, app.PolicyStore.CreatePolicy(ctx, policy))
+
+	// A paid renewal exercises the pricing engine, invoice service and payment store.
+	result, err := app.PolicyLifecycleService.RenewPolicy(ctx, policy.ID, &services.RenewalOptions{
+		CoverageAmount:   policy.CoverageAmount,
+		EffectiveDate:    policy.ExpirationDate,
+		ExpirationDate:   policy.ExpirationDate.AddDate(1, 0, 0),
+		PaymentFrequency: policy.PaymentFrequency,
+		PaymentMethod:    "card",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "renewed", result.Status)
+	assert.NotNil(t, result.InvoiceID)
+
+	status, err := app.PolicyLifecycleService.GetPolicyStatus(ctx, *result.NewPolicyID)
+	require.NoError(t, err)
+	assert.Equal(t, models.PolicyStatusActive, status.Status)
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/edsonmichaque/bazaruto/internal/authorization"
 	"github.com/edsonmichaque/bazaruto/internal/models"
 )
 
@@ -70,6 +71,7 @@ func (m *AuthMiddleware) Authenticate(next http.Handler) http.Handler {
 		// Add user to context
 		ctx = context.WithValue(ctx, userContextKey, user)
 		ctx = context.WithValue(ctx, userIDContextKey, user.ID.String())
+		ctx = authorization.WithActor(ctx, &authorization.Actor{ID: user.ID, Role: authorization.Role(user.Role)})
 
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
@@ -0,0 +1,61 @@
+package authorization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// ErrUnauthorized is returned when the actor in context may not perform an operation.
+var ErrUnauthorized = errors.New("unauthorized")
+
+// actorContextKey is the context key for the acting user.
+type actorContextKey struct{}
+
+// Actor identifies the user performing an operation.
+type Actor struct {
+	ID   uuid.UUID `json:"id"`
+	Role Role      `json:"role"`
+}
+
+// WithActor returns a copy of ctx carrying the actor.
+func WithActor(ctx context.Context, actor *Actor) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actor)
+}
+
+// ActorFromContext returns the actor carried by ctx.
+func ActorFromContext(ctx context.Context) (*Actor, bool) {
+	actor, ok := ctx.Value(actorContextKey{}).(*Actor)
+	return actor, ok && actor != nil
+}
+
+// Authorizer checks whether the actor in context holds a permission.
+type Authorizer interface {
+	Authorize(ctx context.Context, permission Permission) error
+}
+
+// rbacAuthorizer authorizes actors by the permissions granted to their role.
+type rbacAuthorizer struct {
+	rbac *RBACService
+}
+
+// NewRBACAuthorizer creates an Authorizer backed by role permissions.
+func NewRBACAuthorizer(rbac *RBACService) Authorizer {
+	return &rbacAuthorizer{rbac: rbac}
+}
+
+// Authorize returns an error wrapping ErrUnauthorized unless the actor's role grants the permission.
+func (a *rbacAuthorizer) Authorize(ctx context.Context, permission Permission) error {
+	actor, ok := ActorFromContext(ctx)
+	if !ok {
+		return fmt.Errorf("%w: no actor in context", ErrUnauthorized)
+	}
+
+	if !a.rbac.HasPermission(actor.Role, permission) {
+		return fmt.Errorf("%w: role %s lacks permission %s", ErrUnauthorized, actor.Role, permission)
+	}
+
+	return nil
+}
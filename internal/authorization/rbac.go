@@ -17,6 +17,15 @@ const (
 
 	// RoleCustomer represents a customer
 	RoleCustomer Role = "customer"
+
+	// RoleSeniorUnderwriter represents an underwriter who may override underwriting decisions
+	RoleSeniorUnderwriter Role = "senior_underwriter"
+
+	// RoleClaimsSupervisor represents a supervisor who may override claim workflow stages
+	RoleClaimsSupervisor Role = "claims_supervisor"
+
+	// RoleFinance represents a finance user who may release payments
+	RoleFinance Role = "finance"
 )
 
 // Permission represents a system permission
@@ -56,6 +65,12 @@ const (
 	PermissionClaimApprove Permission = "claim:approve"
 	PermissionClaimReject  Permission = "claim:reject"
 
+	// Manual override permissions
+	PermissionUnderwritingOverride  Permission = "underwriting:override"
+	PermissionClaimWorkflowOverride Permission = "claim:workflow_override"
+	PermissionCommissionPay         Permission = "commission:pay"
+	PermissionAppealResolve         Permission = "appeal:resolve"
+
 	// User permissions
 	PermissionUserCreate Permission = "user:create"
 	PermissionUserRead   Permission = "user:read"
@@ -102,6 +117,7 @@ func (r *RBACService) initializeRolePermissions() {
 		PermissionUserCreate, PermissionUserRead, PermissionUserUpdate, PermissionUserDelete, PermissionUserList,
 		PermissionAdminAccess, PermissionAdminAudit, PermissionAdminConfig,
 		PermissionDocumentUpload, PermissionDocumentRead, PermissionDocumentDelete,
+		PermissionUnderwritingOverride, PermissionClaimWorkflowOverride, PermissionCommissionPay, PermissionAppealResolve,
 	}
 
 	// Agent has most permissions except admin and user management
@@ -123,6 +139,28 @@ func (r *RBACService) initializeRolePermissions() {
 		PermissionUserRead, PermissionUserUpdate, // Can read and update own profile
 		PermissionDocumentUpload, PermissionDocumentRead,
 	}
+
+	// Senior underwriters review and override underwriting decisions
+	r.rolePermissions[RoleSeniorUnderwriter] = []Permission{
+		PermissionProductRead, PermissionProductList,
+		PermissionQuoteRead, PermissionQuoteList,
+		PermissionPolicyRead, PermissionPolicyList,
+		PermissionUnderwritingOverride, PermissionAppealResolve,
+	}
+
+	// Claims supervisors decide claims and override workflow stages
+	r.rolePermissions[RoleClaimsSupervisor] = []Permission{
+		PermissionPolicyRead, PermissionPolicyList,
+		PermissionClaimRead, PermissionClaimUpdate, PermissionClaimList, PermissionClaimApprove, PermissionClaimReject,
+		PermissionClaimWorkflowOverride, PermissionAppealResolve,
+		PermissionDocumentRead,
+	}
+
+	// Finance releases commission payments
+	r.rolePermissions[RoleFinance] = []Permission{
+		PermissionPolicyRead, PermissionPolicyList,
+		PermissionCommissionPay,
+	}
 }
 
 // GetPermissions returns all permissions for a role
@@ -170,7 +208,7 @@ func (r *RBACService) ValidateRole(role string) (Role, error) {
 	normalizedRole := Role(strings.ToLower(role))
 
 	switch normalizedRole {
-	case RoleAdmin, RoleAgent, RoleCustomer:
+	case RoleAdmin, RoleAgent, RoleCustomer, RoleSeniorUnderwriter, RoleClaimsSupervisor, RoleFinance:
 		return normalizedRole, nil
 	default:
 		return "", fmt.Errorf("invalid role: %s", role)
@@ -179,7 +217,7 @@ func (r *RBACService) ValidateRole(role string) (Role, error) {
 
 // GetValidRoles returns all valid roles
 func (r *RBACService) GetValidRoles() []Role {
-	return []Role{RoleAdmin, RoleAgent, RoleCustomer}
+	return []Role{RoleAdmin, RoleAgent, RoleCustomer, RoleSeniorUnderwriter, RoleClaimsSupervisor, RoleFinance}
 }
 
 // IsAdmin checks if a role is admin
@@ -3,6 +3,7 @@ package commands
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -90,6 +91,8 @@ WARNING: This will delete all data in the database!`,
 				return nil
 			},
 		},
+		newDBRollbackCmd(),
+		newDBStatusCmd(),
 		&cobra.Command{
 			Use:   "info",
 			Short: "Show database connection information",
@@ -141,3 +144,96 @@ This includes connection details and current database status.`,
 
 	return cmd
 }
+
+func newDBRollbackCmd() *cobra.Command {
+	var steps int
+
+	cmd := &cobra.Command{
+		Use:   "rollback",
+		Short: "Roll back versioned SQL migrations",
+		Long: `Roll back the most recently applied versioned SQL migrations.
+The schema created from the models is not affected.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := connectDatabase()
+			if err != nil {
+				return err
+			}
+			defer func() { _ = db.Close() }()
+
+			migrator, err := database.NewMigrator(db.DB, database.Migrations)
+			if err != nil {
+				return fmt.Errorf("failed to load migrations: %w", err)
+			}
+
+			versions, err := migrator.Down(cmd.Context(), steps)
+			for _, version := range versions {
+				cmd.Printf("Rolled back migration %d\n", version)
+			}
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("Rolled back %d migration(s).\n", len(versions))
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&steps, "steps", 1, "Number of migrations to roll back")
+
+	return cmd
+}
+
+func newDBStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show versioned SQL migration status",
+		Long:  `List the versioned SQL migrations and when each was applied.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db, err := connectDatabase()
+			if err != nil {
+				return err
+			}
+			defer func() { _ = db.Close() }()
+
+			migrator, err := database.NewMigrator(db.DB, database.Migrations)
+			if err != nil {
+				return fmt.Errorf("failed to load migrations: %w", err)
+			}
+
+			statuses, err := migrator.Status(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			for _, status := range statuses {
+				appliedAt := "pending"
+				if status.AppliedAt != nil {
+					appliedAt = status.AppliedAt.Format(time.RFC3339)
+				}
+				cmd.Printf("%04d  %-40s  %s\n", status.Version, status.Name, appliedAt)
+			}
+			return nil
+		},
+	}
+}
+
+// connectDatabase connects to the configured database.
+func connectDatabase() (*database.Database, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	db, err := database.Connect(cfg.DB.DSN, database.DBConfig{
+		MaxConnections: cfg.DB.MaxConnections,
+		MinConnections: cfg.DB.MinConnections,
+		ConnectTimeout: cfg.DB.ConnectTimeout,
+		AcquireTimeout: cfg.DB.AcquireTimeout,
+		MaxLifetime:    cfg.DB.MaxLifetime,
+		IdleTimeout:    cfg.DB.IdleTimeout,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	return db, nil
+}
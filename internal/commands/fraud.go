@@ -0,0 +1,136 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/services"
+	"github.com/edsonmichaque/bazaruto/internal/store"
+)
+
+// fraudServiceFactory builds the fraud detection service a command scores claims with.
+// The returned function releases what was built.
+type fraudServiceFactory func(ctx context.Context) (*services.FraudDetectionService, func(), error)
+
+func newFraudCmd(ctx context.Context, newService fraudServiceFactory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fraud",
+		Short: "Fraud detection operations",
+		Long: `Fraud detection commands for Bazaruto.
+These commands let investigators inspect fraud scores outside the claims workflow.`,
+	}
+
+	cmd.AddCommand(newFraudScoreCmd(newService))
+	return cmd
+}
+
+// newFraudScoreCmd creates the command that recomputes a single claim's fraud score with
+// the current business rules.
+func newFraudScoreCmd(newService fraudServiceFactory) *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "score <claim-id>",
+		Short: "Recompute a claim's fraud score",
+		Long: `Recompute the fraud score of a claim with the current business rules and print
+the score, risk level, factors and recommendations.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			claimID, err := uuid.Parse(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid claim ID %q: %w", args[0], err)
+			}
+			if output != "json" && output != "table" {
+				return fmt.Errorf("output must be either 'json' or 'table'")
+			}
+
+			service, cleanup, err := newService(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			score, err := service.AnalyzeClaimForFraud(cmd.Context(), claimID)
+			if err != nil {
+				return fmt.Errorf("failed to score claim %s: %w", claimID, err)
+			}
+
+			if output == "table" {
+				return writeFraudScoreTable(cmd.OutOrStdout(), score)
+			}
+			encoded, err := json.MarshalIndent(score, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to encode fraud score: %w", err)
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(encoded))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "json", "Output format: json or table")
+	return cmd
+}
+
+// writeFraudScoreTable prints a fraud score as a summary followed by a table of factors.
+func writeFraudScoreTable(w io.Writer, score *services.FraudScore) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Score:\t%.2f\n", score.Score)
+	fmt.Fprintf(tw, "Risk level:\t%s\n", score.RiskLevel)
+	fmt.Fprintf(tw, "Confidence:\t%.2f\n", score.Confidence)
+	fmt.Fprintf(tw, "Requires review:\t%t\n", score.RequiresReview)
+	fmt.Fprintln(tw)
+
+	fmt.Fprintln(tw, "FACTOR\tWEIGHT\tSCORE\tSEVERITY\tDATA QUALITY\tDESCRIPTION")
+	for _, factor := range score.Factors {
+		fmt.Fprintf(tw, "%s\t%.2f\t%.2f\t%s\t%s\t%s\n",
+			factor.Factor, factor.Weight, factor.Score, factor.Severity, factor.DataQuality, factor.Description)
+	}
+
+	if len(score.Recommendations) > 0 {
+		fmt.Fprintln(tw)
+		fmt.Fprintln(tw, "Recommendations:")
+		fmt.Fprintln(tw, "  - "+strings.Join(score.Recommendations, "\n  - "))
+	}
+	return tw.Flush()
+}
+
+// newDatabaseFraudService builds a fraud detection service backed by the configured
+// database and the business rules file, without wiring the rest of the application.
+func newDatabaseFraudService(ctx context.Context) (*services.FraudDetectionService, func(), error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	log := logger.NewLogger(cfg.LogLevel, cfg.LogFormat)
+
+	db, err := connectDatabase()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	configManager := config.NewManager(log, "business_rules.json")
+	if err := configManager.LoadConfig(ctx); err != nil {
+		log.Warn("Failed to load business rules config, using defaults", zap.Error(err))
+	}
+
+	service := services.NewFraudDetectionService(
+		log,
+		configManager,
+		store.NewClaimStore(db.DB),
+		store.NewPolicyStore(db.DB),
+		store.NewCustomerStore(db.DB),
+		nil,
+	)
+	service.SetEndorsementStore(store.NewEndorsementStore(db.DB))
+	return service, func() { _ = db.Close() }, nil
+}
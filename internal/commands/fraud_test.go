@@ -0,0 +1,134 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/database"
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/edsonmichaque/bazaruto/internal/services"
+	"github.com/edsonmichaque/bazaruto/internal/store"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// fakeCustomerStore serves a single customer. Methods not overridden panic through the nil
+// embedded interface.
+type fakeCustomerStore struct {
+	store.CustomerStore
+	customer *models.Customer
+}
+
+func (s *fakeCustomerStore) GetByID(ctx context.Context, id uuid.UUID) (*models.Customer, error) {
+	if s.customer.ID != id {
+		return nil, fmt.Errorf("customer not found")
+	}
+	return s.customer, nil
+}
+
+func newFraudScoreTestService(t *testing.T) (*services.FraudDetectionService, uuid.UUID) {
+	t.Helper()
+	ctx := context.Background()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	require.NoError(t, database.RunMigrations(db))
+
+	userID := uuid.New()
+	customerStore := &fakeCustomerStore{customer: &models.Customer{
+		UserID:         userID,
+		CustomerNumber: "CUS-1",
+		FirstName:      "Ana",
+		LastName:       "Sitoe",
+		Email:          "ana@example.com",
+	}}
+	customerStore.customer.ID = userID
+
+	policyStore := store.NewPolicyStore(db)
+	policy := &models.Policy{
+		PolicyNumber:   "POL-1",
+		ProductID:      uuid.New(),
+		UserID:         userID,
+		Premium:        100,
+		CoverageAmount: 10000,
+		Status:         models.PolicyStatusActive,
+		EffectiveDate:  time.Now().AddDate(0, -6, 0),
+		ExpirationDate: time.Now().AddDate(0, 6, 0),
+	}
+	require.NoError(t, policyStore.CreatePolicy(ctx, policy))
+
+	claimStore := store.NewClaimStore(db)
+	claim := &models.Claim{
+		ClaimNumber:  "CLM-1",
+		PolicyID:     policy.ID,
+		UserID:       userID,
+		Title:        "Claim",
+		Description:  "Claim description",
+		ClaimAmount:  9500,
+		Status:       models.ClaimStatusSubmitted,
+		IncidentDate: time.Now().AddDate(0, 0, -2),
+		ReportedDate: time.Now(),
+	}
+	require.NoError(t, claimStore.CreateClaim(ctx, claim))
+
+	log := logger.NewLogger("error", "json")
+	return services.NewFraudDetectionService(log, config.NewManager(log, ""), claimStore, policyStore, customerStore, nil), claim.ID
+}
+
+func TestFraudScoreOutputsScoreFields(t *testing.T) {
+	service, claimID := newFraudScoreTestService(t)
+	cmd := newFraudScoreCmd(func(ctx context.Context) (*services.FraudDetectionService, func(), error) {
+		return service, func() {}, nil
+	})
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{claimID.String()})
+	require.NoError(t, cmd.Execute())
+
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &fields))
+	for _, field := range []string{"score", "risk_level", "factors", "recommendations"} {
+		assert.Contains(t, fields, field)
+	}
+	assert.NotEmpty(t, fields["risk_level"])
+	assert.NotEmpty(t, fields["factors"])
+}
+
+func TestFraudScoreOutputsTable(t *testing.T) {
+	service, claimID := newFraudScoreTestService(t)
+	cmd := newFraudScoreCmd(func(ctx context.Context) (*services.FraudDetectionService, func(), error) {
+		return service, func() {}, nil
+	})
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{claimID.String(), "--output", "table"})
+	require.NoError(t, cmd.Execute())
+
+	assert.Contains(t, out.String(), "Risk level:")
+	assert.Contains(t, out.String(), "FACTOR")
+}
+
+func TestFraudScoreRejectsInvalidClaimID(t *testing.T) {
+	cmd := newFraudScoreCmd(func(ctx context.Context) (*services.FraudDetectionService, func(), error) {
+		t.Fatal("service should not be built for an invalid claim ID")
+		return nil, nil, nil
+	})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"not-a-uuid"})
+	assert.Error(t, cmd.Execute())
+}
@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	app "github.com/edsonmichaque/bazaruto/internal/application"
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/services"
+)
+
+// lifecycleJob runs a policy lifecycle batch.
+type lifecycleJob func(s *services.PolicyLifecycleService, ctx context.Context, options *services.LifecycleBatchOptions) (*services.LifecycleBatchResult, error)
+
+// lifecycleJobs are the lifecycle batches that can be run on demand, by name.
+var lifecycleJobs = map[string]lifecycleJob{
+	"expired-policies": (*services.PolicyLifecycleService).ProcessExpiredPolicies,
+	"auto-renewals":    (*services.PolicyLifecycleService).ProcessAutoRenewals,
+}
+
+// lifecycleServiceFactory builds the lifecycle service a job runs against. The returned
+// function releases what was built.
+type lifecycleServiceFactory func(ctx context.Context) (*services.PolicyLifecycleService, func(), error)
+
+// jobRunReport is the structured output of a job run.
+type jobRunReport struct {
+	Job      string                         `json:"job"`
+	Duration string                         `json:"duration"`
+	Result   *services.LifecycleBatchResult `json:"result,omitempty"`
+	Error    string                         `json:"error,omitempty"`
+}
+
+// newJobsRunCmd creates the command that runs a lifecycle batch job on demand.
+func newJobsRunCmd(newService lifecycleServiceFactory) *cobra.Command {
+	var timeout time.Duration
+	var dryRun bool
+
+	names := make([]string, 0, len(lifecycleJobs))
+	for name := range lifecycleJobs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	cmd := &cobra.Command{
+		Use:       "run <job>",
+		Short:     "Run a policy lifecycle batch job now",
+		Long:      "Run a policy lifecycle batch job once and print a JSON report of what it did. Jobs: " + strings.Join(names, ", "),
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: names,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			run, ok := lifecycleJobs[args[0]]
+			if !ok {
+				return fmt.Errorf("unknown job %q, valid jobs are %s", args[0], strings.Join(names, ", "))
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+			defer cancel()
+
+			service, cleanup, err := newService(ctx)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			started := time.Now()
+			result, runErr := run(service, ctx, &services.LifecycleBatchOptions{DryRun: dryRun})
+			report := jobRunReport{Job: args[0], Duration: time.Since(started).String(), Result: result}
+			if runErr != nil {
+				report.Error = runErr.Error()
+			}
+
+			output, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to encode job report: %w", err)
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(output))
+
+			if runErr != nil {
+				return fmt.Errorf("job %s failed: %w", args[0], runErr)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().DurationVar(&timeout, "timeout", 10*time.Minute, "Maximum time the job may run")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Report what the job would do without changing anything")
+
+	return cmd
+}
+
+// newApplicationLifecycleService wires the application from the loaded configuration and
+// returns its lifecycle service.
+func newApplicationLifecycleService(ctx context.Context) (*services.PolicyLifecycleService, func(), error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	application, err := app.NewApplication(ctx, cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to wire application: %w", err)
+	}
+	return application.PolicyLifecycleService, func() { _ = application.Close() }, nil
+}
@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/database"
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/edsonmichaque/bazaruto/internal/services"
+	"github.com/edsonmichaque/bazaruto/internal/store"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestJobsRunReportsProcessedCount(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	require.NoError(t, database.RunMigrations(db))
+
+	policyStore := store.NewPolicyStore(db)
+	policy := &models.Policy{
+		PolicyNumber:   "POL-1",
+		ProductID:      uuid.New(),
+		UserID:         uuid.New(),
+		Premium:        100,
+		CoverageAmount: 10000,
+		Status:         models.PolicyStatusActive,
+		EffectiveDate:  time.Now().AddDate(-1, 0, -1),
+		ExpirationDate: time.Now().AddDate(0, 0, -1),
+	}
+	require.NoError(t, policyStore.CreatePolicy(context.Background(), policy))
+
+	log := logger.NewLogger("error", "json")
+	service := services.NewPolicyLifecycleService(log, config.NewManager(log, ""), policyStore,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	cleanedUp := false
+	cmd := newJobsRunCmd(func(ctx context.Context) (*services.PolicyLifecycleService, func(), error) {
+		return service, func() { cleanedUp = true }, nil
+	})
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"expired-policies", "--timeout", "10s"})
+	require.NoError(t, cmd.Execute())
+	assert.True(t, cleanedUp)
+
+	var report jobRunReport
+	require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	assert.Equal(t, "expired-policies", report.Job)
+	require.NotNil(t, report.Result)
+	assert.Equal(t, 1, report.Result.Candidates)
+	assert.Equal(t, 1, report.Result.Processed)
+	assert.Empty(t, report.Error)
+
+	stored, err := policyStore.GetPolicy(context.Background(), policy.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.PolicyStatusExpired, stored.Status)
+}
+
+func TestJobsRunRejectsUnknownJob(t *testing.T) {
+	cmd := newJobsRunCmd(func(ctx context.Context) (*services.PolicyLifecycleService, func(), error) {
+		t.Fatal("service should not be built for an unknown job")
+		return nil, nil, nil
+	})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"unknown"})
+	assert.Error(t, cmd.Execute())
+}
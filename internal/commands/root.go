@@ -18,6 +18,9 @@ It provides RESTful APIs for managing products, quotes, policies, and claims.`,
 		},
 	}
 
+	jobsCmd := NewJobsCommand()
+	jobsCmd.AddCommand(newJobsRunCmd(newApplicationLifecycleService))
+
 	// Register subcommands explicitly
 	cmd.AddCommand(
 		newServeCmd(ctx),
@@ -25,8 +28,12 @@ It provides RESTful APIs for managing products, quotes, policies, and claims.`,
 		newAdminCmd(ctx),
 		newCacheCmd(ctx),
 		newLintCmd(ctx),
+		newFraudCmd(ctx, newDatabaseFraudService),
+		newSARCmd(ctx, newApplicationSARService),
+		newTaxReportCmd(ctx, newApplicationTaxReportService),
 		newVersionCmd(),
 		NewWorkerCommand(),
+		jobsCmd,
 	)
 
 	return cmd
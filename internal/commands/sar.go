@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+
+	app "github.com/edsonmichaque/bazaruto/internal/application"
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/services"
+)
+
+// sarServiceFactory builds the suspicious activity report service a command files and
+// acknowledges reports with. The returned function releases what was built.
+type sarServiceFactory func(ctx context.Context) (*services.SARService, func(), error)
+
+func newSARCmd(ctx context.Context, newService sarServiceFactory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sar",
+		Short: "Suspicious activity report operations",
+		Long: `Suspicious activity report commands for Bazaruto.
+These commands let compliance officers file reviewed drafts with the regulator and record
+the regulator's acknowledgement.`,
+	}
+
+	cmd.AddCommand(
+		newSARFileCmd(newService),
+		newSARAcknowledgeCmd(newService),
+	)
+	return cmd
+}
+
+// newSARFileCmd creates the command that queues a reviewed draft report for submission.
+func newSARFileCmd(newService sarServiceFactory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "file <report-id>",
+		Short: "File a draft suspicious activity report",
+		Long: `Queue a reviewed draft suspicious activity report for submission to the regulator.
+The report is submitted by a worker on the compliance queue.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			reportID, err := uuid.Parse(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid report ID %q: %w", args[0], err)
+			}
+
+			service, cleanup, err := newService(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			if err := service.File(cmd.Context(), reportID); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Suspicious activity report %s queued for filing\n", reportID)
+			return nil
+		},
+	}
+}
+
+// newSARAcknowledgeCmd creates the command that records the regulator's acknowledgement of a
+// filed report.
+func newSARAcknowledgeCmd(newService sarServiceFactory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "acknowledge <report-id> <acknowledgement>",
+		Short: "Record the regulator's acknowledgement of a filed report",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			reportID, err := uuid.Parse(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid report ID %q: %w", args[0], err)
+			}
+
+			service, cleanup, err := newService(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			report, err := service.Acknowledge(cmd.Context(), reportID, args[1])
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Suspicious activity report %s acknowledged as %s\n", report.ReportNumber, report.Acknowledgement)
+			return nil
+		},
+	}
+}
+
+// newApplicationSARService builds the suspicious activity report service from the fully wired
+// application, so filings are queued on the configured job backend.
+func newApplicationSARService(ctx context.Context) (*services.SARService, func(), error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	application, err := app.NewApplication(ctx, cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to wire application: %w", err)
+	}
+	return application.SARService, func() { _ = application.Close() }, nil
+}
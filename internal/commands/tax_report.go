@@ -0,0 +1,129 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+
+	app "github.com/edsonmichaque/bazaruto/internal/application"
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/services"
+)
+
+// taxReportServiceFactory builds the premium tax report service a command generates and
+// reads reports with. The returned function releases what was built.
+type taxReportServiceFactory func(ctx context.Context) (*services.PremiumTaxReportService, func(), error)
+
+func newTaxReportCmd(ctx context.Context, newService taxReportServiceFactory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tax-report",
+		Short: "Premium tax report operations",
+		Long: `Premium tax report commands for Bazaruto.
+These commands let finance generate the premium tax collected per jurisdiction for a period
+and read back generated reports.`,
+	}
+
+	cmd.AddCommand(
+		newTaxReportGenerateCmd(newService),
+		newTaxReportShowCmd(newService),
+	)
+	return cmd
+}
+
+// newTaxReportGenerateCmd creates the command that generates the report of a period.
+func newTaxReportGenerateCmd(newService taxReportServiceFactory) *cobra.Command {
+	var from, to string
+
+	cmd := &cobra.Command{
+		Use:   "generate",
+		Short: "Generate a premium tax report",
+		Long: `Generate and store the premium tax report of the policy invoices paid from the
+--from date up to, but excluding, the --to date. Dates are in YYYY-MM-DD format, UTC.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			start, err := time.Parse(time.DateOnly, from)
+			if err != nil {
+				return fmt.Errorf("invalid --from date %q: %w", from, err)
+			}
+			end, err := time.Parse(time.DateOnly, to)
+			if err != nil {
+				return fmt.Errorf("invalid --to date %q: %w", to, err)
+			}
+
+			service, cleanup, err := newService(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			report, err := service.GeneratePremiumTaxReport(cmd.Context(), services.StatementPeriod{Start: start, End: end})
+			if err != nil {
+				return err
+			}
+			return writeJSON(cmd.OutOrStdout(), report)
+		},
+	}
+
+	cmd.Flags().StringVar(&from, "from", "", "First day of the period (YYYY-MM-DD)")
+	cmd.Flags().StringVar(&to, "to", "", "Day after the last day of the period (YYYY-MM-DD)")
+	_ = cmd.MarkFlagRequired("from")
+	_ = cmd.MarkFlagRequired("to")
+	return cmd
+}
+
+// newTaxReportShowCmd creates the command that prints a generated report.
+func newTaxReportShowCmd(newService taxReportServiceFactory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "show <report-id>",
+		Short: "Show a generated premium tax report",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			reportID, err := uuid.Parse(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid report ID %q: %w", args[0], err)
+			}
+
+			service, cleanup, err := newService(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			report, err := service.GetPremiumTaxReport(cmd.Context(), reportID)
+			if err != nil {
+				return err
+			}
+			return writeJSON(cmd.OutOrStdout(), report)
+		},
+	}
+}
+
+// writeJSON prints a value as indented JSON.
+func writeJSON(w io.Writer, value interface{}) error {
+	encoded, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+	fmt.Fprintln(w, string(encoded))
+	return nil
+}
+
+// newApplicationTaxReportService builds the premium tax report service from the fully wired
+// application.
+func newApplicationTaxReportService(ctx context.Context) (*services.PremiumTaxReportService, func(), error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	application, err := app.NewApplication(ctx, cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to wire application: %w", err)
+	}
+	return application.TaxReportService, func() { _ = application.Close() }, nil
+}
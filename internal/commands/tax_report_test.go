@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/database"
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/edsonmichaque/bazaruto/internal/services"
+	"github.com/edsonmichaque/bazaruto/internal/store"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestTaxReportGenerateReportsPaidInvoicesOfThePeriod(t *testing.T) {
+	ctx := context.Background()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	require.NoError(t, database.RunMigrations(db))
+
+	policyStore := store.NewPolicyStore(db)
+	invoiceStore := store.NewInvoiceStore(db)
+	policy := &models.Policy{
+		PolicyNumber:   "POL-1",
+		ProductID:      uuid.New(),
+		UserID:         uuid.New(),
+		Premium:        1000,
+		CoverageAmount: 10000,
+		Status:         models.PolicyStatusActive,
+		EffectiveDate:  time.Now(),
+		ExpirationDate: time.Now().AddDate(1, 0, 0),
+		Jurisdiction:   "MZ",
+	}
+	require.NoError(t, policyStore.CreatePolicy(ctx, policy))
+
+	// One invoice is paid on the last instant of March, one on the first of April.
+	for i, paidAt := range []time.Time{
+		time.Date(2026, 3, 31, 23, 59, 59, 0, time.UTC),
+		time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+	} {
+		require.NoError(t, invoiceStore.CreateInvoice(ctx, &models.Invoice{
+			InvoiceNumber: []string{"INV-1", "INV-2"}[i],
+			UserID:        policy.UserID,
+			PolicyID:      &policy.ID,
+			Amount:        1000,
+			Tax:           170,
+			Total:         1170,
+			Status:        models.InvoiceStatusPaid,
+			PaidAt:        &paidAt,
+			DueDate:       paidAt,
+		}))
+	}
+
+	service := services.NewPremiumTaxReportService(logger.NewLogger("error", "json"), invoiceStore, policyStore, store.NewPremiumTaxReportStore(db))
+	cmd := newTaxReportGenerateCmd(func(ctx context.Context) (*services.PremiumTaxReportService, func(), error) {
+		return service, func() {}, nil
+	})
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--from", "2026-01-01", "--to", "2026-04-01"})
+	require.NoError(t, cmd.Execute())
+
+	var report models.PremiumTaxReport
+	require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	assert.Equal(t, 1, report.InvoiceCount)
+	require.Len(t, report.Lines, 1)
+	assert.Equal(t, "MZ", report.Lines[0].Jurisdiction)
+	assert.InDelta(t, 170, report.Lines[0].TaxAmount, 0.001)
+}
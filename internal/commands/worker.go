@@ -7,10 +7,11 @@ import (
 	"os/signal"
 	"syscall"
 
+	app "github.com/edsonmichaque/bazaruto/internal/application"
 	"github.com/edsonmichaque/bazaruto/internal/config"
 	"github.com/edsonmichaque/bazaruto/internal/jobs"
-	"github.com/edsonmichaque/bazaruto/internal/logger"
 	"github.com/edsonmichaque/bazaruto/internal/metrics"
+	"github.com/edsonmichaque/bazaruto/internal/services"
 	"github.com/edsonmichaque/bazaruto/internal/tracing"
 	"github.com/edsonmichaque/bazaruto/pkg/job"
 	"github.com/edsonmichaque/bazaruto/pkg/job/factory"
@@ -31,8 +32,13 @@ func NewWorkerCommand() *cobra.Command {
 				return fmt.Errorf("failed to load configuration: %w", err)
 			}
 
-			// Initialize logger
-			logger := logger.NewLogger(cfg.LogLevel, cfg.LogFormat)
+			// Wire the application so jobs get the services they run with
+			application, err := app.NewApplication(cmd.Context(), cfg)
+			if err != nil {
+				return fmt.Errorf("failed to wire application: %w", err)
+			}
+			defer application.Close()
+			logger := application.Logger
 
 			// Initialize metrics
 			metrics := metrics.NewMetrics()
@@ -49,7 +55,7 @@ func NewWorkerCommand() *cobra.Command {
 
 			// Create job registry and register all job types
 			registry := job.NewRegistry()
-			registerJobTypes(registry)
+			registerJobTypes(registry, application)
 
 			// Create adapter based on configuration
 			adapter, err := createAdapter(cfg)
@@ -60,9 +66,11 @@ func NewWorkerCommand() *cobra.Command {
 
 			// Create worker
 			workerConfig := job.WorkerConfig{
-				Queues:       cfg.Jobs.Queues,
-				Concurrency:  cfg.Jobs.Concurrency,
-				PollInterval: cfg.Jobs.PollInterval,
+				Queues:           cfg.Jobs.Queues,
+				Concurrency:      cfg.Jobs.Concurrency,
+				PollInterval:     cfg.Jobs.PollInterval,
+				QueueConcurrency: cfg.Jobs.QueueConcurrency,
+				QueueWeights:     cfg.Jobs.QueueWeights,
 			}
 
 			worker := job.NewWorker(adapter, registry, workerConfig, logger)
@@ -104,14 +112,23 @@ func NewWorkerCommand() *cobra.Command {
 	}
 }
 
-// registerJobTypes registers all available job types with the registry
-func registerJobTypes(registry *job.Registry) {
+// registerJobTypes registers all available job types with the registry. Jobs with injected
+// dependencies are registered with a factory so deserialized jobs get the application's
+// services.
+func registerJobTypes(registry *job.Registry, application *app.Application) {
 	// Email jobs
 	registry.RegisterJob(&jobs.SendEmailJob{})
-	registry.RegisterJob(&jobs.WelcomeEmailJob{})
+	registry.Register("welcomeemailjob", func() job.Job {
+		return &jobs.WelcomeEmailJob{UserService: application.UserService, Customers: application.CustomerStore}
+	})
 
 	// PDF jobs
-	registry.RegisterJob(&jobs.GenerateQuotePDFJob{})
+	registry.Register("generatequotepdfjob", func() job.Job {
+		return &jobs.GenerateQuotePDFJob{DocumentService: application.QuoteDocumentService, Logger: application.Logger}
+	})
+	registry.Register("generateclaimletterjob", func() job.Job {
+		return &services.GenerateClaimLetterJob{LetterService: application.ClaimLetterService}
+	})
 
 	// Payment jobs
 	registry.RegisterJob(&jobs.ProcessPaymentJob{})
@@ -120,8 +137,51 @@ func registerJobTypes(registry *job.Registry) {
 	registry.RegisterJob(&jobs.CalculatePremiumJob{})
 	registry.RegisterJob(&jobs.FraudDetectionJob{})
 
+	// Event jobs
+	registry.Register("eventdeliveryjob", func() job.Job {
+		return &services.EventDeliveryJob{Service: application.EventService}
+	})
+
 	// Notification jobs
 	registry.RegisterJob(&jobs.PushNotificationJob{})
+	registry.Register("notificationjob", func() job.Job {
+		return &services.NotificationJob{Service: application.NotificationService}
+	})
+
+	// Compliance jobs
+	registry.Register("submitsarjob", func() job.Job {
+		return &services.SubmitSARJob{Service: application.SARService}
+	})
+	registry.Register("expirekycverificationsjob", func() job.Job {
+		return &jobs.ExpireKYCVerificationsJob{
+			KYCService: application.KYCService,
+			Dispatcher: &application.JobDispatcher,
+			Logger:     application.Logger,
+		}
+	})
+	registry.Register("rescreensanctionsjob", func() job.Job {
+		return &jobs.RescreenSanctionsJob{
+			ComplianceService: application.ComplianceService,
+			Dispatcher:        &application.JobDispatcher,
+			Logger:            application.Logger,
+		}
+	})
+
+	// Billing jobs
+	registry.Register("recordpolicycommissionsjob", func() job.Job {
+		return &jobs.RecordPolicyCommissionsJob{
+			CommissionService: application.CommissionService,
+			Logger:            application.Logger,
+		}
+	})
+	registry.Register("processoverdueinvoicesjob", func() job.Job {
+		return &jobs.ProcessOverdueInvoicesJob{
+			InvoiceService:      application.InvoiceService,
+			NotificationService: application.NotificationService,
+			Dispatcher:          &application.JobDispatcher,
+			Logger:              application.Logger,
+		}
+	})
 }
 
 // createAdapter creates the appropriate job adapter based on configuration
@@ -13,6 +13,9 @@ type ConfigMetadata struct {
 
 // BusinessRulesConfig holds all business rule configurations.
 type BusinessRulesConfig struct {
+	// Version identifies the configuration as a whole. Every applied configuration is
+	// archived under its version so decisions can be replayed against it.
+	Version         string                `json:"version"`
 	FraudDetection  FraudDetectionConfig  `json:"fraud_detection"`
 	RiskAssessment  RiskAssessmentConfig  `json:"risk_assessment"`
 	Pricing         PricingConfig         `json:"pricing"`
@@ -21,21 +24,52 @@ type BusinessRulesConfig struct {
 	Compliance      ComplianceConfig      `json:"compliance"`
 	PolicyLifecycle PolicyLifecycleConfig `json:"policy_lifecycle"`
 	ClaimProcessing ClaimProcessingConfig `json:"claim_processing"`
+	Appeals         AppealConfig          `json:"appeals"`
+	Currency        CurrencyConfig        `json:"currency"`
+	Numbering       NumberingConfig       `json:"numbering"`
 }
 
+// DefaultFraudBatchConcurrency is the number of workers batch fraud re-scoring uses when
+// FraudDetectionConfig.BatchConcurrency is not set.
+const DefaultFraudBatchConcurrency = 8
+
 // FraudDetectionConfig holds fraud detection configuration.
 type FraudDetectionConfig struct {
-	Enabled              bool                 `json:"enabled"`
-	Version              string               `json:"version"`
-	RiskThresholds       RiskThresholds       `json:"risk_thresholds"`
-	FactorWeights        map[string]float64   `json:"factor_weights"`
-	TimingRules          TimingRules          `json:"timing_rules"`
-	AmountRules          AmountRules          `json:"amount_rules"`
-	DocumentRules        DocumentRules        `json:"document_rules"`
-	GeographicRules      GeographicRules      `json:"geographic_rules"`
-	BehavioralRules      BehavioralRules      `json:"behavioral_rules"`
-	ConfidenceThresholds ConfidenceThresholds `json:"confidence_thresholds"`
-	AutoReviewThresholds AutoReviewThresholds `json:"auto_review_thresholds"`
+	Enabled              bool                          `json:"enabled"`
+	Version              string                        `json:"version"`
+	RiskThresholds       RiskThresholds                `json:"risk_thresholds"`
+	FactorWeights        map[string]float64            `json:"factor_weights"`
+	CategoryWeights      map[string]map[string]float64 `json:"category_weights"` // per product category overrides of FactorWeights
+	EnabledFactors       map[string]bool               `json:"enabled_factors"`  // factors are enabled unless set to false
+	TimingRules          TimingRules                   `json:"timing_rules"`
+	AmountRules          AmountRules                   `json:"amount_rules"`
+	DocumentRules        DocumentRules                 `json:"document_rules"`
+	GeographicRules      GeographicRules               `json:"geographic_rules"`
+	BehavioralRules      BehavioralRules               `json:"behavioral_rules"`
+	SubmissionRules      SubmissionRules               `json:"submission_rules"`
+	CoverageChangeRules  CoverageChangeRules           `json:"coverage_change_rules"`
+	ConfidenceThresholds ConfidenceThresholds          `json:"confidence_thresholds"`
+	AutoReviewThresholds AutoReviewThresholds          `json:"auto_review_thresholds"`
+	BatchConcurrency     int                           `json:"batch_concurrency"` // workers used by batch re-scoring
+	RateLimits           FraudRateLimits               `json:"rate_limits"`
+	SIUEscalation        SIUEscalationConfig           `json:"siu_escalation"`
+}
+
+// SIUEscalationConfig controls which analyzed claims are referred to the Special
+// Investigations Unit. Escalation also needs an SIU connector to be configured.
+type SIUEscalationConfig struct {
+	Enabled        bool     `json:"enabled"`          // true
+	RiskLevels     []string `json:"risk_levels"`      // critical
+	OnManualReview bool     `json:"on_manual_review"` // false; also escalate every claim that requires review
+}
+
+// FraudRateLimits bounds how often fraud analysis may run, per claim and across all claims.
+// Limits are token buckets refilled at the per-minute rate; a zero rate disables that limit.
+type FraudRateLimits struct {
+	PerClaimPerMinute int `json:"per_claim_per_minute"` // 10
+	PerClaimBurst     int `json:"per_claim_burst"`      // 5
+	GlobalPerMinute   int `json:"global_per_minute"`    // 600
+	GlobalBurst       int `json:"global_burst"`         // 100
 }
 
 // RiskThresholds defines risk score thresholds.
@@ -48,11 +82,14 @@ type RiskThresholds struct {
 
 // TimingRules defines timing-based fraud detection rules.
 type TimingRules struct {
-	NewAccountThreshold     time.Duration `json:"new_account_threshold"`     // 6 months
-	PolicyStartThreshold    time.Duration `json:"policy_start_threshold"`    // 7 days
-	ReportingDelayThreshold time.Duration `json:"reporting_delay_threshold"` // 30 days
-	WeekendMultiplier       float64       `json:"weekend_multiplier"`        // 1.2
-	BusinessHoursMultiplier float64       `json:"business_hours_multiplier"` // 0.9
+	NewAccountThreshold     time.Duration  `json:"new_account_threshold"`     // 6 months
+	PolicyStartThreshold    time.Duration  `json:"policy_start_threshold"`    // 7 days
+	ReportingDelayThreshold time.Duration  `json:"reporting_delay_threshold"` // 30 days
+	WeekendMultiplier       float64        `json:"weekend_multiplier"`        // 1.2
+	BusinessHoursMultiplier float64        `json:"business_hours_multiplier"` // 0.9
+	BusinessHourStart       int            `json:"business_hour_start"`       // 9 (inclusive, local time)
+	BusinessHourEnd         int            `json:"business_hour_end"`         // 17 (exclusive, local time)
+	WeekendDays             []time.Weekday `json:"weekend_days"`              // Saturday, Sunday
 }
 
 // AmountRules defines amount-based fraud detection rules.
@@ -69,6 +106,9 @@ type DocumentRules struct {
 	MinFileSize           int64    `json:"min_file_size"`      // 1KB
 	MaxFileSize           int64    `json:"max_file_size"`      // 10MB
 	RequiredDocumentTypes []string `json:"required_document_types"`
+	AllowedContentTypes   []string `json:"allowed_content_types"` // MIME types accepted at claim intake; empty allows any
+	DateMismatchDays      int      `json:"date_mismatch_days"`    // 1 day; documents dated earlier than this before the incident conflict
+	DateMismatchScore     float64  `json:"date_mismatch_score"`   // 70 points
 }
 
 // GeographicRules defines geographic-based fraud detection rules.
@@ -78,6 +118,24 @@ type GeographicRules struct {
 	CountryRiskMultiplier map[string]float64 `json:"country_risk_multiplier"`
 }
 
+// SubmissionRules defines fraud detection rules based on claim submission metadata.
+type SubmissionRules struct {
+	CountryMismatchScore  float64 `json:"country_mismatch_score"`  // 40 points
+	NewDeviceScore        float64 `json:"new_device_score"`        // 15 points
+	SharedDeviceScore     float64 `json:"shared_device_score"`     // 60 points
+	SharedDeviceThreshold int     `json:"shared_device_threshold"` // 1 other account
+}
+
+// CoverageChangeRules defines fraud detection rules for coverage increased shortly before an incident.
+type CoverageChangeRules struct {
+	LookbackDays     int     `json:"lookback_days"`      // 30 days before the incident
+	MinIncreaseRatio float64 `json:"min_increase_ratio"` // 0.25, a 25% increase
+	HighRiskDays     int     `json:"high_risk_days"`     // 7; increases this close to the incident score high
+	IncreaseScore    float64 `json:"increase_score"`     // 40 points
+	HighRiskScore    float64 `json:"high_risk_score"`    // 80 points
+	DoubledScore     float64 `json:"doubled_score"`      // 20 points added when coverage at least doubled
+}
+
 // BehavioralRules defines behavioral-based fraud detection rules.
 type BehavioralRules struct {
 	MinDescriptionLength     int     `json:"min_description_length"`     // 50
@@ -241,7 +299,40 @@ type PricingConfig struct {
 	MarketAdjustments    MarketAdjustments      `json:"market_adjustments"`
 	LoyaltyAdjustments   LoyaltyAdjustments     `json:"loyalty_adjustments"`
 	SeasonalAdjustments  SeasonalAdjustments    `json:"seasonal_adjustments"`
+	NoClaimsBonus        NoClaimsBonusRules     `json:"no_claims_bonus"`
 	ValidationRules      PricingValidationRules `json:"validation_rules"`
+	Deductibles          DeductibleMatrix       `json:"deductibles"`
+	DeductibleCredits    DeductibleCredits      `json:"deductible_credits"`
+	RateTables           map[string]RateTable   `json:"rate_tables"`
+	RateTableRollout     RateTableRollout       `json:"rate_table_rollout"`
+	TechnicalPremium     TechnicalPremiumRules  `json:"technical_premium"`
+}
+
+// RateTable is a named version of the base rates per $1000 of coverage, keyed by product
+// category.
+type RateTable map[string]float64
+
+// RateTableRollout selects the rate table version used to price a quote. Users listed in
+// Assignments always get their assigned version; otherwise VariantPercentage percent of
+// users, chosen by a stable hash of the user ID, get Variant and the rest get Control.
+type RateTableRollout struct {
+	Control           string            `json:"control"`
+	Variant           string            `json:"variant"`
+	VariantPercentage int               `json:"variant_percentage"` // 0-100
+	Assignments       map[string]string `json:"assignments"`        // user ID -> version
+}
+
+// DeductibleMatrix lists the deductibles that may be chosen for each coverage tier of a
+// product category, e.g. Deductibles["home"]["standard"] = [250, 500, 1000]. Categories
+// without an entry accept any deductible.
+type DeductibleMatrix map[string]map[string][]float64
+
+// DeductibleCredits defines the premium credit for choosing a deductible: CreditRate times
+// the deductible's share of the coverage amount, taken off the base premium and capped at
+// MaxCredit.
+type DeductibleCredits struct {
+	CreditRate float64 `json:"credit_rate"` // 2.0
+	MaxCredit  float64 `json:"max_credit"`  // 0.20 (20%)
 }
 
 // CoverageAdjustments defines coverage amount-based pricing adjustments.
@@ -261,12 +352,14 @@ type RiskAdjustments struct {
 
 // DiscountRules defines discount rules and eligibility.
 type DiscountRules struct {
-	MultiPolicyDiscount    float64 `json:"multi_policy_discount"`    // 0.10 (10%)
-	LoyaltyDiscount        float64 `json:"loyalty_discount"`         // 0.05 (5%)
-	EarlyPaymentDiscount   float64 `json:"early_payment_discount"`   // 0.03 (3%)
-	SafeDriverDiscount     float64 `json:"safe_driver_discount"`     // 0.08 (8%)
-	SecuritySystemDiscount float64 `json:"security_system_discount"` // 0.06 (6%)
-	BulkDiscount           float64 `json:"bulk_discount"`            // 0.15 (15%)
+	MultiPolicyDiscount    float64 `json:"multi_policy_discount"`     // 0.10 (10%)
+	MultiPolicyStep        float64 `json:"multi_policy_step"`         // 0.025 (2.5% per additional policy)
+	MultiPolicyMaxDiscount float64 `json:"multi_policy_max_discount"` // 0.20 (20%)
+	LoyaltyDiscount        float64 `json:"loyalty_discount"`          // 0.05 (5%)
+	EarlyPaymentDiscount   float64 `json:"early_payment_discount"`    // 0.03 (3%)
+	SafeDriverDiscount     float64 `json:"safe_driver_discount"`      // 0.08 (8%)
+	SecuritySystemDiscount float64 `json:"security_system_discount"`  // 0.06 (6%)
+	BulkDiscount           float64 `json:"bulk_discount"`             // 0.15 (15%)
 }
 
 // TaxRules defines tax calculation rules.
@@ -303,6 +396,22 @@ type SeasonalAdjustments struct {
 	WinterSurcharge      float64 `json:"winter_surcharge"`       // 0.02 (2%)
 	SummerSurcharge      float64 `json:"summer_surcharge"`       // 0.01 (1%)
 	SpringFallMultiplier float64 `json:"spring_fall_multiplier"` // 1.0
+	// DefaultHemisphere is used for jurisdictions not listed in JurisdictionHemispheres.
+	DefaultHemisphere string `json:"default_hemisphere"` // northern
+	// JurisdictionHemispheres maps jurisdiction codes to "northern" or "southern".
+	JurisdictionHemispheres map[string]string `json:"jurisdiction_hemispheres"`
+}
+
+// NoClaimsBonusRules defines the claims-experience discount ladder.
+type NoClaimsBonusRules struct {
+	Enabled bool                `json:"enabled"`
+	Ladder  []NoClaimsBonusStep `json:"ladder"` // ordered by ClaimFreeYears ascending
+}
+
+// NoClaimsBonusStep defines the discount granted after a number of claim-free years.
+type NoClaimsBonusStep struct {
+	ClaimFreeYears int     `json:"claim_free_years"`
+	Discount       float64 `json:"discount"` // 0.10 (10%)
 }
 
 // PricingValidationRules defines pricing validation rules.
@@ -311,6 +420,24 @@ type PricingValidationRules struct {
 	MaxPremium    float64 `json:"max_premium"`    // 1000000.0
 	MaxAdjustment float64 `json:"max_adjustment"` // 2.0 (200%)
 	MinAdjustment float64 `json:"min_adjustment"` // 0.1 (10%)
+	// CapAdjustments caps an adjusted premium outside [MinAdjustment, MaxAdjustment] times the
+	// base premium to the nearest bound instead of rejecting the calculation.
+	CapAdjustments bool `json:"cap_adjustments"`
+	// RoundingPolicy is applied to the final premium: nearest_cent, nearest_dollar or round_up.
+	RoundingPolicy string `json:"rounding_policy"` // nearest_cent
+}
+
+// TechnicalPremiumRules define the minimum technical premium: the expected loss implied by
+// a quote's risk score, loaded for expenses. The loss probability is interpolated linearly
+// from MinLossProbability at a risk score of 0 to MaxLossProbability at 100, and applied to
+// the coverage amount net of the deductible.
+type TechnicalPremiumRules struct {
+	// Enabled is off by default: the loss probabilities must first be calibrated against the
+	// products' base rates, or the floor overrides ordinary premiums at moderate risk scores.
+	Enabled            bool    `json:"enabled"`
+	MinLossProbability float64 `json:"min_loss_probability"` // 0.001 (0.1%)
+	MaxLossProbability float64 `json:"max_loss_probability"` // 0.05 (5%)
+	ExpenseLoading     float64 `json:"expense_loading"`      // 0.25 (25%)
 }
 
 // UnderwritingConfig holds underwriting configuration.
@@ -368,6 +495,16 @@ type CommissionConfig struct {
 	MaxAmounts       map[string]float64        `json:"max_amounts"`
 	PaymentSchedules PaymentSchedules          `json:"payment_schedules"`
 	ValidationRules  CommissionValidationRules `json:"validation_rules"`
+	OverrideRates    map[string]float64        `json:"override_rates"`    // Parent override rate (percentage) by commission type
+	MaxCombinedRate  float64                   `json:"max_combined_rate"` // Cap on writing plus override rate (percentage)
+	ClawbackSchedule []ClawbackTier            `json:"clawback_schedule"`
+}
+
+// ClawbackTier defines the share of a paid initial commission clawed back when a
+// policy is cancelled within a number of days of taking effect.
+type ClawbackTier struct {
+	WithinDays int     `json:"within_days"`
+	Rate       float64 `json:"rate"` // 1.0 claws back the full commission
 }
 
 // PaymentSchedules defines commission payment schedules.
@@ -404,11 +541,13 @@ type KYCRequirements struct {
 	UpdateFrequency     int      `json:"update_frequency"` // days
 }
 
-// AMLRequirements defines AML compliance requirements.
+// AMLRequirements defines AML compliance requirements. Each review period is paired with the
+// monitoring and reporting thresholds at the same index; when there are fewer thresholds than
+// periods, the last threshold applies to the remaining periods.
 type AMLRequirements struct {
-	MonitoringThresholds []float64 `json:"monitoring_thresholds"`
-	ReportingThresholds  []float64 `json:"reporting_thresholds"`
-	ReviewPeriods        []int     `json:"review_periods"` // days
+	MonitoringThresholds []float64 `json:"monitoring_thresholds"` // Payment totals that require enhanced monitoring
+	ReportingThresholds  []float64 `json:"reporting_thresholds"`  // Payment totals that require a suspicious activity report
+	ReviewPeriods        []int     `json:"review_periods"`        // days
 }
 
 // DataProtectionRules defines data protection compliance rules.
@@ -440,22 +579,38 @@ type PolicyLifecycleConfig struct {
 	RenewalRules      RenewalRules                   `json:"renewal_rules"`
 	CancellationRules CancellationRules              `json:"cancellation_rules"`
 	GracePeriodRules  GracePeriodRules               `json:"grace_period_rules"`
+	BillingRules      BillingRules                   `json:"billing_rules"`
 	ValidationRules   PolicyLifecycleValidationRules `json:"validation_rules"`
+	BeneficiaryRules  map[string]BeneficiaryRules    `json:"beneficiary_rules"` // By product category
 }
 
 // RenewalRules defines policy renewal rules.
 type RenewalRules struct {
 	AdvanceRenewalDays int                `json:"advance_renewal_days"` // 30
 	RateIncreaseRate   float64            `json:"rate_increase_rate"`   // 0.03 (3%)
-	FrequencyDiscounts map[string]float64 `json:"frequency_discounts"`
+	FrequencyDiscounts map[string]float64 `json:"frequency_discounts"`  // by payment frequency; negative values are surcharges
 	LoyaltyDiscounts   map[string]float64 `json:"loyalty_discounts"`
+	OfferValidityDays  int                `json:"offer_validity_days"` // 30; how long a renewal offer quote stays valid
+	// OpenClaimReviewThreshold routes auto-renewal to manual review when the policy has an
+	// open claim of at least this amount. Zero disables the check.
+	OpenClaimReviewThreshold float64 `json:"open_claim_review_threshold"` // 50000
+	OfferBatchConcurrency    int     `json:"offer_batch_concurrency"`     // 8; workers used by batch offer generation
 }
 
+// DefaultRenewalOfferBatchConcurrency is the number of workers batch renewal offer
+// generation uses when RenewalRules.OfferBatchConcurrency is not set.
+const DefaultRenewalOfferBatchConcurrency = 8
+
 // CancellationRules defines policy cancellation rules.
 type CancellationRules struct {
 	CancellationFeeRate     float64 `json:"cancellation_fee_rate"`     // 0.10 (10%)
 	RefundCalculationMethod string  `json:"refund_calculation_method"` // pro_rated
 	ProcessingDays          int     `json:"processing_days"`           // 7
+	// FreeLookDays is the cooling-off period after issue during which a cancelled policy
+	// is refunded in full with no fee. Zero disables it unless a jurisdiction sets one.
+	FreeLookDays int `json:"free_look_days"` // 14
+	// JurisdictionFreeLookDays overrides FreeLookDays by policy jurisdiction code.
+	JurisdictionFreeLookDays map[string]int `json:"jurisdiction_free_look_days"`
 }
 
 // GracePeriodRules defines grace period rules.
@@ -465,6 +620,13 @@ type GracePeriodRules struct {
 	RenewalDays        int `json:"renewal_days"`         // 15
 }
 
+// BillingRules defines policy invoicing rules.
+type BillingRules struct {
+	PaymentTermDays  int     `json:"payment_term_days"`  // 30
+	PolicyFee        float64 `json:"policy_fee"`         // flat fee per invoice
+	OverdueGraceDays int     `json:"overdue_grace_days"` // 15 days overdue before suspension
+}
+
 // PolicyLifecycleValidationRules defines policy lifecycle validation rules.
 type PolicyLifecycleValidationRules struct {
 	MinEffectiveDate  int `json:"min_effective_date"`  // 0 days
@@ -472,13 +634,35 @@ type PolicyLifecycleValidationRules struct {
 	MinPolicyDuration int `json:"min_policy_duration"` // 30 days
 }
 
+// BeneficiaryRules defines the beneficiary designations allowed for a product category.
+type BeneficiaryRules struct {
+	Required             bool     `json:"required"`              // true for life products
+	MinAge               int      `json:"min_age"`               // 0 allows minors
+	MaxBeneficiaries     int      `json:"max_beneficiaries"`     // 10; 0 is unlimited
+	AllowedRelationships []string `json:"allowed_relationships"` // Empty allows any relationship
+}
+
 // ClaimProcessingConfig holds claim processing configuration.
 type ClaimProcessingConfig struct {
-	Enabled         bool                           `json:"enabled"`
-	Version         string                         `json:"version"`
-	WorkflowRules   WorkflowRules                  `json:"workflow_rules"`
-	ApprovalRules   ApprovalRules                  `json:"approval_rules"`
-	ValidationRules ClaimProcessingValidationRules `json:"validation_rules"`
+	Enabled          bool                           `json:"enabled"`
+	Version          string                         `json:"version"`
+	WorkflowRules    WorkflowRules                  `json:"workflow_rules"`
+	ApprovalRules    ApprovalRules                  `json:"approval_rules"`
+	ValidationRules  ClaimProcessingValidationRules `json:"validation_rules"`
+	DamageAssessment DamageAssessmentRules          `json:"damage_assessment"`
+	// CategoryDamageAssessment overrides DamageAssessment per product category.
+	CategoryDamageAssessment map[string]DamageAssessmentRules `json:"category_damage_assessment"`
+	StageRetry               StageRetryRules                  `json:"stage_retry"`
+}
+
+// StageRetryRules define how a workflow stage that fails transiently is retried. The delay
+// before each retry starts at InitialBackoff and is multiplied by Multiplier up to
+// MaxBackoff.
+type StageRetryRules struct {
+	MaxAttempts    int           `json:"max_attempts"`    // 3, including the first attempt
+	InitialBackoff time.Duration `json:"initial_backoff"` // 200ms
+	MaxBackoff     time.Duration `json:"max_backoff"`     // 2s
+	Multiplier     float64       `json:"multiplier"`      // 2
 }
 
 // WorkflowRules defines claim processing workflow rules.
@@ -513,6 +697,28 @@ type ApprovalRules struct {
 	SeniorReviewThreshold    float64 `json:"senior_review_threshold"`    // 50000
 	ExecutiveReviewThreshold float64 `json:"executive_review_threshold"` // 100000
 	ManualReviewThreshold    float64 `json:"manual_review_threshold"`    // 250000
+	// Matrix selects the approval stages and roles for a claim by product category, region
+	// and amount. The first matching rule applies; claims no rule matches fall back to the
+	// review thresholds above.
+	Matrix []ApprovalMatrixRule `json:"matrix"`
+}
+
+// ApprovalMatrixRule requires approval stages and roles for claims of a product category
+// and region whose amount is over MinAmount and up to MaxAmount. Empty fields match any
+// category or region, and a zero MaxAmount has no upper bound.
+type ApprovalMatrixRule struct {
+	ProductCategory string   `json:"product_category"`
+	Region          string   `json:"region"` // ISO country code of the policy's jurisdiction
+	MinAmount       float64  `json:"min_amount"`
+	MaxAmount       float64  `json:"max_amount"`
+	Stages          []string `json:"stages"`         // senior_review, executive_approval
+	RequiredRoles   []string `json:"required_roles"` // roles allowed to decide the approval stages
+}
+
+// DamageAssessmentRules defines when the damage assessment stage is auto-approved.
+type DamageAssessmentRules struct {
+	AutoApproveMax   float64 `json:"auto_approve_max"`   // 10000; larger claims need a manual assessment
+	MinDocumentCount int     `json:"min_document_count"` // 2 supporting documents
 }
 
 // ClaimProcessingValidationRules defines claim processing validation rules.
@@ -522,3 +728,30 @@ type ClaimProcessingValidationRules struct {
 	MaxReportingDelay int     `json:"max_reporting_delay"` // 365 days
 	MinDocumentCount  int     `json:"min_document_count"`  // 1
 }
+
+// AppealConfig holds configuration for appeals against denied claims and declined applications.
+type AppealConfig struct {
+	MaxAppeals int `json:"max_appeals"` // 2 appeals per claim or application
+	ReviewDays int `json:"review_days"` // 30 days for the review to be completed
+}
+
+// CurrencyConfig holds the exchange rates used to convert between currencies.
+type CurrencyConfig struct {
+	BaseCurrency  string             `json:"base_currency"`  // USD
+	ExchangeRates map[string]float64 `json:"exchange_rates"` // Units of each currency per unit of the base currency
+}
+
+// NumberingConfig holds the formats of generated policy, claim and suspicious activity
+// report numbers.
+type NumberingConfig struct {
+	Policy NumberFormat `json:"policy"`
+	Claim  NumberFormat `json:"claim"`
+	SAR    NumberFormat `json:"sar"`
+}
+
+// NumberFormat defines a sequential document number such as POL-2024-000123.
+type NumberFormat struct {
+	Prefix      string `json:"prefix"`       // POL
+	Digits      int    `json:"digits"`       // 6; zero-padded width of the sequence number
+	IncludeYear bool   `json:"include_year"` // true; the sequence restarts each year
+}
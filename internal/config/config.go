@@ -15,6 +15,12 @@ type Config struct {
 	Redis  RedisConfig     `mapstructure:"redis"`
 	Rate   RateLimitConfig `mapstructure:"rate"`
 	Jobs   JobsConfig      `mapstructure:"jobs"`
+	Cache  CacheConfig     `mapstructure:"cache"`
+
+	Storage StorageConfig `mapstructure:"storage"`
+	SAR     SARConfig     `mapstructure:"sar"`
+
+	DocumentAnalysis DocumentAnalysisConfig `mapstructure:"document_analysis"`
 
 	// Observability fields (flattened from ObservabilityConfig)
 	LogLevel       string        `mapstructure:"log_level"`
@@ -30,6 +36,11 @@ type ServerConfig struct {
 	ReadTimeout  time.Duration `mapstructure:"read_timeout"`
 	WriteTimeout time.Duration `mapstructure:"write_timeout"`
 	IdleTimeout  time.Duration `mapstructure:"idle_timeout"`
+	// TrustedProxies lists the addresses or CIDR ranges of reverse proxies whose
+	// X-Forwarded-For and country headers are honoured.
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
+	// CountryHeader names the header a trusted proxy sets to the client's ISO country code.
+	CountryHeader string `mapstructure:"country_header"`
 }
 
 // DBConfig defines database connection parameters.
@@ -41,6 +52,10 @@ type DBConfig struct {
 	AcquireTimeout time.Duration `mapstructure:"acquire_timeout"`
 	MaxLifetime    time.Duration `mapstructure:"max_lifetime"`
 	IdleTimeout    time.Duration `mapstructure:"idle_timeout"`
+	// SlowQueryThreshold is the duration at which a query is logged as slow; 0 disables it.
+	SlowQueryThreshold time.Duration `mapstructure:"slow_query_threshold"`
+	// AutoMigrate runs the database migrations at startup.
+	AutoMigrate bool `mapstructure:"auto_migrate"`
 }
 
 // RedisConfig defines Redis connection parameters.
@@ -88,6 +103,33 @@ type RateLimit struct {
 	Burst     int    `mapstructure:"burst"`
 }
 
+// CacheConfig defines in-process caching of reference data such as products and partners.
+type CacheConfig struct {
+	Enabled    bool          `mapstructure:"enabled"`
+	TTL        time.Duration `mapstructure:"ttl"`
+	MaxEntries int           `mapstructure:"max_entries"` // Per cached store; 0 is unbounded
+}
+
+// StorageConfig defines where generated documents such as quote PDFs are stored.
+type StorageConfig struct {
+	Path    string `mapstructure:"path"`     // Directory documents are written to
+	BaseURL string `mapstructure:"base_url"` // URL prefix documents are downloaded from
+}
+
+// SARConfig defines where suspicious activity reports are filed.
+type SARConfig struct {
+	Endpoint string        `mapstructure:"endpoint"` // Reporting API URL; reports are not filed when empty
+	APIKey   string        `mapstructure:"api_key"`
+	Timeout  time.Duration `mapstructure:"timeout"`
+}
+
+// DocumentAnalysisConfig defines the service that extracts fields from claim documents.
+type DocumentAnalysisConfig struct {
+	Endpoint string        `mapstructure:"endpoint"` // Extraction API URL; documents are not analyzed when empty
+	APIKey   string        `mapstructure:"api_key"`
+	Timeout  time.Duration `mapstructure:"timeout"`
+}
+
 // TracingConfig defines distributed tracing settings.
 type TracingConfig struct {
 	Enabled     bool   `mapstructure:"enabled"`
@@ -104,6 +146,13 @@ type JobsConfig struct {
 	MaxRetries   int           `mapstructure:"max_retries"`   // Default max retries
 	Timeout      time.Duration `mapstructure:"timeout"`       // Default job timeout
 
+	// QueueConcurrency reserves dedicated workers per queue on top of the shared pool.
+	QueueConcurrency map[string]int `mapstructure:"queue_concurrency"`
+	// QueueWeights sets how often the shared pool polls each queue; higher drains first.
+	QueueWeights map[string]int `mapstructure:"queue_weights"`
+	// UniqueWindow is how long a unique job's key blocks duplicate enqueues.
+	UniqueWindow time.Duration `mapstructure:"unique_window"`
+
 	// Redis-specific
 	Redis RedisConfig `mapstructure:"redis"`
 
@@ -149,6 +198,8 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("server.read_timeout", "30s")
 	v.SetDefault("server.write_timeout", "60s")
 	v.SetDefault("server.idle_timeout", "90s")
+	v.SetDefault("server.trusted_proxies", []string{})
+	v.SetDefault("server.country_header", "")
 
 	// Database defaults
 	v.SetDefault("db.max_connections", 25)
@@ -157,6 +208,8 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("db.acquire_timeout", "3s")
 	v.SetDefault("db.max_lifetime", "30m")
 	v.SetDefault("db.idle_timeout", "10m")
+	v.SetDefault("db.slow_query_threshold", "200ms")
+	v.SetDefault("db.auto_migrate", false)
 
 	// Redis defaults
 	v.SetDefault("redis.addr", "localhost:6379")
@@ -175,6 +228,21 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("rate.ttl", "10m")
 	v.SetDefault("rate.gc_interval", "5m")
 
+	// Cache defaults
+	v.SetDefault("cache.enabled", false)
+	v.SetDefault("cache.ttl", "5m")
+	v.SetDefault("cache.max_entries", 10000)
+
+	// Storage defaults
+	v.SetDefault("storage.path", "./data/documents")
+	v.SetDefault("storage.base_url", "/documents")
+
+	// Suspicious activity report defaults
+	v.SetDefault("sar.timeout", "30s")
+
+	// Document analysis defaults
+	v.SetDefault("document_analysis.timeout", "30s")
+
 	// Observability defaults (flattened)
 	v.SetDefault("log_level", "info")
 	v.SetDefault("log_format", "json")
@@ -188,9 +256,21 @@ func setDefaults(v *viper.Viper) {
 
 	// Jobs defaults
 	v.SetDefault("jobs.adapter", "memory")
-	v.SetDefault("jobs.queues", []string{"default", "mailers", "processing", "heavy"})
+	v.SetDefault("jobs.queues", []string{"default", "mailers", "processing", "heavy", "payments", "notifications", "claims", "compliance"})
 	v.SetDefault("jobs.concurrency", 5)
+	v.SetDefault("jobs.queue_concurrency", map[string]int{"payments": 2, "claims": 1})
+	v.SetDefault("jobs.queue_weights", map[string]int{
+		"payments":      10,
+		"claims":        8,
+		"compliance":    5,
+		"notifications": 5,
+		"processing":    3,
+		"default":       2,
+		"heavy":         1,
+		"mailers":       1,
+	})
 	v.SetDefault("jobs.poll_interval", "1s")
 	v.SetDefault("jobs.max_retries", 3)
 	v.SetDefault("jobs.timeout", "5m")
+	v.SetDefault("jobs.unique_window", "24h")
 }
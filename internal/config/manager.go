@@ -2,7 +2,10 @@ package config
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -13,6 +16,34 @@ import (
 	"go.uber.org/zap"
 )
 
+// ErrConfigVersionNotFound is returned when no archived configuration has the requested version.
+var ErrConfigVersionNotFound = errors.New("config version not found")
+
+// ErrConfigVersionConflict is returned when a configuration reuses the version of an
+// archived configuration with different rules.
+var ErrConfigVersionConflict = errors.New("config version already applied with different rules")
+
+// ErrConfigSnapshotNotFound is returned when no archived configuration has the requested sequence.
+var ErrConfigSnapshotNotFound = errors.New("config snapshot not found")
+
+// ConfigSnapshot is an archived copy of a configuration that was loaded or applied.
+type ConfigSnapshot struct {
+	Sequence int                  `json:"sequence"` // 1 for the first configuration applied
+	Version  string               `json:"version"`
+	Hash     string               `json:"hash"` // SHA-256 of the configuration's JSON encoding
+	LoadedAt time.Time            `json:"loaded_at"`
+	Config   *BusinessRulesConfig `json:"config"`
+}
+
+// SnapshotStore persists the configuration history so it survives restarts and is shared by
+// the instances that use the same store.
+type SnapshotStore interface {
+	// SaveSnapshot stores a snapshot. It fails when a snapshot with the same sequence exists.
+	SaveSnapshot(ctx context.Context, snapshot *ConfigSnapshot) error
+	// ListSnapshots returns the stored snapshots, oldest first.
+	ListSnapshots(ctx context.Context) ([]ConfigSnapshot, error)
+}
+
 // Manager manages business rule configurations.
 type Manager struct {
 	logger      *logger.Logger
@@ -20,6 +51,10 @@ type Manager struct {
 	lastUpdated time.Time
 	mutex       sync.RWMutex
 	configPath  string
+	// history holds a snapshot of every configuration applied, oldest first.
+	history []ConfigSnapshot
+	// snapshots persists the history; without it the history is kept in memory only.
+	snapshots SnapshotStore
 }
 
 // NewManager creates a new configuration manager.
@@ -40,10 +75,19 @@ func (m *Manager) LoadConfig(ctx context.Context) error {
 	defer m.mutex.Unlock()
 
 	// Try to load from file first
-	if err := m.loadFromFile(); err != nil {
+	config, err := m.readConfigFile()
+	if err != nil {
 		m.logger.Warn("Failed to load config from file, using defaults", zap.Error(err))
-		m.config = m.getDefaultConfig()
-	} else {
+		config = m.getDefaultConfig()
+	}
+	if err := m.checkVersion(config); err != nil {
+		return err
+	}
+	if err := m.archiveConfig(ctx, config); err != nil {
+		return err
+	}
+	m.config = config
+	if err == nil {
 		m.lastUpdated = time.Now()
 	}
 
@@ -54,20 +98,50 @@ func (m *Manager) LoadConfig(ctx context.Context) error {
 	return nil
 }
 
-// loadFromFile loads configuration from a JSON file.
-func (m *Manager) loadFromFile() error {
+// ReloadConfig re-reads the configuration file and applies it only if it is valid. Unlike
+// LoadConfig it never falls back to the defaults: on any error the current configuration
+// stays in place. Validation failures are returned as ValidationErrors.
+func (m *Manager) ReloadConfig(ctx context.Context) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	config, err := m.readConfigFile()
+	if err != nil {
+		return err
+	}
+	if err := m.validateConfig(config); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+	if err := m.checkVersion(config); err != nil {
+		return err
+	}
+	if err := m.archiveConfig(ctx, config); err != nil {
+		return err
+	}
+
+	m.config = config
+	m.lastUpdated = time.Now()
+
+	m.logger.Info("Configuration reloaded successfully",
+		zap.String("config_path", m.configPath),
+		zap.String("version", config.Version))
+
+	return nil
+}
+
+// readConfigFile reads the configuration from the JSON file.
+func (m *Manager) readConfigFile() (*BusinessRulesConfig, error) {
 	data, err := os.ReadFile(m.configPath)
 	if err != nil {
-		return fmt.Errorf("failed to read config file: %w", err)
+		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
 	var config BusinessRulesConfig
 	if err := json.Unmarshal(data, &config); err != nil {
-		return fmt.Errorf("failed to unmarshal config: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
-	m.config = &config
-	return nil
+	return &config, nil
 }
 
 // SaveConfig saves the current configuration to file.
@@ -131,6 +205,14 @@ func (m *Manager) UpdateConfig(ctx context.Context, config *BusinessRulesConfig)
 	}
 
 	m.mutex.Lock()
+	if err := m.checkVersion(config); err != nil {
+		m.mutex.Unlock()
+		return err
+	}
+	if err := m.archiveConfig(ctx, config); err != nil {
+		m.mutex.Unlock()
+		return err
+	}
 	m.config = config
 	m.lastUpdated = time.Now()
 	m.mutex.Unlock()
@@ -150,6 +232,145 @@ func (m *Manager) RefreshConfig(ctx context.Context) error {
 	return m.LoadConfig(ctx)
 }
 
+// GetConfigVersion returns the configuration that was applied under version. A version
+// always identifies the same rules, as configurations that change the rules without
+// changing the version are rejected. It returns ErrConfigVersionNotFound when no configuration with that version was applied
+// since the manager started.
+func (m *Manager) GetConfigVersion(version string) (*BusinessRulesConfig, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	for i := len(m.history) - 1; i >= 0; i-- {
+		if m.history[i].Version == version {
+			return cloneConfig(m.history[i].Config)
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrConfigVersionNotFound, version)
+}
+
+// ListSnapshots returns the configurations applied since the manager started, oldest
+// first. The returned snapshots do not include the configurations themselves; use
+// GetSnapshot to retrieve one.
+func (m *Manager) ListSnapshots() []ConfigSnapshot {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	snapshots := make([]ConfigSnapshot, len(m.history))
+	for i, snapshot := range m.history {
+		snapshot.Config = nil
+		snapshots[i] = snapshot
+	}
+	return snapshots
+}
+
+// GetSnapshot returns the archived configuration with the given sequence number.
+func (m *Manager) GetSnapshot(sequence int) (*ConfigSnapshot, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	if sequence < 1 || sequence > len(m.history) {
+		return nil, fmt.Errorf("%w: %d", ErrConfigSnapshotNotFound, sequence)
+	}
+
+	snapshot := m.history[sequence-1]
+	config, err := cloneConfig(snapshot.Config)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.Config = config
+	return &snapshot, nil
+}
+
+// checkVersion rejects a configuration that reuses the version of an archived configuration
+// with different rules, so that a version always identifies the rules decisions were made
+// under. The caller must hold the lock.
+func (m *Manager) checkVersion(config *BusinessRulesConfig) error {
+	hash, err := configHash(config)
+	if err != nil {
+		return err
+	}
+	for _, snapshot := range m.history {
+		if snapshot.Version == config.Version && snapshot.Hash != hash {
+			return fmt.Errorf("%w: %s", ErrConfigVersionConflict, config.Version)
+		}
+	}
+	return nil
+}
+
+// configHash returns the SHA-256 of a configuration's JSON encoding.
+func configHash(config *BusinessRulesConfig) (string, error) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash config: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// SetSnapshotStore sets the store the configuration history is persisted to and loads the
+// history saved in it. Set it before loading the configuration, so the configuration loaded
+// at startup is checked against, and added to, the persisted history.
+func (m *Manager) SetSnapshotStore(ctx context.Context, snapshots SnapshotStore) error {
+	history, err := snapshots.ListSnapshots(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load config history: %w", err)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.snapshots = snapshots
+	m.history = history
+	return nil
+}
+
+// archiveConfig appends a snapshot of config to the history and persists it. The caller must
+// hold the write lock and swap to config only when archiving succeeds, so that the snapshot
+// is recorded atomically with the swap. A configuration identical to the latest snapshot,
+// such as the same file loaded at each start, is not archived again.
+func (m *Manager) archiveConfig(ctx context.Context, config *BusinessRulesConfig) error {
+	archived, err := cloneConfig(config)
+	if err != nil {
+		return fmt.Errorf("failed to archive configuration: %w", err)
+	}
+	hash, err := configHash(archived)
+	if err != nil {
+		return fmt.Errorf("failed to archive configuration: %w", err)
+	}
+	if n := len(m.history); n > 0 && m.history[n-1].Version == config.Version && m.history[n-1].Hash == hash {
+		return nil
+	}
+
+	snapshot := ConfigSnapshot{
+		Sequence: len(m.history) + 1,
+		Version:  config.Version,
+		Hash:     hash,
+		LoadedAt: time.Now(),
+		Config:   archived,
+	}
+	if m.snapshots != nil {
+		if err := m.snapshots.SaveSnapshot(ctx, &snapshot); err != nil {
+			return fmt.Errorf("failed to archive configuration: %w", err)
+		}
+	}
+	m.history = append(m.history, snapshot)
+	return nil
+}
+
+// cloneConfig returns a deep copy of config, so that later changes to the maps of a live
+// configuration do not leak into archived ones.
+func cloneConfig(config *BusinessRulesConfig) (*BusinessRulesConfig, error) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy config: %w", err)
+	}
+
+	var clone BusinessRulesConfig
+	if err := json.Unmarshal(data, &clone); err != nil {
+		return nil, fmt.Errorf("failed to copy config: %w", err)
+	}
+	return &clone, nil
+}
+
 // GetLastUpdated returns when the configuration was last updated.
 func (m *Manager) GetLastUpdated() time.Time {
 	m.mutex.RLock()
@@ -162,10 +383,15 @@ func (m *Manager) GetMetadata() *ConfigMetadata {
 	m.mutex.RLock()
 	defer m.mutex.RUnlock()
 
+	version := "1.0"
+	if m.config != nil {
+		version = m.config.Version
+	}
+
 	return &ConfigMetadata{
 		ConfigPath:  m.configPath,
 		LastUpdated: m.lastUpdated,
-		Version:     "1.0",
+		Version:     version,
 	}
 }
 
@@ -180,13 +406,13 @@ func (m *Manager) validateConfig(config *BusinessRulesConfig) error {
 		return fmt.Errorf("configuration cannot be nil")
 	}
 
-	// Basic validation - can be extended as needed
-	return nil
+	return config.Validate()
 }
 
 // getDefaultConfig returns the default business rules configuration.
 func (m *Manager) getDefaultConfig() *BusinessRulesConfig {
 	return &BusinessRulesConfig{
+		Version: "1.0",
 		FraudDetection: FraudDetectionConfig{
 			Enabled: true,
 			Version: "1.0",
@@ -197,11 +423,14 @@ func (m *Manager) getDefaultConfig() *BusinessRulesConfig {
 				Critical: 90.0,
 			},
 			FactorWeights: map[string]float64{
-				"claim_frequency": 0.3,
-				"claim_amount":    0.25,
-				"geographic_risk": 0.2,
-				"payment_history": 0.15,
-				"policy_duration": 0.1,
+				"claim_frequency":      0.3,
+				"claim_amount":         0.25,
+				"geographic_risk":      0.2,
+				"payment_history":      0.15,
+				"policy_duration":      0.1,
+				"submission_metadata":  0.15,
+				"document_consistency": 0.1,
+				"coverage_change":      0.2,
 			},
 			TimingRules: TimingRules{
 				NewAccountThreshold:     6 * 30 * 24 * time.Hour, // 6 months
@@ -209,6 +438,9 @@ func (m *Manager) getDefaultConfig() *BusinessRulesConfig {
 				ReportingDelayThreshold: 30 * 24 * time.Hour,     // 30 days
 				WeekendMultiplier:       1.2,
 				BusinessHoursMultiplier: 0.9,
+				BusinessHourStart:       9,
+				BusinessHourEnd:         17,
+				WeekendDays:             []time.Weekday{time.Saturday, time.Sunday},
 			},
 			AmountRules: AmountRules{
 				HighValueThreshold: 10000.0,
@@ -218,6 +450,9 @@ func (m *Manager) getDefaultConfig() *BusinessRulesConfig {
 				MinFileSize:           1024,             // 1KB
 				MaxFileSize:           10 * 1024 * 1024, // 10MB
 				RequiredDocumentTypes: []string{"id", "proof_of_address", "financial_statement"},
+				AllowedContentTypes:   []string{"application/pdf", "image/jpeg", "image/png"},
+				DateMismatchDays:      1,
+				DateMismatchScore:     70,
 			},
 			GeographicRules: GeographicRules{
 				HighRiskCountries: []string{"AF", "IR", "KP", "SY"},
@@ -234,6 +469,20 @@ func (m *Manager) getDefaultConfig() *BusinessRulesConfig {
 				MaxDescriptionLength:     1000,
 				DescriptionLengthPenalty: 10.0,
 			},
+			SubmissionRules: SubmissionRules{
+				CountryMismatchScore:  40.0,
+				NewDeviceScore:        15.0,
+				SharedDeviceScore:     60.0,
+				SharedDeviceThreshold: 1,
+			},
+			CoverageChangeRules: CoverageChangeRules{
+				LookbackDays:     30,
+				MinIncreaseRatio: 0.25,
+				HighRiskDays:     7,
+				IncreaseScore:    40.0,
+				HighRiskScore:    80.0,
+				DoubledScore:     20.0,
+			},
 			ConfidenceThresholds: ConfidenceThresholds{
 				Low:      0.3,
 				Medium:   0.5,
@@ -245,6 +494,17 @@ func (m *Manager) getDefaultConfig() *BusinessRulesConfig {
 				CriticalFactorCount: 1,
 				HighSeverityCount:   2,
 			},
+			BatchConcurrency: DefaultFraudBatchConcurrency,
+			RateLimits: FraudRateLimits{
+				PerClaimPerMinute: 10,
+				PerClaimBurst:     5,
+				GlobalPerMinute:   600,
+				GlobalBurst:       100,
+			},
+			SIUEscalation: SIUEscalationConfig{
+				Enabled:    true,
+				RiskLevels: []string{"critical"},
+			},
 		},
 		RiskAssessment: RiskAssessmentConfig{
 			Enabled: true,
@@ -268,6 +528,74 @@ func (m *Manager) getDefaultConfig() *BusinessRulesConfig {
 				"travel_insurance":     25.0,
 				"disability_insurance": 75.0,
 			},
+			DiscountRules: DiscountRules{
+				MultiPolicyDiscount:    0.10,
+				MultiPolicyStep:        0.025,
+				MultiPolicyMaxDiscount: 0.20,
+			},
+			TaxRules: TaxRules{
+				DefaultRate: 0.08,
+			},
+			NoClaimsBonus: NoClaimsBonusRules{
+				Enabled: true,
+				Ladder: []NoClaimsBonusStep{
+					{ClaimFreeYears: 1, Discount: 0.10},
+					{ClaimFreeYears: 2, Discount: 0.20},
+					{ClaimFreeYears: 3, Discount: 0.30},
+					{ClaimFreeYears: 5, Discount: 0.40},
+				},
+			},
+			SeasonalAdjustments: SeasonalAdjustments{
+				WinterSurcharge:   0.02,
+				SummerSurcharge:   0.01,
+				DefaultHemisphere: "northern",
+				JurisdictionHemispheres: map[string]string{
+					"AR": "southern",
+					"AU": "southern",
+					"BR": "southern",
+					"CL": "southern",
+					"MZ": "southern",
+					"NZ": "southern",
+					"ZA": "southern",
+				},
+			},
+			ValidationRules: PricingValidationRules{
+				RoundingPolicy: "nearest_cent",
+			},
+			TechnicalPremium: TechnicalPremiumRules{
+				Enabled:            false,
+				MinLossProbability: 0.001,
+				MaxLossProbability: 0.05,
+				ExpenseLoading:     0.25,
+			},
+			Deductibles: DeductibleMatrix{
+				"auto": {
+					"basic":    {500, 1000, 2000},
+					"standard": {250, 500, 1000},
+					"premium":  {100, 250, 500},
+				},
+				"home": {
+					"basic":    {1000, 2500, 5000},
+					"standard": {500, 1000, 2500},
+					"premium":  {250, 500, 1000},
+				},
+			},
+			DeductibleCredits: DeductibleCredits{
+				CreditRate: 2.0,
+				MaxCredit:  0.20,
+			},
+			RateTables: map[string]RateTable{
+				"v1": {
+					"auto":     15.0,
+					"home":     8.0,
+					"life":     5.0,
+					"health":   25.0,
+					"business": 20.0,
+				},
+			},
+			RateTableRollout: RateTableRollout{
+				Control: "v1",
+			},
 		},
 		Underwriting: UnderwritingConfig{
 			Enabled: true,
@@ -276,18 +604,105 @@ func (m *Manager) getDefaultConfig() *BusinessRulesConfig {
 		Commission: CommissionConfig{
 			Enabled: true,
 			Version: "1.0",
+			OverrideRates: map[string]float64{
+				"initial":    3.0,
+				"renewal":    2.0,
+				"adjustment": 1.0,
+			},
+			MaxCombinedRate: 20.0,
+			ClawbackSchedule: []ClawbackTier{
+				{WithinDays: 30, Rate: 1.0},
+				{WithinDays: 180, Rate: 0.5},
+			},
 		},
 		Compliance: ComplianceConfig{
 			Enabled: true,
 			Version: "1.0",
+			KYCRequirements: KYCRequirements{
+				UpdateFrequency: 365,
+			},
+			AMLRequirements: AMLRequirements{
+				MonitoringThresholds: []float64{10000, 50000},
+				ReportingThresholds:  []float64{25000, 100000},
+				ReviewPeriods:        []int{30, 365},
+			},
 		},
 		PolicyLifecycle: PolicyLifecycleConfig{
 			Enabled: true,
 			Version: "1.0",
+			RenewalRules: RenewalRules{
+				AdvanceRenewalDays: 30,
+				RateIncreaseRate:   0.03,
+				FrequencyDiscounts: map[string]float64{
+					"annually":  0.05,
+					"quarterly": -0.02,
+					"monthly":   -0.05,
+				},
+				OfferValidityDays:        30,
+				OpenClaimReviewThreshold: 50000,
+				OfferBatchConcurrency:    DefaultRenewalOfferBatchConcurrency,
+			},
+			CancellationRules: CancellationRules{
+				CancellationFeeRate:     0.10,
+				RefundCalculationMethod: "pro_rated",
+				ProcessingDays:          7,
+				FreeLookDays:            14,
+			},
+			GracePeriodRules: GracePeriodRules{
+				DefaultDays:        15,
+				PaymentFailureDays: 30,
+				RenewalDays:        15,
+			},
+			BillingRules: BillingRules{
+				PaymentTermDays:  30,
+				OverdueGraceDays: 15,
+			},
+			BeneficiaryRules: map[string]BeneficiaryRules{
+				"life": {
+					Required:             true,
+					MaxBeneficiaries:     10,
+					AllowedRelationships: []string{"spouse", "partner", "child", "parent", "sibling", "relative", "estate", "trust", "other"},
+				},
+			},
 		},
 		ClaimProcessing: ClaimProcessingConfig{
 			Enabled: true,
 			Version: "1.0",
+			ApprovalRules: ApprovalRules{
+				AutoApproveMax:           10000,
+				SeniorReviewThreshold:    50000,
+				ExecutiveReviewThreshold: 100000,
+				ManualReviewThreshold:    250000,
+			},
+			DamageAssessment: DamageAssessmentRules{
+				AutoApproveMax:   10000,
+				MinDocumentCount: 2,
+			},
+			StageRetry: StageRetryRules{
+				MaxAttempts:    3,
+				InitialBackoff: 200 * time.Millisecond,
+				MaxBackoff:     2 * time.Second,
+				Multiplier:     2,
+			},
+		},
+		Appeals: AppealConfig{
+			MaxAppeals: 2,
+			ReviewDays: 30,
+		},
+		Currency: CurrencyConfig{
+			BaseCurrency: "USD",
+			ExchangeRates: map[string]float64{
+				"USD": 1.0,
+				"EUR": 0.92,
+				"GBP": 0.79,
+				"ZAR": 18.5,
+				"MZN": 63.9,
+			},
+		},
+		Numbering: NumberingConfig{
+			Policy: NumberFormat{Prefix: "POL", Digits: 6, IncludeYear: true},
+			Claim:  NumberFormat{Prefix: "CLM", Digits: 6, IncludeYear: true},
+			SAR:    NumberFormat{Prefix: "SAR", Digits: 6, IncludeYear: true},
 		},
 	}
 }
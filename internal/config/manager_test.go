@@ -0,0 +1,131 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReloadsArchiveSnapshotsInOrder(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "business_rules.json")
+	m := NewManager(logger.NewLogger("error", "json"), path)
+
+	versions := []string{"1.0", "1.1", "2.0"}
+	for _, version := range versions {
+		cfg := m.getDefaultConfig()
+		cfg.Version = version
+		cfg.FraudDetection.RiskThresholds.High = 70 + float64(len(m.ListSnapshots()))
+		data, err := json.Marshal(cfg)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, data, 0644))
+		require.NoError(t, m.RefreshConfig(ctx))
+	}
+
+	snapshots := m.ListSnapshots()
+	require.Len(t, snapshots, len(versions))
+	for i, snapshot := range snapshots {
+		assert.Equal(t, i+1, snapshot.Sequence)
+		assert.Equal(t, versions[i], snapshot.Version)
+		assert.Nil(t, snapshot.Config)
+		if i > 0 {
+			assert.False(t, snapshot.LoadedAt.Before(snapshots[i-1].LoadedAt))
+		}
+
+		archived, err := m.GetSnapshot(snapshot.Sequence)
+		require.NoError(t, err)
+		assert.Equal(t, versions[i], archived.Config.Version)
+		assert.Equal(t, 70+float64(i), archived.Config.FraudDetection.RiskThresholds.High)
+	}
+
+	_, err := m.GetSnapshot(len(versions) + 1)
+	assert.ErrorIs(t, err, ErrConfigSnapshotNotFound)
+}
+
+func TestUpdateConfigRejectsChangedRulesUnderAnArchivedVersion(t *testing.T) {
+	ctx := context.Background()
+	m := NewManager(logger.NewLogger("error", "json"), filepath.Join(t.TempDir(), "business_rules.json"))
+	require.NoError(t, m.LoadConfig(ctx))
+
+	// Applying the same rules again under their version is allowed.
+	require.NoError(t, m.UpdateConfig(ctx, m.GetConfig()))
+
+	changed := m.GetConfig()
+	changed.FraudDetection.RiskThresholds.High = 75
+	assert.ErrorIs(t, m.UpdateConfig(ctx, changed), ErrConfigVersionConflict)
+	assert.Equal(t, 80.0, m.GetConfig().FraudDetection.RiskThresholds.High)
+
+	changed.Version = "1.1"
+	require.NoError(t, m.UpdateConfig(ctx, changed))
+	archived, err := m.GetConfigVersion("1.0")
+	require.NoError(t, err)
+	assert.Equal(t, 80.0, archived.FraudDetection.RiskThresholds.High)
+}
+
+// memorySnapshotStore is a SnapshotStore kept in memory.
+type memorySnapshotStore struct {
+	snapshots []ConfigSnapshot
+	err       error
+}
+
+func (s *memorySnapshotStore) SaveSnapshot(_ context.Context, snapshot *ConfigSnapshot) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.snapshots = append(s.snapshots, *snapshot)
+	return nil
+}
+
+func (s *memorySnapshotStore) ListSnapshots(context.Context) ([]ConfigSnapshot, error) {
+	return append([]ConfigSnapshot(nil), s.snapshots...), nil
+}
+
+func TestConfigHistorySurvivesRestart(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "business_rules.json")
+	snapshots := &memorySnapshotStore{}
+
+	m := NewManager(logger.NewLogger("error", "json"), path)
+	require.NoError(t, m.SetSnapshotStore(ctx, snapshots))
+	require.NoError(t, m.LoadConfig(ctx))
+	changed := m.GetConfig()
+	changed.Version = "1.1"
+	changed.FraudDetection.RiskThresholds.High = 75
+	require.NoError(t, m.UpdateConfig(ctx, changed))
+	require.Len(t, snapshots.snapshots, 2)
+
+	restarted := NewManager(logger.NewLogger("error", "json"), path)
+	require.NoError(t, restarted.SetSnapshotStore(ctx, snapshots))
+	require.NoError(t, restarted.LoadConfig(ctx))
+	assert.Len(t, snapshots.snapshots, 2, "reloading the current configuration is not archived again")
+
+	archived, err := restarted.GetConfigVersion("1.0")
+	require.NoError(t, err)
+	assert.Equal(t, 80.0, archived.FraudDetection.RiskThresholds.High)
+
+	rewritten := restarted.GetConfig()
+	rewritten.Version = "1.0"
+	assert.ErrorIs(t, restarted.UpdateConfig(ctx, rewritten), ErrConfigVersionConflict)
+}
+
+func TestUpdateConfigIsNotAppliedWhenSnapshotCannotBePersisted(t *testing.T) {
+	ctx := context.Background()
+	snapshots := &memorySnapshotStore{}
+	m := NewManager(logger.NewLogger("error", "json"), filepath.Join(t.TempDir(), "business_rules.json"))
+	require.NoError(t, m.SetSnapshotStore(ctx, snapshots))
+	require.NoError(t, m.LoadConfig(ctx))
+
+	snapshots.err = errors.New("database unavailable")
+	changed := m.GetConfig()
+	changed.Version = "1.1"
+	assert.Error(t, m.UpdateConfig(ctx, changed))
+	assert.Equal(t, "1.0", m.GetConfig().Version)
+	assert.Len(t, m.ListSnapshots(), 1)
+}
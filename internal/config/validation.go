@@ -0,0 +1,207 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ValidationErrors lists every problem found in a business rules configuration.
+type ValidationErrors []string
+
+// Error implements the error interface.
+func (e ValidationErrors) Error() string {
+	return strings.Join(e, "; ")
+}
+
+// Validate checks the configuration for values that cannot be applied. Zero values are
+// accepted, as unset rules fall back to the services' built-in behaviour. It returns
+// ValidationErrors listing every problem found.
+func (c *BusinessRulesConfig) Validate() error {
+	var errs ValidationErrors
+
+	thresholds := c.FraudDetection.RiskThresholds
+	errs = checkAscending(errs, "fraud_detection.risk_thresholds", 100,
+		[]string{"low", "medium", "high", "critical"},
+		[]float64{thresholds.Low, thresholds.Medium, thresholds.High, thresholds.Critical})
+	errs = checkWeights(errs, "fraud_detection.factor_weights", c.FraudDetection.FactorWeights)
+	for category, weights := range c.FraudDetection.CategoryWeights {
+		errs = checkWeights(errs, "fraud_detection.category_weights."+category, weights)
+	}
+	errs = checkWeights(errs, "risk_assessment.factor_weights", c.RiskAssessment.FactorWeights)
+
+	rules := c.Pricing.ValidationRules
+	if rules.MinPremium < 0 {
+		errs = append(errs, "pricing.validation_rules.min_premium must not be negative")
+	}
+	if rules.MaxPremium > 0 && rules.MinPremium > rules.MaxPremium {
+		errs = append(errs, "pricing.validation_rules.min_premium must not exceed max_premium")
+	}
+	if rules.MaxAdjustment > 0 && rules.MinAdjustment > rules.MaxAdjustment {
+		errs = append(errs, "pricing.validation_rules.min_adjustment must not exceed max_adjustment")
+	}
+	for version, table := range c.Pricing.RateTables {
+		errs = checkWeights(errs, "pricing.rate_tables."+version, table)
+	}
+	technical := c.Pricing.TechnicalPremium
+	if technical.MinLossProbability < 0 || technical.MaxLossProbability > 1 {
+		errs = append(errs, "pricing.technical_premium loss probabilities must be between 0 and 1")
+	}
+	if technical.MaxLossProbability > 0 && technical.MinLossProbability > technical.MaxLossProbability {
+		errs = append(errs, "pricing.technical_premium.min_loss_probability must not exceed max_loss_probability")
+	}
+	if technical.ExpenseLoading < 0 {
+		errs = append(errs, "pricing.technical_premium.expense_loading must not be negative")
+	}
+	rollout := c.Pricing.RateTableRollout
+	if rollout.VariantPercentage < 0 || rollout.VariantPercentage > 100 {
+		errs = append(errs, "pricing.rate_table_rollout.variant_percentage must be between 0 and 100")
+	}
+
+	decision := c.Underwriting.DecisionThresholds
+	errs = checkAscending(errs, "underwriting.decision_thresholds", 100,
+		[]string{"auto_approve_max", "pending_review_min", "decline_min"},
+		[]float64{decision.AutoApproveMax, decision.PendingReviewMin, decision.DeclineMin})
+
+	for i, rule := range c.ClaimProcessing.ApprovalRules.Matrix {
+		path := fmt.Sprintf("claim_processing.approval_rules.matrix[%d]", i)
+		if rule.MinAmount < 0 || (rule.MaxAmount > 0 && rule.MaxAmount < rule.MinAmount) {
+			errs = append(errs, path+" amount range is invalid")
+		}
+		for _, stage := range rule.Stages {
+			if stage != "senior_review" && stage != "executive_approval" {
+				errs = append(errs, fmt.Sprintf("%s.stages has unknown stage %q", path, stage))
+			}
+		}
+	}
+
+	retry := c.ClaimProcessing.StageRetry
+	if retry.MaxAttempts < 0 {
+		errs = append(errs, "claim_processing.stage_retry.max_attempts must not be negative")
+	}
+	if retry.InitialBackoff < 0 || retry.MaxBackoff < 0 {
+		errs = append(errs, "claim_processing.stage_retry backoffs must not be negative")
+	}
+	if retry.Multiplier != 0 && retry.Multiplier < 1 {
+		errs = append(errs, "claim_processing.stage_retry.multiplier must be at least 1")
+	}
+
+	cancellation := c.PolicyLifecycle.CancellationRules
+	if cancellation.FreeLookDays < 0 {
+		errs = append(errs, "policy_lifecycle.cancellation_rules.free_look_days must not be negative")
+	}
+	for jurisdiction, days := range cancellation.JurisdictionFreeLookDays {
+		if days < 0 {
+			errs = append(errs, "policy_lifecycle.cancellation_rules.jurisdiction_free_look_days."+jurisdiction+" must not be negative")
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// checkAscending checks that the set values are within [0, max] and in ascending order.
+func checkAscending(errs ValidationErrors, path string, max float64, names []string, values []float64) ValidationErrors {
+	previous := -1
+	for i, value := range values {
+		if value < 0 || value > max {
+			errs = append(errs, fmt.Sprintf("%s.%s must be between 0 and %.0f", path, names[i], max))
+			continue
+		}
+		if value == 0 {
+			continue
+		}
+		if previous >= 0 && value < values[previous] {
+			errs = append(errs, fmt.Sprintf("%s.%s must not be below %s", path, names[i], names[previous]))
+		}
+		previous = i
+	}
+	return errs
+}
+
+// checkWeights checks that no weight or rate is negative.
+func checkWeights(errs ValidationErrors, path string, weights map[string]float64) ValidationErrors {
+	names := make([]string, 0, len(weights))
+	for name, weight := range weights {
+		if weight < 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		errs = append(errs, fmt.Sprintf("%s.%s must not be negative", path, name))
+	}
+	return errs
+}
+
+// ConfigChange is a field whose value differs between two configurations. Path is the
+// dotted JSON path of the field, e.g. "fraud_detection.risk_thresholds.high".
+type ConfigChange struct {
+	Path string      `json:"path"`
+	Old  interface{} `json:"old"`
+	New  interface{} `json:"new"`
+}
+
+// Diff returns the fields that differ between current and proposed, sorted by path.
+// Lists are compared as a whole.
+func Diff(current, proposed *BusinessRulesConfig) ([]ConfigChange, error) {
+	before, err := toJSONValue(current)
+	if err != nil {
+		return nil, err
+	}
+	after, err := toJSONValue(proposed)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := diffValues("", before, after, nil)
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes, nil
+}
+
+// toJSONValue converts config into its generic JSON representation.
+func toJSONValue(config *BusinessRulesConfig) (interface{}, error) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+	return value, nil
+}
+
+// diffValues appends the differences between two JSON values at path to changes.
+func diffValues(path string, before, after interface{}, changes []ConfigChange) []ConfigChange {
+	beforeObject, beforeIsObject := before.(map[string]interface{})
+	afterObject, afterIsObject := after.(map[string]interface{})
+	if !beforeIsObject || !afterIsObject {
+		if !reflect.DeepEqual(before, after) {
+			changes = append(changes, ConfigChange{Path: path, Old: before, New: after})
+		}
+		return changes
+	}
+
+	for key, value := range beforeObject {
+		changes = diffValues(joinPath(path, key), value, afterObject[key], changes)
+	}
+	for key, value := range afterObject {
+		if _, ok := beforeObject[key]; !ok {
+			changes = diffValues(joinPath(path, key), nil, value, changes)
+		}
+	}
+	return changes
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
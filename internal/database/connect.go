@@ -116,4 +116,6 @@ type DBConfig struct {
 	AcquireTimeout time.Duration `mapstructure:"acquire_timeout"`
 	MaxLifetime    time.Duration `mapstructure:"max_lifetime"`
 	IdleTimeout    time.Duration `mapstructure:"idle_timeout"`
+	// SlowQueryThreshold is the duration at which a query is logged as slow; 0 disables it.
+	SlowQueryThreshold time.Duration `mapstructure:"slow_query_threshold"`
 }
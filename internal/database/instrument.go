@@ -0,0 +1,107 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/metrics"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// queryStartKey is the statement setting holding the time a query started.
+const queryStartKey = "instrument:query_start"
+
+// Instrument records the duration of every query in m and logs queries that take at
+// least slowQueryThreshold. Connection pool statistics are exported through m as well.
+// A zero threshold disables slow-query logging; a nil m disables metrics.
+func (d *Database) Instrument(log *logger.Logger, m *metrics.Metrics, slowQueryThreshold time.Duration) error {
+	if m != nil {
+		sqlDB, err := d.DB.DB()
+		if err != nil {
+			return fmt.Errorf("failed to get underlying sql.DB: %w", err)
+		}
+		if err := m.RegisterDatabasePool(sqlDB); err != nil {
+			return fmt.Errorf("failed to register connection pool metrics: %w", err)
+		}
+	}
+
+	observer := &queryObserver{logger: log, metrics: m, slowQueryThreshold: slowQueryThreshold}
+	return observer.register(d.DB)
+}
+
+// queryObserver times queries through gorm callbacks.
+type queryObserver struct {
+	logger             *logger.Logger
+	metrics            *metrics.Metrics
+	slowQueryThreshold time.Duration
+}
+
+// register adds before and after callbacks around every gorm operation.
+func (o *queryObserver) register(db *gorm.DB) error {
+	callbacks := db.Callback()
+	type register func(name string, fn func(*gorm.DB)) error
+	processors := []struct {
+		operation     string
+		before, after register
+	}{
+		{"create", callbacks.Create().Before("gorm:create").Register, callbacks.Create().After("gorm:create").Register},
+		{"query", callbacks.Query().Before("gorm:query").Register, callbacks.Query().After("gorm:query").Register},
+		{"update", callbacks.Update().Before("gorm:update").Register, callbacks.Update().After("gorm:update").Register},
+		{"delete", callbacks.Delete().Before("gorm:delete").Register, callbacks.Delete().After("gorm:delete").Register},
+		{"row", callbacks.Row().Before("gorm:row").Register, callbacks.Row().After("gorm:row").Register},
+		{"raw", callbacks.Raw().Before("gorm:raw").Register, callbacks.Raw().After("gorm:raw").Register},
+	}
+
+	for _, p := range processors {
+		if err := p.before("instrument:before_"+p.operation, o.start); err != nil {
+			return fmt.Errorf("failed to register %s callback: %w", p.operation, err)
+		}
+		if err := p.after("instrument:after_"+p.operation, o.finish(p.operation)); err != nil {
+			return fmt.Errorf("failed to register %s callback: %w", p.operation, err)
+		}
+	}
+	return nil
+}
+
+// start records when a query started.
+func (o *queryObserver) start(db *gorm.DB) {
+	db.InstanceSet(queryStartKey, time.Now())
+}
+
+// finish records the duration of an operation and logs it when it is slow.
+func (o *queryObserver) finish(operation string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(queryStartKey)
+		if !ok {
+			return
+		}
+		started, ok := value.(time.Time)
+		if !ok {
+			return
+		}
+
+		duration := time.Since(started)
+		table := db.Statement.Table
+		if o.metrics != nil {
+			o.metrics.RecordDatabaseQuery(operation, table, duration)
+		}
+
+		if o.slowQueryThreshold <= 0 || duration < o.slowQueryThreshold {
+			return
+		}
+		if o.metrics != nil {
+			o.metrics.RecordSlowDatabaseQuery(operation, table)
+		}
+		if o.logger != nil {
+			o.logger.Warn("Slow database query",
+				zap.String("operation", operation),
+				zap.String("table", table),
+				zap.Duration("duration", duration),
+				zap.Duration("threshold", o.slowQueryThreshold),
+				zap.Int64("rows_affected", db.Statement.RowsAffected),
+				zap.String("sql", db.Statement.SQL.String()))
+		}
+	}
+}
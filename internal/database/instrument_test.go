@@ -0,0 +1,90 @@
+package database
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// stubDriver is a database/sql driver whose queries take latency and return no rows.
+type stubDriver struct{ latency time.Duration }
+
+func (d stubDriver) Open(string) (driver.Conn, error) { return stubConn(d), nil }
+
+type stubConn struct{ latency time.Duration }
+
+func (c stubConn) Prepare(string) (driver.Stmt, error) { return stubStmt(c), nil }
+func (c stubConn) Close() error                        { return nil }
+func (c stubConn) Begin() (driver.Tx, error)           { return stubTx{}, nil }
+
+type stubStmt struct{ latency time.Duration }
+
+func (s stubStmt) Close() error  { return nil }
+func (s stubStmt) NumInput() int { return -1 }
+func (s stubStmt) Exec([]driver.Value) (driver.Result, error) {
+	time.Sleep(s.latency)
+	return driver.RowsAffected(0), nil
+}
+func (s stubStmt) Query([]driver.Value) (driver.Rows, error) {
+	time.Sleep(s.latency)
+	return stubRows{}, nil
+}
+
+type stubRows struct{}
+
+func (stubRows) Columns() []string         { return []string{"id"} }
+func (stubRows) Close() error              { return nil }
+func (stubRows) Next([]driver.Value) error { return io.EOF }
+
+type stubTx struct{}
+
+func (stubTx) Commit() error   { return nil }
+func (stubTx) Rollback() error { return nil }
+
+func init() {
+	sql.Register("instrument_stub_slow", stubDriver{latency: 20 * time.Millisecond})
+}
+
+func TestInstrumentLogsQueriesAboveSlowQueryThreshold(t *testing.T) {
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{DriverName: "instrument_stub_slow", DSN: "stub"}),
+		&gorm.Config{DisableAutomaticPing: true})
+	require.NoError(t, err)
+
+	core, logs := observer.New(zapcore.WarnLevel)
+	m := metrics.NewMetrics()
+	db := &Database{DB: gormDB}
+	require.NoError(t, db.Instrument(&logger.Logger{Logger: zap.New(core)}, m, 10*time.Millisecond))
+
+	var rows []struct{ ID int }
+	require.NoError(t, gormDB.Table("claims").Find(&rows).Error)
+
+	slow := logs.FilterMessage("Slow database query").All()
+	require.Len(t, slow, 1)
+	fields := slow[0].ContextMap()
+	assert.Equal(t, "query", fields["operation"])
+	assert.Equal(t, "claims", fields["table"])
+	assert.Contains(t, fields["sql"], "claims")
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.DatabaseSlowQueries.WithLabelValues("query", "claims")))
+
+	// A threshold above the query latency logs nothing.
+	quiet, err := gorm.Open(postgres.New(postgres.Config{DriverName: "instrument_stub_slow", DSN: "stub"}),
+		&gorm.Config{DisableAutomaticPing: true})
+	require.NoError(t, err)
+	quietCore, quietLogs := observer.New(zapcore.WarnLevel)
+	require.NoError(t, (&Database{DB: quiet}).Instrument(&logger.Logger{Logger: zap.New(quietCore)}, nil, time.Second))
+	require.NoError(t, quiet.Table("claims").Find(&rows).Error)
+	assert.Zero(t, quietLogs.Len())
+}
@@ -0,0 +1,306 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:embed migrations/*.sql
+var embeddedMigrations embed.FS
+
+// Migrations holds the versioned SQL migrations shipped with the binary. They run after
+// the AutoMigrate schema, for changes AutoMigrate cannot express.
+var Migrations fs.FS = mustSub(embeddedMigrations, "migrations")
+
+// migrationLockKey is the Postgres advisory lock held while migrations run, so instances
+// starting together do not apply the same migrations at once.
+const migrationLockKey int64 = 0x62617a6172757465 // "bazarute"
+
+// ErrMigrationChecksumMismatch is returned when an applied migration's SQL has changed
+// since it was applied.
+var ErrMigrationChecksumMismatch = errors.New("migration checksum mismatch")
+
+// Migration is a versioned schema change read from a pair of files named
+// <version>_<name>.up.sql and <version>_<name>.down.sql. Statements in a file are
+// separated by a semicolon at the end of a line.
+type Migration struct {
+	Version  int
+	Name     string
+	Up       string
+	Down     string
+	Checksum string // SHA-256 of Up
+}
+
+// SchemaMigration records an applied migration in the schema_migrations table.
+type SchemaMigration struct {
+	Version   int       `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"not null"`
+	Checksum  string    `gorm:"not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+// TableName returns the table applied migrations are recorded in.
+func (SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// MigrationStatus reports whether a migration has been applied.
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	AppliedAt *time.Time
+}
+
+// Migrator applies and rolls back versioned SQL migrations.
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// NewMigrator reads the migrations in fsys and returns a Migrator for db.
+func NewMigrator(db *gorm.DB, fsys fs.FS) (*Migrator, error) {
+	migrations, err := LoadMigrations(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// LoadMigrations reads the migrations in the root of fsys, ordered by version.
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		fileName := entry.Name()
+		if entry.IsDir() || path.Ext(fileName) != ".sql" {
+			continue
+		}
+
+		base := strings.TrimSuffix(fileName, ".sql")
+		direction := path.Ext(base)
+		if direction != ".up" && direction != ".down" {
+			return nil, fmt.Errorf("migration %s must end in .up.sql or .down.sql", fileName)
+		}
+		versionText, name, ok := strings.Cut(strings.TrimSuffix(base, direction), "_")
+		if !ok {
+			return nil, fmt.Errorf("migration %s must be named <version>_<name>", fileName)
+		}
+		version, err := strconv.Atoi(versionText)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration %s has an invalid version", fileName)
+		}
+
+		data, err := fs.ReadFile(fsys, fileName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", fileName, err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: name}
+			byVersion[version] = migration
+		} else if migration.Name != name {
+			return nil, fmt.Errorf("migration version %d is used by both %s and %s", version, migration.Name, name)
+		}
+		if direction == ".up" {
+			migration.Up = string(data)
+			sum := sha256.Sum256(data)
+			migration.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			migration.Down = string(data)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up script", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Up applies every pending migration in version order and returns the versions applied.
+// Before applying anything it verifies that no applied migration has changed, and fails
+// with ErrMigrationChecksumMismatch if one has.
+func (m *Migrator) Up(ctx context.Context) ([]int, error) {
+	var versions []int
+	err := withMigrationLock(ctx, m.db, func(db *gorm.DB) error {
+		var err error
+		versions, err = m.up(ctx, db)
+		return err
+	})
+	return versions, err
+}
+
+// up applies the pending migrations on db, which must hold the migration lock.
+func (m *Migrator) up(ctx context.Context, db *gorm.DB) ([]int, error) {
+	applied, err := m.applied(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	for _, migration := range m.migrations {
+		record, ok := applied[migration.Version]
+		if ok && record.Checksum != migration.Checksum {
+			return nil, fmt.Errorf("%w: %d_%s was changed after it was applied", ErrMigrationChecksumMismatch, migration.Version, migration.Name)
+		}
+	}
+
+	var versions []int
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+		err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := execScript(tx, migration.Up); err != nil {
+				return err
+			}
+			return tx.Create(&SchemaMigration{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				Checksum:  migration.Checksum,
+				AppliedAt: time.Now().UTC(),
+			}).Error
+		})
+		if err != nil {
+			return versions, fmt.Errorf("failed to apply migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		versions = append(versions, migration.Version)
+	}
+	return versions, nil
+}
+
+// Down rolls back the latest steps applied migrations and returns the versions rolled back.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]int, error) {
+	var versions []int
+	err := withMigrationLock(ctx, m.db, func(db *gorm.DB) error {
+		var err error
+		versions, err = m.down(ctx, db, steps)
+		return err
+	})
+	return versions, err
+}
+
+// down rolls back applied migrations on db, which must hold the migration lock.
+func (m *Migrator) down(ctx context.Context, db *gorm.DB, steps int) ([]int, error) {
+	applied, err := m.applied(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []int
+	for i := len(m.migrations) - 1; i >= 0 && len(versions) < steps; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+		err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := execScript(tx, migration.Down); err != nil {
+				return err
+			}
+			return tx.Delete(&SchemaMigration{}, migration.Version).Error
+		})
+		if err != nil {
+			return versions, fmt.Errorf("failed to roll back migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		versions = append(versions, migration.Version)
+	}
+	return versions, nil
+}
+
+// Status reports every known migration and when it was applied.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	applied, err := m.applied(ctx, m.db)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, len(m.migrations))
+	for i, migration := range m.migrations {
+		statuses[i] = MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if record, ok := applied[migration.Version]; ok {
+			appliedAt := record.AppliedAt
+			statuses[i].AppliedAt = &appliedAt
+		}
+	}
+	return statuses, nil
+}
+
+// applied returns the applied migrations by version, creating the schema_migrations
+// table if needed.
+func (m *Migrator) applied(ctx context.Context, db *gorm.DB) (map[int]SchemaMigration, error) {
+	db = db.WithContext(ctx)
+	if err := db.AutoMigrate(&SchemaMigration{}); err != nil {
+		return nil, fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	var records []SchemaMigration
+	if err := db.Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+
+	applied := make(map[int]SchemaMigration, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+	return applied, nil
+}
+
+// withMigrationLock runs fn with a connection of db that holds the migration lock, waiting
+// for any other instance migrating the database to finish. Only Postgres is locked; other
+// databases run fn on db directly.
+func withMigrationLock(ctx context.Context, db *gorm.DB, fn func(db *gorm.DB) error) error {
+	if db.Dialector.Name() != "postgres" {
+		return fn(db)
+	}
+
+	// Advisory locks belong to a session, so the lock is taken and released on one connection.
+	return db.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec("SELECT pg_advisory_lock(?)", migrationLockKey).Error; err != nil {
+			return fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+		// Unlock even when ctx is done, or the lock stays with the pooled connection.
+		defer conn.WithContext(context.Background()).Exec("SELECT pg_advisory_unlock(?)", migrationLockKey)
+		return fn(conn)
+	})
+}
+
+// execScript runs each statement of a migration script.
+func execScript(tx *gorm.DB, script string) error {
+	for _, statement := range strings.Split(script, ";\n") {
+		statement = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(statement), ";"))
+		if statement == "" {
+			continue
+		}
+		if err := tx.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func mustSub(fsys fs.FS, dir string) fs.FS {
+	sub, err := fs.Sub(fsys, dir)
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}
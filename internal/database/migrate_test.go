@@ -0,0 +1,89 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func newMigrationTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	return db
+}
+
+func testMigrations() fstest.MapFS {
+	return fstest.MapFS{
+		"0001_create_widgets.up.sql":   {Data: []byte("CREATE TABLE widgets (id INTEGER PRIMARY KEY, name TEXT);\n")},
+		"0001_create_widgets.down.sql": {Data: []byte("DROP TABLE widgets;\n")},
+		"0002_index_widgets.up.sql": {Data: []byte("ALTER TABLE widgets ADD COLUMN color TEXT;\n" +
+			"CREATE INDEX idx_widgets_name ON widgets (name);\n")},
+		"0002_index_widgets.down.sql": {Data: []byte("DROP INDEX idx_widgets_name;\n")},
+	}
+}
+
+func TestMigratorUpIsIdempotent(t *testing.T) {
+	ctx := context.Background()
+	db := newMigrationTestDB(t)
+	migrator, err := NewMigrator(db, testMigrations())
+	require.NoError(t, err)
+
+	applied, err := migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, applied)
+	assert.True(t, db.Migrator().HasColumn("widgets", "color"))
+
+	applied, err = migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, applied)
+
+	var count int64
+	require.NoError(t, db.Model(&SchemaMigration{}).Count(&count).Error)
+	assert.Equal(t, int64(2), count)
+
+	rolledBack, err := migrator.Down(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []int{2}, rolledBack)
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	assert.NotNil(t, statuses[0].AppliedAt)
+	assert.Nil(t, statuses[1].AppliedAt)
+}
+
+func TestMigratorDetectsTamperedMigration(t *testing.T) {
+	ctx := context.Background()
+	db := newMigrationTestDB(t)
+	migrations := testMigrations()
+	migrator, err := NewMigrator(db, migrations)
+	require.NoError(t, err)
+	_, err = migrator.Up(ctx)
+	require.NoError(t, err)
+
+	migrations["0001_create_widgets.up.sql"] = &fstest.MapFile{Data: []byte("CREATE TABLE widgets (id INTEGER PRIMARY KEY);\n")}
+	migrations["0003_more_widgets.up.sql"] = &fstest.MapFile{Data: []byte("CREATE TABLE more_widgets (id INTEGER PRIMARY KEY);\n")}
+	tampered, err := NewMigrator(db, migrations)
+	require.NoError(t, err)
+
+	_, err = tampered.Up(ctx)
+	assert.ErrorIs(t, err, ErrMigrationChecksumMismatch)
+	assert.False(t, db.Migrator().HasTable("more_widgets"), "no migration may run after a checksum mismatch")
+}
+
+func TestEmbeddedMigrationsLoad(t *testing.T) {
+	migrations, err := LoadMigrations(Migrations)
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+	for _, migration := range migrations {
+		assert.NotEmpty(t, migration.Down, "migration %d_%s has no down script", migration.Version, migration.Name)
+	}
+}
@@ -1,14 +1,25 @@
 package database
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/edsonmichaque/bazaruto/internal/models"
 	"gorm.io/gorm"
 )
 
-// RunMigrations runs all database migrations using GORM AutoMigrate.
+// RunMigrations runs all database migrations: GORM AutoMigrate for the models, then the
+// versioned SQL migrations in Migrations. On Postgres the migrations run under an advisory
+// lock, so only one instance migrates at a time.
 func RunMigrations(db *gorm.DB) error {
+	ctx := context.Background()
+	return withMigrationLock(ctx, db, func(db *gorm.DB) error {
+		return runMigrations(ctx, db)
+	})
+}
+
+// runMigrations runs the migrations on db, which must hold the migration lock.
+func runMigrations(ctx context.Context, db *gorm.DB) error {
 	// Enable UUID extension (PostgreSQL only)
 	if db.Dialector.Name() == "postgres" {
 		if err := db.Exec("CREATE EXTENSION IF NOT EXISTS \"pgcrypto\"").Error; err != nil {
@@ -24,15 +35,39 @@ func RunMigrations(db *gorm.DB) error {
 		&models.Quote{},
 		&models.Policy{},
 		&models.Claim{},
+		&models.ClaimWorkflowState{},
 		&models.Subscription{},
 		&models.Payment{},
 		&models.Invoice{},
 		&models.Beneficiary{},
 		&models.Coverage{},
+		&models.EventRecord{},
+		&models.EventDeadLetter{},
+		&models.ClaimReserve{},
+		&models.ClaimRecovery{},
+		&models.AuditLog{},
+		&models.PolicyEndorsement{},
+		&models.UnderwritingDecision{},
+		&models.Appeal{},
+		&models.Commission{},
+		&models.PartnerStatement{},
+		&models.PremiumTaxReport{},
+		&models.SuspiciousActivityReport{},
+		&models.Sequence{},
+		&models.ConfigSnapshot{},
 	); err != nil {
 		return fmt.Errorf("failed to run migrations: %w", err)
 	}
 
+	// Apply the versioned SQL migrations on top of the models' schema
+	migrator, err := NewMigrator(db, Migrations)
+	if err != nil {
+		return fmt.Errorf("failed to load migrations: %w", err)
+	}
+	if _, err := migrator.up(ctx, db); err != nil {
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+
 	return nil
 }
 
@@ -40,11 +75,27 @@ func RunMigrations(db *gorm.DB) error {
 func DropAll(db *gorm.DB) error {
 	// Drop tables in reverse dependency order
 	tables := []interface{}{
+		&SchemaMigration{},
+		&models.ConfigSnapshot{},
+		&models.Sequence{},
+		&models.SuspiciousActivityReport{},
+		&models.PremiumTaxReport{},
+		&models.PartnerStatement{},
+		&models.Commission{},
+		&models.Appeal{},
+		&models.UnderwritingDecision{},
+		&models.PolicyEndorsement{},
+		&models.AuditLog{},
+		&models.ClaimRecovery{},
+		&models.ClaimReserve{},
+		&models.EventDeadLetter{},
+		&models.EventRecord{},
 		&models.Coverage{},
 		&models.Beneficiary{},
 		&models.Invoice{},
 		&models.Payment{},
 		&models.Subscription{},
+		&models.ClaimWorkflowState{},
 		&models.Claim{},
 		&models.Policy{},
 		&models.Quote{},
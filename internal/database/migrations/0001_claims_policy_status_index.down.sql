@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_claims_policy_status;
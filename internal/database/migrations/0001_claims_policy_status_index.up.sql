@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_claims_policy_status ON claims (policy_id, status);
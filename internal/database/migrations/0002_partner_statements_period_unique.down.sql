@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_partner_statements_period;
@@ -0,0 +1,2 @@
+DELETE FROM partner_statements WHERE id NOT IN (SELECT id FROM (SELECT id, ROW_NUMBER() OVER (PARTITION BY partner_id, period_start, period_end ORDER BY created_at DESC) AS position FROM partner_statements) ranked WHERE position = 1);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_partner_statements_period ON partner_statements (partner_id, period_start, period_end);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_policies_previous_policy_renewal;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_policies_previous_policy_renewal;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_policies_previous_policy_renewal ON policies (previous_policy_id) WHERE previous_policy_id IS NOT NULL AND status <> 'cancelled' AND deleted_at IS NULL;
@@ -0,0 +1,81 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AppealFiledEvent is published when an appeal is filed against a denied claim or declined application.
+type AppealFiledEvent struct {
+	*BaseBusinessEvent
+	AppealID      uuid.UUID  `json:"appeal_id"`
+	SubjectType   string     `json:"subject_type"`
+	SubjectID     uuid.UUID  `json:"subject_id"`
+	UserID        uuid.UUID  `json:"user_id"`
+	Sequence      int        `json:"sequence"`
+	AssignedTo    *uuid.UUID `json:"assigned_to,omitempty"`
+	ReviewDueDate time.Time  `json:"review_due_date"`
+	FiledAt       time.Time  `json:"filed_at"`
+}
+
+// NewAppealFiledEvent creates a new appeal filed event.
+func NewAppealFiledEvent(appealID uuid.UUID, subjectType string, subjectID, userID uuid.UUID, sequence int, assignedTo *uuid.UUID, reviewDueDate, filedAt time.Time) *AppealFiledEvent {
+	event := &AppealFiledEvent{
+		BaseBusinessEvent: &BaseBusinessEvent{
+			EventID:       uuid.New(),
+			EventType:     "appeal.filed",
+			EntityID:      appealID,
+			EntityType:    "appeal",
+			Timestamp:     time.Now(),
+			EventVersion:  "1.0",
+			EventMetadata: make(map[string]interface{}),
+		},
+		AppealID:      appealID,
+		SubjectType:   subjectType,
+		SubjectID:     subjectID,
+		UserID:        userID,
+		Sequence:      sequence,
+		AssignedTo:    assignedTo,
+		ReviewDueDate: reviewDueDate,
+		FiledAt:       filedAt,
+	}
+	return event
+}
+
+// AppealResolvedEvent is published when an appeal is upheld or overturned.
+type AppealResolvedEvent struct {
+	*BaseBusinessEvent
+	AppealID         uuid.UUID `json:"appeal_id"`
+	SubjectType      string    `json:"subject_type"`
+	SubjectID        uuid.UUID `json:"subject_id"`
+	UserID           uuid.UUID `json:"user_id"`
+	Status           string    `json:"status"`
+	OriginalDecision string    `json:"original_decision"`
+	Outcome          string    `json:"outcome"`
+	ResolvedAt       time.Time `json:"resolved_at"`
+}
+
+// NewAppealResolvedEvent creates a new appeal resolved event.
+func NewAppealResolvedEvent(appealID uuid.UUID, subjectType string, subjectID, userID uuid.UUID, status, originalDecision, outcome string, resolvedAt time.Time) *AppealResolvedEvent {
+	event := &AppealResolvedEvent{
+		BaseBusinessEvent: &BaseBusinessEvent{
+			EventID:       uuid.New(),
+			EventType:     "appeal.resolved",
+			EntityID:      appealID,
+			EntityType:    "appeal",
+			Timestamp:     time.Now(),
+			EventVersion:  "1.0",
+			EventMetadata: make(map[string]interface{}),
+		},
+		AppealID:         appealID,
+		SubjectType:      subjectType,
+		SubjectID:        subjectID,
+		UserID:           userID,
+		Status:           status,
+		OriginalDecision: originalDecision,
+		Outcome:          outcome,
+		ResolvedAt:       resolvedAt,
+	}
+	return event
+}
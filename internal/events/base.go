@@ -14,6 +14,7 @@ type BusinessEvent interface {
 	GetEntityID() uuid.UUID
 	GetEntityType() string
 	GetTimestamp() time.Time
+	GetVersion() string
 	GetMetadata() map[string]interface{}
 }
 
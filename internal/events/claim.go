@@ -44,3 +44,109 @@ func NewClaimSubmittedEvent(claimID, userID, policyID uuid.UUID, claimAmount flo
 	}
 	return event
 }
+
+// ClaimReserveChangedEvent is published when the reserve held against a claim is set or adjusted.
+type ClaimReserveChangedEvent struct {
+	*BaseBusinessEvent
+	ClaimID        uuid.UUID `json:"claim_id"`
+	PreviousAmount float64   `json:"previous_amount"`
+	NewAmount      float64   `json:"new_amount"`
+	Currency       string    `json:"currency"`
+	Stage          string    `json:"stage"`
+	Reason         string    `json:"reason"`
+	ChangedAt      time.Time `json:"changed_at"`
+}
+
+// NewClaimReserveChangedEvent creates a new claim reserve changed event.
+func NewClaimReserveChangedEvent(claimID uuid.UUID, previousAmount, newAmount float64, currency, stage, reason string, changedAt time.Time) *ClaimReserveChangedEvent {
+	event := &ClaimReserveChangedEvent{
+		BaseBusinessEvent: &BaseBusinessEvent{
+			EventID:       uuid.New(),
+			EventType:     "claim.reserve_changed",
+			EntityID:      claimID,
+			EntityType:    "claim",
+			Timestamp:     time.Now(),
+			EventVersion:  "1.0",
+			EventMetadata: make(map[string]interface{}),
+		},
+		ClaimID:        claimID,
+		PreviousAmount: previousAmount,
+		NewAmount:      newAmount,
+		Currency:       currency,
+		Stage:          stage,
+		Reason:         reason,
+		ChangedAt:      changedAt,
+	}
+	return event
+}
+
+// ClaimRecoveryReceivedEvent is published when a subrogation or salvage recovery is received.
+type ClaimRecoveryReceivedEvent struct {
+	*BaseBusinessEvent
+	ClaimID        uuid.UUID `json:"claim_id"`
+	RecoveryID     uuid.UUID `json:"recovery_id"`
+	RecoveryType   string    `json:"recovery_type"`
+	Amount         float64   `json:"amount"`
+	TotalRecovered float64   `json:"total_recovered"`
+	Currency       string    `json:"currency"`
+	RecoveryStatus string    `json:"recovery_status"`
+	ReceivedAt     time.Time `json:"received_at"`
+}
+
+// NewClaimRecoveryReceivedEvent creates a new claim recovery received event.
+func NewClaimRecoveryReceivedEvent(claimID, recoveryID uuid.UUID, recoveryType string, amount, totalRecovered float64, currency, recoveryStatus string, receivedAt time.Time) *ClaimRecoveryReceivedEvent {
+	event := &ClaimRecoveryReceivedEvent{
+		BaseBusinessEvent: &BaseBusinessEvent{
+			EventID:       uuid.New(),
+			EventType:     "claim.recovery_received",
+			EntityID:      claimID,
+			EntityType:    "claim",
+			Timestamp:     time.Now(),
+			EventVersion:  "1.0",
+			EventMetadata: make(map[string]interface{}),
+		},
+		ClaimID:        claimID,
+		RecoveryID:     recoveryID,
+		RecoveryType:   recoveryType,
+		Amount:         amount,
+		TotalRecovered: totalRecovered,
+		Currency:       currency,
+		RecoveryStatus: recoveryStatus,
+		ReceivedAt:     receivedAt,
+	}
+	return event
+}
+
+// ClaimStageChangedEvent is published when a stage of a claim's processing workflow changes
+// status.
+type ClaimStageChangedEvent struct {
+	*BaseBusinessEvent
+	ClaimID    uuid.UUID `json:"claim_id"`
+	StageID    string    `json:"stage_id"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	Result     string    `json:"result"`
+	ChangedAt  time.Time `json:"changed_at"`
+}
+
+// NewClaimStageChangedEvent creates a new claim stage changed event.
+func NewClaimStageChangedEvent(claimID uuid.UUID, stageID, fromStatus, toStatus, result string, changedAt time.Time) *ClaimStageChangedEvent {
+	event := &ClaimStageChangedEvent{
+		BaseBusinessEvent: &BaseBusinessEvent{
+			EventID:       uuid.New(),
+			EventType:     "claim.stage_changed",
+			EntityID:      claimID,
+			EntityType:    "claim",
+			Timestamp:     time.Now(),
+			EventVersion:  "1.0",
+			EventMetadata: make(map[string]interface{}),
+		},
+		ClaimID:    claimID,
+		StageID:    stageID,
+		FromStatus: fromStatus,
+		ToStatus:   toStatus,
+		Result:     result,
+		ChangedAt:  changedAt,
+	}
+	return event
+}
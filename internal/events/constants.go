@@ -25,11 +25,14 @@ const (
 	EventTypePaymentFailed    = "payment.failed"
 	EventTypePaymentRefunded  = "payment.refunded"
 
-	EventTypePolicyCreated   = "policy.created"
-	EventTypePolicyActivated = "policy.activated"
-	EventTypePolicyExpired   = "policy.expired"
-	EventTypePolicyCancelled = "policy.cancelled"
-	EventTypePolicyRenewed   = "policy.renewed"
+	EventTypeInvoiceCreated = "invoice.created"
+
+	EventTypePolicyCreated         = "policy.created"
+	EventTypePolicyActivated       = "policy.activated"
+	EventTypePolicyExpired         = "policy.expired"
+	EventTypePolicyCancelled       = "policy.cancelled"
+	EventTypePolicyRenewed         = "policy.renewed"
+	EventTypePolicyRenewalReminder = "policy.renewal_reminder"
 
 	EventTypeClaimSubmitted = "claim.submitted"
 	EventTypeClaimApproved  = "claim.approved"
@@ -37,6 +40,15 @@ const (
 	EventTypeClaimSettled   = "claim.settled"
 	EventTypeClaimClosed    = "claim.closed"
 
+	EventTypeClaimReserveChanged   = "claim.reserve_changed"
+	EventTypeClaimRecoveryReceived = "claim.recovery_received"
+	EventTypeClaimStageChanged     = "claim.stage_changed"
+
+	EventTypeAppealFiled    = "appeal.filed"
+	EventTypeAppealResolved = "appeal.resolved"
+
+	EventTypeStatementGenerated = "statement.generated"
+
 	EventTypeFraudDetected = "fraud.detected"
 	EventTypeFraudAnalysis = "fraud.analysis"
 	EventTypeRiskAssessed  = "risk.assessed"
@@ -48,12 +60,16 @@ const (
 	EntityTypeUser       = "user"
 	EntityTypeQuote      = "quote"
 	EntityTypePayment    = "payment"
+	EntityTypeInvoice    = "invoice"
 	EntityTypePolicy     = "policy"
 	EntityTypeClaim      = "claim"
+	EntityTypeAppeal     = "appeal"
 	EntityTypeFraud      = "fraud"
 	EntityTypeRisk       = "risk"
 	EntityTypeCommission = "commission"
 
+	EntityTypePartnerStatement = "partner_statement"
+
 	// Event versions
 	EventVersionV1 = "1.0"
 	EventVersionV2 = "2.0"
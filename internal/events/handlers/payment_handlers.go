@@ -10,6 +10,7 @@ import (
 	"github.com/edsonmichaque/bazaruto/internal/services"
 	"github.com/edsonmichaque/bazaruto/pkg/event"
 	"github.com/edsonmichaque/bazaruto/pkg/job"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
@@ -73,15 +74,19 @@ func (h *PaymentInitiatedHandler) HandlerName() string {
 
 // PaymentCompletedHandler handles payment completion events.
 type PaymentCompletedHandler struct {
-	dispatcher job.Dispatcher
-	logger     *logger.Logger
+	commissionService *services.CommissionService
+	invoiceService    *services.InvoiceService
+	dispatcher        job.Dispatcher
+	logger            *logger.Logger
 }
 
 // NewPaymentCompletedHandler creates a new payment completed event handler.
-func NewPaymentCompletedHandler(dispatcher job.Dispatcher, logger *logger.Logger) *PaymentCompletedHandler {
+func NewPaymentCompletedHandler(commissionService *services.CommissionService, invoiceService *services.InvoiceService, dispatcher job.Dispatcher, logger *logger.Logger) *PaymentCompletedHandler {
 	return &PaymentCompletedHandler{
-		dispatcher: dispatcher,
-		logger:     logger,
+		commissionService: commissionService,
+		invoiceService:    invoiceService,
+		dispatcher:        dispatcher,
+		logger:            logger,
 	}
 }
 
@@ -98,6 +103,33 @@ func (h *PaymentCompletedHandler) Handle(ctx context.Context, event event.Event)
 		zap.Float64("amount", paymentEvent.Amount),
 		zap.String("transaction_id", paymentEvent.TransactionID))
 
+	// Settle the policy invoice the payment covers so it is not chased as overdue
+	if h.invoiceService != nil && paymentEvent.PolicyID != uuid.Nil {
+		if _, err := h.invoiceService.RecordPolicyPayment(ctx, paymentEvent.PolicyID, paymentEvent.PaymentID); err != nil {
+			h.logger.Error("Failed to mark policy invoice as paid",
+				zap.Error(err),
+				zap.String("policy_id", paymentEvent.PolicyID.String()),
+				zap.String("payment_id", paymentEvent.PaymentID.String()))
+			return fmt.Errorf("failed to mark policy invoice as paid: %w", err)
+		}
+	}
+
+	// The policy's premium is paid, so the partner that sold it has earned its commission.
+	// Commissions are recorded by their own job, so a failure retries only that step.
+	if h.commissionService != nil && paymentEvent.PolicyID != uuid.Nil {
+		commissionJob := &jobs.RecordPolicyCommissionsJob{
+			PolicyID:          paymentEvent.PolicyID,
+			CommissionService: h.commissionService,
+			Logger:            h.logger,
+		}
+		if err := h.dispatcher.PerformWithContext(ctx, commissionJob); err != nil {
+			h.logger.Error("Failed to dispatch policy commission job",
+				zap.Error(err),
+				zap.String("policy_id", paymentEvent.PolicyID.String()))
+			return fmt.Errorf("failed to dispatch policy commission job: %w", err)
+		}
+	}
+
 	// Dispatch payment confirmation email job
 	emailJob := &jobs.SendEmailJob{
 		To:      fmt.Sprintf("user-%s@example.com", paymentEvent.UserID.String()), // In real app, get from user service
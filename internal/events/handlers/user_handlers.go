@@ -10,23 +10,30 @@ import (
 	"github.com/edsonmichaque/bazaruto/internal/jobs"
 	"github.com/edsonmichaque/bazaruto/internal/logger"
 	"github.com/edsonmichaque/bazaruto/internal/services"
+	"github.com/edsonmichaque/bazaruto/internal/store"
 	"github.com/edsonmichaque/bazaruto/pkg/event"
 	"github.com/edsonmichaque/bazaruto/pkg/job"
 )
 
 // UserRegisteredHandler handles user registration events.
 type UserRegisteredHandler struct {
-	userService *services.UserService
-	dispatcher  job.Dispatcher
-	logger      *logger.Logger
+	userService       *services.UserService
+	customerStore     store.CustomerStore
+	complianceService *services.ComplianceService
+	dispatcher        job.Dispatcher
+	logger            *logger.Logger
 }
 
-// NewUserRegisteredHandler creates a new user registered event handler.
-func NewUserRegisteredHandler(userService *services.UserService, dispatcher job.Dispatcher, logger *logger.Logger) *UserRegisteredHandler {
+// NewUserRegisteredHandler creates a new user registered event handler. The customer store
+// resolves the language the welcome email is written in, and the compliance service screens
+// the new user against sanctions and PEP lists.
+func NewUserRegisteredHandler(userService *services.UserService, customerStore store.CustomerStore, complianceService *services.ComplianceService, dispatcher job.Dispatcher, logger *logger.Logger) *UserRegisteredHandler {
 	return &UserRegisteredHandler{
-		userService: userService,
-		dispatcher:  dispatcher,
-		logger:      logger,
+		userService:       userService,
+		customerStore:     customerStore,
+		complianceService: complianceService,
+		dispatcher:        dispatcher,
+		logger:            logger,
 	}
 }
 
@@ -41,10 +48,28 @@ func (h *UserRegisteredHandler) Handle(ctx context.Context, event event.Event) e
 		zap.String("user_id", userEvent.UserID.String()),
 		zap.String("email", userEvent.Email))
 
+	// Screen the new user against sanctions and PEP lists. A match drafts a suspicious
+	// activity report; it does not hold back the welcome email.
+	if h.complianceService != nil {
+		violations, err := h.complianceService.ScreenUser(ctx, userEvent.UserID)
+		if err != nil {
+			h.logger.Error("Failed to screen registered user",
+				zap.Error(err),
+				zap.String("user_id", userEvent.UserID.String()))
+			return fmt.Errorf("failed to screen registered user: %w", err)
+		}
+		if len(violations) > 0 {
+			h.logger.Warn("Registered user flagged by sanctions screening",
+				zap.String("user_id", userEvent.UserID.String()),
+				zap.Int("violations", len(violations)))
+		}
+	}
+
 	// Dispatch welcome email job
 	welcomeJob := &jobs.WelcomeEmailJob{
 		UserID:      userEvent.UserID,
 		UserService: h.userService,
+		Customers:   h.customerStore,
 	}
 
 	if err := h.dispatcher.PerformWithContext(ctx, welcomeJob); err != nil {
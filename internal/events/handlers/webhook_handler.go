@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -21,14 +22,17 @@ import (
 type WebhookEventHandler struct {
 	webhookService *services.WebhookService
 	dispatcher     job.Dispatcher
+	schemas        *events.SchemaRegistry
 	logger         *logger.Logger
 }
 
-// NewWebhookEventHandler creates a new webhook event handler.
-func NewWebhookEventHandler(webhookService *services.WebhookService, dispatcher job.Dispatcher, logger *logger.Logger) *WebhookEventHandler {
+// NewWebhookEventHandler creates a new webhook event handler. Business event payloads are
+// encoded with the schema registry so receivers get the event's schema version.
+func NewWebhookEventHandler(webhookService *services.WebhookService, dispatcher job.Dispatcher, schemas *events.SchemaRegistry, logger *logger.Logger) *WebhookEventHandler {
 	return &WebhookEventHandler{
 		webhookService: webhookService,
 		dispatcher:     dispatcher,
+		schemas:        schemas,
 		logger:         logger,
 	}
 }
@@ -145,83 +149,40 @@ func (h *WebhookEventHandler) buildWebhookPayload(event event.Event) map[string]
 		"timestamp": time.Now().UTC().Format(time.RFC3339),
 	}
 
-	// Add event-specific data based on event type
-	switch event.Type() {
-	case "user.registered":
-		if userEvent, ok := event.(*events.UserRegisteredEvent); ok {
-			payload["user"] = map[string]interface{}{
-				"id":        userEvent.UserID.String(),
-				"email":     userEvent.Email,
-				"full_name": userEvent.FullName,
-				"role":      userEvent.Role,
-			}
-		}
-	case "user.logged_in":
-		if userEvent, ok := event.(*events.UserLoggedInEvent); ok {
-			payload["user"] = map[string]interface{}{
-				"id":         userEvent.UserID.String(),
-				"email":      userEvent.Email,
-				"login_time": userEvent.LoginTime.Format(time.RFC3339),
-				"ip_address": userEvent.IPAddress,
-			}
-		}
-	case "quote.created":
-		if quoteEvent, ok := event.(*events.QuoteCreatedEvent); ok {
-			payload["quote"] = map[string]interface{}{
-				"id":              quoteEvent.QuoteID.String(),
-				"user_id":         quoteEvent.UserID.String(),
-				"product_id":      quoteEvent.ProductID.String(),
-				"coverage_amount": quoteEvent.CoverageAmount,
-				"currency":        quoteEvent.Currency,
-			}
-		}
-	case "quote.calculated":
-		if quoteEvent, ok := event.(*events.QuoteCalculatedEvent); ok {
-			payload["quote"] = map[string]interface{}{
-				"id":            quoteEvent.QuoteID.String(),
-				"user_id":       quoteEvent.UserID.String(),
-				"final_premium": quoteEvent.FinalPremium,
-				"base_premium":  quoteEvent.BasePremium,
-				"currency":      quoteEvent.Currency,
-			}
-		}
-	case "payment.initiated":
-		if paymentEvent, ok := event.(*events.PaymentInitiatedEvent); ok {
-			payload["payment"] = map[string]interface{}{
-				"id":       paymentEvent.PaymentID.String(),
-				"user_id":  paymentEvent.UserID.String(),
-				"amount":   paymentEvent.Amount,
-				"currency": paymentEvent.Currency,
-				"method":   paymentEvent.PaymentMethod,
-			}
-		}
-	case "payment.completed":
-		if paymentEvent, ok := event.(*events.PaymentCompletedEvent); ok {
-			payload["payment"] = map[string]interface{}{
-				"id":             paymentEvent.PaymentID.String(),
-				"user_id":        paymentEvent.UserID.String(),
-				"amount":         paymentEvent.Amount,
-				"currency":       paymentEvent.Currency,
-				"transaction_id": paymentEvent.TransactionID,
-				"completed_at":   paymentEvent.CompletedAt.Format(time.RFC3339),
-			}
-		}
-	case "payment.failed":
-		if paymentEvent, ok := event.(*events.PaymentFailedEvent); ok {
-			payload["payment"] = map[string]interface{}{
-				"id":             paymentEvent.PaymentID.String(),
-				"user_id":        paymentEvent.UserID.String(),
-				"amount":         paymentEvent.Amount,
-				"currency":       paymentEvent.Currency,
-				"failure_reason": paymentEvent.ErrorMessage,
-				"failed_at":      paymentEvent.FailedAt.Format(time.RFC3339),
-			}
+	// Business events carry their versioned payload, encoded by the schema registry
+	if businessEvent, ok := event.(events.BusinessEvent); ok && h.schemas != nil {
+		if err := addSchemaPayload(payload, h.schemas, businessEvent); err != nil {
+			h.logger.Warn("Failed to encode webhook event payload",
+				zap.Error(err),
+				zap.String("event_type", event.Type()),
+				zap.String("event_id", event.ID().String()))
 		}
 	}
 
 	return payload
 }
 
+// addSchemaPayload adds the schema version and payload the registry encodes an event with.
+func addSchemaPayload(payload map[string]interface{}, schemas *events.SchemaRegistry, e events.BusinessEvent) error {
+	encoded, err := schemas.Encode(e)
+	if err != nil {
+		return err
+	}
+
+	var envelope events.Envelope
+	if err := json.Unmarshal(encoded, &envelope); err != nil {
+		return fmt.Errorf("failed to decode event envelope: %w", err)
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal(envelope.Payload, &data); err != nil {
+		return fmt.Errorf("failed to decode event payload: %w", err)
+	}
+
+	payload["schema_version"] = envelope.SchemaVersion
+	payload["payload"] = data
+	return nil
+}
+
 // buildWebhookHeaders creates the headers for the webhook request
 func (h *WebhookEventHandler) buildWebhookHeaders(config *models.WebhookConfig, event event.Event) map[string]string {
 	headers := make(map[string]string)
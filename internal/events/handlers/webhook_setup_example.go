@@ -5,6 +5,7 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/edsonmichaque/bazaruto/internal/events"
 	"github.com/edsonmichaque/bazaruto/internal/logger"
 	"github.com/edsonmichaque/bazaruto/internal/models"
 	"github.com/edsonmichaque/bazaruto/internal/services"
@@ -19,7 +20,7 @@ func SetupWebhookSystem(
 	logger *logger.Logger,
 ) error {
 	// Create the webhook event handler
-	webhookHandler := NewWebhookEventHandler(webhookService, dispatcher, logger)
+	webhookHandler := NewWebhookEventHandler(webhookService, dispatcher, events.NewDefaultSchemaRegistry(), logger)
 
 	// Subscribe the webhook handler to multiple event types
 	eventTypes := []string{
@@ -0,0 +1,50 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InvoiceCreatedEvent is published when an invoice is generated.
+type InvoiceCreatedEvent struct {
+	*BaseBusinessEvent
+	InvoiceID     uuid.UUID `json:"invoice_id"`
+	InvoiceNumber string    `json:"invoice_number"`
+	UserID        uuid.UUID `json:"user_id"`
+	PolicyID      uuid.UUID `json:"policy_id"`
+	Reason        string    `json:"reason"`
+	Amount        float64   `json:"amount"`
+	Tax           float64   `json:"tax"`
+	Total         float64   `json:"total"`
+	Currency      string    `json:"currency"`
+	DueDate       time.Time `json:"due_date"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// NewInvoiceCreatedEvent creates a new invoice created event.
+func NewInvoiceCreatedEvent(invoiceID uuid.UUID, invoiceNumber string, userID, policyID uuid.UUID, reason string, amount, tax, total float64, currency string, dueDate, createdAt time.Time) *InvoiceCreatedEvent {
+	event := &InvoiceCreatedEvent{
+		BaseBusinessEvent: &BaseBusinessEvent{
+			EventID:       uuid.New(),
+			EventType:     "invoice.created",
+			EntityID:      invoiceID,
+			EntityType:    "invoice",
+			Timestamp:     time.Now(),
+			EventVersion:  "1.0",
+			EventMetadata: make(map[string]interface{}),
+		},
+		InvoiceID:     invoiceID,
+		InvoiceNumber: invoiceNumber,
+		UserID:        userID,
+		PolicyID:      policyID,
+		Reason:        reason,
+		Amount:        amount,
+		Tax:           tax,
+		Total:         total,
+		Currency:      currency,
+		DueDate:       dueDate,
+		CreatedAt:     createdAt,
+	}
+	return event
+}
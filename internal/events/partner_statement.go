@@ -0,0 +1,42 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PartnerStatementGeneratedEvent is published when a partner commission statement is generated.
+type PartnerStatementGeneratedEvent struct {
+	*BaseBusinessEvent
+	StatementID    uuid.UUID `json:"statement_id"`
+	PartnerID      uuid.UUID `json:"partner_id"`
+	PeriodStart    time.Time `json:"period_start"`
+	PeriodEnd      time.Time `json:"period_end"`
+	ClosingBalance float64   `json:"closing_balance"`
+	Currency       string    `json:"currency"`
+	GeneratedAt    time.Time `json:"generated_at"`
+}
+
+// NewPartnerStatementGeneratedEvent creates a new partner statement generated event.
+func NewPartnerStatementGeneratedEvent(statementID, partnerID uuid.UUID, periodStart, periodEnd time.Time, closingBalance float64, currency string, generatedAt time.Time) *PartnerStatementGeneratedEvent {
+	event := &PartnerStatementGeneratedEvent{
+		BaseBusinessEvent: &BaseBusinessEvent{
+			EventID:       uuid.New(),
+			EventType:     "statement.generated",
+			EntityID:      statementID,
+			EntityType:    "partner_statement",
+			Timestamp:     time.Now(),
+			EventVersion:  "1.0",
+			EventMetadata: make(map[string]interface{}),
+		},
+		StatementID:    statementID,
+		PartnerID:      partnerID,
+		PeriodStart:    periodStart,
+		PeriodEnd:      periodEnd,
+		ClosingBalance: closingBalance,
+		Currency:       currency,
+		GeneratedAt:    generatedAt,
+	}
+	return event
+}
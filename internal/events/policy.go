@@ -18,10 +18,14 @@ type PolicyCreatedEvent struct {
 	EffectiveDate  time.Time `json:"effective_date"`
 	ExpirationDate time.Time `json:"expiration_date"`
 	CreatedAt      time.Time `json:"created_at"`
+
+	// Added in version 2.0.
+	PaymentFrequency string `json:"payment_frequency"`
+	Jurisdiction     string `json:"jurisdiction,omitempty"`
 }
 
-// NewPolicyCreatedEvent creates a new policy created event.
-func NewPolicyCreatedEvent(policyID, userID, quoteID, productID uuid.UUID, premium float64, currency string, effectiveDate, expirationDate, createdAt time.Time) *PolicyCreatedEvent {
+// NewPolicyCreatedEvent creates a new policy created event at the current schema version.
+func NewPolicyCreatedEvent(policyID, userID, quoteID, productID uuid.UUID, premium float64, currency, paymentFrequency, jurisdiction string, effectiveDate, expirationDate, createdAt time.Time) *PolicyCreatedEvent {
 	event := &PolicyCreatedEvent{
 		BaseBusinessEvent: &BaseBusinessEvent{
 			EventID:       uuid.New(),
@@ -29,7 +33,7 @@ func NewPolicyCreatedEvent(policyID, userID, quoteID, productID uuid.UUID, premi
 			EntityID:      policyID,
 			EntityType:    "policy",
 			Timestamp:     time.Now(),
-			EventVersion:  "1.0",
+			EventVersion:  EventVersionV2,
 			EventMetadata: make(map[string]interface{}),
 		},
 		PolicyID:       policyID,
@@ -41,10 +45,24 @@ func NewPolicyCreatedEvent(policyID, userID, quoteID, productID uuid.UUID, premi
 		EffectiveDate:  effectiveDate,
 		ExpirationDate: expirationDate,
 		CreatedAt:      createdAt,
+
+		PaymentFrequency: paymentFrequency,
+		Jurisdiction:     jurisdiction,
 	}
 	return event
 }
 
+// upgradePolicyCreatedV2 defaults the fields a version 1.0 payload does not carry.
+func upgradePolicyCreatedV2(e BusinessEvent) {
+	policyEvent, ok := e.(*PolicyCreatedEvent)
+	if !ok {
+		return
+	}
+	if policyEvent.PaymentFrequency == "" {
+		policyEvent.PaymentFrequency = "monthly"
+	}
+}
+
 // PolicyRenewedEvent is published when a policy is renewed.
 type PolicyRenewedEvent struct {
 	*BaseBusinessEvent
@@ -192,6 +210,13 @@ type RenewalReminderEvent struct {
 	DaysUntilExpiry int       `json:"days_until_expiry"`
 	ExpirationDate  time.Time `json:"expiration_date"`
 	ReminderSentAt  time.Time `json:"reminder_sent_at"`
+
+	// Renewal offer attached to the reminder, when one was generated.
+	OfferQuoteID    *uuid.UUID `json:"offer_quote_id,omitempty"`
+	OfferPremium    float64    `json:"offer_premium,omitempty"`
+	OfferCurrency   string     `json:"offer_currency,omitempty"`
+	OfferValidUntil *time.Time `json:"offer_valid_until,omitempty"`
+	OfferURL        string     `json:"offer_url,omitempty"`
 }
 
 // NewRenewalReminderEvent creates a new renewal reminder event.
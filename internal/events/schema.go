@@ -0,0 +1,235 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Schema registry errors.
+var (
+	ErrUnknownEventSchema       = errors.New("unknown event schema")
+	ErrUnsupportedSchemaVersion = errors.New("unsupported event schema version")
+)
+
+// EventSchema describes one version of an event type's payload.
+type EventSchema struct {
+	Type    string
+	Version string
+	// New returns an empty event to decode a payload of this type into.
+	New func() BusinessEvent
+	// Upgrade sets the fields this version introduced when the payload was written by an
+	// earlier version. It is optional for versions that only add fields whose zero value
+	// is the right default.
+	Upgrade func(BusinessEvent)
+}
+
+// Envelope is the wire format of a published event: the event type and the schema
+// version its payload was written with, followed by the payload itself.
+type Envelope struct {
+	Type          string          `json:"type"`
+	SchemaVersion string          `json:"schema_version"`
+	Payload       json.RawMessage `json:"payload"`
+}
+
+// SchemaRegistry holds the known payload versions of each event type so producers and
+// consumers on different versions can exchange events. Versions are registered oldest
+// first; a consumer decodes any payload into the version it understands, with fields
+// added since the payload's version defaulted and fields it does not know ignored.
+type SchemaRegistry struct {
+	mu      sync.RWMutex
+	schemas map[string][]EventSchema
+}
+
+// NewSchemaRegistry creates an empty schema registry.
+func NewSchemaRegistry() *SchemaRegistry {
+	return &SchemaRegistry{schemas: make(map[string][]EventSchema)}
+}
+
+// NewDefaultSchemaRegistry creates a schema registry with every published event type
+// registered at each of its versions.
+func NewDefaultSchemaRegistry() *SchemaRegistry {
+	r := NewSchemaRegistry()
+	v1 := map[string]func() BusinessEvent{
+		EventTypeUserRegistered:        func() BusinessEvent { return &UserRegisteredEvent{} },
+		EventTypeUserLoggedIn:          func() BusinessEvent { return &UserLoggedInEvent{} },
+		EventTypeQuoteCreated:          func() BusinessEvent { return &QuoteCreatedEvent{} },
+		EventTypeQuoteCalculated:       func() BusinessEvent { return &QuoteCalculatedEvent{} },
+		EventTypePaymentInitiated:      func() BusinessEvent { return &PaymentInitiatedEvent{} },
+		EventTypePaymentCompleted:      func() BusinessEvent { return &PaymentCompletedEvent{} },
+		EventTypePaymentFailed:         func() BusinessEvent { return &PaymentFailedEvent{} },
+		EventTypeInvoiceCreated:        func() BusinessEvent { return &InvoiceCreatedEvent{} },
+		EventTypePolicyCreated:         func() BusinessEvent { return &PolicyCreatedEvent{} },
+		EventTypePolicyRenewed:         func() BusinessEvent { return &PolicyRenewedEvent{} },
+		EventTypePolicyCancelled:       func() BusinessEvent { return &PolicyCancelledEvent{} },
+		EventTypePolicyExpired:         func() BusinessEvent { return &PolicyExpiredEvent{} },
+		"policy.grace_period_expired":  func() BusinessEvent { return &GracePeriodExpiredEvent{} },
+		EventTypePolicyRenewalReminder: func() BusinessEvent { return &RenewalReminderEvent{} },
+		EventTypeClaimSubmitted:        func() BusinessEvent { return &ClaimSubmittedEvent{} },
+		EventTypeClaimReserveChanged:   func() BusinessEvent { return &ClaimReserveChangedEvent{} },
+		EventTypeClaimRecoveryReceived: func() BusinessEvent { return &ClaimRecoveryReceivedEvent{} },
+		EventTypeClaimStageChanged:     func() BusinessEvent { return &ClaimStageChangedEvent{} },
+		EventTypeAppealFiled:           func() BusinessEvent { return &AppealFiledEvent{} },
+		EventTypeAppealResolved:        func() BusinessEvent { return &AppealResolvedEvent{} },
+		EventTypeStatementGenerated:    func() BusinessEvent { return &PartnerStatementGeneratedEvent{} },
+		"fraud.analysis_completed":     func() BusinessEvent { return &FraudAnalysisCompletedEvent{} },
+	}
+	for eventType, newEvent := range v1 {
+		_ = r.Register(EventSchema{Type: eventType, Version: EventVersionV1, New: newEvent})
+	}
+
+	_ = r.Register(EventSchema{
+		Type:    EventTypePolicyCreated,
+		Version: EventVersionV2,
+		New:     v1[EventTypePolicyCreated],
+		Upgrade: upgradePolicyCreatedV2,
+	})
+
+	return r
+}
+
+// Register adds a version of an event type. Versions of a type must be registered
+// oldest first.
+func (r *SchemaRegistry) Register(schema EventSchema) error {
+	if schema.Type == "" || schema.Version == "" || schema.New == nil {
+		return fmt.Errorf("event schema requires a type, version and constructor")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.schemas[schema.Type] {
+		if existing.Version == schema.Version {
+			return fmt.Errorf("event schema %s version %s is already registered", schema.Type, schema.Version)
+		}
+	}
+	r.schemas[schema.Type] = append(r.schemas[schema.Type], schema)
+	return nil
+}
+
+// Versions returns the registered versions of an event type, oldest first.
+func (r *SchemaRegistry) Versions(eventType string) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	versions := make([]string, 0, len(r.schemas[eventType]))
+	for _, schema := range r.schemas[eventType] {
+		versions = append(versions, schema.Version)
+	}
+	return versions
+}
+
+// Negotiate returns the newest registered version of an event type that the consumer
+// supports, given the versions it accepts.
+func (r *SchemaRegistry) Negotiate(eventType string, accepted ...string) (string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	schemas := r.schemas[eventType]
+	if len(schemas) == 0 {
+		return "", fmt.Errorf("%w: %s", ErrUnknownEventSchema, eventType)
+	}
+	for i := len(schemas) - 1; i >= 0; i-- {
+		for _, version := range accepted {
+			if schemas[i].Version == version {
+				return version, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("%w: %s accepts none of %v", ErrUnsupportedSchemaVersion, eventType, accepted)
+}
+
+// Encode wraps an event in an envelope stamped with the event's schema version.
+func (r *SchemaRegistry) Encode(e BusinessEvent) ([]byte, error) {
+	version := e.GetVersion()
+	if _, err := r.schemaIndex(e.GetEventType(), version); err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode event payload: %w", err)
+	}
+	return json.Marshal(Envelope{
+		Type:          e.GetEventType(),
+		SchemaVersion: version,
+		Payload:       payload,
+	})
+}
+
+// Decode reads an envelope and decodes its payload as the given schema version of its
+// event type. A payload written by an older version has the fields added since upgraded
+// to their defaults; fields the decoded event type does not declare are ignored.
+func (r *SchemaRegistry) Decode(data []byte, version string) (BusinessEvent, error) {
+	var envelope Envelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, fmt.Errorf("failed to decode event envelope: %w", err)
+	}
+
+	target, err := r.schemaIndex(envelope.Type, version)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	schemas := r.schemas[envelope.Type]
+	r.mu.RUnlock()
+
+	e := schemas[target].New()
+	allocateBase(e)
+	if err := json.Unmarshal(envelope.Payload, e); err != nil {
+		return nil, fmt.Errorf("failed to decode %s payload: %w", envelope.Type, err)
+	}
+
+	// A payload from a version this registry does not know is newer than any registered
+	// version, so there is nothing to upgrade.
+	if source, err := r.schemaIndex(envelope.Type, envelope.SchemaVersion); err == nil {
+		for i := source + 1; i <= target; i++ {
+			if schemas[i].Upgrade != nil {
+				schemas[i].Upgrade(e)
+			}
+		}
+	}
+	if versioned, ok := e.(interface{ setVersion(string) }); ok {
+		versioned.setVersion(version)
+	}
+	return e, nil
+}
+
+// allocateBase allocates the embedded *BaseBusinessEvent of an event to decode into, so a
+// payload that carries none of the base fields still yields an event whose accessors and
+// version can be used.
+func allocateBase(e BusinessEvent) {
+	v := reflect.ValueOf(e)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return
+	}
+	base := v.Elem().FieldByName("BaseBusinessEvent")
+	if base.IsValid() && base.Kind() == reflect.Pointer && base.IsNil() && base.CanSet() {
+		base.Set(reflect.New(base.Type().Elem()))
+	}
+}
+
+// setVersion records the schema version an event was decoded as.
+func (e *BaseBusinessEvent) setVersion(version string) {
+	e.EventVersion = version
+}
+
+// schemaIndex returns the position of a version among its event type's registered versions.
+func (r *SchemaRegistry) schemaIndex(eventType, version string) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	schemas, ok := r.schemas[eventType]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrUnknownEventSchema, eventType)
+	}
+	for i, schema := range schemas {
+		if schema.Version == version {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %s version %s", ErrUnsupportedSchemaVersion, eventType, version)
+}
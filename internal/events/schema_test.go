@@ -0,0 +1,90 @@
+package events
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeV1PolicyCreatedPayloadAsV2(t *testing.T) {
+	registry := NewDefaultSchemaRegistry()
+	policyID := uuid.New()
+
+	// A version 1.0 producer knows nothing of payment frequency or jurisdiction.
+	v1 := NewPolicyCreatedEvent(policyID, uuid.New(), uuid.New(), uuid.New(), 1200, "USD", "", "",
+		time.Now(), time.Now().AddDate(1, 0, 0), time.Now())
+	v1.EventVersion = EventVersionV1
+	data, err := registry.Encode(v1)
+	require.NoError(t, err)
+
+	var envelope Envelope
+	require.NoError(t, json.Unmarshal(data, &envelope))
+	assert.Equal(t, EventTypePolicyCreated, envelope.Type)
+	assert.Equal(t, EventVersionV1, envelope.SchemaVersion)
+
+	version, err := registry.Negotiate(EventTypePolicyCreated, EventVersionV1, EventVersionV2)
+	require.NoError(t, err)
+	assert.Equal(t, EventVersionV2, version)
+
+	decoded, err := registry.Decode(data, version)
+	require.NoError(t, err)
+	policyEvent, ok := decoded.(*PolicyCreatedEvent)
+	require.True(t, ok)
+	assert.Equal(t, policyID, policyEvent.PolicyID)
+	assert.Equal(t, 1200.0, policyEvent.Premium)
+	assert.Equal(t, "monthly", policyEvent.PaymentFrequency)
+	assert.Empty(t, policyEvent.Jurisdiction)
+	assert.Equal(t, EventVersionV2, policyEvent.GetVersion())
+}
+
+func TestDecodeV2PolicyCreatedPayloadAsV1(t *testing.T) {
+	registry := NewDefaultSchemaRegistry()
+
+	v2 := NewPolicyCreatedEvent(uuid.New(), uuid.New(), uuid.New(), uuid.New(), 300, "EUR", "annually", "PT",
+		time.Now(), time.Now().AddDate(1, 0, 0), time.Now())
+	data, err := registry.Encode(v2)
+	require.NoError(t, err)
+
+	decoded, err := registry.Decode(data, EventVersionV1)
+	require.NoError(t, err)
+	assert.Equal(t, v2.PolicyID, decoded.(*PolicyCreatedEvent).PolicyID)
+	assert.Equal(t, EventVersionV1, decoded.GetVersion())
+}
+
+func TestDecodeRejectsUnknownSchemas(t *testing.T) {
+	registry := NewDefaultSchemaRegistry()
+
+	_, err := registry.Decode([]byte(`{"type":"policy.unknown","schema_version":"1.0","payload":{}}`), EventVersionV1)
+	assert.ErrorIs(t, err, ErrUnknownEventSchema)
+
+	_, err = registry.Decode([]byte(`{"type":"policy.created","schema_version":"1.0","payload":{}}`), "3.0")
+	assert.ErrorIs(t, err, ErrUnsupportedSchemaVersion)
+
+	_, err = registry.Negotiate(EventTypeQuoteCreated, EventVersionV2)
+	assert.ErrorIs(t, err, ErrUnsupportedSchemaVersion)
+
+	err = registry.Register(EventSchema{
+		Type:    EventTypePolicyCreated,
+		Version: EventVersionV1,
+		New:     func() BusinessEvent { return &PolicyCreatedEvent{} },
+	})
+	assert.Error(t, err)
+}
+
+func TestDecodePayloadWithoutBaseFields(t *testing.T) {
+	registry := NewDefaultSchemaRegistry()
+	policyID := uuid.New()
+
+	data := []byte(`{"type":"policy.created","schema_version":"1.0","payload":{"policy_id":"` + policyID.String() + `"}}`)
+	decoded, err := registry.Decode(data, EventVersionV2)
+	require.NoError(t, err)
+
+	policyEvent := decoded.(*PolicyCreatedEvent)
+	assert.Equal(t, policyID, policyEvent.PolicyID)
+	assert.Equal(t, "monthly", policyEvent.PaymentFrequency)
+	assert.Equal(t, EventVersionV2, decoded.GetVersion())
+}
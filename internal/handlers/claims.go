@@ -1,10 +1,12 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/edsonmichaque/bazaruto/internal/models"
 	"github.com/edsonmichaque/bazaruto/internal/services"
+	"github.com/edsonmichaque/bazaruto/internal/store"
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 )
@@ -12,6 +14,7 @@ import (
 // ClaimHandler handles HTTP requests for claims.
 type ClaimHandler struct {
 	service *services.ClaimService
+	clients *ClientResolver
 }
 
 // NewClaimHandler creates a new ClaimHandler.
@@ -73,6 +76,12 @@ func (h *ClaimHandler) ListClaims(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// SetClientResolver sets the resolver used to capture submission metadata. Without one,
+// only the connection address is recorded and forwarding headers are ignored.
+func (h *ClaimHandler) SetClientResolver(clients *ClientResolver) {
+	h.clients = clients
+}
+
 // GetClaim handles GET /v1/claims/{id}.
 func (h *ClaimHandler) GetClaim(w http.ResponseWriter, r *http.Request) {
 	// Parse claim ID
@@ -136,6 +145,13 @@ func (h *ClaimHandler) CreateClaim(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Capture submission metadata for fraud analysis from the connection; values in the
+	// body are the claimant's own and are discarded.
+	claim.SubmissionIP = h.clients.IP(r)
+	claim.SubmissionCountry = h.clients.Country(r)
+	claim.DeviceFingerprint = h.clients.DeviceFingerprint(r)
+	claim.UserAgent = r.UserAgent()
+
 	// Create claim
 	if err := h.service.CreateClaim(r.Context(), &claim); err != nil {
 		_ = writeValidationError(w, err.Error())
@@ -170,10 +186,14 @@ func (h *ClaimHandler) UpdateClaim(w http.ResponseWriter, r *http.Request) {
 
 	// Update claim
 	if err := h.service.UpdateClaim(r.Context(), &claim); err != nil {
-		if err.Error() == "claim not found" {
+		if err.Error() == "claim not found" || errors.Is(err, store.ErrNotFound) {
 			_ = writeNotFound(w, "Claim")
 			return
 		}
+		if errors.Is(err, store.ErrVersionConflict) {
+			_ = writeError(w, http.StatusConflict, "Claim was modified by another request")
+			return
+		}
 		_ = writeValidationError(w, err.Error())
 		return
 	}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/edsonmichaque/bazaruto/internal/config"
+)
+
+// ClientResolver derives request metadata from the connection rather than from anything
+// the client sends in the body. Forwarding headers are honoured only when the request
+// arrives from a configured trusted proxy.
+type ClientResolver struct {
+	trustedProxies []*net.IPNet
+	countryHeader  string
+}
+
+// NewClientResolver creates a client resolver from the server configuration.
+func NewClientResolver(cfg config.ServerConfig) (*ClientResolver, error) {
+	resolver := &ClientResolver{countryHeader: cfg.CountryHeader}
+	for _, cidr := range cfg.TrustedProxies {
+		if !strings.Contains(cidr, "/") {
+			if ip := net.ParseIP(cidr); ip != nil && ip.To4() != nil {
+				cidr += "/32"
+			} else {
+				cidr += "/128"
+			}
+		}
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", cidr, err)
+		}
+		resolver.trustedProxies = append(resolver.trustedProxies, network)
+	}
+	return resolver, nil
+}
+
+// IP returns the originating client IP address of a request. X-Forwarded-For is walked
+// from the right, skipping trusted proxies, so a client cannot prepend its own address.
+func (c *ClientResolver) IP(r *http.Request) string {
+	remote := remoteIP(r)
+	if !c.trusted(remote) {
+		return remote
+	}
+
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+		hops := strings.Split(forwarded, ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop := strings.TrimSpace(hops[i])
+			if net.ParseIP(hop) == nil {
+				break
+			}
+			if !c.trusted(hop) {
+				return hop
+			}
+			remote = hop
+		}
+	}
+	return remote
+}
+
+// Country returns the ISO country code of the client as reported by a trusted proxy, or
+// an empty string when the request did not come through one.
+func (c *ClientResolver) Country(r *http.Request) string {
+	if c == nil || c.countryHeader == "" || !c.trusted(remoteIP(r)) {
+		return ""
+	}
+	return strings.ToUpper(strings.TrimSpace(r.Header.Get(c.countryHeader)))
+}
+
+// DeviceFingerprint returns a fingerprint of the client computed from the connection
+// and the headers its user agent sends on every request.
+func (c *ClientResolver) DeviceFingerprint(r *http.Request) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		c.IP(r),
+		r.UserAgent(),
+		r.Header.Get("Accept-Language"),
+		r.Header.Get("Accept-Encoding"),
+	}, "|")))
+	return hex.EncodeToString(sum[:16])
+}
+
+// trusted reports whether an address belongs to a configured trusted proxy.
+func (c *ClientResolver) trusted(addr string) bool {
+	if c == nil {
+		return false
+	}
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, network := range c.trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// remoteIP returns the address of the peer the request was received from.
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
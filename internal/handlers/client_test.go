@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientResolverHonoursForwardingOnlyFromTrustedProxies(t *testing.T) {
+	clients, err := NewClientResolver(config.ServerConfig{
+		TrustedProxies: []string{"10.0.0.0/8"},
+		CountryHeader:  "X-Client-Country",
+	})
+	require.NoError(t, err)
+
+	direct := httptest.NewRequest("POST", "/v1/claims", nil)
+	direct.RemoteAddr = "203.0.113.7:4242"
+	direct.Header.Set("X-Forwarded-For", "198.51.100.1")
+	direct.Header.Set("X-Client-Country", "PT")
+	assert.Equal(t, "203.0.113.7", clients.IP(direct))
+	assert.Empty(t, clients.Country(direct))
+
+	proxied := httptest.NewRequest("POST", "/v1/claims", nil)
+	proxied.RemoteAddr = "10.1.2.3:4242"
+	proxied.Header.Set("X-Forwarded-For", "198.51.100.1, 203.0.113.7, 10.4.5.6")
+	proxied.Header.Set("X-Client-Country", "mz")
+	assert.Equal(t, "203.0.113.7", clients.IP(proxied), "a client-supplied first hop is not trusted")
+	assert.Equal(t, "MZ", clients.Country(proxied))
+
+	var none *ClientResolver
+	assert.Equal(t, "203.0.113.7", none.IP(direct))
+	assert.Empty(t, none.Country(direct))
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"errors"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/edsonmichaque/bazaruto/internal/services"
+	"github.com/go-chi/chi/v5"
+)
+
+// DocumentHandler serves generated documents, such as quote PDFs and claim letters, from
+// the blob store they were written to.
+type DocumentHandler struct {
+	blobStore services.BlobStore
+}
+
+// NewDocumentHandler creates a new DocumentHandler.
+func NewDocumentHandler(blobStore services.BlobStore) *DocumentHandler {
+	return &DocumentHandler{
+		blobStore: blobStore,
+	}
+}
+
+// GetDocument handles GET {base}/*, where the rest of the path is the blob key.
+func (h *DocumentHandler) GetDocument(w http.ResponseWriter, r *http.Request) {
+	key := chi.URLParam(r, "*")
+	data, err := h.blobStore.Get(r.Context(), key)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidBlobKey) || errors.Is(err, fs.ErrNotExist) {
+			_ = writeNotFound(w, "Document")
+			return
+		}
+		_ = writeInternalError(w, err)
+		return
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = http.DetectContentType(data)
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": path.Base(key)}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+// RegisterRoutes registers the document route below basePath, the path of the download URLs
+// the blob store hands out. Nothing is registered when documents are served from another
+// host.
+func (h *DocumentHandler) RegisterRoutes(r chi.Router, basePath string) {
+	if !strings.HasPrefix(basePath, "/") {
+		return
+	}
+	r.Get(strings.TrimSuffix(basePath, "/")+"/*", h.GetDocument)
+}
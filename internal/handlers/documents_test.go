@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/edsonmichaque/bazaruto/internal/services"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentHandlerServesStoredDocuments(t *testing.T) {
+	blobStore := services.NewLocalBlobStore(t.TempDir(), "/documents")
+	url, err := blobStore.Put(context.Background(), "quotes/q1/QTE-1.pdf", "application/pdf", []byte("%PDF-1.4"))
+	require.NoError(t, err)
+
+	r := chi.NewRouter()
+	NewDocumentHandler(blobStore).RegisterRoutes(r, "/documents")
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/pdf", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "%PDF-1.4", rec.Body.String())
+
+	for _, missing := range []string{"/documents/quotes/q1/QTE-2.pdf", "/documents/quotes/../../etc/passwd"} {
+		rec = httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, missing, nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code, missing)
+	}
+}
@@ -1,17 +1,21 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/edsonmichaque/bazaruto/internal/models"
 	"github.com/edsonmichaque/bazaruto/internal/services"
+	"github.com/edsonmichaque/bazaruto/internal/store"
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 )
 
 // PolicyHandler handles HTTP requests for policies.
 type PolicyHandler struct {
-	service *services.PolicyService
+	service       *services.PolicyService
+	beneficiaries *services.BeneficiaryService
 }
 
 // NewPolicyHandler creates a new PolicyHandler.
@@ -21,6 +25,12 @@ func NewPolicyHandler(service *services.PolicyService) *PolicyHandler {
 	}
 }
 
+// SetBeneficiaryService sets the service that serves the beneficiaries of policies. Without
+// one the beneficiary routes are not registered.
+func (h *PolicyHandler) SetBeneficiaryService(beneficiaries *services.BeneficiaryService) {
+	h.beneficiaries = beneficiaries
+}
+
 // ListPolicies handles GET /v1/policies.
 func (h *PolicyHandler) ListPolicies(w http.ResponseWriter, r *http.Request) {
 	// Parse pagination parameters
@@ -131,6 +141,10 @@ func (h *PolicyHandler) CreatePolicy(w http.ResponseWriter, r *http.Request) {
 
 	// Create policy
 	if err := h.service.CreatePolicy(r.Context(), &policy); err != nil {
+		if errors.Is(err, services.ErrKYCReverificationRequired) {
+			_ = writeError(w, http.StatusForbidden, err.Error())
+			return
+		}
 		_ = writeValidationError(w, err.Error())
 		return
 	}
@@ -160,10 +174,14 @@ func (h *PolicyHandler) UpdatePolicy(w http.ResponseWriter, r *http.Request) {
 
 	// Update policy
 	if err := h.service.UpdatePolicy(r.Context(), &policy); err != nil {
-		if err.Error() == "policy not found" {
+		if err.Error() == "policy not found" || errors.Is(err, store.ErrNotFound) {
 			_ = writeNotFound(w, "Policy")
 			return
 		}
+		if errors.Is(err, store.ErrVersionConflict) {
+			_ = writeError(w, http.StatusConflict, "Policy was modified by another request")
+			return
+		}
 		_ = writeValidationError(w, err.Error())
 		return
 	}
@@ -195,6 +213,65 @@ func (h *PolicyHandler) DeletePolicy(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// ListBeneficiaries handles GET /v1/policies/{id}/beneficiaries.
+func (h *PolicyHandler) ListBeneficiaries(w http.ResponseWriter, r *http.Request) {
+	// Parse policy ID
+	policyID, err := paramUUID(r, "id")
+	if err != nil {
+		_ = writeValidationError(w, "Invalid policy ID")
+		return
+	}
+
+	// Get beneficiaries
+	beneficiaries, err := h.beneficiaries.ListPolicyBeneficiaries(r.Context(), policyID)
+	if err != nil {
+		if strings.Contains(err.Error(), "policy not found") {
+			_ = writeNotFound(w, "Policy")
+			return
+		}
+		_ = writeInternalError(w, err)
+		return
+	}
+
+	// Write response
+	_ = WriteJSONIgnoreError(w, http.StatusOK, beneficiaries)
+}
+
+// DesignateBeneficiaries handles PUT /v1/policies/{id}/beneficiaries. The designation in the
+// body replaces the policy's beneficiaries.
+func (h *PolicyHandler) DesignateBeneficiaries(w http.ResponseWriter, r *http.Request) {
+	// Parse policy ID
+	policyID, err := paramUUID(r, "id")
+	if err != nil {
+		_ = writeValidationError(w, "Invalid policy ID")
+		return
+	}
+
+	// Parse request body
+	var beneficiaries []*models.Beneficiary
+	if err := parseJSON(r, &beneficiaries); err != nil {
+		_ = writeValidationError(w, "Invalid JSON body")
+		return
+	}
+
+	// Designate beneficiaries
+	if err := h.beneficiaries.DesignateBeneficiaries(r.Context(), policyID, beneficiaries); err != nil {
+		if strings.Contains(err.Error(), "policy not found") {
+			_ = writeNotFound(w, "Policy")
+			return
+		}
+		if errors.Is(err, services.ErrInvalidBeneficiaryDesignation) {
+			_ = writeValidationError(w, err.Error())
+			return
+		}
+		_ = writeInternalError(w, err)
+		return
+	}
+
+	// Write response
+	_ = WriteJSONIgnoreError(w, http.StatusOK, beneficiaries)
+}
+
 // RegisterRoutes registers policy routes with the router.
 func (h *PolicyHandler) RegisterRoutes(r chi.Router) {
 	r.Route("/policies", func(r chi.Router) {
@@ -204,5 +281,9 @@ func (h *PolicyHandler) RegisterRoutes(r chi.Router) {
 		r.Put("/{id}", h.UpdatePolicy)
 		r.Delete("/{id}", h.DeletePolicy)
 		r.Get("/number/{number}", h.GetPolicyByNumber)
+		if h.beneficiaries != nil {
+			r.Get("/{id}/beneficiaries", h.ListBeneficiaries)
+			r.Put("/{id}/beneficiaries", h.DesignateBeneficiaries)
+		}
 	})
 }
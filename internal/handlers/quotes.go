@@ -1,7 +1,9 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/edsonmichaque/bazaruto/internal/models"
 	"github.com/edsonmichaque/bazaruto/internal/services"
@@ -11,7 +13,8 @@ import (
 
 // QuoteHandler handles HTTP requests for quotes.
 type QuoteHandler struct {
-	service *services.QuoteService
+	service  *services.QuoteService
+	renewals *services.PolicyLifecycleService
 }
 
 // NewQuoteHandler creates a new QuoteHandler.
@@ -21,6 +24,12 @@ func NewQuoteHandler(service *services.QuoteService) *QuoteHandler {
 	}
 }
 
+// SetRenewalService sets the service that renews policies from accepted renewal offers.
+// Without one the accept route is not registered.
+func (h *QuoteHandler) SetRenewalService(renewals *services.PolicyLifecycleService) {
+	h.renewals = renewals
+}
+
 // ListQuotes handles GET /v1/quotes.
 func (h *QuoteHandler) ListQuotes(w http.ResponseWriter, r *http.Request) {
 	// Parse pagination parameters
@@ -230,6 +239,50 @@ func (h *QuoteHandler) ExpireQuote(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// AcceptRenewalOffer handles POST /v1/quotes/{id}/accept. The policy the offer was made for
+// is renewed at the offered price.
+func (h *QuoteHandler) AcceptRenewalOffer(w http.ResponseWriter, r *http.Request) {
+	// Parse quote ID
+	quoteID, err := paramUUID(r, "id")
+	if err != nil {
+		_ = writeValidationError(w, "Invalid quote ID")
+		return
+	}
+
+	// Parse optional request body
+	var acceptance struct {
+		PaymentMethod string `json:"payment_method"`
+	}
+	if r.ContentLength > 0 {
+		if err := parseJSON(r, &acceptance); err != nil {
+			_ = writeValidationError(w, "Invalid JSON body")
+			return
+		}
+	}
+
+	// Renew the policy
+	result, err := h.renewals.AcceptRenewalOffer(r.Context(), quoteID, acceptance.PaymentMethod)
+	if err != nil {
+		if strings.Contains(err.Error(), "quote not found") {
+			_ = writeNotFound(w, "Quote")
+			return
+		}
+		if errors.Is(err, services.ErrRenewalOfferUnavailable) {
+			_ = writeError(w, http.StatusConflict, err.Error())
+			return
+		}
+		_ = writeInternalError(w, err)
+		return
+	}
+	if result.NewPolicyID == nil {
+		_ = writeError(w, http.StatusConflict, result.Message)
+		return
+	}
+
+	// Write response
+	_ = WriteJSONIgnoreError(w, http.StatusCreated, result)
+}
+
 // RegisterRoutes registers quote routes with the router.
 func (h *QuoteHandler) RegisterRoutes(r chi.Router) {
 	r.Route("/quotes", func(r chi.Router) {
@@ -239,6 +292,9 @@ func (h *QuoteHandler) RegisterRoutes(r chi.Router) {
 		r.Put("/{id}", h.UpdateQuote)
 		r.Delete("/{id}", h.DeleteQuote)
 		r.Post("/{id}/expire", h.ExpireQuote)
+		if h.renewals != nil {
+			r.Post("/{id}/accept", h.AcceptRenewalOffer)
+		}
 		r.Get("/number/{number}", h.GetQuoteByNumber)
 	})
 }
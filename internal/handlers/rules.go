@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
@@ -105,6 +106,10 @@ func (h *RulesHandler) UpdateRules(w http.ResponseWriter, r *http.Request) {
 
 	// Update configuration
 	if err := h.configManager.UpdateConfig(r.Context(), &newRules); err != nil {
+		if errors.Is(err, config.ErrConfigVersionConflict) {
+			_ = writeError(w, http.StatusConflict, "Rules changed without a new version: "+err.Error())
+			return
+		}
 		h.logger.Error("Failed to update rules configuration", zap.Error(err))
 		_ = writeInternalError(w, err)
 		return
@@ -124,7 +129,8 @@ func (h *RulesHandler) UpdateRules(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// UpdateRuleSection handles PUT /v1/rules/{section}
+// UpdateRuleSection handles PUT /v1/rules/{section}. The version query parameter sets the
+// version of the resulting configuration; changed rules must be given a new version.
 func (h *RulesHandler) UpdateRuleSection(w http.ResponseWriter, r *http.Request) {
 	// Get section name from URL parameter
 	section := chi.URLParam(r, "section")
@@ -135,6 +141,9 @@ func (h *RulesHandler) UpdateRuleSection(w http.ResponseWriter, r *http.Request)
 
 	// Get current business rules configuration
 	currentRules := h.configManager.GetConfig()
+	if version := queryParam(r, "version"); version != "" {
+		currentRules.Version = version
+	}
 
 	// Parse request body based on section type
 	var sectionData interface{}
@@ -199,6 +208,10 @@ func (h *RulesHandler) UpdateRuleSection(w http.ResponseWriter, r *http.Request)
 
 	// Update configuration
 	if err := h.configManager.UpdateConfig(r.Context(), currentRules); err != nil {
+		if errors.Is(err, config.ErrConfigVersionConflict) {
+			_ = writeError(w, http.StatusConflict, "Rules changed without a new version: "+err.Error())
+			return
+		}
 		h.logger.Error("Failed to update rule section", zap.Error(err), zap.String("section", section))
 		_ = writeInternalError(w, err)
 		return
@@ -235,10 +248,52 @@ func (h *RulesHandler) GetRulesVersion(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// RulesDiffResponse is the result of validating a proposed configuration against the
+// current one.
+type RulesDiffResponse struct {
+	Valid   bool                  `json:"valid"`
+	Errors  []string              `json:"errors,omitempty"`
+	Changes []config.ConfigChange `json:"changes"`
+}
+
+// DiffRules handles POST /v1/rules/diff. It validates the proposed configuration in the
+// request body and lists the fields that differ from the current configuration, without
+// applying it.
+func (h *RulesHandler) DiffRules(w http.ResponseWriter, r *http.Request) {
+	var proposed config.BusinessRulesConfig
+	if err := json.NewDecoder(r.Body).Decode(&proposed); err != nil {
+		_ = writeValidationError(w, "invalid JSON: "+err.Error())
+		return
+	}
+
+	if err := h.configManager.ValidateConfig(&proposed); err != nil {
+		_ = WriteJSON(w, http.StatusBadRequest, RulesDiffResponse{Errors: validationMessages(err)})
+		return
+	}
+
+	changes, err := config.Diff(h.configManager.GetConfig(), &proposed)
+	if err != nil {
+		h.logger.Error("Failed to diff rules configuration", zap.Error(err))
+		_ = writeInternalError(w, err)
+		return
+	}
+
+	_ = WriteJSON(w, http.StatusOK, RulesDiffResponse{Valid: true, Changes: changes})
+}
+
 // ReloadRules handles POST /v1/rules/reload
 func (h *RulesHandler) ReloadRules(w http.ResponseWriter, r *http.Request) {
-	// Reload configuration from file
-	if err := h.configManager.LoadConfig(r.Context()); err != nil {
+	// Reload configuration from file, keeping the current one if the file is invalid
+	if err := h.configManager.ReloadConfig(r.Context()); err != nil {
+		var validationErrs config.ValidationErrors
+		if errors.As(err, &validationErrs) {
+			_ = WriteJSON(w, http.StatusBadRequest, RulesDiffResponse{Errors: validationErrs})
+			return
+		}
+		if errors.Is(err, config.ErrConfigVersionConflict) {
+			_ = writeError(w, http.StatusConflict, "Rules changed without a new version: "+err.Error())
+			return
+		}
 		h.logger.Error("Failed to reload rules configuration", zap.Error(err))
 		_ = writeInternalError(w, err)
 		return
@@ -263,6 +318,15 @@ func (h *RulesHandler) ReloadRules(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// validationMessages returns the individual problems reported by a validation error.
+func validationMessages(err error) []string {
+	var validationErrs config.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		return validationErrs
+	}
+	return []string{err.Error()}
+}
+
 // RegisterRoutes registers rules routes with the router
 func (h *RulesHandler) RegisterRoutes(r chi.Router) {
 	// Rules endpoints
@@ -270,6 +334,7 @@ func (h *RulesHandler) RegisterRoutes(r chi.Router) {
 	r.Put("/rules", h.UpdateRules)
 	r.Get("/rules/version", h.GetRulesVersion)
 	r.Post("/rules/reload", h.ReloadRules)
+	r.Post("/rules/diff", h.DiffRules)
 
 	// Section-specific endpoints
 	r.Get("/rules/{section}", h.GetRuleSection)
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRulesRouter(t *testing.T) (http.Handler, *config.Manager, string) {
+	t.Helper()
+	log := logger.NewLogger("error", "json")
+	path := filepath.Join(t.TempDir(), "business_rules.json")
+	configManager := config.NewManager(log, path)
+	require.NoError(t, configManager.LoadConfig(context.Background()))
+
+	r := chi.NewRouter()
+	NewRulesHandler(configManager, log).RegisterRoutes(r)
+	return r, configManager, path
+}
+
+func postRules(t *testing.T, router http.Handler, path string, body interface{}) (*httptest.ResponseRecorder, RulesDiffResponse) {
+	t.Helper()
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = json.Marshal(body)
+		require.NoError(t, err)
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, bytes.NewReader(payload)))
+
+	var response RulesDiffResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	return rec, response
+}
+
+func TestDiffRulesReportsChangedThreshold(t *testing.T) {
+	router, configManager, _ := newTestRulesRouter(t)
+
+	proposed := configManager.GetConfig()
+	proposed.FraudDetection.RiskThresholds.High = 75
+
+	rec, response := postRules(t, router, "/rules/diff", proposed)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, response.Valid)
+	require.Len(t, response.Changes, 1)
+	assert.Equal(t, "fraud_detection.risk_thresholds.high", response.Changes[0].Path)
+	assert.Equal(t, 80.0, response.Changes[0].Old)
+	assert.Equal(t, 75.0, response.Changes[0].New)
+	assert.Equal(t, 80.0, configManager.GetConfig().FraudDetection.RiskThresholds.High, "a diff must not apply the proposal")
+}
+
+func TestDiffRulesRejectsInvalidProposal(t *testing.T) {
+	router, configManager, _ := newTestRulesRouter(t)
+
+	proposed := configManager.GetConfig()
+	proposed.FraudDetection.RiskThresholds.High = 95 // above critical
+
+	rec, response := postRules(t, router, "/rules/diff", proposed)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.False(t, response.Valid)
+	assert.Equal(t, []string{"fraud_detection.risk_thresholds.critical must not be below high"}, response.Errors)
+}
+
+func TestReloadRulesKeepsCurrentConfigWhenFileIsInvalid(t *testing.T) {
+	router, configManager, path := newTestRulesRouter(t)
+
+	invalid := configManager.GetConfig()
+	invalid.Version = "2.0"
+	invalid.Pricing.RateTableRollout.VariantPercentage = 150
+	data, err := json.Marshal(invalid)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0644))
+
+	rec, response := postRules(t, router, "/rules/reload", nil)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, []string{"pricing.rate_table_rollout.variant_percentage must be between 0 and 100"}, response.Errors)
+	assert.Equal(t, "1.0", configManager.GetConfig().Version)
+}
+
+func TestUpdateRuleSectionRequiresNewVersionForChangedRules(t *testing.T) {
+	router, configManager, _ := newTestRulesRouter(t)
+
+	fraud := configManager.GetConfig().FraudDetection
+	fraud.RiskThresholds.High = 75
+	payload, err := json.Marshal(fraud)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/rules/fraud_detection", bytes.NewReader(payload)))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, 80.0, configManager.GetConfig().FraudDetection.RiskThresholds.High)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/rules/fraud_detection?version=1.1", bytes.NewReader(payload)))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "1.1", configManager.GetConfig().Version)
+	assert.Equal(t, 75.0, configManager.GetConfig().FraudDetection.RiskThresholds.High)
+}
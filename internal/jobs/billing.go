@@ -0,0 +1,93 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/edsonmichaque/bazaruto/internal/services"
+	"github.com/edsonmichaque/bazaruto/pkg/job"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// DefaultOverdueInvoiceInterval is how often the overdue invoice job runs.
+const DefaultOverdueInvoiceInterval = 24 * time.Hour
+
+// ProcessOverdueInvoicesJob represents a recurring job that marks unpaid invoices overdue,
+// reminds customers and suspends policies left unpaid past the overdue grace.
+type ProcessOverdueInvoicesJob struct {
+	ID                  uuid.UUID                     `json:"id"`
+	Interval            time.Duration                 `json:"interval"`
+	InvoiceService      *services.InvoiceService      `json:"-"` // Injected dependency
+	NotificationService *services.NotificationService `json:"-"` // Injected dependency
+	Dispatcher          *job.Dispatcher               `json:"-"` // Injected dependency
+	Logger              *logger.Logger                `json:"-"` // Injected dependency
+	Attempts            int                           `json:"attempts"`
+	RunAtTime           time.Time                     `json:"run_at_time"`
+}
+
+// Perform executes the overdue invoice job and schedules the next run.
+func (j *ProcessOverdueInvoicesJob) Perform(ctx context.Context) error {
+	if j.InvoiceService == nil || j.Logger == nil {
+		return fmt.Errorf("invoice service is not configured")
+	}
+
+	result, err := j.InvoiceService.ProcessOverdueInvoices(ctx, time.Now(), j.sendReminder)
+	if err != nil {
+		j.Logger.Error("Failed to process overdue invoices", zap.Error(err))
+		return fmt.Errorf("failed to process overdue invoices: %w", err)
+	}
+
+	j.Logger.Info("Overdue invoices processed successfully",
+		zap.Int("marked_overdue", result.MarkedOverdue),
+		zap.Int("reminded", result.Reminded),
+		zap.Int("policies_suspended", result.PoliciesSuspended))
+
+	// Schedule the next run
+	if j.Dispatcher != nil && j.Interval > 0 {
+		next := &ProcessOverdueInvoicesJob{Interval: j.Interval}
+		if err := j.Dispatcher.PerformInWithContext(ctx, next, j.Interval); err != nil {
+			j.Logger.Error("Failed to schedule next overdue invoice run", zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+// sendReminder notifies the customer of an overdue invoice on their preferred channels.
+func (j *ProcessOverdueInvoicesJob) sendReminder(ctx context.Context, invoice *models.Invoice) error {
+	if j.NotificationService == nil {
+		return fmt.Errorf("notification service is not configured")
+	}
+
+	_, err := j.NotificationService.Notify(ctx, &services.Notification{
+		Type:   services.NotificationTypeInvoiceOverdue,
+		UserID: invoice.UserID,
+		Data: map[string]interface{}{
+			"InvoiceNumber": invoice.InvoiceNumber,
+			"Amount":        fmt.Sprintf("%.2f", invoice.Total),
+			"Currency":      invoice.Currency,
+			"DueDate":       invoice.DueDate.Format("2006-01-02"),
+		},
+		// One reminder per invoice per day, even if the batch reruns
+		DedupKey: fmt.Sprintf("invoice_overdue_reminder:%s:%s", invoice.ID, time.Now().Format("2006-01-02")),
+	})
+	return err
+}
+
+// ProcessOverdueInvoicesJob interface methods
+func (j *ProcessOverdueInvoicesJob) Queue() string               { return job.QueuePayments }
+func (j *ProcessOverdueInvoicesJob) MaxRetries() int             { return 3 }
+func (j *ProcessOverdueInvoicesJob) RetryBackoff() time.Duration { return time.Minute }
+func (j *ProcessOverdueInvoicesJob) Priority() int               { return 0 }
+func (j *ProcessOverdueInvoicesJob) Type() string                { return "jobs.ProcessOverdueInvoicesJob" }
+func (j *ProcessOverdueInvoicesJob) SetID(id uuid.UUID)          { j.ID = id }
+func (j *ProcessOverdueInvoicesJob) GetID() uuid.UUID            { return j.ID }
+func (j *ProcessOverdueInvoicesJob) SetAttempts(attempts int)    { j.Attempts = attempts }
+func (j *ProcessOverdueInvoicesJob) GetAttempts() int            { return j.Attempts }
+func (j *ProcessOverdueInvoicesJob) SetRunAt(t time.Time)        { j.RunAtTime = t }
+func (j *ProcessOverdueInvoicesJob) GetRunAt() time.Time         { return j.RunAtTime }
+func (j *ProcessOverdueInvoicesJob) Timeout() time.Duration      { return 0 } // Use the worker's configured timeout
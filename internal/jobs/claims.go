@@ -82,7 +82,7 @@ func (j *FraudDetectionJob) SetAttempts(attempts int)    { j.Attempts = attempts
 func (j *FraudDetectionJob) GetAttempts() int            { return j.Attempts }
 func (j *FraudDetectionJob) SetRunAt(t time.Time)        { j.RunAtTime = t }
 func (j *FraudDetectionJob) GetRunAt() time.Time         { return j.RunAtTime }
-func (j *FraudDetectionJob) Timeout() time.Duration      { return job.DefaultTimeout }
+func (j *FraudDetectionJob) Timeout() time.Duration      { return 0 } // Use the worker's configured timeout
 
 // SettleClaimPayoutJob interface methods
 func (j *SettleClaimPayoutJob) Queue() string               { return job.QueuePayments }
@@ -96,4 +96,4 @@ func (j *SettleClaimPayoutJob) SetAttempts(attempts int)    { j.Attempts = attem
 func (j *SettleClaimPayoutJob) GetAttempts() int            { return j.Attempts }
 func (j *SettleClaimPayoutJob) SetRunAt(t time.Time)        { j.RunAtTime = t }
 func (j *SettleClaimPayoutJob) GetRunAt() time.Time         { return j.RunAtTime }
-func (j *SettleClaimPayoutJob) Timeout() time.Duration      { return job.DefaultTimeout }
+func (j *SettleClaimPayoutJob) Timeout() time.Duration      { return 0 } // Use the worker's configured timeout
@@ -0,0 +1,60 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/services"
+	"github.com/edsonmichaque/bazaruto/pkg/job"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// RecordPolicyCommissionsJob represents a job that records the commissions earned on a
+// paid policy. It runs apart from the payment's other follow-up work, so a failure retries
+// only the commission step.
+type RecordPolicyCommissionsJob struct {
+	ID                uuid.UUID                   `json:"id"`
+	PolicyID          uuid.UUID                   `json:"policy_id"`
+	CommissionService *services.CommissionService `json:"-"` // Injected dependency
+	Logger            *logger.Logger              `json:"-"` // Injected dependency
+	Attempts          int                         `json:"attempts"`
+	RunAtTime         time.Time                   `json:"run_at_time"`
+}
+
+// Perform executes the commission recording job.
+func (j *RecordPolicyCommissionsJob) Perform(ctx context.Context) error {
+	if j.CommissionService == nil || j.Logger == nil {
+		return fmt.Errorf("commission service is not configured")
+	}
+
+	commissions, err := j.CommissionService.RecordPolicyCommissions(ctx, j.PolicyID)
+	if err != nil {
+		j.Logger.Error("Failed to record policy commissions",
+			zap.Error(err),
+			zap.String("policy_id", j.PolicyID.String()))
+		return fmt.Errorf("failed to record policy commissions: %w", err)
+	}
+
+	j.Logger.Info("Policy commissions recorded",
+		zap.String("policy_id", j.PolicyID.String()),
+		zap.Int("commissions", len(commissions)))
+
+	return nil
+}
+
+// RecordPolicyCommissionsJob interface methods
+func (j *RecordPolicyCommissionsJob) Queue() string               { return job.QueuePayments }
+func (j *RecordPolicyCommissionsJob) MaxRetries() int             { return 5 }
+func (j *RecordPolicyCommissionsJob) RetryBackoff() time.Duration { return 30 * time.Second }
+func (j *RecordPolicyCommissionsJob) Priority() int               { return 1 }
+func (j *RecordPolicyCommissionsJob) Type() string                { return "jobs.RecordPolicyCommissionsJob" }
+func (j *RecordPolicyCommissionsJob) SetID(id uuid.UUID)          { j.ID = id }
+func (j *RecordPolicyCommissionsJob) GetID() uuid.UUID            { return j.ID }
+func (j *RecordPolicyCommissionsJob) SetAttempts(attempts int)    { j.Attempts = attempts }
+func (j *RecordPolicyCommissionsJob) GetAttempts() int            { return j.Attempts }
+func (j *RecordPolicyCommissionsJob) SetRunAt(t time.Time)        { j.RunAtTime = t }
+func (j *RecordPolicyCommissionsJob) GetRunAt() time.Time         { return j.RunAtTime }
+func (j *RecordPolicyCommissionsJob) Timeout() time.Duration      { return 0 } // Use the worker's configured timeout
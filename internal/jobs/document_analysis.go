@@ -0,0 +1,78 @@
+package jobs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/models"
+)
+
+// HTTPDocumentAnalyzer extracts claim document fields by posting the document to an
+// extraction endpoint, such as an OCR service. The endpoint responds with the extracted
+// document metadata.
+type HTTPDocumentAnalyzer struct {
+	Endpoint string
+	APIKey   string
+	Client   *http.Client
+}
+
+// NewHTTPDocumentAnalyzer creates an analyzer for endpoint with the given request timeout.
+func NewHTTPDocumentAnalyzer(endpoint, apiKey string, timeout time.Duration) *HTTPDocumentAnalyzer {
+	return &HTTPDocumentAnalyzer{
+		Endpoint: endpoint,
+		APIKey:   apiKey,
+		Client:   &http.Client{Timeout: timeout},
+	}
+}
+
+// ExtractMetadata posts the document and returns the metadata extracted from it.
+func (a *HTTPDocumentAnalyzer) ExtractMetadata(ctx context.Context, doc *models.Document) (*models.DocumentMetadata, error) {
+	payload, err := json.Marshal(struct {
+		FileName   string    `json:"file_name"`
+		FileType   string    `json:"file_type"`
+		FileSize   int64     `json:"file_size"`
+		UploadDate time.Time `json:"upload_date"`
+	}{doc.FileName, doc.FileType, doc.FileSize, doc.UploadDate})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal document: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.Endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Bazaruto-Documents/1.0")
+	if a.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+a.APIKey)
+	}
+
+	client := a.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send document: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("extraction endpoint returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var metadata models.DocumentMetadata
+	if err := json.Unmarshal(body, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &metadata, nil
+}
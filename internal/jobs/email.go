@@ -2,14 +2,15 @@ package jobs
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"html/template"
 	"strings"
 	"time"
 
 	"github.com/edsonmichaque/bazaruto/internal/logger"
 	"github.com/edsonmichaque/bazaruto/internal/models"
 	"github.com/edsonmichaque/bazaruto/internal/services"
+	"github.com/edsonmichaque/bazaruto/internal/store"
 	"github.com/edsonmichaque/bazaruto/pkg/job"
 	"github.com/go-mail/mail/v2"
 	"github.com/google/uuid"
@@ -146,13 +147,21 @@ func (j *SendEmailJob) Priority() int {
 	return 5 // Medium priority
 }
 
+// GetRunAt returns when this job should run; zero runs it immediately
+func (j *SendEmailJob) GetRunAt() time.Time {
+	return j.RunAtTime
+}
+
 // WelcomeEmailJob represents a job for sending welcome emails to new users
 type WelcomeEmailJob struct {
-	ID          uuid.UUID             `json:"id"`
-	UserID      uuid.UUID             `json:"user_id"`
-	UserService *services.UserService `json:"-"` // Injected dependency
-	Attempts    int                   `json:"attempts"`
-	RunAtTime   time.Time             `json:"run_at_time"`
+	ID          uuid.UUID                    `json:"id"`
+	UserID      uuid.UUID                    `json:"user_id"`
+	Locale      string                       `json:"locale,omitempty"` // Falls back to the customer's language, then English
+	UserService *services.UserService        `json:"-"`                // Injected dependency
+	Customers   store.CustomerStore          `json:"-"`                // Injected dependency; resolves the locale when unset
+	Templates   *services.EmailTemplateStore `json:"-"`                // Injected dependency; built-in templates when nil
+	Attempts    int                          `json:"attempts"`
+	RunAtTime   time.Time                    `json:"run_at_time"`
 }
 
 // Perform executes the welcome email job
@@ -172,9 +181,13 @@ func (j *WelcomeEmailJob) Perform(ctx context.Context) error {
 		return fmt.Errorf("user not found: %s", j.UserID.String())
 	}
 
+	locale, err := emailLocale(ctx, j.Customers, j.UserID, j.Locale)
+	if err != nil {
+		return err
+	}
+
 	// Generate personalized welcome email content
-	subject := "Welcome to Bazaruto Insurance!"
-	body, err := j.generateWelcomeEmailBody(user)
+	subject, body, err := emailTemplates(j.Templates).Render(services.EmailTemplateWelcome, locale, user)
 	if err != nil {
 		log.Error("Failed to generate welcome email body",
 			zap.Error(err),
@@ -230,76 +243,22 @@ func (j *WelcomeEmailJob) Priority() int {
 	return 3 // High priority for welcome emails
 }
 
-// generateWelcomeEmailBody generates the HTML body for the welcome email
-func (j *WelcomeEmailJob) generateWelcomeEmailBody(user *models.User) (string, error) {
-	tmpl := `
-<!DOCTYPE html>
-<html>
-<head>
-    <meta charset="UTF-8">
-    <title>Welcome to Bazaruto Insurance</title>
-    <style>
-        body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
-        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
-        .header { background-color: #2c3e50; color: white; padding: 20px; text-align: center; }
-        .content { padding: 20px; background-color: #f9f9f9; }
-        .footer { padding: 20px; text-align: center; font-size: 12px; color: #666; }
-        .button { display: inline-block; padding: 12px 24px; background-color: #3498db; color: white; text-decoration: none; border-radius: 4px; margin: 10px 0; }
-    </style>
-</head>
-<body>
-    <div class="container">
-        <div class="header">
-            <h1>Welcome to Bazaruto Insurance!</h1>
-        </div>
-        <div class="content">
-            <h2>Hello {{.FullName}}!</h2>
-            <p>Welcome to Bazaruto Insurance, your trusted partner for comprehensive insurance solutions.</p>
-            <p>We're excited to have you on board and look forward to helping you protect what matters most.</p>
-            
-            <h3>What's next?</h3>
-            <ul>
-                <li>Complete your profile setup</li>
-                <li>Explore our insurance products</li>
-                <li>Get your first quote</li>
-                <li>Connect with our support team</li>
-            </ul>
-            
-            <p>
-                <a href="https://bazaruto.com/dashboard" class="button">Go to Dashboard</a>
-            </p>
-            
-            <p>If you have any questions, don't hesitate to reach out to our support team.</p>
-        </div>
-        <div class="footer">
-            <p>© 2024 Bazaruto Insurance. All rights reserved.</p>
-            <p>This email was sent to {{.Email}}</p>
-        </div>
-    </div>
-</body>
-</html>`
-
-	t, err := template.New("welcome").Parse(tmpl)
-	if err != nil {
-		return "", fmt.Errorf("failed to parse template: %w", err)
-	}
-
-	var buf strings.Builder
-	if err := t.Execute(&buf, user); err != nil {
-		return "", fmt.Errorf("failed to execute template: %w", err)
-	}
-
-	return buf.String(), nil
+// GetRunAt returns when this job should run; zero runs it immediately
+func (j *WelcomeEmailJob) GetRunAt() time.Time {
+	return j.RunAtTime
 }
 
 // PasswordResetJob represents a job for sending password reset emails
 type PasswordResetJob struct {
-	ID          uuid.UUID             `json:"id"`
-	UserID      uuid.UUID             `json:"user_id"`
-	Token       string                `json:"token"`
-	UserService *services.UserService `json:"-"` // Injected dependency
-	Attempts    int                   `json:"attempts"`
-	RunAtTime   time.Time             `json:"run_at_time"`
+	ID          uuid.UUID                    `json:"id"`
+	UserID      uuid.UUID                    `json:"user_id"`
+	Token       string                       `json:"token"`
+	Locale      string                       `json:"locale,omitempty"` // Falls back to the customer's language, then English
+	UserService *services.UserService        `json:"-"`                // Injected dependency
+	Customers   store.CustomerStore          `json:"-"`                // Injected dependency; resolves the locale when unset
+	Templates   *services.EmailTemplateStore `json:"-"`                // Injected dependency; built-in templates when nil
+	Attempts    int                          `json:"attempts"`
+	RunAtTime   time.Time                    `json:"run_at_time"`
 }
 
 // Perform executes the password reset email job
@@ -320,8 +279,18 @@ func (j *PasswordResetJob) Perform(ctx context.Context) error {
 	}
 
 	// Generate password reset email content
-	subject := "Reset Your Bazaruto Insurance Password"
-	body, err := j.generatePasswordResetEmailBody(user, j.Token)
+	data := struct {
+		*models.User
+		ResetURL string
+	}{
+		User:     user,
+		ResetURL: fmt.Sprintf("https://bazaruto.com/reset-password?token=%s", j.Token),
+	}
+	locale, err := emailLocale(ctx, j.Customers, j.UserID, j.Locale)
+	if err != nil {
+		return err
+	}
+	subject, body, err := emailTemplates(j.Templates).Render(services.EmailTemplatePasswordReset, locale, data)
 	if err != nil {
 		log.Error("Failed to generate password reset email body",
 			zap.Error(err),
@@ -353,79 +322,56 @@ func (j *PasswordResetJob) Perform(ctx context.Context) error {
 	return nil
 }
 
-// generatePasswordResetEmailBody generates the HTML body for the password reset email
-func (j *PasswordResetJob) generatePasswordResetEmailBody(user *models.User, token string) (string, error) {
-	resetURL := fmt.Sprintf("https://bazaruto.com/reset-password?token=%s", token)
-
-	tmpl := `
-<!DOCTYPE html>
-<html>
-<head>
-    <meta charset="UTF-8">
-    <title>Reset Your Password - Bazaruto Insurance</title>
-    <style>
-        body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
-        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
-        .header { background-color: #e74c3c; color: white; padding: 20px; text-align: center; }
-        .content { padding: 20px; background-color: #f9f9f9; }
-        .footer { padding: 20px; text-align: center; font-size: 12px; color: #666; }
-        .button { display: inline-block; padding: 12px 24px; background-color: #e74c3c; color: white; text-decoration: none; border-radius: 4px; margin: 10px 0; }
-        .warning { background-color: #fff3cd; border: 1px solid #ffeaa7; padding: 15px; border-radius: 4px; margin: 15px 0; }
-    </style>
-</head>
-<body>
-    <div class="container">
-        <div class="header">
-            <h1>Password Reset Request</h1>
-        </div>
-        <div class="content">
-            <h2>Hello {{.FullName}}!</h2>
-            <p>We received a request to reset your password for your Bazaruto Insurance account.</p>
-            
-            <p>Click the button below to reset your password:</p>
-            <p>
-                <a href="{{.ResetURL}}" class="button">Reset Password</a>
-            </p>
-            
-            <div class="warning">
-                <strong>Security Notice:</strong>
-                <ul>
-                    <li>This link will expire in 24 hours</li>
-                    <li>If you didn't request this reset, please ignore this email</li>
-                    <li>Never share your password with anyone</li>
-                </ul>
-            </div>
-            
-            <p>If the button doesn't work, copy and paste this link into your browser:</p>
-            <p style="word-break: break-all; background-color: #f0f0f0; padding: 10px; border-radius: 4px;">
-                {{.ResetURL}}
-            </p>
-        </div>
-        <div class="footer">
-            <p>© 2024 Bazaruto Insurance. All rights reserved.</p>
-            <p>This email was sent to {{.Email}}</p>
-        </div>
-    </div>
-</body>
-</html>`
-
-	t, err := template.New("password_reset").Parse(tmpl)
-	if err != nil {
-		return "", fmt.Errorf("failed to parse template: %w", err)
-	}
+// Queue returns the queue name for this job
+func (j *PasswordResetJob) Queue() string {
+	return job.QueueMailers
+}
 
-	data := struct {
-		*models.User
-		ResetURL string
-	}{
-		User:     user,
-		ResetURL: resetURL,
+// MaxRetries returns the maximum number of retries for this job
+func (j *PasswordResetJob) MaxRetries() int {
+	return 3
+}
+
+// RetryBackoff returns the backoff duration for retries
+func (j *PasswordResetJob) RetryBackoff() time.Duration {
+	return time.Duration(j.Attempts) * 30 * time.Second
+}
+
+// Priority returns the priority of this job
+func (j *PasswordResetJob) Priority() int {
+	return 3 // High priority so reset links arrive while they are valid
+}
+
+// GetRunAt returns when this job should run; zero runs it immediately
+func (j *PasswordResetJob) GetRunAt() time.Time {
+	return j.RunAtTime
+}
+
+// emailTemplates returns the injected template store, or the built-in templates when none is set.
+func emailTemplates(templates *services.EmailTemplateStore) *services.EmailTemplateStore {
+	if templates == nil {
+		return services.NewEmailTemplateStore()
 	}
+	return templates
+}
 
-	var buf strings.Builder
-	if err := t.Execute(&buf, data); err != nil {
-		return "", fmt.Errorf("failed to execute template: %w", err)
+// emailLocale returns the locale an email to a user is rendered in: the job's locale when set,
+// otherwise the language of the user's customer profile. A user without a customer profile
+// gets the default locale.
+func emailLocale(ctx context.Context, customers store.CustomerStore, userID uuid.UUID, locale string) (string, error) {
+	if locale != "" || customers == nil {
+		return locale, nil
 	}
 
-	return buf.String(), nil
+	customer, err := customers.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return services.DefaultLocale, nil
+		}
+		return "", fmt.Errorf("failed to fetch customer language: %w", err)
+	}
+	if customer.Language == "" {
+		return services.DefaultLocale, nil
+	}
+	return customer.Language, nil
 }
@@ -0,0 +1,43 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/pkg/job"
+	"github.com/edsonmichaque/bazaruto/pkg/job/adapter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFutureDatedEmailJobIsHeldUntilItsRunTime(t *testing.T) {
+	ctx := context.Background()
+	memory := adapter.NewMemoryAdapter()
+	registry := job.NewRegistry()
+	registry.RegisterJob(&SendEmailJob{})
+	dispatcher := job.NewDispatcher(memory, registry)
+
+	now := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	memory.SetClock(func() time.Time { return now })
+
+	runAt := now.Add(24 * time.Hour)
+	require.NoError(t, dispatcher.PerformWithContext(ctx, &SendEmailJob{
+		To:        "customer@example.com",
+		Subject:   "Your policy renews tomorrow",
+		Body:      "Reminder",
+		RunAtTime: runAt,
+	}))
+
+	_, err := memory.Dequeue(ctx, job.QueueMailers)
+	assert.Error(t, err, "email was ready before its run time")
+
+	now = runAt
+	queued, err := memory.Dequeue(ctx, job.QueueMailers)
+	require.NoError(t, err)
+	assert.True(t, queued.RunAt.Equal(runAt))
+
+	restored, err := registry.Deserialize(queued)
+	require.NoError(t, err)
+	assert.Equal(t, "customer@example.com", restored.(*SendEmailJob).To)
+}
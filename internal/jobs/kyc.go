@@ -0,0 +1,67 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/services"
+	"github.com/edsonmichaque/bazaruto/pkg/job"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// DefaultKYCExpiryInterval is how often the KYC expiry job runs.
+const DefaultKYCExpiryInterval = 24 * time.Hour
+
+// ExpireKYCVerificationsJob represents a recurring job that flags customers whose KYC
+// verification is older than the KYC update frequency as needing re-verification.
+type ExpireKYCVerificationsJob struct {
+	ID         uuid.UUID            `json:"id"`
+	Interval   time.Duration        `json:"interval"`
+	KYCService *services.KYCService `json:"-"` // Injected dependency
+	Dispatcher *job.Dispatcher      `json:"-"` // Injected dependency
+	Logger     *logger.Logger       `json:"-"` // Injected dependency
+	Attempts   int                  `json:"attempts"`
+	RunAtTime  time.Time            `json:"run_at_time"`
+}
+
+// Perform executes the KYC expiry job and schedules the next run.
+func (j *ExpireKYCVerificationsJob) Perform(ctx context.Context) error {
+	if j.KYCService == nil || j.Logger == nil {
+		return fmt.Errorf("KYC service is not configured")
+	}
+
+	expired, err := j.KYCService.ExpireVerifications(ctx)
+	if err != nil {
+		j.Logger.Error("Failed to expire KYC verifications", zap.Error(err))
+		return fmt.Errorf("failed to expire KYC verifications: %w", err)
+	}
+
+	j.Logger.Info("KYC verifications expired successfully", zap.Int("expired", expired))
+
+	// Schedule the next run
+	if j.Dispatcher != nil && j.Interval > 0 {
+		next := &ExpireKYCVerificationsJob{Interval: j.Interval}
+		if err := j.Dispatcher.PerformInWithContext(ctx, next, j.Interval); err != nil {
+			j.Logger.Error("Failed to schedule next KYC expiry run", zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+// ExpireKYCVerificationsJob interface methods
+func (j *ExpireKYCVerificationsJob) Queue() string               { return job.QueueCompliance }
+func (j *ExpireKYCVerificationsJob) MaxRetries() int             { return 3 }
+func (j *ExpireKYCVerificationsJob) RetryBackoff() time.Duration { return time.Minute }
+func (j *ExpireKYCVerificationsJob) Priority() int               { return 0 }
+func (j *ExpireKYCVerificationsJob) Type() string                { return "jobs.ExpireKYCVerificationsJob" }
+func (j *ExpireKYCVerificationsJob) SetID(id uuid.UUID)          { j.ID = id }
+func (j *ExpireKYCVerificationsJob) GetID() uuid.UUID            { return j.ID }
+func (j *ExpireKYCVerificationsJob) SetAttempts(attempts int)    { j.Attempts = attempts }
+func (j *ExpireKYCVerificationsJob) GetAttempts() int            { return j.Attempts }
+func (j *ExpireKYCVerificationsJob) SetRunAt(t time.Time)        { j.RunAtTime = t }
+func (j *ExpireKYCVerificationsJob) GetRunAt() time.Time         { return j.RunAtTime }
+func (j *ExpireKYCVerificationsJob) Timeout() time.Duration      { return 0 } // Use the worker's configured timeout
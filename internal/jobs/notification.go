@@ -15,6 +15,7 @@ type PushNotificationJob struct {
 	UserID    uuid.UUID `json:"user_id"`
 	Title     string    `json:"title"`
 	Body      string    `json:"body"`
+	DedupKey  string    `json:"dedup_key,omitempty"` // Suppresses duplicate notifications within the dedup window
 	Attempts  int       `json:"attempts"`
 	RunAtTime time.Time `json:"run_at_time"`
 }
@@ -83,7 +84,8 @@ func (j *PushNotificationJob) SetAttempts(attempts int)    { j.Attempts = attemp
 func (j *PushNotificationJob) GetAttempts() int            { return j.Attempts }
 func (j *PushNotificationJob) SetRunAt(t time.Time)        { j.RunAtTime = t }
 func (j *PushNotificationJob) GetRunAt() time.Time         { return j.RunAtTime }
-func (j *PushNotificationJob) Timeout() time.Duration      { return job.DefaultTimeout }
+func (j *PushNotificationJob) Timeout() time.Duration      { return 30 * time.Second }
+func (j *PushNotificationJob) UniqueKey() string           { return j.DedupKey }
 
 // SendSMSJob interface methods
 func (j *SendSMSJob) Queue() string               { return job.QueueNotifications }
@@ -97,4 +99,32 @@ func (j *SendSMSJob) SetAttempts(attempts int)    { j.Attempts = attempts }
 func (j *SendSMSJob) GetAttempts() int            { return j.Attempts }
 func (j *SendSMSJob) SetRunAt(t time.Time)        { j.RunAtTime = t }
 func (j *SendSMSJob) GetRunAt() time.Time         { return j.RunAtTime }
-func (j *SendSMSJob) Timeout() time.Duration      { return job.DefaultTimeout }
+func (j *SendSMSJob) Timeout() time.Duration      { return 30 * time.Second }
+
+// EmailNotificationSender delivers notifications by email over SMTP.
+type EmailNotificationSender struct{}
+
+// Send emails the notification to the recipient address.
+func (s *EmailNotificationSender) Send(ctx context.Context, recipient, subject, message string) error {
+	return (&SendEmailJob{To: recipient, Subject: subject, Body: message}).Perform(ctx)
+}
+
+// SMSNotificationSender delivers notifications by SMS.
+type SMSNotificationSender struct{}
+
+// Send texts the notification message to the recipient phone number.
+func (s *SMSNotificationSender) Send(ctx context.Context, recipient, subject, message string) error {
+	return (&SendSMSJob{Phone: recipient, Message: message}).Perform(ctx)
+}
+
+// PushNotificationSender delivers notifications as push notifications to a user's devices.
+type PushNotificationSender struct{}
+
+// Send pushes the notification to the user whose ID is the recipient.
+func (s *PushNotificationSender) Send(ctx context.Context, recipient, subject, message string) error {
+	userID, err := uuid.Parse(recipient)
+	if err != nil {
+		return fmt.Errorf("invalid push recipient %q: %w", recipient, err)
+	}
+	return (&PushNotificationJob{UserID: userID, Title: subject, Body: message}).Perform(ctx)
+}
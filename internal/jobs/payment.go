@@ -54,4 +54,4 @@ func (j *ProcessPaymentJob) SetAttempts(attempts int)    { j.Attempts = attempts
 func (j *ProcessPaymentJob) GetAttempts() int            { return j.Attempts }
 func (j *ProcessPaymentJob) SetRunAt(t time.Time)        { j.RunAtTime = t }
 func (j *ProcessPaymentJob) GetRunAt() time.Time         { return j.RunAtTime }
-func (j *ProcessPaymentJob) Timeout() time.Duration      { return job.DefaultTimeout }
+func (j *ProcessPaymentJob) Timeout() time.Duration      { return 0 } // Use the worker's configured timeout
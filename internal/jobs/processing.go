@@ -53,4 +53,4 @@ func (j *CalculatePremiumJob) SetAttempts(attempts int)    { j.Attempts = attemp
 func (j *CalculatePremiumJob) GetAttempts() int            { return j.Attempts }
 func (j *CalculatePremiumJob) SetRunAt(t time.Time)        { j.RunAtTime = t }
 func (j *CalculatePremiumJob) GetRunAt() time.Time         { return j.RunAtTime }
-func (j *CalculatePremiumJob) Timeout() time.Duration      { return job.DefaultTimeout }
+func (j *CalculatePremiumJob) Timeout() time.Duration      { return 0 } // Use the worker's configured timeout
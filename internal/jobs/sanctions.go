@@ -0,0 +1,68 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/services"
+	"github.com/edsonmichaque/bazaruto/pkg/job"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// DefaultSanctionsRescreenInterval is how often users are screened again against sanctions
+// and PEP lists.
+const DefaultSanctionsRescreenInterval = 24 * time.Hour
+
+// RescreenSanctionsJob represents a recurring job that screens every user again against
+// sanctions and PEP lists, so list updates are caught without waiting for a compliance check.
+type RescreenSanctionsJob struct {
+	ID                uuid.UUID                   `json:"id"`
+	Interval          time.Duration               `json:"interval"`
+	ComplianceService *services.ComplianceService `json:"-"` // Injected dependency
+	Dispatcher        *job.Dispatcher             `json:"-"` // Injected dependency
+	Logger            *logger.Logger              `json:"-"` // Injected dependency
+	Attempts          int                         `json:"attempts"`
+	RunAtTime         time.Time                   `json:"run_at_time"`
+}
+
+// Perform executes the sanctions re-screening job and schedules the next run.
+func (j *RescreenSanctionsJob) Perform(ctx context.Context) error {
+	if j.ComplianceService == nil || j.Logger == nil {
+		return fmt.Errorf("compliance service is not configured")
+	}
+
+	matched, err := j.ComplianceService.RescreenUsers(ctx)
+	if err != nil {
+		j.Logger.Error("Failed to re-screen users against sanctions lists", zap.Error(err))
+		return fmt.Errorf("failed to re-screen users: %w", err)
+	}
+
+	j.Logger.Info("Users re-screened against sanctions lists", zap.Int("matched", matched))
+
+	// Schedule the next run
+	if j.Dispatcher != nil && j.Interval > 0 {
+		next := &RescreenSanctionsJob{Interval: j.Interval}
+		if err := j.Dispatcher.PerformInWithContext(ctx, next, j.Interval); err != nil {
+			j.Logger.Error("Failed to schedule next sanctions re-screening run", zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+// RescreenSanctionsJob interface methods
+func (j *RescreenSanctionsJob) Queue() string               { return job.QueueCompliance }
+func (j *RescreenSanctionsJob) MaxRetries() int             { return 3 }
+func (j *RescreenSanctionsJob) RetryBackoff() time.Duration { return time.Minute }
+func (j *RescreenSanctionsJob) Priority() int               { return 0 }
+func (j *RescreenSanctionsJob) Type() string                { return "jobs.RescreenSanctionsJob" }
+func (j *RescreenSanctionsJob) SetID(id uuid.UUID)          { j.ID = id }
+func (j *RescreenSanctionsJob) GetID() uuid.UUID            { return j.ID }
+func (j *RescreenSanctionsJob) SetAttempts(attempts int)    { j.Attempts = attempts }
+func (j *RescreenSanctionsJob) GetAttempts() int            { return j.Attempts }
+func (j *RescreenSanctionsJob) SetRunAt(t time.Time)        { j.RunAtTime = t }
+func (j *RescreenSanctionsJob) GetRunAt() time.Time         { return j.RunAtTime }
+func (j *RescreenSanctionsJob) Timeout() time.Duration      { return 0 } // Use the worker's configured timeout
@@ -0,0 +1,78 @@
+package jobs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/models"
+)
+
+// HTTPSARSubmitter files suspicious activity reports by posting them as JSON to a reporting
+// endpoint. The endpoint responds with the filing reference in a "reference" field.
+type HTTPSARSubmitter struct {
+	Endpoint string
+	APIKey   string
+	Client   *http.Client
+}
+
+// NewHTTPSARSubmitter creates a submitter for endpoint with the given request timeout.
+func NewHTTPSARSubmitter(endpoint, apiKey string, timeout time.Duration) *HTTPSARSubmitter {
+	return &HTTPSARSubmitter{
+		Endpoint: endpoint,
+		APIKey:   apiKey,
+		Client:   &http.Client{Timeout: timeout},
+	}
+}
+
+// Submit posts the report and returns the filing reference from the response.
+func (s *HTTPSARSubmitter) Submit(ctx context.Context, report *models.SuspiciousActivityReport) (string, error) {
+	payload, err := json.Marshal(report)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal report: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.Endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Bazaruto-SAR/1.0")
+	req.Header.Set("Idempotency-Key", report.ReportNumber)
+	if s.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+s.APIKey)
+	}
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to send report: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("reporting endpoint returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		Reference string `json:"reference"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+	if result.Reference == "" {
+		return "", fmt.Errorf("reporting endpoint returned no filing reference")
+	}
+	return result.Reference, nil
+}
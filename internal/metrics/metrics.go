@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"context"
+	"database/sql"
 	"net/http"
 	"time"
 
@@ -26,6 +27,7 @@ type Metrics struct {
 	DatabaseConnectionsIdle   prometheus.Gauge
 	DatabaseQueriesTotal      *prometheus.CounterVec
 	DatabaseQueryDuration     *prometheus.HistogramVec
+	DatabaseSlowQueries       *prometheus.CounterVec
 
 	// Rate limiting metrics
 	RateLimitRequestsTotal   *prometheus.CounterVec
@@ -39,6 +41,12 @@ type Metrics struct {
 	ClaimsSubmitted   prometheus.Counter
 	PaymentsProcessed prometheus.Counter
 
+	// Fraud detection metrics
+	FraudScores         *prometheus.HistogramVec
+	FraudRiskLevels     *prometheus.CounterVec
+	FraudAnalysesTotal  *prometheus.CounterVec
+	FraudReviewRequired *prometheus.CounterVec
+
 	// Job metrics
 	JobStarted   *prometheus.CounterVec
 	JobCompleted *prometheus.CounterVec
@@ -126,6 +134,14 @@ func NewMetrics() *Metrics {
 		[]string{"operation", "table"},
 	)
 
+	metrics.DatabaseSlowQueries = promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "database_slow_queries_total",
+			Help: "Total number of database queries slower than the slow query threshold",
+		},
+		[]string{"operation", "table"},
+	)
+
 	// Initialize rate limiting metrics
 	metrics.RateLimitRequestsTotal = promauto.With(registry).NewCounterVec(
 		prometheus.CounterOpts{
@@ -187,6 +203,40 @@ func NewMetrics() *Metrics {
 		},
 	)
 
+	// Initialize fraud detection metrics
+	metrics.FraudScores = promauto.With(registry).NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "fraud_score",
+			Help:    "Distribution of claim fraud scores (0-100)",
+			Buckets: prometheus.LinearBuckets(10, 10, 10),
+		},
+		[]string{"product_category"},
+	)
+
+	metrics.FraudRiskLevels = promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "fraud_risk_level_total",
+			Help: "Total number of fraud analyses by risk level",
+		},
+		[]string{"risk_level", "product_category"},
+	)
+
+	metrics.FraudAnalysesTotal = promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "fraud_analyses_total",
+			Help: "Total number of claim fraud analyses",
+		},
+		[]string{"product_category"},
+	)
+
+	metrics.FraudReviewRequired = promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "fraud_review_required_total",
+			Help: "Total number of fraud analyses flagged for manual review",
+		},
+		[]string{"product_category"},
+	)
+
 	// Initialize job metrics
 	metrics.JobStarted = promauto.With(registry).NewCounterVec(
 		prometheus.CounterOpts{
@@ -244,6 +294,17 @@ func (m *Metrics) RecordDatabaseQuery(operation, table string, duration time.Dur
 	m.DatabaseQueryDuration.WithLabelValues(operation, table).Observe(duration.Seconds())
 }
 
+// RecordSlowDatabaseQuery counts a query slower than the slow query threshold.
+func (m *Metrics) RecordSlowDatabaseQuery(operation, table string) {
+	m.DatabaseSlowQueries.WithLabelValues(operation, table).Inc()
+}
+
+// RegisterDatabasePool exports the statistics of a connection pool: open, in-use and
+// idle connections, and how often and how long callers waited for a connection.
+func (m *Metrics) RegisterDatabasePool(db *sql.DB) error {
+	return m.registry.Register(collectors.NewDBStatsCollector(db, "bazaruto"))
+}
+
 // RecordRateLimitRequest records rate limiting metrics.
 func (m *Metrics) RecordRateLimitRequest(policy, key string, allowed bool) {
 	m.RateLimitRequestsTotal.WithLabelValues(policy, key).Inc()
@@ -285,6 +346,16 @@ func (m *Metrics) IncrementPaymentProcessed() {
 	m.PaymentsProcessed.Inc()
 }
 
+// RecordFraudAnalysis records the outcome of a claim fraud analysis.
+func (m *Metrics) RecordFraudAnalysis(productCategory, riskLevel string, score float64, requiresReview bool) {
+	m.FraudScores.WithLabelValues(productCategory).Observe(score)
+	m.FraudRiskLevels.WithLabelValues(riskLevel, productCategory).Inc()
+	m.FraudAnalysesTotal.WithLabelValues(productCategory).Inc()
+	if requiresReview {
+		m.FraudReviewRequired.WithLabelValues(productCategory).Inc()
+	}
+}
+
 // StartMetricsServer starts the metrics server.
 func (m *Metrics) StartMetricsServer(ctx context.Context, addr string) error {
 	mux := http.NewServeMux()
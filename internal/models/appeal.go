@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Appeal represents an appeal against a denied claim or a declined underwriting application.
+// A pending appeal is the review task for the reviewer it is assigned to.
+type Appeal struct {
+	Base
+	SubjectType      string     `json:"subject_type" gorm:"not null;index:idx_appeal_subject"`         // claim, underwriting
+	SubjectID        uuid.UUID  `json:"subject_id" gorm:"type:uuid;not null;index:idx_appeal_subject"` // claim or application ID
+	UserID           uuid.UUID  `json:"user_id" gorm:"type:uuid;index;not null"`
+	Sequence         int        `json:"sequence" gorm:"not null"` // 1 for the first appeal against the subject
+	Reason           string     `json:"reason" gorm:"not null"`
+	Evidence         []Document `json:"evidence" gorm:"serializer:json"`
+	Status           string     `json:"status" gorm:"default:pending_review"`
+	OriginalDecision string     `json:"original_decision"`
+	Outcome          string     `json:"outcome"` // decision reached on review
+	AssignedTo       *uuid.UUID `json:"assigned_to" gorm:"type:uuid"`
+	ReviewDueDate    time.Time  `json:"review_due_date"`
+	ReviewedBy       *uuid.UUID `json:"reviewed_by" gorm:"type:uuid"`
+	ReviewedAt       *time.Time `json:"reviewed_at"`
+	ReviewNotes      string     `json:"review_notes"`
+}
+
+// TableName returns the table name for the Appeal model.
+func (Appeal) TableName() string {
+	return "appeals"
+}
+
+// IsOpen reports whether the appeal is still awaiting a decision.
+func (a *Appeal) IsOpen() bool {
+	return a.Status == AppealStatusPendingReview
+}
+
+// Appeal subject type constants.
+const (
+	AppealSubjectClaim        = "claim"
+	AppealSubjectUnderwriting = "underwriting"
+)
+
+// Appeal status constants.
+const (
+	AppealStatusPendingReview = "pending_review"
+	AppealStatusUpheld        = "upheld"     // the original decision stands
+	AppealStatusOverturned    = "overturned" // the original decision was reversed
+)
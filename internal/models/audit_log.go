@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Audit log entity types.
+const (
+	AuditEntityUnderwritingDecision = "underwriting_decision"
+	AuditEntityClaim                = "claim"
+	AuditEntityAppeal               = "appeal"
+)
+
+// Audit log actions.
+const (
+	AuditActionUnderwritingOverride  = "underwriting_override"
+	AuditActionWorkflowStageOverride = "workflow_stage_override"
+	AuditActionAppealResolved        = "appeal_resolved"
+)
+
+// AuditLog records a manual change to an entity: who made it, when, and the state before and after.
+type AuditLog struct {
+	Base
+	EntityType string                 `json:"entity_type" gorm:"index:idx_audit_logs_entity;not null"`
+	EntityID   string                 `json:"entity_id" gorm:"index:idx_audit_logs_entity;not null"`
+	Action     string                 `json:"action" gorm:"not null"`
+	ActorID    *uuid.UUID             `json:"actor_id" gorm:"type:uuid;index"`
+	Before     map[string]interface{} `json:"before" gorm:"serializer:json"`
+	After      map[string]interface{} `json:"after" gorm:"serializer:json"`
+	Reason     string                 `json:"reason"`
+	OccurredAt time.Time              `json:"occurred_at" gorm:"index;not null"`
+}
+
+// TableName returns the table name for the AuditLog model.
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
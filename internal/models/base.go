@@ -52,22 +52,41 @@ const (
 	PaymentStatusRefunded  = "refunded"
 )
 
+// Invoice status constants.
+const (
+	InvoiceStatusDraft   = "draft"
+	InvoiceStatusSent    = "sent"
+	InvoiceStatusPaid    = "paid"
+	InvoiceStatusOverdue = "overdue"
+)
+
+// Grace period cause constants.
+const (
+	GracePeriodCauseRenewal        = "renewal"         // renewal issued awaiting payment
+	GracePeriodCausePaymentFailure = "payment_failure" // premium payment attempt failed
+)
+
 // Claim status constants.
 const (
 	ClaimStatusSubmitted   = "submitted"
 	ClaimStatusUnderReview = "under_review"
 	ClaimStatusApproved    = "approved"
 	ClaimStatusDenied      = "denied"
+	ClaimStatusUnderAppeal = "under_appeal"
 	ClaimStatusPaid        = "paid"
 )
 
+// PolicyStatus is the lifecycle status of a policy.
+type PolicyStatus string
+
 // Policy status constants.
 const (
-	PolicyStatusActive    = "active"
-	PolicyStatusInactive  = "inactive"
-	PolicyStatusExpired   = "expired"
-	PolicyStatusCancelled = "cancelled"
-	PolicyStatusSuspended = "suspended"
+	PolicyStatusPending   PolicyStatus = "pending"
+	PolicyStatusActive    PolicyStatus = "active"
+	PolicyStatusInactive  PolicyStatus = "inactive"
+	PolicyStatusExpired   PolicyStatus = "expired"
+	PolicyStatusCancelled PolicyStatus = "cancelled"
+	PolicyStatusSuspended PolicyStatus = "suspended"
 )
 
 // Quote status constants.
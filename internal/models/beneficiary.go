@@ -28,3 +28,21 @@ type Beneficiary struct {
 func (Beneficiary) TableName() string {
 	return "beneficiaries"
 }
+
+// GetAge returns the beneficiary's age in years, or 0 when the date of birth is unknown.
+func (b *Beneficiary) GetAge() int {
+	if b.DateOfBirth.IsZero() {
+		return 0
+	}
+	return ageAt(b.DateOfBirth, time.Now())
+}
+
+// ageAt returns the age in completed years on a date of someone born on birth. The birthday
+// is compared by month and day, so leap years do not shift it.
+func ageAt(birth, on time.Time) int {
+	age := on.Year() - birth.Year()
+	if on.Month() < birth.Month() || (on.Month() == birth.Month() && on.Day() < birth.Day()) {
+		age--
+	}
+	return age
+}
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBeneficiaryAgeCountsBirthdaysByMonthAndDay(t *testing.T) {
+	birth := time.Date(2001, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	// 29 February 2024 is the 60th day of the year, as is 1 March 2001.
+	if got := ageAt(birth, time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)); got != 22 {
+		t.Errorf("age the day before the birthday = %d, want 22", got)
+	}
+	if got := ageAt(birth, time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)); got != 23 {
+		t.Errorf("age on the birthday = %d, want 23", got)
+	}
+}
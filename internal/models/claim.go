@@ -1,6 +1,8 @@
 package models
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -19,11 +21,27 @@ type Claim struct {
 	Status       string     `json:"status" gorm:"default:submitted"`
 	IncidentDate time.Time  `json:"incident_date" gorm:"not null"`
 	ReportedDate time.Time  `json:"reported_date" gorm:"not null"`
+	Timezone     string     `json:"timezone"`                          // IANA timezone where the incident occurred
+	Peril        string     `json:"peril,omitempty" gorm:"index"`      // Cause of loss, e.g. flood, fire or theft
+	Version      int        `json:"version" gorm:"not null;default:1"` // incremented on every update for optimistic locking
 	ResolvedDate *time.Time `json:"resolved_date"`
 	PaidAmount   float64    `json:"paid_amount" gorm:"default:0"`
 	DenialReason *string    `json:"denial_reason"`
 	Documents    []Document `json:"documents" gorm:"type:json"`
 
+	// DecisionLetterURL is where the customer's settlement or denial letter can be downloaded.
+	DecisionLetterURL string `json:"decision_letter_url,omitempty"`
+
+	// SIUCaseID is the case opened in the Special Investigations Unit's system when the claim
+	// was escalated for fraud investigation.
+	SIUCaseID string `json:"siu_case_id,omitempty"`
+
+	// Submission metadata captured when the claim was filed
+	SubmissionIP      string `json:"submission_ip,omitempty"`
+	SubmissionCountry string `json:"submission_country,omitempty"` // ISO country code resolved from the submission IP
+	DeviceFingerprint string `json:"device_fingerprint,omitempty" gorm:"index"`
+	UserAgent         string `json:"user_agent,omitempty"`
+
 	// Relationships
 	Policy Policy `json:"policy,omitempty" gorm:"foreignKey:PolicyID"`
 	User   User   `json:"user,omitempty" gorm:"foreignKey:UserID"`
@@ -36,9 +54,69 @@ type Document struct {
 	FileSize    int64     `json:"file_size"`
 	UploadDate  time.Time `json:"upload_date"`
 	Description string    `json:"description"`
+	// ValidationFlags records issues found at intake that did not reject the document.
+	ValidationFlags []string `json:"validation_flags,omitempty"`
+	// Metadata holds fields extracted from the document content, when an analyzer is configured.
+	Metadata *DocumentMetadata `json:"metadata,omitempty"`
+}
+
+// DocumentMetadata holds fields extracted from a claim document, for example by OCR.
+type DocumentMetadata struct {
+	DocumentType string            `json:"document_type,omitempty"` // invoice, receipt, police_report, medical_report
+	DocumentDate *time.Time        `json:"document_date,omitempty"` // date printed on the document
+	Amount       float64           `json:"amount,omitempty"`
+	Currency     string            `json:"currency,omitempty"`
+	Fields       map[string]string `json:"fields,omitempty"`
 }
 
 // TableName returns the table name for the Claim model.
 func (Claim) TableName() string {
 	return "claims"
 }
+
+// ErrInvalidStatusTransition is returned when an entity is moved to a status that is not
+// reachable from its current status.
+var ErrInvalidStatusTransition = errors.New("invalid status transition")
+
+// claimStatusTransitions lists the statuses a claim may move to from each status.
+// Paid claims are final; a denied claim can only be reopened through an appeal.
+var claimStatusTransitions = map[string][]string{
+	ClaimStatusSubmitted:   {ClaimStatusUnderReview, ClaimStatusApproved, ClaimStatusDenied},
+	ClaimStatusUnderReview: {ClaimStatusApproved, ClaimStatusDenied},
+	ClaimStatusApproved:    {ClaimStatusPaid},
+	ClaimStatusDenied:      {ClaimStatusUnderAppeal},
+	ClaimStatusUnderAppeal: {ClaimStatusApproved, ClaimStatusDenied},
+	ClaimStatusPaid:        {},
+}
+
+// CanTransitionClaimStatus reports whether a claim may move from one status to another.
+// Staying in the same status is always allowed.
+func CanTransitionClaimStatus(from, to string) bool {
+	if from == to {
+		return true
+	}
+	for _, next := range claimStatusTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateClaimStatusTransition returns an error wrapping ErrInvalidStatusTransition
+// unless a claim may move from one status to another.
+func ValidateClaimStatusTransition(from, to string) error {
+	if !CanTransitionClaimStatus(from, to) {
+		return fmt.Errorf("%w: claim cannot move from %s to %s", ErrInvalidStatusTransition, from, to)
+	}
+	return nil
+}
+
+// TransitionTo moves the claim to status if the transition is allowed.
+func (c *Claim) TransitionTo(status string) error {
+	if err := ValidateClaimStatusTransition(c.Status, status); err != nil {
+		return err
+	}
+	c.Status = status
+	return nil
+}
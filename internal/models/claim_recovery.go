@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ClaimRecovery represents an expected or realized post-payout recovery against a settled claim.
+type ClaimRecovery struct {
+	Base
+	ClaimID         uuid.UUID  `json:"claim_id" gorm:"type:uuid;index;not null"`
+	RecoveryType    string     `json:"recovery_type" gorm:"not null"` // subrogation, salvage
+	ExpectedAmount  float64    `json:"expected_amount" gorm:"not null"`
+	RecoveredAmount float64    `json:"recovered_amount" gorm:"default:0"`
+	Currency        string     `json:"currency" gorm:"default:USD"`
+	Status          string     `json:"status" gorm:"default:expected"`
+	Counterparty    string     `json:"counterparty"`
+	Notes           string     `json:"notes"`
+	LastRecoveredAt *time.Time `json:"last_recovered_at"`
+
+	// Relationships
+	Claim Claim `json:"claim,omitempty" gorm:"foreignKey:ClaimID"`
+}
+
+// TableName returns the table name for the ClaimRecovery model.
+func (ClaimRecovery) TableName() string {
+	return "claim_recoveries"
+}
+
+// Claim recovery type constants.
+const (
+	RecoveryTypeSubrogation = "subrogation"
+	RecoveryTypeSalvage     = "salvage"
+)
+
+// Claim recovery status constants.
+const (
+	RecoveryStatusExpected   = "expected"
+	RecoveryStatusPartial    = "partial"
+	RecoveryStatusRecovered  = "recovered"
+	RecoveryStatusWrittenOff = "written_off"
+)
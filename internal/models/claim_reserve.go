@@ -0,0 +1,33 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// ClaimReserve represents the estimated financial liability held against an open claim.
+type ClaimReserve struct {
+	Base
+	ClaimID         uuid.UUID `json:"claim_id" gorm:"type:uuid;uniqueIndex;not null"`
+	InitialAmount   float64   `json:"initial_amount" gorm:"not null"`
+	CurrentAmount   float64   `json:"current_amount" gorm:"not null"`
+	Currency        string    `json:"currency" gorm:"default:USD"`
+	FraudScore      float64   `json:"fraud_score" gorm:"default:0"`
+	SettlementRatio float64   `json:"settlement_ratio" gorm:"not null"`
+	Stage           string    `json:"stage"`
+	Status          string    `json:"status" gorm:"default:open"`
+	Reason          string    `json:"reason"`
+
+	// Relationships
+	Claim Claim `json:"claim,omitempty" gorm:"foreignKey:ClaimID"`
+}
+
+// TableName returns the table name for the ClaimReserve model.
+func (ClaimReserve) TableName() string {
+	return "claim_reserves"
+}
+
+// Claim reserve status constants.
+const (
+	ReserveStatusOpen   = "open"
+	ReserveStatusClosed = "closed"
+)
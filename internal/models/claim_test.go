@@ -0,0 +1,51 @@
+package models
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestClaimStatusTransitions(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    string
+		to      string
+		allowed bool
+	}{
+		{name: "submitted to under review", from: ClaimStatusSubmitted, to: ClaimStatusUnderReview, allowed: true},
+		{name: "under review to approved", from: ClaimStatusUnderReview, to: ClaimStatusApproved, allowed: true},
+		{name: "under review to denied", from: ClaimStatusUnderReview, to: ClaimStatusDenied, allowed: true},
+		{name: "approved to paid", from: ClaimStatusApproved, to: ClaimStatusPaid, allowed: true},
+		{name: "same status", from: ClaimStatusApproved, to: ClaimStatusApproved, allowed: true},
+		{name: "denied to approved", from: ClaimStatusDenied, to: ClaimStatusApproved, allowed: false},
+		{name: "denied to under appeal", from: ClaimStatusDenied, to: ClaimStatusUnderAppeal, allowed: true},
+		{name: "under appeal to approved", from: ClaimStatusUnderAppeal, to: ClaimStatusApproved, allowed: true},
+		{name: "under appeal to denied", from: ClaimStatusUnderAppeal, to: ClaimStatusDenied, allowed: true},
+		{name: "paid to under review", from: ClaimStatusPaid, to: ClaimStatusUnderReview, allowed: false},
+		{name: "submitted to paid", from: ClaimStatusSubmitted, to: ClaimStatusPaid, allowed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claim := &Claim{Status: tt.from}
+			err := claim.TransitionTo(tt.to)
+
+			if tt.allowed {
+				if err != nil {
+					t.Fatalf("expected transition %s -> %s to be allowed, got %v", tt.from, tt.to, err)
+				}
+				if claim.Status != tt.to {
+					t.Errorf("expected status %s, got %s", tt.to, claim.Status)
+				}
+				return
+			}
+
+			if !errors.Is(err, ErrInvalidStatusTransition) {
+				t.Fatalf("expected transition %s -> %s to be rejected, got %v", tt.from, tt.to, err)
+			}
+			if claim.Status != tt.from {
+				t.Errorf("expected status to remain %s, got %s", tt.from, claim.Status)
+			}
+		})
+	}
+}
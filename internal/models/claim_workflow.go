@@ -0,0 +1,20 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// ClaimWorkflowState is the persisted state of a claim's automated processing workflow. A
+// claim has a single workflow; the workflow and its stages are stored as a JSON document.
+type ClaimWorkflowState struct {
+	Base
+	ClaimID      uuid.UUID `json:"claim_id" gorm:"type:uuid;uniqueIndex;not null"`
+	Status       string    `json:"status" gorm:"not null"` // pending, in_progress, completed, failed
+	CurrentStage string    `json:"current_stage"`
+	Workflow     string    `json:"workflow" gorm:"type:text"` // JSON document of the workflow and its stages
+}
+
+// TableName returns the table name for the ClaimWorkflowState model.
+func (ClaimWorkflowState) TableName() string {
+	return "claim_workflows"
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Commission represents a commission owed to a partner on a policy.
+// A clawback is recorded as a separate commission with a negative amount that
+// references the commission it reverses.
+type Commission struct {
+	Base
+	PolicyID         uuid.UUID              `json:"policy_id" gorm:"type:uuid;not null;index"`
+	PartnerID        uuid.UUID              `json:"partner_id" gorm:"type:uuid;not null;index"`
+	CommissionType   string                 `json:"commission_type" gorm:"not null"` // initial, renewal, adjustment, clawback
+	Role             string                 `json:"role"`                            // writing, override
+	BaseAmount       float64                `json:"base_amount"`
+	Rate             float64                `json:"rate"` // percentage
+	Amount           float64                `json:"amount" gorm:"not null"`
+	Currency         string                 `json:"currency" gorm:"default:USD"`
+	Status           string                 `json:"status" gorm:"default:calculated"`
+	DueDate          time.Time              `json:"due_date"`
+	PaidAt           *time.Time             `json:"paid_at"`
+	ReversalOf       *uuid.UUID             `json:"reversal_of,omitempty" gorm:"type:uuid;index"`
+	ClawedBackAmount float64                `json:"clawed_back_amount" gorm:"default:0"`
+	Metadata         map[string]interface{} `json:"metadata" gorm:"serializer:json"`
+}
+
+// TableName returns the table name for the Commission model.
+func (Commission) TableName() string {
+	return "commissions"
+}
+
+// IsPaid reports whether the commission has been paid to the partner.
+func (c *Commission) IsPaid() bool {
+	return c.Status == CommissionStatusPaid
+}
+
+// Commission type constants.
+const (
+	CommissionTypeInitial    = "initial"
+	CommissionTypeRenewal    = "renewal"
+	CommissionTypeAdjustment = "adjustment"
+	CommissionTypeClawback   = "clawback"
+)
+
+// Commission status constants.
+const (
+	CommissionStatusCalculated = "calculated"
+	CommissionStatusPending    = "pending"
+	CommissionStatusPaid       = "paid"
+	CommissionStatusCancelled  = "cancelled"
+)
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// ConfigSnapshot is a persisted entry of the business rules configuration history. Each
+// configuration applied is stored once, numbered in the order it was applied.
+type ConfigSnapshot struct {
+	Base
+	Sequence int       `json:"sequence" gorm:"uniqueIndex;not null"`
+	Version  string    `json:"version" gorm:"not null;index"`
+	Hash     string    `json:"hash" gorm:"not null"` // SHA-256 of the configuration's JSON encoding
+	LoadedAt time.Time `json:"loaded_at" gorm:"not null"`
+	Config   string    `json:"config" gorm:"type:text;not null"` // JSON document of the configuration
+}
+
+// TableName returns the table name for the ConfigSnapshot model.
+func (ConfigSnapshot) TableName() string {
+	return "config_snapshots"
+}
@@ -1,22 +1,28 @@
 package models
 
 import (
+	"slices"
+	"strings"
+	"unicode"
+
 	"github.com/google/uuid"
 )
 
 // Coverage represents coverage details for an insurance product.
 type Coverage struct {
 	Base
-	ProductID     uuid.UUID `json:"product_id" gorm:"not null"`
-	CoverageType  string    `json:"coverage_type" gorm:"not null"` // medical, dental, vision, etc.
-	CoverageName  string    `json:"coverage_name" gorm:"not null"`
-	Description   string    `json:"description"`
-	CoverageLimit float64   `json:"coverage_limit"`
-	Deductible    float64   `json:"deductible"`
-	Copay         float64   `json:"copay"`
-	Coinsurance   float64   `json:"coinsurance"` // percentage
-	IsIncluded    bool      `json:"is_included" gorm:"default:true"`
-	SortOrder     int       `json:"sort_order" gorm:"default:0"`
+	ProductID         uuid.UUID   `json:"product_id" gorm:"not null"`
+	CoverageType      string      `json:"coverage_type" gorm:"not null"` // medical, dental, vision, etc.
+	CoverageName      string      `json:"coverage_name" gorm:"not null"`
+	Description       string      `json:"description"`
+	CoverageLimit     float64     `json:"coverage_limit"`
+	Deductible        float64     `json:"deductible"`
+	Copay             float64     `json:"copay"`
+	Coinsurance       float64     `json:"coinsurance"` // percentage
+	IsIncluded        bool        `json:"is_included" gorm:"default:true"`
+	SortOrder         int         `json:"sort_order" gorm:"default:0"`
+	WaitingPeriodDays int         `json:"waiting_period_days"` // Overrides the product's waiting period when set
+	Exclusions        []Exclusion `json:"exclusions,omitempty" gorm:"serializer:json"`
 
 	// Relationships
 	Product Product `json:"product,omitempty" gorm:"foreignKey:ProductID"`
@@ -26,3 +32,49 @@ type Coverage struct {
 func (Coverage) TableName() string {
 	return "coverages"
 }
+
+// Exclusion is a loss a policy or coverage does not pay for, such as war or intentional acts.
+type Exclusion struct {
+	Code        string   `json:"code"` // e.g. EXC-WAR, recorded as the reason when a claim is denied
+	Description string   `json:"description"`
+	Perils      []string `json:"perils,omitempty"`   // Claim perils the exclusion applies to
+	Keywords    []string `json:"keywords,omitempty"` // Terms in a claim's title or description that trigger the exclusion
+}
+
+// Matches reports whether a claim falls under the exclusion, either because its peril is
+// excluded or because its title or description mentions one of the exclusion's keywords.
+// Keywords match whole words only, so "war" does not match "warehouse".
+func (e Exclusion) Matches(claim *Claim) bool {
+	for _, peril := range e.Perils {
+		if claim.Peril != "" && strings.EqualFold(peril, claim.Peril) {
+			return true
+		}
+	}
+	text := words(claim.Title + " " + claim.Description)
+	for _, keyword := range e.Keywords {
+		if containsWords(text, words(keyword)) {
+			return true
+		}
+	}
+	return false
+}
+
+// words splits text into its lower-cased words.
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// containsWords reports whether phrase occurs as consecutive words of text.
+func containsWords(text, phrase []string) bool {
+	if len(phrase) == 0 {
+		return false
+	}
+	for i := 0; i+len(phrase) <= len(text); i++ {
+		if slices.Equal(text[i:i+len(phrase)], phrase) {
+			return true
+		}
+	}
+	return false
+}
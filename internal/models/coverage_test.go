@@ -0,0 +1,29 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestExclusionMatchesWholeWords(t *testing.T) {
+	exclusion := Exclusion{Code: "EXC-WAR", Keywords: []string{"war", "set fire"}, Perils: []string{"flood"}}
+
+	tests := []struct {
+		name    string
+		claim   Claim
+		matches bool
+	}{
+		{name: "keyword as a word", claim: Claim{Description: "Damage caused by war."}, matches: true},
+		{name: "keyword inside a word", claim: Claim{Title: "Warehouse roof collapsed", Description: "Stock was awarded"}, matches: false},
+		{name: "phrase", claim: Claim{Title: "Neighbour SET FIRE to the car"}, matches: true},
+		{name: "phrase inside words", claim: Claim{Description: "reset firewall"}, matches: false},
+		{name: "excluded peril", claim: Claim{Peril: "Flood"}, matches: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exclusion.Matches(&tt.claim); got != tt.matches {
+				t.Errorf("Matches() = %v, want %v", got, tt.matches)
+			}
+		})
+	}
+}
@@ -27,14 +27,15 @@ type Customer struct {
 	RiskProfile      string                 `json:"risk_profile"`  // low, medium, high, very_high
 	CustomerTier     string                 `json:"customer_tier"` // bronze, silver, gold, platinum
 	Status           string                 `json:"status" gorm:"default:active"`
-	PreferredContact string                 `json:"preferred_contact"` // email, phone, sms
-	Language         string                 `json:"language" gorm:"default:en"`
+	PreferredContact string                 `json:"preferred_contact"`          // email, phone, sms
+	Language         string                 `json:"language" gorm:"default:en"` // Locale for customer-facing content, e.g. en, pt or pt-MZ
 	Timezone         string                 `json:"timezone"`
 	MarketingConsent bool                   `json:"marketing_consent" gorm:"default:false"`
 	DataConsent      bool                   `json:"data_consent" gorm:"default:false"`
 	KYCStatus        string                 `json:"kyc_status" gorm:"default:pending"` // pending, verified, rejected, expired
 	AMLStatus        string                 `json:"aml_status" gorm:"default:pending"` // pending, cleared, flagged, under_review
 	LastKYCUpdate    *time.Time             `json:"last_kyc_update"`
+	KYCVerifiedAt    *time.Time             `json:"kyc_verified_at"` // When KYC was last verified; re-verification is due after the KYC update frequency
 	LastAMLUpdate    *time.Time             `json:"last_aml_update"`
 	Addresses        []CustomerAddress      `json:"addresses" gorm:"foreignKey:CustomerID"`
 	Documents        []CustomerDocument     `json:"documents" gorm:"foreignKey:CustomerID"`
@@ -42,6 +43,8 @@ type Customer struct {
 	Preferences      map[string]interface{} `json:"preferences" gorm:"type:jsonb"`
 	Metadata         map[string]interface{} `json:"metadata" gorm:"type:jsonb"`
 
+	NotificationPreferences NotificationPreferences `json:"notification_preferences" gorm:"serializer:json"`
+
 	// Relationships
 	User     User      `json:"user,omitempty" gorm:"foreignKey:UserID"`
 	Policies []Policy  `json:"policies,omitempty" gorm:"foreignKey:CustomerID"`
@@ -50,6 +53,21 @@ type Customer struct {
 	Quotes   []Quote   `json:"quotes,omitempty" gorm:"foreignKey:CustomerID"`
 }
 
+// NotificationPreferences controls how and when a customer is notified.
+type NotificationPreferences struct {
+	Channels   []string    `json:"channels,omitempty"` // email, sms, push; overrides PreferredContact when set
+	OptOuts    []string    `json:"opt_outs,omitempty"` // Notification types the customer does not want
+	QuietHours *QuietHours `json:"quiet_hours,omitempty"`
+}
+
+// QuietHours is a daily period, in the customer's local time, during which non-critical
+// notifications are held back. A period whose end is before its start runs past midnight.
+type QuietHours struct {
+	Start    string `json:"start"`              // HH:MM
+	End      string `json:"end"`                // HH:MM
+	Timezone string `json:"timezone,omitempty"` // IANA name; defaults to the customer's timezone
+}
+
 // CustomerAddress represents a customer's address.
 type CustomerAddress struct {
 	Base
@@ -148,7 +166,17 @@ func (c *Customer) GetActiveRiskFactors() []CustomerRiskFactor {
 
 // IsKYCVerified returns true if the customer's KYC is verified.
 func (c *Customer) IsKYCVerified() bool {
-	return c.KYCStatus == "verified"
+	return c.KYCStatus == KYCStatusVerified
+}
+
+// KYCReverificationDue reports whether the customer's verified KYC is older than
+// updateFrequencyDays at now. It is false for customers whose KYC is not verified or who
+// have no verification date, and when updateFrequencyDays is not positive.
+func (c *Customer) KYCReverificationDue(now time.Time, updateFrequencyDays int) bool {
+	if updateFrequencyDays <= 0 || !c.IsKYCVerified() || c.KYCVerifiedAt == nil {
+		return false
+	}
+	return !now.Before(c.KYCVerifiedAt.AddDate(0, 0, updateFrequencyDays))
 }
 
 // IsAMLCleared returns true if the customer's AML check is cleared.
@@ -189,3 +217,68 @@ func (c *Customer) GetCustomerTierLevel() int {
 		return 0
 	}
 }
+
+// HasOptedOut reports whether the customer opted out of a notification type.
+func (p NotificationPreferences) HasOptedOut(notificationType string) bool {
+	for _, optOut := range p.OptOuts {
+		if optOut == notificationType {
+			return true
+		}
+	}
+	return false
+}
+
+// Until reports whether t falls within the quiet hours and, if so, when they end. The
+// quiet hours' timezone is used, then defaultTimezone, then UTC. Quiet hours with an
+// unparseable start or end never apply.
+func (q QuietHours) Until(t time.Time, defaultTimezone string) (time.Time, bool) {
+	start, okStart := parseClock(q.Start)
+	end, okEnd := parseClock(q.End)
+	if !okStart || !okEnd || start == end {
+		return time.Time{}, false
+	}
+
+	timezone := q.Timezone
+	if timezone == "" {
+		timezone = defaultTimezone
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		location = time.UTC
+	}
+
+	local := t.In(location)
+	minute := local.Hour()*60 + local.Minute()
+	var quiet bool
+	if start < end {
+		quiet = minute >= start && minute < end
+	} else {
+		quiet = minute >= start || minute < end
+	}
+	if !quiet {
+		return time.Time{}, false
+	}
+
+	until := time.Date(local.Year(), local.Month(), local.Day(), end/60, end%60, 0, 0, location)
+	if !until.After(local) {
+		until = until.AddDate(0, 0, 1)
+	}
+	return until, true
+}
+
+// parseClock parses an HH:MM time of day into minutes after midnight.
+func parseClock(value string) (int, bool) {
+	clock, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, false
+	}
+	return clock.Hour()*60 + clock.Minute(), true
+}
+
+// Customer KYC status constants.
+const (
+	KYCStatusPending  = "pending"
+	KYCStatusVerified = "verified"
+	KYCStatusRejected = "rejected"
+	KYCStatusExpired  = "expired"
+)
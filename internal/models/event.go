@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventRecord represents a published domain event persisted to the event log.
+type EventRecord struct {
+	Base
+	EventID       uuid.UUID              `json:"event_id" gorm:"type:uuid;uniqueIndex;not null"`
+	EventType     string                 `json:"event_type" gorm:"index;not null"`
+	AggregateID   uuid.UUID              `json:"aggregate_id" gorm:"type:uuid;index;not null"`
+	AggregateType string                 `json:"aggregate_type"`
+	Version       int                    `json:"version" gorm:"default:1"`
+	OccurredAt    time.Time              `json:"occurred_at" gorm:"index;not null"`
+	Data          map[string]interface{} `json:"data" gorm:"serializer:json"`
+	Metadata      map[string]interface{} `json:"metadata" gorm:"serializer:json"`
+}
+
+// TableName returns the table name for the EventRecord model.
+func (EventRecord) TableName() string {
+	return "event_records"
+}
+
+// EventDeadLetter is an event a handler failed to process after all delivery attempts.
+// The event is preserved as published so it can be inspected or re-delivered.
+type EventDeadLetter struct {
+	Base
+	HandlerName   string                 `json:"handler_name" gorm:"index;not null"`
+	EventID       uuid.UUID              `json:"event_id" gorm:"type:uuid;index;not null"`
+	EventType     string                 `json:"event_type" gorm:"not null"`
+	AggregateID   uuid.UUID              `json:"aggregate_id" gorm:"type:uuid"`
+	AggregateType string                 `json:"aggregate_type"`
+	Version       int                    `json:"version" gorm:"default:1"`
+	OccurredAt    time.Time              `json:"occurred_at"`
+	Data          map[string]interface{} `json:"data" gorm:"serializer:json"`
+	Metadata      map[string]interface{} `json:"metadata" gorm:"serializer:json"`
+	Attempts      int                    `json:"attempts"`
+	Error         string                 `json:"error"`
+	FailedAt      time.Time              `json:"failed_at" gorm:"index;not null"`
+}
+
+// TableName returns the table name for the EventDeadLetter model.
+func (EventDeadLetter) TableName() string {
+	return "event_dead_letters"
+}
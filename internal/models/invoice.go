@@ -17,11 +17,12 @@ type Invoice struct {
 	Currency       string        `json:"currency" gorm:"default:USD"`
 	Tax            float64       `json:"tax" gorm:"default:0"`
 	Total          float64       `json:"total" gorm:"not null"`
-	Status         string        `json:"status" gorm:"default:pending"`
+	Status         string        `json:"status" gorm:"default:draft"`
+	Reason         string        `json:"reason"`
 	DueDate        time.Time     `json:"due_date" gorm:"not null"`
 	PaidAt         *time.Time    `json:"paid_at"`
 	PaymentID      *uuid.UUID    `json:"payment_id"`
-	Items          []InvoiceItem `json:"items" gorm:"type:json"`
+	Items          []InvoiceItem `json:"items" gorm:"serializer:json"`
 
 	// Relationships
 	User         User          `json:"user,omitempty" gorm:"foreignKey:UserID"`
@@ -32,6 +33,7 @@ type Invoice struct {
 
 // InvoiceItem represents an item on an invoice.
 type InvoiceItem struct {
+	Type        string  `json:"type"`
 	Description string  `json:"description"`
 	Quantity    int     `json:"quantity"`
 	UnitPrice   float64 `json:"unit_price"`
@@ -42,3 +44,17 @@ type InvoiceItem struct {
 func (Invoice) TableName() string {
 	return "invoices"
 }
+
+// Invoice line item type constants.
+const (
+	InvoiceItemTypePremium  = "premium"
+	InvoiceItemTypeTax      = "tax"
+	InvoiceItemTypeFee      = "fee"
+	InvoiceItemTypeDiscount = "discount"
+)
+
+// Invoice reason constants.
+const (
+	InvoiceReasonIssuance = "issuance"
+	InvoiceReasonRenewal  = "renewal"
+)
@@ -276,6 +276,28 @@ func NewClaimListOptions() *ClaimListOptions {
 	}
 }
 
+// ClaimFilter selects claims for cursor-paginated listing. Zero-valued fields do not filter.
+// The reported date range is inclusive at both ends.
+type ClaimFilter struct {
+	UserID       *uuid.UUID `json:"user_id"`
+	PolicyID     *uuid.UUID `json:"policy_id"`
+	Statuses     []string   `json:"statuses"`
+	ReportedFrom *time.Time `json:"reported_from"`
+	ReportedTo   *time.Time `json:"reported_to"`
+}
+
+// CursorPage requests a page of results following an opaque cursor.
+type CursorPage struct {
+	Cursor string `json:"cursor"` // empty for the first page
+	Limit  int    `json:"limit"`  // defaults to 30, at most 100
+}
+
+// ClaimPage is a page of claims, most recently reported first.
+type ClaimPage struct {
+	Claims     []*Claim `json:"claims"`
+	NextCursor string   `json:"next_cursor,omitempty"` // empty on the last page
+}
+
 // WebhookListOptions provides filtering options specific to webhook deliveries
 type WebhookListOptions struct {
 	*ListOptions
@@ -1,5 +1,7 @@
 package models
 
+import "github.com/google/uuid"
+
 // Partner represents an insurance provider/company.
 type Partner struct {
 	Base
@@ -13,10 +15,22 @@ type Partner struct {
 	Status         string  `json:"status" gorm:"default:active"`
 	CommissionRate float64 `json:"commission_rate" gorm:"default:0.1"` // 10% default commission
 
+	// SettlementCurrency is the currency the partner's commissions are paid out in.
+	SettlementCurrency string `json:"settlement_currency" gorm:"default:USD"`
+
+	// ParentPartnerID is the agency a sub-agent sells under. The parent earns an
+	// override commission on the sub-agent's sales.
+	ParentPartnerID *uuid.UUID `json:"parent_partner_id,omitempty" gorm:"type:uuid;index"`
+
 	// Relationships
 	Products []Product `json:"products,omitempty" gorm:"foreignKey:PartnerID"`
 }
 
+// HasParent reports whether the partner is a sub-agent of another partner.
+func (p *Partner) HasParent() bool {
+	return p.ParentPartnerID != nil && *p.ParentPartnerID != uuid.Nil
+}
+
 // TableName returns the table name for the Partner model.
 func (Partner) TableName() string {
 	return "partners"
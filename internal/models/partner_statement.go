@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PartnerStatement is a periodic commission statement for a partner. Amounts are in the
+// partner's settlement currency and the balance is what is owed to the partner.
+type PartnerStatement struct {
+	Base
+	PartnerID         uuid.UUID `json:"partner_id" gorm:"type:uuid;not null;index"`
+	PeriodStart       time.Time `json:"period_start" gorm:"not null"`
+	PeriodEnd         time.Time `json:"period_end" gorm:"not null;index"`
+	Currency          string    `json:"currency" gorm:"default:USD"`
+	OpeningBalance    float64   `json:"opening_balance"`
+	CommissionsEarned float64   `json:"commissions_earned"`
+	Clawbacks         float64   `json:"clawbacks"`
+	PaymentsMade      float64   `json:"payments_made"`
+	ClosingBalance    float64   `json:"closing_balance"`
+	CommissionCount   int       `json:"commission_count"`
+}
+
+// TableName returns the table name for the PartnerStatement model.
+func (PartnerStatement) TableName() string {
+	return "partner_statements"
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -9,19 +10,31 @@ import (
 // Policy represents an active insurance policy.
 type Policy struct {
 	Base
-	PolicyNumber     string     `json:"policy_number" gorm:"uniqueIndex;not null"`
-	ProductID        uuid.UUID  `json:"product_id" gorm:"not null"`
-	UserID           uuid.UUID  `json:"user_id" gorm:"not null"`
-	QuoteID          *uuid.UUID `json:"quote_id"`
-	Premium          float64    `json:"premium" gorm:"not null"`
-	Currency         string     `json:"currency" gorm:"default:USD"`
-	CoverageAmount   float64    `json:"coverage_amount" gorm:"not null"`
-	Status           string     `json:"status" gorm:"default:active"`
-	EffectiveDate    time.Time  `json:"effective_date" gorm:"not null"`
-	ExpirationDate   time.Time  `json:"expiration_date" gorm:"not null"`
-	RenewalDate      *time.Time `json:"renewal_date"`
-	AutoRenew        bool       `json:"auto_renew" gorm:"default:false"`
-	PaymentFrequency string     `json:"payment_frequency" gorm:"default:monthly"` // monthly, quarterly, annually
+	PolicyNumber     string       `json:"policy_number" gorm:"uniqueIndex;not null"`
+	ProductID        uuid.UUID    `json:"product_id" gorm:"not null"`
+	UserID           uuid.UUID    `json:"user_id" gorm:"not null"`
+	QuoteID          *uuid.UUID   `json:"quote_id"`
+	PreviousPolicyID *uuid.UUID   `json:"previous_policy_id" gorm:"type:uuid;index"` // policy this one renews
+	Premium          float64      `json:"premium" gorm:"not null"`
+	Currency         string       `json:"currency" gorm:"default:USD"`
+	Jurisdiction     string       `json:"jurisdiction,omitempty" gorm:"index"` // ISO country code of the insured risk
+	CoverageAmount   float64      `json:"coverage_amount" gorm:"not null"`
+	CoverageTier     string       `json:"coverage_tier,omitempty"`     // basic, standard or premium; empty is standard
+	Deductible       float64      `json:"deductible" gorm:"default:0"` // amount the insured pays towards each claim
+	Status           PolicyStatus `json:"status" gorm:"default:active"`
+	EffectiveDate    time.Time    `json:"effective_date" gorm:"not null"`
+	ExpirationDate   time.Time    `json:"expiration_date" gorm:"not null"`
+	RenewalDate      *time.Time   `json:"renewal_date"`
+	GracePeriodEnd   *time.Time   `json:"grace_period_end"`
+	GracePeriodCause string       `json:"grace_period_cause,omitempty"` // why the current grace period was granted
+	AutoRenew        bool         `json:"auto_renew" gorm:"default:false"`
+	PaymentFrequency string       `json:"payment_frequency" gorm:"default:monthly"`    // monthly, quarterly, annually
+	Version          int          `json:"version" gorm:"not null;default:1"`           // incremented on every update for optimistic locking
+	Exclusions       []Exclusion  `json:"exclusions,omitempty" gorm:"serializer:json"` // Losses the policy does not pay for
+
+	// PricingBreakdown itemizes Premium when the term was priced by the pricing engine. It is
+	// what the policy's invoice is itemized from.
+	PricingBreakdown *PricingBreakdown `json:"pricing_breakdown,omitempty" gorm:"serializer:json"`
 
 	// Relationships
 	Product       Product        `json:"product,omitempty" gorm:"foreignKey:ProductID"`
@@ -38,3 +51,51 @@ type Policy struct {
 func (Policy) TableName() string {
 	return "policies"
 }
+
+// policyStatusTransitions lists the statuses a policy may move to from each status.
+// Expired and cancelled policies are final; renewal issues a new policy.
+var policyStatusTransitions = map[PolicyStatus][]PolicyStatus{
+	PolicyStatusPending:   {PolicyStatusActive, PolicyStatusCancelled},
+	PolicyStatusActive:    {PolicyStatusSuspended, PolicyStatusInactive, PolicyStatusExpired, PolicyStatusCancelled},
+	PolicyStatusSuspended: {PolicyStatusActive, PolicyStatusExpired, PolicyStatusCancelled},
+	PolicyStatusInactive:  {PolicyStatusActive, PolicyStatusCancelled},
+	PolicyStatusExpired:   {},
+	PolicyStatusCancelled: {},
+}
+
+// CanTransitionTo reports whether a policy may move from s to status.
+// Staying in the same status is always allowed.
+func (s PolicyStatus) CanTransitionTo(status PolicyStatus) bool {
+	if s == status {
+		return true
+	}
+	for _, next := range policyStatusTransitions[s] {
+		if next == status {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidatePolicyStatusTransition returns an error wrapping ErrInvalidStatusTransition
+// unless a policy may move from one status to another.
+func ValidatePolicyStatusTransition(from, to PolicyStatus) error {
+	if !from.CanTransitionTo(to) {
+		return fmt.Errorf("%w: policy cannot move from %s to %s", ErrInvalidStatusTransition, from, to)
+	}
+	return nil
+}
+
+// TransitionTo moves the policy to status if the transition is allowed.
+func (p *Policy) TransitionTo(status PolicyStatus) error {
+	if err := ValidatePolicyStatusTransition(p.Status, status); err != nil {
+		return err
+	}
+	p.Status = status
+	return nil
+}
+
+// InGracePeriod reports whether the policy is within a grace window at the given time.
+func (p *Policy) InGracePeriod(at time.Time) bool {
+	return p.GracePeriodEnd != nil && at.Before(*p.GracePeriodEnd)
+}
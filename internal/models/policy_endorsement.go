@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PolicyEndorsement records a mid-term change to a policy's coverage and the
+// pro-rated premium adjustment it produced.
+type PolicyEndorsement struct {
+	Base
+	PolicyID         uuid.UUID `json:"policy_id" gorm:"type:uuid;index;not null"`
+	EffectiveDate    time.Time `json:"effective_date" gorm:"not null"`
+	PreviousCoverage float64   `json:"previous_coverage"`
+	NewCoverage      float64   `json:"new_coverage" gorm:"not null"`
+	PreviousPremium  float64   `json:"previous_premium"`
+	NewPremium       float64   `json:"new_premium" gorm:"not null"`
+	ProratedAmount   float64   `json:"prorated_amount"` // positive is charged, negative is credited
+	AdjustmentType   string    `json:"adjustment_type" gorm:"not null"`
+	Currency         string    `json:"currency" gorm:"default:USD"`
+	Reason           string    `json:"reason"`
+
+	// Relationships
+	Policy Policy `json:"policy,omitempty" gorm:"foreignKey:PolicyID"`
+}
+
+// TableName returns the table name for the PolicyEndorsement model.
+func (PolicyEndorsement) TableName() string {
+	return "policy_endorsements"
+}
+
+// Endorsement adjustment type constants.
+const (
+	EndorsementAdditionalPremium = "additional_premium"
+	EndorsementReturnPremium     = "return_premium"
+	EndorsementNoAdjustment      = "no_adjustment"
+)
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPolicyStatusTransitions(t *testing.T) {
+	statuses := []PolicyStatus{
+		PolicyStatusPending,
+		PolicyStatusActive,
+		PolicyStatusSuspended,
+		PolicyStatusInactive,
+		PolicyStatusExpired,
+		PolicyStatusCancelled,
+	}
+
+	allowed := map[PolicyStatus][]PolicyStatus{
+		PolicyStatusPending:   {PolicyStatusActive, PolicyStatusCancelled},
+		PolicyStatusActive:    {PolicyStatusSuspended, PolicyStatusInactive, PolicyStatusExpired, PolicyStatusCancelled},
+		PolicyStatusSuspended: {PolicyStatusActive, PolicyStatusExpired, PolicyStatusCancelled},
+		PolicyStatusInactive:  {PolicyStatusActive, PolicyStatusCancelled},
+	}
+
+	for _, from := range statuses {
+		for _, to := range statuses {
+			want := from == to
+			for _, next := range allowed[from] {
+				if next == to {
+					want = true
+				}
+			}
+
+			policy := &Policy{Status: from}
+			err := policy.TransitionTo(to)
+
+			if want {
+				if err != nil {
+					t.Errorf("expected %s -> %s to be allowed, got %v", from, to, err)
+				}
+				if policy.Status != to {
+					t.Errorf("expected status %s after %s -> %s, got %s", to, from, to, policy.Status)
+				}
+				continue
+			}
+
+			if !errors.Is(err, ErrInvalidStatusTransition) {
+				t.Errorf("expected %s -> %s to be rejected, got %v", from, to, err)
+			}
+			if policy.Status != from {
+				t.Errorf("expected status to remain %s after rejected %s -> %s, got %s", from, from, to, policy.Status)
+			}
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+)
+
+// PremiumTaxReport is the premium tax collected on policy invoices paid in a period,
+// broken down by the jurisdiction of the insured risk.
+type PremiumTaxReport struct {
+	Base
+	PeriodStart  time.Time              `json:"period_start" gorm:"not null"`
+	PeriodEnd    time.Time              `json:"period_end" gorm:"not null;index"`
+	PolicyCount  int                    `json:"policy_count"`
+	InvoiceCount int                    `json:"invoice_count"`
+	Lines        []PremiumTaxReportLine `json:"lines" gorm:"serializer:json"`
+}
+
+// PremiumTaxReportLine is the premium and tax collected in one jurisdiction and currency.
+type PremiumTaxReportLine struct {
+	Jurisdiction  string  `json:"jurisdiction"`
+	Currency      string  `json:"currency"`
+	PolicyCount   int     `json:"policy_count"`
+	InvoiceCount  int     `json:"invoice_count"`
+	PremiumAmount float64 `json:"premium_amount"`
+	TaxAmount     float64 `json:"tax_amount"`
+}
+
+// TableName returns the table name for the PremiumTaxReport model.
+func (PremiumTaxReport) TableName() string {
+	return "premium_tax_reports"
+}
+
+// UnknownJurisdiction groups invoices for policies issued without a jurisdiction.
+const UnknownJurisdiction = "UNKNOWN"
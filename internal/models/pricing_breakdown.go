@@ -0,0 +1,31 @@
+package models
+
+// PricingBreakdown provides detailed breakdown of pricing components.
+type PricingBreakdown struct {
+	BaseRate            float64 `json:"base_rate"`
+	CoverageAdjustment  float64 `json:"coverage_adjustment"`
+	RiskAdjustment      float64 `json:"risk_adjustment"`
+	DiscountAdjustment  float64 `json:"discount_adjustment"`
+	TaxAdjustment       float64 `json:"tax_adjustment"`
+	FrequencyAdjustment float64 `json:"frequency_adjustment"`
+	MarketAdjustment    float64 `json:"market_adjustment"`
+	// BoundsAdjustment is the change made by the non-negative floor, adjustment caps, the
+	// minimum and maximum premium and the technical premium floor.
+	BoundsAdjustment float64 `json:"bounds_adjustment"`
+	// RoundingAdjustment is the change made by the rounding policy.
+	RoundingAdjustment float64 `json:"rounding_adjustment"`
+	TotalAdjustment    float64 `json:"total_adjustment"`
+}
+
+// Total returns the premium the breakdown accounts for: the base rate plus every adjustment.
+func (b PricingBreakdown) Total() float64 {
+	return b.BaseRate +
+		b.CoverageAdjustment +
+		b.RiskAdjustment +
+		b.DiscountAdjustment +
+		b.TaxAdjustment +
+		b.FrequencyAdjustment +
+		b.MarketAdjustment +
+		b.BoundsAdjustment +
+		b.RoundingAdjustment
+}
@@ -16,8 +16,11 @@ type Product struct {
 	BasePrice          float64    `json:"base_price" gorm:"not null"`
 	Currency           string     `json:"currency" gorm:"default:USD"`
 	CoverageAmount     float64    `json:"coverage_amount"`
-	CoveragePeriod     int        `json:"coverage_period"` // in days
+	MinCoverageAmount  float64    `json:"min_coverage_amount"` // 0 means no minimum
+	MaxCoverageAmount  float64    `json:"max_coverage_amount"` // 0 means no maximum
+	CoveragePeriod     int        `json:"coverage_period"`     // in days
 	Deductible         float64    `json:"deductible"`
+	WaitingPeriodDays  int        `json:"waiting_period_days"` // Days after the effective date before claims are payable
 	Status             string     `json:"status" gorm:"default:active"`
 	EffectiveDate      time.Time  `json:"effective_date"`
 	ExpirationDate     *time.Time `json:"expiration_date"`
@@ -34,3 +37,15 @@ type Product struct {
 func (Product) TableName() string {
 	return "products"
 }
+
+// CoverageWithinLimits reports whether a coverage amount is within the product's minimum and
+// maximum coverage. Unset limits are not enforced.
+func (p *Product) CoverageWithinLimits(amount float64) bool {
+	if p.MinCoverageAmount > 0 && amount < p.MinCoverageAmount {
+		return false
+	}
+	if p.MaxCoverageAmount > 0 && amount > p.MaxCoverageAmount {
+		return false
+	}
+	return true
+}
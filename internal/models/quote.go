@@ -21,6 +21,26 @@ type Quote struct {
 	ValidUntil  time.Time    `json:"valid_until" gorm:"not null"`
 	RiskFactors []RiskFactor `json:"risk_factors" gorm:"type:json"`
 
+	// CoverageAmount is the sum insured the quote is priced for.
+	CoverageAmount float64 `json:"coverage_amount,omitempty"`
+
+	// CoverageTier and Deductible are the coverage tier and deductible the quote is priced
+	// for. An empty tier is the standard tier and a zero deductible means none was chosen.
+	CoverageTier string  `json:"coverage_tier,omitempty"`
+	Deductible   float64 `json:"deductible,omitempty" gorm:"default:0"`
+
+	// DocumentURL is where the quote's PDF can be downloaded once it has been generated.
+	DocumentURL string `json:"document_url,omitempty"`
+
+	// RenewalOfPolicyID is set on renewal offers to the policy being renewed.
+	RenewalOfPolicyID *uuid.UUID `json:"renewal_of_policy_id,omitempty" gorm:"index"`
+
+	// RateTableVersion is the pricing rate table version the quote was priced from.
+	RateTableVersion string `json:"rate_table_version,omitempty"`
+
+	// PricingBreakdown itemizes FinalPrice when the quote was priced by the pricing engine.
+	PricingBreakdown *PricingBreakdown `json:"pricing_breakdown,omitempty" gorm:"serializer:json"`
+
 	// Relationships
 	Product  Product  `json:"product,omitempty" gorm:"foreignKey:ProductID"`
 	User     User     `json:"user,omitempty" gorm:"foreignKey:UserID"`
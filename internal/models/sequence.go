@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// Sequence is a named counter used to issue unique, increasing, human-readable numbers such as
+// policy and claim numbers. A value drawn for a record that then fails to save is not reused,
+// so numbers may have gaps. Each name counts independently, e.g. one sequence per prefix and year.
+type Sequence struct {
+	Name      string    `json:"name" gorm:"primaryKey"`
+	Value     int64     `json:"value" gorm:"not null;default:0"` // Last value issued
+	UpdatedAt time.Time `json:"updated_at"`
+}
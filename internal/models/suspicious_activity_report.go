@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SuspiciousActivityReport is a regulatory report of suspected money laundering or fraud by a
+// customer. Reports start as drafts for a compliance officer to review, are filed with the
+// regulator and are then acknowledged with the regulator's reference. A user has at most one
+// open draft for compliance findings, and a claim at most one open draft for fraud findings.
+type SuspiciousActivityReport struct {
+	Base
+	ReportNumber    string                 `json:"report_number" gorm:"uniqueIndex;not null"`
+	UserID          uuid.UUID              `json:"user_id" gorm:"type:uuid;index;not null;uniqueIndex:idx_suspicious_activity_reports_user_draft,where:status = 'draft' AND claim_id IS NULL"`
+	CustomerID      *uuid.UUID             `json:"customer_id" gorm:"type:uuid;index"`
+	ClaimID         *uuid.UUID             `json:"claim_id" gorm:"type:uuid;index;uniqueIndex:idx_suspicious_activity_reports_claim_draft,where:status = 'draft'"`
+	SubjectName     string                 `json:"subject_name"`
+	Reason          string                 `json:"reason" gorm:"not null"`
+	Indicators      []SARIndicator         `json:"indicators" gorm:"serializer:json"`
+	Amount          float64                `json:"amount" gorm:"default:0"` // Amount involved in the suspicious activity
+	Currency        string                 `json:"currency" gorm:"default:USD"`
+	Status          string                 `json:"status" gorm:"default:draft"`
+	FiledAt         *time.Time             `json:"filed_at"`
+	FilingReference string                 `json:"filing_reference"` // Reference returned when the report was filed
+	AcknowledgedAt  *time.Time             `json:"acknowledged_at"`
+	Acknowledgement string                 `json:"acknowledgement"` // Regulator's acknowledgement reference
+	Metadata        map[string]interface{} `json:"metadata" gorm:"serializer:json"`
+}
+
+// SARIndicator is a finding that made the activity suspicious, such as a compliance violation
+// or a fraud factor.
+type SARIndicator struct {
+	Code        string `json:"code"`
+	Severity    string `json:"severity"`
+	Description string `json:"description"`
+}
+
+// TableName returns the table name for the SuspiciousActivityReport model.
+func (SuspiciousActivityReport) TableName() string {
+	return "suspicious_activity_reports"
+}
+
+// Suspicious activity report status constants.
+const (
+	SARStatusDraft        = "draft"
+	SARStatusFiled        = "filed"
+	SARStatusAcknowledged = "acknowledged"
+)
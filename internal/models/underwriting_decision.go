@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UnderwritingDecision records an underwriting decision on an application. Automated
+// decisions have no reviewer; a manual override is recorded as a new decision on the same
+// application, linked to the decision it replaces.
+type UnderwritingDecision struct {
+	Base
+	ApplicationID uuid.UUID  `json:"application_id" gorm:"type:uuid;not null;index"`
+	UserID        uuid.UUID  `json:"user_id" gorm:"type:uuid;not null;index"`
+	ProductID     uuid.UUID  `json:"product_id" gorm:"type:uuid"`
+	Decision      string     `json:"decision" gorm:"not null"` // approved, declined, conditional, pending_review
+	RiskScore     float64    `json:"risk_score"`
+	Premium       float64    `json:"premium"`
+	Currency      string     `json:"currency"`
+	Reasons       []string   `json:"reasons" gorm:"serializer:json"`
+	ValidUntil    time.Time  `json:"valid_until"`
+	ReviewedBy    *uuid.UUID `json:"reviewed_by,omitempty" gorm:"type:uuid"`
+	SupersedesID  *uuid.UUID `json:"supersedes_id,omitempty" gorm:"type:uuid"` // decision this override replaces
+}
+
+// TableName returns the table name for the UnderwritingDecision model.
+func (UnderwritingDecision) TableName() string {
+	return "underwriting_decisions"
+}
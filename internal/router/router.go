@@ -31,13 +31,14 @@ type Router struct {
 	claimService   *services.ClaimService
 
 	// Handlers
-	productHandler *handlers.ProductHandler
-	quoteHandler   *handlers.QuoteHandler
-	policyHandler  *handlers.PolicyHandler
-	claimHandler   *handlers.ClaimHandler
-	rulesHandler   *handlers.RulesHandler
-	healthHandler  *handlers.HealthHandler
-	versionHandler *handlers.VersionHandler
+	productHandler  *handlers.ProductHandler
+	quoteHandler    *handlers.QuoteHandler
+	policyHandler   *handlers.PolicyHandler
+	claimHandler    *handlers.ClaimHandler
+	rulesHandler    *handlers.RulesHandler
+	documentHandler *handlers.DocumentHandler
+	healthHandler   *handlers.HealthHandler
+	versionHandler  *handlers.VersionHandler
 
 	// Middleware
 	rateLimitEngine *middleware.PolicyEngine
@@ -114,6 +115,11 @@ func (rt *Router) RegisterRoutes() {
 		rt.Get(rt.cfg.MetricsPath, rt.metrics.Handler().ServeHTTP)
 	}
 
+	// Register the download route of generated documents
+	if rt.documentHandler != nil {
+		rt.documentHandler.RegisterRoutes(rt.Router, rt.cfg.Storage.BaseURL)
+	}
+
 	// Register API routes
 	rt.Route("/v1", func(r chi.Router) {
 		// Register handler routes
@@ -180,9 +186,17 @@ func NewWithApp(r chi.Router, application *app.Application) *Router {
 	// Create handlers using services from the wired application
 	productHandler := handlers.NewProductHandler(application.ProductService)
 	quoteHandler := handlers.NewQuoteHandler(application.QuoteService)
+	quoteHandler.SetRenewalService(application.PolicyLifecycleService)
 	policyHandler := handlers.NewPolicyHandler(application.PolicyService)
+	policyHandler.SetBeneficiaryService(application.BeneficiaryService)
 	claimHandler := handlers.NewClaimHandler(application.ClaimService)
+	if clients, err := handlers.NewClientResolver(application.Config.Server); err != nil {
+		application.Logger.Error("Invalid trusted proxy configuration", zap.Error(err))
+	} else {
+		claimHandler.SetClientResolver(clients)
+	}
 	rulesHandler := handlers.NewRulesHandler(application.ConfigManager, application.Logger)
+	documentHandler := handlers.NewDocumentHandler(application.BlobStore)
 	healthHandler := handlers.NewHealthHandler(application.Database)
 	versionHandler := handlers.NewVersionHandler()
 
@@ -215,6 +229,7 @@ func NewWithApp(r chi.Router, application *app.Application) *Router {
 		policyHandler:   policyHandler,
 		claimHandler:    claimHandler,
 		rulesHandler:    rulesHandler,
+		documentHandler: documentHandler,
 		healthHandler:   healthHandler,
 		versionHandler:  versionHandler,
 		rateLimitEngine: rateLimitEngine,
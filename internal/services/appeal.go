@@ -0,0 +1,415 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/authorization"
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/events"
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/edsonmichaque/bazaruto/internal/store"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrAppealLimitReached is returned when a claim or application has used all of its appeals.
+	ErrAppealLimitReached = errors.New("appeal limit reached")
+	// ErrAppealAlreadyOpen is returned when an appeal is filed while another is still under review.
+	ErrAppealAlreadyOpen = errors.New("an appeal is already under review")
+)
+
+// AppealService handles appeals against denied claims and declined underwriting applications.
+// Each pending appeal is a review task assigned to a reviewer with a due date.
+type AppealService struct {
+	appealStore         store.AppealStore
+	claimStore          store.ClaimStore
+	underwritingService *UnderwritingService
+	configManager       *config.Manager
+	eventService        *EventService
+	authorizer          authorization.Authorizer
+	auditService        *AuditService
+	logger              *logger.Logger
+}
+
+// NewAppealService creates a new AppealService instance.
+func NewAppealService(
+	logger *logger.Logger,
+	configManager *config.Manager,
+	appealStore store.AppealStore,
+	claimStore store.ClaimStore,
+	underwritingService *UnderwritingService,
+	eventService *EventService,
+	authorizer authorization.Authorizer,
+	auditService *AuditService,
+) *AppealService {
+	return &AppealService{
+		appealStore:         appealStore,
+		claimStore:          claimStore,
+		underwritingService: underwritingService,
+		configManager:       configManager,
+		eventService:        eventService,
+		authorizer:          authorizer,
+		auditService:        auditService,
+		logger:              logger,
+	}
+}
+
+// AppealRequest represents a request to appeal a decision. The appellant is the actor in context.
+type AppealRequest struct {
+	Reason     string            `json:"reason"`
+	Evidence   []models.Document `json:"evidence"`
+	AssignedTo *uuid.UUID        `json:"assigned_to"` // reviewer the appeal is assigned to
+}
+
+// AppealReview represents a reviewer's decision on a claim appeal. The reviewer is the actor in context.
+type AppealReview struct {
+	Overturn bool   `json:"overturn"` // approve the claim instead of upholding the denial
+	Notes    string `json:"notes"`
+}
+
+// FileClaimAppeal files an appeal against a denied claim and moves the claim under appeal.
+// Only the claimant may appeal their claim.
+func (s *AppealService) FileClaimAppeal(ctx context.Context, claimID uuid.UUID, request *AppealRequest) (*models.Appeal, error) {
+	actor, ok := authorization.ActorFromContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("%w: no actor in context", authorization.ErrUnauthorized)
+	}
+	if err := validateAppealRequest(request); err != nil {
+		return nil, err
+	}
+
+	claim, err := s.claimStore.GetClaim(ctx, claimID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch claim: %w", err)
+	}
+	if claim.UserID != actor.ID {
+		return nil, fmt.Errorf("%w: only the claimant can appeal claim %s", authorization.ErrUnauthorized, claimID)
+	}
+	if claim.Status != models.ClaimStatusDenied {
+		return nil, fmt.Errorf("only denied claims can be appealed")
+	}
+
+	appeal, err := s.newAppeal(ctx, models.AppealSubjectClaim, claimID, actor.ID, models.ClaimStatusDenied, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := claim.TransitionTo(models.ClaimStatusUnderAppeal); err != nil {
+		return nil, err
+	}
+	if err := s.appealStore.FileClaimAppeal(ctx, claim, appeal); err != nil {
+		return nil, fmt.Errorf("failed to file appeal: %w", err)
+	}
+
+	s.publishAppealFiled(ctx, appeal)
+	return appeal, nil
+}
+
+// FileUnderwritingAppeal files an appeal against the declined underwriting decision recorded
+// for an application. Only the applicant may appeal their application.
+func (s *AppealService) FileUnderwritingAppeal(ctx context.Context, applicationID uuid.UUID, request *AppealRequest) (*models.Appeal, error) {
+	actor, ok := authorization.ActorFromContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("%w: no actor in context", authorization.ErrUnauthorized)
+	}
+	if applicationID == uuid.Nil {
+		return nil, fmt.Errorf("application ID is required")
+	}
+	if err := validateAppealRequest(request); err != nil {
+		return nil, err
+	}
+	if s.underwritingService == nil {
+		return nil, fmt.Errorf("underwriting service is not configured")
+	}
+
+	decision, err := s.underwritingService.GetApplicationDecision(ctx, applicationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch underwriting decision: %w", err)
+	}
+	if decision.UserID != actor.ID {
+		return nil, fmt.Errorf("%w: only the applicant can appeal application %s", authorization.ErrUnauthorized, applicationID)
+	}
+	if decision.Decision != "declined" {
+		return nil, fmt.Errorf("only declined applications can be appealed")
+	}
+
+	appeal, err := s.newAppeal(ctx, models.AppealSubjectUnderwriting, applicationID, actor.ID, decision.Decision, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.appealStore.CreateAppeal(ctx, appeal); err != nil {
+		return nil, fmt.Errorf("failed to create appeal: %w", err)
+	}
+
+	s.publishAppealFiled(ctx, appeal)
+	return appeal, nil
+}
+
+// AddEvidence attaches new evidence to an appeal that is still under review.
+func (s *AppealService) AddEvidence(ctx context.Context, appealID uuid.UUID, evidence ...models.Document) (*models.Appeal, error) {
+	appeal, err := s.appealStore.GetAppeal(ctx, appealID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch appeal: %w", err)
+	}
+	if !appeal.IsOpen() {
+		return nil, fmt.Errorf("appeal is no longer under review")
+	}
+
+	appeal.Evidence = append(appeal.Evidence, evidence...)
+	if err := s.appealStore.UpdateAppeal(ctx, appeal); err != nil {
+		return nil, fmt.Errorf("failed to update appeal: %w", err)
+	}
+
+	return appeal, nil
+}
+
+// ResolveClaimAppeal records the reviewer's decision on a claim appeal. Overturning the denial
+// approves the claim; upholding it returns the claim to denied.
+func (s *AppealService) ResolveClaimAppeal(ctx context.Context, appealID uuid.UUID, review *AppealReview) (*models.Appeal, error) {
+	if review == nil {
+		return nil, fmt.Errorf("appeal review is required")
+	}
+	reviewerID, err := s.authorizeReview(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	appeal, err := s.openAppeal(ctx, appealID, models.AppealSubjectClaim)
+	if err != nil {
+		return nil, err
+	}
+
+	claim, err := s.claimStore.GetClaim(ctx, appeal.SubjectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch claim: %w", err)
+	}
+
+	outcome := models.ClaimStatusDenied
+	if review.Overturn {
+		outcome = models.ClaimStatusApproved
+	}
+	if err := claim.TransitionTo(outcome); err != nil {
+		return nil, err
+	}
+	if review.Overturn {
+		claim.DenialReason = nil
+	}
+
+	return s.resolveAppeal(ctx, appeal, claim, reviewerID, outcome, review.Overturn, review.Notes)
+}
+
+// RerunUnderwritingDecision re-runs underwriting for an appealed application, with the appeal's
+// evidence attached to the application data, and resolves the appeal with the new decision.
+func (s *AppealService) RerunUnderwritingDecision(ctx context.Context, appealID uuid.UUID, request *UnderwritingRequest) (*models.Appeal, *UnderwritingDecision, error) {
+	reviewerID, err := s.authorizeReview(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if request == nil {
+		return nil, nil, fmt.Errorf("underwriting request is required")
+	}
+	if s.underwritingService == nil {
+		return nil, nil, fmt.Errorf("underwriting service is not configured")
+	}
+
+	appeal, err := s.openAppeal(ctx, appealID, models.AppealSubjectUnderwriting)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if request.ApplicationData == nil {
+		request.ApplicationData = make(map[string]interface{})
+	}
+	request.ApplicationID = appeal.SubjectID
+	request.ApplicationData["appeal_id"] = appeal.ID.String()
+	request.ApplicationData["appeal_evidence"] = appeal.Evidence
+
+	decision, err := s.underwritingService.ProcessUnderwriting(ctx, request)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to re-run underwriting: %w", err)
+	}
+
+	appeal, err = s.resolveAppeal(ctx, appeal, nil, reviewerID, decision.Decision, decision.Decision != "declined", "Underwriting decision re-run on appeal")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return appeal, decision, nil
+}
+
+// ListAppeals retrieves the appeals filed against a claim or application.
+func (s *AppealService) ListAppeals(ctx context.Context, subjectType string, subjectID uuid.UUID) ([]*models.Appeal, error) {
+	return s.appealStore.ListAppealsBySubject(ctx, subjectType, subjectID)
+}
+
+// newAppeal builds the next appeal against a subject, enforcing the appeal limit and
+// rejecting a new appeal while a previous one is still under review.
+func (s *AppealService) newAppeal(ctx context.Context, subjectType string, subjectID, userID uuid.UUID, originalDecision string, request *AppealRequest) (*models.Appeal, error) {
+	rules := s.configManager.GetConfig().Appeals
+
+	previous, err := s.appealStore.ListAppealsBySubject(ctx, subjectType, subjectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list appeals: %w", err)
+	}
+	for _, appeal := range previous {
+		if appeal.IsOpen() {
+			return nil, ErrAppealAlreadyOpen
+		}
+	}
+	if len(previous) >= rules.MaxAppeals {
+		return nil, fmt.Errorf("%w: %d of %d appeals used", ErrAppealLimitReached, len(previous), rules.MaxAppeals)
+	}
+
+	return &models.Appeal{
+		SubjectType:      subjectType,
+		SubjectID:        subjectID,
+		UserID:           userID,
+		Sequence:         len(previous) + 1,
+		Reason:           request.Reason,
+		Evidence:         request.Evidence,
+		Status:           models.AppealStatusPendingReview,
+		OriginalDecision: originalDecision,
+		AssignedTo:       request.AssignedTo,
+		ReviewDueDate:    time.Now().AddDate(0, 0, rules.ReviewDays),
+	}, nil
+}
+
+// openAppeal fetches an appeal of the given subject type that is still under review.
+func (s *AppealService) openAppeal(ctx context.Context, appealID uuid.UUID, subjectType string) (*models.Appeal, error) {
+	appeal, err := s.appealStore.GetAppeal(ctx, appealID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch appeal: %w", err)
+	}
+	if appeal.SubjectType != subjectType {
+		return nil, fmt.Errorf("appeal is not a %s appeal", subjectType)
+	}
+	if !appeal.IsOpen() {
+		return nil, fmt.Errorf("appeal is no longer under review")
+	}
+	return appeal, nil
+}
+
+// authorizeReview returns the ID of the reviewer in context, who must hold the permission
+// to resolve appeals.
+func (s *AppealService) authorizeReview(ctx context.Context) (uuid.UUID, error) {
+	actor, ok := authorization.ActorFromContext(ctx)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("%w: no actor in context", authorization.ErrUnauthorized)
+	}
+	if s.authorizer != nil {
+		if err := s.authorizer.Authorize(ctx, authorization.PermissionAppealResolve); err != nil {
+			return uuid.Nil, err
+		}
+	}
+	return actor.ID, nil
+}
+
+// resolveAppeal records the outcome of a review and publishes the resolution. A claim
+// appeal's claim, already moved to the outcome, is saved in the same transaction.
+func (s *AppealService) resolveAppeal(ctx context.Context, appeal *models.Appeal, claim *models.Claim, reviewerID uuid.UUID, outcome string, overturned bool, notes string) (*models.Appeal, error) {
+	before := map[string]interface{}{"status": appeal.Status, "decision": appeal.OriginalDecision}
+	now := time.Now()
+	appeal.Status = models.AppealStatusUpheld
+	if overturned {
+		appeal.Status = models.AppealStatusOverturned
+	}
+	appeal.Outcome = outcome
+	appeal.ReviewedBy = &reviewerID
+	appeal.ReviewedAt = &now
+	appeal.ReviewNotes = notes
+
+	if claim != nil {
+		if err := s.appealStore.ResolveClaimAppeal(ctx, claim, appeal); err != nil {
+			return nil, fmt.Errorf("failed to resolve appeal: %w", err)
+		}
+	} else if err := s.appealStore.UpdateAppeal(ctx, appeal); err != nil {
+		return nil, fmt.Errorf("failed to update appeal: %w", err)
+	}
+
+	if s.auditService != nil {
+		entry := &models.AuditLog{
+			EntityType: models.AuditEntityAppeal,
+			EntityID:   appeal.ID.String(),
+			Action:     models.AuditActionAppealResolved,
+			ActorID:    &reviewerID,
+			Before:     before,
+			After:      map[string]interface{}{"status": appeal.Status, "outcome": appeal.Outcome},
+			Reason:     notes,
+		}
+		if err := s.auditService.Record(ctx, entry); err != nil {
+			return nil, fmt.Errorf("failed to record appeal resolution: %w", err)
+		}
+	}
+
+	s.logger.Info("Appeal resolved",
+		zap.String("appeal_id", appeal.ID.String()),
+		zap.String("subject_type", appeal.SubjectType),
+		zap.String("subject_id", appeal.SubjectID.String()),
+		zap.String("status", appeal.Status))
+
+	if s.eventService != nil {
+		resolvedEvent := events.NewAppealResolvedEvent(
+			appeal.ID,
+			appeal.SubjectType,
+			appeal.SubjectID,
+			appeal.UserID,
+			appeal.Status,
+			appeal.OriginalDecision,
+			appeal.Outcome,
+			now,
+		)
+		if err := s.eventService.PublishEvent(ctx, resolvedEvent); err != nil {
+			s.logger.Error("Failed to publish appeal resolved event",
+				zap.Error(err),
+				zap.String("appeal_id", appeal.ID.String()))
+		}
+	}
+
+	return appeal, nil
+}
+
+// publishAppealFiled notifies reviewers of a new appeal.
+func (s *AppealService) publishAppealFiled(ctx context.Context, appeal *models.Appeal) {
+	s.logger.Info("Appeal filed",
+		zap.String("appeal_id", appeal.ID.String()),
+		zap.String("subject_type", appeal.SubjectType),
+		zap.String("subject_id", appeal.SubjectID.String()),
+		zap.Int("sequence", appeal.Sequence))
+
+	if s.eventService == nil {
+		return
+	}
+
+	filedEvent := events.NewAppealFiledEvent(
+		appeal.ID,
+		appeal.SubjectType,
+		appeal.SubjectID,
+		appeal.UserID,
+		appeal.Sequence,
+		appeal.AssignedTo,
+		appeal.ReviewDueDate,
+		appeal.CreatedAt,
+	)
+	if err := s.eventService.PublishEvent(ctx, filedEvent); err != nil {
+		s.logger.Error("Failed to publish appeal filed event",
+			zap.Error(err),
+			zap.String("appeal_id", appeal.ID.String()))
+	}
+}
+
+// validateAppealRequest validates the fields required to file an appeal.
+func validateAppealRequest(request *AppealRequest) error {
+	if request == nil {
+		return fmt.Errorf("appeal request is required")
+	}
+	if request.Reason == "" {
+		return fmt.Errorf("appeal reason is required")
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/edsonmichaque/bazaruto/internal/authorization"
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileClaimAppealMovesClaimUnderAppealAndEnforcesLimit(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	configManager := config.NewManager(log, filepath.Join(t.TempDir(), "business_rules.json"))
+	cfg := configManager.GetConfig()
+	cfg.Appeals.MaxAppeals = 1
+	require.NoError(t, configManager.UpdateConfig(ctx, cfg))
+
+	reason := "Pre-existing damage"
+	claim := &models.Claim{UserID: uuid.New(), ClaimAmount: 5000, Status: models.ClaimStatusDenied, DenialReason: &reason}
+	claimStore := newFakeClaimStore(claim)
+	auditStore := &fakeAuditLogStore{}
+	authorizer := authorization.NewRBACAuthorizer(authorization.NewRBACService())
+	svc := NewAppealService(log, configManager, &fakeAppealStore{}, claimStore, nil, nil, authorizer, NewAuditService(log, auditStore))
+
+	claimant := authorization.WithActor(ctx, &authorization.Actor{ID: claim.UserID, Role: authorization.RoleCustomer})
+	request := &AppealRequest{
+		Reason:   "Repair invoice shows the damage is new",
+		Evidence: []models.Document{{FileName: "invoice.pdf", FileType: "application/pdf"}},
+	}
+	appeal, err := svc.FileClaimAppeal(claimant, claim.ID, request)
+	require.NoError(t, err)
+	assert.Equal(t, models.ClaimStatusUnderAppeal, claim.Status)
+	assert.Equal(t, models.AppealStatusPendingReview, appeal.Status)
+	assert.Equal(t, claim.UserID, appeal.UserID)
+	assert.Equal(t, 1, appeal.Sequence)
+	assert.False(t, appeal.ReviewDueDate.IsZero())
+
+	// The claimant cannot decide their own appeal.
+	_, err = svc.ResolveClaimAppeal(claimant, appeal.ID, &AppealReview{Overturn: true})
+	assert.ErrorIs(t, err, authorization.ErrUnauthorized)
+	assert.Equal(t, models.ClaimStatusUnderAppeal, claim.Status)
+
+	reviewer := &authorization.Actor{ID: uuid.New(), Role: authorization.RoleClaimsSupervisor}
+	resolved, err := svc.ResolveClaimAppeal(authorization.WithActor(ctx, reviewer), appeal.ID, &AppealReview{Notes: "Damage predates the policy"})
+	require.NoError(t, err)
+	assert.Equal(t, models.AppealStatusUpheld, resolved.Status)
+	assert.Equal(t, reviewer.ID, *resolved.ReviewedBy)
+	assert.Equal(t, models.ClaimStatusDenied, claim.Status)
+
+	require.Len(t, auditStore.entries, 1)
+	assert.Equal(t, models.AuditActionAppealResolved, auditStore.entries[0].Action)
+	assert.Equal(t, reviewer.ID, *auditStore.entries[0].ActorID)
+
+	_, err = svc.FileClaimAppeal(claimant, claim.ID, request)
+	assert.ErrorIs(t, err, ErrAppealLimitReached)
+	assert.Equal(t, models.ClaimStatusDenied, claim.Status)
+}
+
+func TestOverturnedClaimAppealApprovesClaim(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	claim := &models.Claim{UserID: uuid.New(), ClaimAmount: 5000, Status: models.ClaimStatusDenied}
+	svc := NewAppealService(log, config.NewManager(log, ""), &fakeAppealStore{}, newFakeClaimStore(claim), nil, nil, nil, nil)
+	claimant := authorization.WithActor(ctx, &authorization.Actor{ID: claim.UserID, Role: authorization.RoleCustomer})
+
+	// Only the claimant may appeal the claim.
+	stranger := authorization.WithActor(ctx, &authorization.Actor{ID: uuid.New(), Role: authorization.RoleCustomer})
+	_, err := svc.FileClaimAppeal(stranger, claim.ID, &AppealRequest{Reason: "Not my claim"})
+	assert.ErrorIs(t, err, authorization.ErrUnauthorized)
+	assert.Equal(t, models.ClaimStatusDenied, claim.Status)
+
+	appeal, err := svc.FileClaimAppeal(claimant, claim.ID, &AppealRequest{Reason: "Police report attached"})
+	require.NoError(t, err)
+
+	// The claim is under appeal, so it cannot be appealed again until the review completes.
+	_, err = svc.FileClaimAppeal(claimant, claim.ID, &AppealRequest{Reason: "Second attempt"})
+	assert.Error(t, err)
+
+	appeal, err = svc.AddEvidence(ctx, appeal.ID, models.Document{FileName: "police_report.pdf"})
+	require.NoError(t, err)
+	assert.Len(t, appeal.Evidence, 1)
+
+	reviewer := authorization.WithActor(ctx, &authorization.Actor{ID: uuid.New(), Role: authorization.RoleAdmin})
+	resolved, err := svc.ResolveClaimAppeal(reviewer, appeal.ID, &AppealReview{Overturn: true})
+	require.NoError(t, err)
+	assert.Equal(t, models.AppealStatusOverturned, resolved.Status)
+	assert.Equal(t, models.ClaimStatusApproved, claim.Status)
+
+	_, err = svc.AddEvidence(ctx, appeal.ID, models.Document{FileName: "late.pdf"})
+	assert.Error(t, err)
+}
+
+func TestUnderwritingAppealUsesStoredDecision(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	decisions := &fakeDecisionStore{}
+	underwriting := NewUnderwritingService(log, nil, nil, nil, nil, nil, nil, nil, nil)
+	underwriting.SetDecisionStore(decisions)
+	svc := NewAppealService(log, config.NewManager(log, ""), &fakeAppealStore{}, nil, underwriting, nil, nil, nil)
+
+	applicant := uuid.New()
+	approved := &models.UnderwritingDecision{ApplicationID: uuid.New(), UserID: applicant, Decision: "approved"}
+	declined := &models.UnderwritingDecision{ApplicationID: uuid.New(), UserID: applicant, Decision: "declined"}
+	require.NoError(t, decisions.CreateDecision(ctx, approved))
+	require.NoError(t, decisions.CreateDecision(ctx, declined))
+
+	actx := authorization.WithActor(ctx, &authorization.Actor{ID: applicant, Role: authorization.RoleCustomer})
+	request := &AppealRequest{Reason: "Updated medical report"}
+
+	_, err := svc.FileUnderwritingAppeal(actx, approved.ApplicationID, request)
+	assert.Error(t, err)
+
+	_, err = svc.FileUnderwritingAppeal(actx, uuid.New(), request)
+	assert.Error(t, err)
+
+	stranger := authorization.WithActor(ctx, &authorization.Actor{ID: uuid.New(), Role: authorization.RoleCustomer})
+	_, err = svc.FileUnderwritingAppeal(stranger, declined.ApplicationID, request)
+	assert.ErrorIs(t, err, authorization.ErrUnauthorized)
+
+	appeal, err := svc.FileUnderwritingAppeal(actx, declined.ApplicationID, request)
+	require.NoError(t, err)
+	assert.Equal(t, models.AppealSubjectUnderwriting, appeal.SubjectType)
+	assert.Equal(t, "declined", appeal.OriginalDecision)
+	assert.Equal(t, applicant, appeal.UserID)
+
+	_, err = svc.FileUnderwritingAppeal(actx, declined.ApplicationID, request)
+	assert.ErrorIs(t, err, ErrAppealAlreadyOpen)
+}
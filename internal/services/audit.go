@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/edsonmichaque/bazaruto/internal/store"
+	"go.uber.org/zap"
+)
+
+// AuditService records manual overrides made in underwriting and claim processing.
+type AuditService struct {
+	auditStore store.AuditLogStore
+	logger     *logger.Logger
+}
+
+// NewAuditService creates a new AuditService instance.
+func NewAuditService(logger *logger.Logger, auditStore store.AuditLogStore) *AuditService {
+	return &AuditService{
+		auditStore: auditStore,
+		logger:     logger,
+	}
+}
+
+// Record writes an entry to the audit log. OccurredAt defaults to the current time.
+func (s *AuditService) Record(ctx context.Context, entry *models.AuditLog) error {
+	if entry == nil {
+		return fmt.Errorf("audit entry cannot be nil")
+	}
+	if entry.EntityType == "" || entry.EntityID == "" {
+		return fmt.Errorf("audit entity is required")
+	}
+	if entry.Action == "" {
+		return fmt.Errorf("audit action is required")
+	}
+
+	if entry.OccurredAt.IsZero() {
+		entry.OccurredAt = time.Now()
+	}
+
+	if err := s.auditStore.CreateAuditLog(ctx, entry); err != nil {
+		return fmt.Errorf("failed to record audit entry: %w", err)
+	}
+
+	s.logger.Info("Manual override recorded",
+		zap.String("entity_type", entry.EntityType),
+		zap.String("entity_id", entry.EntityID),
+		zap.String("action", entry.Action))
+
+	return nil
+}
+
+// GetAuditTrail retrieves the audit trail of an entity, oldest first.
+func (s *AuditService) GetAuditTrail(ctx context.Context, entityType, entityID string, limit, offset int) ([]*models.AuditLog, error) {
+	if entityType == "" || entityID == "" {
+		return nil, fmt.Errorf("audit entity is required")
+	}
+
+	return s.auditStore.ListAuditLogsByEntity(ctx, entityType, entityID, limit, offset)
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/edsonmichaque/bazaruto/internal/store"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidBeneficiaryDesignation is returned when a policy's beneficiaries do not satisfy the
+// beneficiary rules of its product.
+var ErrInvalidBeneficiaryDesignation = errors.New("invalid beneficiary designation")
+
+// beneficiaryPercentageTolerance absorbs rounding in splits such as three ways of 33.33%.
+const beneficiaryPercentageTolerance = 0.01
+
+// BeneficiaryService validates and stores the beneficiaries designated on policies.
+type BeneficiaryService struct {
+	configManager    *config.Manager
+	beneficiaryStore store.BeneficiaryStore
+	policyStore      store.PolicyStore
+	productStore     store.ProductStore
+}
+
+// NewBeneficiaryService creates a new BeneficiaryService instance.
+func NewBeneficiaryService(configManager *config.Manager, beneficiaryStore store.BeneficiaryStore, policyStore store.PolicyStore, productStore store.ProductStore) *BeneficiaryService {
+	return &BeneficiaryService{
+		configManager:    configManager,
+		beneficiaryStore: beneficiaryStore,
+		policyStore:      policyStore,
+		productStore:     productStore,
+	}
+}
+
+// DesignateBeneficiaries validates a policy's beneficiary designation and replaces its current
+// beneficiaries with it.
+func (s *BeneficiaryService) DesignateBeneficiaries(ctx context.Context, policyID uuid.UUID, beneficiaries []*models.Beneficiary) error {
+	policy, err := s.policyStore.GetPolicy(ctx, policyID)
+	if err != nil {
+		return fmt.Errorf("failed to get policy: %w", err)
+	}
+	if err := s.ValidateDesignation(policy, beneficiaries); err != nil {
+		return err
+	}
+
+	for _, beneficiary := range beneficiaries {
+		if beneficiary.Status == "" {
+			beneficiary.Status = models.StatusActive
+		}
+	}
+	return s.beneficiaryStore.ReplaceBeneficiaries(ctx, policyID, beneficiaries)
+}
+
+// ListPolicyBeneficiaries retrieves the beneficiaries designated on a policy.
+func (s *BeneficiaryService) ListPolicyBeneficiaries(ctx context.Context, policyID uuid.UUID) ([]*models.Beneficiary, error) {
+	if _, err := s.policyStore.GetPolicy(ctx, policyID); err != nil {
+		return nil, fmt.Errorf("failed to get policy: %w", err)
+	}
+	return s.beneficiaryStore.ListBeneficiaries(ctx, &policyID, 0, 0)
+}
+
+// ValidateIssuance checks the beneficiaries a new policy is issued with against the rules
+// of its product, before the policy is stored.
+func (s *BeneficiaryService) ValidateIssuance(ctx context.Context, policy *models.Policy) error {
+	issued := *policy
+	if issued.Product.Category == "" {
+		product, err := s.productStore.GetProduct(ctx, policy.ProductID)
+		if err != nil {
+			return fmt.Errorf("failed to get product: %w", err)
+		}
+		issued.Product = *product
+	}
+
+	beneficiaries := make([]*models.Beneficiary, len(policy.Beneficiaries))
+	for i := range policy.Beneficiaries {
+		beneficiaries[i] = &policy.Beneficiaries[i]
+	}
+	return s.ValidateDesignation(&issued, beneficiaries)
+}
+
+// ValidatePolicyBeneficiaries checks a policy's stored beneficiaries against its product's
+// rules, for example before reinstating a life policy.
+func (s *BeneficiaryService) ValidatePolicyBeneficiaries(ctx context.Context, policyID uuid.UUID) error {
+	policy, err := s.policyStore.GetPolicy(ctx, policyID)
+	if err != nil {
+		return fmt.Errorf("failed to get policy: %w", err)
+	}
+	beneficiaries, err := s.beneficiaryStore.ListBeneficiaries(ctx, &policyID, 0, 0)
+	if err != nil {
+		return fmt.Errorf("failed to list beneficiaries: %w", err)
+	}
+	return s.ValidateDesignation(policy, beneficiaries)
+}
+
+// ValidateDesignation checks beneficiaries against the rules of the policy's product category.
+// Whenever beneficiaries are designated their percentages must total 100%, whether or not the
+// product requires them.
+func (s *BeneficiaryService) ValidateDesignation(policy *models.Policy, beneficiaries []*models.Beneficiary) error {
+	category := policy.Product.Category
+	rules := s.configManager.GetConfig().PolicyLifecycle.BeneficiaryRules[category]
+
+	if len(beneficiaries) == 0 {
+		if rules.Required {
+			return fmt.Errorf("%w: %s policies require at least one beneficiary", ErrInvalidBeneficiaryDesignation, category)
+		}
+		return nil
+	}
+	if rules.MaxBeneficiaries > 0 && len(beneficiaries) > rules.MaxBeneficiaries {
+		return fmt.Errorf("%w: at most %d beneficiaries are allowed, got %d", ErrInvalidBeneficiaryDesignation, rules.MaxBeneficiaries, len(beneficiaries))
+	}
+
+	total := 0.0
+	for _, beneficiary := range beneficiaries {
+		name := strings.TrimSpace(beneficiary.FirstName + " " + beneficiary.LastName)
+		if beneficiary.FirstName == "" || beneficiary.LastName == "" {
+			return fmt.Errorf("%w: beneficiary first and last name are required", ErrInvalidBeneficiaryDesignation)
+		}
+		if beneficiary.Relationship == "" {
+			return fmt.Errorf("%w: relationship is required for beneficiary %s", ErrInvalidBeneficiaryDesignation, name)
+		}
+		if !relationshipAllowed(rules.AllowedRelationships, beneficiary.Relationship) {
+			return fmt.Errorf("%w: relationship %q is not allowed for beneficiary %s", ErrInvalidBeneficiaryDesignation, beneficiary.Relationship, name)
+		}
+		if beneficiary.Percentage <= 0 || beneficiary.Percentage > 100 {
+			return fmt.Errorf("%w: percentage for beneficiary %s must be between 0 and 100", ErrInvalidBeneficiaryDesignation, name)
+		}
+		if rules.MinAge > 0 {
+			if beneficiary.DateOfBirth.IsZero() {
+				return fmt.Errorf("%w: date of birth is required for beneficiary %s", ErrInvalidBeneficiaryDesignation, name)
+			}
+			if age := beneficiary.GetAge(); age < rules.MinAge {
+				return fmt.Errorf("%w: beneficiary %s is %d, the minimum age is %d", ErrInvalidBeneficiaryDesignation, name, age, rules.MinAge)
+			}
+		}
+		total += beneficiary.Percentage
+	}
+
+	if math.Abs(total-100) > beneficiaryPercentageTolerance {
+		return fmt.Errorf("%w: beneficiary percentages total %.2f%%, they must total 100%%", ErrInvalidBeneficiaryDesignation, total)
+	}
+	return nil
+}
+
+// relationshipAllowed reports whether a relationship is in the allowed list. An empty list
+// allows any relationship.
+func relationshipAllowed(allowed []string, relationship string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, candidate := range allowed {
+		if strings.EqualFold(candidate, relationship) {
+			return true
+		}
+	}
+	return false
+}
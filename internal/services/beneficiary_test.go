@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBeneficiaryTestService(t *testing.T, category string) (*BeneficiaryService, *fakeBeneficiaryStore, *models.Policy) {
+	t.Helper()
+	policy := &models.Policy{
+		PolicyNumber: "POL-2024-000001",
+		Product:      models.Product{Name: "Term Life", Category: category},
+	}
+	beneficiaries := newFakeBeneficiaryStore()
+	log := logger.NewLogger("error", "json")
+	svc := NewBeneficiaryService(config.NewManager(log, ""), beneficiaries, newFakePolicyStore(policy), newFakeProductStore())
+	return svc, beneficiaries, policy
+}
+
+func TestLifePolicyRequiresBeneficiaries(t *testing.T) {
+	ctx := context.Background()
+	svc, _, policy := newBeneficiaryTestService(t, "life")
+
+	err := svc.DesignateBeneficiaries(ctx, policy.ID, nil)
+	require.ErrorIs(t, err, ErrInvalidBeneficiaryDesignation)
+
+	err = svc.ValidatePolicyBeneficiaries(ctx, policy.ID)
+	require.ErrorIs(t, err, ErrInvalidBeneficiaryDesignation)
+
+	// Products without beneficiary rules may have none.
+	auto, _, autoPolicy := newBeneficiaryTestService(t, "auto")
+	assert.NoError(t, auto.DesignateBeneficiaries(ctx, autoPolicy.ID, nil))
+}
+
+func TestBeneficiarySplitMustTotal100Percent(t *testing.T) {
+	ctx := context.Background()
+	svc, store, policy := newBeneficiaryTestService(t, "life")
+
+	err := svc.DesignateBeneficiaries(ctx, policy.ID, []*models.Beneficiary{
+		{FirstName: "Ana", LastName: "Silva", Relationship: "spouse", Percentage: 60},
+		{FirstName: "Rui", LastName: "Silva", Relationship: "child", Percentage: 50},
+	})
+	require.ErrorIs(t, err, ErrInvalidBeneficiaryDesignation)
+	assert.Contains(t, err.Error(), "110.00%")
+	assert.Empty(t, store.beneficiaries, "a rejected designation is not stored")
+
+	require.NoError(t, svc.DesignateBeneficiaries(ctx, policy.ID, []*models.Beneficiary{
+		{FirstName: "Ana", LastName: "Silva", Relationship: "spouse", Percentage: 60},
+		{FirstName: "Rui", LastName: "Silva", Relationship: "child", Percentage: 40},
+	}))
+	assert.NoError(t, svc.ValidatePolicyBeneficiaries(ctx, policy.ID))
+
+	// A new designation replaces the previous one.
+	require.NoError(t, svc.DesignateBeneficiaries(ctx, policy.ID, []*models.Beneficiary{
+		{FirstName: "Ana", LastName: "Silva", Relationship: "spouse", Percentage: 100},
+	}))
+	stored, err := store.ListBeneficiaries(ctx, &policy.ID, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+	assert.Equal(t, policy.ID, stored[0].PolicyID)
+	assert.Equal(t, models.StatusActive, stored[0].Status)
+}
+
+func TestBeneficiaryRelationshipMustBeAllowed(t *testing.T) {
+	ctx := context.Background()
+	svc, _, policy := newBeneficiaryTestService(t, "life")
+
+	err := svc.DesignateBeneficiaries(ctx, policy.ID, []*models.Beneficiary{
+		{FirstName: "Acme", LastName: "Lending", Relationship: "creditor", Percentage: 100},
+	})
+	require.ErrorIs(t, err, ErrInvalidBeneficiaryDesignation)
+	assert.Contains(t, err.Error(), "creditor")
+}
+
+func TestLifePolicyIsNotIssuedWithoutValidBeneficiaries(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	product := &models.Product{Name: "Term Life", Category: "life"}
+	policyStore := newFakePolicyStore()
+	beneficiaries := NewBeneficiaryService(config.NewManager(log, ""), newFakeBeneficiaryStore(), policyStore, newFakeProductStore(product))
+	policies := NewPolicyService(policyStore)
+	policies.SetBeneficiaryService(beneficiaries)
+
+	newPolicy := func(designation ...models.Beneficiary) *models.Policy {
+		return &models.Policy{
+			ProductID:      product.ID,
+			UserID:         uuid.New(),
+			Premium:        120,
+			CoverageAmount: 100000,
+			EffectiveDate:  time.Now().Add(time.Hour),
+			ExpirationDate: time.Now().AddDate(1, 0, 0),
+			Beneficiaries:  designation,
+		}
+	}
+
+	err := policies.CreatePolicy(ctx, newPolicy())
+	require.ErrorIs(t, err, ErrInvalidBeneficiaryDesignation)
+	assert.Empty(t, policyStore.policies, "a policy failing validation is not issued")
+
+	issued := newPolicy(models.Beneficiary{FirstName: "Ana", LastName: "Silva", Relationship: "spouse", Percentage: 100})
+	require.NoError(t, policies.CreatePolicy(ctx, issued))
+	assert.Empty(t, issued.Product.Category, "the policy's product association is left unset")
+}
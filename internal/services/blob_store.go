@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ErrInvalidBlobKey is returned when a blob key is empty or escapes the store.
+var ErrInvalidBlobKey = errors.New("invalid blob key")
+
+// BlobStore stores generated documents and returns the URL they can be downloaded from.
+type BlobStore interface {
+	// Put stores data under key, replacing any existing blob, and returns its download URL.
+	Put(ctx context.Context, key, contentType string, data []byte) (string, error)
+	// Get returns the blob stored under key.
+	Get(ctx context.Context, key string) ([]byte, error)
+}
+
+// localBlobStore stores blobs as files below a root directory.
+type localBlobStore struct {
+	root    string
+	baseURL string
+}
+
+// NewLocalBlobStore creates a BlobStore that writes files below root. Download URLs are
+// baseURL followed by the blob key.
+func NewLocalBlobStore(root, baseURL string) BlobStore {
+	return &localBlobStore{
+		root:    root,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+// Put writes the blob to disk and returns its download URL.
+func (s *localBlobStore) Put(ctx context.Context, key, contentType string, data []byte) (string, error) {
+	filename, err := s.path(key)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return "", fmt.Errorf("failed to create blob directory: %w", err)
+	}
+	if err := os.WriteFile(filename, data, 0o644); err != nil {
+		return "", fmt.Errorf("failed to write blob: %w", err)
+	}
+
+	return s.baseURL + "/" + key, nil
+}
+
+// Get reads the blob from disk.
+func (s *localBlobStore) Get(ctx context.Context, key string) ([]byte, error) {
+	filename, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob: %w", err)
+	}
+	return data, nil
+}
+
+// path returns the file for key, rejecting keys that would escape the root directory.
+func (s *localBlobStore) path(key string) (string, error) {
+	cleaned := path.Clean("/" + key)
+	if key == "" || cleaned != "/"+key {
+		return "", fmt.Errorf("%w: %q", ErrInvalidBlobKey, key)
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
@@ -13,16 +13,30 @@ import (
 
 // ClaimService handles business logic for claims.
 type ClaimService struct {
-	store       store.ClaimStore
-	policyStore store.PolicyStore
+	store             store.ClaimStore
+	policyStore       store.PolicyStore
+	documentValidator DocumentValidator
+	documentAnalyzer  DocumentAnalyzer
+	numbers           *NumberGenerator
 }
 
-// NewClaimService creates a new ClaimService instance.
-func NewClaimService(store store.ClaimStore, policyStore store.PolicyStore) *ClaimService {
-	return &ClaimService{
+// NewClaimService creates a new ClaimService instance. An optional DocumentValidator
+// checks claim documents at intake.
+func NewClaimService(store store.ClaimStore, policyStore store.PolicyStore, documentValidator ...DocumentValidator) *ClaimService {
+	service := &ClaimService{
 		store:       store,
 		policyStore: policyStore,
 	}
+	if len(documentValidator) > 0 {
+		service.documentValidator = documentValidator[0]
+	}
+	return service
+}
+
+// SetNumberGenerator sets the generator used to number new claims. Without one, claim numbers
+// are generated from a timestamp and a random component.
+func (s *ClaimService) SetNumberGenerator(numbers *NumberGenerator) {
+	s.numbers = numbers
 }
 
 // CreateClaim creates a new claim with business logic validation.
@@ -60,7 +74,15 @@ func (s *ClaimService) CreateClaim(ctx context.Context, claim *models.Claim) err
 
 	// Generate claim number if not provided
 	if claim.ClaimNumber == "" {
-		claim.ClaimNumber = s.generateClaimNumber()
+		if s.numbers != nil {
+			number, err := s.numbers.ClaimNumber(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to generate claim number: %w", err)
+			}
+			claim.ClaimNumber = number
+		} else {
+			claim.ClaimNumber = s.generateClaimNumber()
+		}
 	}
 
 	// Validate incident date is not in the future
@@ -103,6 +125,12 @@ func (s *ClaimService) CreateClaim(ctx context.Context, claim *models.Claim) err
 		return fmt.Errorf("user does not own the policy")
 	}
 
+	discardClientDocumentAnalysis(claim)
+	if err := s.validateDocuments(ctx, claim); err != nil {
+		return err
+	}
+	s.extractDocumentMetadata(ctx, claim)
+
 	return s.store.CreateClaim(ctx, claim)
 }
 
@@ -191,6 +219,13 @@ func (s *ClaimService) UpdateClaim(ctx context.Context, claim *models.Claim) err
 		return fmt.Errorf("cannot change reported date")
 	}
 
+	// Enforce the claim status state machine
+	if err := models.ValidateClaimStatusTransition(existing.Status, claim.Status); err != nil {
+		return err
+	}
+
+	preserveDocumentAnalysis(existing, claim)
+
 	// Validate claim amount is not negative
 	if claim.ClaimAmount < 0 {
 		return fmt.Errorf("claim amount cannot be negative")
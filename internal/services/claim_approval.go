@@ -0,0 +1,86 @@
+package services
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+)
+
+// Built-in review thresholds used when the approval rules set none.
+const (
+	DefaultSeniorReviewThreshold    = 50000
+	DefaultExecutiveReviewThreshold = 100000
+)
+
+// approvalStages are the approval stages a claim may require, in workflow order.
+var approvalStages = []struct {
+	id   string
+	name string
+}{
+	{"senior_review", "Senior Review"},
+	{"executive_approval", "Executive Approval"},
+}
+
+// ApprovalRequirement is the approval a claim requires before payout.
+type ApprovalRequirement struct {
+	Stages        []string `json:"stages"`         // approval stages, in workflow order
+	RequiredRoles []string `json:"required_roles"` // roles allowed to decide the stages; empty allows any
+	MatrixRule    int      `json:"matrix_rule"`    // index of the matching matrix rule, -1 for the thresholds
+}
+
+// ResolveApproval returns the approval a claim requires. The first approval matrix rule
+// matching the policy's product category, the policy's jurisdiction and the claim amount
+// applies; without a match the senior and executive review thresholds apply. The region
+// is never taken from the claim, whose submission details the claimant can influence.
+func ResolveApproval(rules config.ApprovalRules, claim *models.Claim, policy *models.Policy) ApprovalRequirement {
+	category, region := "", ""
+	if policy != nil {
+		category = policy.Product.Category
+		region = policy.Jurisdiction
+	}
+
+	for i, rule := range rules.Matrix {
+		if !approvalRuleMatches(rule, category, region, claim.ClaimAmount) {
+			continue
+		}
+		requirement := ApprovalRequirement{RequiredRoles: rule.RequiredRoles, MatrixRule: i}
+		for _, stage := range approvalStages {
+			if slices.Contains(rule.Stages, stage.id) {
+				requirement.Stages = append(requirement.Stages, stage.id)
+			}
+		}
+		return requirement
+	}
+
+	senior, executive := rules.SeniorReviewThreshold, rules.ExecutiveReviewThreshold
+	if senior <= 0 {
+		senior = DefaultSeniorReviewThreshold
+	}
+	if executive <= 0 {
+		executive = DefaultExecutiveReviewThreshold
+	}
+	requirement := ApprovalRequirement{MatrixRule: -1}
+	if claim.ClaimAmount > senior {
+		requirement.Stages = append(requirement.Stages, "senior_review")
+	}
+	if claim.ClaimAmount > executive {
+		requirement.Stages = append(requirement.Stages, "executive_approval")
+	}
+	return requirement
+}
+
+// approvalRuleMatches reports whether an approval matrix rule applies to a claim.
+func approvalRuleMatches(rule config.ApprovalMatrixRule, category, region string, amount float64) bool {
+	if rule.ProductCategory != "" && !strings.EqualFold(rule.ProductCategory, category) {
+		return false
+	}
+	if rule.Region != "" && !strings.EqualFold(rule.Region, region) {
+		return false
+	}
+	if amount <= rule.MinAmount {
+		return false
+	}
+	return rule.MaxAmount <= 0 || amount <= rule.MaxAmount
+}
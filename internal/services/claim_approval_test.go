@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/edsonmichaque/bazaruto/internal/authorization"
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/edsonmichaque/bazaruto/pkg/job"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mozambiqueAutoApprovalRules() config.ApprovalRules {
+	return config.ApprovalRules{
+		SeniorReviewThreshold:    50000,
+		ExecutiveReviewThreshold: 100000,
+		Matrix: []config.ApprovalMatrixRule{{
+			ProductCategory: "auto",
+			Region:          "MZ",
+			MinAmount:       20000,
+			Stages:          []string{"senior_review"},
+			RequiredRoles:   []string{"claims_supervisor"},
+		}},
+	}
+}
+
+func TestResolveApprovalRegionRuleOverridesThresholds(t *testing.T) {
+	rules := mozambiqueAutoApprovalRules()
+	mozambique := &models.Policy{Jurisdiction: "MZ", Product: models.Product{Category: "auto"}}
+	southAfrica := &models.Policy{Jurisdiction: "ZA", Product: models.Product{Category: "auto"}}
+
+	tests := []struct {
+		name    string
+		claim   *models.Claim
+		policy  *models.Policy
+		stages  []string
+		roles   []string
+		matched int
+	}{
+		{"region rule below the flat threshold", &models.Claim{ClaimAmount: 30000}, mozambique, []string{"senior_review"}, []string{"claims_supervisor"}, 0},
+		{"region rule above the executive threshold", &models.Claim{ClaimAmount: 150000}, &models.Policy{Jurisdiction: "mz", Product: models.Product{Category: "auto"}}, []string{"senior_review"}, []string{"claims_supervisor"}, 0},
+		{"other region uses thresholds", &models.Claim{ClaimAmount: 30000}, southAfrica, nil, nil, -1},
+		{"other region over thresholds", &models.Claim{ClaimAmount: 150000}, southAfrica, []string{"senior_review", "executive_approval"}, nil, -1},
+		{"region claim under the rule's amount", &models.Claim{ClaimAmount: 15000}, mozambique, nil, nil, -1},
+		{"submission country does not choose the region", &models.Claim{ClaimAmount: 150000, SubmissionCountry: "MZ"}, southAfrica, []string{"senior_review", "executive_approval"}, nil, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requirement := ResolveApproval(rules, tt.claim, tt.policy)
+			assert.Equal(t, tt.stages, requirement.Stages)
+			assert.Equal(t, tt.roles, requirement.RequiredRoles)
+			assert.Equal(t, tt.matched, requirement.MatrixRule)
+		})
+	}
+
+	homePolicy := &models.Policy{Jurisdiction: "MZ", Product: models.Product{Category: "home"}}
+	assert.Empty(t, ResolveApproval(rules, &models.Claim{ClaimAmount: 30000}, homePolicy).Stages)
+}
+
+func TestWorkflowStagesFollowApprovalMatrix(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	configManager := config.NewManager(log, filepath.Join(t.TempDir(), "business_rules.json"))
+	cfg := configManager.GetConfig()
+	cfg.ClaimProcessing.ApprovalRules = mozambiqueAutoApprovalRules()
+	require.NoError(t, configManager.UpdateConfig(ctx, cfg))
+	svc := NewClaimProcessingService(configManager, newFakeClaimStore(), newFakePolicyStore(), nil, newFakeClaimWorkflowStore(), nil, nil, nil, nil, job.Dispatcher{}, nil)
+
+	policy := &models.Policy{Jurisdiction: "MZ", Product: models.Product{Category: "auto"}}
+	stages := svc.defineWorkflowStages(&models.Claim{ClaimAmount: 30000}, policy)
+
+	var senior *WorkflowStage
+	for i := range stages {
+		assert.NotEqual(t, "executive_approval", stages[i].StageID)
+		if stages[i].StageID == "senior_review" {
+			senior = &stages[i]
+		}
+	}
+	require.NotNil(t, senior)
+	assert.Equal(t, []string{"claims_supervisor"}, senior.Metadata["required_roles"])
+}
+
+func TestUpdateWorkflowStageRequiresMatrixRole(t *testing.T) {
+	log := logger.NewLogger("error", "json")
+	authorizer := authorization.NewRBACAuthorizer(authorization.NewRBACService())
+	auditStore := &fakeAuditLogStore{}
+	svc := NewClaimProcessingService(config.NewManager(log, ""), newFakeClaimStore(), newFakePolicyStore(), nil, newFakeClaimWorkflowStore(), nil, nil, nil, nil, job.Dispatcher{}, authorizer, NewAuditService(log, auditStore))
+
+	claimID := uuid.New()
+	require.NoError(t, svc.workflows.save(context.Background(), &ClaimWorkflow{
+		ClaimID:      claimID,
+		CurrentStage: "senior_review",
+		Status:       "in_progress",
+		Stages: []WorkflowStage{{
+			StageID:  "senior_review",
+			Status:   "completed",
+			Result:   "requires_review",
+			Metadata: map[string]interface{}{"required_roles": []string{"claims_supervisor"}},
+		}},
+		Metadata: make(map[string]interface{}),
+	}))
+
+	adminCtx := authorization.WithActor(context.Background(), &authorization.Actor{ID: uuid.New(), Role: authorization.RoleAdmin})
+	err := svc.UpdateWorkflowStage(adminCtx, claimID, "senior_review", "requires_review", "Reviewed", "", nil)
+	assert.ErrorIs(t, err, authorization.ErrUnauthorized)
+
+	supervisor := &authorization.Actor{ID: uuid.New(), Role: authorization.RoleClaimsSupervisor}
+	assignee := uuid.New()
+	supervisorCtx := authorization.WithActor(context.Background(), supervisor)
+	require.NoError(t, svc.UpdateWorkflowStage(supervisorCtx, claimID, "senior_review", "requires_review", "Reviewed", "", &assignee))
+
+	require.Len(t, auditStore.entries, 1)
+	require.NotNil(t, auditStore.entries[0].ActorID)
+	assert.Equal(t, supervisor.ID, *auditStore.entries[0].ActorID, "the override is attributed to the caller, not the assignee")
+}
@@ -0,0 +1,214 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/edsonmichaque/bazaruto/internal/store"
+)
+
+// SetCoverageStore sets the store of product coverages that claim perils are matched against
+// during policy validation. Without it, perils are not checked.
+func (s *ClaimProcessingService) SetCoverageStore(coverageStore store.CoverageStore) {
+	s.coverageStore = coverageStore
+}
+
+// validatePerilCovered declines a claim whose peril is not one of the coverage types included
+// in the policy's product. A claim against a product with coverages must name its peril;
+// products without coverages are not checked.
+func (s *ClaimProcessingService) validatePerilCovered(ctx context.Context, claim *models.Claim, policy *models.Policy, stage *WorkflowStage) error {
+	if s.coverageStore == nil {
+		return nil
+	}
+
+	coverages, err := s.coverageStore.ListCoverages(ctx, &policy.ProductID, "", 0, 0)
+	if err != nil {
+		return fmt.Errorf("failed to fetch policy coverages: %w", err)
+	}
+	if len(coverages) == 0 {
+		return nil
+	}
+
+	var covered []string
+	for _, coverage := range coverages {
+		if !coverage.IsIncluded {
+			continue
+		}
+		if strings.EqualFold(coverage.CoverageType, claim.Peril) {
+			if stage.Metadata == nil {
+				stage.Metadata = make(map[string]interface{})
+			}
+			stage.Metadata["coverage_id"] = coverage.ID.String()
+			stage.Metadata["coverage_name"] = coverage.CoverageName
+			return nil
+		}
+		covered = append(covered, coverage.CoverageType)
+	}
+
+	if claim.Peril == "" {
+		stage.Result = "declined"
+		stage.Decision = "Peril required"
+		stage.Comments = fmt.Sprintf("The claim names no peril; covered perils: %s", strings.Join(covered, ", "))
+		return fmt.Errorf("claim names no peril")
+	}
+
+	stage.Result = "declined"
+	stage.Decision = "Peril not covered by policy"
+	stage.Comments = fmt.Sprintf("Peril %q is not covered; covered perils: %s", claim.Peril, strings.Join(covered, ", "))
+	return fmt.Errorf("claim peril %s is not covered by the policy", claim.Peril)
+}
+
+// executeExclusionCheck executes the exclusion check stage. A claim matching one of the
+// policy's exclusions, or an exclusion of the coverage for its peril, is denied with the
+// exclusion code as the denial reason.
+func (s *ClaimProcessingService) executeExclusionCheck(ctx context.Context, workflow *ClaimWorkflow, stage *WorkflowStage) error {
+	claim, err := s.claimStore.GetClaim(ctx, workflow.ClaimID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch claim: %w", err)
+	}
+
+	policy, err := s.policyStore.GetPolicy(ctx, claim.PolicyID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch policy: %w", err)
+	}
+
+	exclusions, err := s.applicableExclusions(ctx, claim, policy)
+	if err != nil {
+		return err
+	}
+
+	for _, exclusion := range exclusions {
+		if !exclusion.Matches(claim) {
+			continue
+		}
+
+		stage.Result = "declined"
+		stage.Decision = fmt.Sprintf("Claim matches exclusion %s", exclusion.Code)
+		stage.Comments = exclusion.Description
+		stage.Metadata = map[string]interface{}{"exclusion_code": exclusion.Code}
+
+		if err := s.denyClaim(ctx, claim, stage, fmt.Sprintf("Excluded (%s): %s", exclusion.Code, exclusion.Description)); err != nil {
+			return err
+		}
+		return fmt.Errorf("claim is excluded under %s", exclusion.Code)
+	}
+
+	stage.Result = "approved"
+	stage.Decision = "No exclusions apply"
+	stage.AutoApproved = true
+	stage.Comments = fmt.Sprintf("Checked %d exclusions", len(exclusions))
+
+	return nil
+}
+
+// applicableExclusions returns the policy's exclusions and those of the product coverages for
+// the claim's peril.
+func (s *ClaimProcessingService) applicableExclusions(ctx context.Context, claim *models.Claim, policy *models.Policy) ([]models.Exclusion, error) {
+	exclusions := append([]models.Exclusion(nil), policy.Exclusions...)
+	coverages, err := s.perilCoverages(ctx, claim, policy)
+	if err != nil {
+		return nil, err
+	}
+	for _, coverage := range coverages {
+		exclusions = append(exclusions, coverage.Exclusions...)
+	}
+	return exclusions, nil
+}
+
+// executeWaitingPeriodCheck executes the waiting period stage. A claim whose incident occurred
+// before the waiting period from the policy's effective date ended is denied. The coverage
+// for the claim's peril sets the waiting period when it has one, otherwise the product does.
+// Renewals continue the original cover, so their claims have no waiting period.
+func (s *ClaimProcessingService) executeWaitingPeriodCheck(ctx context.Context, workflow *ClaimWorkflow, stage *WorkflowStage) error {
+	claim, err := s.claimStore.GetClaim(ctx, workflow.ClaimID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch claim: %w", err)
+	}
+
+	policy, err := s.policyStore.GetPolicy(ctx, claim.PolicyID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch policy: %w", err)
+	}
+
+	days := policy.Product.WaitingPeriodDays
+	coverages, err := s.perilCoverages(ctx, claim, policy)
+	if err != nil {
+		return err
+	}
+	for _, coverage := range coverages {
+		if coverage.WaitingPeriodDays > 0 {
+			days = coverage.WaitingPeriodDays
+		}
+	}
+
+	if days <= 0 || policy.PreviousPolicyID != nil {
+		stage.Result = "approved"
+		stage.Decision = "No waiting period applies"
+		stage.AutoApproved = true
+		return nil
+	}
+
+	waitingPeriodEnd := policy.EffectiveDate.AddDate(0, 0, days)
+	stage.Metadata = map[string]interface{}{
+		"waiting_period_days": days,
+		"waiting_period_end":  waitingPeriodEnd,
+	}
+
+	if claim.IncidentDate.Before(waitingPeriodEnd) {
+		stage.Result = "declined"
+		stage.Decision = "Incident within waiting period"
+		stage.Comments = fmt.Sprintf("The %d-day waiting period ends on %s", days, waitingPeriodEnd.Format("2006-01-02"))
+
+		reason := fmt.Sprintf("Incident occurred within the %d-day waiting period ending %s", days, waitingPeriodEnd.Format("2006-01-02"))
+		if err := s.denyClaim(ctx, claim, stage, reason); err != nil {
+			return err
+		}
+		return fmt.Errorf("incident occurred within the waiting period")
+	}
+
+	stage.Result = "approved"
+	stage.Decision = "Waiting period served"
+	stage.AutoApproved = true
+
+	return nil
+}
+
+// perilCoverages returns the product coverages for the claim's peril. It returns none when
+// the claim has no peril or no coverage store is set.
+func (s *ClaimProcessingService) perilCoverages(ctx context.Context, claim *models.Claim, policy *models.Policy) ([]*models.Coverage, error) {
+	if s.coverageStore == nil || claim.Peril == "" {
+		return nil, nil
+	}
+
+	coverages, err := s.coverageStore.ListCoverages(ctx, &policy.ProductID, "", 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch policy coverages: %w", err)
+	}
+	var matched []*models.Coverage
+	for _, coverage := range coverages {
+		if strings.EqualFold(coverage.CoverageType, claim.Peril) {
+			matched = append(matched, coverage)
+		}
+	}
+	return matched, nil
+}
+
+// denyClaim denies a claim that a workflow stage declined outright, records the reason and
+// queues the denial letter.
+func (s *ClaimProcessingService) denyClaim(ctx context.Context, claim *models.Claim, stage *WorkflowStage, reason string) error {
+	if claim.TransitionTo(models.ClaimStatusDenied) != nil {
+		return nil
+	}
+
+	claim.DenialReason = &reason
+	now := time.Now()
+	claim.ResolvedDate = &now
+	if err := s.claimStore.UpdateClaim(ctx, claim); err != nil {
+		return fmt.Errorf("failed to deny claim: %w", err)
+	}
+	s.dispatchDecisionLetter(ctx, stage, claim.ID)
+	return nil
+}
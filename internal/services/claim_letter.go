@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/edsonmichaque/bazaruto/internal/store"
+	"github.com/edsonmichaque/bazaruto/pkg/job"
+	"github.com/edsonmichaque/bazaruto/pkg/pdf"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// ErrClaimNotDecided is returned when a decision letter is requested for a claim that has
+// been neither approved nor denied.
+var ErrClaimNotDecided = errors.New("claim has no decision")
+
+// ClaimLetterService renders settlement and denial letters for decided claims and attaches
+// them to the claim.
+type ClaimLetterService struct {
+	configManager *config.Manager
+	claimStore    store.ClaimStore
+	blobStore     BlobStore
+	logger        *logger.Logger
+}
+
+// NewClaimLetterService creates a new ClaimLetterService instance.
+func NewClaimLetterService(logger *logger.Logger, configManager *config.Manager, claimStore store.ClaimStore, blobStore BlobStore) *ClaimLetterService {
+	return &ClaimLetterService{
+		configManager: configManager,
+		claimStore:    claimStore,
+		blobStore:     blobStore,
+		logger:        logger,
+	}
+}
+
+// GenerateClaimLetter renders the letter for a claim's decision, stores it and records the
+// download URL on the claim. Approved and paid claims get a settlement letter with the payout
+// amount; denied claims get a denial letter with the denial reason.
+func (s *ClaimLetterService) GenerateClaimLetter(ctx context.Context, claimID uuid.UUID) (*GeneratedDocument, error) {
+	claim, err := s.claimStore.GetClaim(ctx, claimID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get claim: %w", err)
+	}
+
+	data, err := RenderClaimLetter(claim, s.configManager.GetConfig().Appeals)
+	if err != nil {
+		return nil, err
+	}
+
+	key := fmt.Sprintf("claims/%s/%s-%s.pdf", claim.ID, claim.ClaimNumber, claim.Status)
+	url, err := s.blobStore.Put(ctx, key, pdfContentType, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store claim letter: %w", err)
+	}
+
+	claim.DecisionLetterURL = url
+	if err := s.claimStore.UpdateClaim(ctx, claim); err != nil {
+		return nil, fmt.Errorf("failed to record claim letter: %w", err)
+	}
+
+	s.logger.Info("Claim decision letter generated",
+		zap.String("claim_id", claim.ID.String()),
+		zap.String("status", claim.Status),
+		zap.String("url", url))
+
+	return &GeneratedDocument{
+		Key:         key,
+		URL:         url,
+		ContentType: pdfContentType,
+		Size:        len(data),
+	}, nil
+}
+
+// RenderClaimLetter renders the settlement or denial letter for a decided claim.
+func RenderClaimLetter(claim *models.Claim, appeals config.AppealConfig) ([]byte, error) {
+	decided := time.Now()
+	if claim.ResolvedDate != nil {
+		decided = *claim.ResolvedDate
+	}
+
+	var doc *pdf.Document
+	switch claim.Status {
+	case models.ClaimStatusApproved, models.ClaimStatusPaid:
+		doc = pdf.New(DocumentBrand, "Claim Settlement Letter")
+		writeClaimHeader(doc, claim, decided, "Approved")
+
+		payout := claim.ClaimAmount
+		if claim.PaidAmount > 0 {
+			payout = claim.PaidAmount
+		}
+
+		doc.Heading("Settlement")
+		doc.Row("Amount claimed", formatMoney(claim.ClaimAmount, claim.Currency))
+		doc.TotalRow("Approved payout", formatMoney(payout, claim.Currency))
+		doc.Text("We have approved your claim. The payout will be made to the account held on your policy.")
+	case models.ClaimStatusDenied:
+		doc = pdf.New(DocumentBrand, "Claim Decision Letter")
+		writeClaimHeader(doc, claim, decided, "Denied")
+
+		reason := "No reason was recorded."
+		if claim.DenialReason != nil && *claim.DenialReason != "" {
+			reason = *claim.DenialReason
+		}
+
+		doc.Heading("Decision")
+		doc.Text("We are unable to approve your claim for the following reason:")
+		doc.Text(reason)
+	default:
+		return nil, fmt.Errorf("%w: claim %s is %s", ErrClaimNotDecided, claim.ID, claim.Status)
+	}
+
+	doc.Heading("How to appeal")
+	doc.Text(fmt.Sprintf("If you disagree with this decision you can appeal it by filing an appeal against "+
+		"claim %s with any additional evidence. A claim can be appealed up to %d times, and each appeal "+
+		"is reviewed within %d days.", claim.ClaimNumber, appeals.MaxAppeals, appeals.ReviewDays))
+
+	return doc.Bytes()
+}
+
+// writeClaimHeader writes the claim details shared by every decision letter.
+func writeClaimHeader(doc *pdf.Document, claim *models.Claim, decided time.Time, decision string) {
+	doc.Row("Claim number", claim.ClaimNumber)
+	doc.Row("Claim", claim.Title)
+	doc.Row("Incident date", claim.IncidentDate.Format("2006-01-02"))
+	doc.Row("Decision date", decided.Format("2006-01-02"))
+	doc.Row("Decision", decision)
+}
+
+// GenerateClaimLetterJob renders and stores the decision letter for a claim.
+type GenerateClaimLetterJob struct {
+	ID            uuid.UUID           `json:"id"`
+	ClaimID       uuid.UUID           `json:"claim_id"`
+	LetterService *ClaimLetterService `json:"-"` // Injected dependency
+	Attempts      int                 `json:"attempts"`
+	RunAtTime     time.Time           `json:"run_at_time"`
+}
+
+// Perform generates the claim's decision letter.
+func (j *GenerateClaimLetterJob) Perform(ctx context.Context) error {
+	if j.LetterService == nil {
+		return fmt.Errorf("claim letter service is not configured")
+	}
+
+	if _, err := j.LetterService.GenerateClaimLetter(ctx, j.ClaimID); err != nil {
+		return fmt.Errorf("failed to generate claim letter: %w", err)
+	}
+	return nil
+}
+
+// GenerateClaimLetterJob interface methods
+func (j *GenerateClaimLetterJob) Queue() string               { return job.QueueClaims }
+func (j *GenerateClaimLetterJob) MaxRetries() int             { return 2 } // PDF generation is expensive, fewer retries
+func (j *GenerateClaimLetterJob) RetryBackoff() time.Duration { return 5 * time.Second }
+func (j *GenerateClaimLetterJob) Priority() int               { return 0 }
+func (j *GenerateClaimLetterJob) Timeout() time.Duration      { return 0 } // Use the worker's configured timeout
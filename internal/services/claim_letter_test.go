@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/edsonmichaque/bazaruto/pkg/job"
+	"github.com/edsonmichaque/bazaruto/pkg/job/adapter"
+	"github.com/edsonmichaque/bazaruto/pkg/pdf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApprovalDecisionDispatchesClaimLetter(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	configManager := config.NewManager(log, "")
+
+	// decide runs the approval decision over the given stage results, performs the dispatched
+	// letter job and returns the claim and its letter's text.
+	decide := func(stageResults map[string]string) (*models.Claim, []string) {
+		claim := &models.Claim{
+			ClaimNumber:  "CLM-2026-0042",
+			Title:        "Burst pipe",
+			ClaimAmount:  4200.5,
+			Currency:     "USD",
+			Status:       models.ClaimStatusSubmitted,
+			IncidentDate: time.Date(2026, 9, 30, 0, 0, 0, 0, time.UTC),
+		}
+		claimStore := newFakeClaimStore(claim)
+		blobStore := newFakeBlobStore()
+		letters := NewClaimLetterService(log, configManager, claimStore, blobStore)
+
+		memory := adapter.NewMemoryAdapter()
+		registry := job.NewRegistry()
+		registry.Register("generateclaimletterjob", func() job.Job {
+			return &GenerateClaimLetterJob{LetterService: letters}
+		})
+		svc := NewClaimProcessingService(configManager, claimStore, nil, nil, newFakeClaimWorkflowStore(), nil, nil, nil, nil, *job.NewDispatcher(memory, registry), nil)
+
+		workflow := &ClaimWorkflow{ClaimID: claim.ID}
+		for name, result := range stageResults {
+			workflow.Stages = append(workflow.Stages, WorkflowStage{Name: name, Result: result, Decision: "Exceeds policy limits"})
+		}
+		_ = svc.executeApprovalDecision(ctx, workflow, &WorkflowStage{StageID: "approval_decision"})
+
+		serialized, err := memory.Dequeue(ctx, job.QueueClaims)
+		require.NoError(t, err, "decision letter job was not dispatched")
+		letterJob, err := registry.Deserialize(serialized)
+		require.NoError(t, err)
+		require.NoError(t, letterJob.Perform(ctx))
+
+		require.NotEmpty(t, claim.DecisionLetterURL)
+		data, err := blobStore.Get(ctx, strings.TrimPrefix(claim.DecisionLetterURL, "https://files.test/"))
+		require.NoError(t, err)
+		lines, err := pdf.ExtractText(data)
+		require.NoError(t, err)
+		return claim, lines
+	}
+
+	claim, lines := decide(map[string]string{"Damage Assessment": "approved"})
+	assert.Equal(t, models.ClaimStatusApproved, claim.Status)
+	assert.Contains(t, lines, "Claim Settlement Letter")
+	assert.Contains(t, lines, "Approved payout 4200.50 USD")
+
+	claim, lines = decide(map[string]string{"Policy Validation": "declined"})
+	assert.Equal(t, models.ClaimStatusDenied, claim.Status)
+	require.NotNil(t, claim.DenialReason)
+	assert.Contains(t, *claim.DenialReason, "Policy Validation: Exceeds policy limits")
+	assert.Contains(t, strings.Join(lines, " "), *claim.DenialReason)
+	assert.Contains(t, lines, "How to appeal")
+}
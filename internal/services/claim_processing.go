@@ -2,9 +2,15 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/edsonmichaque/bazaruto/internal/authorization"
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/events"
 	"github.com/edsonmichaque/bazaruto/internal/models"
 	"github.com/edsonmichaque/bazaruto/internal/store"
 	"github.com/edsonmichaque/bazaruto/pkg/job"
@@ -13,34 +19,53 @@ import (
 
 // ClaimProcessingService handles automated claim processing workflows and approval chains.
 type ClaimProcessingService struct {
-	claimStore   store.ClaimStore
-	policyStore  store.PolicyStore
-	userStore    store.UserStore
-	fraudService *FraudDetectionService
-	riskService  *RiskAssessmentService
-	eventService *EventService
-	dispatcher   job.Dispatcher
+	configManager  *config.Manager
+	claimStore     store.ClaimStore
+	policyStore    store.PolicyStore
+	userStore      store.UserStore
+	fraudService   *FraudDetectionService
+	riskService    *RiskAssessmentService
+	reserveService *ClaimReserveService
+	eventService   *EventService
+	dispatcher     job.Dispatcher
+	authorizer     authorization.Authorizer
+	auditService   *AuditService
+	coverageStore  store.CoverageStore
+	workflows      *claimWorkflowRegistry
 }
 
 // NewClaimProcessingService creates a new ClaimProcessingService instance.
 func NewClaimProcessingService(
+	configManager *config.Manager,
 	claimStore store.ClaimStore,
 	policyStore store.PolicyStore,
 	userStore store.UserStore,
+	workflowStore store.ClaimWorkflowStore,
 	fraudService *FraudDetectionService,
 	riskService *RiskAssessmentService,
+	reserveService *ClaimReserveService,
 	eventService *EventService,
 	dispatcher job.Dispatcher,
+	authorizer authorization.Authorizer,
+	auditService ...*AuditService,
 ) *ClaimProcessingService {
-	return &ClaimProcessingService{
-		claimStore:   claimStore,
-		policyStore:  policyStore,
-		userStore:    userStore,
-		fraudService: fraudService,
-		riskService:  riskService,
-		eventService: eventService,
-		dispatcher:   dispatcher,
-	}
+	service := &ClaimProcessingService{
+		configManager:  configManager,
+		claimStore:     claimStore,
+		policyStore:    policyStore,
+		userStore:      userStore,
+		fraudService:   fraudService,
+		riskService:    riskService,
+		reserveService: reserveService,
+		eventService:   eventService,
+		dispatcher:     dispatcher,
+		authorizer:     authorizer,
+		workflows:      newClaimWorkflowRegistry(workflowStore),
+	}
+	if len(auditService) > 0 {
+		service.auditService = auditService[0]
+	}
+	return service
 }
 
 // ClaimWorkflow represents the automated claim processing workflow.
@@ -70,8 +95,22 @@ type WorkflowStage struct {
 	Metadata     map[string]interface{} `json:"metadata"`
 }
 
-// ProcessClaim initiates the automated claim processing workflow.
+// ProcessClaim initiates the automated claim processing workflow. It is idempotent: while
+// a claim has an in-progress or completed workflow, that workflow is returned instead of
+// starting a new one. A failed workflow is replaced by a fresh run. The workflow is saved
+// when the run stops, and is marked failed when the run returns an error.
 func (s *ClaimProcessingService) ProcessClaim(ctx context.Context, claimID uuid.UUID) (*ClaimWorkflow, error) {
+	unlock := s.workflows.lock(claimID)
+	defer unlock()
+
+	existing, err := s.workflows.get(ctx, claimID)
+	if err == nil && existing.Status != "failed" {
+		return existing, nil
+	}
+	if err != nil && !errors.Is(err, ErrClaimWorkflowNotFound) {
+		return nil, fmt.Errorf("failed to get claim workflow: %w", err)
+	}
+
 	// Fetch claim details
 	claim, err := s.claimStore.GetClaim(ctx, claimID)
 	if err != nil {
@@ -97,14 +136,42 @@ func (s *ClaimProcessingService) ProcessClaim(ctx context.Context, claimID uuid.
 	// Define workflow stages based on claim characteristics
 	workflow.Stages = s.defineWorkflowStages(claim, policy)
 
+	// Set the initial reserve; the fraud score is not known yet and is applied
+	// once the fraud detection stage completes
+	if s.reserveService != nil {
+		reserve, err := s.reserveService.OpenReserve(ctx, claim, 0)
+		if err != nil {
+			workflow.Metadata["reserve_error"] = err.Error()
+		} else {
+			workflow.Metadata["reserve_amount"] = reserve.CurrentAmount
+		}
+	}
+
 	// Start processing
-	if err := s.executeWorkflowStage(ctx, workflow, "initial_review"); err != nil {
+	if err := s.runWorkflow(ctx, workflow, func() error {
+		return s.executeWorkflowStage(ctx, workflow, "initial_review")
+	}); err != nil {
 		return nil, fmt.Errorf("failed to execute initial review: %w", err)
 	}
 
 	return workflow, nil
 }
 
+// runWorkflow advances a workflow and saves it. A workflow whose run returns an error is
+// marked failed before it is saved, so it is never left in progress.
+func (s *ClaimProcessingService) runWorkflow(ctx context.Context, workflow *ClaimWorkflow, run func() error) error {
+	runErr := run()
+	if runErr != nil {
+		workflow.Status = "failed"
+		workflow.UpdatedAt = time.Now()
+	}
+
+	if err := s.workflows.save(ctx, workflow); err != nil {
+		return errors.Join(runErr, fmt.Errorf("failed to save claim workflow: %w", err))
+	}
+	return runErr
+}
+
 // defineWorkflowStages defines the workflow stages based on claim and policy characteristics.
 func (s *ClaimProcessingService) defineWorkflowStages(claim *models.Claim, policy *models.Policy) []WorkflowStage {
 	stages := []WorkflowStage{
@@ -123,6 +190,16 @@ func (s *ClaimProcessingService) defineWorkflowStages(claim *models.Claim, polic
 			Name:    "Policy Validation",
 			Status:  "pending",
 		},
+		{
+			StageID: "waiting_period",
+			Name:    "Waiting Period",
+			Status:  "pending",
+		},
+		{
+			StageID: "exclusion_check",
+			Name:    "Exclusion Check",
+			Status:  "pending",
+		},
 		{
 			StageID: "damage_assessment",
 			Name:    "Damage Assessment",
@@ -135,22 +212,21 @@ func (s *ClaimProcessingService) defineWorkflowStages(claim *models.Claim, polic
 		},
 	}
 
-	// Add conditional stages based on claim amount
-	if claim.ClaimAmount > 50000 {
-		stages = append(stages, WorkflowStage{
-			StageID: "senior_review",
-			Name:    "Senior Review",
-			Status:  "pending",
-		})
-	}
-
-	// Add conditional stages based on claim amount
-	if claim.ClaimAmount > 100000 {
-		stages = append(stages, WorkflowStage{
-			StageID: "executive_approval",
-			Name:    "Executive Approval",
+	// Add the approval stages the approval rules require for the claim
+	approval := ResolveApproval(s.configManager.GetConfig().ClaimProcessing.ApprovalRules, claim, policy)
+	for _, approvalStage := range approvalStages {
+		if !slices.Contains(approval.Stages, approvalStage.id) {
+			continue
+		}
+		stage := WorkflowStage{
+			StageID: approvalStage.id,
+			Name:    approvalStage.name,
 			Status:  "pending",
-		})
+		}
+		if len(approval.RequiredRoles) > 0 {
+			stage.Metadata = map[string]interface{}{"required_roles": approval.RequiredRoles}
+		}
+		stages = append(stages, stage)
 	}
 
 	// Add payout stage
@@ -179,44 +255,27 @@ func (s *ClaimProcessingService) executeWorkflowStage(ctx context.Context, workf
 	}
 
 	// Update stage status
-	stage.Status = "in_progress"
 	now := time.Now()
 	stage.StartedAt = &now
 	workflow.UpdatedAt = now
+	s.transitionStage(ctx, workflow, stage, "in_progress")
 
-	// Execute stage-specific logic
-	var err error
-	switch stageID {
-	case "initial_review":
-		err = s.executeInitialReview(ctx, workflow, stage)
-	case "fraud_detection":
-		err = s.executeFraudDetection(ctx, workflow, stage)
-	case "policy_validation":
-		err = s.executePolicyValidation(ctx, workflow, stage)
-	case "damage_assessment":
-		err = s.executeDamageAssessment(ctx, workflow, stage)
-	case "senior_review":
-		err = s.executeSeniorReview(ctx, workflow, stage)
-	case "executive_approval":
-		err = s.executeExecutiveApproval(ctx, workflow, stage)
-	case "approval_decision":
-		err = s.executeApprovalDecision(ctx, workflow, stage)
-	case "payout_processing":
-		err = s.executePayoutProcessing(ctx, workflow, stage)
-	default:
-		err = fmt.Errorf("unknown stage: %s", stageID)
-	}
+	// Execute stage-specific logic, retrying transient failures
+	err := s.runStageWithRetry(ctx, workflow, stage)
 
 	// Update stage completion
 	if err != nil {
-		stage.Status = "failed"
 		stage.Comments = err.Error()
 		workflow.Status = "failed"
+		s.transitionStage(ctx, workflow, stage, "failed")
 	} else {
-		stage.Status = "completed"
 		stage.CompletedAt = &now
+		s.transitionStage(ctx, workflow, stage, "completed")
 	}
 
+	// Adjust the claim reserve to reflect the stage outcome
+	s.adjustReserve(ctx, workflow, stage)
+
 	// Move to next stage if current stage completed successfully
 	if stage.Status == "completed" {
 		if err := s.moveToNextStage(ctx, workflow); err != nil {
@@ -227,6 +286,113 @@ func (s *ClaimProcessingService) executeWorkflowStage(ctx context.Context, workf
 	return err
 }
 
+// transitionStage moves a stage to a new status and publishes a stage changed event. A
+// failure to publish is recorded in the workflow metadata and does not affect the stage.
+func (s *ClaimProcessingService) transitionStage(ctx context.Context, workflow *ClaimWorkflow, stage *WorkflowStage, status string) {
+	from := stage.Status
+	stage.Status = status
+	if s.eventService == nil {
+		return
+	}
+
+	stageEvent := events.NewClaimStageChangedEvent(workflow.ClaimID, stage.StageID, from, status, stage.Result, time.Now())
+	if err := s.eventService.PublishEvent(ctx, stageEvent); err != nil {
+		workflow.Metadata["event_error"] = err.Error()
+	}
+}
+
+// runStageWithRetry runs a stage, retrying transient failures with backoff according to
+// the configured stage retry rules. Business rejections fail on the first attempt. The
+// number of attempts is recorded in the stage metadata when the stage was retried.
+func (s *ClaimProcessingService) runStageWithRetry(ctx context.Context, workflow *ClaimWorkflow, stage *WorkflowStage) error {
+	rules := s.configManager.GetConfig().ClaimProcessing.StageRetry
+	backoff := rules.InitialBackoff
+
+	attempt := 1
+	err := s.runStage(ctx, workflow, stage)
+	for ; err != nil && attempt < rules.MaxAttempts && isTransientStageError(ctx, stage, err); attempt++ {
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		if rules.Multiplier > 1 {
+			backoff = time.Duration(float64(backoff) * rules.Multiplier)
+		}
+		if rules.MaxBackoff > 0 && backoff > rules.MaxBackoff {
+			backoff = rules.MaxBackoff
+		}
+		err = s.runStage(ctx, workflow, stage)
+	}
+
+	if attempt > 1 {
+		if stage.Metadata == nil {
+			stage.Metadata = make(map[string]interface{})
+		}
+		stage.Metadata["attempts"] = attempt
+	}
+	return err
+}
+
+// isTransientStageError reports whether a stage failure may succeed on retry. Stages record
+// a result before returning a business rejection, so only failures without a result, such
+// as store errors, are transient; failures after the context is done never are.
+func isTransientStageError(ctx context.Context, stage *WorkflowStage, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	return stage.Result == ""
+}
+
+// runStage executes the stage-specific logic of a stage once.
+func (s *ClaimProcessingService) runStage(ctx context.Context, workflow *ClaimWorkflow, stage *WorkflowStage) error {
+	switch stage.StageID {
+	case "initial_review":
+		return s.executeInitialReview(ctx, workflow, stage)
+	case "fraud_detection":
+		return s.executeFraudDetection(ctx, workflow, stage)
+	case "policy_validation":
+		return s.executePolicyValidation(ctx, workflow, stage)
+	case "waiting_period":
+		return s.executeWaitingPeriodCheck(ctx, workflow, stage)
+	case "exclusion_check":
+		return s.executeExclusionCheck(ctx, workflow, stage)
+	case "damage_assessment":
+		return s.executeDamageAssessment(ctx, workflow, stage)
+	case "senior_review":
+		return s.executeSeniorReview(ctx, workflow, stage)
+	case "executive_approval":
+		return s.executeExecutiveApproval(ctx, workflow, stage)
+	case "approval_decision":
+		return s.executeApprovalDecision(ctx, workflow, stage)
+	case "payout_processing":
+		return s.executePayoutProcessing(ctx, workflow, stage)
+	default:
+		return fmt.Errorf("unknown stage: %s", stage.StageID)
+	}
+}
+
+// adjustReserve updates the claim reserve after a stage and records the result on the workflow.
+func (s *ClaimProcessingService) adjustReserve(ctx context.Context, workflow *ClaimWorkflow, stage *WorkflowStage) {
+	if s.reserveService == nil {
+		return
+	}
+
+	claim, err := s.claimStore.GetClaim(ctx, workflow.ClaimID)
+	if err != nil {
+		workflow.Metadata["reserve_error"] = err.Error()
+		return
+	}
+
+	reserve, err := s.reserveService.AdjustReserveForStage(ctx, claim, stage)
+	if err != nil {
+		workflow.Metadata["reserve_error"] = err.Error()
+		return
+	}
+
+	workflow.Metadata["reserve_amount"] = reserve.CurrentAmount
+}
+
 // executeInitialReview executes the initial review stage.
 func (s *ClaimProcessingService) executeInitialReview(ctx context.Context, workflow *ClaimWorkflow, stage *WorkflowStage) error {
 	// Fetch claim details
@@ -290,6 +456,15 @@ func (s *ClaimProcessingService) executeFraudDetection(ctx context.Context, work
 		"requires_review": fraudScore.RequiresReview,
 	}
 
+	if fraudScore.Disabled {
+		stage.Metadata["fraud_detection_disabled"] = true
+		stage.Result = "approved"
+		stage.Decision = "Fraud detection disabled"
+		stage.AutoApproved = true
+		stage.Comments = "Fraud detection is disabled; claim not screened"
+		return nil
+	}
+
 	// Make decision based on fraud score
 	if fraudScore.Score >= 80 {
 		stage.Result = "declined"
@@ -345,6 +520,11 @@ func (s *ClaimProcessingService) executePolicyValidation(ctx context.Context, wo
 		return fmt.Errorf("user does not own the policy")
 	}
 
+	// Validate the claim's peril is covered by the policy
+	if err := s.validatePerilCovered(ctx, claim, policy, stage); err != nil {
+		return err
+	}
+
 	// Auto-approve if all policy validations pass
 	stage.Result = "approved"
 	stage.Decision = "Policy validation passed"
@@ -362,8 +542,18 @@ func (s *ClaimProcessingService) executeDamageAssessment(ctx context.Context, wo
 		return fmt.Errorf("failed to fetch claim: %w", err)
 	}
 
+	category := ""
+	if policy, err := s.policyStore.GetPolicy(ctx, claim.PolicyID); err == nil {
+		category = policy.Product.Category
+	}
+	rules := s.damageAssessmentRules(category)
+	stage.Metadata = map[string]interface{}{
+		"auto_approve_max":   rules.AutoApproveMax,
+		"min_document_count": rules.MinDocumentCount,
+	}
+
 	// Check if damage assessment is required based on claim amount
-	if claim.ClaimAmount > 10000 {
+	if claim.ClaimAmount > rules.AutoApproveMax {
 		// For high-value claims, require manual assessment
 		stage.Result = "requires_review"
 		stage.Decision = "Manual damage assessment required"
@@ -372,7 +562,7 @@ func (s *ClaimProcessingService) executeDamageAssessment(ctx context.Context, wo
 	} else {
 		// For lower-value claims, auto-approve based on documentation
 		docCount := len(claim.Documents)
-		if docCount >= 2 {
+		if docCount >= rules.MinDocumentCount {
 			stage.Result = "approved"
 			stage.Decision = "Damage assessment approved based on documentation"
 			stage.AutoApproved = true
@@ -387,6 +577,16 @@ func (s *ClaimProcessingService) executeDamageAssessment(ctx context.Context, wo
 	return nil
 }
 
+// damageAssessmentRules returns the damage assessment rules for a product category,
+// falling back to the global rules when the category has no override.
+func (s *ClaimProcessingService) damageAssessmentRules(category string) config.DamageAssessmentRules {
+	claimConfig := s.configManager.GetConfig().ClaimProcessing
+	if rules, ok := claimConfig.CategoryDamageAssessment[category]; ok {
+		return rules
+	}
+	return claimConfig.DamageAssessment
+}
+
 // executeSeniorReview executes the senior review stage.
 func (s *ClaimProcessingService) executeSeniorReview(ctx context.Context, workflow *ClaimWorkflow, stage *WorkflowStage) error {
 	// This stage requires manual review by a senior adjuster
@@ -425,6 +625,7 @@ func (s *ClaimProcessingService) executeApprovalDecision(ctx context.Context, wo
 	approvedStages := 0
 	declinedStages := 0
 	reviewRequiredStages := 0
+	var declineReasons []string
 
 	for _, s := range workflow.Stages {
 		switch s.Result {
@@ -432,6 +633,9 @@ func (s *ClaimProcessingService) executeApprovalDecision(ctx context.Context, wo
 			approvedStages++
 		case "declined":
 			declinedStages++
+			if s.Decision != "" {
+				declineReasons = append(declineReasons, fmt.Sprintf("%s: %s", s.Name, s.Decision))
+			}
 		case "requires_review":
 			reviewRequiredStages++
 		}
@@ -445,13 +649,17 @@ func (s *ClaimProcessingService) executeApprovalDecision(ctx context.Context, wo
 
 		// Update claim status
 		claim, err := s.claimStore.GetClaim(ctx, workflow.ClaimID)
-		if err == nil {
-			claim.Status = models.ClaimStatusDenied
+		if err == nil && claim.TransitionTo(models.ClaimStatusDenied) == nil {
 			denialReason := stage.Decision
+			if len(declineReasons) > 0 {
+				denialReason = fmt.Sprintf("%s (%s)", denialReason, strings.Join(declineReasons, "; "))
+			}
 			claim.DenialReason = &denialReason
 			now := time.Now()
 			claim.ResolvedDate = &now
-			_ = s.claimStore.UpdateClaim(ctx, claim)
+			if s.claimStore.UpdateClaim(ctx, claim) == nil {
+				s.dispatchDecisionLetter(ctx, stage, claim.ID)
+			}
 		}
 
 		return fmt.Errorf("claim declined")
@@ -462,8 +670,7 @@ func (s *ClaimProcessingService) executeApprovalDecision(ctx context.Context, wo
 
 		// Update claim status
 		claim, err := s.claimStore.GetClaim(ctx, workflow.ClaimID)
-		if err == nil {
-			claim.Status = models.ClaimStatusUnderReview
+		if err == nil && claim.TransitionTo(models.ClaimStatusUnderReview) == nil {
 			_ = s.claimStore.UpdateClaim(ctx, claim)
 		}
 	} else {
@@ -474,15 +681,25 @@ func (s *ClaimProcessingService) executeApprovalDecision(ctx context.Context, wo
 
 		// Update claim status
 		claim, err := s.claimStore.GetClaim(ctx, workflow.ClaimID)
-		if err == nil {
-			claim.Status = models.ClaimStatusApproved
-			_ = s.claimStore.UpdateClaim(ctx, claim)
+		if err == nil && claim.TransitionTo(models.ClaimStatusApproved) == nil {
+			if s.claimStore.UpdateClaim(ctx, claim) == nil {
+				s.dispatchDecisionLetter(ctx, stage, claim.ID)
+			}
 		}
 	}
 
 	return nil
 }
 
+// dispatchDecisionLetter queues the customer's settlement or denial letter. A failed dispatch
+// does not change the decision; it is noted on the stage so the letter can be sent later.
+func (s *ClaimProcessingService) dispatchDecisionLetter(ctx context.Context, stage *WorkflowStage, claimID uuid.UUID) {
+	letterJob := &GenerateClaimLetterJob{ClaimID: claimID}
+	if err := s.dispatcher.PerformWithContext(ctx, letterJob); err != nil {
+		stage.Comments = fmt.Sprintf("%s; decision letter not dispatched: %v", stage.Comments, err)
+	}
+}
+
 // executePayoutProcessing executes the payout processing stage.
 func (s *ClaimProcessingService) executePayoutProcessing(ctx context.Context, workflow *ClaimWorkflow, stage *WorkflowStage) error {
 	// Only process payout if claim was approved
@@ -546,23 +763,28 @@ func (s *ClaimProcessingService) moveToNextStage(ctx context.Context, workflow *
 	return s.executeWorkflowStage(ctx, workflow, nextStage.StageID)
 }
 
-// GetWorkflowStatus retrieves the current status of a claim workflow.
+// GetWorkflowStatus retrieves the current status of a claim workflow. The workflow returned
+// is a copy taken under the claim's lock. It returns ErrClaimWorkflowNotFound when
+// ProcessClaim has not been called for the claim.
 func (s *ClaimProcessingService) GetWorkflowStatus(ctx context.Context, claimID uuid.UUID) (*ClaimWorkflow, error) {
-	// In a real implementation, this would retrieve from a workflow store
-	// For now, we'll create a new workflow and return its status
-	workflow, err := s.ProcessClaim(ctx, claimID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to process claim: %w", err)
-	}
+	unlock := s.workflows.lock(claimID)
+	defer unlock()
 
-	return workflow, nil
+	return s.workflows.get(ctx, claimID)
 }
 
 // UpdateWorkflowStage manually updates a workflow stage (for manual reviews).
 func (s *ClaimProcessingService) UpdateWorkflowStage(ctx context.Context, claimID uuid.UUID, stageID string, result, decision, comments string, assignedTo *uuid.UUID) error {
-	// In a real implementation, this would update the workflow in the store
-	// For now, we'll simulate the update
-	workflow, err := s.GetWorkflowStatus(ctx, claimID)
+	if s.authorizer != nil {
+		if err := s.authorizer.Authorize(ctx, authorization.PermissionClaimWorkflowOverride); err != nil {
+			return err
+		}
+	}
+
+	unlock := s.workflows.lock(claimID)
+	defer unlock()
+
+	workflow, err := s.workflows.get(ctx, claimID)
 	if err != nil {
 		return fmt.Errorf("failed to get workflow status: %w", err)
 	}
@@ -570,22 +792,108 @@ func (s *ClaimProcessingService) UpdateWorkflowStage(ctx context.Context, claimI
 	// Find and update the stage
 	for i := range workflow.Stages {
 		if workflow.Stages[i].StageID == stageID {
+			if err := s.authorizeStageRoles(ctx, &workflow.Stages[i]); err != nil {
+				return err
+			}
+			before := stageAuditSnapshot(&workflow.Stages[i])
 			workflow.Stages[i].Result = result
 			workflow.Stages[i].Decision = decision
 			workflow.Stages[i].Comments = comments
 			workflow.Stages[i].AssignedTo = assignedTo
-			workflow.Stages[i].Status = "completed"
+			s.transitionStage(ctx, workflow, &workflow.Stages[i], "completed")
 			now := time.Now()
 			workflow.Stages[i].CompletedAt = &now
 			workflow.UpdatedAt = now
+
+			if err := s.recordStageOverride(ctx, claimID, &workflow.Stages[i], before); err != nil {
+				return err
+			}
 			break
 		}
 	}
 
-	// Continue workflow if stage was completed
-	if result == "approved" || result == "declined" {
-		return s.moveToNextStage(ctx, workflow)
+	return s.runWorkflow(ctx, workflow, func() error {
+		// Continue workflow if stage was completed
+		if result == "approved" || result == "declined" {
+			return s.moveToNextStage(ctx, workflow)
+		}
+		return nil
+	})
+}
+
+// authorizeStageRoles checks that the actor in context holds one of the roles the approval
+// rules require to decide a stage. It is only enforced when an authorizer is configured.
+func (s *ClaimProcessingService) authorizeStageRoles(ctx context.Context, stage *WorkflowStage) error {
+	roles := stageRequiredRoles(stage)
+	if s.authorizer == nil || len(roles) == 0 {
+		return nil
 	}
 
+	actor, ok := authorization.ActorFromContext(ctx)
+	if !ok {
+		return fmt.Errorf("%w: no actor in context", authorization.ErrUnauthorized)
+	}
+	if !slices.Contains(roles, string(actor.Role)) {
+		return fmt.Errorf("%w: stage %s requires one of the roles %s", authorization.ErrUnauthorized, stage.StageID, strings.Join(roles, ", "))
+	}
 	return nil
 }
+
+// stageRequiredRoles returns the roles allowed to decide a stage. A stage loaded from the
+// workflow store holds them as a decoded JSON array.
+func stageRequiredRoles(stage *WorkflowStage) []string {
+	switch roles := stage.Metadata["required_roles"].(type) {
+	case []string:
+		return roles
+	case []interface{}:
+		names := make([]string, 0, len(roles))
+		for _, role := range roles {
+			if name, ok := role.(string); ok {
+				names = append(names, name)
+			}
+		}
+		return names
+	}
+	return nil
+}
+
+// recordStageOverride records a manual workflow stage update in the audit trail. The actor
+// is the user in context who made the update, not the user the stage is assigned to.
+func (s *ClaimProcessingService) recordStageOverride(ctx context.Context, claimID uuid.UUID, stage *WorkflowStage, before map[string]interface{}) error {
+	if s.auditService == nil {
+		return nil
+	}
+
+	var actorID *uuid.UUID
+	if actor, ok := authorization.ActorFromContext(ctx); ok {
+		actorID = &actor.ID
+	}
+
+	entry := &models.AuditLog{
+		EntityType: models.AuditEntityClaim,
+		EntityID:   claimID.String(),
+		Action:     models.AuditActionWorkflowStageOverride,
+		ActorID:    actorID,
+		Before:     before,
+		After:      stageAuditSnapshot(stage),
+		Reason:     stage.Comments,
+	}
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		return fmt.Errorf("failed to record workflow stage override: %w", err)
+	}
+	return nil
+}
+
+// stageAuditSnapshot captures the reviewable state of a workflow stage.
+func stageAuditSnapshot(stage *WorkflowStage) map[string]interface{} {
+	snapshot := map[string]interface{}{
+		"stage_id": stage.StageID,
+		"status":   stage.Status,
+		"result":   stage.Result,
+		"decision": stage.Decision,
+	}
+	if stage.AssignedTo != nil {
+		snapshot["assigned_to"] = stage.AssignedTo.String()
+	}
+	return snapshot
+}
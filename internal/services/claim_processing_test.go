@@ -0,0 +1,441 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/events"
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/edsonmichaque/bazaruto/pkg/event"
+	"github.com/edsonmichaque/bazaruto/pkg/job"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDamageAssessmentUsesConfiguredAutoApprovalThreshold(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+
+	newFixture := func(configManager *config.Manager, category string) (*ClaimProcessingService, *ClaimWorkflow) {
+		policy := &models.Policy{Product: models.Product{Category: category}}
+		claim := &models.Claim{
+			ClaimAmount: 15000,
+			Documents:   []models.Document{{FileName: "photo.jpg"}, {FileName: "estimate.pdf"}},
+		}
+		policyStore := newFakePolicyStore(policy)
+		claim.PolicyID = policy.ID
+		claimStore := newFakeClaimStore(claim)
+		svc := NewClaimProcessingService(configManager, claimStore, policyStore, nil, newFakeClaimWorkflowStore(), nil, nil, nil, nil, job.Dispatcher{}, nil)
+		return svc, &ClaimWorkflow{ClaimID: claim.ID}
+	}
+
+	// The default $10,000 threshold sends a $15,000 claim to manual review.
+	svc, workflow := newFixture(config.NewManager(log, ""), "auto")
+	stage := &WorkflowStage{StageID: "damage_assessment"}
+	require.NoError(t, svc.executeDamageAssessment(ctx, workflow, stage))
+	assert.Equal(t, "requires_review", stage.Result)
+
+	configManager := config.NewManager(log, filepath.Join(t.TempDir(), "business_rules.json"))
+	cfg := configManager.GetConfig()
+	cfg.ClaimProcessing.DamageAssessment.AutoApproveMax = 25000
+	cfg.ClaimProcessing.CategoryDamageAssessment = map[string]config.DamageAssessmentRules{
+		"life": {AutoApproveMax: 5000, MinDocumentCount: 2},
+	}
+	require.NoError(t, configManager.UpdateConfig(ctx, cfg))
+
+	svc, workflow = newFixture(configManager, "auto")
+	stage = &WorkflowStage{StageID: "damage_assessment"}
+	require.NoError(t, svc.executeDamageAssessment(ctx, workflow, stage))
+	assert.Equal(t, "approved", stage.Result)
+	assert.True(t, stage.AutoApproved)
+
+	// The category override takes precedence over the global threshold.
+	svc, workflow = newFixture(configManager, "life")
+	stage = &WorkflowStage{StageID: "damage_assessment"}
+	require.NoError(t, svc.executeDamageAssessment(ctx, workflow, stage))
+	assert.Equal(t, "requires_review", stage.Result)
+	assert.Equal(t, 5000.0, stage.Metadata["auto_approve_max"])
+}
+
+func TestPolicyValidationDeclinesUncoveredPeril(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+
+	productID := uuid.New()
+	userID := uuid.New()
+	policy := &models.Policy{
+		ProductID:      productID,
+		UserID:         userID,
+		Status:         models.PolicyStatusActive,
+		CoverageAmount: 250000,
+	}
+	policyStore := newFakePolicyStore(policy)
+	coverageStore := newFakeCoverageStore(
+		&models.Coverage{ProductID: productID, CoverageType: "fire", CoverageName: "Fire and smoke", IsIncluded: true},
+		&models.Coverage{ProductID: productID, CoverageType: "theft", CoverageName: "Theft", IsIncluded: true},
+		&models.Coverage{ProductID: productID, CoverageType: "flood", CoverageName: "Flood rider", IsIncluded: false},
+	)
+
+	validate := func(peril string) (*WorkflowStage, error) {
+		claim := &models.Claim{PolicyID: policy.ID, UserID: userID, ClaimAmount: 12000, Peril: peril}
+		svc := NewClaimProcessingService(config.NewManager(log, ""), newFakeClaimStore(claim), policyStore, nil, newFakeClaimWorkflowStore(), nil, nil, nil, nil, job.Dispatcher{}, nil)
+		svc.SetCoverageStore(coverageStore)
+		stage := &WorkflowStage{StageID: "policy_validation"}
+		return stage, svc.executePolicyValidation(ctx, &ClaimWorkflow{ClaimID: claim.ID}, stage)
+	}
+
+	stage, err := validate("flood")
+	require.Error(t, err)
+	assert.Equal(t, "declined", stage.Result)
+	assert.Equal(t, "Peril not covered by policy", stage.Decision)
+
+	stage, err = validate("")
+	require.Error(t, err)
+	assert.Equal(t, "declined", stage.Result)
+	assert.Equal(t, "Peril required", stage.Decision)
+
+	stage, err = validate("fire")
+	require.NoError(t, err)
+	assert.Equal(t, "approved", stage.Result)
+	assert.Equal(t, "Fire and smoke", stage.Metadata["coverage_name"])
+}
+
+func TestExclusionCheckDeniesExcludedClaimWithExclusionCode(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+
+	productID := uuid.New()
+	policy := &models.Policy{
+		ProductID: productID,
+		Status:    models.PolicyStatusActive,
+		Exclusions: []models.Exclusion{
+			{Code: "EXC-WAR", Description: "War, invasion and military action", Perils: []string{"war"}},
+		},
+	}
+	policyStore := newFakePolicyStore(policy)
+	coverageStore := newFakeCoverageStore(&models.Coverage{
+		ProductID:    productID,
+		CoverageType: "fire",
+		IsIncluded:   true,
+		Exclusions: []models.Exclusion{
+			{Code: "EXC-ARSON", Description: "Fire deliberately set by the insured", Keywords: []string{"set fire"}},
+		},
+	})
+
+	check := func(claim *models.Claim) (*WorkflowStage, error) {
+		claim.PolicyID = policy.ID
+		claim.Status = models.ClaimStatusSubmitted
+		svc := NewClaimProcessingService(config.NewManager(log, ""), newFakeClaimStore(claim), policyStore, nil, newFakeClaimWorkflowStore(), nil, nil, nil, nil, job.Dispatcher{}, nil)
+		svc.SetCoverageStore(coverageStore)
+		stage := &WorkflowStage{StageID: "exclusion_check"}
+		return stage, svc.executeExclusionCheck(ctx, &ClaimWorkflow{ClaimID: claim.ID}, stage)
+	}
+
+	excluded := &models.Claim{Title: "Store destroyed", Description: "Shelling during the conflict", Peril: "war"}
+	stage, err := check(excluded)
+	require.Error(t, err)
+	assert.Equal(t, "declined", stage.Result)
+	assert.Equal(t, "EXC-WAR", stage.Metadata["exclusion_code"])
+	assert.Equal(t, models.ClaimStatusDenied, excluded.Status)
+	require.NotNil(t, excluded.DenialReason)
+	assert.Contains(t, *excluded.DenialReason, "EXC-WAR")
+
+	// Coverage exclusions apply to claims under that coverage.
+	arson := &models.Claim{Title: "Kitchen fire", Description: "Tenant admitted they set fire to the kitchen", Peril: "fire"}
+	_, err = check(arson)
+	require.Error(t, err)
+	require.NotNil(t, arson.DenialReason)
+	assert.Contains(t, *arson.DenialReason, "EXC-ARSON")
+
+	covered := &models.Claim{Title: "Kitchen fire", Description: "Electrical fault in the oven", Peril: "fire"}
+	stage, err = check(covered)
+	require.NoError(t, err)
+	assert.Equal(t, "approved", stage.Result)
+	assert.Equal(t, models.ClaimStatusSubmitted, covered.Status)
+	assert.Nil(t, covered.DenialReason)
+}
+
+func TestWaitingPeriodCheckDeclinesIncidentsWithinWaitingPeriod(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+
+	effective := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	productID := uuid.New()
+	policy := &models.Policy{
+		ProductID:     productID,
+		Status:        models.PolicyStatusActive,
+		EffectiveDate: effective,
+		Product:       models.Product{Category: "health", WaitingPeriodDays: 30},
+	}
+	policyStore := newFakePolicyStore(policy)
+	coverageStore := newFakeCoverageStore(&models.Coverage{ProductID: productID, CoverageType: "maternity", IsIncluded: true, WaitingPeriodDays: 270})
+
+	check := func(peril string, incident time.Time) (*models.Claim, *WorkflowStage, error) {
+		claim := &models.Claim{PolicyID: policy.ID, Status: models.ClaimStatusSubmitted, Peril: peril, IncidentDate: incident}
+		svc := NewClaimProcessingService(config.NewManager(log, ""), newFakeClaimStore(claim), policyStore, nil, newFakeClaimWorkflowStore(), nil, nil, nil, nil, job.Dispatcher{}, nil)
+		svc.SetCoverageStore(coverageStore)
+		stage := &WorkflowStage{StageID: "waiting_period"}
+		return claim, stage, svc.executeWaitingPeriodCheck(ctx, &ClaimWorkflow{ClaimID: claim.ID}, stage)
+	}
+
+	// Inside the product's 30-day waiting period.
+	claim, stage, err := check("illness", effective.AddDate(0, 0, 10))
+	require.Error(t, err)
+	assert.Equal(t, "declined", stage.Result)
+	assert.Equal(t, models.ClaimStatusDenied, claim.Status)
+	require.NotNil(t, claim.DenialReason)
+	assert.Contains(t, *claim.DenialReason, "30-day waiting period")
+
+	// Outside it.
+	claim, stage, err = check("illness", effective.AddDate(0, 0, 45))
+	require.NoError(t, err)
+	assert.Equal(t, "approved", stage.Result)
+	assert.Equal(t, models.ClaimStatusSubmitted, claim.Status)
+
+	// The maternity coverage has a longer waiting period of its own.
+	_, stage, err = check("maternity", effective.AddDate(0, 0, 45))
+	require.Error(t, err)
+	assert.Equal(t, 270, stage.Metadata["waiting_period_days"])
+
+	// A renewal continues the original cover.
+	previousID := uuid.New()
+	policy.PreviousPolicyID = &previousID
+	_, stage, err = check("illness", effective.AddDate(0, 0, 10))
+	require.NoError(t, err)
+	assert.Equal(t, "approved", stage.Result)
+}
+
+func TestFraudDetectionStageAutoApprovesWhenFraudEngineDisabled(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	configManager := config.NewManager(log, filepath.Join(t.TempDir(), "business_rules.json"))
+	cfg := configManager.GetConfig()
+	cfg.FraudDetection.Enabled = false
+	require.NoError(t, configManager.UpdateConfig(ctx, cfg))
+
+	claim := &models.Claim{ClaimAmount: 2500}
+	claimStore := newFakeClaimStore(claim)
+	fraudService := NewFraudDetectionService(log, configManager, claimStore, newFakePolicyStore(), newFakeCustomerStore(), nil)
+	svc := NewClaimProcessingService(configManager, claimStore, newFakePolicyStore(), nil, newFakeClaimWorkflowStore(), fraudService, nil, nil, nil, job.Dispatcher{}, nil)
+
+	stage := &WorkflowStage{StageID: "fraud_detection"}
+	require.NoError(t, svc.executeFraudDetection(ctx, &ClaimWorkflow{ClaimID: claim.ID}, stage))
+
+	assert.Equal(t, "approved", stage.Result)
+	assert.True(t, stage.AutoApproved)
+	assert.Equal(t, true, stage.Metadata["fraud_detection_disabled"])
+	assert.Equal(t, 0.0, stage.Metadata["fraud_score"])
+}
+
+func TestProcessClaimReturnsExistingWorkflow(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	configManager := config.NewManager(log, filepath.Join(t.TempDir(), "business_rules.json"))
+	cfg := configManager.GetConfig()
+	cfg.FraudDetection.Enabled = false
+	require.NoError(t, configManager.UpdateConfig(ctx, cfg))
+
+	now := time.Now()
+	policy := &models.Policy{Base: models.Base{ID: uuid.New()}, Status: models.PolicyStatusActive, CoverageAmount: 100000,
+		EffectiveDate: now.AddDate(-1, 0, 0), ExpirationDate: now.AddDate(1, 0, 0)}
+	claim := &models.Claim{PolicyID: policy.ID, Title: "Burst pipe", Description: "Kitchen flooded", ClaimAmount: 2500,
+		Status: models.ClaimStatusSubmitted, IncidentDate: now.AddDate(0, 0, -2), ReportedDate: now}
+	claimStore := newFakeClaimStore(claim)
+	policyStore := newFakePolicyStore(policy)
+	fraudService := NewFraudDetectionService(log, configManager, claimStore, policyStore, newFakeCustomerStore(), nil)
+	svc := NewClaimProcessingService(configManager, claimStore, policyStore, nil, newFakeClaimWorkflowStore(), fraudService, nil, nil, nil, job.Dispatcher{}, nil)
+
+	_, err := svc.GetWorkflowStatus(ctx, claim.ID)
+	assert.ErrorIs(t, err, ErrClaimWorkflowNotFound)
+
+	first, err := svc.ProcessClaim(ctx, claim.ID)
+	require.NoError(t, err)
+	require.NotEqual(t, "failed", first.Status)
+
+	second, err := svc.ProcessClaim(ctx, claim.ID)
+	require.NoError(t, err)
+	assert.Equal(t, workflowProgress(first), workflowProgress(second), "stages must not be restarted")
+
+	status, err := svc.GetWorkflowStatus(ctx, claim.ID)
+	require.NoError(t, err)
+	assert.Equal(t, workflowProgress(first), workflowProgress(status))
+
+	// Each caller gets its own copy of the workflow.
+	status.Stages[0].Result = "declined"
+	again, err := svc.GetWorkflowStatus(ctx, claim.ID)
+	require.NoError(t, err)
+	assert.Equal(t, workflowProgress(first), workflowProgress(again))
+}
+
+func TestUpdateWorkflowStageMarksWorkflowFailedOnError(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	workflowStore := newFakeClaimWorkflowStore()
+	svc := NewClaimProcessingService(config.NewManager(log, ""), newFakeClaimStore(), newFakePolicyStore(), nil, workflowStore, nil, nil, nil, nil, job.Dispatcher{}, nil)
+
+	// The workflow's current stage is not one of its stages, so it cannot move on.
+	claimID := uuid.New()
+	require.NoError(t, svc.workflows.save(ctx, &ClaimWorkflow{
+		ClaimID:      claimID,
+		CurrentStage: "retired_stage",
+		Status:       "in_progress",
+		Stages:       []WorkflowStage{{StageID: "senior_review", Status: "in_progress"}},
+		Metadata:     make(map[string]interface{}),
+	}))
+
+	err := svc.UpdateWorkflowStage(ctx, claimID, "senior_review", "approved", "Approved", "", nil)
+	require.Error(t, err)
+
+	state, err := workflowStore.GetWorkflow(ctx, claimID)
+	require.NoError(t, err)
+	assert.Equal(t, "failed", state.Status)
+}
+
+// workflowProgress summarizes the status and result of each workflow stage.
+func workflowProgress(workflow *ClaimWorkflow) []string {
+	progress := []string{workflow.Status + ":" + workflow.CurrentStage}
+	for _, stage := range workflow.Stages {
+		progress = append(progress, stage.StageID+":"+stage.Status+":"+stage.Result)
+	}
+	return progress
+}
+
+// flakyClaimStore fails failures GetClaim calls after the first, as a database blip during
+// the workflow's first stage would.
+type flakyClaimStore struct {
+	*fakeClaimStore
+	failures int
+	calls    int
+}
+
+func (s *flakyClaimStore) GetClaim(ctx context.Context, id uuid.UUID) (*models.Claim, error) {
+	s.calls++
+	if s.calls > 1 && s.calls <= s.failures+1 {
+		return nil, fmt.Errorf("connection reset by peer")
+	}
+	return s.fakeClaimStore.GetClaim(ctx, id)
+}
+
+func newStageRetryTestService(t *testing.T, claim *models.Claim, failures int) (*ClaimProcessingService, *flakyClaimStore) {
+	t.Helper()
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	configManager := config.NewManager(log, filepath.Join(t.TempDir(), "business_rules.json"))
+	cfg := configManager.GetConfig()
+	cfg.FraudDetection.Enabled = false
+	cfg.ClaimProcessing.StageRetry = config.StageRetryRules{MaxAttempts: 3, InitialBackoff: time.Millisecond, Multiplier: 2}
+	require.NoError(t, configManager.UpdateConfig(ctx, cfg))
+
+	now := time.Now()
+	policy := &models.Policy{Base: models.Base{ID: uuid.New()}, Status: models.PolicyStatusActive, CoverageAmount: 100000,
+		EffectiveDate: now.AddDate(-1, 0, 0), ExpirationDate: now.AddDate(1, 0, 0)}
+	claim.PolicyID = policy.ID
+	claimStore := &flakyClaimStore{fakeClaimStore: newFakeClaimStore(claim), failures: failures}
+	policyStore := newFakePolicyStore(policy)
+	fraudService := NewFraudDetectionService(log, configManager, claimStore, policyStore, newFakeCustomerStore(), nil)
+	return NewClaimProcessingService(configManager, claimStore, policyStore, nil, newFakeClaimWorkflowStore(), fraudService, nil, nil, nil, job.Dispatcher{}, nil), claimStore
+}
+
+func TestWorkflowStageRetriesTransientFailures(t *testing.T) {
+	now := time.Now()
+	claim := &models.Claim{Title: "Burst pipe", Description: "Kitchen flooded", ClaimAmount: 2500,
+		Status: models.ClaimStatusSubmitted, IncidentDate: now.AddDate(0, 0, -2), ReportedDate: now}
+	svc, _ := newStageRetryTestService(t, claim, 2)
+
+	workflow, err := svc.ProcessClaim(context.Background(), claim.ID)
+	require.NoError(t, err)
+	assert.NotEqual(t, "failed", workflow.Status)
+
+	review := workflow.Stages[0]
+	require.Equal(t, "initial_review", review.StageID)
+	assert.Equal(t, "completed", review.Status)
+	assert.Equal(t, "approved", review.Result)
+	assert.Equal(t, 3, review.Metadata["attempts"])
+}
+
+func TestWorkflowStageFailsDeclinedStageWithoutRetry(t *testing.T) {
+	now := time.Now()
+	claim := &models.Claim{Description: "Kitchen flooded", ClaimAmount: 2500,
+		Status: models.ClaimStatusSubmitted, IncidentDate: now.AddDate(0, 0, -2), ReportedDate: now}
+	svc, claimStore := newStageRetryTestService(t, claim, 0)
+
+	_, err := svc.ProcessClaim(context.Background(), claim.ID)
+	require.Error(t, err)
+
+	workflow, err := svc.GetWorkflowStatus(context.Background(), claim.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "failed", workflow.Status)
+	assert.Equal(t, "declined", workflow.Stages[0].Result)
+	assert.NotContains(t, workflow.Stages[0].Metadata, "attempts")
+	assert.Equal(t, 2, claimStore.calls, "ProcessClaim fetch plus a single initial review attempt")
+}
+
+func TestWorkflowStageFailsAfterRetriesExhausted(t *testing.T) {
+	now := time.Now()
+	claim := &models.Claim{Title: "Burst pipe", Description: "Kitchen flooded", ClaimAmount: 2500,
+		Status: models.ClaimStatusSubmitted, IncidentDate: now.AddDate(0, 0, -2), ReportedDate: now}
+	svc, claimStore := newStageRetryTestService(t, claim, 5)
+
+	_, err := svc.ProcessClaim(context.Background(), claim.ID)
+	require.Error(t, err)
+	assert.Equal(t, 4, claimStore.calls, "ProcessClaim fetch plus three initial review attempts")
+}
+
+func TestWorkflowStageTransitionsPublishEvents(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	configManager := config.NewManager(log, filepath.Join(t.TempDir(), "business_rules.json"))
+	cfg := configManager.GetConfig()
+	cfg.FraudDetection.Enabled = false
+	require.NoError(t, configManager.UpdateConfig(ctx, cfg))
+
+	now := time.Now()
+	policy := &models.Policy{Base: models.Base{ID: uuid.New()}, Status: models.PolicyStatusActive, CoverageAmount: 100000,
+		EffectiveDate: now.AddDate(-1, 0, 0), ExpirationDate: now.AddDate(1, 0, 0)}
+	claim := &models.Claim{PolicyID: policy.ID, Title: "Burst pipe", Description: "Kitchen flooded", ClaimAmount: 2500,
+		Status: models.ClaimStatusSubmitted, IncidentDate: now.AddDate(0, 0, -2), ReportedDate: now}
+	claimStore := newFakeClaimStore(claim)
+	policyStore := newFakePolicyStore(policy)
+
+	bus := event.NewBus()
+	handler := &recordingHandler{}
+	require.NoError(t, bus.Subscribe(handler, events.EventTypeClaimStageChanged))
+	eventService := NewEventService(bus, log, nil)
+	fraudService := NewFraudDetectionService(log, configManager, claimStore, policyStore, newFakeCustomerStore(), nil)
+	svc := NewClaimProcessingService(configManager, claimStore, policyStore, nil, newFakeClaimWorkflowStore(), fraudService, nil, nil, eventService, job.Dispatcher{}, nil)
+
+	_, err := svc.ProcessClaim(ctx, claim.ID)
+	require.NoError(t, err)
+
+	// initialReviewEvents returns the initial review transitions received so far.
+	initialReviewEvents := func() []*events.ClaimStageChangedEvent {
+		handler.mu.Lock()
+		defer handler.mu.Unlock()
+		var transitions []*events.ClaimStageChangedEvent
+		for _, e := range handler.events {
+			stageEvent, ok := e.(*events.ClaimStageChangedEvent)
+			if ok && stageEvent.StageID == "initial_review" {
+				transitions = append(transitions, stageEvent)
+			}
+		}
+		return transitions
+	}
+	require.Eventually(t, func() bool { return len(initialReviewEvents()) == 2 }, time.Second, 10*time.Millisecond)
+
+	var completed *events.ClaimStageChangedEvent
+	for _, transition := range initialReviewEvents() {
+		assert.Equal(t, claim.ID, transition.ClaimID)
+		if transition.ToStatus == "completed" {
+			completed = transition
+		}
+	}
+	require.NotNil(t, completed)
+	assert.Equal(t, "in_progress", completed.FromStatus)
+	assert.Equal(t, "approved", completed.Result)
+}
@@ -0,0 +1,209 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/events"
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/edsonmichaque/bazaruto/internal/store"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultSettlementRatio is used when there is no settlement history to learn from.
+	DefaultSettlementRatio = 0.85
+	// MinSettlementRatio and MaxSettlementRatio bound the historical settlement ratio.
+	MinSettlementRatio = 0.1
+	MaxSettlementRatio = 1.0
+	// MaxFraudReserveReduction is the reserve reduction applied at a fraud score of 100.
+	MaxFraudReserveReduction = 0.5
+	// SettlementHistorySampleSize is the number of paid claims sampled for the settlement ratio.
+	SettlementHistorySampleSize = 500
+)
+
+// ClaimReserveService computes and maintains the financial reserve held against open claims.
+type ClaimReserveService struct {
+	reserveStore store.ClaimReserveStore
+	claimStore   store.ClaimStore
+	eventService *EventService
+	logger       *logger.Logger
+}
+
+// NewClaimReserveService creates a new ClaimReserveService instance.
+func NewClaimReserveService(
+	logger *logger.Logger,
+	reserveStore store.ClaimReserveStore,
+	claimStore store.ClaimStore,
+	eventService *EventService,
+) *ClaimReserveService {
+	return &ClaimReserveService{
+		reserveStore: reserveStore,
+		claimStore:   claimStore,
+		eventService: eventService,
+		logger:       logger,
+	}
+}
+
+// CalculateReserve estimates the reserve for a claim amount given its fraud score (0-100)
+// and the historical settlement ratio. Higher fraud scores lower the reserve because
+// suspicious claims are less likely to be paid in full.
+func (s *ClaimReserveService) CalculateReserve(claimAmount, fraudScore, settlementRatio float64) float64 {
+	if claimAmount <= 0 {
+		return 0
+	}
+
+	fraudScore = math.Max(0, math.Min(100, fraudScore))
+	fraudFactor := 1 - (fraudScore/100)*MaxFraudReserveReduction
+
+	return math.Round(claimAmount*settlementRatio*fraudFactor*100) / 100
+}
+
+// OpenReserve computes and records the initial reserve when a claim enters the workflow.
+// If a reserve already exists for the claim it is returned unchanged.
+func (s *ClaimReserveService) OpenReserve(ctx context.Context, claim *models.Claim, fraudScore float64) (*models.ClaimReserve, error) {
+	existing, err := s.reserveStore.GetReserveByClaimID(ctx, claim.ID)
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, store.ErrNotFound) {
+		return nil, fmt.Errorf("failed to get claim reserve: %w", err)
+	}
+
+	settlementRatio := s.historicalSettlementRatio(ctx)
+	amount := s.CalculateReserve(claim.ClaimAmount, fraudScore, settlementRatio)
+
+	reserve := &models.ClaimReserve{
+		ClaimID:         claim.ID,
+		InitialAmount:   amount,
+		CurrentAmount:   amount,
+		Currency:        claim.Currency,
+		FraudScore:      fraudScore,
+		SettlementRatio: settlementRatio,
+		Stage:           "initial_review",
+		Status:          models.ReserveStatusOpen,
+		Reason:          "Initial reserve on workflow entry",
+	}
+
+	if err := s.reserveStore.CreateReserve(ctx, reserve); err != nil {
+		return nil, fmt.Errorf("failed to create claim reserve: %w", err)
+	}
+
+	s.publishReserveChanged(ctx, reserve, 0)
+	return reserve, nil
+}
+
+// AdjustReserveForStage updates the reserve after a workflow stage completes.
+// A declined stage releases the reserve; the fraud stage re-weights it with the actual
+// fraud score; an approval sets it to the full claim amount; a payout closes it.
+func (s *ClaimReserveService) AdjustReserveForStage(ctx context.Context, claim *models.Claim, stage *WorkflowStage) (*models.ClaimReserve, error) {
+	reserve, err := s.reserveStore.GetReserveByClaimID(ctx, claim.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get claim reserve: %w", err)
+	}
+
+	if reserve.Status == models.ReserveStatusClosed {
+		return reserve, nil
+	}
+
+	previous := reserve.CurrentAmount
+	newAmount := previous
+	reason := ""
+
+	switch {
+	case stage.Result == "declined":
+		newAmount = 0
+		reserve.Status = models.ReserveStatusClosed
+		reason = fmt.Sprintf("Reserve released: %s", stage.Decision)
+	case stage.StageID == "fraud_detection":
+		if score, ok := stage.Metadata["fraud_score"].(float64); ok {
+			reserve.FraudScore = score
+			newAmount = s.CalculateReserve(claim.ClaimAmount, score, reserve.SettlementRatio)
+			reason = fmt.Sprintf("Reserve re-weighted for fraud score %.2f", score)
+		}
+	case stage.StageID == "approval_decision" && stage.Result == "approved":
+		newAmount = claim.ClaimAmount
+		reason = "Reserve set to approved claim amount"
+	case stage.StageID == "payout_processing" && stage.Result == "approved":
+		newAmount = 0
+		reserve.Status = models.ReserveStatusClosed
+		reason = "Reserve released on payout"
+	}
+
+	if newAmount == previous && reserve.Status == models.ReserveStatusOpen {
+		return reserve, nil
+	}
+
+	reserve.CurrentAmount = newAmount
+	reserve.Stage = stage.StageID
+	reserve.Reason = reason
+
+	if err := s.reserveStore.UpdateReserve(ctx, reserve); err != nil {
+		return nil, fmt.Errorf("failed to update claim reserve: %w", err)
+	}
+
+	s.publishReserveChanged(ctx, reserve, previous)
+	return reserve, nil
+}
+
+// GetReserve retrieves the reserve held against a claim.
+func (s *ClaimReserveService) GetReserve(ctx context.Context, claimID uuid.UUID) (*models.ClaimReserve, error) {
+	if claimID == uuid.Nil {
+		return nil, fmt.Errorf("claim ID is required")
+	}
+
+	return s.reserveStore.GetReserveByClaimID(ctx, claimID)
+}
+
+// historicalSettlementRatio derives the paid-to-claimed ratio from recently paid claims.
+func (s *ClaimReserveService) historicalSettlementRatio(ctx context.Context) float64 {
+	claims, err := s.claimStore.ListClaims(ctx, nil, nil, models.ClaimStatusPaid, SettlementHistorySampleSize, 0)
+	if err != nil {
+		s.logger.Warn("Failed to load settlement history, using default ratio", zap.Error(err))
+		return DefaultSettlementRatio
+	}
+
+	totalClaimed := 0.0
+	totalPaid := 0.0
+	for _, claim := range claims {
+		if claim.ClaimAmount <= 0 {
+			continue
+		}
+		totalClaimed += claim.ClaimAmount
+		totalPaid += claim.PaidAmount
+	}
+
+	if totalClaimed == 0 {
+		return DefaultSettlementRatio
+	}
+
+	return math.Max(MinSettlementRatio, math.Min(MaxSettlementRatio, totalPaid/totalClaimed))
+}
+
+// publishReserveChanged publishes a reserve changed event.
+func (s *ClaimReserveService) publishReserveChanged(ctx context.Context, reserve *models.ClaimReserve, previousAmount float64) {
+	if s.eventService == nil {
+		return
+	}
+
+	reserveEvent := events.NewClaimReserveChangedEvent(
+		reserve.ClaimID,
+		previousAmount,
+		reserve.CurrentAmount,
+		reserve.Currency,
+		reserve.Stage,
+		reserve.Reason,
+		time.Now(),
+	)
+
+	if err := s.eventService.PublishEvent(ctx, reserveEvent); err != nil {
+		s.logger.Error("Failed to publish claim reserve changed event",
+			zap.Error(err),
+			zap.String("claim_id", reserve.ClaimID.String()))
+	}
+}
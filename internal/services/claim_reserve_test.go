@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimReserveFraudScoreLowersInitialReserve(t *testing.T) {
+	ctx := context.Background()
+	svc := NewClaimReserveService(logger.NewLogger("error", "json"), newFakeClaimReserveStore(), newFakeClaimStore(), nil)
+
+	clean := &models.Claim{ClaimAmount: 20000, Currency: "USD"}
+	suspicious := &models.Claim{ClaimAmount: 20000, Currency: "USD"}
+	clean.ID, suspicious.ID = uuid.New(), uuid.New()
+
+	cleanReserve, err := svc.OpenReserve(ctx, clean, 5)
+	require.NoError(t, err)
+	suspiciousReserve, err := svc.OpenReserve(ctx, suspicious, 85)
+	require.NoError(t, err)
+
+	assert.Less(t, suspiciousReserve.InitialAmount, cleanReserve.InitialAmount)
+	assert.Equal(t, DefaultSettlementRatio, cleanReserve.SettlementRatio)
+}
+
+func TestClaimReserveAdjustsAcrossStages(t *testing.T) {
+	ctx := context.Background()
+	claim := &models.Claim{ClaimAmount: 10000, Currency: "USD"}
+	claim.ID = uuid.New()
+	paid := &models.Claim{ClaimAmount: 10000, PaidAmount: 6000, Status: models.ClaimStatusPaid}
+	svc := NewClaimReserveService(logger.NewLogger("error", "json"), newFakeClaimReserveStore(), newFakeClaimStore(paid), nil)
+
+	reserve, err := svc.OpenReserve(ctx, claim, 0)
+	require.NoError(t, err)
+	assert.InDelta(t, 6000, reserve.InitialAmount, 0.01)
+
+	reserve, err = svc.AdjustReserveForStage(ctx, claim, &WorkflowStage{
+		StageID:  "fraud_detection",
+		Result:   "approved",
+		Metadata: map[string]interface{}{"fraud_score": 40.0},
+	})
+	require.NoError(t, err)
+	assert.InDelta(t, 4800, reserve.CurrentAmount, 0.01)
+
+	reserve, err = svc.AdjustReserveForStage(ctx, claim, &WorkflowStage{StageID: "approval_decision", Result: "declined"})
+	require.NoError(t, err)
+	assert.Zero(t, reserve.CurrentAmount)
+	assert.Equal(t, models.ReserveStatusClosed, reserve.Status)
+}
+
+// failingClaimReserveStore is a ClaimReserveStore whose reads fail.
+type failingClaimReserveStore struct {
+	*fakeClaimReserveStore
+}
+
+func (s *failingClaimReserveStore) GetReserveByClaimID(ctx context.Context, claimID uuid.UUID) (*models.ClaimReserve, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestOpenReserveFailsOnStoreError(t *testing.T) {
+	reserves := &failingClaimReserveStore{newFakeClaimReserveStore()}
+	svc := NewClaimReserveService(logger.NewLogger("error", "json"), reserves, newFakeClaimStore(), nil)
+	claim := &models.Claim{ClaimAmount: 10000, Currency: "USD"}
+	claim.ID = uuid.New()
+
+	_, err := svc.OpenReserve(context.Background(), claim, 0)
+	require.Error(t, err)
+	assert.Empty(t, reserves.reserves, "no reserve is created when the existing one cannot be read")
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/edsonmichaque/bazaruto/internal/store"
+	"github.com/google/uuid"
+)
+
+// ErrClaimWorkflowNotFound is returned when no workflow has been started for a claim.
+var ErrClaimWorkflowNotFound = errors.New("claim workflow not found")
+
+// claimWorkflowRegistry keeps the workflow started for each claim in the workflow store, so a
+// claim is processed by a single workflow across restarts. Work on a claim's workflow is
+// serialized by a per-claim lock that is released once no caller holds or waits for it.
+type claimWorkflowRegistry struct {
+	store store.ClaimWorkflowStore
+	mu    sync.Mutex
+	locks map[uuid.UUID]*claimWorkflowLock
+}
+
+// claimWorkflowLock is a claim's lock and the number of callers holding or waiting for it.
+type claimWorkflowLock struct {
+	mu      sync.Mutex
+	holders int
+}
+
+// newClaimWorkflowRegistry creates a claim workflow registry backed by a workflow store.
+func newClaimWorkflowRegistry(workflowStore store.ClaimWorkflowStore) *claimWorkflowRegistry {
+	return &claimWorkflowRegistry{
+		store: workflowStore,
+		locks: make(map[uuid.UUID]*claimWorkflowLock),
+	}
+}
+
+// lock acquires the claim's lock and returns the function that releases it.
+func (r *claimWorkflowRegistry) lock(claimID uuid.UUID) func() {
+	r.mu.Lock()
+	claimLock, ok := r.locks[claimID]
+	if !ok {
+		claimLock = &claimWorkflowLock{}
+		r.locks[claimID] = claimLock
+	}
+	claimLock.holders++
+	r.mu.Unlock()
+
+	claimLock.mu.Lock()
+	return func() {
+		claimLock.mu.Unlock()
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		claimLock.holders--
+		if claimLock.holders == 0 {
+			delete(r.locks, claimID)
+		}
+	}
+}
+
+// get loads the workflow started for a claim. Each call returns its own copy, so callers
+// never share a workflow with another caller. It returns ErrClaimWorkflowNotFound when no
+// workflow has been saved for the claim.
+func (r *claimWorkflowRegistry) get(ctx context.Context, claimID uuid.UUID) (*ClaimWorkflow, error) {
+	state, err := r.store.GetWorkflow(ctx, claimID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrClaimWorkflowNotFound, claimID)
+		}
+		return nil, err
+	}
+
+	var workflow ClaimWorkflow
+	if err := json.Unmarshal([]byte(state.Workflow), &workflow); err != nil {
+		return nil, fmt.Errorf("failed to decode claim workflow: %w", err)
+	}
+	return &workflow, nil
+}
+
+// save stores the workflow of its claim, replacing any earlier one.
+func (r *claimWorkflowRegistry) save(ctx context.Context, workflow *ClaimWorkflow) error {
+	encoded, err := json.Marshal(workflow)
+	if err != nil {
+		return fmt.Errorf("failed to encode claim workflow: %w", err)
+	}
+
+	return r.store.SaveWorkflow(ctx, &models.ClaimWorkflowState{
+		ClaimID:      workflow.ClaimID,
+		Status:       workflow.Status,
+		CurrentStage: workflow.CurrentStage,
+		Workflow:     string(encoded),
+	})
+}
@@ -0,0 +1,284 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/edsonmichaque/bazaruto/internal/store"
+	"github.com/google/uuid"
+)
+
+// analyticsPageSize is the number of claims or policies fetched per store call.
+const analyticsPageSize = 100
+
+// ClaimsAnalyticsService produces aggregate claims metrics, such as loss ratio and settlement
+// times, for a reporting period and segment.
+type ClaimsAnalyticsService struct {
+	configManager *config.Manager
+	claimStore    store.ClaimStore
+	policyStore   store.PolicyStore
+	reserveStore  store.ClaimReserveStore
+	workflowStore store.ClaimWorkflowStore
+}
+
+// NewClaimsAnalyticsService creates a new ClaimsAnalyticsService instance. The reserve store
+// is optional; without it outstanding reserves are not counted as incurred losses and fraud
+// flags come only from SIU referrals. The workflow store is optional; without it settlement
+// times are not broken down by workflow stage.
+func NewClaimsAnalyticsService(configManager *config.Manager, claimStore store.ClaimStore, policyStore store.PolicyStore, reserveStore store.ClaimReserveStore, workflowStore store.ClaimWorkflowStore) *ClaimsAnalyticsService {
+	return &ClaimsAnalyticsService{
+		configManager: configManager,
+		claimStore:    claimStore,
+		policyStore:   policyStore,
+		reserveStore:  reserveStore,
+		workflowStore: workflowStore,
+	}
+}
+
+// ClaimsAnalyticsRequest selects the period and segment to report on.
+type ClaimsAnalyticsRequest struct {
+	From      time.Time  `json:"from"`
+	To        time.Time  `json:"to"`
+	ProductID *uuid.UUID `json:"product_id,omitempty"` // Limits the report to one product
+	Region    string     `json:"region,omitempty"`     // Policyholder country, e.g. MZ
+}
+
+// ClaimsAnalyticsReport holds claims metrics for claims reported in a period.
+type ClaimsAnalyticsReport struct {
+	From      time.Time  `json:"from"`
+	To        time.Time  `json:"to"`
+	ProductID *uuid.UUID `json:"product_id,omitempty"`
+	Region    string     `json:"region,omitempty"`
+
+	ClaimCount    int     `json:"claim_count"`
+	ApprovedCount int     `json:"approved_count"` // Approved or paid
+	DeniedCount   int     `json:"denied_count"`
+	OpenCount     int     `json:"open_count"`
+	ApprovalRate  float64 `json:"approval_rate"` // Share of decided claims approved (0-1)
+	DeclineRate   float64 `json:"decline_rate"`  // Share of decided claims denied (0-1)
+
+	FraudFlagCount int     `json:"fraud_flag_count"`
+	FraudFlagRate  float64 `json:"fraud_flag_rate"` // Share of claims flagged for fraud (0-1)
+
+	PaidLosses          float64 `json:"paid_losses"`
+	OutstandingReserves float64 `json:"outstanding_reserves"`
+	IncurredLosses      float64 `json:"incurred_losses"` // Paid losses plus outstanding reserves
+	EarnedPremium       float64 `json:"earned_premium"`
+	LossRatio           float64 `json:"loss_ratio"` // Incurred losses over earned premium
+
+	AverageSettlementDays float64            `json:"average_settlement_days"`
+	SettlementDaysByStage map[string]float64 `json:"settlement_days_by_stage"` // Average days each claim workflow stage took, by stage name
+	GeneratedAt           time.Time          `json:"generated_at"`
+}
+
+// GenerateReport computes claims metrics for claims reported in the request's period and
+// segment. Earned premium is each issued policy's premium pro-rated to the part of its term
+// that falls in the period.
+func (s *ClaimsAnalyticsService) GenerateReport(ctx context.Context, request *ClaimsAnalyticsRequest) (*ClaimsAnalyticsReport, error) {
+	if request.From.IsZero() || request.To.IsZero() || !request.To.After(request.From) {
+		return nil, fmt.Errorf("a reporting period with to after from is required")
+	}
+
+	report := &ClaimsAnalyticsReport{
+		From:                  request.From,
+		To:                    request.To,
+		ProductID:             request.ProductID,
+		Region:                request.Region,
+		SettlementDaysByStage: make(map[string]float64),
+		GeneratedAt:           time.Now(),
+	}
+
+	claims, err := s.segmentClaims(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	s.addClaimMetrics(ctx, report, claims)
+	if err := s.addStageSettlementTimes(ctx, report, claims); err != nil {
+		return nil, err
+	}
+
+	earned, err := s.earnedPremium(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	report.EarnedPremium = earned
+	report.IncurredLosses = report.PaidLosses + report.OutstandingReserves
+	if earned > 0 {
+		report.LossRatio = report.IncurredLosses / earned
+	}
+
+	return report, nil
+}
+
+// addClaimMetrics adds counts, rates, losses and settlement times for claims to the report.
+func (s *ClaimsAnalyticsService) addClaimMetrics(ctx context.Context, report *ClaimsAnalyticsReport, claims []*models.Claim) {
+	fraudThreshold := s.configManager.GetConfig().FraudDetection.RiskThresholds.High
+	totalSettlementDays := 0.0
+	settled := 0
+
+	for _, claim := range claims {
+		report.ClaimCount++
+		report.PaidLosses += claim.PaidAmount
+
+		switch claim.Status {
+		case models.ClaimStatusApproved, models.ClaimStatusPaid:
+			report.ApprovedCount++
+		case models.ClaimStatusDenied:
+			report.DeniedCount++
+		default:
+			report.OpenCount++
+		}
+
+		flagged := claim.SIUCaseID != ""
+		if s.reserveStore != nil {
+			if reserve, err := s.reserveStore.GetReserveByClaimID(ctx, claim.ID); err == nil {
+				if reserve.Status == models.ReserveStatusOpen {
+					report.OutstandingReserves += reserve.CurrentAmount
+				}
+				if fraudThreshold > 0 && reserve.FraudScore >= fraudThreshold {
+					flagged = true
+				}
+			}
+		}
+		if flagged {
+			report.FraudFlagCount++
+		}
+
+		if claim.ResolvedDate != nil {
+			totalSettlementDays += claim.ResolvedDate.Sub(claim.ReportedDate).Hours() / 24
+			settled++
+		}
+	}
+
+	if decided := report.ApprovedCount + report.DeniedCount; decided > 0 {
+		report.ApprovalRate = float64(report.ApprovedCount) / float64(decided)
+		report.DeclineRate = float64(report.DeniedCount) / float64(decided)
+	}
+	if report.ClaimCount > 0 {
+		report.FraudFlagRate = float64(report.FraudFlagCount) / float64(report.ClaimCount)
+	}
+	if settled > 0 {
+		report.AverageSettlementDays = totalSettlementDays / float64(settled)
+	}
+}
+
+// addStageSettlementTimes adds the average time each claim workflow stage took to the report,
+// over the completed stages of the claims' workflows. Claims without a workflow are skipped.
+func (s *ClaimsAnalyticsService) addStageSettlementTimes(ctx context.Context, report *ClaimsAnalyticsReport, claims []*models.Claim) error {
+	if s.workflowStore == nil {
+		return nil
+	}
+
+	workflows := newClaimWorkflowRegistry(s.workflowStore)
+	totals := make(map[string]float64)
+	counts := make(map[string]int)
+	for _, claim := range claims {
+		workflow, err := workflows.get(ctx, claim.ID)
+		if err != nil {
+			if errors.Is(err, ErrClaimWorkflowNotFound) {
+				continue
+			}
+			return fmt.Errorf("failed to load claim workflow: %w", err)
+		}
+		for _, stage := range workflow.Stages {
+			if stage.StartedAt == nil || stage.CompletedAt == nil {
+				continue
+			}
+			totals[stage.Name] += stage.CompletedAt.Sub(*stage.StartedAt).Hours() / 24
+			counts[stage.Name]++
+		}
+	}
+
+	for stage, total := range totals {
+		report.SettlementDaysByStage[stage] = total / float64(counts[stage])
+	}
+	return nil
+}
+
+// segmentClaims returns the claims reported in the period that belong to the segment.
+func (s *ClaimsAnalyticsService) segmentClaims(ctx context.Context, request *ClaimsAnalyticsRequest) ([]*models.Claim, error) {
+	from, to := request.From, request.To
+	filter := &models.ClaimFilter{ReportedFrom: &from, ReportedTo: &to}
+	page := &models.CursorPage{Limit: analyticsPageSize}
+
+	var claims []*models.Claim
+	for {
+		result, err := s.claimStore.List(ctx, filter, page)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list claims: %w", err)
+		}
+		for _, claim := range result.Claims {
+			if request.ProductID != nil && claim.Policy.ProductID != *request.ProductID {
+				continue
+			}
+			if request.Region != "" && claim.User.Address.Country != request.Region {
+				continue
+			}
+			claims = append(claims, claim)
+		}
+		if result.NextCursor == "" {
+			return claims, nil
+		}
+		page.Cursor = result.NextCursor
+	}
+}
+
+// earningPolicyStatuses are the statuses of issued policies, which earn premium over their
+// term. Pending policies have not been issued and earn nothing.
+var earningPolicyStatuses = []models.PolicyStatus{
+	models.PolicyStatusActive,
+	models.PolicyStatusSuspended,
+	models.PolicyStatusInactive,
+	models.PolicyStatusExpired,
+	models.PolicyStatusCancelled,
+}
+
+// earnedPremium returns the premium earned in the period by the segment's issued policies
+// whose term overlaps the period.
+func (s *ClaimsAnalyticsService) earnedPremium(ctx context.Context, request *ClaimsAnalyticsRequest) (float64, error) {
+	earned := 0.0
+	for offset := 0; ; offset += analyticsPageSize {
+		policies, err := s.policyStore.ListPoliciesInTerm(ctx, request.ProductID, earningPolicyStatuses, request.From, request.To, analyticsPageSize, offset)
+		if err != nil {
+			return 0, fmt.Errorf("failed to list policies: %w", err)
+		}
+		for _, policy := range policies {
+			if request.Region != "" && policy.User.Address.Country != request.Region {
+				continue
+			}
+			earned += earnedPolicyPremium(policy, request.From, request.To)
+		}
+		if len(policies) < analyticsPageSize {
+			return earned, nil
+		}
+	}
+}
+
+// earnedPolicyPremium pro-rates a policy's premium to the part of its term between from and to.
+// A cancelled policy stops earning when it was cancelled, its last update since cancellation
+// is final.
+func earnedPolicyPremium(policy *models.Policy, from, to time.Time) float64 {
+	term := policy.ExpirationDate.Sub(policy.EffectiveDate)
+	if term <= 0 {
+		return 0
+	}
+
+	start, end := policy.EffectiveDate, policy.ExpirationDate
+	if policy.Status == models.PolicyStatusCancelled && !policy.UpdatedAt.IsZero() && policy.UpdatedAt.Before(end) {
+		end = policy.UpdatedAt
+	}
+	if from.After(start) {
+		start = from
+	}
+	if to.Before(end) {
+		end = to
+	}
+	if !end.After(start) {
+		return 0
+	}
+	return policy.Premium * float64(end.Sub(start)) / float64(term)
+}
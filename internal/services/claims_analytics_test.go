@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimsAnalyticsComputesLossRatio(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+
+	yearStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	yearEnd := yearStart.AddDate(1, 0, 0)
+	day := func(month time.Month, d int) time.Time { return time.Date(2024, month, d, 0, 0, 0, 0, time.UTC) }
+	resolved := func(t time.Time) *time.Time { return &t }
+
+	homeID, autoID := uuid.New(), uuid.New()
+	homeA := &models.Policy{ProductID: homeID, Premium: 10000, Status: models.PolicyStatusActive, EffectiveDate: yearStart, ExpirationDate: yearEnd}
+	homeB := &models.Policy{ProductID: homeID, Premium: 10000, Status: models.PolicyStatusExpired, EffectiveDate: yearStart, ExpirationDate: yearEnd}
+	auto := &models.Policy{ProductID: autoID, Premium: 5000, Status: models.PolicyStatusActive, EffectiveDate: yearStart, ExpirationDate: yearEnd}
+	// Neither an unissued policy nor one whose term starts after the period earns premium.
+	pending := &models.Policy{ProductID: homeID, Premium: 7000, Status: models.PolicyStatusPending, EffectiveDate: yearStart, ExpirationDate: yearEnd}
+	nextYear := &models.Policy{ProductID: homeID, Premium: 9000, Status: models.PolicyStatusActive, EffectiveDate: yearEnd, ExpirationDate: yearEnd.AddDate(1, 0, 0)}
+	policyStore := newFakePolicyStore(homeA, homeB, auto, pending, nextYear)
+
+	paid := &models.Claim{Policy: *homeA, Status: models.ClaimStatusPaid, PaidAmount: 6000,
+		ReportedDate: day(3, 1), ResolvedDate: resolved(day(3, 11))}
+	denied := &models.Claim{Policy: *homeB, Status: models.ClaimStatusDenied, SIUCaseID: "SIU-1",
+		ReportedDate: day(4, 1), ResolvedDate: resolved(day(4, 5))}
+	open := &models.Claim{Policy: *homeA, Status: models.ClaimStatusUnderReview, ReportedDate: day(9, 15)}
+	otherProduct := &models.Claim{Policy: *auto, Status: models.ClaimStatusPaid, PaidAmount: 50000,
+		ReportedDate: day(5, 1), ResolvedDate: resolved(day(5, 2))}
+	claimStore := newFakeClaimStore(paid, denied, open, otherProduct)
+
+	reserveStore := newFakeClaimReserveStore()
+	require.NoError(t, reserveStore.CreateReserve(ctx, &models.ClaimReserve{ClaimID: open.ID, CurrentAmount: 2000, Status: models.ReserveStatusOpen}))
+	require.NoError(t, reserveStore.CreateReserve(ctx, &models.ClaimReserve{ClaimID: paid.ID, CurrentAmount: 0, Status: models.ReserveStatusClosed}))
+
+	workflowStore := newFakeClaimWorkflowStore()
+	workflows := newClaimWorkflowRegistry(workflowStore)
+	for _, claim := range []*models.Claim{paid, denied} {
+		started := claim.ReportedDate
+		validated := started.AddDate(0, 0, 1)
+		require.NoError(t, workflows.save(ctx, &ClaimWorkflow{ClaimID: claim.ID, Stages: []WorkflowStage{
+			{Name: "Policy Validation", StartedAt: &started, CompletedAt: &validated},
+			{Name: "Damage Assessment", StartedAt: &validated, CompletedAt: claim.ResolvedDate},
+		}}))
+	}
+
+	svc := NewClaimsAnalyticsService(config.NewManager(log, ""), claimStore, policyStore, reserveStore, workflowStore)
+	report, err := svc.GenerateReport(ctx, &ClaimsAnalyticsRequest{From: yearStart, To: yearEnd, ProductID: &homeID})
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, report.ClaimCount)
+	assert.InDelta(t, 20000, report.EarnedPremium, 0.001)
+	assert.InDelta(t, 6000, report.PaidLosses, 0.001)
+	assert.InDelta(t, 2000, report.OutstandingReserves, 0.001)
+	assert.InDelta(t, 8000, report.IncurredLosses, 0.001)
+	assert.InDelta(t, 0.4, report.LossRatio, 0.0001)
+
+	assert.InDelta(t, 0.5, report.ApprovalRate, 0.0001)
+	assert.InDelta(t, 0.5, report.DeclineRate, 0.0001)
+	assert.InDelta(t, 1.0/3.0, report.FraudFlagRate, 0.0001)
+	assert.InDelta(t, 7, report.AverageSettlementDays, 0.0001)
+	assert.InDelta(t, 1, report.SettlementDaysByStage["Policy Validation"], 0.0001)
+	assert.InDelta(t, 6, report.SettlementDaysByStage["Damage Assessment"], 0.0001)
+	assert.Len(t, report.SettlementDaysByStage, 2)
+
+	// Premium is earned pro rata over the part of each term in the period.
+	firstHalf, err := svc.GenerateReport(ctx, &ClaimsAnalyticsRequest{From: yearStart, To: day(7, 1), ProductID: &homeID})
+	require.NoError(t, err)
+	assert.InDelta(t, 20000*182.0/366.0, firstHalf.EarnedPremium, 0.01)
+}
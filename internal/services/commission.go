@@ -2,41 +2,74 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/edsonmichaque/bazaruto/internal/authorization"
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
 	"github.com/edsonmichaque/bazaruto/internal/store"
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 )
 
 // CommissionService handles commission calculation and partner payout logic.
 type CommissionService struct {
-	partnerStore    store.PartnerStore
-	policyStore     store.PolicyStore
-	paymentStore    store.PaymentStore
-	commissionStore interface{} // Generic store interface
+	partnerStore      store.PartnerStore
+	policyStore       store.PolicyStore
+	paymentStore      store.PaymentStore
+	commissionStore   store.CommissionStore
+	authorizer        authorization.Authorizer
+	currencyConverter CurrencyConverter
+	configManager     *config.Manager
+	logger            *logger.Logger
 }
 
 // NewCommissionService creates a new CommissionService instance.
 func NewCommissionService(
+	logger *logger.Logger,
+	configManager *config.Manager,
 	partnerStore store.PartnerStore,
 	policyStore store.PolicyStore,
 	paymentStore store.PaymentStore,
-	commissionStore interface{},
+	commissionStore store.CommissionStore,
+	authorizer authorization.Authorizer,
+	currencyConverter ...CurrencyConverter,
 ) *CommissionService {
+	var converter CurrencyConverter
+	if len(currencyConverter) > 0 {
+		converter = currencyConverter[0]
+	}
 	return &CommissionService{
-		partnerStore:    partnerStore,
-		policyStore:     policyStore,
-		paymentStore:    paymentStore,
-		commissionStore: commissionStore,
+		partnerStore:      partnerStore,
+		policyStore:       policyStore,
+		paymentStore:      paymentStore,
+		commissionStore:   commissionStore,
+		authorizer:        authorizer,
+		currencyConverter: converter,
+		configManager:     configManager,
+		logger:            logger,
 	}
 }
 
+// ErrCommissionRateCapExceeded is returned when a writing partner's rate plus the parent
+// override exceeds the configured maximum combined rate.
+var ErrCommissionRateCapExceeded = errors.New("combined commission rate exceeds cap")
+
+// Commission roles on a policy sale.
+const (
+	CommissionRoleWriting  = "writing"
+	CommissionRoleOverride = "override"
+)
+
 // CommissionCalculation represents the result of a commission calculation.
 type CommissionCalculation struct {
 	PolicyID         uuid.UUID              `json:"policy_id"`
 	PartnerID        uuid.UUID              `json:"partner_id"`
 	CommissionType   string                 `json:"commission_type"`   // initial, renewal, adjustment
+	Role             string                 `json:"role"`              // writing, override
 	BaseAmount       float64                `json:"base_amount"`       // Base premium amount
 	CommissionRate   float64                `json:"commission_rate"`   // Commission rate (percentage)
 	CommissionAmount float64                `json:"commission_amount"` // Calculated commission amount
@@ -62,8 +95,11 @@ type CommissionRule struct {
 	Metadata       map[string]interface{} `json:"metadata"`
 }
 
-// CalculateCommission calculates commission for a policy and partner.
-func (s *CommissionService) CalculateCommission(ctx context.Context, policyID uuid.UUID, partnerID uuid.UUID, commissionType string) (*CommissionCalculation, error) {
+// CalculateCommission calculates the commissions owed on a policy sold by a partner.
+// The first calculation is the writing partner's commission. When the partner is a
+// sub-agent, a second calculation carries the parent partner's override commission.
+// The writing and override rates combined may not exceed the configured cap.
+func (s *CommissionService) CalculateCommission(ctx context.Context, policyID uuid.UUID, partnerID uuid.UUID, commissionType string) ([]*CommissionCalculation, error) {
 	// Fetch policy details
 	policy, err := s.policyStore.GetPolicy(ctx, policyID)
 	if err != nil {
@@ -82,20 +118,6 @@ func (s *CommissionService) CalculateCommission(ctx context.Context, policyID uu
 		return nil, fmt.Errorf("failed to get commission rule: %w", err)
 	}
 
-	// Calculate commission
-	calculation := &CommissionCalculation{
-		PolicyID:        policyID,
-		PartnerID:       partnerID,
-		CommissionType:  commissionType,
-		BaseAmount:      policy.Premium,
-		CommissionRate:  rule.Rate,
-		Currency:        policy.Currency,
-		Status:          "calculated",
-		CalculationDate: time.Now(),
-		DueDate:         s.calculateDueDate(commissionType),
-		Metadata:        make(map[string]interface{}),
-	}
-
 	// Calculate commission amount
 	commissionAmount := policy.Premium * (rule.Rate / 100.0)
 
@@ -107,15 +129,74 @@ func (s *CommissionService) CalculateCommission(ctx context.Context, policyID uu
 		commissionAmount = rule.MaxAmount
 	}
 
-	calculation.CommissionAmount = commissionAmount
-
-	// Store metadata
-	calculation.Metadata["partner_name"] = partner.Name
-	calculation.Metadata["product_id"] = policy.ProductID.String()
+	calculation := s.newCommissionCalculation(policy, partner, commissionType, CommissionRoleWriting, rule.Rate, commissionAmount)
 	calculation.Metadata["rule_id"] = rule.PartnerID.String() // Using partner ID as rule identifier
-	calculation.Metadata["calculation_version"] = "1.0"
+	calculations := []*CommissionCalculation{calculation}
+
+	if partner.HasParent() {
+		override, err := s.calculateOverride(ctx, policy, partner, commissionType, rule.Rate)
+		if err != nil {
+			return nil, err
+		}
+		calculations = append(calculations, override)
+	}
+
+	for _, calc := range calculations {
+		s.logger.Info("Commission calculated",
+			zap.String("policy_id", policyID.String()),
+			zap.String("partner_id", calc.PartnerID.String()),
+			zap.String("commission_type", commissionType),
+			zap.String("role", calc.Role),
+			zap.Float64("commission_amount", calc.CommissionAmount))
+	}
+
+	return calculations, nil
+}
+
+// calculateOverride calculates the parent partner's override commission on a sub-agent's sale.
+func (s *CommissionService) calculateOverride(ctx context.Context, policy *models.Policy, partner *models.Partner, commissionType string, writingRate float64) (*CommissionCalculation, error) {
+	if *partner.ParentPartnerID == partner.ID {
+		return nil, fmt.Errorf("partner %s cannot be its own parent", partner.ID)
+	}
+
+	parent, err := s.partnerStore.GetPartner(ctx, *partner.ParentPartnerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch parent partner: %w", err)
+	}
+
+	cfg := s.configManager.GetConfig().Commission
+	overrideRate := cfg.OverrideRates[commissionType]
+	if cfg.MaxCombinedRate > 0 && writingRate+overrideRate > cfg.MaxCombinedRate {
+		return nil, fmt.Errorf("%w: %.2f%% writing plus %.2f%% override exceeds %.2f%%",
+			ErrCommissionRateCapExceeded, writingRate, overrideRate, cfg.MaxCombinedRate)
+	}
+
+	override := s.newCommissionCalculation(policy, parent, commissionType, CommissionRoleOverride, overrideRate, policy.Premium*(overrideRate/100.0))
+	override.Metadata["sub_agent_id"] = partner.ID.String()
+
+	return override, nil
+}
 
-	return calculation, nil
+// newCommissionCalculation creates a calculated commission for a partner on a policy.
+func (s *CommissionService) newCommissionCalculation(policy *models.Policy, partner *models.Partner, commissionType, role string, rate, amount float64) *CommissionCalculation {
+	return &CommissionCalculation{
+		PolicyID:         policy.ID,
+		PartnerID:        partner.ID,
+		CommissionType:   commissionType,
+		Role:             role,
+		BaseAmount:       policy.Premium,
+		CommissionRate:   rate,
+		CommissionAmount: amount,
+		Currency:         policy.Currency,
+		Status:           "calculated",
+		CalculationDate:  time.Now(),
+		DueDate:          s.calculateDueDate(commissionType),
+		Metadata: map[string]interface{}{
+			"partner_name":        partner.Name,
+			"product_id":          policy.ProductID.String(),
+			"calculation_version": "1.0",
+		},
+	}
 }
 
 // getCommissionRule retrieves the applicable commission rule for a partner and product.
@@ -170,47 +251,124 @@ func (s *CommissionService) calculateDueDate(commissionType string) time.Time {
 	}
 }
 
-// ProcessCommissionPayment processes payment of a commission.
-func (s *CommissionService) ProcessCommissionPayment(ctx context.Context, calculationID uuid.UUID, paymentMethod string) (*CommissionPayment, error) {
-	// Fetch commission calculation
-	// In a real implementation, this would fetch from the commission store
-	calculation := &CommissionCalculation{
-		PolicyID:         uuid.New(),
-		PartnerID:        uuid.New(),
-		Status:           "calculated",
-		CommissionAmount: 1000.0, // Simulated amount
-		Currency:         "USD",
+// RecordPolicyCommissions records the commissions owed on a policy to the partner that
+// offers its product: initial commissions on new business, renewal commissions on a
+// renewal. It is called once the policy's premium is paid and is idempotent, so a
+// policy's later payments do not record the commissions again.
+func (s *CommissionService) RecordPolicyCommissions(ctx context.Context, policyID uuid.UUID) ([]*models.Commission, error) {
+	if s.commissionStore == nil {
+		return nil, fmt.Errorf("commission store is not configured")
+	}
+
+	policy, err := s.policyStore.GetPolicy(ctx, policyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch policy: %w", err)
+	}
+	if policy.Product.PartnerID == uuid.Nil {
+		return nil, nil
+	}
+
+	commissionType := models.CommissionTypeInitial
+	if policy.PreviousPolicyID != nil {
+		commissionType = models.CommissionTypeRenewal
+	}
+
+	existing, err := s.commissionStore.ListCommissionsByPolicy(ctx, policyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commissions: %w", err)
+	}
+	for _, commission := range existing {
+		if commission.CommissionType == commissionType {
+			return nil, nil
+		}
+	}
+
+	calculations, err := s.CalculateCommission(ctx, policyID, policy.Product.PartnerID, commissionType)
+	if err != nil {
+		return nil, err
+	}
+
+	commissions := make([]*models.Commission, 0, len(calculations))
+	for _, calc := range calculations {
+		commission := &models.Commission{
+			PolicyID:       calc.PolicyID,
+			PartnerID:      calc.PartnerID,
+			CommissionType: calc.CommissionType,
+			Role:           calc.Role,
+			BaseAmount:     calc.BaseAmount,
+			Rate:           calc.CommissionRate,
+			Amount:         roundCurrency(calc.CommissionAmount),
+			Currency:       calc.Currency,
+			Status:         models.CommissionStatusCalculated,
+			DueDate:        calc.DueDate,
+			Metadata:       calc.Metadata,
+		}
+		commissions = append(commissions, commission)
+	}
+
+	// The writing commission and any override are stored together, so a retry after a
+	// failure never finds one of them recorded without the other
+	if err := s.commissionStore.CreateCommissions(ctx, commissions); err != nil {
+		return nil, fmt.Errorf("failed to create commissions: %w", err)
+	}
+
+	return commissions, nil
+}
+
+// ProcessCommissionPayment settles a recorded commission with its partner and marks it paid.
+func (s *CommissionService) ProcessCommissionPayment(ctx context.Context, commissionID uuid.UUID, paymentMethod string) (*CommissionPayment, error) {
+	if s.authorizer != nil {
+		if err := s.authorizer.Authorize(ctx, authorization.PermissionCommissionPay); err != nil {
+			return nil, err
+		}
+	}
+	if s.commissionStore == nil {
+		return nil, fmt.Errorf("commission store is not configured")
+	}
+	if paymentMethod == "" {
+		return nil, fmt.Errorf("payment method is required")
+	}
+
+	commission, err := s.commissionStore.GetCommission(ctx, commissionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch commission: %w", err)
 	}
 
 	// Validate commission can be paid
-	if calculation.Status != "calculated" {
-		return nil, fmt.Errorf("commission is not in calculated status")
+	if commission.Status != models.CommissionStatusCalculated && commission.Status != models.CommissionStatusPending {
+		return nil, fmt.Errorf("commission %s is %s and cannot be paid", commissionID, commission.Status)
 	}
 
-	// Create commission payment record
+	now := time.Now()
 	payment := &CommissionPayment{
-		CommissionID:  calculationID,
-		PartnerID:     calculation.PartnerID,
-		Amount:        calculation.CommissionAmount,
-		Currency:      calculation.Currency,
+		ID:            uuid.New(),
+		CommissionID:  commission.ID,
+		PartnerID:     commission.PartnerID,
+		Amount:        commission.Amount,
+		Currency:      commission.Currency,
 		PaymentMethod: paymentMethod,
-		Status:        "pending",
-		PaymentDate:   time.Now(),
+		Status:        "completed",
+		PaymentDate:   now,
 		Metadata:      make(map[string]interface{}),
 	}
+	payment.TransactionID = fmt.Sprintf("comm_%d_%s", now.Unix(), commission.ID.String()[:8])
 
-	// Process payment (simplified - in real implementation, this would integrate with payment gateway)
-	payment.Status = "completed"
-	payment.TransactionID = fmt.Sprintf("comm_%d_%s", time.Now().Unix(), calculationID.String()[:8])
-
-	// Update commission status
-	calculation.Status = "paid"
-	now := time.Now()
-	calculation.PaymentDate = &now
+	commission.Status = models.CommissionStatusPaid
+	commission.PaidAt = &now
+	if commission.Metadata == nil {
+		commission.Metadata = make(map[string]interface{})
+	}
+	commission.Metadata["payment_method"] = paymentMethod
+	commission.Metadata["transaction_id"] = payment.TransactionID
+	if err := s.commissionStore.UpdateCommission(ctx, commission); err != nil {
+		return nil, fmt.Errorf("failed to update commission: %w", err)
+	}
 
-	// Store payment and update commission
-	// In a real implementation, this would store in the commission store
-	// For now, we'll simulate successful storage
+	s.logger.Info("Commission paid",
+		zap.String("commission_id", commission.ID.String()),
+		zap.String("partner_id", payment.PartnerID.String()),
+		zap.Float64("amount", payment.Amount),
+		zap.String("transaction_id", payment.TransactionID))
 
 	return payment, nil
 }
@@ -244,26 +402,52 @@ func (s *CommissionService) GetPendingCommissions(ctx context.Context) ([]Commis
 }
 
 // CalculatePartnerEarnings calculates total earnings for a partner in a given period.
+// Commissions paid within the period are grouped by commission type and converted to
+// the partner's settlement currency. A partner with no paid commissions earns zero.
 func (s *CommissionService) CalculatePartnerEarnings(ctx context.Context, partnerID uuid.UUID, startDate, endDate time.Time) (*PartnerEarnings, error) {
-	// In a real implementation, this would calculate actual earnings from commission data
-	// For now, we'll return a simulated result
+	if s.commissionStore == nil {
+		return nil, fmt.Errorf("commission store is not configured")
+	}
+
+	partner, err := s.partnerStore.GetPartner(ctx, partnerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch partner: %w", err)
+	}
+
+	currency := partner.SettlementCurrency
+	if currency == "" {
+		currency = "USD"
+	}
 
 	earnings := &PartnerEarnings{
-		PartnerID:     partnerID,
-		StartDate:     startDate,
-		EndDate:       endDate,
-		TotalEarnings: 0.0,
-		Currency:      "USD",
-		Breakdown:     make(map[string]float64),
-		Metadata:      make(map[string]interface{}),
+		PartnerID: partnerID,
+		StartDate: startDate,
+		EndDate:   endDate,
+		Currency:  currency,
+		Breakdown: make(map[string]float64),
+		Metadata:  make(map[string]interface{}),
 	}
 
-	// Simulate earnings breakdown by commission type
-	earnings.Breakdown["initial"] = 2500.0
-	earnings.Breakdown["renewal"] = 1200.0
-	earnings.Breakdown["adjustment"] = 300.0
-	earnings.TotalEarnings = 4000.0
+	commissions, err := s.commissionStore.ListPaidCommissionsByPartner(ctx, partnerID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list paid commissions: %w", err)
+	}
 
+	for _, commission := range commissions {
+		amount, err := convertAmount(ctx, s.currencyConverter, commission.Amount, commission.Currency, currency)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert commission %s: %w", commission.ID, err)
+		}
+		earnings.Breakdown[commission.CommissionType] += amount
+	}
+
+	for commissionType, amount := range earnings.Breakdown {
+		earnings.Breakdown[commissionType] = roundCurrency(amount)
+		earnings.TotalEarnings += amount
+	}
+	earnings.TotalEarnings = roundCurrency(earnings.TotalEarnings)
+
+	earnings.Metadata["commission_count"] = len(commissions)
 	earnings.Metadata["calculation_date"] = time.Now()
 	earnings.Metadata["calculation_version"] = "1.0"
 
@@ -346,13 +530,32 @@ func (s *CommissionService) GetCommissionRules(ctx context.Context, partnerID uu
 }
 
 // ProcessBulkCommissionPayments processes multiple commission payments in batch.
+// If the context is cancelled mid-batch, the payments completed so far are returned
+// together with the context error.
 func (s *CommissionService) ProcessBulkCommissionPayments(ctx context.Context, paymentRequests []CommissionPaymentRequest) ([]CommissionPayment, error) {
+	if s.authorizer != nil {
+		if err := s.authorizer.Authorize(ctx, authorization.PermissionCommissionPay); err != nil {
+			return nil, err
+		}
+	}
+
 	payments := []CommissionPayment{}
 
 	for _, request := range paymentRequests {
+		if err := ctx.Err(); err != nil {
+			s.logger.Warn("Bulk commission payments interrupted",
+				zap.Int("processed", len(payments)),
+				zap.Int("requested", len(paymentRequests)),
+				zap.Error(err))
+			return payments, err
+		}
+
 		payment, err := s.ProcessCommissionPayment(ctx, request.CommissionID, request.PaymentMethod)
 		if err != nil {
 			// Log error but continue processing other payments
+			s.logger.Error("Failed to process commission payment",
+				zap.String("commission_id", request.CommissionID.String()),
+				zap.Error(err))
 			continue
 		}
 		payments = append(payments, *payment)
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// ClawbackCommission reverses the paid initial commissions on a cancelled policy.
+// The share clawed back depends on how long the policy was in force, following the
+// configured clawback schedule; a policy cancelled after the last tier owes nothing.
+// Each clawback is recorded as a negative commission against the same partner, and a
+// commission is never clawed back twice.
+func (s *CommissionService) ClawbackCommission(ctx context.Context, policyID uuid.UUID) ([]*models.Commission, error) {
+	if s.commissionStore == nil {
+		return nil, fmt.Errorf("commission store is not configured")
+	}
+
+	policy, err := s.policyStore.GetPolicy(ctx, policyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch policy: %w", err)
+	}
+
+	daysActive := int(time.Since(policy.EffectiveDate).Hours() / 24)
+	if daysActive < 0 {
+		daysActive = 0
+	}
+
+	rate := s.clawbackRate(daysActive)
+	if rate <= 0 {
+		return nil, nil
+	}
+
+	commissions, err := s.commissionStore.ListCommissionsByPolicy(ctx, policyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commissions: %w", err)
+	}
+
+	var clawbacks []*models.Commission
+	for _, commission := range commissions {
+		if commission.CommissionType != models.CommissionTypeInitial || !commission.IsPaid() || commission.ClawedBackAmount > 0 {
+			continue
+		}
+
+		amount := roundCurrency(commission.Amount * rate)
+		clawback := &models.Commission{
+			PolicyID:       commission.PolicyID,
+			PartnerID:      commission.PartnerID,
+			CommissionType: models.CommissionTypeClawback,
+			Role:           commission.Role,
+			BaseAmount:     commission.Amount,
+			Rate:           rate * 100,
+			Amount:         -amount,
+			Currency:       commission.Currency,
+			Status:         models.CommissionStatusCalculated,
+			DueDate:        s.calculateDueDate(models.CommissionTypeClawback),
+			ReversalOf:     &commission.ID,
+			Metadata: map[string]interface{}{
+				"days_active": daysActive,
+			},
+		}
+		if err := s.commissionStore.CreateCommission(ctx, clawback); err != nil {
+			return clawbacks, fmt.Errorf("failed to create clawback: %w", err)
+		}
+
+		commission.ClawedBackAmount = amount
+		if err := s.commissionStore.UpdateCommission(ctx, commission); err != nil {
+			return clawbacks, fmt.Errorf("failed to update commission: %w", err)
+		}
+
+		clawbacks = append(clawbacks, clawback)
+
+		s.logger.Info("Commission clawed back",
+			zap.String("policy_id", policyID.String()),
+			zap.String("commission_id", commission.ID.String()),
+			zap.String("partner_id", commission.PartnerID.String()),
+			zap.Int("days_active", daysActive),
+			zap.Float64("amount", amount))
+	}
+
+	return clawbacks, nil
+}
+
+// clawbackRate returns the share of commission clawed back for a policy cancelled after
+// daysActive days, taken from the narrowest schedule tier that covers it.
+func (s *CommissionService) clawbackRate(daysActive int) float64 {
+	rate := 0.0
+	withinDays := -1
+	for _, tier := range s.configManager.GetConfig().Commission.ClawbackSchedule {
+		if daysActive > tier.WithinDays {
+			continue
+		}
+		if withinDays == -1 || tier.WithinDays < withinDays {
+			withinDays = tier.WithinDays
+			rate = tier.Rate
+		}
+	}
+	return rate
+}
@@ -0,0 +1,274 @@
+package services
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/authorization"
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessCommissionPaymentRequiresFinanceRole(t *testing.T) {
+	commission := &models.Commission{PartnerID: uuid.New(), Amount: 150, Currency: "USD", Status: models.CommissionStatusCalculated}
+	commissionStore := &fakeCommissionStore{}
+	require.NoError(t, commissionStore.CreateCommission(context.Background(), commission))
+
+	authorizer := authorization.NewRBACAuthorizer(authorization.NewRBACService())
+	svc := NewCommissionService(logger.NewLogger("error", "json"), nil, nil, nil, nil, commissionStore, authorizer)
+
+	claimsCtx := authorization.WithActor(context.Background(), &authorization.Actor{ID: uuid.New(), Role: authorization.RoleClaimsSupervisor})
+	_, err := svc.ProcessCommissionPayment(claimsCtx, commission.ID, "bank_transfer")
+	assert.ErrorIs(t, err, authorization.ErrUnauthorized)
+
+	financeCtx := authorization.WithActor(context.Background(), &authorization.Actor{ID: uuid.New(), Role: authorization.RoleFinance})
+	payment, err := svc.ProcessCommissionPayment(financeCtx, commission.ID, "bank_transfer")
+	require.NoError(t, err)
+	assert.Equal(t, "completed", payment.Status)
+	assert.Equal(t, commission.PartnerID, payment.PartnerID)
+	assert.InDelta(t, 150.0, payment.Amount, 0.001)
+	assert.Equal(t, models.CommissionStatusPaid, commission.Status)
+	assert.NotNil(t, commission.PaidAt)
+
+	_, err = svc.ProcessCommissionPayment(financeCtx, commission.ID, "bank_transfer")
+	assert.Error(t, err, "a paid commission is not paid twice")
+}
+
+func TestRecordPolicyCommissionsOnceForTheSellingPartner(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	partner := &models.Partner{Base: models.Base{ID: uuid.New()}, Name: "Agency"}
+	policy := &models.Policy{
+		Base:      models.Base{ID: uuid.New()},
+		ProductID: uuid.New(),
+		Product:   models.Product{PartnerID: partner.ID},
+		Premium:   1000,
+		Currency:  "USD",
+	}
+	previousID := uuid.New()
+	renewal := &models.Policy{
+		Base:             models.Base{ID: uuid.New()},
+		ProductID:        policy.ProductID,
+		Product:          policy.Product,
+		PreviousPolicyID: &previousID,
+		Premium:          1000,
+		Currency:         "USD",
+	}
+	commissionStore := &fakeCommissionStore{}
+	svc := NewCommissionService(log, config.NewManager(log, ""), newFakePartnerStore(partner), newFakePolicyStore(policy, renewal), nil, commissionStore, nil)
+
+	commissions, err := svc.RecordPolicyCommissions(ctx, policy.ID)
+	require.NoError(t, err)
+	require.Len(t, commissions, 1)
+	assert.Equal(t, partner.ID, commissions[0].PartnerID)
+	assert.Equal(t, models.CommissionTypeInitial, commissions[0].CommissionType)
+	assert.InDelta(t, 150.0, commissions[0].Amount, 0.001)
+
+	// A later instalment does not record the commission again.
+	again, err := svc.RecordPolicyCommissions(ctx, policy.ID)
+	require.NoError(t, err)
+	assert.Empty(t, again)
+
+	renewed, err := svc.RecordPolicyCommissions(ctx, renewal.ID)
+	require.NoError(t, err)
+	require.Len(t, renewed, 1)
+	assert.Equal(t, models.CommissionTypeRenewal, renewed[0].CommissionType)
+	assert.Len(t, commissionStore.commissions, 2)
+}
+
+// cancellingAuthorizer allows every request and cancels a context after a number of checks.
+type cancellingAuthorizer struct {
+	cancel      context.CancelFunc
+	cancelAfter int
+	calls       int
+}
+
+func (a *cancellingAuthorizer) Authorize(ctx context.Context, permission authorization.Permission) error {
+	a.calls++
+	if a.calls == a.cancelAfter {
+		a.cancel()
+	}
+	return nil
+}
+
+func TestProcessBulkCommissionPaymentsStopsWhenContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The bulk call authorizes once, then once per payment: cancel during the second payment.
+	authorizer := &cancellingAuthorizer{cancel: cancel, cancelAfter: 3}
+	commissionStore := &fakeCommissionStore{}
+	svc := NewCommissionService(logger.NewLogger("error", "json"), nil, nil, nil, nil, commissionStore, authorizer)
+
+	requests := make([]CommissionPaymentRequest, 5)
+	for i := range requests {
+		commission := &models.Commission{PartnerID: uuid.New(), Amount: 100, Currency: "USD", Status: models.CommissionStatusCalculated}
+		require.NoError(t, commissionStore.CreateCommission(ctx, commission))
+		requests[i] = CommissionPaymentRequest{CommissionID: commission.ID, PaymentMethod: "bank_transfer"}
+	}
+
+	payments, err := svc.ProcessBulkCommissionPayments(ctx, requests)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Len(t, payments, 2)
+	assert.Equal(t, 3, authorizer.calls)
+}
+
+func TestCalculateCommissionAddsParentOverrideForSubAgent(t *testing.T) {
+	log := logger.NewLogger("error", "json")
+	parent := &models.Partner{Base: models.Base{ID: uuid.New()}, Name: "Agency"}
+	subAgent := &models.Partner{Base: models.Base{ID: uuid.New()}, Name: "Sub-agent", ParentPartnerID: &parent.ID}
+	policy := &models.Policy{Base: models.Base{ID: uuid.New()}, ProductID: uuid.New(), Premium: 1000, Currency: "USD"}
+
+	svc := NewCommissionService(log, config.NewManager(log, ""), newFakePartnerStore(parent, subAgent), newFakePolicyStore(policy), nil, nil, nil)
+
+	calculations, err := svc.CalculateCommission(context.Background(), policy.ID, subAgent.ID, "initial")
+	require.NoError(t, err)
+	require.Len(t, calculations, 2)
+
+	writing, override := calculations[0], calculations[1]
+	assert.Equal(t, subAgent.ID, writing.PartnerID)
+	assert.Equal(t, CommissionRoleWriting, writing.Role)
+	assert.InDelta(t, 150.0, writing.CommissionAmount, 0.001)
+
+	assert.Equal(t, parent.ID, override.PartnerID)
+	assert.Equal(t, CommissionRoleOverride, override.Role)
+	assert.InDelta(t, 3.0, override.CommissionRate, 0.001)
+	assert.InDelta(t, 30.0, override.CommissionAmount, 0.001)
+	assert.Equal(t, subAgent.ID.String(), override.Metadata["sub_agent_id"])
+
+	direct, err := svc.CalculateCommission(context.Background(), policy.ID, parent.ID, "initial")
+	require.NoError(t, err)
+	assert.Len(t, direct, 1)
+}
+
+func TestCalculateCommissionRejectsCombinedRateAboveCap(t *testing.T) {
+	log := logger.NewLogger("error", "json")
+	manager := config.NewManager(log, filepath.Join(t.TempDir(), "business_rules.json"))
+	cfg := manager.GetConfig()
+	cfg.Commission.MaxCombinedRate = 16
+	require.NoError(t, manager.UpdateConfig(context.Background(), cfg))
+
+	parent := &models.Partner{Base: models.Base{ID: uuid.New()}, Name: "Agency"}
+	subAgent := &models.Partner{Base: models.Base{ID: uuid.New()}, Name: "Sub-agent", ParentPartnerID: &parent.ID}
+	policy := &models.Policy{Base: models.Base{ID: uuid.New()}, ProductID: uuid.New(), Premium: 1000, Currency: "USD"}
+
+	svc := NewCommissionService(log, manager, newFakePartnerStore(parent, subAgent), newFakePolicyStore(policy), nil, nil, nil)
+
+	_, err := svc.CalculateCommission(context.Background(), policy.ID, subAgent.ID, "initial")
+	assert.ErrorIs(t, err, ErrCommissionRateCapExceeded)
+}
+
+func TestCancelPolicyClawsBackCommissionBySchedule(t *testing.T) {
+	tests := []struct {
+		name         string
+		daysActive   int
+		wantClawback bool
+		wantAmount   float64
+	}{
+		{name: "within 30 days", daysActive: 20, wantClawback: true, wantAmount: 150},
+		{name: "within 6 months", daysActive: 90, wantClawback: true, wantAmount: 75},
+		{name: "after schedule", daysActive: 200},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			log := logger.NewLogger("error", "json")
+			configManager := config.NewManager(log, "")
+			now := time.Now()
+
+			policy := &models.Policy{
+				Base:           models.Base{ID: uuid.New()},
+				UserID:         uuid.New(),
+				Status:         models.PolicyStatusActive,
+				Premium:        1000,
+				Currency:       "USD",
+				EffectiveDate:  now.AddDate(0, 0, -tt.daysActive),
+				ExpirationDate: now.AddDate(0, 0, 365-tt.daysActive),
+			}
+			policyStore := newFakePolicyStore(policy)
+			paidAt := policy.EffectiveDate
+			paid := &models.Commission{
+				PolicyID:       policy.ID,
+				PartnerID:      uuid.New(),
+				CommissionType: models.CommissionTypeInitial,
+				Amount:         150,
+				Currency:       "USD",
+				Status:         models.CommissionStatusPaid,
+				PaidAt:         &paidAt,
+			}
+			commissionStore := &fakeCommissionStore{}
+			require.NoError(t, commissionStore.CreateCommission(ctx, paid))
+
+			commissions := NewCommissionService(log, configManager, nil, policyStore, nil, commissionStore, nil)
+			svc := NewPolicyLifecycleService(log, configManager, policyStore, nil, nil, nil, nil, nil, nil, nil, commissions, nil, nil)
+
+			result, err := svc.CancelPolicy(ctx, policy.ID, nil)
+			require.NoError(t, err)
+			assert.True(t, result.Success)
+
+			if !tt.wantClawback {
+				assert.Len(t, commissionStore.commissions, 1)
+				assert.NotContains(t, result.Metadata, "commission_clawback_amount")
+				return
+			}
+
+			require.Len(t, commissionStore.commissions, 2)
+			clawback := commissionStore.commissions[1]
+			assert.Equal(t, models.CommissionTypeClawback, clawback.CommissionType)
+			assert.Equal(t, paid.PartnerID, clawback.PartnerID)
+			assert.Equal(t, &paid.ID, clawback.ReversalOf)
+			assert.InDelta(t, -tt.wantAmount, clawback.Amount, 0.001)
+			assert.InDelta(t, tt.wantAmount, paid.ClawedBackAmount, 0.001)
+			assert.InDelta(t, tt.wantAmount, result.Metadata["commission_clawback_amount"], 0.001)
+
+			again, err := commissions.ClawbackCommission(ctx, policy.ID)
+			require.NoError(t, err)
+			assert.Empty(t, again)
+		})
+	}
+}
+
+func TestCalculatePartnerEarningsConvertsToSettlementCurrency(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	configManager := config.NewManager(log, "")
+	now := time.Now()
+
+	partner := &models.Partner{Base: models.Base{ID: uuid.New()}, Name: "Agency", SettlementCurrency: "EUR"}
+	paidAt := now.AddDate(0, 0, -10)
+	outOfRange := now.AddDate(0, -3, 0)
+
+	commissionStore := &fakeCommissionStore{}
+	for _, commission := range []*models.Commission{
+		{PartnerID: partner.ID, CommissionType: models.CommissionTypeInitial, Amount: 100, Currency: "USD", Status: models.CommissionStatusPaid, PaidAt: &paidAt},
+		{PartnerID: partner.ID, CommissionType: models.CommissionTypeInitial, Amount: 46, Currency: "EUR", Status: models.CommissionStatusPaid, PaidAt: &paidAt},
+		{PartnerID: partner.ID, CommissionType: models.CommissionTypeRenewal, Amount: 50, Currency: "USD", Status: models.CommissionStatusPaid, PaidAt: &paidAt},
+		{PartnerID: partner.ID, CommissionType: models.CommissionTypeRenewal, Amount: 500, Currency: "USD", Status: models.CommissionStatusCalculated},
+		{PartnerID: partner.ID, CommissionType: models.CommissionTypeInitial, Amount: 500, Currency: "USD", Status: models.CommissionStatusPaid, PaidAt: &outOfRange},
+		{PartnerID: uuid.New(), CommissionType: models.CommissionTypeInitial, Amount: 500, Currency: "USD", Status: models.CommissionStatusPaid, PaidAt: &paidAt},
+	} {
+		require.NoError(t, commissionStore.CreateCommission(ctx, commission))
+	}
+
+	svc := NewCommissionService(log, configManager, newFakePartnerStore(partner), nil, nil, commissionStore, nil, NewCurrencyConverter(configManager))
+
+	earnings, err := svc.CalculatePartnerEarnings(ctx, partner.ID, now.AddDate(0, -1, 0), now)
+	require.NoError(t, err)
+	assert.Equal(t, "EUR", earnings.Currency)
+	assert.InDelta(t, 138.0, earnings.Breakdown[models.CommissionTypeInitial], 0.001) // 92 + 46
+	assert.InDelta(t, 46.0, earnings.Breakdown[models.CommissionTypeRenewal], 0.001)
+	assert.InDelta(t, 184.0, earnings.TotalEarnings, 0.001)
+	assert.Equal(t, 3, earnings.Metadata["commission_count"])
+
+	empty, err := svc.CalculatePartnerEarnings(ctx, partner.ID, now.AddDate(-2, 0, 0), now.AddDate(-1, 0, 0))
+	require.NoError(t, err)
+	assert.Zero(t, empty.TotalEarnings)
+	assert.Empty(t, empty.Breakdown)
+}
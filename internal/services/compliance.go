@@ -5,31 +5,43 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/logger"
 	"github.com/edsonmichaque/bazaruto/internal/models"
 	"github.com/edsonmichaque/bazaruto/internal/store"
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 )
 
 // ComplianceService handles compliance and regulatory validation for insurance operations.
 type ComplianceService struct {
-	userStore    store.UserStore
-	policyStore  store.PolicyStore
-	claimStore   store.ClaimStore
-	paymentStore store.PaymentStore
+	configManager *config.Manager
+	userStore     store.UserStore
+	policyStore   store.PolicyStore
+	claimStore    store.ClaimStore
+	paymentStore  store.PaymentStore
+	currencies    CurrencyConverter
+	sanctions     *sanctionsScreening
+	sars          *SARService
+	logger        *logger.Logger
 }
 
 // NewComplianceService creates a new ComplianceService instance.
 func NewComplianceService(
+	logger *logger.Logger,
+	configManager *config.Manager,
 	userStore store.UserStore,
 	policyStore store.PolicyStore,
 	claimStore store.ClaimStore,
 	paymentStore store.PaymentStore,
 ) *ComplianceService {
 	return &ComplianceService{
-		userStore:    userStore,
-		policyStore:  policyStore,
-		claimStore:   claimStore,
-		paymentStore: paymentStore,
+		configManager: configManager,
+		userStore:     userStore,
+		policyStore:   policyStore,
+		claimStore:    claimStore,
+		paymentStore:  paymentStore,
+		logger:        logger,
 	}
 }
 
@@ -99,10 +111,24 @@ func (s *ComplianceService) ValidateUserCompliance(ctx context.Context, userID u
 	violations = append(violations, kycViolations...)
 	recommendations = append(recommendations, kycRecommendations...)
 
-	// AML (Anti-Money Laundering) checks
-	amlViolations, amlRecommendations := s.performAMLChecks(user)
+	// AML (Anti-Money Laundering) checks, including sanctions and PEP screening. The check is
+	// only valid while the screening result is.
+	amlViolations, amlRecommendations, screeningExpiresAt := s.performAMLChecks(ctx, user)
 	violations = append(violations, amlViolations...)
 	recommendations = append(recommendations, amlRecommendations...)
+	if !screeningExpiresAt.IsZero() && screeningExpiresAt.Before(check.ValidUntil) {
+		check.ValidUntil = screeningExpiresAt
+	}
+
+	// Transaction monitoring against the AML thresholds
+	monitoringViolations, err := s.performTransactionMonitoring(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	violations = append(violations, monitoringViolations...)
+	if len(monitoringViolations) > 0 {
+		recommendations = append(recommendations, "Review the user's recent payment activity")
+	}
 
 	// Data protection checks
 	dataViolations, dataRecommendations := s.performDataProtectionChecks(user)
@@ -122,9 +148,44 @@ func (s *ComplianceService) ValidateUserCompliance(ctx context.Context, userID u
 	check.Metadata["user_status"] = user.Status
 	check.Metadata["check_version"] = "1.0"
 
+	s.reportSuspiciousActivity(ctx, user, check)
+
+	s.logComplianceCheck(check)
 	return check, nil
 }
 
+// SetCurrencyConverter sets the converter used to total payments made in different
+// currencies in the base currency for AML transaction monitoring.
+func (s *ComplianceService) SetCurrencyConverter(converter CurrencyConverter) {
+	s.currencies = converter
+}
+
+// SetSARService sets the service that drafts suspicious activity reports for users whose
+// compliance checks find critical AML violations.
+func (s *ComplianceService) SetSARService(sars *SARService) {
+	s.sars = sars
+}
+
+// reportSuspiciousActivity drafts a suspicious activity report when the check found critical
+// AML violations and records its ID in the check metadata. Failures are logged and do not fail
+// the check.
+func (s *ComplianceService) reportSuspiciousActivity(ctx context.Context, user *models.User, check *ComplianceCheck) {
+	if s.sars == nil {
+		return
+	}
+
+	report, err := s.sars.CreateFromComplianceCheck(ctx, user, check)
+	if err != nil {
+		s.logger.Error("Failed to draft suspicious activity report",
+			zap.String("user_id", user.ID.String()),
+			zap.Error(err))
+		return
+	}
+	if report != nil {
+		check.Metadata["sar_id"] = report.ID.String()
+	}
+}
+
 // performKYCChecks performs Know Your Customer compliance checks.
 func (s *ComplianceService) performKYCChecks(user *models.User) ([]ComplianceViolation, []string) {
 	violations := []ComplianceViolation{}
@@ -173,11 +234,20 @@ func (s *ComplianceService) performKYCChecks(user *models.User) ([]ComplianceVio
 	return violations, recommendations
 }
 
-// performAMLChecks performs Anti-Money Laundering compliance checks.
-func (s *ComplianceService) performAMLChecks(user *models.User) ([]ComplianceViolation, []string) {
+// performAMLChecks performs Anti-Money Laundering compliance checks, screening the user
+// against sanctions and PEP lists. It also returns when the screening result expires, which
+// is zero when the user was not screened.
+func (s *ComplianceService) performAMLChecks(ctx context.Context, user *models.User) ([]ComplianceViolation, []string, time.Time) {
 	violations := []ComplianceViolation{}
 	recommendations := []string{}
 
+	// Sanctions and PEP screening
+	sanctionsViolations, screeningExpiresAt := s.performSanctionsScreening(ctx, user)
+	violations = append(violations, sanctionsViolations...)
+	if len(sanctionsViolations) > 0 {
+		recommendations = append(recommendations, "Resolve sanctions screening results before proceeding")
+	}
+
 	// Check for suspicious patterns (simplified)
 	accountAge := time.Since(user.CreatedAt).Hours() / 24 / 365 // years
 
@@ -210,7 +280,7 @@ func (s *ComplianceService) performAMLChecks(user *models.User) ([]ComplianceVio
 		recommendations = append(recommendations, "Consider filing suspicious activity report if warranted")
 	}
 
-	return violations, recommendations
+	return violations, recommendations, screeningExpiresAt
 }
 
 // performDataProtectionChecks performs data protection compliance checks.
@@ -370,6 +440,7 @@ func (s *ComplianceService) ValidatePolicyCompliance(ctx context.Context, policy
 	check.Metadata["premium"] = policy.Premium
 	check.Metadata["check_version"] = "1.0"
 
+	s.logComplianceCheck(check)
 	return check, nil
 }
 
@@ -441,9 +512,26 @@ func (s *ComplianceService) ValidateClaimCompliance(ctx context.Context, claimID
 	check.Metadata["incident_date"] = claim.IncidentDate
 	check.Metadata["check_version"] = "1.0"
 
+	s.logComplianceCheck(check)
 	return check, nil
 }
 
+// logComplianceCheck logs the outcome of a compliance check, warning when violations were found.
+func (s *ComplianceService) logComplianceCheck(check *ComplianceCheck) {
+	fields := []zap.Field{
+		zap.String("entity_type", check.EntityType),
+		zap.String("entity_id", check.EntityID.String()),
+		zap.String("status", check.Status),
+		zap.Float64("score", check.Score),
+		zap.Int("violations", len(check.Violations)),
+	}
+	if len(check.Violations) > 0 {
+		s.logger.Warn("Compliance check found violations", fields...)
+		return
+	}
+	s.logger.Info("Compliance check passed", fields...)
+}
+
 // GetComplianceRules retrieves active compliance rules for a jurisdiction.
 func (s *ComplianceService) GetComplianceRules(ctx context.Context, jurisdiction string) ([]ComplianceRule, error) {
 	// In a real implementation, this would query compliance rules from the database
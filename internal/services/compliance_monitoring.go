@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"go.uber.org/zap"
+)
+
+// transactionMonitoringPageSize is the number of payments fetched per store call when
+// aggregating a user's payment activity.
+const transactionMonitoringPageSize = 200
+
+// performTransactionMonitoring totals a user's completed payments over each AML review period
+// in the base currency and compares the totals to the configured thresholds. A total at or above a reporting
+// threshold is a critical violation that warrants a suspicious activity report; one at or
+// above a monitoring threshold requires enhanced monitoring. Each period reports at most one
+// violation, for the higher threshold crossed.
+func (s *ComplianceService) performTransactionMonitoring(ctx context.Context, user *models.User) ([]ComplianceViolation, error) {
+	if s.configManager == nil || s.paymentStore == nil {
+		return nil, nil
+	}
+
+	requirements := s.configManager.GetConfig().Compliance.AMLRequirements
+	if len(requirements.ReviewPeriods) == 0 {
+		return nil, nil
+	}
+
+	longest := 0
+	for _, days := range requirements.ReviewPeriods {
+		if days > longest {
+			longest = days
+		}
+	}
+	if longest == 0 {
+		return nil, nil
+	}
+
+	now := time.Now()
+	payments, err := s.completedPaymentsSince(ctx, user, now.AddDate(0, 0, -longest))
+	if err != nil {
+		return nil, err
+	}
+
+	baseCurrency := s.configManager.GetConfig().Currency.BaseCurrency
+	var violations []ComplianceViolation
+	for i, days := range requirements.ReviewPeriods {
+		if days <= 0 {
+			continue
+		}
+		since := now.AddDate(0, 0, -days)
+
+		byCurrency := make(map[string]float64)
+		count := 0
+		for _, payment := range payments {
+			if !paymentDate(payment).Before(since) {
+				byCurrency[payment.Currency] += payment.Amount
+				count++
+			}
+		}
+
+		total := 0.0
+		for currency, amount := range byCurrency {
+			converted, err := convertAmount(ctx, s.currencies, amount, currency, baseCurrency)
+			if err != nil {
+				return nil, fmt.Errorf("failed to total payments in %s: %w", currency, err)
+			}
+			total += converted
+		}
+
+		metadata := map[string]interface{}{
+			"review_period_days": days,
+			"payment_total":      total,
+			"payment_count":      count,
+			"currency":           baseCurrency,
+		}
+
+		if threshold, ok := amlThreshold(requirements.ReportingThresholds, i); ok && total >= threshold {
+			metadata["threshold"] = threshold
+			metadata["sar_required"] = true
+			violations = append(violations, ComplianceViolation{
+				Code:        "AML_006",
+				Severity:    "critical",
+				Description: fmt.Sprintf("Payments of %.2f in the last %d days exceed the reporting threshold of %.2f", total, days, threshold),
+				Rule:        "Suspicious Activity Reporting",
+				Remediation: "File a suspicious activity report",
+				Metadata:    metadata,
+			})
+			continue
+		}
+
+		if threshold, ok := amlThreshold(requirements.MonitoringThresholds, i); ok && total >= threshold {
+			metadata["threshold"] = threshold
+			violations = append(violations, ComplianceViolation{
+				Code:        "AML_005",
+				Severity:    "medium",
+				Description: fmt.Sprintf("Payments of %.2f in the last %d days exceed the monitoring threshold of %.2f", total, days, threshold),
+				Rule:        "Transaction Monitoring",
+				Remediation: "Place the user under enhanced transaction monitoring",
+				Metadata:    metadata,
+			})
+		}
+	}
+
+	if len(violations) > 0 {
+		s.logger.Warn("AML transaction thresholds exceeded",
+			zap.String("user_id", user.ID.String()),
+			zap.Int("violations", len(violations)))
+	}
+
+	return violations, nil
+}
+
+// completedPaymentsSince returns a user's completed payments made at or after since.
+func (s *ComplianceService) completedPaymentsSince(ctx context.Context, user *models.User, since time.Time) ([]*models.Payment, error) {
+	var payments []*models.Payment
+	for offset := 0; ; offset += transactionMonitoringPageSize {
+		page, err := s.paymentStore.ListCompletedPaymentsSince(ctx, user.ID, since, transactionMonitoringPageSize, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list payments: %w", err)
+		}
+		payments = append(payments, page...)
+		if len(page) < transactionMonitoringPageSize {
+			return payments, nil
+		}
+	}
+}
+
+// paymentDate returns when a payment was processed, or when it was created if it has no
+// processing date.
+func paymentDate(payment *models.Payment) time.Time {
+	if payment.ProcessedAt != nil {
+		return *payment.ProcessedAt
+	}
+	return payment.CreatedAt
+}
+
+// amlThreshold returns the threshold for the review period at index i. Periods beyond the
+// configured thresholds use the last one.
+func amlThreshold(thresholds []float64, i int) (float64, bool) {
+	if len(thresholds) == 0 {
+		return 0, false
+	}
+	if i >= len(thresholds) {
+		i = len(thresholds) - 1
+	}
+	if thresholds[i] <= 0 {
+		return 0, false
+	}
+	return thresholds[i], true
+}
@@ -0,0 +1,255 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// DefaultSanctionsScreeningTTL is how long a screening result is reused before the user is
+// screened again.
+const DefaultSanctionsScreeningTTL = 24 * time.Hour
+
+// sanctionsRescreenPageSize is the number of users fetched per store call when re-screening.
+const sanctionsRescreenPageSize = 200
+
+// ScreeningSubject identifies a person to screen against sanctions and PEP lists.
+type ScreeningSubject struct {
+	Name        string     `json:"name"`
+	DateOfBirth *time.Time `json:"date_of_birth,omitempty"`
+	Country     string     `json:"country,omitempty"`
+}
+
+// ScreeningResult is the outcome of screening a subject.
+type ScreeningResult struct {
+	Match       bool      `json:"match"`
+	Lists       []string  `json:"lists,omitempty"`        // Lists the subject matched, e.g. OFAC SDN or PEP
+	MatchedName string    `json:"matched_name,omitempty"` // Listed name that matched the subject
+	Score       float64   `json:"score"`                  // Match confidence (0-1)
+	ScreenedAt  time.Time `json:"screened_at"`
+}
+
+// SanctionsScreener screens people against sanctions lists, such as OFAC, and politically
+// exposed person (PEP) lists.
+type SanctionsScreener interface {
+	Screen(ctx context.Context, subject *ScreeningSubject) (*ScreeningResult, error)
+}
+
+// sanctionsScreening caches screening results by user so repeated compliance checks do not
+// call the screening provider each time. A result is reused until its TTL passes or the
+// user's screened details change.
+type sanctionsScreening struct {
+	screener SanctionsScreener
+	ttl      time.Duration
+	mu       sync.Mutex
+	results  map[uuid.UUID]cachedScreening
+	now      func() time.Time
+}
+
+// cachedScreening is a screening result and the subject it was produced for.
+type cachedScreening struct {
+	subject   ScreeningSubject
+	result    *ScreeningResult
+	expiresAt time.Time
+}
+
+// SetSanctionsScreener sets the screener used during AML checks. Results are cached for ttl;
+// a ttl of zero uses DefaultSanctionsScreeningTTL.
+func (s *ComplianceService) SetSanctionsScreener(screener SanctionsScreener, ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = DefaultSanctionsScreeningTTL
+	}
+	s.sanctions = &sanctionsScreening{
+		screener: screener,
+		ttl:      ttl,
+		results:  make(map[uuid.UUID]cachedScreening),
+		now:      time.Now,
+	}
+}
+
+// SanctionsScreeningEnabled reports whether a sanctions screener is configured. Without one,
+// users are not screened and RescreenUsers does nothing.
+func (s *ComplianceService) SanctionsScreeningEnabled() bool {
+	return s.sanctions != nil
+}
+
+// screen returns the screening result for a user and when it expires, screening the user
+// when there is no current cached result.
+func (c *sanctionsScreening) screen(ctx context.Context, user *models.User) (*ScreeningResult, time.Time, error) {
+	subject := screeningSubject(user)
+	now := c.now()
+
+	c.mu.Lock()
+	cached, ok := c.results[user.ID]
+	c.mu.Unlock()
+	if ok && now.Before(cached.expiresAt) && sameScreeningSubject(cached.subject, subject) {
+		return cached.result, cached.expiresAt, nil
+	}
+
+	result, err := c.screener.Screen(ctx, &subject)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if result.ScreenedAt.IsZero() {
+		result.ScreenedAt = now
+	}
+
+	expiresAt := now.Add(c.ttl)
+	c.mu.Lock()
+	c.results[user.ID] = cachedScreening{subject: subject, result: result, expiresAt: expiresAt}
+	c.mu.Unlock()
+
+	return result, expiresAt, nil
+}
+
+// forget drops a user's cached screening result so the next screening calls the screener.
+func (c *sanctionsScreening) forget(userID uuid.UUID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.results, userID)
+}
+
+// ScreenUser screens a user against sanctions and PEP lists, as during onboarding, and
+// drafts a suspicious activity report on a match. It returns the screening violations, which
+// are none when no screener is configured.
+func (s *ComplianceService) ScreenUser(ctx context.Context, userID uuid.UUID) ([]ComplianceViolation, error) {
+	user, err := s.userStore.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch user: %w", err)
+	}
+	return s.screenUser(ctx, user), nil
+}
+
+// RescreenUsers screens every user again, ignoring cached results, so that users are
+// checked against the current lists even when no compliance check runs for them. It returns
+// the number of users who matched a list.
+func (s *ComplianceService) RescreenUsers(ctx context.Context) (int, error) {
+	if s.sanctions == nil {
+		return 0, nil
+	}
+
+	matched := 0
+	for offset := 0; ; offset += sanctionsRescreenPageSize {
+		users, err := s.userStore.List(ctx, sanctionsRescreenPageSize, offset)
+		if err != nil {
+			return matched, fmt.Errorf("failed to list users: %w", err)
+		}
+		for _, user := range users {
+			if err := ctx.Err(); err != nil {
+				return matched, err
+			}
+			s.sanctions.forget(user.ID)
+			for _, violation := range s.screenUser(ctx, user) {
+				if violation.Code == "AML_003" {
+					matched++
+					break
+				}
+			}
+		}
+		if len(users) < sanctionsRescreenPageSize {
+			return matched, nil
+		}
+	}
+}
+
+// screenUser screens a user outside a full compliance check and drafts a suspicious activity
+// report when the user matches a list.
+func (s *ComplianceService) screenUser(ctx context.Context, user *models.User) []ComplianceViolation {
+	violations, _ := s.performSanctionsScreening(ctx, user)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	score := s.calculateComplianceScore(violations)
+	check := &ComplianceCheck{
+		EntityID:   user.ID,
+		EntityType: "user",
+		CheckType:  "sanctions_screening",
+		Status:     s.determineComplianceStatus(score, violations),
+		Score:      score,
+		CheckDate:  time.Now(),
+		Violations: violations,
+		Metadata:   make(map[string]interface{}),
+	}
+	s.reportSuspiciousActivity(ctx, user, check)
+	return violations
+}
+
+// performSanctionsScreening screens a user against sanctions and PEP lists. A match is a
+// critical violation; a screening failure is a high violation so the check cannot pass
+// unscreened. It also returns when the screening result expires, which is zero when the
+// user was not screened.
+func (s *ComplianceService) performSanctionsScreening(ctx context.Context, user *models.User) ([]ComplianceViolation, time.Time) {
+	if s.sanctions == nil {
+		return nil, time.Time{}
+	}
+
+	result, expiresAt, err := s.sanctions.screen(ctx, user)
+	if err != nil {
+		s.logger.Error("Sanctions screening failed",
+			zap.String("user_id", user.ID.String()),
+			zap.Error(err))
+		return []ComplianceViolation{{
+			Code:        "AML_004",
+			Severity:    "high",
+			Description: "Sanctions and PEP screening could not be completed",
+			Rule:        "Sanctions Screening",
+			Remediation: "Retry screening before onboarding or transacting",
+			Metadata:    map[string]interface{}{"error": err.Error()},
+		}}, time.Time{}
+	}
+
+	if !result.Match {
+		return nil, expiresAt
+	}
+
+	s.logger.Warn("Sanctions screening match",
+		zap.String("user_id", user.ID.String()),
+		zap.Strings("lists", result.Lists),
+		zap.String("matched_name", result.MatchedName),
+		zap.Float64("score", result.Score))
+
+	return []ComplianceViolation{{
+		Code:        "AML_003",
+		Severity:    "critical",
+		Description: "User matches a sanctions or politically exposed person list",
+		Rule:        "Sanctions Screening",
+		Remediation: "Block transactions and escalate to the compliance officer for review",
+		Metadata: map[string]interface{}{
+			"lists":        result.Lists,
+			"matched_name": result.MatchedName,
+			"score":        result.Score,
+			"screened_at":  result.ScreenedAt,
+		},
+	}}, expiresAt
+}
+
+// screeningSubject builds the screening subject for a user.
+func screeningSubject(user *models.User) ScreeningSubject {
+	subject := ScreeningSubject{
+		Name:    strings.TrimSpace(user.FullName),
+		Country: user.Address.Country,
+	}
+	if !user.DateOfBirth.IsZero() {
+		dob := user.DateOfBirth
+		subject.DateOfBirth = &dob
+	}
+	return subject
+}
+
+// sameScreeningSubject reports whether two subjects have the same screened details.
+func sameScreeningSubject(a, b ScreeningSubject) bool {
+	if a.Name != b.Name || a.Country != b.Country {
+		return false
+	}
+	if a.DateOfBirth == nil || b.DateOfBirth == nil {
+		return a.DateOfBirth == nil && b.DateOfBirth == nil
+	}
+	return a.DateOfBirth.Equal(*b.DateOfBirth)
+}
@@ -0,0 +1,244 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubSanctionsScreener returns a fixed result and records the subjects it screened.
+type stubSanctionsScreener struct {
+	result   ScreeningResult
+	subjects []ScreeningSubject
+}
+
+func (s *stubSanctionsScreener) Screen(ctx context.Context, subject *ScreeningSubject) (*ScreeningResult, error) {
+	s.subjects = append(s.subjects, *subject)
+	result := s.result
+	return &result, nil
+}
+
+func newComplianceTestUser() *models.User {
+	user := &models.User{
+		FullName:    "Ivan Petrov",
+		Email:       "ivan.petrov@example.com",
+		Status:      models.StatusActive,
+		DateOfBirth: time.Date(1970, 3, 14, 0, 0, 0, 0, time.UTC),
+		Address:     models.Address{Country: "RU"},
+	}
+	user.CreatedAt = time.Now().AddDate(-2, 0, 0)
+	return user
+}
+
+func newComplianceTestService(t *testing.T, user *models.User, payments ...*models.Payment) *ComplianceService {
+	t.Helper()
+	log := logger.NewLogger("error", "json")
+	configManager := config.NewManager(log, "")
+	svc := NewComplianceService(log, configManager, newFakeUserStore(user), nil, nil, newFakePaymentStore(payments...))
+	svc.SetCurrencyConverter(NewCurrencyConverter(configManager))
+	return svc
+}
+
+func TestSanctionsMatchFailsUserCompliance(t *testing.T) {
+	ctx := context.Background()
+	user := newComplianceTestUser()
+	svc := newComplianceTestService(t, user)
+
+	screener := &stubSanctionsScreener{result: ScreeningResult{
+		Match:       true,
+		Lists:       []string{"OFAC SDN"},
+		MatchedName: "PETROV, Ivan",
+		Score:       0.97,
+	}}
+	svc.SetSanctionsScreener(screener, time.Hour)
+
+	check, err := svc.ValidateUserCompliance(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "failed", check.Status)
+
+	var violation *ComplianceViolation
+	for i := range check.Violations {
+		if check.Violations[i].Code == "AML_003" {
+			violation = &check.Violations[i]
+		}
+	}
+	require.NotNil(t, violation, "expected a sanctions violation")
+	assert.Equal(t, "critical", violation.Severity)
+	assert.Equal(t, []string{"OFAC SDN"}, violation.Metadata["lists"])
+
+	require.Len(t, screener.subjects, 1)
+	assert.Equal(t, "Ivan Petrov", screener.subjects[0].Name)
+	assert.Equal(t, "RU", screener.subjects[0].Country)
+	require.NotNil(t, screener.subjects[0].DateOfBirth)
+	assert.True(t, user.DateOfBirth.Equal(*screener.subjects[0].DateOfBirth))
+}
+
+func TestSanctionsScreeningIsCachedUntilTTL(t *testing.T) {
+	ctx := context.Background()
+	user := newComplianceTestUser()
+	svc := newComplianceTestService(t, user)
+
+	screener := &stubSanctionsScreener{}
+	svc.SetSanctionsScreener(screener, time.Hour)
+	now := time.Now()
+	svc.sanctions.now = func() time.Time { return now }
+
+	check, err := svc.ValidateUserCompliance(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "passed", check.Status)
+	assert.True(t, check.ValidUntil.Equal(now.Add(time.Hour)), "check is valid only as long as the screening")
+
+	_, err = svc.ValidateUserCompliance(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Len(t, screener.subjects, 1, "a cached result is reused within the TTL")
+
+	// A changed name is screened again.
+	user.FullName = "Ivan A. Petrov"
+	_, err = svc.ValidateUserCompliance(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Len(t, screener.subjects, 2)
+
+	// After the TTL the user is re-screened.
+	now = now.Add(time.Hour + time.Second)
+	_, err = svc.ValidateUserCompliance(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Len(t, screener.subjects, 3)
+}
+
+func TestRescreenUsersIgnoresCachedResults(t *testing.T) {
+	ctx := context.Background()
+	user := newComplianceTestUser()
+	svc := newComplianceTestService(t, user)
+
+	screener := &stubSanctionsScreener{}
+	svc.SetSanctionsScreener(screener, time.Hour)
+
+	// Onboarding screens the new user, who is not listed yet.
+	violations, err := svc.ScreenUser(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+
+	// The user is added to a list; re-screening within the TTL still catches it.
+	screener.result = ScreeningResult{Match: true, Lists: []string{"OFAC SDN"}}
+	matched, err := svc.RescreenUsers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, matched)
+	assert.Len(t, screener.subjects, 2)
+
+	// Compliance checks now reuse the fresh result.
+	check, err := svc.ValidateUserCompliance(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "failed", check.Status)
+	assert.Len(t, screener.subjects, 2)
+}
+
+func TestSanctionsScreeningIsDisabledWithoutScreener(t *testing.T) {
+	ctx := context.Background()
+	user := newComplianceTestUser()
+	svc := newComplianceTestService(t, user)
+	assert.False(t, svc.SanctionsScreeningEnabled())
+
+	matched, err := svc.RescreenUsers(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, matched)
+
+	svc.SetSanctionsScreener(&stubSanctionsScreener{}, time.Hour)
+	assert.True(t, svc.SanctionsScreeningEnabled())
+}
+
+func TestPaymentsAboveReportingThresholdRequireSAR(t *testing.T) {
+	ctx := context.Background()
+	user := newComplianceTestUser()
+	user.ID = uuid.New()
+
+	// 30,000 in the last 30 days crosses the default 30-day reporting threshold of 25,000.
+	recent := time.Now().AddDate(0, 0, -10)
+	old := time.Now().AddDate(0, 0, -90)
+	payments := []*models.Payment{
+		{UserID: user.ID, Amount: 18000, Currency: "USD", Status: models.PaymentStatusCompleted, ProcessedAt: &recent},
+		{UserID: user.ID, Amount: 12000, Currency: "USD", Status: models.PaymentStatusCompleted, ProcessedAt: &recent},
+		{UserID: user.ID, Amount: 40000, Currency: "USD", Status: models.PaymentStatusFailed, ProcessedAt: &recent},
+		{UserID: user.ID, Amount: 15000, Currency: "USD", Status: models.PaymentStatusCompleted, ProcessedAt: &old},
+	}
+	svc := newComplianceTestService(t, user, payments...)
+
+	check, err := svc.ValidateUserCompliance(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "failed", check.Status)
+
+	violations := make(map[int]ComplianceViolation)
+	for _, violation := range check.Violations {
+		if violation.Code == "AML_005" || violation.Code == "AML_006" {
+			violations[violation.Metadata["review_period_days"].(int)] = violation
+		}
+	}
+
+	monthly, ok := violations[30]
+	require.True(t, ok, "expected a violation for the 30-day review period")
+	assert.Equal(t, "AML_006", monthly.Code)
+	assert.Equal(t, "critical", monthly.Severity)
+	assert.Equal(t, true, monthly.Metadata["sar_required"])
+	assert.Equal(t, 30000.0, monthly.Metadata["payment_total"])
+
+	// 45,000 over the year is below the annual monitoring threshold of 50,000.
+	_, ok = violations[365]
+	assert.False(t, ok)
+}
+
+func TestPaymentsAboveMonitoringThresholdRequireEnhancedMonitoring(t *testing.T) {
+	ctx := context.Background()
+	user := newComplianceTestUser()
+	user.ID = uuid.New()
+
+	recent := time.Now().AddDate(0, 0, -5)
+	svc := newComplianceTestService(t, user,
+		&models.Payment{UserID: user.ID, Amount: 12000, Currency: "USD", Status: models.PaymentStatusCompleted, ProcessedAt: &recent})
+
+	check, err := svc.ValidateUserCompliance(ctx, user.ID)
+	require.NoError(t, err)
+	assert.NotEqual(t, "failed", check.Status)
+
+	var codes []string
+	for _, violation := range check.Violations {
+		codes = append(codes, violation.Code)
+	}
+	assert.Contains(t, codes, "AML_005")
+	assert.NotContains(t, codes, "AML_006")
+}
+
+func TestPaymentsInDifferentCurrenciesAreTotalledInTheBaseCurrency(t *testing.T) {
+	ctx := context.Background()
+	user := newComplianceTestUser()
+	user.ID = uuid.New()
+
+	// 9,200 EUR is 10,000 USD and 639,000 MZN is another 10,000 USD; summed as raw amounts
+	// they would wrongly cross the 25,000 reporting threshold.
+	recent := time.Now().AddDate(0, 0, -3)
+	svc := newComplianceTestService(t, user,
+		&models.Payment{UserID: user.ID, Amount: 9200, Currency: "EUR", Status: models.PaymentStatusCompleted, ProcessedAt: &recent},
+		&models.Payment{UserID: user.ID, Amount: 639000, Currency: "MZN", Status: models.PaymentStatusCompleted, ProcessedAt: &recent})
+
+	check, err := svc.ValidateUserCompliance(ctx, user.ID)
+	require.NoError(t, err)
+
+	var monthly *ComplianceViolation
+	for i := range check.Violations {
+		violation := &check.Violations[i]
+		if violation.Code == "AML_006" {
+			t.Fatalf("unexpected reporting violation: %s", violation.Description)
+		}
+		if violation.Code == "AML_005" && violation.Metadata["review_period_days"] == 30 {
+			monthly = violation
+		}
+	}
+	require.NotNil(t, monthly, "expected a monitoring violation for the 30-day review period")
+	assert.InDelta(t, 20000.0, monthly.Metadata["payment_total"].(float64), 0.01)
+	assert.Equal(t, "USD", monthly.Metadata["currency"])
+}
@@ -0,0 +1,46 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/edsonmichaque/bazaruto/internal/store"
+)
+
+// maxConflictRetries is the number of times an update is reapplied after losing a version check.
+const maxConflictRetries = 3
+
+// updatePolicyWithRetry applies mutate to policy and saves it. When a concurrent update
+// wins the version check the policy is reloaded and mutate is reapplied to the fresh copy,
+// so mutate must re-validate any preconditions. mutate reports whether the policy changed;
+// unchanged policies are not saved. The returned policy is the latest stored copy.
+func updatePolicyWithRetry(ctx context.Context, policyStore store.PolicyStore, policy *models.Policy, mutate func(*models.Policy) (bool, error)) (*models.Policy, bool, error) {
+	return savePolicyWithRetry(ctx, policyStore, policy, mutate, policyStore.UpdatePolicy)
+}
+
+// savePolicyWithRetry is updatePolicyWithRetry with the policy saved by save, which must
+// apply the version check of PolicyStore.UpdatePolicy.
+func savePolicyWithRetry(ctx context.Context, policyStore store.PolicyStore, policy *models.Policy, mutate func(*models.Policy) (bool, error), save func(context.Context, *models.Policy) error) (*models.Policy, bool, error) {
+	for attempt := 0; ; attempt++ {
+		changed, err := mutate(policy)
+		if err != nil || !changed {
+			return policy, false, err
+		}
+
+		err = save(ctx, policy)
+		if err == nil {
+			return policy, true, nil
+		}
+		if !errors.Is(err, store.ErrVersionConflict) || attempt+1 >= maxConflictRetries {
+			return policy, false, err
+		}
+
+		fresh, getErr := policyStore.GetPolicy(ctx, policy.ID)
+		if getErr != nil {
+			return policy, false, fmt.Errorf("failed to reload policy after conflict: %w", getErr)
+		}
+		policy = fresh
+	}
+}
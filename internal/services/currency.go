@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/edsonmichaque/bazaruto/internal/config"
+)
+
+// ErrUnsupportedCurrency is returned when no exchange rate is configured for a currency.
+var ErrUnsupportedCurrency = errors.New("unsupported currency")
+
+// CurrencyConverter converts monetary amounts between currencies.
+type CurrencyConverter interface {
+	Convert(ctx context.Context, amount float64, from, to string) (float64, error)
+}
+
+// configCurrencyConverter converts amounts using the exchange rates in CurrencyConfig.
+type configCurrencyConverter struct {
+	configManager *config.Manager
+}
+
+// NewCurrencyConverter creates a CurrencyConverter backed by the configured exchange rates.
+func NewCurrencyConverter(configManager *config.Manager) CurrencyConverter {
+	return &configCurrencyConverter{configManager: configManager}
+}
+
+// Convert converts an amount from one currency to another through the base currency.
+func (c *configCurrencyConverter) Convert(ctx context.Context, amount float64, from, to string) (float64, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	if from == to {
+		return amount, nil
+	}
+
+	rates := c.configManager.GetConfig().Currency.ExchangeRates
+	fromRate, ok := rates[from]
+	if !ok || fromRate <= 0 {
+		return 0, fmt.Errorf("%w: %s", ErrUnsupportedCurrency, from)
+	}
+	toRate, ok := rates[to]
+	if !ok || toRate <= 0 {
+		return 0, fmt.Errorf("%w: %s", ErrUnsupportedCurrency, to)
+	}
+
+	return amount / fromRate * toRate, nil
+}
+
+// convertAmount converts an amount between currencies, failing when a conversion is
+// needed but the converter is nil.
+func convertAmount(ctx context.Context, converter CurrencyConverter, amount float64, from, to string) (float64, error) {
+	if strings.EqualFold(from, to) {
+		return amount, nil
+	}
+	if converter == nil {
+		return 0, fmt.Errorf("no currency converter configured to convert %s to %s", from, to)
+	}
+	return converter.Convert(ctx, amount, from, to)
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/google/uuid"
+)
+
+// DocumentFlagAnalysisFailed is recorded on documents whose metadata could not be extracted.
+const DocumentFlagAnalysisFailed = "analysis_failed"
+
+// DocumentAnalyzer extracts structured fields, such as dates, amounts and the document type,
+// from claim documents, for example through OCR.
+type DocumentAnalyzer interface {
+	ExtractMetadata(ctx context.Context, doc *models.Document) (*models.DocumentMetadata, error)
+}
+
+// SetDocumentAnalyzer sets the analyzer used to extract document metadata at claim intake.
+func (s *ClaimService) SetDocumentAnalyzer(analyzer DocumentAnalyzer) {
+	s.documentAnalyzer = analyzer
+}
+
+// AnalyzeDocuments extracts metadata from the documents of an existing claim and saves it on the
+// claim, where the fraud engine's document consistency factor reads it.
+func (s *ClaimService) AnalyzeDocuments(ctx context.Context, claimID uuid.UUID) (*models.Claim, error) {
+	if s.documentAnalyzer == nil {
+		return nil, fmt.Errorf("document analyzer is not configured")
+	}
+
+	claim, err := s.store.GetClaim(ctx, claimID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch claim: %w", err)
+	}
+
+	s.extractDocumentMetadata(ctx, claim)
+
+	if err := s.store.UpdateClaim(ctx, claim); err != nil {
+		return nil, fmt.Errorf("failed to update claim: %w", err)
+	}
+
+	return claim, nil
+}
+
+// extractDocumentMetadata attaches extracted metadata to each document of a claim. Extraction is
+// best effort: a failure flags the document rather than rejecting the claim.
+func (s *ClaimService) extractDocumentMetadata(ctx context.Context, claim *models.Claim) {
+	if s.documentAnalyzer == nil {
+		return
+	}
+
+	for i := range claim.Documents {
+		doc := &claim.Documents[i]
+		metadata, err := s.documentAnalyzer.ExtractMetadata(ctx, doc)
+		if err != nil {
+			if !contains(doc.ValidationFlags, DocumentFlagAnalysisFailed) {
+				doc.ValidationFlags = append(doc.ValidationFlags, DocumentFlagAnalysisFailed)
+			}
+			continue
+		}
+		doc.Metadata = metadata
+	}
+}
+
+// discardClientDocumentAnalysis clears the validation flags and extracted metadata a client
+// sent with a claim's documents. Both are written only by the validator and the analyzer,
+// so the fraud engine never scores evidence the claimant supplied about their own documents.
+func discardClientDocumentAnalysis(claim *models.Claim) {
+	for i := range claim.Documents {
+		claim.Documents[i].ValidationFlags = nil
+		claim.Documents[i].Metadata = nil
+	}
+}
+
+// preserveDocumentAnalysis carries the stored validation flags and extracted metadata over to
+// an updated claim, matching documents by file name. Documents not seen before have none.
+func preserveDocumentAnalysis(existing, claim *models.Claim) {
+	stored := make(map[string]models.Document, len(existing.Documents))
+	for _, doc := range existing.Documents {
+		stored[doc.FileName] = doc
+	}
+	for i := range claim.Documents {
+		doc := &claim.Documents[i]
+		previous := stored[doc.FileName]
+		doc.ValidationFlags = previous.ValidationFlags
+		doc.Metadata = previous.Metadata
+	}
+}
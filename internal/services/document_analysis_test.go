@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubDocumentAnalyzer returns fixed metadata for every document.
+type stubDocumentAnalyzer struct {
+	metadata *models.DocumentMetadata
+}
+
+func (a stubDocumentAnalyzer) ExtractMetadata(ctx context.Context, doc *models.Document) (*models.DocumentMetadata, error) {
+	return a.metadata, nil
+}
+
+func findFraudFactor(score *FraudScore, name string) *FraudFactor {
+	for i := range score.Factors {
+		if score.Factors[i].Factor == name {
+			return &score.Factors[i]
+		}
+	}
+	return nil
+}
+
+func TestExtractedDocumentDateBeforeIncidentRaisesFraudFactor(t *testing.T) {
+	ctx := context.Background()
+	fraudService, claim := newFraudTestFixture(t, config.NewManager(logger.NewLogger("error", "json"), ""))
+	claimStore := fraudService.claimStore.(*fakeClaimStore)
+	claim.Documents = []models.Document{{FileName: "repair_invoice.pdf", FileType: "application/pdf", FileSize: 200 * 1024}}
+
+	// Without extracted data the factor does not contribute.
+	score, err := fraudService.AnalyzeClaimForFraud(ctx, claim.ID)
+	require.NoError(t, err)
+	factor := findFraudFactor(score, "document_consistency")
+	require.NotNil(t, factor)
+	assert.Zero(t, factor.Score)
+
+	invoiceDate := claim.IncidentDate.AddDate(0, 0, -30)
+	claimService := NewClaimService(claimStore, nil)
+	claimService.SetDocumentAnalyzer(stubDocumentAnalyzer{metadata: &models.DocumentMetadata{
+		DocumentType: "invoice",
+		DocumentDate: &invoiceDate,
+		Amount:       2500,
+	}})
+
+	analyzed, err := claimService.AnalyzeDocuments(ctx, claim.ID)
+	require.NoError(t, err)
+	require.NotNil(t, analyzed.Documents[0].Metadata)
+
+	score, err = fraudService.AnalyzeClaimForFraud(ctx, claim.ID)
+	require.NoError(t, err)
+	factor = findFraudFactor(score, "document_consistency")
+	require.NotNil(t, factor)
+	assert.Equal(t, 70.0, factor.Score)
+	assert.Equal(t, "high", factor.Severity)
+	assert.Contains(t, factor.Description, "repair_invoice.pdf")
+	assert.Contains(t, factor.Description, invoiceDate.Format("2006-01-02"))
+
+	// A document dated on the incident day is consistent.
+	sameDay := claim.IncidentDate
+	claim.Documents[0].Metadata.DocumentDate = &sameDay
+	score, err = fraudService.AnalyzeClaimForFraud(ctx, claim.ID)
+	require.NoError(t, err)
+	assert.Zero(t, findFraudFactor(score, "document_consistency").Score)
+}
+
+func TestClientSuppliedDocumentAnalysisIsDiscarded(t *testing.T) {
+	ctx := context.Background()
+	fraudService, claim := newFraudTestFixture(t, config.NewManager(logger.NewLogger("error", "json"), ""))
+	claimStore := fraudService.claimStore.(*fakeClaimStore)
+	claimService := NewClaimService(claimStore, nil)
+
+	invoiceDate := claim.IncidentDate
+	update := *claim
+	update.Documents = []models.Document{{
+		FileName:        "repair_invoice.pdf",
+		ValidationFlags: []string{},
+		Metadata:        &models.DocumentMetadata{DocumentType: "invoice", DocumentDate: &invoiceDate},
+	}}
+	require.NoError(t, claimService.UpdateClaim(ctx, &update))
+
+	stored, err := claimStore.GetClaim(ctx, claim.ID)
+	require.NoError(t, err)
+	require.Len(t, stored.Documents, 1)
+	assert.Nil(t, stored.Documents[0].Metadata)
+	assert.Nil(t, stored.Documents[0].ValidationFlags)
+}
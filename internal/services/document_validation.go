@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+)
+
+// ErrDocumentRejected is returned when a claim document fails intake validation.
+var ErrDocumentRejected = errors.New("document rejected")
+
+// Document validation flags recorded on documents that are accepted with issues.
+const (
+	DocumentFlagTooLarge = "too_large"
+	DocumentFlagTooSmall = "too_small"
+)
+
+// DocumentValidationResult is the outcome of validating a single claim document.
+type DocumentValidationResult struct {
+	Rejected bool     `json:"rejected"`
+	Reason   string   `json:"reason,omitempty"`
+	Flags    []string `json:"flags,omitempty"`
+}
+
+// DocumentValidator validates claim documents at intake.
+type DocumentValidator interface {
+	ValidateDocument(ctx context.Context, doc *models.Document) (*DocumentValidationResult, error)
+}
+
+// MalwareScanner scans an uploaded document for malware. It reports whether the
+// document is clean and, if not, the name of the detected threat.
+type MalwareScanner interface {
+	Scan(ctx context.Context, doc *models.Document) (clean bool, threat string, err error)
+}
+
+// documentRulesValidator validates documents against FraudDetectionConfig.DocumentRules
+// and an optional malware scanner.
+type documentRulesValidator struct {
+	configManager *config.Manager
+	scanner       MalwareScanner
+}
+
+// NewDocumentValidator creates a DocumentValidator that enforces the configured content types
+// and size limits. Disallowed content types and infected files are rejected; files outside the
+// size limits are accepted but flagged. The scanner may be nil.
+func NewDocumentValidator(configManager *config.Manager, scanner MalwareScanner) DocumentValidator {
+	return &documentRulesValidator{
+		configManager: configManager,
+		scanner:       scanner,
+	}
+}
+
+// ValidateDocument validates a single document.
+func (v *documentRulesValidator) ValidateDocument(ctx context.Context, doc *models.Document) (*DocumentValidationResult, error) {
+	rules := v.configManager.GetConfig().FraudDetection.DocumentRules
+	result := &DocumentValidationResult{}
+
+	if !contentTypeAllowed(rules.AllowedContentTypes, doc.FileType) {
+		result.Rejected = true
+		result.Reason = fmt.Sprintf("content type %q is not allowed", doc.FileType)
+		return result, nil
+	}
+
+	if rules.MaxFileSize > 0 && doc.FileSize > rules.MaxFileSize {
+		result.Flags = append(result.Flags, DocumentFlagTooLarge)
+	}
+	if doc.FileSize < rules.MinFileSize {
+		result.Flags = append(result.Flags, DocumentFlagTooSmall)
+	}
+
+	if v.scanner != nil {
+		clean, threat, err := v.scanner.Scan(ctx, doc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan document: %w", err)
+		}
+		if !clean {
+			result.Rejected = true
+			result.Reason = fmt.Sprintf("malware detected: %s", threat)
+		}
+	}
+
+	return result, nil
+}
+
+// contentTypeAllowed reports whether a content type is in the allowed list. An empty list allows any type.
+func contentTypeAllowed(allowed []string, contentType string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, allowedType := range allowed {
+		if strings.EqualFold(allowedType, contentType) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateDocuments runs the document validator over a claim's documents, rejecting the claim
+// if any document is rejected and recording flags on the documents that are accepted.
+func (s *ClaimService) validateDocuments(ctx context.Context, claim *models.Claim) error {
+	if s.documentValidator == nil {
+		return nil
+	}
+
+	for i := range claim.Documents {
+		doc := &claim.Documents[i]
+		result, err := s.documentValidator.ValidateDocument(ctx, doc)
+		if err != nil {
+			return fmt.Errorf("failed to validate document %s: %w", doc.FileName, err)
+		}
+		if result.Rejected {
+			return fmt.Errorf("%w: %s: %s", ErrDocumentRejected, doc.FileName, result.Reason)
+		}
+		doc.ValidationFlags = result.Flags
+	}
+
+	return nil
+}
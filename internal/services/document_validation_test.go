@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubMalwareScanner reports files with the given name as infected.
+type stubMalwareScanner struct {
+	infected string
+}
+
+func (s stubMalwareScanner) Scan(ctx context.Context, doc *models.Document) (bool, string, error) {
+	if doc.FileName == s.infected {
+		return false, "EICAR-Test-File", nil
+	}
+	return true, "", nil
+}
+
+func newDocumentIntakeFixture(t *testing.T, scanner MalwareScanner) (*ClaimService, func(...models.Document) *models.Claim) {
+	t.Helper()
+
+	policy := &models.Policy{
+		UserID:         uuid.New(),
+		Status:         models.PolicyStatusActive,
+		EffectiveDate:  time.Now().AddDate(0, -6, 0),
+		ExpirationDate: time.Now().AddDate(0, 6, 0),
+	}
+	validator := NewDocumentValidator(config.NewManager(logger.NewLogger("error", "json"), ""), scanner)
+	svc := NewClaimService(newFakeClaimStore(), newFakePolicyStore(policy), validator)
+
+	newClaim := func(documents ...models.Document) *models.Claim {
+		return &models.Claim{
+			PolicyID:     policy.ID,
+			UserID:       policy.UserID,
+			Title:        "Burst pipe",
+			Description:  "Kitchen flooded",
+			ClaimAmount:  1200,
+			IncidentDate: time.Now().AddDate(0, 0, -2),
+			Documents:    documents,
+		}
+	}
+	return svc, newClaim
+}
+
+func TestClaimIntakeRejectsDisallowedContentType(t *testing.T) {
+	svc, newClaim := newDocumentIntakeFixture(t, nil)
+
+	err := svc.CreateClaim(context.Background(), newClaim(
+		models.Document{FileName: "photo.jpg", FileType: "image/jpeg", FileSize: 200 * 1024},
+		models.Document{FileName: "setup.exe", FileType: "application/x-msdownload", FileSize: 200 * 1024},
+	))
+	assert.ErrorIs(t, err, ErrDocumentRejected)
+	assert.Contains(t, err.Error(), "setup.exe")
+}
+
+func TestClaimIntakeFlagsOversizedDocument(t *testing.T) {
+	svc, newClaim := newDocumentIntakeFixture(t, nil)
+
+	claim := newClaim(
+		models.Document{FileName: "estimate.pdf", FileType: "application/pdf", FileSize: 200 * 1024},
+		models.Document{FileName: "video.png", FileType: "image/png", FileSize: 50 * 1024 * 1024},
+	)
+	require.NoError(t, svc.CreateClaim(context.Background(), claim))
+	assert.Empty(t, claim.Documents[0].ValidationFlags)
+	assert.Equal(t, []string{DocumentFlagTooLarge}, claim.Documents[1].ValidationFlags)
+}
+
+func TestClaimIntakeRejectsInfectedDocument(t *testing.T) {
+	svc, newClaim := newDocumentIntakeFixture(t, stubMalwareScanner{infected: "receipt.pdf"})
+
+	err := svc.CreateClaim(context.Background(), newClaim(
+		models.Document{FileName: "receipt.pdf", FileType: "application/pdf", FileSize: 200 * 1024},
+	))
+	assert.ErrorIs(t, err, ErrDocumentRejected)
+	assert.Contains(t, err.Error(), "malware detected")
+}
@@ -0,0 +1,275 @@
+package services
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+)
+
+// Email template names.
+const (
+	EmailTemplateWelcome       = "welcome"
+	EmailTemplatePasswordReset = "password_reset"
+)
+
+// EmailTemplateStore holds the HTML email templates by name and locale. Subjects are text
+// templates and bodies are HTML templates, so data in the body is escaped. Templates are
+// picked for the closest available locale with the same fallback as MessageCatalog.
+type EmailTemplateStore struct {
+	templates *MessageCatalog
+}
+
+// NewEmailTemplateStore creates a store with the built-in emails in English and Portuguese.
+func NewEmailTemplateStore() *EmailTemplateStore {
+	s := &EmailTemplateStore{templates: &MessageCatalog{messages: make(map[string]map[string]MessageTemplate)}}
+	for locale, emails := range emailTemplates {
+		for name, tmpl := range emails {
+			s.Register(name, locale, tmpl)
+		}
+	}
+	return s
+}
+
+// Register adds or replaces the template for an email in a locale.
+func (s *EmailTemplateStore) Register(name, locale string, tmpl MessageTemplate) {
+	s.templates.Register(name, locale, tmpl)
+}
+
+// Render executes the subject and HTML body of an email in the closest available locale.
+func (s *EmailTemplateStore) Render(name, locale string, data interface{}) (string, string, error) {
+	tmpl, resolved, ok := s.templates.Lookup(name, locale)
+	if !ok {
+		return "", "", fmt.Errorf("unknown email template: %s", name)
+	}
+
+	subject, err := executeTextTemplate(name+".subject", tmpl.Subject, data)
+	if err != nil {
+		return "", "", err
+	}
+
+	body, err := template.New(name + "." + resolved).Parse(tmpl.Body)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to parse %s email template: %w", name, err)
+	}
+	var buf strings.Builder
+	if err := body.Execute(&buf, data); err != nil {
+		return "", "", fmt.Errorf("failed to render %s email template: %w", name, err)
+	}
+
+	return subject, buf.String(), nil
+}
+
+// emailTemplates are the built-in email templates by locale and name. The welcome email is
+// rendered with the user; the password reset email with the user and a ResetURL.
+var emailTemplates = map[string]map[string]MessageTemplate{
+	"en": {
+		EmailTemplateWelcome: {
+			Subject: "Welcome to Bazaruto Insurance!",
+			Body: `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>Welcome to Bazaruto Insurance</title>
+    <style>
+        body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
+        .header { background-color: #2c3e50; color: white; padding: 20px; text-align: center; }
+        .content { padding: 20px; background-color: #f9f9f9; }
+        .footer { padding: 20px; text-align: center; font-size: 12px; color: #666; }
+        .button { display: inline-block; padding: 12px 24px; background-color: #3498db; color: white; text-decoration: none; border-radius: 4px; margin: 10px 0; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>Welcome to Bazaruto Insurance!</h1>
+        </div>
+        <div class="content">
+            <h2>Hello {{.FullName}}!</h2>
+            <p>Welcome to Bazaruto Insurance, your trusted partner for comprehensive insurance solutions.</p>
+            <p>We're excited to have you on board and look forward to helping you protect what matters most.</p>
+            
+            <h3>What's next?</h3>
+            <ul>
+                <li>Complete your profile setup</li>
+                <li>Explore our insurance products</li>
+                <li>Get your first quote</li>
+                <li>Connect with our support team</li>
+            </ul>
+            
+            <p>
+                <a href="https://bazaruto.com/dashboard" class="button">Go to Dashboard</a>
+            </p>
+            
+            <p>If you have any questions, don't hesitate to reach out to our support team.</p>
+        </div>
+        <div class="footer">
+            <p>© 2024 Bazaruto Insurance. All rights reserved.</p>
+            <p>This email was sent to {{.Email}}</p>
+        </div>
+    </div>
+</body>
+</html>`,
+		},
+		EmailTemplatePasswordReset: {
+			Subject: "Reset Your Bazaruto Insurance Password",
+			Body: `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>Reset Your Password - Bazaruto Insurance</title>
+    <style>
+        body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
+        .header { background-color: #e74c3c; color: white; padding: 20px; text-align: center; }
+        .content { padding: 20px; background-color: #f9f9f9; }
+        .footer { padding: 20px; text-align: center; font-size: 12px; color: #666; }
+        .button { display: inline-block; padding: 12px 24px; background-color: #e74c3c; color: white; text-decoration: none; border-radius: 4px; margin: 10px 0; }
+        .warning { background-color: #fff3cd; border: 1px solid #ffeaa7; padding: 15px; border-radius: 4px; margin: 15px 0; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>Password Reset Request</h1>
+        </div>
+        <div class="content">
+            <h2>Hello {{.FullName}}!</h2>
+            <p>We received a request to reset your password for your Bazaruto Insurance account.</p>
+            
+            <p>Click the button below to reset your password:</p>
+            <p>
+                <a href="{{.ResetURL}}" class="button">Reset Password</a>
+            </p>
+            
+            <div class="warning">
+                <strong>Security Notice:</strong>
+                <ul>
+                    <li>This link will expire in 24 hours</li>
+                    <li>If you didn't request this reset, please ignore this email</li>
+                    <li>Never share your password with anyone</li>
+                </ul>
+            </div>
+            
+            <p>If the button doesn't work, copy and paste this link into your browser:</p>
+            <p style="word-break: break-all; background-color: #f0f0f0; padding: 10px; border-radius: 4px;">
+                {{.ResetURL}}
+            </p>
+        </div>
+        <div class="footer">
+            <p>© 2024 Bazaruto Insurance. All rights reserved.</p>
+            <p>This email was sent to {{.Email}}</p>
+        </div>
+    </div>
+</body>
+</html>`,
+		},
+	},
+	"pt": {
+		EmailTemplateWelcome: {
+			Subject: "Bem-vindo à Bazaruto Seguros!",
+			Body: `
+<!DOCTYPE html>
+<html lang="pt">
+<head>
+    <meta charset="UTF-8">
+    <title>Bem-vindo à Bazaruto Seguros</title>
+    <style>
+        body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
+        .header { background-color: #2c3e50; color: white; padding: 20px; text-align: center; }
+        .content { padding: 20px; background-color: #f9f9f9; }
+        .footer { padding: 20px; text-align: center; font-size: 12px; color: #666; }
+        .button { display: inline-block; padding: 12px 24px; background-color: #3498db; color: white; text-decoration: none; border-radius: 4px; margin: 10px 0; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>Bem-vindo à Bazaruto Seguros!</h1>
+        </div>
+        <div class="content">
+            <h2>Olá {{.FullName}}!</h2>
+            <p>Bem-vindo à Bazaruto Seguros, o seu parceiro de confiança para soluções de seguros completas.</p>
+            <p>Estamos muito contentes por tê-lo connosco e ansiosos por ajudá-lo a proteger o que mais importa.</p>
+            
+            <h3>Próximos passos</h3>
+            <ul>
+                <li>Complete o seu perfil</li>
+                <li>Explore os nossos produtos de seguros</li>
+                <li>Obtenha a sua primeira cotação</li>
+                <li>Fale com a nossa equipa de apoio</li>
+            </ul>
+            
+            <p>
+                <a href="https://bazaruto.com/dashboard" class="button">Ir para o painel</a>
+            </p>
+            
+            <p>Se tiver alguma dúvida, não hesite em contactar a nossa equipa de apoio.</p>
+        </div>
+        <div class="footer">
+            <p>© 2024 Bazaruto Seguros. Todos os direitos reservados.</p>
+            <p>Este email foi enviado para {{.Email}}</p>
+        </div>
+    </div>
+</body>
+</html>`,
+		},
+		EmailTemplatePasswordReset: {
+			Subject: "Redefina a sua palavra-passe da Bazaruto Seguros",
+			Body: `
+<!DOCTYPE html>
+<html lang="pt">
+<head>
+    <meta charset="UTF-8">
+    <title>Redefinir a palavra-passe - Bazaruto Seguros</title>
+    <style>
+        body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
+        .header { background-color: #e74c3c; color: white; padding: 20px; text-align: center; }
+        .content { padding: 20px; background-color: #f9f9f9; }
+        .footer { padding: 20px; text-align: center; font-size: 12px; color: #666; }
+        .button { display: inline-block; padding: 12px 24px; background-color: #e74c3c; color: white; text-decoration: none; border-radius: 4px; margin: 10px 0; }
+        .warning { background-color: #fff3cd; border: 1px solid #ffeaa7; padding: 15px; border-radius: 4px; margin: 15px 0; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>Pedido de redefinição da palavra-passe</h1>
+        </div>
+        <div class="content">
+            <h2>Olá {{.FullName}}!</h2>
+            <p>Recebemos um pedido para redefinir a palavra-passe da sua conta Bazaruto Seguros.</p>
+            
+            <p>Clique no botão abaixo para redefinir a sua palavra-passe:</p>
+            <p>
+                <a href="{{.ResetURL}}" class="button">Redefinir palavra-passe</a>
+            </p>
+            
+            <div class="warning">
+                <strong>Aviso de segurança:</strong>
+                <ul>
+                    <li>Esta ligação expira dentro de 24 horas</li>
+                    <li>Se não pediu esta redefinição, ignore este email</li>
+                    <li>Nunca partilhe a sua palavra-passe com ninguém</li>
+                </ul>
+            </div>
+            
+            <p>Se o botão não funcionar, copie e cole esta ligação no seu navegador:</p>
+            <p style="word-break: break-all; background-color: #f0f0f0; padding: 10px; border-radius: 4px;">
+                {{.ResetURL}}
+            </p>
+        </div>
+        <div class="footer">
+            <p>© 2024 Bazaruto Seguros. Todos os direitos reservados.</p>
+            <p>Este email foi enviado para {{.Email}}</p>
+        </div>
+    </div>
+</body>
+</html>`,
+		},
+	},
+}
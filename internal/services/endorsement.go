@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// EndorsementChanges describes a mid-term change to a policy's coverage.
+type EndorsementChanges struct {
+	CoverageAmount float64   `json:"coverage_amount"`
+	EffectiveDate  time.Time `json:"effective_date"`
+	Reason         string    `json:"reason"`
+}
+
+// EndorsePolicy applies a mid-term coverage change to an active policy. The policy term is
+// re-priced with the pricing engine and the difference from the current premium is pro-rated
+// over the remaining term, producing an additional premium charge for an increase or a return
+// premium credit for a decrease. The policy is updated and the endorsement is recorded.
+func (s *PolicyLifecycleService) EndorsePolicy(ctx context.Context, policyID uuid.UUID, changes *EndorsementChanges) (*models.PolicyEndorsement, error) {
+	if changes == nil || changes.CoverageAmount <= 0 {
+		return nil, fmt.Errorf("coverage amount must be greater than 0")
+	}
+	if s.pricingService == nil || s.endorsementStore == nil {
+		return nil, fmt.Errorf("policy endorsements are not configured")
+	}
+
+	policy, err := s.policyStore.GetPolicy(ctx, policyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch policy: %w", err)
+	}
+
+	if policy.Status != models.PolicyStatusActive {
+		return nil, fmt.Errorf("only active policies can be endorsed")
+	}
+
+	effectiveDate := changes.EffectiveDate
+	if effectiveDate.IsZero() {
+		effectiveDate = time.Now()
+	}
+	if effectiveDate.Before(policy.EffectiveDate) || !effectiveDate.Before(policy.ExpirationDate) {
+		return nil, fmt.Errorf("endorsement effective date must fall within the policy term")
+	}
+
+	pricing, err := s.pricingService.CalculatePremium(ctx, &PricingRequest{
+		ProductID:        policy.ProductID,
+		UserID:           policy.UserID,
+		CoverageAmount:   changes.CoverageAmount,
+		CoverageTier:     policy.CoverageTier,
+		Deductible:       policy.Deductible,
+		Currency:         policy.Currency,
+		PaymentFrequency: policy.PaymentFrequency,
+		EffectiveDate:    policy.EffectiveDate,
+		ExpirationDate:   policy.ExpirationDate,
+		Jurisdiction:     policy.Jurisdiction,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to price endorsement: %w", err)
+	}
+
+	newPremium := roundCurrency(pricing.FinalPremium)
+
+	// The endorsement is rebuilt from each reloaded copy, so it always records the
+	// coverage and premium it replaced.
+	var endorsement *models.PolicyEndorsement
+	policy, _, err = savePolicyWithRetry(ctx, s.policyStore, policy, func(policy *models.Policy) (bool, error) {
+		if policy.Status != models.PolicyStatusActive {
+			return false, fmt.Errorf("only active policies can be endorsed")
+		}
+		prorated := roundCurrency((newPremium - policy.Premium) * remainingTermRatio(policy, effectiveDate))
+		endorsement = &models.PolicyEndorsement{
+			PolicyID:         policy.ID,
+			EffectiveDate:    effectiveDate,
+			PreviousCoverage: policy.CoverageAmount,
+			NewCoverage:      changes.CoverageAmount,
+			PreviousPremium:  policy.Premium,
+			NewPremium:       newPremium,
+			ProratedAmount:   prorated,
+			AdjustmentType:   endorsementAdjustmentType(prorated),
+			Currency:         policy.Currency,
+			Reason:           changes.Reason,
+		}
+		policy.CoverageAmount = changes.CoverageAmount
+		policy.Premium = newPremium
+		return true, nil
+	}, func(ctx context.Context, policy *models.Policy) error {
+		return s.endorsementStore.EndorsePolicy(ctx, policy, endorsement)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to endorse policy: %w", err)
+	}
+
+	s.logger.Info("Policy endorsed",
+		zap.String("policy_id", policy.ID.String()),
+		zap.Float64("previous_coverage", endorsement.PreviousCoverage),
+		zap.Float64("new_coverage", endorsement.NewCoverage),
+		zap.Float64("prorated_amount", endorsement.ProratedAmount))
+
+	return endorsement, nil
+}
+
+// remainingTermRatio returns the fraction of the policy term remaining from the given date.
+func remainingTermRatio(policy *models.Policy, from time.Time) float64 {
+	term := policy.ExpirationDate.Sub(policy.EffectiveDate)
+	if term <= 0 {
+		return 0
+	}
+	remaining := policy.ExpirationDate.Sub(from)
+	if remaining <= 0 {
+		return 0
+	}
+	if remaining > term {
+		return 1
+	}
+	return float64(remaining) / float64(term)
+}
+
+// endorsementAdjustmentType classifies a pro-rated endorsement amount.
+func endorsementAdjustmentType(prorated float64) string {
+	switch {
+	case prorated > 0:
+		return models.EndorsementAdditionalPremium
+	case prorated < 0:
+		return models.EndorsementReturnPremium
+	default:
+		return models.EndorsementNoAdjustment
+	}
+}
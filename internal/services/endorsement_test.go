@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newEndorsementFixture returns a lifecycle service and an active policy halfway through
+// its term whose premium matches the pricing engine's rate for its current coverage.
+func newEndorsementFixture(t *testing.T) (*PolicyLifecycleService, *fakeEndorsementStore, *models.Policy) {
+	t.Helper()
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	configManager := config.NewManager(log, "")
+
+	now := time.Now()
+	product := &models.Product{Base: models.Base{ID: uuid.New()}, Category: "home"}
+	user := &models.User{Base: models.Base{ID: uuid.New(), CreatedAt: now}}
+	policy := &models.Policy{
+		ProductID:        product.ID,
+		UserID:           user.ID,
+		Currency:         "USD",
+		CoverageAmount:   100000,
+		Status:           models.PolicyStatusActive,
+		PaymentFrequency: "annually",
+		EffectiveDate:    now.AddDate(0, -6, 0),
+		ExpirationDate:   now.AddDate(0, 6, 0),
+	}
+	policyStore := newFakePolicyStore(policy)
+	// The engine gets its own policy store so the multi-policy discount does not apply.
+	pricing := NewPricingEngineService(log, configManager, newFakeProductStore(product), newFakePolicyStore(), newFakeClaimStore(), newFakeUserStore(user))
+
+	current, err := pricing.CalculatePremium(ctx, &PricingRequest{
+		ProductID:        policy.ProductID,
+		UserID:           policy.UserID,
+		CoverageAmount:   policy.CoverageAmount,
+		Currency:         policy.Currency,
+		PaymentFrequency: policy.PaymentFrequency,
+		EffectiveDate:    policy.EffectiveDate,
+		ExpirationDate:   policy.ExpirationDate,
+	})
+	require.NoError(t, err)
+	policy.Premium = roundCurrency(current.FinalPremium)
+
+	endorsementStore := &fakeEndorsementStore{policies: policyStore}
+	svc := NewPolicyLifecycleService(log, configManager, policyStore, nil, nil, nil, endorsementStore, pricing, nil, nil, nil, nil, nil)
+	return svc, endorsementStore, policy
+}
+
+func TestEndorsePolicyCoverageIncreaseChargesAdditionalPremium(t *testing.T) {
+	ctx := context.Background()
+	svc, endorsementStore, policy := newEndorsementFixture(t)
+	previousPremium := policy.Premium
+
+	endorsement, err := svc.EndorsePolicy(ctx, policy.ID, &EndorsementChanges{CoverageAmount: 150000, Reason: "Home extension"})
+	require.NoError(t, err)
+
+	assert.Equal(t, models.EndorsementAdditionalPremium, endorsement.AdjustmentType)
+	assert.Greater(t, endorsement.ProratedAmount, 0.0)
+	assert.Less(t, endorsement.ProratedAmount, endorsement.NewPremium-previousPremium)
+	assert.InDelta(t, (endorsement.NewPremium-previousPremium)*remainingTermRatio(policy, endorsement.EffectiveDate), endorsement.ProratedAmount, 0.01)
+	assert.Equal(t, 150000.0, policy.CoverageAmount)
+	assert.Equal(t, endorsement.NewPremium, policy.Premium)
+
+	recorded, err := endorsementStore.ListEndorsementsByPolicy(ctx, policy.ID)
+	require.NoError(t, err)
+	assert.Len(t, recorded, 1)
+}
+
+func TestEndorsePolicyCoverageDecreaseCreditsReturnPremium(t *testing.T) {
+	ctx := context.Background()
+	svc, _, policy := newEndorsementFixture(t)
+	previousPremium := policy.Premium
+
+	endorsement, err := svc.EndorsePolicy(ctx, policy.ID, &EndorsementChanges{CoverageAmount: 50000})
+	require.NoError(t, err)
+
+	assert.Equal(t, models.EndorsementReturnPremium, endorsement.AdjustmentType)
+	assert.Less(t, endorsement.ProratedAmount, 0.0)
+	assert.Greater(t, endorsement.ProratedAmount, endorsement.NewPremium-previousPremium)
+	assert.Less(t, policy.Premium, previousPremium)
+}
+
+func TestEndorsePolicyPricesInPolicyJurisdiction(t *testing.T) {
+	ctx := context.Background()
+	svc, _, policy := newEndorsementFixture(t)
+	// A January start is winter in the northern hemisphere and summer in the southern one.
+	policy.EffectiveDate = time.Date(time.Now().Year()-1, time.January, 15, 0, 0, 0, 0, time.UTC)
+	policy.Jurisdiction = "ZA"
+
+	request := &PricingRequest{
+		ProductID:        policy.ProductID,
+		UserID:           policy.UserID,
+		CoverageAmount:   150000,
+		Currency:         policy.Currency,
+		PaymentFrequency: policy.PaymentFrequency,
+		EffectiveDate:    policy.EffectiveDate,
+		ExpirationDate:   policy.ExpirationDate,
+	}
+	northern, err := svc.pricingService.CalculatePremium(ctx, request)
+	require.NoError(t, err)
+	request.Jurisdiction = policy.Jurisdiction
+	southern, err := svc.pricingService.CalculatePremium(ctx, request)
+	require.NoError(t, err)
+	require.NotEqual(t, northern.FinalPremium, southern.FinalPremium)
+
+	endorsement, err := svc.EndorsePolicy(ctx, policy.ID, &EndorsementChanges{CoverageAmount: 150000})
+	require.NoError(t, err)
+	assert.InDelta(t, southern.FinalPremium, endorsement.NewPremium, 0.01)
+}
@@ -2,34 +2,65 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
+	"github.com/edsonmichaque/bazaruto/internal/events"
 	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/edsonmichaque/bazaruto/internal/store"
 	"github.com/edsonmichaque/bazaruto/pkg/event"
+	"github.com/edsonmichaque/bazaruto/pkg/job"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
+// replayBatchSize is the number of persisted events read per page during a replay.
+const replayBatchSize = 100
+
 // EventService handles event publishing and subscription management.
 type EventService struct {
-	eventBus event.EventBus
-	logger   *logger.Logger
+	eventBus   event.EventBus
+	eventStore store.EventStore
+	logger     *logger.Logger
+	dispatcher *job.Dispatcher
+	schemas    *events.SchemaRegistry
 }
 
 // NewEventService creates a new event service.
-func NewEventService(eventBus event.EventBus, logger *logger.Logger) *EventService {
+// The event store is optional; without it events are not persisted and cannot be replayed.
+func NewEventService(eventBus event.EventBus, logger *logger.Logger, eventStore ...store.EventStore) *EventService {
+	var evtStore store.EventStore
+	if len(eventStore) > 0 {
+		evtStore = eventStore[0]
+	}
 	return &EventService{
-		eventBus: eventBus,
-		logger:   logger,
+		eventBus:   eventBus,
+		eventStore: evtStore,
+		logger:     logger,
 	}
 }
 
-// PublishEvent publishes an event to the event bus asynchronously.
+// PublishEvent records the event in the event log and publishes it to the event bus.
 func (s *EventService) PublishEvent(ctx context.Context, event event.Event) error {
 	s.logger.Info("Publishing event",
 		zap.String("event_type", event.Type()),
 		zap.String("event_id", event.ID().String()),
 		zap.String("aggregate_id", event.AggregateID().String()))
 
-	// Publish asynchronously - the event bus handles this
+	// Persist the event before delivery so the log is a superset of what handlers saw.
+	// A persistence failure must not block delivery, so it is only logged.
+	if s.eventStore != nil {
+		if err := s.eventStore.CreateEvent(ctx, newEventRecord(event)); err != nil {
+			s.logger.Error("Failed to persist event",
+				zap.Error(err),
+				zap.String("event_type", event.Type()),
+				zap.String("event_id", event.ID().String()))
+		}
+	}
+
+	// The event bus hands asynchronous subscriptions off and delivers synchronous ones
 	if err := s.eventBus.Publish(ctx, event); err != nil {
 		s.logger.Error("Failed to publish event",
 			zap.Error(err),
@@ -41,9 +72,53 @@ func (s *EventService) PublishEvent(ctx context.Context, event event.Event) erro
 	return nil
 }
 
-// SubscribeHandler subscribes an event handler to specific event types.
+// PublishBatch records a batch of events in the event log atomically and then publishes
+// each to the event bus. Unlike PublishEvent, a persistence failure aborts the batch:
+// no event is stored or delivered. Delivery failures after the batch is stored are
+// logged and returned together.
+func (s *EventService) PublishBatch(ctx context.Context, events []event.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	s.logger.Info("Publishing event batch", zap.Int("count", len(events)))
+
+	if s.eventStore != nil {
+		records := make([]*models.EventRecord, len(events))
+		for i, e := range events {
+			records[i] = newEventRecord(e)
+		}
+		if err := s.eventStore.CreateEvents(ctx, records); err != nil {
+			s.logger.Error("Failed to persist event batch",
+				zap.Error(err),
+				zap.Int("count", len(events)))
+			return err
+		}
+	}
+
+	var errs []error
+	for _, e := range events {
+		if err := s.eventBus.Publish(ctx, e); err != nil {
+			s.logger.Error("Failed to publish event",
+				zap.Error(err),
+				zap.String("event_type", e.Type()),
+				zap.String("event_id", e.ID().String()))
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// SubscribeHandler subscribes an event handler to specific event types with asynchronous delivery.
 func (s *EventService) SubscribeHandler(handler event.EventHandler, eventTypes ...string) error {
-	if err := s.eventBus.Subscribe(handler, eventTypes...); err != nil {
+	return s.SubscribeHandlerWithMode(handler, event.DeliveryModeAsync, eventTypes...)
+}
+
+// SubscribeHandlerWithMode subscribes an event handler to specific event types. Handlers
+// subscribed with event.DeliveryModeSync run before PublishEvent returns.
+func (s *EventService) SubscribeHandlerWithMode(handler event.EventHandler, mode event.DeliveryMode, eventTypes ...string) error {
+	if err := s.eventBus.SubscribeWithMode(handler, mode, eventTypes...); err != nil {
 		s.logger.Error("Failed to subscribe event handler",
 			zap.Error(err),
 			zap.String("handler_name", handler.HandlerName()),
@@ -53,6 +128,7 @@ func (s *EventService) SubscribeHandler(handler event.EventHandler, eventTypes .
 
 	s.logger.Info("Event handler subscribed",
 		zap.String("handler_name", handler.HandlerName()),
+		zap.String("delivery_mode", string(mode)),
 		zap.Strings("event_types", eventTypes))
 
 	return nil
@@ -73,6 +149,165 @@ func (s *EventService) UnsubscribeHandler(handlerName string) error {
 	return nil
 }
 
+// GetEventLog retrieves the persisted events of an aggregate, oldest first.
+// An empty eventType returns events of all types.
+func (s *EventService) GetEventLog(ctx context.Context, aggregateID uuid.UUID, eventType string, opts *models.ListOptions) (*models.ListResponse[*models.EventRecord], error) {
+	if s.eventStore == nil {
+		return nil, fmt.Errorf("event persistence is not configured")
+	}
+	if aggregateID == uuid.Nil {
+		return nil, fmt.Errorf("aggregate ID is required")
+	}
+
+	if opts == nil {
+		opts = models.DefaultListOptions()
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid list options: %w", err)
+	}
+
+	records, err := s.eventStore.ListEventsByAggregate(ctx, aggregateID, eventType, opts.GetLimit(), opts.GetOffset())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	total, err := s.eventStore.CountEventsByAggregate(ctx, aggregateID, eventType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count events: %w", err)
+	}
+
+	return models.NewListResponse(records, total, opts), nil
+}
+
+// ReplayResult summarizes the outcome of an event replay.
+type ReplayResult struct {
+	From       time.Time `json:"from"`
+	To         time.Time `json:"to"`
+	EventTypes []string  `json:"event_types"`
+	Delivered  int       `json:"delivered"`
+	Skipped    int       `json:"skipped"`
+	Failed     int       `json:"failed"`
+}
+
+// Replay re-delivers persisted events that occurred within [from, to) to the given handler.
+// An empty eventTypes slice replays all event types. Replayed events keep their original
+// ID and carry the event.MetadataReplayed marker so idempotent handlers can dedup them.
+// Delivery is synchronous; a handler error is logged and counted without aborting the replay.
+func (s *EventService) Replay(ctx context.Context, from, to time.Time, eventTypes []string, handler event.EventHandler) (*ReplayResult, error) {
+	if s.eventStore == nil {
+		return nil, fmt.Errorf("event persistence is not configured")
+	}
+	if handler == nil {
+		return nil, fmt.Errorf("replay handler is required")
+	}
+	if !to.After(from) {
+		return nil, fmt.Errorf("replay window end must be after start")
+	}
+
+	result := &ReplayResult{
+		From:       from,
+		To:         to,
+		EventTypes: eventTypes,
+	}
+	replayedAt := time.Now()
+
+	s.logger.Info("Replaying events",
+		zap.Time("from", from),
+		zap.Time("to", to),
+		zap.Strings("event_types", eventTypes),
+		zap.String("handler_name", handler.HandlerName()))
+
+	var after *models.EventRecord
+	for {
+		records, err := s.eventStore.ListEventsInRange(ctx, from, to, eventTypes, after, replayBatchSize)
+		if err != nil {
+			return result, fmt.Errorf("failed to load events for replay: %w", err)
+		}
+
+		for _, record := range records {
+			if err := ctx.Err(); err != nil {
+				return result, err
+			}
+
+			if !handler.CanHandle(record.EventType) {
+				result.Skipped++
+				continue
+			}
+
+			if err := handler.Handle(ctx, newReplayedEvent(record, replayedAt)); err != nil {
+				s.logger.Error("Failed to replay event",
+					zap.Error(err),
+					zap.String("event_type", record.EventType),
+					zap.String("event_id", record.EventID.String()),
+					zap.String("handler_name", handler.HandlerName()))
+				result.Failed++
+				continue
+			}
+			result.Delivered++
+		}
+
+		if len(records) < replayBatchSize {
+			break
+		}
+		after = records[len(records)-1]
+	}
+
+	s.logger.Info("Event replay completed",
+		zap.String("handler_name", handler.HandlerName()),
+		zap.Int("delivered", result.Delivered),
+		zap.Int("skipped", result.Skipped),
+		zap.Int("failed", result.Failed))
+
+	return result, nil
+}
+
+// RecordDeadLetter persists a delivery that exhausted its retries so the event is not
+// lost. It is installed as the event bus dead-letter handler.
+func (s *EventService) RecordDeadLetter(ctx context.Context, failure event.DeliveryFailure) {
+	s.logger.Error("Event delivery failed permanently",
+		zap.Error(failure.Err),
+		zap.String("handler_name", failure.HandlerName),
+		zap.String("event_type", failure.Event.Type()),
+		zap.String("event_id", failure.Event.ID().String()),
+		zap.Int("attempts", failure.Attempts))
+
+	if s.eventStore == nil {
+		return
+	}
+
+	deadLetter := &models.EventDeadLetter{
+		HandlerName:   failure.HandlerName,
+		EventID:       failure.Event.ID(),
+		EventType:     failure.Event.Type(),
+		AggregateID:   failure.Event.AggregateID(),
+		AggregateType: failure.Event.AggregateType(),
+		Version:       failure.Event.Version(),
+		OccurredAt:    failure.Event.OccurredAt(),
+		Data:          failure.Event.Data(),
+		Metadata:      failure.Event.Metadata(),
+		Attempts:      failure.Attempts,
+		FailedAt:      failure.FailedAt,
+	}
+	if failure.Err != nil {
+		deadLetter.Error = failure.Err.Error()
+	}
+	if err := s.eventStore.CreateDeadLetter(ctx, deadLetter); err != nil {
+		s.logger.Error("Failed to record event dead letter",
+			zap.Error(err),
+			zap.String("handler_name", failure.HandlerName),
+			zap.String("event_id", failure.Event.ID().String()))
+	}
+}
+
+// ListDeadLetters retrieves events a handler failed to process, most recent first.
+// An empty handlerName lists them for all handlers.
+func (s *EventService) ListDeadLetters(ctx context.Context, handlerName string, limit, offset int) ([]*models.EventDeadLetter, error) {
+	if s.eventStore == nil {
+		return nil, fmt.Errorf("event persistence is not configured")
+	}
+	return s.eventStore.ListDeadLetters(ctx, handlerName, limit, offset)
+}
+
 // Close closes the event service and cleans up resources.
 func (s *EventService) Close() error {
 	if err := s.eventBus.Close(); err != nil {
@@ -84,3 +319,78 @@ func (s *EventService) Close() error {
 	s.logger.Info("Event service closed")
 	return nil
 }
+
+// newEventRecord converts a published event into its event log representation.
+func newEventRecord(e event.Event) *models.EventRecord {
+	return &models.EventRecord{
+		EventID:       e.ID(),
+		EventType:     e.Type(),
+		AggregateID:   e.AggregateID(),
+		AggregateType: e.AggregateType(),
+		Version:       e.Version(),
+		OccurredAt:    e.OccurredAt(),
+		Data:          e.Data(),
+		Metadata:      e.Metadata(),
+	}
+}
+
+// replayedEvent rebuilds an event.Event from a persisted event record.
+type replayedEvent struct {
+	record   *models.EventRecord
+	metadata map[string]interface{}
+}
+
+// newReplayedEvent wraps a persisted record and marks it as replayed.
+func newReplayedEvent(record *models.EventRecord, replayedAt time.Time) *replayedEvent {
+	metadata := make(map[string]interface{}, len(record.Metadata)+2)
+	for k, v := range record.Metadata {
+		metadata[k] = v
+	}
+	metadata[event.MetadataReplayed] = true
+	metadata[event.MetadataReplayedAt] = replayedAt
+
+	return &replayedEvent{
+		record:   record,
+		metadata: metadata,
+	}
+}
+
+// ID returns the original event ID so handlers can dedup replays.
+func (e *replayedEvent) ID() uuid.UUID {
+	return e.record.EventID
+}
+
+// Type returns the event type name.
+func (e *replayedEvent) Type() string {
+	return e.record.EventType
+}
+
+// AggregateID returns the ID of the aggregate that generated this event.
+func (e *replayedEvent) AggregateID() uuid.UUID {
+	return e.record.AggregateID
+}
+
+// AggregateType returns the type of the aggregate.
+func (e *replayedEvent) AggregateType() string {
+	return e.record.AggregateType
+}
+
+// Version returns the version of the aggregate when this event occurred.
+func (e *replayedEvent) Version() int {
+	return e.record.Version
+}
+
+// OccurredAt returns when the original event occurred.
+func (e *replayedEvent) OccurredAt() time.Time {
+	return e.record.OccurredAt
+}
+
+// Data returns the persisted event payload.
+func (e *replayedEvent) Data() map[string]interface{} {
+	return e.record.Data
+}
+
+// Metadata returns the persisted metadata plus the replay marker.
+func (e *replayedEvent) Metadata() map[string]interface{} {
+	return e.metadata
+}
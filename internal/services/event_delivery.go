@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/events"
+	"github.com/edsonmichaque/bazaruto/pkg/event"
+	"github.com/edsonmichaque/bazaruto/pkg/job"
+)
+
+// eventDeliverer is implemented by event buses that can deliver an event to one of their
+// subscribed handlers by name.
+type eventDeliverer interface {
+	Deliver(ctx context.Context, handlerName string, e event.Event) error
+}
+
+// SetJobDispatcher makes asynchronous handler deliveries run as jobs, so they are retried
+// by the job workers and survive a restart instead of running in a goroutine of the
+// publisher. Events are serialized for the job with the schema registry; events it cannot
+// encode are still delivered in the background of the publishing process.
+func (s *EventService) SetJobDispatcher(dispatcher job.Dispatcher, schemas *events.SchemaRegistry) {
+	s.dispatcher = &dispatcher
+	s.schemas = schemas
+}
+
+// EnqueueDelivery dispatches a job that delivers an event to the named handler. It is
+// installed as the event bus async delivery function.
+func (s *EventService) EnqueueDelivery(ctx context.Context, handlerName string, e event.Event) error {
+	if s.dispatcher == nil || s.schemas == nil {
+		return fmt.Errorf("event delivery jobs are not configured")
+	}
+	businessEvent, ok := e.(events.BusinessEvent)
+	if !ok {
+		return fmt.Errorf("event %s has no schema", e.Type())
+	}
+
+	payload, err := s.schemas.Encode(businessEvent)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	return s.dispatcher.PerformWithContext(ctx, &EventDeliveryJob{
+		HandlerName: handlerName,
+		Event:       payload,
+	})
+}
+
+// deliver decodes an event enqueued by EnqueueDelivery and delivers it to the named handler.
+func (s *EventService) deliver(ctx context.Context, handlerName string, payload []byte) error {
+	if s.schemas == nil {
+		return fmt.Errorf("event delivery jobs are not configured")
+	}
+	deliverer, ok := s.eventBus.(eventDeliverer)
+	if !ok {
+		return fmt.Errorf("event bus does not support delivery to a single handler")
+	}
+
+	var envelope events.Envelope
+	if err := json.Unmarshal(payload, &envelope); err != nil {
+		return fmt.Errorf("failed to decode event envelope: %w", err)
+	}
+	e, err := s.schemas.Decode(payload, envelope.SchemaVersion)
+	if err != nil {
+		return err
+	}
+	return deliverer.Deliver(ctx, handlerName, e)
+}
+
+// EventDeliveryJob delivers a published event to one asynchronously subscribed handler.
+// Handler failures are retried and dead-lettered by the event bus, so the job itself only
+// fails when the event cannot be decoded or the handler is not subscribed.
+type EventDeliveryJob struct {
+	HandlerName string          `json:"handler_name"`
+	Event       json.RawMessage `json:"event"` // Schema registry envelope
+	Service     *EventService   `json:"-"`     // Injected dependency
+	Attempts    int             `json:"attempts"`
+}
+
+// Perform delivers the event to the handler.
+func (j *EventDeliveryJob) Perform(ctx context.Context) error {
+	if j.Service == nil {
+		return fmt.Errorf("event service is not configured")
+	}
+	return j.Service.deliver(ctx, j.HandlerName, j.Event)
+}
+
+// EventDeliveryJob interface methods
+func (j *EventDeliveryJob) Queue() string               { return job.QueueProcessing }
+func (j *EventDeliveryJob) MaxRetries() int             { return 1 }
+func (j *EventDeliveryJob) RetryBackoff() time.Duration { return time.Second }
+func (j *EventDeliveryJob) Priority() int               { return job.DefaultPriority }
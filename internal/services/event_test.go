@@ -0,0 +1,259 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/events"
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/edsonmichaque/bazaruto/internal/store"
+	"github.com/edsonmichaque/bazaruto/pkg/event"
+	"github.com/edsonmichaque/bazaruto/pkg/job"
+	"github.com/edsonmichaque/bazaruto/pkg/job/adapter"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// recordingHandler collects every event delivered to it.
+type recordingHandler struct {
+	mu     sync.Mutex
+	events []event.Event
+}
+
+func (h *recordingHandler) Handle(ctx context.Context, e event.Event) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = append(h.events, e)
+	return nil
+}
+
+func (h *recordingHandler) CanHandle(eventType string) bool { return true }
+
+func (h *recordingHandler) HandlerName() string { return "recording_handler" }
+
+func newTestEventStore(t *testing.T) store.EventStore {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	// A single connection keeps every query on the same in-memory database, including
+	// dead letters written from delivery goroutines.
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	require.NoError(t, db.AutoMigrate(&models.EventRecord{}, &models.EventDeadLetter{}))
+	return store.NewEventStore(db)
+}
+
+func TestEventServiceReplay(t *testing.T) {
+	ctx := context.Background()
+	eventStore := newTestEventStore(t)
+	svc := NewEventService(event.NewBus(), logger.NewLogger("error", "json"), eventStore)
+
+	base := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	seed := []struct {
+		eventType  string
+		occurredAt time.Time
+	}{
+		{"policy.created", base.Add(-time.Hour)},      // before window
+		{"policy.created", base},                      // in window
+		{"claim.submitted", base.Add(time.Hour)},      // in window, other type
+		{"policy.renewed", base.Add(2 * time.Hour)},   // in window
+		{"policy.created", base.Add(24 * time.Hour)},  // after window
+		{"policy.cancelled", base.Add(3 * time.Hour)}, // in window, not requested
+	}
+
+	ids := make(map[string]uuid.UUID)
+	for _, s := range seed {
+		record := &models.EventRecord{
+			EventID:     uuid.New(),
+			EventType:   s.eventType,
+			AggregateID: uuid.New(),
+			OccurredAt:  s.occurredAt,
+			Data:        map[string]interface{}{"type": s.eventType},
+		}
+		require.NoError(t, eventStore.CreateEvent(ctx, record))
+		ids[s.eventType+s.occurredAt.String()] = record.EventID
+	}
+
+	handler := &recordingHandler{}
+	result, err := svc.Replay(ctx, base, base.Add(12*time.Hour), []string{"policy.created", "policy.renewed"}, handler)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, result.Delivered)
+	require.Len(t, handler.events, 2)
+
+	assert.Equal(t, "policy.created", handler.events[0].Type())
+	assert.Equal(t, ids["policy.created"+base.String()], handler.events[0].ID())
+	assert.Equal(t, "policy.renewed", handler.events[1].Type())
+
+	for _, e := range handler.events {
+		assert.True(t, event.IsReplayed(e))
+		assert.False(t, e.OccurredAt().Before(base))
+	}
+}
+
+func TestEventServiceReplayDeliversEventsSharingATimestampOnce(t *testing.T) {
+	ctx := context.Background()
+	eventStore := newTestEventStore(t)
+	svc := NewEventService(event.NewBus(), logger.NewLogger("error", "json"), eventStore)
+
+	occurredAt := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	seeded := make([]uuid.UUID, 0, 2*replayBatchSize+1)
+	for i := 0; i < 2*replayBatchSize+1; i++ {
+		record := &models.EventRecord{
+			EventID:     uuid.New(),
+			EventType:   "policy.created",
+			AggregateID: uuid.New(),
+			OccurredAt:  occurredAt,
+		}
+		require.NoError(t, eventStore.CreateEvent(ctx, record))
+		seeded = append(seeded, record.EventID)
+	}
+
+	handler := &recordingHandler{}
+	result, err := svc.Replay(ctx, occurredAt, occurredAt.Add(time.Hour), nil, handler)
+	require.NoError(t, err)
+	assert.Equal(t, len(seeded), result.Delivered)
+
+	var delivered []uuid.UUID
+	for _, e := range handler.events {
+		delivered = append(delivered, e.ID())
+	}
+	assert.ElementsMatch(t, seeded, delivered)
+}
+
+func TestEventServiceReplayRequiresStore(t *testing.T) {
+	svc := NewEventService(event.NewBus(), logger.NewLogger("error", "json"))
+
+	_, err := svc.Replay(context.Background(), time.Now().Add(-time.Hour), time.Now(), nil, &recordingHandler{})
+	assert.Error(t, err)
+}
+
+func TestEventServicePublishPersistsEvent(t *testing.T) {
+	ctx := context.Background()
+	eventStore := newTestEventStore(t)
+	svc := NewEventService(event.NewBus(), logger.NewLogger("error", "json"), eventStore)
+
+	policyID := uuid.New()
+	now := time.Now()
+	published := events.NewPolicyCreatedEvent(policyID, uuid.New(), uuid.New(), uuid.New(), 1200, "USD", "monthly", "MZ", now, now.AddDate(1, 0, 0), now)
+	require.NoError(t, svc.PublishEvent(ctx, published))
+
+	log, err := svc.GetEventLog(ctx, policyID, "policy.created", nil)
+	require.NoError(t, err)
+	require.Len(t, log.Items, 1)
+
+	record := log.Items[0]
+	assert.Equal(t, published.ID(), record.EventID)
+	assert.Equal(t, policyID, record.AggregateID)
+	assert.Equal(t, "policy", record.AggregateType)
+	assert.Equal(t, int64(1), log.Total)
+}
+
+func TestEventServicePublishBatchIsAllOrNothing(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	newBatch := func() []event.Event {
+		batch := make([]event.Event, 100)
+		for i := range batch {
+			batch[i] = events.NewPolicyCreatedEvent(uuid.New(), uuid.New(), uuid.New(), uuid.New(), 1200, "USD", "monthly", "MZ", now, now.AddDate(1, 0, 0), now)
+		}
+		return batch
+	}
+	countEvents := func(eventStore store.EventStore) int {
+		records, err := eventStore.ListEventsInRange(ctx, now.Add(-time.Hour), now.Add(time.Hour), nil, nil, 1000)
+		require.NoError(t, err)
+		return len(records)
+	}
+
+	t.Run("stores and delivers the whole batch", func(t *testing.T) {
+		eventStore := newTestEventStore(t)
+		bus := event.NewBus()
+		handler := &recordingHandler{}
+		require.NoError(t, bus.Subscribe(handler, "policy.created"))
+		svc := NewEventService(bus, logger.NewLogger("error", "json"), eventStore)
+
+		require.NoError(t, svc.PublishBatch(ctx, newBatch()))
+		assert.Equal(t, 100, countEvents(eventStore))
+		assert.Eventually(t, func() bool {
+			handler.mu.Lock()
+			defer handler.mu.Unlock()
+			return len(handler.events) == 100
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("rolls back the batch on a mid-batch failure", func(t *testing.T) {
+		eventStore := newTestEventStore(t)
+		svc := NewEventService(event.NewBus(), logger.NewLogger("error", "json"), eventStore)
+
+		// A duplicate event ID in the second insert chunk violates the unique index.
+		batch := newBatch()
+		batch[75].(*events.PolicyCreatedEvent).EventID = batch[10].ID()
+
+		assert.Error(t, svc.PublishBatch(ctx, batch))
+		assert.Zero(t, countEvents(eventStore))
+	})
+}
+
+func TestRecordDeadLetterPersistsFailedDelivery(t *testing.T) {
+	ctx := context.Background()
+	svc := NewEventService(event.NewBus(), logger.NewLogger("error", "json"), newTestEventStore(t))
+
+	failed := event.NewBaseEvent(events.EventTypePolicyCreated, "policy", uuid.New(), 1, map[string]interface{}{"premium": 1000.0})
+	svc.RecordDeadLetter(ctx, event.DeliveryFailure{
+		HandlerName: "failing_handler",
+		Event:       failed,
+		Err:         errors.New("handler exploded"),
+		Attempts:    2,
+		FailedAt:    time.Now(),
+	})
+
+	deadLetters, err := svc.ListDeadLetters(ctx, "failing_handler", 0, 0)
+	require.NoError(t, err)
+	require.Len(t, deadLetters, 1)
+	deadLetter := deadLetters[0]
+	assert.Equal(t, failed.ID(), deadLetter.EventID)
+	assert.Equal(t, events.EventTypePolicyCreated, deadLetter.EventType)
+	assert.Equal(t, 1000.0, deadLetter.Data["premium"])
+	assert.Equal(t, 2, deadLetter.Attempts)
+	assert.Contains(t, deadLetter.Error, "handler exploded")
+}
+
+func TestAsyncDeliveryRunsAsJob(t *testing.T) {
+	ctx := context.Background()
+	bus := event.NewBus()
+	svc := NewEventService(bus, logger.NewLogger("error", "json"))
+
+	memory := adapter.NewMemoryAdapter()
+	registry := job.NewRegistry()
+	registry.Register("eventdeliveryjob", func() job.Job {
+		return &EventDeliveryJob{Service: svc}
+	})
+	svc.SetJobDispatcher(*job.NewDispatcher(memory, registry), events.NewDefaultSchemaRegistry())
+	bus.SetAsyncDelivery(svc.EnqueueDelivery)
+
+	recording := &recordingHandler{}
+	require.NoError(t, svc.SubscribeHandler(recording, events.EventTypeUserRegistered))
+
+	published := events.NewUserRegisteredEvent(uuid.New(), "ana@example.com", "Ana Silva", "customer")
+	require.NoError(t, svc.PublishEvent(ctx, published))
+	assert.Empty(t, recording.events, "the delivery must wait for a worker")
+
+	serialized, err := memory.Dequeue(ctx, job.QueueProcessing)
+	require.NoError(t, err, "delivery job was not dispatched")
+	deliveryJob, err := registry.Deserialize(serialized)
+	require.NoError(t, err)
+	require.NoError(t, deliveryJob.Perform(ctx))
+
+	require.Len(t, recording.events, 1)
+	delivered, ok := recording.events[0].(*events.UserRegisteredEvent)
+	require.True(t, ok, "handlers receive the concrete event type")
+	assert.Equal(t, published.ID(), delivered.ID())
+	assert.Equal(t, "ana@example.com", delivered.Email)
+}
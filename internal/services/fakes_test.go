@@ -0,0 +1,1311 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/edsonmichaque/bazaruto/internal/store"
+	"github.com/google/uuid"
+)
+
+// fakeClaimStore is an in-memory ClaimStore for service tests.
+// Methods not overridden panic through the nil embedded interface.
+type fakeClaimStore struct {
+	store.ClaimStore
+	mu     sync.Mutex
+	claims map[uuid.UUID]*models.Claim
+}
+
+func newFakeClaimStore(claims ...*models.Claim) *fakeClaimStore {
+	s := &fakeClaimStore{claims: make(map[uuid.UUID]*models.Claim)}
+	for _, claim := range claims {
+		if claim.ID == uuid.Nil {
+			claim.ID = uuid.New()
+		}
+		s.claims[claim.ID] = claim
+	}
+	return s
+}
+
+func (s *fakeClaimStore) GetClaim(ctx context.Context, id uuid.UUID) (*models.Claim, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	claim, ok := s.claims[id]
+	if !ok {
+		return nil, fmt.Errorf("claim not found")
+	}
+	return claim, nil
+}
+
+func (s *fakeClaimStore) CreateClaim(ctx context.Context, claim *models.Claim) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if claim.ID == uuid.Nil {
+		claim.ID = uuid.New()
+	}
+	s.claims[claim.ID] = claim
+	return nil
+}
+
+func (s *fakeClaimStore) UpdateClaim(ctx context.Context, claim *models.Claim) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.claims[claim.ID] = claim
+	return nil
+}
+
+func (s *fakeClaimStore) ListClaims(ctx context.Context, userID *uuid.UUID, policyID *uuid.UUID, status string, limit, offset int) ([]*models.Claim, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var claims []*models.Claim
+	for _, claim := range s.claims {
+		if userID != nil && claim.UserID != *userID {
+			continue
+		}
+		if policyID != nil && claim.PolicyID != *policyID {
+			continue
+		}
+		if status != "" && claim.Status != status {
+			continue
+		}
+		claims = append(claims, claim)
+	}
+	return claims, nil
+}
+
+func (s *fakeClaimStore) List(ctx context.Context, filter *models.ClaimFilter, page *models.CursorPage) (*models.ClaimPage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result := &models.ClaimPage{}
+	for _, claim := range s.claims {
+		if filter.UserID != nil && claim.UserID != *filter.UserID {
+			continue
+		}
+		if filter.PolicyID != nil && claim.PolicyID != *filter.PolicyID {
+			continue
+		}
+		if len(filter.Statuses) > 0 && !slices.Contains(filter.Statuses, claim.Status) {
+			continue
+		}
+		if filter.ReportedFrom != nil && claim.ReportedDate.Before(*filter.ReportedFrom) {
+			continue
+		}
+		if filter.ReportedTo != nil && claim.ReportedDate.After(*filter.ReportedTo) {
+			continue
+		}
+		result.Claims = append(result.Claims, claim)
+	}
+	return result, nil
+}
+
+func (s *fakeClaimStore) CountUsersByDevice(ctx context.Context, deviceFingerprint string, excludeUserID uuid.UUID) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	users := make(map[uuid.UUID]bool)
+	for _, claim := range s.claims {
+		if claim.DeviceFingerprint == deviceFingerprint && claim.UserID != excludeUserID {
+			users[claim.UserID] = true
+		}
+	}
+	return int64(len(users)), nil
+}
+
+// fakeClaimReserveStore is an in-memory ClaimReserveStore for service tests.
+type fakeClaimReserveStore struct {
+	mu       sync.Mutex
+	reserves map[uuid.UUID]*models.ClaimReserve
+}
+
+func newFakeClaimReserveStore() *fakeClaimReserveStore {
+	return &fakeClaimReserveStore{reserves: make(map[uuid.UUID]*models.ClaimReserve)}
+}
+
+func (s *fakeClaimReserveStore) CreateReserve(ctx context.Context, reserve *models.ClaimReserve) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if reserve.ID == uuid.Nil {
+		reserve.ID = uuid.New()
+	}
+	s.reserves[reserve.ClaimID] = reserve
+	return nil
+}
+
+func (s *fakeClaimReserveStore) GetReserveByClaimID(ctx context.Context, claimID uuid.UUID) (*models.ClaimReserve, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	reserve, ok := s.reserves[claimID]
+	if !ok {
+		return nil, fmt.Errorf("claim reserve %w", store.ErrNotFound)
+	}
+	return reserve, nil
+}
+
+func (s *fakeClaimReserveStore) UpdateReserve(ctx context.Context, reserve *models.ClaimReserve) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reserves[reserve.ClaimID] = reserve
+	return nil
+}
+
+// fakeClaimRecoveryStore is an in-memory ClaimRecoveryStore for service tests.
+type fakeClaimRecoveryStore struct {
+	mu         sync.Mutex
+	recoveries map[uuid.UUID]*models.ClaimRecovery
+}
+
+func newFakeClaimRecoveryStore() *fakeClaimRecoveryStore {
+	return &fakeClaimRecoveryStore{recoveries: make(map[uuid.UUID]*models.ClaimRecovery)}
+}
+
+func (s *fakeClaimRecoveryStore) CreateRecovery(ctx context.Context, recovery *models.ClaimRecovery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if recovery.ID == uuid.Nil {
+		recovery.ID = uuid.New()
+	}
+	s.recoveries[recovery.ID] = recovery
+	return nil
+}
+
+func (s *fakeClaimRecoveryStore) GetRecovery(ctx context.Context, id uuid.UUID) (*models.ClaimRecovery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	recovery, ok := s.recoveries[id]
+	if !ok {
+		return nil, fmt.Errorf("claim recovery not found")
+	}
+	return recovery, nil
+}
+
+func (s *fakeClaimRecoveryStore) ListRecoveriesByClaim(ctx context.Context, claimID uuid.UUID) ([]*models.ClaimRecovery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var recoveries []*models.ClaimRecovery
+	for _, recovery := range s.recoveries {
+		if recovery.ClaimID == claimID {
+			recoveries = append(recoveries, recovery)
+		}
+	}
+	return recoveries, nil
+}
+
+func (s *fakeClaimRecoveryStore) UpdateRecovery(ctx context.Context, recovery *models.ClaimRecovery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.recoveries[recovery.ID] = recovery
+	return nil
+}
+
+// fakePolicyStore is an in-memory PolicyStore for service tests.
+type fakePolicyStore struct {
+	store.PolicyStore
+	mu       sync.Mutex
+	policies map[uuid.UUID]*models.Policy
+	// invoices receives the invoices created with a policy.
+	invoices *fakeInvoiceStore
+}
+
+func newFakePolicyStore(policies ...*models.Policy) *fakePolicyStore {
+	s := &fakePolicyStore{policies: make(map[uuid.UUID]*models.Policy), invoices: newFakeInvoiceStore()}
+	for _, policy := range policies {
+		if policy.ID == uuid.Nil {
+			policy.ID = uuid.New()
+		}
+		s.policies[policy.ID] = policy
+	}
+	return s
+}
+
+func (s *fakePolicyStore) CreatePolicy(ctx context.Context, policy *models.Policy) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if policy.ID == uuid.Nil {
+		policy.ID = uuid.New()
+	}
+	s.policies[policy.ID] = policy
+	return nil
+}
+
+func (s *fakePolicyStore) CreatePolicyWithInvoice(ctx context.Context, policy *models.Policy, invoice *models.Invoice) error {
+	if err := s.CreatePolicy(ctx, policy); err != nil {
+		return err
+	}
+	invoice.PolicyID = &policy.ID
+	return s.invoices.CreateInvoice(ctx, invoice)
+}
+
+func (s *fakePolicyStore) GetPolicy(ctx context.Context, id uuid.UUID) (*models.Policy, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	policy, ok := s.policies[id]
+	if !ok {
+		return nil, fmt.Errorf("policy not found")
+	}
+	return policy, nil
+}
+
+func (s *fakePolicyStore) ListPolicies(ctx context.Context, userID *uuid.UUID, productID *uuid.UUID, status string, limit, offset int) ([]*models.Policy, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var policies []*models.Policy
+	for _, policy := range s.policies {
+		if productID != nil && policy.ProductID != *productID {
+			continue
+		}
+		policies = append(policies, policy)
+	}
+	sort.Slice(policies, func(i, j int) bool { return policies[i].ID.String() < policies[j].ID.String() })
+	if offset >= len(policies) {
+		return nil, nil
+	}
+	policies = policies[offset:]
+	if limit > 0 && len(policies) > limit {
+		policies = policies[:limit]
+	}
+	return policies, nil
+}
+
+func (s *fakePolicyStore) UpdatePolicy(ctx context.Context, policy *models.Policy) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.policies[policy.ID] = policy
+	return nil
+}
+
+func (s *fakePolicyStore) ListExpiredPolicies(ctx context.Context, asOf time.Time) ([]*models.Policy, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var policies []*models.Policy
+	for _, policy := range s.policies {
+		if policy.Status == models.PolicyStatusActive && policy.ExpirationDate.Before(asOf) {
+			policies = append(policies, policy)
+		}
+	}
+	return policies, nil
+}
+
+func (s *fakePolicyStore) ListPoliciesInTerm(ctx context.Context, productID *uuid.UUID, statuses []models.PolicyStatus, from, to time.Time, limit, offset int) ([]*models.Policy, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var policies []*models.Policy
+	for _, policy := range s.policies {
+		if productID != nil && policy.ProductID != *productID {
+			continue
+		}
+		if !slices.Contains(statuses, policy.Status) || !policy.EffectiveDate.Before(to) || !policy.ExpirationDate.After(from) {
+			continue
+		}
+		policies = append(policies, policy)
+	}
+	sort.Slice(policies, func(i, j int) bool { return policies[i].ID.String() < policies[j].ID.String() })
+	if offset >= len(policies) {
+		return nil, nil
+	}
+	policies = policies[offset:]
+	if limit > 0 && len(policies) > limit {
+		policies = policies[:limit]
+	}
+	return policies, nil
+}
+
+func (s *fakePolicyStore) ListPoliciesExpiringWithin(ctx context.Context, from, to time.Time, autoRenewOnly bool) ([]*models.Policy, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var policies []*models.Policy
+	for _, policy := range s.policies {
+		if policy.Status != models.PolicyStatusActive || (autoRenewOnly && !policy.AutoRenew) {
+			continue
+		}
+		if !policy.ExpirationDate.Before(from) && !policy.ExpirationDate.After(to) {
+			policies = append(policies, policy)
+		}
+	}
+	return policies, nil
+}
+
+func (s *fakePolicyStore) HasRenewal(ctx context.Context, policyID uuid.UUID) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, policy := range s.policies {
+		if policy.PreviousPolicyID != nil && *policy.PreviousPolicyID == policyID && policy.Status != models.PolicyStatusCancelled {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *fakePolicyStore) CountActiveByUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var count int64
+	for _, policy := range s.policies {
+		if policy.UserID == userID && policy.Status == models.PolicyStatusActive {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// fakeInvoiceStore is an in-memory InvoiceStore for service tests.
+type fakeInvoiceStore struct {
+	store.InvoiceStore
+	mu       sync.Mutex
+	invoices map[uuid.UUID]*models.Invoice
+}
+
+func newFakeInvoiceStore(invoices ...*models.Invoice) *fakeInvoiceStore {
+	s := &fakeInvoiceStore{invoices: make(map[uuid.UUID]*models.Invoice)}
+	for _, invoice := range invoices {
+		if invoice.ID == uuid.Nil {
+			invoice.ID = uuid.New()
+		}
+		s.invoices[invoice.ID] = invoice
+	}
+	return s
+}
+
+func (s *fakeInvoiceStore) CreateInvoice(ctx context.Context, invoice *models.Invoice) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if invoice.ID == uuid.Nil {
+		invoice.ID = uuid.New()
+	}
+	if invoice.CreatedAt.IsZero() {
+		invoice.CreatedAt = time.Now()
+	}
+	s.invoices[invoice.ID] = invoice
+	return nil
+}
+
+func (s *fakeInvoiceStore) GetInvoice(ctx context.Context, id uuid.UUID) (*models.Invoice, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	invoice, ok := s.invoices[id]
+	if !ok {
+		return nil, fmt.Errorf("invoice not found")
+	}
+	return invoice, nil
+}
+
+func (s *fakeInvoiceStore) UpdateInvoice(ctx context.Context, invoice *models.Invoice) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.invoices[invoice.ID] = invoice
+	return nil
+}
+
+func (s *fakeInvoiceStore) ListInvoices(ctx context.Context, userID *uuid.UUID, policyID *uuid.UUID, subscriptionID *uuid.UUID, status string, limit, offset int) ([]*models.Invoice, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var invoices []*models.Invoice
+	for _, invoice := range s.invoices {
+		if userID != nil && invoice.UserID != *userID {
+			continue
+		}
+		if policyID != nil && (invoice.PolicyID == nil || *invoice.PolicyID != *policyID) {
+			continue
+		}
+		if status != "" && invoice.Status != status {
+			continue
+		}
+		invoices = append(invoices, invoice)
+	}
+	return invoices, nil
+}
+
+func (s *fakeInvoiceStore) GetOverdue(ctx context.Context, asOf time.Time, limit, offset int) ([]*models.Invoice, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var invoices []*models.Invoice
+	for _, invoice := range s.invoices {
+		if invoice.Status == models.InvoiceStatusPaid || !invoice.DueDate.Before(asOf) {
+			continue
+		}
+		invoices = append(invoices, invoice)
+	}
+	sort.Slice(invoices, func(i, j int) bool { return invoices[i].DueDate.Before(invoices[j].DueDate) })
+	if offset >= len(invoices) {
+		return nil, nil
+	}
+	invoices = invoices[offset:]
+	if limit > 0 && len(invoices) > limit {
+		invoices = invoices[:limit]
+	}
+	return invoices, nil
+}
+
+func (s *fakeInvoiceStore) ListPolicyInvoicesPaid(ctx context.Context, from, to time.Time) ([]*models.Invoice, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var invoices []*models.Invoice
+	for _, invoice := range s.invoices {
+		if invoice.PolicyID == nil || invoice.Status != models.InvoiceStatusPaid || invoice.PaidAt == nil ||
+			invoice.PaidAt.Before(from) || !invoice.PaidAt.Before(to) {
+			continue
+		}
+		invoices = append(invoices, invoice)
+	}
+	sort.Slice(invoices, func(i, j int) bool { return invoices[i].PaidAt.Before(*invoices[j].PaidAt) })
+	return invoices, nil
+}
+
+// fakePremiumTaxReportStore is an in-memory PremiumTaxReportStore for service tests.
+type fakePremiumTaxReportStore struct {
+	store.PremiumTaxReportStore
+	mu      sync.Mutex
+	reports []*models.PremiumTaxReport
+}
+
+func (s *fakePremiumTaxReportStore) CreatePremiumTaxReport(ctx context.Context, report *models.PremiumTaxReport) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if report.ID == uuid.Nil {
+		report.ID = uuid.New()
+	}
+	s.reports = append(s.reports, report)
+	return nil
+}
+
+func (s *fakePremiumTaxReportStore) GetPremiumTaxReport(ctx context.Context, id uuid.UUID) (*models.PremiumTaxReport, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, report := range s.reports {
+		if report.ID == id {
+			return report, nil
+		}
+	}
+	return nil, fmt.Errorf("premium tax report not found")
+}
+
+// fakeUserStore is an in-memory UserStore for service tests that counts lookups.
+type fakeUserStore struct {
+	store.UserStore
+	mu      sync.Mutex
+	users   map[uuid.UUID]*models.User
+	lookups int
+}
+
+func newFakeUserStore(users ...*models.User) *fakeUserStore {
+	s := &fakeUserStore{users: make(map[uuid.UUID]*models.User)}
+	for _, user := range users {
+		if user.ID == uuid.Nil {
+			user.ID = uuid.New()
+		}
+		s.users[user.ID] = user
+	}
+	return s
+}
+
+func (s *fakeUserStore) FindByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lookups++
+	user, ok := s.users[id]
+	if !ok {
+		return nil, fmt.Errorf("user not found")
+	}
+	return user, nil
+}
+
+func (s *fakeUserStore) List(ctx context.Context, limit, offset int) ([]*models.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	users := make([]*models.User, 0, len(s.users))
+	for _, user := range s.users {
+		users = append(users, user)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].ID.String() < users[j].ID.String() })
+
+	if offset >= len(users) {
+		return nil, nil
+	}
+	users = users[offset:]
+	if limit > 0 && len(users) > limit {
+		users = users[:limit]
+	}
+	return users, nil
+}
+
+// fakeCustomerStore is an in-memory CustomerStore for service tests.
+type fakeCustomerStore struct {
+	store.CustomerStore
+	mu        sync.Mutex
+	customers map[uuid.UUID]*models.Customer
+}
+
+func newFakeCustomerStore(customers ...*models.Customer) *fakeCustomerStore {
+	s := &fakeCustomerStore{customers: make(map[uuid.UUID]*models.Customer)}
+	for _, customer := range customers {
+		if customer.ID == uuid.Nil {
+			customer.ID = uuid.New()
+		}
+		s.customers[customer.ID] = customer
+	}
+	return s
+}
+
+func (s *fakeCustomerStore) GetByID(ctx context.Context, id uuid.UUID) (*models.Customer, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	customer, ok := s.customers[id]
+	if !ok {
+		return nil, fmt.Errorf("customer %w", store.ErrNotFound)
+	}
+	return customer, nil
+}
+
+func (s *fakeCustomerStore) GetByUserID(ctx context.Context, userID uuid.UUID) (*models.Customer, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, customer := range s.customers {
+		if customer.UserID == userID {
+			return customer, nil
+		}
+	}
+	return nil, fmt.Errorf("customer %w", store.ErrNotFound)
+}
+
+func (s *fakeCustomerStore) Update(ctx context.Context, customer *models.Customer) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.customers[customer.ID] = customer
+	return nil
+}
+
+func (s *fakeCustomerStore) GetCustomersWithKYCVerifiedBefore(ctx context.Context, before time.Time, limit int) ([]*models.Customer, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var customers []*models.Customer
+	for _, customer := range s.customers {
+		if customer.KYCStatus == models.KYCStatusVerified && customer.KYCVerifiedAt != nil && customer.KYCVerifiedAt.Before(before) {
+			customers = append(customers, customer)
+		}
+	}
+	if limit > 0 && len(customers) > limit {
+		customers = customers[:limit]
+	}
+	return customers, nil
+}
+
+// fakeAuditLogStore is an in-memory AuditLogStore for service tests.
+type fakeAuditLogStore struct {
+	mu      sync.Mutex
+	entries []*models.AuditLog
+}
+
+func (s *fakeAuditLogStore) CreateAuditLog(ctx context.Context, entry *models.AuditLog) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if entry.ID == uuid.Nil {
+		entry.ID = uuid.New()
+	}
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+func (s *fakeAuditLogStore) ListAuditLogsByEntity(ctx context.Context, entityType, entityID string, limit, offset int) ([]*models.AuditLog, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var entries []*models.AuditLog
+	for _, entry := range s.entries {
+		if entry.EntityType == entityType && entry.EntityID == entityID {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// fakeProductStore is an in-memory ProductStore for service tests.
+type fakeProductStore struct {
+	store.ProductStore
+	mu       sync.Mutex
+	products map[uuid.UUID]*models.Product
+}
+
+func newFakeProductStore(products ...*models.Product) *fakeProductStore {
+	s := &fakeProductStore{products: make(map[uuid.UUID]*models.Product)}
+	for _, product := range products {
+		if product.ID == uuid.Nil {
+			product.ID = uuid.New()
+		}
+		s.products[product.ID] = product
+	}
+	return s
+}
+
+func (s *fakeProductStore) GetProduct(ctx context.Context, id uuid.UUID) (*models.Product, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	product, ok := s.products[id]
+	if !ok {
+		return nil, fmt.Errorf("product not found")
+	}
+	return product, nil
+}
+
+// fakePartnerStore is an in-memory PartnerStore for service tests.
+type fakePartnerStore struct {
+	store.PartnerStore
+	mu       sync.Mutex
+	partners map[uuid.UUID]*models.Partner
+}
+
+func newFakePartnerStore(partners ...*models.Partner) *fakePartnerStore {
+	s := &fakePartnerStore{partners: make(map[uuid.UUID]*models.Partner)}
+	for _, partner := range partners {
+		if partner.ID == uuid.Nil {
+			partner.ID = uuid.New()
+		}
+		s.partners[partner.ID] = partner
+	}
+	return s
+}
+
+func (s *fakePartnerStore) GetPartner(ctx context.Context, id uuid.UUID) (*models.Partner, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	partner, ok := s.partners[id]
+	if !ok {
+		return nil, fmt.Errorf("partner not found")
+	}
+	return partner, nil
+}
+
+// fakeCommissionStore is an in-memory CommissionStore for service tests.
+type fakeCommissionStore struct {
+	mu          sync.Mutex
+	commissions []*models.Commission
+}
+
+func (s *fakeCommissionStore) CreateCommission(ctx context.Context, commission *models.Commission) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if commission.ID == uuid.Nil {
+		commission.ID = uuid.New()
+	}
+	s.commissions = append(s.commissions, commission)
+	return nil
+}
+
+func (s *fakeCommissionStore) CreateCommissions(ctx context.Context, commissions []*models.Commission) error {
+	for _, commission := range commissions {
+		if err := s.CreateCommission(ctx, commission); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *fakeCommissionStore) GetCommission(ctx context.Context, id uuid.UUID) (*models.Commission, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, commission := range s.commissions {
+		if commission.ID == id {
+			return commission, nil
+		}
+	}
+	return nil, fmt.Errorf("commission not found")
+}
+
+func (s *fakeCommissionStore) ListCommissionsByPolicy(ctx context.Context, policyID uuid.UUID) ([]*models.Commission, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var commissions []*models.Commission
+	for _, commission := range s.commissions {
+		if commission.PolicyID == policyID {
+			commissions = append(commissions, commission)
+		}
+	}
+	return commissions, nil
+}
+
+func (s *fakeCommissionStore) ListCommissionsByPartner(ctx context.Context, partnerID uuid.UUID, from, to time.Time) ([]*models.Commission, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var commissions []*models.Commission
+	for _, commission := range s.commissions {
+		if commission.PartnerID != partnerID || commission.CreatedAt.Before(from) || commission.CreatedAt.After(to) {
+			continue
+		}
+		commissions = append(commissions, commission)
+	}
+	return commissions, nil
+}
+
+func (s *fakeCommissionStore) ListPaidCommissionsByPartner(ctx context.Context, partnerID uuid.UUID, from, to time.Time) ([]*models.Commission, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var commissions []*models.Commission
+	for _, commission := range s.commissions {
+		if commission.PartnerID != partnerID || !commission.IsPaid() || commission.PaidAt == nil {
+			continue
+		}
+		if commission.PaidAt.Before(from) || commission.PaidAt.After(to) {
+			continue
+		}
+		commissions = append(commissions, commission)
+	}
+	return commissions, nil
+}
+
+func (s *fakeCommissionStore) UpdateCommission(ctx context.Context, commission *models.Commission) error {
+	return nil
+}
+
+// fakePartnerStatementStore is an in-memory PartnerStatementStore for service tests.
+type fakePartnerStatementStore struct {
+	mu         sync.Mutex
+	statements []*models.PartnerStatement
+}
+
+func (s *fakePartnerStatementStore) SavePartnerStatement(ctx context.Context, statement *models.PartnerStatement) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, existing := range s.statements {
+		if existing.PartnerID == statement.PartnerID && existing.PeriodStart.Equal(statement.PeriodStart) && existing.PeriodEnd.Equal(statement.PeriodEnd) {
+			statement.ID = existing.ID
+			s.statements[i] = statement
+			return nil
+		}
+	}
+	if statement.ID == uuid.Nil {
+		statement.ID = uuid.New()
+	}
+	s.statements = append(s.statements, statement)
+	return nil
+}
+
+func (s *fakePartnerStatementStore) GetPartnerStatement(ctx context.Context, id uuid.UUID) (*models.PartnerStatement, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, statement := range s.statements {
+		if statement.ID == id {
+			return statement, nil
+		}
+	}
+	return nil, fmt.Errorf("partner statement not found")
+}
+
+func (s *fakePartnerStatementStore) GetLatestPartnerStatement(ctx context.Context, partnerID uuid.UUID, endingBy time.Time) (*models.PartnerStatement, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var latest *models.PartnerStatement
+	for _, statement := range s.statements {
+		if statement.PartnerID != partnerID || statement.PeriodEnd.After(endingBy) {
+			continue
+		}
+		if latest == nil || statement.PeriodEnd.After(latest.PeriodEnd) {
+			latest = statement
+		}
+	}
+	return latest, nil
+}
+
+func (s *fakePartnerStatementStore) ListPartnerStatements(ctx context.Context, partnerID uuid.UUID) ([]*models.PartnerStatement, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var statements []*models.PartnerStatement
+	for _, statement := range s.statements {
+		if statement.PartnerID == partnerID {
+			statements = append(statements, statement)
+		}
+	}
+	return statements, nil
+}
+
+// fakeEndorsementStore is an in-memory EndorsementStore for service tests. Endorsed
+// policies are saved to policies.
+type fakeEndorsementStore struct {
+	mu           sync.Mutex
+	policies     store.PolicyStore
+	endorsements []*models.PolicyEndorsement
+}
+
+func (s *fakeEndorsementStore) EndorsePolicy(ctx context.Context, policy *models.Policy, endorsement *models.PolicyEndorsement) error {
+	if err := s.policies.UpdatePolicy(ctx, policy); err != nil {
+		return err
+	}
+	return s.CreateEndorsement(ctx, endorsement)
+}
+
+func (s *fakeEndorsementStore) CreateEndorsement(ctx context.Context, endorsement *models.PolicyEndorsement) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if endorsement.ID == uuid.Nil {
+		endorsement.ID = uuid.New()
+	}
+	s.endorsements = append(s.endorsements, endorsement)
+	return nil
+}
+
+func (s *fakeEndorsementStore) ListEndorsementsByPolicy(ctx context.Context, policyID uuid.UUID) ([]*models.PolicyEndorsement, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var endorsements []*models.PolicyEndorsement
+	for _, endorsement := range s.endorsements {
+		if endorsement.PolicyID == policyID {
+			endorsements = append(endorsements, endorsement)
+		}
+	}
+	return endorsements, nil
+}
+
+func (s *fakeEndorsementStore) ListEndorsementsEffectiveBetween(ctx context.Context, policyID uuid.UUID, from, to time.Time) ([]*models.PolicyEndorsement, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var endorsements []*models.PolicyEndorsement
+	for _, endorsement := range s.endorsements {
+		if endorsement.PolicyID == policyID && !endorsement.EffectiveDate.Before(from) && !endorsement.EffectiveDate.After(to) {
+			endorsements = append(endorsements, endorsement)
+		}
+	}
+	return endorsements, nil
+}
+
+// fakeAppealStore is an in-memory AppealStore for service tests.
+type fakeAppealStore struct {
+	mu      sync.Mutex
+	appeals []*models.Appeal
+}
+
+func (s *fakeAppealStore) CreateAppeal(ctx context.Context, appeal *models.Appeal) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if appeal.ID == uuid.Nil {
+		appeal.ID = uuid.New()
+	}
+	s.appeals = append(s.appeals, appeal)
+	return nil
+}
+
+func (s *fakeAppealStore) GetAppeal(ctx context.Context, id uuid.UUID) (*models.Appeal, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, appeal := range s.appeals {
+		if appeal.ID == id {
+			return appeal, nil
+		}
+	}
+	return nil, fmt.Errorf("appeal not found")
+}
+
+func (s *fakeAppealStore) ListAppealsBySubject(ctx context.Context, subjectType string, subjectID uuid.UUID) ([]*models.Appeal, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var appeals []*models.Appeal
+	for _, appeal := range s.appeals {
+		if appeal.SubjectType == subjectType && appeal.SubjectID == subjectID {
+			appeals = append(appeals, appeal)
+		}
+	}
+	return appeals, nil
+}
+
+func (s *fakeAppealStore) UpdateAppeal(ctx context.Context, appeal *models.Appeal) error {
+	return nil
+}
+
+func (s *fakeAppealStore) FileClaimAppeal(ctx context.Context, claim *models.Claim, appeal *models.Appeal) error {
+	return s.CreateAppeal(ctx, appeal)
+}
+
+func (s *fakeAppealStore) ResolveClaimAppeal(ctx context.Context, claim *models.Claim, appeal *models.Appeal) error {
+	return nil
+}
+
+// fakeDecisionStore is an in-memory UnderwritingDecisionStore for service tests.
+type fakeDecisionStore struct {
+	mu        sync.Mutex
+	decisions []*models.UnderwritingDecision
+}
+
+func (s *fakeDecisionStore) CreateDecision(ctx context.Context, decision *models.UnderwritingDecision) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if decision.ID == uuid.Nil {
+		decision.ID = uuid.New()
+	}
+	s.decisions = append(s.decisions, decision)
+	return nil
+}
+
+func (s *fakeDecisionStore) GetDecision(ctx context.Context, id uuid.UUID) (*models.UnderwritingDecision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, decision := range s.decisions {
+		if decision.ID == id {
+			return decision, nil
+		}
+	}
+	return nil, fmt.Errorf("underwriting decision not found")
+}
+
+func (s *fakeDecisionStore) GetLatestDecision(ctx context.Context, applicationID uuid.UUID) (*models.UnderwritingDecision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := len(s.decisions) - 1; i >= 0; i-- {
+		if s.decisions[i].ApplicationID == applicationID {
+			return s.decisions[i], nil
+		}
+	}
+	return nil, fmt.Errorf("underwriting decision not found")
+}
+
+// fakeQuoteStore is an in-memory QuoteStore for service tests.
+type fakeQuoteStore struct {
+	store.QuoteStore
+	mu     sync.Mutex
+	quotes map[uuid.UUID]*models.Quote
+}
+
+func newFakeQuoteStore() *fakeQuoteStore {
+	return &fakeQuoteStore{quotes: make(map[uuid.UUID]*models.Quote)}
+}
+
+func (s *fakeQuoteStore) CreateQuote(ctx context.Context, quote *models.Quote) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if quote.ID == uuid.Nil {
+		quote.ID = uuid.New()
+	}
+	s.quotes[quote.ID] = quote
+	return nil
+}
+
+func (s *fakeQuoteStore) GetQuote(ctx context.Context, id uuid.UUID) (*models.Quote, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	quote, ok := s.quotes[id]
+	if !ok {
+		return nil, fmt.Errorf("quote not found")
+	}
+	return quote, nil
+}
+
+func (s *fakeQuoteStore) UpdateQuote(ctx context.Context, quote *models.Quote) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.quotes[quote.ID]; !ok {
+		return fmt.Errorf("quote not found")
+	}
+	s.quotes[quote.ID] = quote
+	return nil
+}
+
+func (s *fakeQuoteStore) GetActiveRenewalOffer(ctx context.Context, policyID uuid.UUID, asOf time.Time) (*models.Quote, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var latest *models.Quote
+	for _, quote := range s.quotes {
+		if quote.RenewalOfPolicyID == nil || *quote.RenewalOfPolicyID != policyID ||
+			quote.Status != models.QuoteStatusActive || !quote.ValidUntil.After(asOf) {
+			continue
+		}
+		if latest == nil || quote.ValidUntil.After(latest.ValidUntil) {
+			latest = quote
+		}
+	}
+	return latest, nil
+}
+
+// fakeBlobStore is an in-memory BlobStore for service tests.
+type fakeBlobStore struct {
+	mu    sync.Mutex
+	blobs map[string][]byte
+}
+
+func newFakeBlobStore() *fakeBlobStore {
+	return &fakeBlobStore{blobs: make(map[string][]byte)}
+}
+
+func (s *fakeBlobStore) Put(ctx context.Context, key, contentType string, data []byte) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.blobs[key] = data
+	return "https://files.test/" + key, nil
+}
+
+func (s *fakeBlobStore) Get(ctx context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.blobs[key]
+	if !ok {
+		return nil, fmt.Errorf("blob not found")
+	}
+	return data, nil
+}
+
+// fakePaymentStore is an in-memory PaymentStore for service tests.
+type fakePaymentStore struct {
+	store.PaymentStore
+	mu       sync.Mutex
+	payments []*models.Payment
+}
+
+func newFakePaymentStore(payments ...*models.Payment) *fakePaymentStore {
+	for _, payment := range payments {
+		if payment.ID == uuid.Nil {
+			payment.ID = uuid.New()
+		}
+	}
+	return &fakePaymentStore{payments: payments}
+}
+
+func (s *fakePaymentStore) CreatePayment(ctx context.Context, payment *models.Payment) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if payment.ID == uuid.Nil {
+		payment.ID = uuid.New()
+	}
+	s.payments = append(s.payments, payment)
+	return nil
+}
+
+func (s *fakePaymentStore) UpdatePayment(ctx context.Context, payment *models.Payment) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, existing := range s.payments {
+		if existing.ID == payment.ID {
+			s.payments[i] = payment
+			return nil
+		}
+	}
+	return fmt.Errorf("payment %w", store.ErrNotFound)
+}
+
+func (s *fakePaymentStore) ListPayments(ctx context.Context, userID *uuid.UUID, policyID *uuid.UUID, subscriptionID *uuid.UUID, status string, limit, offset int) ([]*models.Payment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var matched []*models.Payment
+	for _, payment := range s.payments {
+		if userID != nil && payment.UserID != *userID {
+			continue
+		}
+		if policyID != nil && (payment.PolicyID == nil || *payment.PolicyID != *policyID) {
+			continue
+		}
+		if status != "" && payment.Status != status {
+			continue
+		}
+		matched = append(matched, payment)
+	}
+
+	if offset >= len(matched) {
+		return nil, nil
+	}
+	matched = matched[offset:]
+	if limit > 0 && len(matched) > limit {
+		matched = matched[:limit]
+	}
+	return matched, nil
+}
+
+func (s *fakePaymentStore) ListCompletedPaymentsSince(ctx context.Context, userID uuid.UUID, since time.Time, limit, offset int) ([]*models.Payment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var matched []*models.Payment
+	for _, payment := range s.payments {
+		if payment.UserID != userID || payment.Status != models.PaymentStatusCompleted {
+			continue
+		}
+		if paymentDate(payment).Before(since) {
+			continue
+		}
+		matched = append(matched, payment)
+	}
+
+	if offset >= len(matched) {
+		return nil, nil
+	}
+	matched = matched[offset:]
+	if limit > 0 && len(matched) > limit {
+		matched = matched[:limit]
+	}
+	return matched, nil
+}
+
+// fakeClaimWorkflowStore is an in-memory ClaimWorkflowStore for service tests.
+type fakeClaimWorkflowStore struct {
+	mu        sync.Mutex
+	workflows map[uuid.UUID]models.ClaimWorkflowState
+}
+
+func newFakeClaimWorkflowStore() *fakeClaimWorkflowStore {
+	return &fakeClaimWorkflowStore{workflows: make(map[uuid.UUID]models.ClaimWorkflowState)}
+}
+
+func (s *fakeClaimWorkflowStore) GetWorkflow(ctx context.Context, claimID uuid.UUID) (*models.ClaimWorkflowState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, ok := s.workflows[claimID]
+	if !ok {
+		return nil, fmt.Errorf("claim workflow %w", store.ErrNotFound)
+	}
+	return &state, nil
+}
+
+func (s *fakeClaimWorkflowStore) SaveWorkflow(ctx context.Context, state *models.ClaimWorkflowState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.workflows[state.ClaimID] = *state
+	return nil
+}
+
+// fakeSARStore is an in-memory SARStore for service tests.
+type fakeSARStore struct {
+	store.SARStore
+	mu      sync.Mutex
+	reports []*models.SuspiciousActivityReport
+}
+
+func (s *fakeSARStore) CreateReport(ctx context.Context, report *models.SuspiciousActivityReport) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if report.ID == uuid.Nil {
+		report.ID = uuid.New()
+	}
+	s.reports = append(s.reports, report)
+	return nil
+}
+
+func (s *fakeSARStore) GetReport(ctx context.Context, id uuid.UUID) (*models.SuspiciousActivityReport, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, report := range s.reports {
+		if report.ID == id {
+			return report, nil
+		}
+	}
+	return nil, fmt.Errorf("suspicious activity report not found")
+}
+
+func (s *fakeSARStore) ListReportsByUser(ctx context.Context, userID uuid.UUID, status string) ([]*models.SuspiciousActivityReport, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var reports []*models.SuspiciousActivityReport
+	for _, report := range s.reports {
+		if report.UserID == userID && (status == "" || report.Status == status) {
+			reports = append(reports, report)
+		}
+	}
+	return reports, nil
+}
+
+func (s *fakeSARStore) UpdateReport(ctx context.Context, report *models.SuspiciousActivityReport) error {
+	return nil
+}
+
+func (s *fakeSARStore) SaveDraft(ctx context.Context, report *models.SuspiciousActivityReport, merge func(draft *models.SuspiciousActivityReport)) (*models.SuspiciousActivityReport, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, draft := range s.reports {
+		if draft.Status != models.SARStatusDraft {
+			continue
+		}
+		sameClaim := report.ClaimID != nil && draft.ClaimID != nil && *draft.ClaimID == *report.ClaimID
+		sameUser := report.ClaimID == nil && draft.ClaimID == nil && draft.UserID == report.UserID
+		if sameClaim || sameUser {
+			merge(draft)
+			return draft, nil
+		}
+	}
+	if report.ID == uuid.Nil {
+		report.ID = uuid.New()
+	}
+	s.reports = append(s.reports, report)
+	return report, nil
+}
+
+// fakeBeneficiaryStore is an in-memory BeneficiaryStore for service tests.
+type fakeBeneficiaryStore struct {
+	store.BeneficiaryStore
+	mu            sync.Mutex
+	beneficiaries map[uuid.UUID]*models.Beneficiary
+}
+
+func newFakeBeneficiaryStore() *fakeBeneficiaryStore {
+	return &fakeBeneficiaryStore{beneficiaries: make(map[uuid.UUID]*models.Beneficiary)}
+}
+
+func (s *fakeBeneficiaryStore) CreateBeneficiary(ctx context.Context, beneficiary *models.Beneficiary) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if beneficiary.ID == uuid.Nil {
+		beneficiary.ID = uuid.New()
+	}
+	s.beneficiaries[beneficiary.ID] = beneficiary
+	return nil
+}
+
+func (s *fakeBeneficiaryStore) ListBeneficiaries(ctx context.Context, policyID *uuid.UUID, limit, offset int) ([]*models.Beneficiary, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var beneficiaries []*models.Beneficiary
+	for _, beneficiary := range s.beneficiaries {
+		if policyID == nil || beneficiary.PolicyID == *policyID {
+			beneficiaries = append(beneficiaries, beneficiary)
+		}
+	}
+	return beneficiaries, nil
+}
+
+func (s *fakeBeneficiaryStore) ReplaceBeneficiaries(ctx context.Context, policyID uuid.UUID, beneficiaries []*models.Beneficiary) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, beneficiary := range s.beneficiaries {
+		if beneficiary.PolicyID == policyID {
+			delete(s.beneficiaries, id)
+		}
+	}
+	for _, beneficiary := range beneficiaries {
+		if beneficiary.ID == uuid.Nil {
+			beneficiary.ID = uuid.New()
+		}
+		beneficiary.PolicyID = policyID
+		s.beneficiaries[beneficiary.ID] = beneficiary
+	}
+	return nil
+}
+
+func (s *fakeBeneficiaryStore) DeleteBeneficiary(ctx context.Context, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.beneficiaries, id)
+	return nil
+}
+
+// fakeCoverageStore is an in-memory CoverageStore for service tests.
+type fakeCoverageStore struct {
+	store.CoverageStore
+	coverages []*models.Coverage
+}
+
+func newFakeCoverageStore(coverages ...*models.Coverage) *fakeCoverageStore {
+	for _, coverage := range coverages {
+		if coverage.ID == uuid.Nil {
+			coverage.ID = uuid.New()
+		}
+	}
+	return &fakeCoverageStore{coverages: coverages}
+}
+
+func (s *fakeCoverageStore) ListCoverages(ctx context.Context, productID *uuid.UUID, coverageType string, limit, offset int) ([]*models.Coverage, error) {
+	var coverages []*models.Coverage
+	for _, coverage := range s.coverages {
+		if productID != nil && coverage.ProductID != *productID {
+			continue
+		}
+		if coverageType != "" && coverage.CoverageType != coverageType {
+			continue
+		}
+		coverages = append(coverages, coverage)
+	}
+	return coverages, nil
+}
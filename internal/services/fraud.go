@@ -3,11 +3,16 @@ package services
 import (
 	"context"
 	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/edsonmichaque/bazaruto/internal/config"
 	"github.com/edsonmichaque/bazaruto/internal/events"
 	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/metrics"
 	"github.com/edsonmichaque/bazaruto/internal/models"
 	"github.com/edsonmichaque/bazaruto/internal/store"
 	"github.com/google/uuid"
@@ -60,6 +65,12 @@ const (
 	TotalFactors      = 8.0
 	ConfidenceDivisor = 2.0
 
+	// Factor confidence by data quality
+	ExcellentDataConfidence = 1.0
+	GoodDataConfidence      = 0.9
+	FairDataConfidence      = 0.6
+	PoorDataConfidence      = 0.3
+
 	// Tier adjustments
 	HighTierMultiplier = 0.8
 	NoTierMultiplier   = 1.2
@@ -67,6 +78,9 @@ const (
 	// Reporting delay
 	SignificantDelayScore = 20.0
 
+	// Explainability
+	MaxExplanationFactors = 3
+
 	// Account age scoring
 	NewAccountScore         = 60.0
 	RecentAccountScore      = 30.0
@@ -89,7 +103,15 @@ type FraudDetectionService struct {
 	customerStore store.CustomerStore
 	configManager *config.Manager
 	eventService  *EventService
+	metrics       *metrics.Metrics
 	logger        *logger.Logger
+	evaluators    []FraudFactorEvaluator
+	evaluatorsMu  sync.RWMutex
+	rateLimiter   *fraudRateLimiter
+	siuConnector  SIUConnector
+	sars          *SARService
+	// endorsementStore is optional; the coverage_change factor is only evaluated when it is set
+	endorsementStore store.EndorsementStore
 }
 
 // NewFraudDetectionService creates a new FraudDetectionService instance.
@@ -100,15 +122,45 @@ func NewFraudDetectionService(
 	policyStore store.PolicyStore,
 	customerStore store.CustomerStore,
 	eventService *EventService,
+	fraudMetrics ...*metrics.Metrics,
 ) *FraudDetectionService {
-	return &FraudDetectionService{
+	s := &FraudDetectionService{
 		claimStore:    claimStore,
 		policyStore:   policyStore,
 		customerStore: customerStore,
 		configManager: configManager,
 		eventService:  eventService,
 		logger:        logger,
+		rateLimiter:   newFraudRateLimiter(),
 	}
+	if len(fraudMetrics) > 0 {
+		s.metrics = fraudMetrics[0]
+	}
+	s.evaluators = s.defaultFraudEvaluators()
+	return s
+}
+
+// SetSARService sets the service that drafts suspicious activity reports for claims analysed
+// as high or critical risk.
+func (s *FraudDetectionService) SetSARService(sars *SARService) {
+	s.sars = sars
+}
+
+// reportSuspiciousClaim drafts a suspicious activity report for a high or critical risk claim
+// and records its ID in the score metadata. Failures are logged and do not fail the analysis.
+func (s *FraudDetectionService) reportSuspiciousClaim(ctx context.Context, claim *models.Claim, score *FraudScore) {
+	if s.sars == nil || (score.RiskLevel != "high" && score.RiskLevel != "critical") {
+		return
+	}
+
+	report, err := s.sars.CreateFromFraudScore(ctx, claim, score)
+	if err != nil {
+		s.logger.Error("Failed to draft suspicious activity report",
+			zap.String("claim_id", claim.ID.String()),
+			zap.Error(err))
+		return
+	}
+	score.Metadata["sar_id"] = report.ID.String()
 }
 
 // FraudScore represents the result of fraud detection analysis.
@@ -119,63 +171,186 @@ type FraudScore struct {
 	Recommendations []string               `json:"recommendations"` // Recommended actions
 	RequiresReview  bool                   `json:"requires_review"` // Whether manual review is needed
 	Confidence      float64                `json:"confidence"`      // Confidence in the score (0-1)
+	Explanation     *FraudExplanation      `json:"explanation"`     // Consolidated explanation of the score
+	Disabled        bool                   `json:"disabled"`        // Fraud detection was disabled; the score is neutral
 	AnalysisDate    time.Time              `json:"analysis_date"`
 	Metadata        map[string]interface{} `json:"metadata"`
 }
 
+// FraudExplanation summarizes which factors drove a fraud score.
+type FraudExplanation struct {
+	Summary    string                    `json:"summary"`     // Human-readable one-line explanation
+	TopFactors []FraudFactorContribution `json:"top_factors"` // Highest contributing factors, largest first
+}
+
+// FraudFactorContribution describes a single factor's share of a fraud score.
+type FraudFactorContribution struct {
+	Code            string  `json:"code"`             // Stable machine-readable factor code
+	Factor          string  `json:"factor"`           // Name of the risk factor
+	Weight          float64 `json:"weight"`           // Configured weight of the factor
+	Score           float64 `json:"score"`            // Factor score (0-100)
+	Contribution    float64 `json:"contribution"`     // Weight x score
+	ContributionPct float64 `json:"contribution_pct"` // Share of the total weighted score (0-100)
+	Description     string  `json:"description"`      // Human-readable description
+}
+
 // FraudFactor represents an individual risk factor in fraud detection.
 type FraudFactor struct {
-	Factor      string  `json:"factor"`      // Name of the risk factor
-	Weight      float64 `json:"weight"`      // Weight of this factor (0-1)
-	Score       float64 `json:"score"`       // Individual score for this factor (0-100)
-	Description string  `json:"description"` // Human-readable description
-	Severity    string  `json:"severity"`    // low, medium, high, critical
+	Factor      string  `json:"factor"`       // Name of the risk factor
+	Weight      float64 `json:"weight"`       // Weight of this factor (0-1)
+	Score       float64 `json:"score"`        // Individual score for this factor (0-100)
+	Description string  `json:"description"`  // Human-readable description
+	Severity    string  `json:"severity"`     // low, medium, high, critical
+	DataQuality string  `json:"data_quality"` // Quality of the inputs (excellent, good, fair, poor)
+	Confidence  float64 `json:"confidence"`   // Confidence in this factor's score (0-1)
 }
 
 // AnalyzeClaimForFraud performs comprehensive fraud detection analysis on a claim.
+// Analysis is rate limited per claim and globally; calls over the configured limits
+// return ErrFraudAnalysisThrottled without touching the stores. When fraud detection is
+// disabled it returns a neutral, low-risk score flagged as Disabled.
 func (s *FraudDetectionService) AnalyzeClaimForFraud(ctx context.Context, claimID uuid.UUID) (*FraudScore, error) {
+	return s.analyzeClaimForFraud(ctx, claimID, false)
+}
+
+// analyzeClaimForFraud analyzes a claim. When wait is set, a call over the rate limits
+// waits for its turn until ctx is done instead of returning ErrFraudAnalysisThrottled.
+func (s *FraudDetectionService) analyzeClaimForFraud(ctx context.Context, claimID uuid.UUID, wait bool) (*FraudScore, error) {
 	// Get configuration
 	config := s.configManager.GetConfig()
-	if !config.FraudDetection.Enabled {
-		return nil, fmt.Errorf("fraud detection is disabled")
-	}
 	fraudConfig := config.FraudDetection
+	if !fraudConfig.Enabled {
+		s.logger.Debug("Fraud detection disabled, returning neutral score", zap.String("claim_id", claimID.String()))
+		return disabledFraudScore(claimID, config), nil
+	}
+
+	// Protect the claim, customer and policy stores from repeated analysis
+	if wait {
+		if err := s.rateLimiter.wait(ctx, claimID, fraudConfig.RateLimits); err != nil {
+			return nil, err
+		}
+	} else if err := s.rateLimiter.allow(claimID, fraudConfig.RateLimits); err != nil {
+		s.logger.Warn("Fraud analysis throttled", zap.String("claim_id", claimID.String()))
+		return nil, err
+	}
+
+	score, claim, err := s.scoreClaim(ctx, claimID, config, &fraudConfig)
+	if err != nil {
+		return nil, err
+	}
+	productCategory, _ := score.Metadata["product_category"].(string)
 
+	if s.metrics != nil {
+		s.metrics.RecordFraudAnalysis(productCategory, score.RiskLevel, score.Score, score.RequiresReview)
+	}
+
+	// Refer critical cases to the Special Investigations Unit
+	s.escalateToSIU(ctx, &fraudConfig, claim, score)
+
+	// Draft a suspicious activity report for high-risk claims
+	s.reportSuspiciousClaim(ctx, claim, score)
+
+	// Publish fraud analysis completed event
+	if s.eventService != nil {
+		factorNames := make([]string, len(score.Factors))
+		for i, factor := range score.Factors {
+			factorNames[i] = factor.Factor
+		}
+
+		fraudEvent := events.NewFraudAnalysisCompletedEvent(
+			claimID,
+			claim.UserID,
+			score.Score,
+			score.RiskLevel,
+			score.RequiresReview,
+			score.Confidence,
+			factorNames,
+			time.Now(),
+		)
+
+		if err := s.eventService.PublishEvent(ctx, fraudEvent); err != nil {
+			s.logger.Error("Failed to publish fraud analysis completed event",
+				zap.Error(err),
+				zap.String("claim_id", claimID.String()))
+			// Don't fail the analysis if event publishing fails
+		}
+	}
+
+	return score, nil
+}
+
+// ReplayFraudAnalysis recomputes the fraud score of a claim against the archived
+// configuration with the given version, to show how a past decision was reached. The
+// replay has no side effects: it is not rate limited, records no metrics, opens no SIU
+// case and publishes no events.
+func (s *FraudDetectionService) ReplayFraudAnalysis(ctx context.Context, claimID uuid.UUID, configVersion string) (*FraudScore, error) {
+	rules, err := s.configManager.GetConfigVersion(configVersion)
+	if err != nil {
+		return nil, err
+	}
+	if !rules.FraudDetection.Enabled {
+		score := disabledFraudScore(claimID, rules)
+		score.Metadata["replayed"] = true
+		return score, nil
+	}
+	fraudConfig := rules.FraudDetection
+
+	score, _, err := s.scoreClaim(ctx, claimID, rules, &fraudConfig)
+	if err != nil {
+		return nil, err
+	}
+	score.Metadata["replayed"] = true
+	return score, nil
+}
+
+// disabledFraudScore is the neutral score reported when fraud detection is disabled, so
+// that callers such as the claim workflow can proceed.
+func disabledFraudScore(claimID uuid.UUID, rules *config.BusinessRulesConfig) *FraudScore {
+	return &FraudScore{
+		RiskLevel:    "low",
+		Disabled:     true,
+		AnalysisDate: time.Now(),
+		Metadata: map[string]interface{}{
+			"claim_id":       claimID.String(),
+			"config_version": rules.FraudDetection.Version,
+			"rules_version":  rules.Version,
+		},
+	}
+}
+
+// scoreClaim computes the fraud score of a claim under rules. fraudConfig is a copy of
+// rules.FraudDetection whose factor weights are resolved for the policy's product category.
+func (s *FraudDetectionService) scoreClaim(ctx context.Context, claimID uuid.UUID, rules *config.BusinessRulesConfig, fraudConfig *config.FraudDetectionConfig) (*FraudScore, *models.Claim, error) {
 	// Fetch claim details
 	claim, err := s.claimStore.GetClaim(ctx, claimID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch claim: %w", err)
+		return nil, nil, fmt.Errorf("failed to fetch claim: %w", err)
 	}
 
 	// Fetch customer details instead of user
 	customer, err := s.customerStore.GetByID(ctx, claim.UserID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch customer: %w", err)
+		return nil, nil, fmt.Errorf("failed to fetch customer: %w", err)
 	}
 
 	// Fetch related policy
 	policy, err := s.policyStore.GetPolicy(ctx, claim.PolicyID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch policy: %w", err)
+		return nil, nil, fmt.Errorf("failed to fetch policy: %w", err)
 	}
 
+	// Resolve factor weights for the policy's product category
+	productCategory := policy.Product.Category
+	fraudConfig.FactorWeights = resolveFactorWeights(fraudConfig, productCategory)
+
 	// Perform comprehensive fraud analysis
 	score := &FraudScore{
 		AnalysisDate: time.Now(),
 		Metadata:     make(map[string]interface{}),
 	}
 
-	// Analyze various fraud indicators using configuration
-	factors := []FraudFactor{
-		s.analyzeClaimTiming(ctx, &fraudConfig, claim, policy),
-		s.analyzeClaimAmount(ctx, &fraudConfig, claim, policy),
-		s.analyzeCustomerHistory(ctx, &fraudConfig, claim, customer),
-		s.analyzeIncidentPatterns(ctx, &fraudConfig, claim, policy),
-		s.analyzeDocumentation(ctx, &fraudConfig, claim),
-		s.analyzeGeographicRisk(ctx, &fraudConfig, claim, customer),
-		s.analyzeBehavioralPatterns(ctx, &fraudConfig, claim, customer),
-		s.analyzePolicyHistory(ctx, &fraudConfig, claim, policy),
-	}
+	// Analyze various fraud indicators using the registered, config-enabled evaluators
+	factors := s.evaluateFactors(ctx, fraudConfig, claim, customer, policy)
 
 	// Calculate weighted fraud score using configuration weights
 	totalWeight := 0.0
@@ -193,48 +368,25 @@ func (s *FraudDetectionService) AnalyzeClaimForFraud(ctx context.Context, claimI
 	}
 
 	score.Factors = factors
-	score.Confidence = s.calculateConfidence(ctx, &fraudConfig, factors)
-	score.RiskLevel = s.determineRiskLevel(ctx, &fraudConfig, score.Score)
-	score.RequiresReview = s.requiresManualReview(ctx, &fraudConfig, score.Score, factors)
-	score.Recommendations = s.generateRecommendations(ctx, &fraudConfig, score, factors)
+	score.Confidence = s.calculateConfidence(ctx, fraudConfig, factors)
+	score.RiskLevel = s.determineRiskLevel(ctx, fraudConfig, score.Score)
+	score.RequiresReview = s.requiresManualReview(ctx, fraudConfig, score.Score, factors)
+	score.Recommendations = s.generateRecommendations(ctx, fraudConfig, score, factors)
+	score.Explanation = s.explainScore(score, factors)
 
 	// Store fraud analysis results
 	score.Metadata["claim_id"] = claimID.String()
 	score.Metadata["policy_id"] = claim.PolicyID.String()
 	score.Metadata["customer_id"] = claim.UserID.String()
-	score.Metadata["analysis_version"] = fraudConfig.Version
-
-	// Publish fraud analysis completed event
-	if s.eventService != nil {
-		factorNames := make([]string, len(factors))
-		for i, factor := range factors {
-			factorNames[i] = factor.Factor
-		}
-
-		fraudEvent := events.NewFraudAnalysisCompletedEvent(
-			claimID,
-			claim.UserID,
-			score.Score,
-			score.RiskLevel,
-			score.RequiresReview,
-			score.Confidence,
-			factorNames,
-			time.Now(),
-		)
-
-		if err := s.eventService.PublishEvent(ctx, fraudEvent); err != nil {
-			s.logger.Error("Failed to publish fraud analysis completed event",
-				zap.Error(err),
-				zap.String("claim_id", claimID.String()))
-			// Don't fail the analysis if event publishing fails
-		}
-	}
+	score.Metadata["config_version"] = fraudConfig.Version
+	score.Metadata["rules_version"] = rules.Version
+	score.Metadata["product_category"] = productCategory
 
-	return score, nil
+	return score, claim, nil
 }
 
 // analyzeClaimTiming analyzes timing-related fraud indicators using configuration.
-func (s *FraudDetectionService) analyzeClaimTiming(ctx context.Context, config *config.FraudDetectionConfig, claim *models.Claim, policy *models.Policy) FraudFactor {
+func (s *FraudDetectionService) analyzeClaimTiming(ctx context.Context, config *config.FraudDetectionConfig, claim *models.Claim, customer *models.Customer, policy *models.Policy) FraudFactor {
 	factor := FraudFactor{
 		Factor: "claim_timing",
 		Weight: config.FactorWeights["claim_timing"],
@@ -270,16 +422,15 @@ func (s *FraudDetectionService) analyzeClaimTiming(ctx context.Context, config *
 		}
 	}
 
-	// Apply weekend multiplier
-	weekday := claim.IncidentDate.Weekday()
-	if weekday == time.Saturday || weekday == time.Sunday {
+	// Apply weekend multiplier in the incident's local time
+	incidentTime := incidentLocalTime(claim, customer)
+	if isWeekend(config, incidentTime) {
 		factor.Score *= config.TimingRules.WeekendMultiplier
 		factor.Description += "; Incident occurred on weekend"
 	}
 
 	// Apply business hours multiplier
-	hour := claim.IncidentDate.Hour()
-	if hour >= BusinessHourStart && hour <= BusinessHourEnd {
+	if isBusinessHours(config, incidentTime) {
 		factor.Score *= config.TimingRules.BusinessHoursMultiplier
 		factor.Description += "; Incident occurred during business hours"
 	}
@@ -404,16 +555,16 @@ func (s *FraudDetectionService) analyzeCustomerHistory(ctx context.Context, conf
 }
 
 // analyzeIncidentPatterns analyzes patterns in the incident for fraud indicators.
-func (s *FraudDetectionService) analyzeIncidentPatterns(ctx context.Context, config *config.FraudDetectionConfig, claim *models.Claim, policy *models.Policy) FraudFactor {
+func (s *FraudDetectionService) analyzeIncidentPatterns(ctx context.Context, config *config.FraudDetectionConfig, claim *models.Claim, customer *models.Customer, policy *models.Policy) FraudFactor {
 	factor := FraudFactor{
 		Factor: "incident_patterns",
 		Weight: config.FactorWeights["incident_patterns"],
 	}
 
-	// Check if incident occurred on weekend (common fraud pattern)
-	weekday := claim.IncidentDate.Weekday()
+	// Check if incident occurred on weekend (common fraud pattern) in the incident's local time
+	incidentTime := incidentLocalTime(claim, customer)
 
-	if weekday == time.Saturday || weekday == time.Sunday {
+	if isWeekend(config, incidentTime) {
 		factor.Score = LowSeverityScore
 		factor.Description = "Incident occurred on weekend"
 		factor.Severity = "medium"
@@ -424,9 +575,7 @@ func (s *FraudDetectionService) analyzeIncidentPatterns(ctx context.Context, con
 	}
 
 	// Check if incident occurred during business hours
-	hour := claim.IncidentDate.Hour()
-
-	if hour >= BusinessHourStart && hour <= BusinessHourEnd {
+	if isBusinessHours(config, incidentTime) {
 		factor.Score += BusinessHourAddition
 		factor.Description += "; Incident occurred during business hours"
 	} else {
@@ -455,14 +604,17 @@ func (s *FraudDetectionService) analyzeDocumentation(ctx context.Context, config
 		factor.Score = NoDocumentsScore
 		factor.Description = "No supporting documents provided"
 		factor.Severity = "high"
+		factor.DataQuality = "poor"
 	} else if docCount < minDocCount {
 		factor.Score = FewDocumentsScore
 		factor.Description = fmt.Sprintf("Limited supporting documentation (%d/%d)", docCount, minDocCount)
 		factor.Severity = "medium"
+		factor.DataQuality = "fair"
 	} else {
 		factor.Score = GoodDocumentsScore
 		factor.Description = "Adequate supporting documentation"
 		factor.Severity = "low"
+		factor.DataQuality = "good"
 	}
 
 	// Check document quality (simplified)
@@ -493,6 +645,7 @@ func (s *FraudDetectionService) analyzeGeographicRisk(ctx context.Context, confi
 		factor.Score = LowSeverityScore
 		factor.Description = "No address information available"
 		factor.Severity = "medium"
+		factor.DataQuality = "poor"
 		return factor
 	}
 
@@ -597,19 +750,149 @@ func (s *FraudDetectionService) analyzePolicyHistory(ctx context.Context, config
 	return factor
 }
 
-// calculateConfidence calculates the confidence level in the fraud score.
+// analyzeSubmissionMetadata analyzes how a claim was submitted for fraud indicators: a
+// submission country that differs from the customer's address, a device the customer has
+// not used before, and a device shared with other accounts.
+func (s *FraudDetectionService) analyzeSubmissionMetadata(ctx context.Context, config *config.FraudDetectionConfig, claim *models.Claim, customer *models.Customer) FraudFactor {
+	factor := FraudFactor{
+		Factor: "submission_metadata",
+		Weight: config.FactorWeights["submission_metadata"],
+	}
+
+	if claim.SubmissionCountry == "" && claim.DeviceFingerprint == "" {
+		factor.Description = "No submission metadata available"
+		factor.Severity = "low"
+		factor.DataQuality = "fair"
+		return factor
+	}
+
+	rules := config.SubmissionRules
+	var findings []string
+
+	// Check submission country against the customer's address
+	if claim.SubmissionCountry != "" && customer != nil {
+		if address := customer.GetPrimaryAddress(); address != nil && address.Country != "" &&
+			!strings.EqualFold(address.Country, claim.SubmissionCountry) {
+			factor.Score += rules.CountryMismatchScore
+			findings = append(findings, fmt.Sprintf("Submitted from %s but customer address is in %s", claim.SubmissionCountry, address.Country))
+		}
+	}
+
+	if claim.DeviceFingerprint != "" {
+		// Check the device against devices used on the customer's previous claims
+		previousClaims, err := s.claimStore.ListClaims(ctx, &claim.UserID, nil, "", 100, 0)
+		if err == nil {
+			knownDevices := 0
+			knownDevice := false
+			for _, previous := range previousClaims {
+				if previous.ID == claim.ID || previous.DeviceFingerprint == "" {
+					continue
+				}
+				knownDevices++
+				if previous.DeviceFingerprint == claim.DeviceFingerprint {
+					knownDevice = true
+					break
+				}
+			}
+			if knownDevices > 0 && !knownDevice {
+				factor.Score += rules.NewDeviceScore
+				findings = append(findings, "Submitted from a device not previously used by the customer")
+			}
+		}
+
+		// Check for the same device across other accounts
+		sharedUsers, err := s.claimStore.CountUsersByDevice(ctx, claim.DeviceFingerprint, claim.UserID)
+		if err == nil && rules.SharedDeviceThreshold > 0 && sharedUsers >= int64(rules.SharedDeviceThreshold) {
+			factor.Score += rules.SharedDeviceScore
+			findings = append(findings, fmt.Sprintf("Device used by %d other account(s)", sharedUsers))
+		}
+	}
+
+	factor.Score = math.Min(factor.Score, 100)
+
+	switch {
+	case factor.Score >= MediumSeverityScore:
+		factor.Severity = "high"
+	case factor.Score >= ModerateSeverityScore:
+		factor.Severity = "medium"
+	default:
+		factor.Severity = "low"
+	}
+
+	if len(findings) == 0 {
+		factor.Description = "Submission metadata consistent with customer profile"
+	} else {
+		factor.Description = strings.Join(findings, "; ")
+	}
+
+	return factor
+}
+
+// analyzeDocumentConsistency compares fields extracted from claim documents with the claim.
+// A document such as a repair invoice dated well before the incident suggests pre-existing damage.
+// Only metadata written by the document analyzer is present; values a client sent are discarded
+// at intake.
+func (s *FraudDetectionService) analyzeDocumentConsistency(ctx context.Context, config *config.FraudDetectionConfig, claim *models.Claim) FraudFactor {
+	factor := FraudFactor{
+		Factor:   "document_consistency",
+		Weight:   config.FactorWeights["document_consistency"],
+		Severity: "low",
+	}
+
+	rules := config.DocumentRules
+	earliestAllowed := claim.IncidentDate.AddDate(0, 0, -rules.DateMismatchDays)
+	analyzed := 0
+	var findings []string
+
+	for _, doc := range claim.Documents {
+		if doc.Metadata == nil {
+			continue
+		}
+		analyzed++
+
+		if doc.Metadata.DocumentDate != nil && doc.Metadata.DocumentDate.Before(earliestAllowed) {
+			findings = append(findings, fmt.Sprintf("%s is dated %s, before the incident on %s",
+				doc.FileName, doc.Metadata.DocumentDate.Format("2006-01-02"), claim.IncidentDate.Format("2006-01-02")))
+		}
+	}
+
+	switch {
+	case analyzed == 0:
+		factor.Description = "No extracted document data available"
+		factor.DataQuality = "fair"
+	case len(findings) == 0:
+		factor.Description = "Extracted document data consistent with the claim"
+	default:
+		factor.Score = math.Min(rules.DateMismatchScore, 100)
+		factor.Severity = "high"
+		factor.Description = strings.Join(findings, "; ")
+	}
+
+	return factor
+}
+
+// calculateConfidence calculates the confidence level in the fraud score. The coverage of
+// the weighted factors is scaled by the weighted confidence of the factors, so that scores
+// built on incomplete inputs are trusted less.
 func (s *FraudDetectionService) calculateConfidence(ctx context.Context, config *config.FraudDetectionConfig, factors []FraudFactor) float64 {
-	// Confidence is based on the number of factors and their weights
+	// Confidence is based on the number of factors, their weights and their input quality
 	totalWeight := 0.0
+	weightedFactorConfidence := 0.0
 	activeFactors := 0
 
 	for _, factor := range factors {
 		if factor.Weight > 0 {
 			totalWeight += factor.Weight
+			weightedFactorConfidence += factor.Weight * factor.Confidence
 			activeFactors++
 		}
 	}
 
+	completeness := MaxConfidence
+	if totalWeight > 0 {
+		completeness = weightedFactorConfidence / totalWeight
+	}
+
 	// Base confidence on total weight and number of factors
 	weightConfidence := totalWeight
 	if weightConfidence > MaxConfidence {
@@ -621,7 +904,22 @@ func (s *FraudDetectionService) calculateConfidence(ctx context.Context, config
 		factorConfidence = MaxConfidence
 	}
 
-	return (weightConfidence + factorConfidence) / ConfidenceDivisor
+	return (weightConfidence + factorConfidence) / ConfidenceDivisor * completeness
+}
+
+// dataQualityConfidence returns the confidence in a factor scored from inputs of the given
+// quality. Factors that do not report a quality are assumed to have good inputs.
+func dataQualityConfidence(quality string) float64 {
+	switch quality {
+	case "excellent":
+		return ExcellentDataConfidence
+	case "fair":
+		return FairDataConfidence
+	case "poor":
+		return PoorDataConfidence
+	default:
+		return GoodDataConfidence
+	}
 }
 
 // determineRiskLevel determines the risk level based on the fraud score using configuration.
@@ -707,6 +1005,8 @@ func (s *FraudDetectionService) generateRecommendations(ctx context.Context, con
 				recommendations = append(recommendations, "Verify customer identity and account history")
 			case "documentation":
 				recommendations = append(recommendations, "Request comprehensive supporting documentation")
+			case "coverage_change":
+				recommendations = append(recommendations, "Review the reason for the recent coverage increase")
 			}
 		}
 	}
@@ -714,6 +1014,52 @@ func (s *FraudDetectionService) generateRecommendations(ctx context.Context, con
 	return recommendations
 }
 
+// explainScore ranks factors by weight x score and explains the top contributors.
+func (s *FraudDetectionService) explainScore(score *FraudScore, factors []FraudFactor) *FraudExplanation {
+	totalContribution := 0.0
+	contributions := make([]FraudFactorContribution, 0, len(factors))
+	for _, factor := range factors {
+		contribution := factor.Weight * factor.Score
+		if contribution <= 0 {
+			continue
+		}
+		totalContribution += contribution
+		contributions = append(contributions, FraudFactorContribution{
+			Code:         strings.ToUpper(factor.Factor),
+			Factor:       factor.Factor,
+			Weight:       factor.Weight,
+			Score:        factor.Score,
+			Contribution: contribution,
+			Description:  factor.Description,
+		})
+	}
+
+	sort.SliceStable(contributions, func(i, j int) bool {
+		return contributions[i].Contribution > contributions[j].Contribution
+	})
+	if len(contributions) > MaxExplanationFactors {
+		contributions = contributions[:MaxExplanationFactors]
+	}
+
+	parts := make([]string, len(contributions))
+	for i := range contributions {
+		contributions[i].ContributionPct = math.Round(contributions[i].Contribution/totalContribution*10000) / 100
+		parts[i] = fmt.Sprintf("%s (%.0f%%)", contributions[i].Factor, contributions[i].ContributionPct)
+	}
+
+	summary := fmt.Sprintf("Fraud score %.1f (%s risk)", score.Score, score.RiskLevel)
+	if len(parts) > 0 {
+		summary += " driven by " + strings.Join(parts, ", ")
+	} else {
+		summary += " with no contributing risk factors"
+	}
+
+	return &FraudExplanation{
+		Summary:    summary,
+		TopFactors: contributions,
+	}
+}
+
 // GetFraudScore retrieves a previously calculated fraud score.
 func (s *FraudDetectionService) GetFraudScore(ctx context.Context, claimID uuid.UUID) (*FraudScore, error) {
 	// In a real implementation, this would retrieve from a fraud analysis store
@@ -736,6 +1082,70 @@ func (s *FraudDetectionService) UpdateFraudScore(ctx context.Context, claimID uu
 	return nil
 }
 
+// resolveFactorWeights returns the factor weights for a product category: the global
+// FactorWeights with any category-specific overrides applied on top.
+func resolveFactorWeights(config *config.FraudDetectionConfig, category string) map[string]float64 {
+	overrides, ok := config.CategoryWeights[category]
+	if !ok || category == "" {
+		return config.FactorWeights
+	}
+
+	weights := make(map[string]float64, len(config.FactorWeights)+len(overrides))
+	for factor, weight := range config.FactorWeights {
+		weights[factor] = weight
+	}
+	for factor, weight := range overrides {
+		weights[factor] = weight
+	}
+	return weights
+}
+
+// incidentLocalTime returns the incident date in the claim's timezone, falling back to the
+// customer's timezone. Without a valid timezone the incident date is used as recorded.
+func incidentLocalTime(claim *models.Claim, customer *models.Customer) time.Time {
+	timezones := []string{claim.Timezone}
+	if customer != nil {
+		timezones = append(timezones, customer.Timezone)
+	}
+
+	for _, tz := range timezones {
+		if tz == "" {
+			continue
+		}
+		if loc, err := time.LoadLocation(tz); err == nil {
+			return claim.IncidentDate.In(loc)
+		}
+	}
+
+	return claim.IncidentDate
+}
+
+// isWeekend reports whether t falls on a configured weekend day.
+func isWeekend(config *config.FraudDetectionConfig, t time.Time) bool {
+	weekendDays := config.TimingRules.WeekendDays
+	if len(weekendDays) == 0 {
+		weekendDays = []time.Weekday{time.Saturday, time.Sunday}
+	}
+
+	for _, day := range weekendDays {
+		if t.Weekday() == day {
+			return true
+		}
+	}
+	return false
+}
+
+// isBusinessHours reports whether t falls within the configured business hours [start, end).
+func isBusinessHours(config *config.FraudDetectionConfig, t time.Time) bool {
+	start, end := config.TimingRules.BusinessHourStart, config.TimingRules.BusinessHourEnd
+	if start == 0 && end == 0 {
+		start, end = BusinessHourStart, BusinessHourEnd
+	}
+
+	hour := t.Hour()
+	return hour >= start && hour < end
+}
+
 // contains checks if a slice contains a string.
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/edsonmichaque/bazaruto/internal/store"
+	"go.uber.org/zap"
+)
+
+// SetEndorsementStore sets the store of policy endorsements and enables the coverage_change
+// factor, which flags coverage increased shortly before the incident.
+func (s *FraudDetectionService) SetEndorsementStore(endorsementStore store.EndorsementStore) {
+	s.endorsementStore = endorsementStore
+	s.RegisterEvaluator(NewFraudFactorEvaluator("coverage_change", "coverage_change", s.analyzeCoverageChanges))
+}
+
+// analyzeCoverageChanges scores coverage increases that took effect within the lookback window
+// before the incident. Increases closer to the incident score higher, and coverage that at least
+// doubled adds to the score. The highest scoring increase is reported.
+func (s *FraudDetectionService) analyzeCoverageChanges(ctx context.Context, config *config.FraudDetectionConfig, claim *models.Claim, customer *models.Customer, policy *models.Policy) FraudFactor {
+	factor := FraudFactor{
+		Factor:   "coverage_change",
+		Weight:   config.FactorWeights["coverage_change"],
+		Severity: "low",
+	}
+
+	rules := config.CoverageChangeRules
+	if s.endorsementStore == nil || rules.LookbackDays <= 0 {
+		factor.Description = "Coverage change history not checked"
+		return factor
+	}
+
+	from := claim.IncidentDate.AddDate(0, 0, -rules.LookbackDays)
+	endorsements, err := s.endorsementStore.ListEndorsementsEffectiveBetween(ctx, policy.ID, from, claim.IncidentDate)
+	if err != nil {
+		s.logger.Warn("Failed to load coverage change history",
+			zap.String("policy_id", policy.ID.String()),
+			zap.Error(err))
+		factor.Description = "Coverage change history unavailable"
+		return factor
+	}
+
+	for _, endorsement := range endorsements {
+		if endorsement.PreviousCoverage <= 0 || endorsement.NewCoverage <= endorsement.PreviousCoverage {
+			continue
+		}
+		increase := endorsement.NewCoverage/endorsement.PreviousCoverage - 1
+		if increase < rules.MinIncreaseRatio {
+			continue
+		}
+
+		daysBefore := claim.IncidentDate.Sub(endorsement.EffectiveDate).Hours() / 24
+		score := rules.IncreaseScore
+		if daysBefore <= float64(rules.HighRiskDays) {
+			score = rules.HighRiskScore
+		}
+		if increase >= 1 {
+			score += rules.DoubledScore
+		}
+		score = math.Min(score, 100)
+
+		if score > factor.Score {
+			factor.Score = score
+			factor.Description = fmt.Sprintf("Coverage increased %.0f%% (%.2f to %.2f) %.0f days before the incident",
+				increase*100, endorsement.PreviousCoverage, endorsement.NewCoverage, daysBefore)
+		}
+	}
+
+	switch {
+	case factor.Score >= MediumSeverityScore:
+		factor.Severity = "high"
+	case factor.Score >= ModerateSeverityScore:
+		factor.Severity = "medium"
+	case factor.Score == 0:
+		factor.Description = fmt.Sprintf("No coverage increases in the %d days before the incident", rules.LookbackDays)
+	}
+
+	return factor
+}
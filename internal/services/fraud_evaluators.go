@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+)
+
+// FraudFactorEvaluator evaluates a single fraud risk factor for a claim.
+// Evaluators are registered with the FraudDetectionService and can be disabled
+// through FraudDetectionConfig.EnabledFactors without code changes.
+type FraudFactorEvaluator interface {
+	// Name returns the factor name reported in FraudFactor.Factor.
+	Name() string
+	// Weight returns the factor's weight in the overall score.
+	Weight(config *config.FraudDetectionConfig) float64
+	// Evaluate scores the factor for the claim.
+	Evaluate(ctx context.Context, claim *models.Claim, customer *models.Customer, policy *models.Policy, config *config.FraudDetectionConfig) FraudFactor
+}
+
+// fraudFactorEvaluateFunc scores a fraud factor.
+type fraudFactorEvaluateFunc func(ctx context.Context, config *config.FraudDetectionConfig, claim *models.Claim, customer *models.Customer, policy *models.Policy) FraudFactor
+
+// configWeightedEvaluator is a FraudFactorEvaluator whose weight is read from
+// FraudDetectionConfig.FactorWeights.
+type configWeightedEvaluator struct {
+	name      string
+	weightKey string
+	evaluate  fraudFactorEvaluateFunc
+}
+
+// NewFraudFactorEvaluator creates an evaluator named name whose weight is FactorWeights[weightKey].
+func NewFraudFactorEvaluator(name, weightKey string, evaluate func(ctx context.Context, config *config.FraudDetectionConfig, claim *models.Claim, customer *models.Customer, policy *models.Policy) FraudFactor) FraudFactorEvaluator {
+	return &configWeightedEvaluator{
+		name:      name,
+		weightKey: weightKey,
+		evaluate:  evaluate,
+	}
+}
+
+// Name returns the factor name.
+func (e *configWeightedEvaluator) Name() string {
+	return e.name
+}
+
+// Weight returns the configured weight of the factor.
+func (e *configWeightedEvaluator) Weight(config *config.FraudDetectionConfig) float64 {
+	return config.FactorWeights[e.weightKey]
+}
+
+// Evaluate scores the factor.
+func (e *configWeightedEvaluator) Evaluate(ctx context.Context, claim *models.Claim, customer *models.Customer, policy *models.Policy, config *config.FraudDetectionConfig) FraudFactor {
+	return e.evaluate(ctx, config, claim, customer, policy)
+}
+
+// defaultFraudEvaluators returns the built-in fraud factor evaluators in evaluation order.
+func (s *FraudDetectionService) defaultFraudEvaluators() []FraudFactorEvaluator {
+	return []FraudFactorEvaluator{
+		NewFraudFactorEvaluator("claim_timing", "claim_timing", s.analyzeClaimTiming),
+		NewFraudFactorEvaluator("claim_amount", "claim_amount",
+			func(ctx context.Context, config *config.FraudDetectionConfig, claim *models.Claim, customer *models.Customer, policy *models.Policy) FraudFactor {
+				return s.analyzeClaimAmount(ctx, config, claim, policy)
+			}),
+		NewFraudFactorEvaluator("customer_history", "user_history",
+			func(ctx context.Context, config *config.FraudDetectionConfig, claim *models.Claim, customer *models.Customer, policy *models.Policy) FraudFactor {
+				return s.analyzeCustomerHistory(ctx, config, claim, customer)
+			}),
+		NewFraudFactorEvaluator("incident_patterns", "incident_patterns", s.analyzeIncidentPatterns),
+		NewFraudFactorEvaluator("documentation", "documentation",
+			func(ctx context.Context, config *config.FraudDetectionConfig, claim *models.Claim, customer *models.Customer, policy *models.Policy) FraudFactor {
+				return s.analyzeDocumentation(ctx, config, claim)
+			}),
+		NewFraudFactorEvaluator("geographic_risk", "geographic_risk",
+			func(ctx context.Context, config *config.FraudDetectionConfig, claim *models.Claim, customer *models.Customer, policy *models.Policy) FraudFactor {
+				return s.analyzeGeographicRisk(ctx, config, claim, customer)
+			}),
+		NewFraudFactorEvaluator("behavioral_patterns", "behavioral_patterns",
+			func(ctx context.Context, config *config.FraudDetectionConfig, claim *models.Claim, customer *models.Customer, policy *models.Policy) FraudFactor {
+				return s.analyzeBehavioralPatterns(ctx, config, claim, customer)
+			}),
+		NewFraudFactorEvaluator("policy_history", "policy_history",
+			func(ctx context.Context, config *config.FraudDetectionConfig, claim *models.Claim, customer *models.Customer, policy *models.Policy) FraudFactor {
+				return s.analyzePolicyHistory(ctx, config, claim, policy)
+			}),
+		NewFraudFactorEvaluator("submission_metadata", "submission_metadata",
+			func(ctx context.Context, config *config.FraudDetectionConfig, claim *models.Claim, customer *models.Customer, policy *models.Policy) FraudFactor {
+				return s.analyzeSubmissionMetadata(ctx, config, claim, customer)
+			}),
+		NewFraudFactorEvaluator("document_consistency", "document_consistency",
+			func(ctx context.Context, config *config.FraudDetectionConfig, claim *models.Claim, customer *models.Customer, policy *models.Policy) FraudFactor {
+				return s.analyzeDocumentConsistency(ctx, config, claim)
+			}),
+	}
+}
+
+// RegisterEvaluator adds a fraud factor evaluator, replacing any registered evaluator with the same name.
+func (s *FraudDetectionService) RegisterEvaluator(evaluator FraudFactorEvaluator) {
+	s.evaluatorsMu.Lock()
+	defer s.evaluatorsMu.Unlock()
+
+	for i, existing := range s.evaluators {
+		if existing.Name() == evaluator.Name() {
+			s.evaluators[i] = evaluator
+			return
+		}
+	}
+	s.evaluators = append(s.evaluators, evaluator)
+}
+
+// evaluateFactors runs every registered evaluator that is enabled in config.
+// A factor is enabled unless EnabledFactors explicitly sets it to false. Factors that
+// report no data quality are treated as having good inputs, and factors that report no
+// confidence get the confidence of their data quality.
+func (s *FraudDetectionService) evaluateFactors(ctx context.Context, config *config.FraudDetectionConfig, claim *models.Claim, customer *models.Customer, policy *models.Policy) []FraudFactor {
+	s.evaluatorsMu.RLock()
+	evaluators := make([]FraudFactorEvaluator, len(s.evaluators))
+	copy(evaluators, s.evaluators)
+	s.evaluatorsMu.RUnlock()
+
+	factors := make([]FraudFactor, 0, len(evaluators))
+	for _, evaluator := range evaluators {
+		if enabled, ok := config.EnabledFactors[evaluator.Name()]; ok && !enabled {
+			continue
+		}
+
+		factor := evaluator.Evaluate(ctx, claim, customer, policy, config)
+		factor.Factor = evaluator.Name()
+		factor.Weight = evaluator.Weight(config)
+		if factor.DataQuality == "" {
+			factor.DataQuality = "good"
+		}
+		if factor.Confidence == 0 {
+			factor.Confidence = dataQualityConfidence(factor.DataQuality)
+		}
+		factors = append(factors, factor)
+	}
+
+	return factors
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/google/uuid"
+	"golang.org/x/time/rate"
+)
+
+// ErrFraudAnalysisThrottled is returned when fraud analysis exceeds its configured rate limits.
+var ErrFraudAnalysisThrottled = errors.New("fraud analysis throttled")
+
+// maxTrackedFraudClaims is the number of per-claim limiters kept before fully
+// refilled ones are pruned.
+const maxTrackedFraudClaims = 10000
+
+// fraudRateLimiter applies per-claim and global token bucket limits to fraud analysis.
+// The limiters are rebuilt when the configured limits change.
+type fraudRateLimiter struct {
+	mu     sync.Mutex
+	limits config.FraudRateLimits
+	global *rate.Limiter
+	claims map[uuid.UUID]*rate.Limiter
+	now    func() time.Time
+}
+
+// newFraudRateLimiter creates an empty fraud rate limiter.
+func newFraudRateLimiter() *fraudRateLimiter {
+	return &fraudRateLimiter{
+		claims: make(map[uuid.UUID]*rate.Limiter),
+		now:    time.Now,
+	}
+}
+
+// allow takes a token from the claim's bucket and the global bucket, returning
+// ErrFraudAnalysisThrottled without consuming either when one is empty.
+func (l *fraudRateLimiter) allow(claimID uuid.UUID, limits config.FraudRateLimits) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	claimLimiter := l.limiters(claimID, limits, now)
+
+	claimReservation, ok := reserveNow(claimLimiter, now)
+	if !ok {
+		return ErrFraudAnalysisThrottled
+	}
+	if _, ok := reserveNow(l.global, now); !ok {
+		claimReservation.CancelAt(now)
+		return ErrFraudAnalysisThrottled
+	}
+
+	return nil
+}
+
+// wait takes a token from the claim's bucket and the global bucket, waiting until both
+// are available. If ctx is done first, both tokens are returned and the context error is
+// returned. A limit whose burst can never be met returns ErrFraudAnalysisThrottled.
+func (l *fraudRateLimiter) wait(ctx context.Context, claimID uuid.UUID, limits config.FraudRateLimits) error {
+	l.mu.Lock()
+	now := l.now()
+	claimLimiter := l.limiters(claimID, limits, now)
+	claimReservation := claimLimiter.ReserveN(now, 1)
+	globalReservation := l.global.ReserveN(now, 1)
+	l.mu.Unlock()
+
+	if !claimReservation.OK() || !globalReservation.OK() {
+		claimReservation.CancelAt(now)
+		globalReservation.CancelAt(now)
+		return ErrFraudAnalysisThrottled
+	}
+
+	delay := claimReservation.DelayFrom(now)
+	if globalDelay := globalReservation.DelayFrom(now); globalDelay > delay {
+		delay = globalDelay
+	}
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		claimReservation.Cancel()
+		globalReservation.Cancel()
+		return ctx.Err()
+	}
+}
+
+// limiters returns the claim's limiter, rebuilding every limiter when the configured limits
+// have changed. Callers must hold mu.
+func (l *fraudRateLimiter) limiters(claimID uuid.UUID, limits config.FraudRateLimits, now time.Time) *rate.Limiter {
+	if limits != l.limits || l.global == nil {
+		l.limits = limits
+		l.global = newPerMinuteLimiter(limits.GlobalPerMinute, limits.GlobalBurst)
+		l.claims = make(map[uuid.UUID]*rate.Limiter)
+	}
+
+	claimLimiter, ok := l.claims[claimID]
+	if !ok {
+		if len(l.claims) >= maxTrackedFraudClaims {
+			l.pruneClaims(now)
+		}
+		claimLimiter = newPerMinuteLimiter(limits.PerClaimPerMinute, limits.PerClaimBurst)
+		l.claims[claimID] = claimLimiter
+	}
+	return claimLimiter
+}
+
+// pruneClaims drops per-claim limiters whose buckets have refilled. Callers must hold mu.
+func (l *fraudRateLimiter) pruneClaims(now time.Time) {
+	for claimID, limiter := range l.claims {
+		if limiter.TokensAt(now) >= float64(limiter.Burst()) {
+			delete(l.claims, claimID)
+		}
+	}
+}
+
+// newPerMinuteLimiter creates a token bucket refilled at perMinute tokens a minute.
+// A non-positive rate yields an unlimited limiter.
+func newPerMinuteLimiter(perMinute, burst int) *rate.Limiter {
+	if perMinute <= 0 {
+		return rate.NewLimiter(rate.Inf, 0)
+	}
+	if burst <= 0 {
+		burst = 1
+	}
+	return rate.NewLimiter(rate.Limit(float64(perMinute)/60.0), burst)
+}
+
+// reserveNow takes a token if one is available at now. A reservation that would
+// have to wait is cancelled so the token is returned to the bucket.
+func reserveNow(limiter *rate.Limiter, now time.Time) (*rate.Reservation, bool) {
+	reservation := limiter.ReserveN(now, 1)
+	if !reservation.OK() {
+		return nil, false
+	}
+	if reservation.DelayFrom(now) > 0 {
+		reservation.CancelAt(now)
+		return nil, false
+	}
+	return reservation, true
+}
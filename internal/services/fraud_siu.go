@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// SIUCase is a fraud case referred to the Special Investigations Unit.
+type SIUCase struct {
+	ClaimID        uuid.UUID     `json:"claim_id"`
+	ClaimNumber    string        `json:"claim_number"`
+	PolicyID       uuid.UUID     `json:"policy_id"`
+	CustomerID     uuid.UUID     `json:"customer_id"`
+	ClaimAmount    float64       `json:"claim_amount"`
+	Currency       string        `json:"currency"`
+	Score          float64       `json:"score"`
+	RiskLevel      string        `json:"risk_level"`
+	RequiresReview bool          `json:"requires_review"`
+	Factors        []FraudFactor `json:"factors"`
+	Summary        string        `json:"summary"`
+}
+
+// SIUConnector opens cases in an external Special Investigations Unit system.
+type SIUConnector interface {
+	// OpenCase opens an investigation and returns the external case ID.
+	OpenCase(ctx context.Context, siuCase *SIUCase) (string, error)
+}
+
+// SetSIUConnector sets the connector used to escalate fraud cases to the SIU.
+func (s *FraudDetectionService) SetSIUConnector(connector SIUConnector) {
+	s.siuConnector = connector
+}
+
+// escalateToSIU opens an SIU case for a claim whose analysis meets the escalation rules and
+// records the case ID on the claim. A claim is escalated at most once. Escalation failures are
+// logged and do not fail the analysis.
+func (s *FraudDetectionService) escalateToSIU(ctx context.Context, fraudConfig *config.FraudDetectionConfig, claim *models.Claim, score *FraudScore) {
+	if s.siuConnector == nil || claim.SIUCaseID != "" || !shouldEscalateToSIU(fraudConfig.SIUEscalation, score) {
+		return
+	}
+
+	siuCase := &SIUCase{
+		ClaimID:        claim.ID,
+		ClaimNumber:    claim.ClaimNumber,
+		PolicyID:       claim.PolicyID,
+		CustomerID:     claim.UserID,
+		ClaimAmount:    claim.ClaimAmount,
+		Currency:       claim.Currency,
+		Score:          score.Score,
+		RiskLevel:      score.RiskLevel,
+		RequiresReview: score.RequiresReview,
+		Factors:        score.Factors,
+	}
+	if score.Explanation != nil {
+		siuCase.Summary = score.Explanation.Summary
+	}
+
+	caseID, err := s.siuConnector.OpenCase(ctx, siuCase)
+	if err != nil {
+		s.logger.Error("Failed to open SIU case",
+			zap.String("claim_id", claim.ID.String()),
+			zap.Error(err))
+		return
+	}
+
+	claim.SIUCaseID = caseID
+	if err := s.claimStore.UpdateClaim(ctx, claim); err != nil {
+		s.logger.Error("Failed to record SIU case on claim",
+			zap.String("claim_id", claim.ID.String()),
+			zap.String("siu_case_id", caseID),
+			zap.Error(err))
+	}
+	score.Metadata["siu_case_id"] = caseID
+
+	s.logger.Info("Claim escalated to SIU",
+		zap.String("claim_id", claim.ID.String()),
+		zap.String("siu_case_id", caseID),
+		zap.String("risk_level", score.RiskLevel))
+}
+
+// shouldEscalateToSIU reports whether a fraud score meets the SIU escalation rules.
+func shouldEscalateToSIU(rules config.SIUEscalationConfig, score *FraudScore) bool {
+	if !rules.Enabled {
+		return false
+	}
+	if rules.OnManualReview && score.RequiresReview {
+		return true
+	}
+	return contains(rules.RiskLevels, score.RiskLevel)
+}
@@ -0,0 +1,540 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/metrics"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFraudTestFixture seeds a claim by a customer in a high-risk country, which makes
+// geographic risk the dominant factor under the default weights.
+func newFraudTestFixture(t *testing.T, configManager *config.Manager) (*FraudDetectionService, *models.Claim) {
+	t.Helper()
+
+	customer := &models.Customer{
+		Addresses: []models.CustomerAddress{{Country: "KP", IsPrimary: true, IsActive: true}},
+	}
+	customer.CreatedAt = time.Now().AddDate(-5, 0, 0)
+
+	policy := &models.Policy{CoverageAmount: 100000, EffectiveDate: time.Now().AddDate(-2, 0, 0), ExpirationDate: time.Now().AddDate(1, 0, 0)}
+
+	claim := &models.Claim{
+		ClaimAmount:  2500,
+		Description:  "Water damage to the kitchen floor after a pipe burst overnight while the house was occupied.",
+		IncidentDate: time.Now().AddDate(0, 0, -3),
+		ReportedDate: time.Now().AddDate(0, 0, -2),
+	}
+
+	customerStore := newFakeCustomerStore(customer)
+	policyStore := newFakePolicyStore(policy)
+	claim.UserID = customer.ID
+	claim.PolicyID = policy.ID
+	claimStore := newFakeClaimStore(claim)
+
+	svc := NewFraudDetectionService(logger.NewLogger("error", "json"), configManager, claimStore, policyStore, customerStore, nil)
+	return svc, claim
+}
+
+func TestFraudExplanationRanksTopContributor(t *testing.T) {
+	ctx := context.Background()
+	svc, claim := newFraudTestFixture(t, config.NewManager(logger.NewLogger("error", "json"), ""))
+
+	score, err := svc.AnalyzeClaimForFraud(ctx, claim.ID)
+	require.NoError(t, err)
+	require.NotNil(t, score.Explanation)
+	require.NotEmpty(t, score.Explanation.TopFactors)
+	assert.LessOrEqual(t, len(score.Explanation.TopFactors), MaxExplanationFactors)
+
+	top := score.Explanation.TopFactors[0]
+	assert.Equal(t, "geographic_risk", top.Factor)
+	assert.Equal(t, "GEOGRAPHIC_RISK", top.Code)
+
+	totalPct := 0.0
+	for i, factor := range score.Explanation.TopFactors {
+		if i > 0 {
+			assert.GreaterOrEqual(t, score.Explanation.TopFactors[i-1].Contribution, factor.Contribution)
+		}
+		totalPct += factor.ContributionPct
+	}
+	assert.LessOrEqual(t, totalPct, 100.01)
+	assert.Contains(t, score.Explanation.Summary, "geographic_risk")
+}
+
+func TestIncidentPatternsUseLocalTimezone(t *testing.T) {
+	log := logger.NewLogger("error", "json")
+	configManager := config.NewManager(log, "")
+	fraudConfig := configManager.GetConfig().FraudDetection
+	svc := NewFraudDetectionService(log, configManager, nil, nil, nil, nil)
+
+	// Monday 10:00 in Auckland (UTC+13) is Sunday 21:00 UTC.
+	auckland, err := time.LoadLocation("Pacific/Auckland")
+	require.NoError(t, err)
+	incident := time.Date(2024, 1, 15, 10, 0, 0, 0, auckland).UTC()
+	require.Equal(t, time.Sunday, incident.Weekday())
+
+	claim := &models.Claim{IncidentDate: incident, Timezone: "Pacific/Auckland"}
+	local := svc.analyzeIncidentPatterns(context.Background(), &fraudConfig, claim, nil, nil)
+	assert.Contains(t, local.Description, "Incident occurred on weekday")
+	assert.Contains(t, local.Description, "during business hours")
+	assert.Equal(t, MinimalSeverityScore+BusinessHourAddition, local.Score)
+
+	// The customer's timezone is used when the claim has none.
+	claim.Timezone = ""
+	customer := &models.Customer{Timezone: "Pacific/Auckland"}
+	fromCustomer := svc.analyzeIncidentPatterns(context.Background(), &fraudConfig, claim, customer, nil)
+	assert.Equal(t, local.Score, fromCustomer.Score)
+
+	// Without a timezone the incident is evaluated as recorded (UTC): weekend, outside hours.
+	utc := svc.analyzeIncidentPatterns(context.Background(), &fraudConfig, claim, nil, nil)
+	assert.Contains(t, utc.Description, "Incident occurred on weekend")
+	assert.Contains(t, utc.Description, "outside business hours")
+}
+
+func TestDisabledFraudEvaluatorIsSkipped(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	configManager := config.NewManager(log, filepath.Join(t.TempDir(), "business_rules.json"))
+
+	cfg := configManager.GetConfig()
+	cfg.FraudDetection.EnabledFactors = map[string]bool{"geographic_risk": false, "claim_timing": true}
+	require.NoError(t, configManager.UpdateConfig(ctx, cfg))
+
+	svc, claim := newFraudTestFixture(t, configManager)
+
+	score, err := svc.AnalyzeClaimForFraud(ctx, claim.ID)
+	require.NoError(t, err)
+
+	names := make([]string, len(score.Factors))
+	for i, factor := range score.Factors {
+		names[i] = factor.Factor
+	}
+	assert.NotContains(t, names, "geographic_risk")
+	assert.Contains(t, names, "claim_timing")
+	assert.Len(t, score.Factors, 9)
+}
+
+func TestRegisterFraudEvaluator(t *testing.T) {
+	ctx := context.Background()
+	svc, claim := newFraudTestFixture(t, config.NewManager(logger.NewLogger("error", "json"), ""))
+
+	svc.RegisterEvaluator(NewFraudFactorEvaluator("watchlist", "watchlist",
+		func(ctx context.Context, config *config.FraudDetectionConfig, claim *models.Claim, customer *models.Customer, policy *models.Policy) FraudFactor {
+			return FraudFactor{Score: 90, Severity: "high", Description: "Customer on watchlist"}
+		}))
+
+	score, err := svc.AnalyzeClaimForFraud(ctx, claim.ID)
+	require.NoError(t, err)
+	require.Len(t, score.Factors, 11)
+	assert.Equal(t, "watchlist", score.Factors[10].Factor)
+	assert.Equal(t, 90.0, score.Factors[10].Score)
+}
+
+func TestCategoryFactorWeightsOverrideGlobalWeights(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+
+	analyze := func(configManager *config.Manager, category string) *FraudScore {
+		svc, claim := newFraudTestFixture(t, configManager)
+		policy, err := svc.policyStore.GetPolicy(ctx, claim.PolicyID)
+		require.NoError(t, err)
+		policy.Product.Category = category
+
+		score, err := svc.AnalyzeClaimForFraud(ctx, claim.ID)
+		require.NoError(t, err)
+		return score
+	}
+
+	baseline := config.NewManager(log, "")
+	overridden := config.NewManager(log, filepath.Join(t.TempDir(), "business_rules.json"))
+	cfg := overridden.GetConfig()
+	cfg.FraudDetection.CategoryWeights = map[string]map[string]float64{
+		"auto_insurance": {"geographic_risk": 0.6},
+	}
+	require.NoError(t, overridden.UpdateConfig(ctx, cfg))
+
+	autoBaseline := analyze(baseline, "auto_insurance")
+	autoOverridden := analyze(overridden, "auto_insurance")
+	assert.Greater(t, autoOverridden.Score, autoBaseline.Score)
+	assert.Equal(t, "auto_insurance", autoOverridden.Metadata["product_category"])
+
+	homeBaseline := analyze(baseline, "home_insurance")
+	homeOverridden := analyze(overridden, "home_insurance")
+	assert.InDelta(t, homeBaseline.Score, homeOverridden.Score, 1e-9)
+}
+
+func TestSubmissionMetadataFlagsCountryMismatch(t *testing.T) {
+	log := logger.NewLogger("error", "json")
+	configManager := config.NewManager(log, "")
+	fraudConfig := configManager.GetConfig().FraudDetection
+	svc := NewFraudDetectionService(log, configManager, newFakeClaimStore(), nil, nil, nil)
+
+	customer := &models.Customer{
+		Addresses: []models.CustomerAddress{{Country: "MZ", IsPrimary: true, IsActive: true}},
+	}
+	claim := &models.Claim{UserID: uuid.New(), SubmissionIP: "203.0.113.7", SubmissionCountry: "BR"}
+
+	factor := svc.analyzeSubmissionMetadata(context.Background(), &fraudConfig, claim, customer)
+	assert.Equal(t, fraudConfig.SubmissionRules.CountryMismatchScore, factor.Score)
+	assert.Equal(t, "medium", factor.Severity)
+	assert.Contains(t, factor.Description, "Submitted from BR but customer address is in MZ")
+
+	claim.SubmissionCountry = "MZ"
+	factor = svc.analyzeSubmissionMetadata(context.Background(), &fraudConfig, claim, customer)
+	assert.Zero(t, factor.Score)
+}
+
+func TestSubmissionMetadataFlagsSharedDevice(t *testing.T) {
+	log := logger.NewLogger("error", "json")
+	configManager := config.NewManager(log, "")
+	fraudConfig := configManager.GetConfig().FraudDetection
+
+	other := &models.Claim{UserID: uuid.New(), DeviceFingerprint: "device-1"}
+	claim := &models.Claim{UserID: uuid.New(), DeviceFingerprint: "device-1"}
+	svc := NewFraudDetectionService(log, configManager, newFakeClaimStore(other, claim), nil, nil, nil)
+
+	factor := svc.analyzeSubmissionMetadata(context.Background(), &fraudConfig, claim, nil)
+	assert.Equal(t, fraudConfig.SubmissionRules.SharedDeviceScore, factor.Score)
+	assert.Equal(t, "high", factor.Severity)
+	assert.Contains(t, factor.Description, "Device used by 1 other account(s)")
+}
+
+func TestFraudAnalysisRecordsRiskLevelMetrics(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	configManager := config.NewManager(log, filepath.Join(t.TempDir(), "business_rules.json"))
+
+	// Only a single heavily weighted watchlist factor contributes to the score.
+	cfg := configManager.GetConfig()
+	cfg.FraudDetection.FactorWeights = map[string]float64{"watchlist": 1}
+	require.NoError(t, configManager.UpdateConfig(ctx, cfg))
+
+	svc, claim := newFraudTestFixture(t, configManager)
+	m := metrics.NewMetrics()
+	svc.metrics = m
+	svc.RegisterEvaluator(NewFraudFactorEvaluator("watchlist", "watchlist",
+		func(ctx context.Context, config *config.FraudDetectionConfig, claim *models.Claim, customer *models.Customer, policy *models.Policy) FraudFactor {
+			return FraudFactor{Score: 95, Severity: "critical", Description: "Customer on watchlist"}
+		}))
+	policy, err := svc.policyStore.GetPolicy(ctx, claim.PolicyID)
+	require.NoError(t, err)
+	policy.Product.Category = "auto"
+
+	score, err := svc.AnalyzeClaimForFraud(ctx, claim.ID)
+	require.NoError(t, err)
+	require.Equal(t, "critical", score.RiskLevel)
+	require.True(t, score.RequiresReview)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.FraudRiskLevels.WithLabelValues("critical", "auto")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(m.FraudRiskLevels.WithLabelValues("low", "auto")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.FraudAnalysesTotal.WithLabelValues("auto")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.FraudReviewRequired.WithLabelValues("auto")))
+}
+
+func TestAnalyzeClaimsBatchIsolatesPerClaimFailures(t *testing.T) {
+	ctx := context.Background()
+	svc, claim := newFraudTestFixture(t, config.NewManager(logger.NewLogger("error", "json"), ""))
+	claimStore := svc.claimStore.(*fakeClaimStore)
+
+	claimIDs := []uuid.UUID{claim.ID}
+	for i := 1; i < 49; i++ {
+		seeded := *claim
+		seeded.ID = uuid.New()
+		require.NoError(t, claimStore.UpdateClaim(ctx, &seeded))
+		claimIDs = append(claimIDs, seeded.ID)
+	}
+	missing := uuid.New()
+	claimIDs = append(claimIDs[:20], append([]uuid.UUID{missing}, claimIDs[20:]...)...)
+	require.Len(t, claimIDs, 50)
+
+	report, err := svc.AnalyzeClaimsBatch(ctx, claimIDs)
+	require.NoError(t, err)
+	assert.Equal(t, 49, report.Succeeded)
+	assert.Equal(t, 1, report.Failed)
+	require.Len(t, report.Results, 50)
+
+	for i, result := range report.Results {
+		assert.Equal(t, claimIDs[i], result.ClaimID)
+		if result.ClaimID == missing {
+			assert.Nil(t, result.Score)
+			assert.Contains(t, result.Error, "claim not found")
+			continue
+		}
+		assert.Empty(t, result.Error)
+		assert.NotNil(t, result.Score)
+	}
+}
+
+func TestAnalyzeClaimsBatchStopsWhenContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	svc, claim := newFraudTestFixture(t, config.NewManager(logger.NewLogger("error", "json"), ""))
+	cancel()
+
+	report, err := svc.AnalyzeClaimsBatch(ctx, []uuid.UUID{claim.ID, claim.ID})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 2, report.Failed)
+	assert.Zero(t, report.Succeeded)
+}
+
+func TestAnalyzeClaimForFraudThrottlesPerClaim(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	configManager := config.NewManager(log, filepath.Join(t.TempDir(), "business_rules.json"))
+	cfg := configManager.GetConfig()
+	cfg.FraudDetection.RateLimits = config.FraudRateLimits{PerClaimPerMinute: 60, PerClaimBurst: 2}
+	require.NoError(t, configManager.UpdateConfig(ctx, cfg))
+
+	svc, claim := newFraudTestFixture(t, configManager)
+	now := time.Now()
+	svc.rateLimiter.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		_, err := svc.AnalyzeClaimForFraud(ctx, claim.ID)
+		require.NoError(t, err)
+	}
+
+	_, err := svc.AnalyzeClaimForFraud(ctx, claim.ID)
+	assert.ErrorIs(t, err, ErrFraudAnalysisThrottled)
+
+	// Other claims draw from their own bucket.
+	_, err = svc.AnalyzeClaimForFraud(ctx, uuid.New())
+	assert.NotErrorIs(t, err, ErrFraudAnalysisThrottled)
+
+	// One token is refilled per second at 60 a minute.
+	now = now.Add(time.Second)
+	_, err = svc.AnalyzeClaimForFraud(ctx, claim.ID)
+	require.NoError(t, err)
+}
+
+func TestAnalyzeClaimsBatchWaitsForTheGlobalRateLimit(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	configManager := config.NewManager(log, filepath.Join(t.TempDir(), "business_rules.json"))
+	cfg := configManager.GetConfig()
+	cfg.FraudDetection.RateLimits = config.FraudRateLimits{PerClaimPerMinute: 60, PerClaimBurst: 2, GlobalPerMinute: 6000, GlobalBurst: 5}
+	require.NoError(t, configManager.UpdateConfig(ctx, cfg))
+
+	svc, claim := newFraudTestFixture(t, configManager)
+	claimStore := svc.claimStore.(*fakeClaimStore)
+
+	claimIDs := []uuid.UUID{claim.ID}
+	for i := 1; i < 20; i++ {
+		seeded := *claim
+		seeded.ID = uuid.New()
+		require.NoError(t, claimStore.UpdateClaim(ctx, &seeded))
+		claimIDs = append(claimIDs, seeded.ID)
+	}
+
+	report, err := svc.AnalyzeClaimsBatch(ctx, claimIDs)
+	require.NoError(t, err)
+	assert.Equal(t, 20, report.Succeeded)
+	assert.Zero(t, report.Failed)
+
+	// Interactive callers are still refused once the bucket is empty.
+	_, err = svc.AnalyzeClaimForFraud(ctx, claim.ID)
+	assert.ErrorIs(t, err, ErrFraudAnalysisThrottled)
+}
+
+// stubSIUConnector records the cases it opens.
+type stubSIUConnector struct {
+	cases []*SIUCase
+}
+
+func (c *stubSIUConnector) OpenCase(ctx context.Context, siuCase *SIUCase) (string, error) {
+	c.cases = append(c.cases, siuCase)
+	return fmt.Sprintf("SIU-%d", len(c.cases)), nil
+}
+
+func TestCriticalFraudScoreOpensSIUCase(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	configManager := config.NewManager(log, filepath.Join(t.TempDir(), "business_rules.json"))
+
+	// Only the watchlist factor contributes, so its score sets the risk level.
+	cfg := configManager.GetConfig()
+	cfg.FraudDetection.FactorWeights = map[string]float64{"watchlist": 1}
+	require.NoError(t, configManager.UpdateConfig(ctx, cfg))
+
+	// analyze scores a fresh claim whose watchlist factor scores watchlistScore.
+	analyze := func(watchlistScore float64) (*models.Claim, *stubSIUConnector) {
+		svc, claim := newFraudTestFixture(t, configManager)
+		connector := &stubSIUConnector{}
+		svc.SetSIUConnector(connector)
+		svc.RegisterEvaluator(NewFraudFactorEvaluator("watchlist", "watchlist",
+			func(ctx context.Context, config *config.FraudDetectionConfig, claim *models.Claim, customer *models.Customer, policy *models.Policy) FraudFactor {
+				return FraudFactor{Score: watchlistScore, Severity: "low", Description: "Watchlist check"}
+			}))
+
+		_, err := svc.AnalyzeClaimForFraud(ctx, claim.ID)
+		require.NoError(t, err)
+		return claim, connector
+	}
+
+	claim, connector := analyze(95)
+	require.Len(t, connector.cases, 1)
+	siuCase := connector.cases[0]
+	assert.Equal(t, claim.ID, siuCase.ClaimID)
+	assert.Equal(t, "critical", siuCase.RiskLevel)
+	assert.InDelta(t, 95, siuCase.Score, 1e-9)
+	require.NotEmpty(t, siuCase.Factors)
+	assert.Equal(t, "SIU-1", claim.SIUCaseID)
+
+	claim, connector = analyze(5)
+	assert.Empty(t, connector.cases)
+	assert.Empty(t, claim.SIUCaseID)
+}
+
+func TestCoverageIncreaseBeforeIncidentFlagsHigh(t *testing.T) {
+	ctx := context.Background()
+	svc, claim := newFraudTestFixture(t, config.NewManager(logger.NewLogger("error", "json"), ""))
+	endorsements := &fakeEndorsementStore{}
+	svc.SetEndorsementStore(endorsements)
+
+	require.NoError(t, endorsements.CreateEndorsement(ctx, &models.PolicyEndorsement{
+		PolicyID:         claim.PolicyID,
+		EffectiveDate:    claim.IncidentDate.AddDate(0, 0, -2),
+		PreviousCoverage: 50000,
+		NewCoverage:      100000,
+	}))
+
+	score, err := svc.AnalyzeClaimForFraud(ctx, claim.ID)
+	require.NoError(t, err)
+
+	var factor *FraudFactor
+	for i := range score.Factors {
+		if score.Factors[i].Factor == "coverage_change" {
+			factor = &score.Factors[i]
+		}
+	}
+	require.NotNil(t, factor, "coverage_change factor should be evaluated")
+	assert.Equal(t, "high", factor.Severity)
+	assert.Equal(t, 100.0, factor.Score)
+	assert.Contains(t, factor.Description, "Coverage increased 100%")
+	assert.Contains(t, score.Recommendations, "Review the reason for the recent coverage increase")
+}
+
+func TestCoverageIncreaseLongBeforeIncidentIsNotFlagged(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	configManager := config.NewManager(log, "")
+	fraudConfig := configManager.GetConfig().FraudDetection
+	svc := NewFraudDetectionService(log, configManager, nil, nil, nil, nil)
+	endorsements := &fakeEndorsementStore{}
+	svc.SetEndorsementStore(endorsements)
+
+	policy := &models.Policy{Base: models.Base{ID: uuid.New()}}
+	claim := &models.Claim{PolicyID: policy.ID, IncidentDate: time.Now().AddDate(0, 0, -3)}
+	require.NoError(t, endorsements.CreateEndorsement(ctx, &models.PolicyEndorsement{
+		PolicyID:         policy.ID,
+		EffectiveDate:    claim.IncidentDate.AddDate(-1, 0, 0),
+		PreviousCoverage: 50000,
+		NewCoverage:      100000,
+	}))
+
+	factor := svc.analyzeCoverageChanges(ctx, &fraudConfig, claim, nil, policy)
+	assert.Equal(t, "low", factor.Severity)
+	assert.Zero(t, factor.Score)
+}
+
+func TestFraudScoreRecordsLiveConfigVersion(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	configManager := config.NewManager(log, filepath.Join(t.TempDir(), "business_rules.json"))
+	svc, claim := newFraudTestFixture(t, configManager)
+
+	score, err := svc.AnalyzeClaimForFraud(ctx, claim.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "1.0", score.Metadata["config_version"])
+
+	cfg := configManager.GetConfig()
+	cfg.FraudDetection.Version = "1.1"
+	require.NoError(t, configManager.UpdateConfig(ctx, cfg))
+
+	score, err = svc.AnalyzeClaimForFraud(ctx, claim.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "1.1", score.Metadata["config_version"])
+}
+
+func TestReplayFraudAnalysisUsesArchivedConfig(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	configManager := config.NewManager(log, filepath.Join(t.TempDir(), "business_rules.json"))
+	require.NoError(t, configManager.UpdateConfig(ctx, configManager.GetConfig()))
+	svc, claim := newFraudTestFixture(t, configManager)
+
+	original, err := svc.AnalyzeClaimForFraud(ctx, claim.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "1.0", original.Metadata["rules_version"])
+
+	cfg := configManager.GetConfig()
+	cfg.Version = "2.0"
+	weights := make(map[string]float64, len(cfg.FraudDetection.FactorWeights))
+	for factor, weight := range cfg.FraudDetection.FactorWeights {
+		weights[factor] = weight
+	}
+	weights["geographic_risk"] = 0.01
+	cfg.FraudDetection.FactorWeights = weights
+	require.NoError(t, configManager.UpdateConfig(ctx, cfg))
+
+	current, err := svc.AnalyzeClaimForFraud(ctx, claim.ID)
+	require.NoError(t, err)
+	require.Less(t, current.Score, original.Score)
+
+	replayed, err := svc.ReplayFraudAnalysis(ctx, claim.ID, "1.0")
+	require.NoError(t, err)
+	assert.InDelta(t, original.Score, replayed.Score, 1e-9)
+	assert.Equal(t, original.RiskLevel, replayed.RiskLevel)
+	assert.Equal(t, true, replayed.Metadata["replayed"])
+
+	_, err = svc.ReplayFraudAnalysis(ctx, claim.ID, "0.9")
+	assert.ErrorIs(t, err, config.ErrConfigVersionNotFound)
+}
+
+func TestIncompleteInputsLowerFraudConfidence(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+
+	complete, completeClaim := newFraudTestFixture(t, config.NewManager(log, ""))
+	completeClaim.Documents = []models.Document{{FileName: "photo.jpg", FileSize: 200000}, {FileName: "invoice.pdf", FileSize: 150000}}
+	completeScore, err := complete.AnalyzeClaimForFraud(ctx, completeClaim.ID)
+	require.NoError(t, err)
+
+	incomplete, incompleteClaim := newFraudTestFixture(t, config.NewManager(log, ""))
+	customer, err := incomplete.customerStore.GetByID(ctx, incompleteClaim.UserID)
+	require.NoError(t, err)
+	customer.Addresses = nil
+	incompleteScore, err := incomplete.AnalyzeClaimForFraud(ctx, incompleteClaim.ID)
+	require.NoError(t, err)
+
+	assert.Less(t, incompleteScore.Confidence, completeScore.Confidence)
+	qualities := make(map[string]string)
+	for _, factor := range incompleteScore.Factors {
+		qualities[factor.Factor] = factor.DataQuality
+	}
+	assert.Equal(t, "poor", qualities["geographic_risk"])
+	assert.Equal(t, "poor", qualities["documentation"])
+
+	// With the factor scores held equal, poorer inputs alone lower the confidence.
+	degraded := make([]FraudFactor, len(completeScore.Factors))
+	copy(degraded, completeScore.Factors)
+	for i := range degraded {
+		if degraded[i].Factor == "geographic_risk" || degraded[i].Factor == "documentation" {
+			degraded[i].DataQuality = "poor"
+			degraded[i].Confidence = dataQualityConfidence("poor")
+		}
+	}
+	fraudConfig := config.NewManager(log, "").GetConfig().FraudDetection
+	assert.Less(t,
+		complete.calculateConfidence(ctx, &fraudConfig, degraded),
+		complete.calculateConfidence(ctx, &fraudConfig, completeScore.Factors))
+}
@@ -0,0 +1,366 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/events"
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/edsonmichaque/bazaruto/internal/store"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// overdueInvoiceBatchSize is the number of overdue invoices loaded per page.
+const overdueInvoiceBatchSize = 100
+
+// InvoiceReminderFunc sends a payment reminder for an overdue invoice.
+type InvoiceReminderFunc func(ctx context.Context, invoice *models.Invoice) error
+
+// InvoiceService generates and tracks invoices for policy issuance and renewal.
+type InvoiceService struct {
+	invoiceStore  store.InvoiceStore
+	policyStore   store.PolicyStore
+	eventService  *EventService
+	configManager *config.Manager
+	logger        *logger.Logger
+}
+
+// NewInvoiceService creates a new InvoiceService instance.
+func NewInvoiceService(
+	logger *logger.Logger,
+	configManager *config.Manager,
+	invoiceStore store.InvoiceStore,
+	policyStore store.PolicyStore,
+	eventService *EventService,
+) *InvoiceService {
+	return &InvoiceService{
+		invoiceStore:  invoiceStore,
+		policyStore:   policyStore,
+		eventService:  eventService,
+		configManager: configManager,
+		logger:        logger,
+	}
+}
+
+// GeneratePolicyInvoice creates a draft invoice for a policy on issuance or renewal.
+// When a pricing breakdown is available the line items mirror it and the breakdown's tax is
+// used as-is; otherwise the policy premium is billed and tax is applied at the default rate.
+func (s *InvoiceService) GeneratePolicyInvoice(ctx context.Context, policy *models.Policy, reason string, breakdown *PricingBreakdown) (*models.Invoice, error) {
+	if policy == nil || policy.ID == uuid.Nil {
+		return nil, fmt.Errorf("policy is required")
+	}
+
+	invoice, err := s.newPolicyInvoice(policy, reason, breakdown)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.invoiceStore.CreateInvoice(ctx, invoice); err != nil {
+		return nil, fmt.Errorf("failed to create invoice: %w", err)
+	}
+
+	s.publishInvoiceCreated(ctx, invoice)
+	return invoice, nil
+}
+
+// newPolicyInvoice builds, without storing it, the draft invoice billing a policy on issuance
+// or renewal.
+func (s *InvoiceService) newPolicyInvoice(policy *models.Policy, reason string, breakdown *PricingBreakdown) (*models.Invoice, error) {
+	if reason != models.InvoiceReasonIssuance && reason != models.InvoiceReasonRenewal {
+		return nil, fmt.Errorf("invalid invoice reason: %s", reason)
+	}
+
+	cfg := s.configManager.GetConfig()
+	billing := cfg.PolicyLifecycle.BillingRules
+
+	items := s.buildLineItems(policy.Premium, breakdown, cfg.Pricing.TaxRules.DefaultRate, billing.PolicyFee)
+
+	amount := 0.0
+	tax := 0.0
+	for _, item := range items {
+		if item.Type == models.InvoiceItemTypeTax {
+			tax += item.Total
+			continue
+		}
+		amount += item.Total
+	}
+
+	invoice := &models.Invoice{
+		InvoiceNumber: s.generateInvoiceNumber(),
+		UserID:        policy.UserID,
+		Amount:        roundCurrency(amount),
+		Currency:      policy.Currency,
+		Tax:           roundCurrency(tax),
+		Total:         roundCurrency(amount + tax),
+		Status:        models.InvoiceStatusDraft,
+		Reason:        reason,
+		DueDate:       time.Now().AddDate(0, 0, billing.PaymentTermDays),
+		Items:         items,
+	}
+	if policy.ID != uuid.Nil {
+		invoice.PolicyID = &policy.ID
+	}
+	return invoice, nil
+}
+
+// publishInvoiceCreated notifies subscribers of a stored policy invoice.
+func (s *InvoiceService) publishInvoiceCreated(ctx context.Context, invoice *models.Invoice) {
+	if s.eventService == nil || invoice.PolicyID == nil {
+		return
+	}
+
+	invoiceEvent := events.NewInvoiceCreatedEvent(
+		invoice.ID,
+		invoice.InvoiceNumber,
+		invoice.UserID,
+		*invoice.PolicyID,
+		invoice.Reason,
+		invoice.Amount,
+		invoice.Tax,
+		invoice.Total,
+		invoice.Currency,
+		invoice.DueDate,
+		time.Now(),
+	)
+	if err := s.eventService.PublishEvent(ctx, invoiceEvent); err != nil {
+		s.logger.Error("Failed to publish invoice created event",
+			zap.Error(err),
+			zap.String("invoice_id", invoice.ID.String()))
+	}
+}
+
+// MarkSent marks a draft invoice as sent to the customer.
+func (s *InvoiceService) MarkSent(ctx context.Context, invoiceID uuid.UUID) (*models.Invoice, error) {
+	invoice, err := s.invoiceStore.GetInvoice(ctx, invoiceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch invoice: %w", err)
+	}
+
+	if invoice.Status != models.InvoiceStatusDraft {
+		return nil, fmt.Errorf("only draft invoices can be sent")
+	}
+
+	invoice.Status = models.InvoiceStatusSent
+	if err := s.invoiceStore.UpdateInvoice(ctx, invoice); err != nil {
+		return nil, fmt.Errorf("failed to update invoice: %w", err)
+	}
+
+	return invoice, nil
+}
+
+// MarkPaid records payment of an invoice.
+func (s *InvoiceService) MarkPaid(ctx context.Context, invoiceID uuid.UUID, paymentID *uuid.UUID) (*models.Invoice, error) {
+	invoice, err := s.invoiceStore.GetInvoice(ctx, invoiceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch invoice: %w", err)
+	}
+
+	if invoice.Status == models.InvoiceStatusPaid {
+		return nil, fmt.Errorf("invoice is already paid")
+	}
+
+	now := time.Now()
+	invoice.Status = models.InvoiceStatusPaid
+	invoice.PaidAt = &now
+	invoice.PaymentID = paymentID
+
+	if err := s.invoiceStore.UpdateInvoice(ctx, invoice); err != nil {
+		return nil, fmt.Errorf("failed to update invoice: %w", err)
+	}
+
+	return invoice, nil
+}
+
+// RecordPolicyPayment marks the oldest unpaid invoice of a policy paid by a completed
+// payment. It is idempotent: an invoice already paid by the payment is returned unchanged.
+// It returns nil when the policy has no unpaid invoice.
+func (s *InvoiceService) RecordPolicyPayment(ctx context.Context, policyID, paymentID uuid.UUID) (*models.Invoice, error) {
+	invoices, err := s.invoiceStore.ListInvoices(ctx, nil, &policyID, nil, "", 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list policy invoices: %w", err)
+	}
+
+	var unpaid *models.Invoice
+	for _, invoice := range invoices {
+		if invoice.PaymentID != nil && *invoice.PaymentID == paymentID {
+			return invoice, nil
+		}
+		if invoice.Status == models.InvoiceStatusPaid {
+			continue
+		}
+		if unpaid == nil || invoice.DueDate.Before(unpaid.DueDate) {
+			unpaid = invoice
+		}
+	}
+	if unpaid == nil {
+		return nil, nil
+	}
+
+	return s.MarkPaid(ctx, unpaid.ID, &paymentID)
+}
+
+// OverdueInvoiceResult summarizes an overdue invoice processing run.
+type OverdueInvoiceResult struct {
+	Processed         int      `json:"processed"`
+	MarkedOverdue     int      `json:"marked_overdue"`
+	Reminded          int      `json:"reminded"`
+	PoliciesSuspended int      `json:"policies_suspended"`
+	Errors            []string `json:"errors,omitempty"`
+}
+
+// ProcessOverdueInvoices marks unpaid invoices past their due date as overdue and sends a
+// reminder when an invoice first becomes overdue. Policies whose invoice stays unpaid beyond
+// the configured overdue grace are suspended. Failures on a single invoice are recorded in
+// the result without aborting the run.
+func (s *InvoiceService) ProcessOverdueInvoices(ctx context.Context, asOf time.Time, remind InvoiceReminderFunc) (*OverdueInvoiceResult, error) {
+	graceDays := s.configManager.GetConfig().PolicyLifecycle.BillingRules.OverdueGraceDays
+	result := &OverdueInvoiceResult{}
+
+	for offset := 0; ; offset += overdueInvoiceBatchSize {
+		invoices, err := s.invoiceStore.GetOverdue(ctx, asOf, overdueInvoiceBatchSize, offset)
+		if err != nil {
+			return result, fmt.Errorf("failed to get overdue invoices: %w", err)
+		}
+
+		for _, invoice := range invoices {
+			if err := ctx.Err(); err != nil {
+				return result, err
+			}
+			result.Processed++
+
+			if invoice.Status != models.InvoiceStatusOverdue {
+				invoice.Status = models.InvoiceStatusOverdue
+				if err := s.invoiceStore.UpdateInvoice(ctx, invoice); err != nil {
+					result.Errors = append(result.Errors, fmt.Sprintf("invoice %s: %v", invoice.InvoiceNumber, err))
+					continue
+				}
+				result.MarkedOverdue++
+
+				if remind != nil {
+					if err := remind(ctx, invoice); err != nil {
+						s.logger.Error("Failed to send overdue invoice reminder",
+							zap.Error(err),
+							zap.String("invoice_id", invoice.ID.String()))
+						result.Errors = append(result.Errors, fmt.Sprintf("invoice %s reminder: %v", invoice.InvoiceNumber, err))
+					} else {
+						result.Reminded++
+					}
+				}
+			}
+
+			if invoice.PolicyID == nil || asOf.Before(invoice.DueDate.AddDate(0, 0, graceDays)) {
+				continue
+			}
+
+			suspended, err := s.suspendPolicy(ctx, *invoice.PolicyID)
+			if err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("invoice %s suspension: %v", invoice.InvoiceNumber, err))
+				continue
+			}
+			if suspended {
+				result.PoliciesSuspended++
+			}
+		}
+
+		if len(invoices) < overdueInvoiceBatchSize {
+			break
+		}
+	}
+
+	s.logger.Info("Overdue invoices processed",
+		zap.Int("processed", result.Processed),
+		zap.Int("marked_overdue", result.MarkedOverdue),
+		zap.Int("reminded", result.Reminded),
+		zap.Int("policies_suspended", result.PoliciesSuspended))
+
+	return result, nil
+}
+
+// suspendPolicy suspends an active policy for non-payment. It reports whether the policy was changed.
+func (s *InvoiceService) suspendPolicy(ctx context.Context, policyID uuid.UUID) (bool, error) {
+	policy, err := s.policyStore.GetPolicy(ctx, policyID)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch policy: %w", err)
+	}
+
+	_, suspended, err := updatePolicyWithRetry(ctx, s.policyStore, policy, func(policy *models.Policy) (bool, error) {
+		if policy.Status != models.PolicyStatusActive {
+			return false, nil
+		}
+		return true, policy.TransitionTo(models.PolicyStatusSuspended)
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to suspend policy: %w", err)
+	}
+	if !suspended {
+		return false, nil
+	}
+
+	s.logger.Warn("Policy suspended for overdue invoice",
+		zap.String("policy_id", policyID.String()))
+
+	return true, nil
+}
+
+// buildLineItems builds the invoice line items for a premium.
+func (s *InvoiceService) buildLineItems(premium float64, breakdown *PricingBreakdown, taxRate, fee float64) []models.InvoiceItem {
+	var items []models.InvoiceItem
+
+	if breakdown != nil {
+		grossPremium := breakdown.BaseRate +
+			breakdown.CoverageAdjustment +
+			breakdown.RiskAdjustment +
+			breakdown.FrequencyAdjustment +
+			breakdown.MarketAdjustment +
+			breakdown.BoundsAdjustment +
+			breakdown.RoundingAdjustment
+		items = append(items, newInvoiceItem(models.InvoiceItemTypePremium, "Insurance premium", grossPremium))
+		if breakdown.DiscountAdjustment != 0 {
+			items = append(items, newInvoiceItem(models.InvoiceItemTypeDiscount, "Discounts", breakdown.DiscountAdjustment))
+		}
+		if fee > 0 {
+			items = append(items, newInvoiceItem(models.InvoiceItemTypeFee, "Policy fee", fee))
+		}
+		items = append(items, newInvoiceItem(models.InvoiceItemTypeTax, "Insurance tax", breakdown.TaxAdjustment))
+		return items
+	}
+
+	items = append(items, newInvoiceItem(models.InvoiceItemTypePremium, "Insurance premium", premium))
+	if fee > 0 {
+		items = append(items, newInvoiceItem(models.InvoiceItemTypeFee, "Policy fee", fee))
+	}
+	items = append(items, newInvoiceItem(models.InvoiceItemTypeTax,
+		fmt.Sprintf("Insurance tax (%.1f%%)", taxRate*100), (premium+fee)*taxRate))
+
+	return items
+}
+
+// generateInvoiceNumber generates a unique invoice number.
+func (s *InvoiceService) generateInvoiceNumber() string {
+	// Generate an invoice number with timestamp and random component
+	timestamp := time.Now().Format("20060102150405")
+	random := rand.Intn(9999)
+	return fmt.Sprintf("INV-%s-%04d", timestamp, random)
+}
+
+// newInvoiceItem creates a single-quantity invoice line item.
+func newInvoiceItem(itemType, description string, amount float64) models.InvoiceItem {
+	amount = roundCurrency(amount)
+	return models.InvoiceItem{
+		Type:        itemType,
+		Description: description,
+		Quantity:    1,
+		UnitPrice:   amount,
+		Total:       amount,
+	}
+}
+
+// roundCurrency rounds an amount to two decimal places.
+func roundCurrency(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
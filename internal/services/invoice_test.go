@@ -0,0 +1,289 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenewalGeneratesInvoice(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	configManager := config.NewManager(log, "")
+
+	policy := &models.Policy{
+		ProductID:        uuid.New(),
+		UserID:           uuid.New(),
+		Premium:          1000,
+		Currency:         "USD",
+		CoverageAmount:   50000,
+		Status:           models.PolicyStatusActive,
+		EffectiveDate:    time.Now().AddDate(-1, 0, 10),
+		ExpirationDate:   time.Now().AddDate(0, 0, 10),
+		PaymentFrequency: "annually",
+	}
+	policyStore := newFakePolicyStore(policy)
+	invoiceStore := newFakeInvoiceStore()
+	policyStore.invoices = invoiceStore
+	invoiceService := NewInvoiceService(log, configManager, invoiceStore, policyStore, nil)
+	svc := NewPolicyLifecycleService(log, configManager, policyStore, nil, nil, nil, nil, nil, invoiceService, nil, nil, nil, nil)
+
+	result, err := svc.RenewPolicy(ctx, policy.ID, nil)
+	require.NoError(t, err)
+	require.NotNil(t, result.InvoiceID)
+
+	invoice, err := invoiceStore.GetInvoice(ctx, *result.InvoiceID)
+	require.NoError(t, err)
+
+	expectedTax := roundCurrency(result.Premium * configManager.GetConfig().Pricing.TaxRules.DefaultRate)
+	assert.Equal(t, models.InvoiceReasonRenewal, invoice.Reason)
+	assert.Equal(t, models.InvoiceStatusDraft, invoice.Status)
+	assert.Equal(t, *result.NewPolicyID, *invoice.PolicyID)
+	assert.InDelta(t, expectedTax, invoice.Tax, 0.001)
+	assert.InDelta(t, roundCurrency(result.Premium)+expectedTax, invoice.Total, 0.001)
+	require.Len(t, invoice.Items, 2)
+	assert.Equal(t, models.InvoiceItemTypePremium, invoice.Items[0].Type)
+	assert.Equal(t, models.InvoiceItemTypeTax, invoice.Items[1].Type)
+}
+
+func TestInvoiceLineItemsMirrorPricingBreakdown(t *testing.T) {
+	log := logger.NewLogger("error", "json")
+	invoiceStore := newFakeInvoiceStore()
+	svc := NewInvoiceService(log, config.NewManager(log, ""), invoiceStore, nil, nil)
+
+	policy := &models.Policy{Premium: 1150, Currency: "USD", UserID: uuid.New()}
+	policy.ID = uuid.New()
+	breakdown := &PricingBreakdown{
+		BaseRate:           1000,
+		RiskAdjustment:     150,
+		DiscountAdjustment: -100,
+		TaxAdjustment:      100,
+	}
+
+	invoice, err := svc.GeneratePolicyInvoice(context.Background(), policy, models.InvoiceReasonIssuance, breakdown)
+	require.NoError(t, err)
+
+	require.Len(t, invoice.Items, 3)
+	assert.InDelta(t, 1150, invoice.Items[0].Total, 0.001)
+	assert.Equal(t, models.InvoiceItemTypeDiscount, invoice.Items[1].Type)
+	assert.InDelta(t, 1050, invoice.Amount, 0.001)
+	assert.InDelta(t, 100, invoice.Tax, 0.001)
+	assert.InDelta(t, 1150, invoice.Total, 0.001)
+}
+
+func TestProcessOverdueInvoicesMarksAndReminds(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+
+	policy := &models.Policy{UserID: uuid.New(), Status: models.PolicyStatusActive}
+	policyStore := newFakePolicyStore(policy)
+	now := time.Now()
+	overdue := &models.Invoice{InvoiceNumber: "INV-1", PolicyID: &policy.ID, Status: models.InvoiceStatusSent, DueDate: now.AddDate(0, 0, -1)}
+	current := &models.Invoice{InvoiceNumber: "INV-2", PolicyID: &policy.ID, Status: models.InvoiceStatusSent, DueDate: now.AddDate(0, 0, 5)}
+	invoiceStore := newFakeInvoiceStore(overdue, current)
+	svc := NewInvoiceService(log, config.NewManager(log, ""), invoiceStore, policyStore, nil)
+
+	var reminded []string
+	remind := func(ctx context.Context, invoice *models.Invoice) error {
+		reminded = append(reminded, invoice.InvoiceNumber)
+		return nil
+	}
+
+	result, err := svc.ProcessOverdueInvoices(ctx, now, remind)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, result.MarkedOverdue)
+	assert.Equal(t, []string{"INV-1"}, reminded)
+	assert.Equal(t, models.InvoiceStatusOverdue, overdue.Status)
+	assert.Equal(t, models.InvoiceStatusSent, current.Status)
+	assert.Equal(t, models.PolicyStatusActive, policy.Status)
+
+	// A second run does not remind again
+	result, err = svc.ProcessOverdueInvoices(ctx, now, remind)
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.MarkedOverdue)
+	assert.Len(t, reminded, 1)
+}
+
+func TestProcessOverdueInvoicesSuspendsAfterGrace(t *testing.T) {
+	log := logger.NewLogger("error", "json")
+	configManager := config.NewManager(log, "")
+	graceDays := configManager.GetConfig().PolicyLifecycle.BillingRules.OverdueGraceDays
+
+	policy := &models.Policy{UserID: uuid.New(), Status: models.PolicyStatusActive}
+	now := time.Now()
+	invoice := &models.Invoice{PolicyID: &policy.ID, Status: models.InvoiceStatusOverdue, DueDate: now.AddDate(0, 0, -graceDays-1)}
+	svc := NewInvoiceService(log, configManager, newFakeInvoiceStore(invoice), newFakePolicyStore(policy), nil)
+
+	result, err := svc.ProcessOverdueInvoices(context.Background(), now, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, result.PoliciesSuspended)
+	assert.Equal(t, models.PolicyStatusSuspended, policy.Status)
+}
+
+func TestPaidRenewalInvoiceIsNotProcessedAsOverdue(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	configManager := config.NewManager(log, "")
+
+	policy := &models.Policy{
+		ProductID:        uuid.New(),
+		UserID:           uuid.New(),
+		Premium:          1000,
+		Currency:         "USD",
+		CoverageAmount:   50000,
+		Status:           models.PolicyStatusActive,
+		EffectiveDate:    time.Now().AddDate(-1, 0, 10),
+		ExpirationDate:   time.Now().AddDate(0, 0, 10),
+		PaymentFrequency: "annually",
+	}
+	policyStore := newFakePolicyStore(policy)
+	invoiceStore := newFakeInvoiceStore()
+	policyStore.invoices = invoiceStore
+	invoiceService := NewInvoiceService(log, configManager, invoiceStore, policyStore, nil)
+	svc := NewPolicyLifecycleService(log, configManager, policyStore, newFakePaymentStore(), nil, nil, nil, nil, invoiceService, nil, nil, nil, nil)
+
+	options := svc.getDefaultRenewalOptions(policy)
+	options.PaymentMethod = "card"
+	result, err := svc.RenewPolicy(ctx, policy.ID, options)
+	require.NoError(t, err)
+	require.Equal(t, "renewed", result.Status)
+	require.NotNil(t, result.InvoiceID)
+
+	invoice, err := invoiceStore.GetInvoice(ctx, *result.InvoiceID)
+	require.NoError(t, err)
+	assert.Equal(t, models.InvoiceStatusPaid, invoice.Status)
+	assert.NotNil(t, invoice.PaidAt)
+	assert.NotNil(t, invoice.PaymentID)
+
+	overdue, err := invoiceService.ProcessOverdueInvoices(ctx, invoice.DueDate.AddDate(1, 0, 0), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, overdue.Processed)
+}
+
+func TestRecordPolicyPaymentSettlesOldestUnpaidInvoiceOnce(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+
+	policyID := uuid.New()
+	now := time.Now()
+	older := &models.Invoice{InvoiceNumber: "INV-1", PolicyID: &policyID, Status: models.InvoiceStatusSent, DueDate: now.AddDate(0, 0, -5)}
+	newer := &models.Invoice{InvoiceNumber: "INV-2", PolicyID: &policyID, Status: models.InvoiceStatusDraft, DueDate: now.AddDate(0, 0, 25)}
+	svc := NewInvoiceService(log, config.NewManager(log, ""), newFakeInvoiceStore(older, newer), nil, nil)
+
+	paymentID := uuid.New()
+	paid, err := svc.RecordPolicyPayment(ctx, policyID, paymentID)
+	require.NoError(t, err)
+	require.NotNil(t, paid)
+	assert.Equal(t, "INV-1", paid.InvoiceNumber)
+	assert.Equal(t, models.InvoiceStatusPaid, older.Status)
+	assert.Equal(t, paymentID, *older.PaymentID)
+
+	// Redelivery of the same payment does not settle another invoice
+	paid, err = svc.RecordPolicyPayment(ctx, policyID, paymentID)
+	require.NoError(t, err)
+	assert.Equal(t, "INV-1", paid.InvoiceNumber)
+	assert.Equal(t, models.InvoiceStatusDraft, newer.Status)
+}
+
+func TestRenewalInvoiceMirrorsEngineBreakdownAndIsChargedInFull(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	configManager := config.NewManager(log, "")
+
+	product := &models.Product{Base: models.Base{ID: uuid.New()}, Category: "home"}
+	user := &models.User{Base: models.Base{ID: uuid.New(), CreatedAt: time.Now().AddDate(-3, 0, 0)}}
+	policy := &models.Policy{
+		ProductID:        product.ID,
+		UserID:           user.ID,
+		Premium:          1000,
+		Currency:         "USD",
+		CoverageAmount:   100000,
+		Status:           models.PolicyStatusActive,
+		EffectiveDate:    time.Now().AddDate(-1, 0, 10),
+		ExpirationDate:   time.Now().AddDate(0, 0, 10),
+		PaymentFrequency: "annually",
+	}
+	policyStore := newFakePolicyStore(policy)
+	invoiceStore := newFakeInvoiceStore()
+	policyStore.invoices = invoiceStore
+	payments := newFakePaymentStore()
+	pricing := NewPricingEngineService(log, configManager, newFakeProductStore(product), newFakePolicyStore(), newFakeClaimStore(), newFakeUserStore(user))
+	invoiceService := NewInvoiceService(log, configManager, invoiceStore, policyStore, nil)
+	svc := NewPolicyLifecycleService(log, configManager, policyStore, payments, nil, nil, nil, pricing, invoiceService, nil, nil, nil, nil)
+
+	options := svc.getDefaultRenewalOptions(policy)
+	options.PaymentMethod = "card"
+	result, err := svc.RenewPolicy(ctx, policy.ID, options)
+	require.NoError(t, err)
+	require.NotNil(t, result.InvoiceID)
+
+	renewal, err := policyStore.GetPolicy(ctx, *result.NewPolicyID)
+	require.NoError(t, err)
+	require.NotNil(t, renewal.PricingBreakdown)
+	require.Positive(t, renewal.PricingBreakdown.TaxAdjustment)
+
+	invoice, err := invoiceStore.GetInvoice(ctx, *result.InvoiceID)
+	require.NoError(t, err)
+	// The engine's tax is billed as-is rather than taxing the taxed premium again
+	assert.InDelta(t, renewal.PricingBreakdown.TaxAdjustment, invoice.Tax, 0.01)
+	fee := configManager.GetConfig().PolicyLifecycle.BillingRules.PolicyFee
+	assert.InDelta(t, renewal.Premium+fee, invoice.Total, 0.01)
+
+	charged, err := payments.ListPayments(ctx, nil, &renewal.ID, nil, "", 0, 0)
+	require.NoError(t, err)
+	require.Len(t, charged, 1)
+	assert.Equal(t, invoice.Total, charged[0].Amount)
+}
+
+func TestIssuanceInvoiceUsesQuotedBreakdown(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	configManager := config.NewManager(log, "")
+
+	quote := &models.Quote{
+		FinalPrice: 1150,
+		PricingBreakdown: &models.PricingBreakdown{
+			BaseRate:           1000,
+			RiskAdjustment:     150,
+			DiscountAdjustment: -100,
+			TaxAdjustment:      100,
+		},
+	}
+	quotes := newFakeQuoteStore()
+	require.NoError(t, quotes.CreateQuote(ctx, quote))
+
+	policyStore := newFakePolicyStore()
+	invoiceStore := newFakeInvoiceStore()
+	policyStore.invoices = invoiceStore
+	policies := NewPolicyService(policyStore, NewInvoiceService(log, configManager, invoiceStore, policyStore, nil))
+	policies.SetQuoteStore(quotes)
+
+	policy := &models.Policy{
+		ProductID:        uuid.New(),
+		UserID:           uuid.New(),
+		QuoteID:          &quote.ID,
+		Premium:          1150,
+		Currency:         "USD",
+		CoverageAmount:   50000,
+		EffectiveDate:    time.Now().AddDate(0, 0, 1),
+		ExpirationDate:   time.Now().AddDate(1, 0, 1),
+		PaymentFrequency: "annually",
+	}
+	require.NoError(t, policies.CreatePolicy(ctx, policy))
+
+	invoices, err := invoiceStore.ListInvoices(ctx, nil, &policy.ID, nil, "", 0, 0)
+	require.NoError(t, err)
+	require.Len(t, invoices, 1)
+	assert.InDelta(t, 100, invoices[0].Tax, 0.001)
+	fee := configManager.GetConfig().PolicyLifecycle.BillingRules.PolicyFee
+	assert.InDelta(t, 1150+fee, invoices[0].Total, 0.001)
+}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/edsonmichaque/bazaruto/internal/store"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// ErrKYCReverificationRequired is returned when a customer's KYC verification has expired and
+// must be refreshed before new policies are issued to them.
+var ErrKYCReverificationRequired = errors.New("KYC re-verification required")
+
+// kycExpiryBatchSize is the number of customers expired per store call.
+const kycExpiryBatchSize = 100
+
+// KYCService tracks customers' KYC verification and expires verifications older than the
+// configured KYC update frequency.
+type KYCService struct {
+	configManager *config.Manager
+	customerStore store.CustomerStore
+	now           func() time.Time
+	logger        *logger.Logger
+}
+
+// NewKYCService creates a new KYCService instance.
+func NewKYCService(logger *logger.Logger, configManager *config.Manager, customerStore store.CustomerStore) *KYCService {
+	return &KYCService{
+		configManager: configManager,
+		customerStore: customerStore,
+		now:           time.Now,
+		logger:        logger,
+	}
+}
+
+// RecordVerification marks a customer's KYC as verified now, starting a new re-verification window.
+func (s *KYCService) RecordVerification(ctx context.Context, customerID uuid.UUID) (*models.Customer, error) {
+	customer, err := s.customerStore.GetByID(ctx, customerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get customer: %w", err)
+	}
+
+	now := s.now()
+	customer.KYCStatus = models.KYCStatusVerified
+	customer.KYCVerifiedAt = &now
+	customer.LastKYCUpdate = &now
+	if err := s.customerStore.Update(ctx, customer); err != nil {
+		return nil, fmt.Errorf("failed to update customer: %w", err)
+	}
+	return customer, nil
+}
+
+// ExpireVerifications moves verified customers whose KYC is older than the KYC update frequency
+// to the expired status, so they must re-verify before new policies are issued. It returns the
+// number of customers expired. Nothing expires when no update frequency is configured.
+func (s *KYCService) ExpireVerifications(ctx context.Context) (int, error) {
+	days := s.updateFrequencyDays()
+	if days <= 0 {
+		return 0, nil
+	}
+
+	now := s.now()
+	cutoff := now.AddDate(0, 0, -days)
+	expired := 0
+	for {
+		customers, err := s.customerStore.GetCustomersWithKYCVerifiedBefore(ctx, cutoff, kycExpiryBatchSize)
+		if err != nil {
+			return expired, fmt.Errorf("failed to get customers due for KYC re-verification: %w", err)
+		}
+
+		for _, customer := range customers {
+			customer.KYCStatus = models.KYCStatusExpired
+			customer.LastKYCUpdate = &now
+			if err := s.customerStore.Update(ctx, customer); err != nil {
+				return expired, fmt.Errorf("failed to expire KYC for customer %s: %w", customer.ID, err)
+			}
+			expired++
+
+			s.logger.Info("Customer KYC expired",
+				zap.String("customer_id", customer.ID.String()),
+				zap.Timep("kyc_verified_at", customer.KYCVerifiedAt))
+		}
+
+		if len(customers) < kycExpiryBatchSize {
+			return expired, nil
+		}
+	}
+}
+
+// CheckPolicyIssuance returns ErrKYCReverificationRequired when a user's customer profile has
+// an expired KYC verification, including one past the update frequency that has not been
+// expired yet. Users without a customer profile are not checked; any other failure to load
+// the profile blocks issuance.
+func (s *KYCService) CheckPolicyIssuance(ctx context.Context, userID uuid.UUID) error {
+	customer, err := s.customerStore.GetByUserID(ctx, userID)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check customer KYC: %w", err)
+	}
+
+	if customer.KYCStatus == models.KYCStatusExpired || customer.KYCReverificationDue(s.now(), s.updateFrequencyDays()) {
+		return fmt.Errorf("%w: customer %s", ErrKYCReverificationRequired, customer.CustomerNumber)
+	}
+	return nil
+}
+
+// updateFrequencyDays returns the configured KYC update frequency.
+func (s *KYCService) updateFrequencyDays() int {
+	return s.configManager.GetConfig().Compliance.KYCRequirements.UpdateFrequency
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newKYCTestPolicy(userID uuid.UUID) *models.Policy {
+	return &models.Policy{
+		ProductID:      uuid.New(),
+		UserID:         userID,
+		Premium:        120,
+		CoverageAmount: 10000,
+		EffectiveDate:  time.Now().Add(time.Hour),
+		ExpirationDate: time.Now().AddDate(1, 0, 0),
+	}
+}
+
+func TestExpiredKYCBlocksPolicyIssuance(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	configManager := config.NewManager(log, "")
+	updateFrequency := configManager.GetConfig().Compliance.KYCRequirements.UpdateFrequency
+	require.Positive(t, updateFrequency)
+
+	stale := time.Now().AddDate(0, 0, -updateFrequency-1)
+	recent := time.Now().AddDate(0, 0, -30)
+	expired := &models.Customer{UserID: uuid.New(), CustomerNumber: "CUS-1", KYCStatus: models.KYCStatusVerified, KYCVerifiedAt: &stale}
+	current := &models.Customer{UserID: uuid.New(), CustomerNumber: "CUS-2", KYCStatus: models.KYCStatusVerified, KYCVerifiedAt: &recent}
+	customerStore := newFakeCustomerStore(expired, current)
+
+	kyc := NewKYCService(log, configManager, customerStore)
+	policies := NewPolicyService(newFakePolicyStore())
+	policies.SetKYCService(kyc)
+
+	count, err := kyc.ExpireVerifications(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, models.KYCStatusExpired, expired.KYCStatus)
+	assert.NotNil(t, expired.LastKYCUpdate)
+	assert.Equal(t, models.KYCStatusVerified, current.KYCStatus)
+
+	err = policies.CreatePolicy(ctx, newKYCTestPolicy(expired.UserID))
+	assert.True(t, errors.Is(err, ErrKYCReverificationRequired), "got %v", err)
+
+	require.NoError(t, policies.CreatePolicy(ctx, newKYCTestPolicy(current.UserID)))
+
+	// Re-verifying lifts the block.
+	_, err = kyc.RecordVerification(ctx, expired.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.KYCStatusVerified, expired.KYCStatus)
+	require.NoError(t, policies.CreatePolicy(ctx, newKYCTestPolicy(expired.UserID)))
+}
+
+func TestKYCPastWindowIsBlockedBeforeExpiryRuns(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	stale := time.Now().AddDate(-2, 0, 0)
+	customer := &models.Customer{UserID: uuid.New(), KYCStatus: models.KYCStatusVerified, KYCVerifiedAt: &stale}
+	kyc := NewKYCService(log, config.NewManager(log, ""), newFakeCustomerStore(customer))
+
+	assert.ErrorIs(t, kyc.CheckPolicyIssuance(ctx, customer.UserID), ErrKYCReverificationRequired)
+	assert.NoError(t, kyc.CheckPolicyIssuance(ctx, uuid.New()), "users without a customer profile are not checked")
+}
+
+// failingCustomerStore is a CustomerStore whose reads fail.
+type failingCustomerStore struct {
+	*fakeCustomerStore
+}
+
+func (s *failingCustomerStore) GetByUserID(ctx context.Context, userID uuid.UUID) (*models.Customer, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestKYCCheckFailsClosedOnStoreError(t *testing.T) {
+	log := logger.NewLogger("error", "json")
+	kyc := NewKYCService(log, config.NewManager(log, ""), &failingCustomerStore{newFakeCustomerStore()})
+
+	err := kyc.CheckPolicyIssuance(context.Background(), uuid.New())
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrKYCReverificationRequired)
+}
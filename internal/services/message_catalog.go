@@ -0,0 +1,159 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+// DefaultLocale is the locale used for content that has no translation in the requested one.
+const DefaultLocale = "en"
+
+// MessageTemplate is a localized subject and body. Both are template sources executed against
+// the message data.
+type MessageTemplate struct {
+	Subject string
+	Body    string
+}
+
+// MessageCatalog holds message templates by key and locale. Lookups fall back from a regional
+// locale to its language and then to DefaultLocale, so "pt-MZ" uses the "pt" translation and
+// a message without one uses English.
+type MessageCatalog struct {
+	mu       sync.RWMutex
+	messages map[string]map[string]MessageTemplate
+}
+
+// NewMessageCatalog creates a catalog with the notification messages in English and Portuguese.
+func NewMessageCatalog() *MessageCatalog {
+	c := &MessageCatalog{messages: make(map[string]map[string]MessageTemplate)}
+	for locale, messages := range notificationMessages {
+		for key, tmpl := range messages {
+			c.Register(key, locale, tmpl)
+		}
+	}
+	return c
+}
+
+// Register adds or replaces the template for a message key in a locale.
+func (c *MessageCatalog) Register(key, locale string, tmpl MessageTemplate) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.messages[key] == nil {
+		c.messages[key] = make(map[string]MessageTemplate)
+	}
+	c.messages[key][normalizeLocale(locale)] = tmpl
+}
+
+// Lookup returns the template for a message key in the closest available locale, along with
+// that locale.
+func (c *MessageCatalog) Lookup(key, locale string) (MessageTemplate, string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	translations, ok := c.messages[key]
+	if !ok {
+		return MessageTemplate{}, "", false
+	}
+	for _, candidate := range localeFallbacks(locale) {
+		if tmpl, ok := translations[candidate]; ok {
+			return tmpl, candidate, true
+		}
+	}
+	return MessageTemplate{}, "", false
+}
+
+// Render executes the subject and body templates for a message key in the closest available
+// locale.
+func (c *MessageCatalog) Render(key, locale string, data interface{}) (string, string, error) {
+	tmpl, _, ok := c.Lookup(key, locale)
+	if !ok {
+		return "", "", fmt.Errorf("unknown message: %s", key)
+	}
+
+	subject, err := executeTextTemplate(key+".subject", tmpl.Subject, data)
+	if err != nil {
+		return "", "", err
+	}
+	body, err := executeTextTemplate(key+".body", tmpl.Body, data)
+	if err != nil {
+		return "", "", err
+	}
+	return subject, body, nil
+}
+
+// localeFallbacks returns the locales to try for a requested locale, most specific first.
+func localeFallbacks(locale string) []string {
+	locale = normalizeLocale(locale)
+
+	var fallbacks []string
+	if locale != "" {
+		fallbacks = append(fallbacks, locale)
+		if language, _, found := strings.Cut(locale, "-"); found {
+			fallbacks = append(fallbacks, language)
+		}
+	}
+	return append(fallbacks, DefaultLocale)
+}
+
+// normalizeLocale lowercases a locale and uses "-" as its separator, so "pt_MZ" becomes "pt-mz".
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+// executeTextTemplate parses and executes a single text template source.
+func executeTextTemplate(name, source string, data interface{}) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=zero").Parse(source)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s template: %w", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render %s template: %w", name, err)
+	}
+	return buf.String(), nil
+}
+
+// notificationMessages are the built-in notification templates by locale and notification type.
+var notificationMessages = map[string]map[string]MessageTemplate{
+	"en": {
+		NotificationTypeRenewalReminder: {
+			Subject: "Your policy {{.PolicyNumber}} is due for renewal",
+			Body: "Your policy {{.PolicyNumber}} expires on {{.ExpirationDate}}." +
+				"{{if .OfferPremium}} Renew now for {{.OfferPremium}} {{.OfferCurrency}}.{{end}}",
+		},
+		NotificationTypePolicyExpired: {
+			Subject: "Your policy {{.PolicyNumber}} has expired",
+			Body:    "Your policy {{.PolicyNumber}} expired on {{.ExpirationDate}} and no longer provides cover.",
+		},
+		NotificationTypeInvoiceOverdue: {
+			Subject: "Invoice Overdue",
+			Body: "Invoice {{.InvoiceNumber}} for {{.Amount}} {{.Currency}} was due on {{.DueDate}}. " +
+				"Please pay it to keep your coverage active.",
+		},
+		NotificationTypeSecurityAlert: {
+			Subject: "Security alert for your account",
+			Body:    "{{.Detail}} If this was not you, contact us immediately.",
+		},
+	},
+	"pt": {
+		NotificationTypeRenewalReminder: {
+			Subject: "A sua apólice {{.PolicyNumber}} está prestes a ser renovada",
+			Body: "A sua apólice {{.PolicyNumber}} expira em {{.ExpirationDate}}." +
+				"{{if .OfferPremium}} Renove agora por {{.OfferPremium}} {{.OfferCurrency}}.{{end}}",
+		},
+		NotificationTypePolicyExpired: {
+			Subject: "A sua apólice {{.PolicyNumber}} expirou",
+			Body:    "A sua apólice {{.PolicyNumber}} expirou em {{.ExpirationDate}} e já não oferece cobertura.",
+		},
+		NotificationTypeInvoiceOverdue: {
+			Subject: "Fatura em atraso",
+			Body: "A fatura {{.InvoiceNumber}} de {{.Amount}} {{.Currency}} venceu em {{.DueDate}}. " +
+				"Por favor, pague-a para manter a sua cobertura ativa.",
+		},
+	},
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/edsonmichaque/bazaruto/pkg/job"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifyRendersInCustomerLanguage(t *testing.T) {
+	ctx := context.Background()
+	customer := &models.Customer{UserID: uuid.New(), Email: "ana@example.com", Language: "pt-MZ"}
+	svc, memory, registry := newNotificationTestService(customer)
+
+	// dequeue returns the next dispatched notification job.
+	dequeue := func() *NotificationJob {
+		serialized, err := memory.Dequeue(ctx, job.QueueNotifications)
+		require.NoError(t, err)
+		notificationJob, err := registry.Deserialize(serialized)
+		require.NoError(t, err)
+		return notificationJob.(*NotificationJob)
+	}
+
+	_, err := svc.Notify(ctx, &Notification{
+		Type:   NotificationTypeRenewalReminder,
+		UserID: customer.UserID,
+		Data: map[string]interface{}{
+			"PolicyNumber":   "POL-2026-0001",
+			"ExpirationDate": "2026-11-01",
+			"OfferPremium":   "1250.00",
+			"OfferCurrency":  "MZN",
+		},
+	})
+	require.NoError(t, err)
+	reminder := dequeue()
+	assert.Equal(t, "A sua apólice POL-2026-0001 está prestes a ser renovada", reminder.Subject)
+	assert.Equal(t, "A sua apólice POL-2026-0001 expira em 2026-11-01. Renove agora por 1250.00 MZN.", reminder.Message)
+
+	// Security alerts have no Portuguese translation, so the English text is used.
+	_, err = svc.Notify(ctx, &Notification{
+		Type:     NotificationTypeSecurityAlert,
+		UserID:   customer.UserID,
+		Data:     map[string]interface{}{"Detail": "Your password was changed."},
+		Priority: NotificationPriorityHigh,
+	})
+	require.NoError(t, err)
+	alert := dequeue()
+	assert.Equal(t, "Security alert for your account", alert.Subject)
+	assert.Equal(t, "Your password was changed. If this was not you, contact us immediately.", alert.Message)
+}
+
+func TestEmailTemplateStoreSelectsLocale(t *testing.T) {
+	templates := NewEmailTemplateStore()
+	user := &models.User{FullName: "Ana <Silva>", Email: "ana@example.com"}
+
+	subject, body, err := templates.Render(EmailTemplateWelcome, "pt_MZ", user)
+	require.NoError(t, err)
+	assert.Equal(t, "Bem-vindo à Bazaruto Seguros!", subject)
+	assert.Contains(t, body, "<h2>Olá Ana &lt;Silva&gt;!</h2>")
+
+	subject, body, err = templates.Render(EmailTemplateWelcome, "fr", user)
+	require.NoError(t, err)
+	assert.Equal(t, "Welcome to Bazaruto Insurance!", subject)
+	assert.Contains(t, body, "<h2>Hello Ana &lt;Silva&gt;!</h2>")
+
+	_, _, err = templates.Render("unknown", "en", user)
+	assert.Error(t, err)
+}
@@ -0,0 +1,317 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/edsonmichaque/bazaruto/internal/store"
+	"github.com/edsonmichaque/bazaruto/pkg/job"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Notification channels.
+const (
+	NotificationChannelEmail = "email"
+	NotificationChannelSMS   = "sms"
+	NotificationChannelPush  = "push"
+)
+
+// Notification types. Each type has a subject and message in the message catalog.
+const (
+	NotificationTypeRenewalReminder = "renewal_reminder"
+	NotificationTypePolicyExpired   = "policy_expired"
+	NotificationTypeInvoiceOverdue  = "invoice_overdue"
+	NotificationTypeSecurityAlert   = "security_alert"
+)
+
+// Notification priorities. Higher priority notifications are delivered first. Notifications
+// at NotificationPriorityHigh or above, such as fraud and security alerts, are critical: they
+// ignore the customer's opt-outs and quiet hours.
+const (
+	NotificationPriorityLow    = -10
+	NotificationPriorityNormal = job.DefaultPriority
+	NotificationPriorityHigh   = 10
+)
+
+// defaultNotificationMaxRetries is used when a notification does not set MaxRetries.
+const defaultNotificationMaxRetries = 3
+
+// NotificationSender delivers a message on one channel, for example through an SMTP server
+// or an SMS gateway. Returned errors are retried up to the notification's MaxRetries.
+type NotificationSender interface {
+	Send(ctx context.Context, recipient, subject, message string) error
+}
+
+// Notification is a message of a given type for a user. The channels it is sent on are
+// resolved from the customer's contact preference.
+type Notification struct {
+	Type   string
+	UserID uuid.UUID
+	Data   map[string]interface{}
+	// Priority orders delivery against other notifications; see the NotificationPriority constants.
+	Priority int
+	// MaxRetries limits delivery retries. Zero uses the default of 3.
+	MaxRetries int
+	// DedupKey suppresses repeated notifications within the job dedup window. Each channel
+	// is deduplicated separately.
+	DedupKey string
+}
+
+// NotificationService renders notifications from their templates and dispatches a delivery
+// job for each of the recipient's preferred channels. The jobs deliver through the sender
+// set for their channel.
+type NotificationService struct {
+	customerStore store.CustomerStore
+	userStore     store.UserStore
+	dispatcher    job.Dispatcher
+	sendersMu     sync.RWMutex
+	senders       map[string]NotificationSender
+	catalog       *MessageCatalog
+	now           func() time.Time
+	logger        *logger.Logger
+}
+
+// NewNotificationService creates a new NotificationService instance. The user store is
+// optional and is used to find an email address for users without a customer profile.
+func NewNotificationService(logger *logger.Logger, customerStore store.CustomerStore, userStore store.UserStore, dispatcher job.Dispatcher) *NotificationService {
+	return &NotificationService{
+		customerStore: customerStore,
+		userStore:     userStore,
+		dispatcher:    dispatcher,
+		senders:       make(map[string]NotificationSender),
+		catalog:       NewMessageCatalog(),
+		now:           time.Now,
+		logger:        logger,
+	}
+}
+
+// SetSender sets the sender that delivers notifications on a channel.
+func (s *NotificationService) SetSender(channel string, sender NotificationSender) {
+	s.sendersMu.Lock()
+	defer s.sendersMu.Unlock()
+	s.senders[channel] = sender
+}
+
+// Notify renders a notification in the customer's language and dispatches it on each channel
+// the user prefers. It returns the channels the notification was dispatched on, which is none
+// when the customer opted out of the notification's type. Non-critical notifications sent
+// during the customer's quiet hours are scheduled for when the quiet hours end.
+func (s *NotificationService) Notify(ctx context.Context, notification *Notification) ([]string, error) {
+	var recipients []notificationRecipient
+	var runAt time.Time
+	customer, err := s.customerStore.GetByUserID(ctx, notification.UserID)
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		// Users without a customer profile have no preferences and are notified by email.
+		customer = nil
+		recipients, err = s.userEmailRecipient(ctx, notification.UserID, err)
+	case err != nil:
+		// Sending without the profile would bypass the customer's opt-outs and quiet hours.
+		return nil, fmt.Errorf("failed to get customer: %w", err)
+	default:
+		recipients, err = customerRecipients(customer)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	locale := DefaultLocale
+	if customer != nil && customer.Language != "" {
+		locale = customer.Language
+	}
+	subject, message, err := s.catalog.Render(notification.Type, locale, notification.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render %s notification: %w", notification.Type, err)
+	}
+
+	if customer != nil && notification.Priority < NotificationPriorityHigh {
+		preferences := customer.NotificationPreferences
+		if preferences.HasOptedOut(notification.Type) {
+			s.logger.Info("Notification suppressed by customer opt-out",
+				zap.String("type", notification.Type),
+				zap.String("user_id", notification.UserID.String()))
+			return nil, nil
+		}
+		if preferences.QuietHours != nil {
+			if until, quiet := preferences.QuietHours.Until(s.now(), customer.Timezone); quiet {
+				runAt = until
+			}
+		}
+	}
+
+	maxRetries := notification.MaxRetries
+	if maxRetries <= 0 {
+		maxRetries = defaultNotificationMaxRetries
+	}
+
+	var channels []string
+	for _, recipient := range recipients {
+		notificationJob := &NotificationJob{
+			ID:                   uuid.New(),
+			Type:                 notification.Type,
+			Channel:              recipient.channel,
+			UserID:               notification.UserID,
+			Recipient:            recipient.address,
+			Subject:              subject,
+			Message:              message,
+			NotificationPriority: notification.Priority,
+			RetryLimit:           maxRetries,
+			RunAtTime:            runAt,
+		}
+		if notification.DedupKey != "" {
+			notificationJob.DedupKey = notification.DedupKey + ":" + recipient.channel
+		}
+
+		if err := s.dispatcher.PerformWithContext(ctx, notificationJob); err != nil {
+			return channels, fmt.Errorf("failed to dispatch %s notification: %w", recipient.channel, err)
+		}
+		channels = append(channels, recipient.channel)
+	}
+
+	s.logger.Info("Notification dispatched",
+		zap.String("type", notification.Type),
+		zap.String("user_id", notification.UserID.String()),
+		zap.Strings("channels", channels),
+		zap.Time("run_at", runAt))
+
+	return channels, nil
+}
+
+// Send delivers a rendered notification through the sender for its channel.
+func (s *NotificationService) Send(ctx context.Context, channel, recipient, subject, message string) error {
+	s.sendersMu.RLock()
+	sender, ok := s.senders[channel]
+	s.sendersMu.RUnlock()
+	if !ok {
+		return fmt.Errorf("no sender configured for %s notifications", channel)
+	}
+
+	if err := sender.Send(ctx, recipient, subject, message); err != nil {
+		return fmt.Errorf("failed to send %s notification: %w", channel, err)
+	}
+	return nil
+}
+
+// notificationRecipient is a channel and the address to deliver to on it.
+type notificationRecipient struct {
+	channel string
+	address string
+}
+
+// customerRecipients returns the channels and addresses to notify a customer on. The channels
+// come from the notification preferences or, when those set none, from PreferredContact, which
+// lists channels separated by commas with "phone" meaning SMS. Preferred channels the customer
+// has no address for are skipped, and email is used when none remain.
+func customerRecipients(customer *models.Customer) ([]notificationRecipient, error) {
+	var recipients []notificationRecipient
+	seen := make(map[string]bool)
+	for _, channel := range preferredChannels(customer) {
+		if seen[channel] {
+			continue
+		}
+		seen[channel] = true
+
+		switch channel {
+		case NotificationChannelEmail:
+			if customer.Email != "" {
+				recipients = append(recipients, notificationRecipient{channel: channel, address: customer.Email})
+			}
+		case NotificationChannelSMS:
+			if customer.Phone != "" {
+				recipients = append(recipients, notificationRecipient{channel: channel, address: customer.Phone})
+			}
+		case NotificationChannelPush:
+			recipients = append(recipients, notificationRecipient{channel: channel, address: customer.UserID.String()})
+		}
+	}
+
+	if len(recipients) == 0 {
+		if customer.Email == "" {
+			return nil, fmt.Errorf("customer %s has no reachable notification channel", customer.ID)
+		}
+		recipients = append(recipients, notificationRecipient{channel: NotificationChannelEmail, address: customer.Email})
+	}
+	return recipients, nil
+}
+
+// userEmailRecipient falls back to the user account's email for users without a customer profile.
+func (s *NotificationService) userEmailRecipient(ctx context.Context, userID uuid.UUID, customerErr error) ([]notificationRecipient, error) {
+	if s.userStore == nil {
+		return nil, fmt.Errorf("failed to get customer: %w", customerErr)
+	}
+	user, err := s.userStore.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.Email == "" {
+		return nil, fmt.Errorf("user %s has no email address", userID)
+	}
+	return []notificationRecipient{{channel: NotificationChannelEmail, address: user.Email}}, nil
+}
+
+// preferredChannels returns a customer's notification channels in order of preference.
+func preferredChannels(customer *models.Customer) []string {
+	preferences := customer.NotificationPreferences.Channels
+	if len(preferences) == 0 {
+		preferences = strings.Split(customer.PreferredContact, ",")
+	}
+
+	var channels []string
+	for _, preference := range preferences {
+		switch channel := strings.ToLower(strings.TrimSpace(preference)); channel {
+		case "phone":
+			channels = append(channels, NotificationChannelSMS)
+		case NotificationChannelEmail, NotificationChannelSMS, NotificationChannelPush:
+			channels = append(channels, channel)
+		}
+	}
+	return channels
+}
+
+// NotificationJob delivers a rendered notification on one channel.
+type NotificationJob struct {
+	ID                   uuid.UUID            `json:"id"`
+	Type                 string               `json:"type"`
+	Channel              string               `json:"channel"`
+	UserID               uuid.UUID            `json:"user_id"`
+	Recipient            string               `json:"recipient"` // Email address, phone number or user ID, by channel
+	Subject              string               `json:"subject"`
+	Message              string               `json:"message"`
+	NotificationPriority int                  `json:"priority"`
+	RetryLimit           int                  `json:"max_retries"`
+	DedupKey             string               `json:"dedup_key,omitempty"` // Suppresses duplicate notifications within the dedup window
+	Service              *NotificationService `json:"-"`                   // Injected dependency
+	Attempts             int                  `json:"attempts"`
+	RunAtTime            time.Time            `json:"run_at_time"`
+}
+
+// Perform sends the notification through the service's sender for the job's channel.
+func (j *NotificationJob) Perform(ctx context.Context) error {
+	if j.Service == nil {
+		return fmt.Errorf("notification service is not configured")
+	}
+	return j.Service.Send(ctx, j.Channel, j.Recipient, j.Subject, j.Message)
+}
+
+// MaxRetries returns the notification's retry limit, defaulting to 3.
+func (j *NotificationJob) MaxRetries() int {
+	if j.RetryLimit <= 0 {
+		return defaultNotificationMaxRetries
+	}
+	return j.RetryLimit
+}
+
+// NotificationJob interface methods
+func (j *NotificationJob) Queue() string               { return job.QueueNotifications }
+func (j *NotificationJob) RetryBackoff() time.Duration { return time.Second }
+func (j *NotificationJob) Priority() int               { return j.NotificationPriority }
+func (j *NotificationJob) GetRunAt() time.Time         { return j.RunAtTime }
+func (j *NotificationJob) Timeout() time.Duration      { return 30 * time.Second }
+func (j *NotificationJob) UniqueKey() string           { return j.DedupKey }
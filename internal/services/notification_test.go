@@ -0,0 +1,299 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/edsonmichaque/bazaruto/pkg/job"
+	"github.com/edsonmichaque/bazaruto/pkg/job/adapter"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifyUsesPreferredChannel(t *testing.T) {
+	ctx := context.Background()
+
+	// notify sends an overdue invoice notification to a customer and returns the dispatched jobs.
+	notify := func(customer *models.Customer) []*NotificationJob {
+		svc, memory, registry := newNotificationTestService(customer)
+
+		_, err := svc.Notify(ctx, &Notification{
+			Type:   NotificationTypeInvoiceOverdue,
+			UserID: customer.UserID,
+			Data: map[string]interface{}{
+				"InvoiceNumber": "INV-2026-0007",
+				"Amount":        "120.00",
+				"Currency":      "USD",
+				"DueDate":       "2026-10-01",
+			},
+		})
+		require.NoError(t, err)
+
+		var dispatched []*NotificationJob
+		for {
+			serialized, err := memory.Dequeue(ctx, job.QueueNotifications)
+			if err != nil {
+				break
+			}
+			notificationJob, err := registry.Deserialize(serialized)
+			require.NoError(t, err)
+			dispatched = append(dispatched, notificationJob.(*NotificationJob))
+		}
+		return dispatched
+	}
+
+	dispatched := notify(&models.Customer{
+		UserID:           uuid.New(),
+		Email:            "ana@example.com",
+		Phone:            "+258840000001",
+		PreferredContact: "sms",
+	})
+	require.Len(t, dispatched, 1)
+	assert.Equal(t, NotificationChannelSMS, dispatched[0].Channel)
+	assert.Equal(t, "+258840000001", dispatched[0].Recipient)
+	assert.Equal(t, "Invoice INV-2026-0007 for 120.00 USD was due on 2026-10-01. Please pay it to keep your coverage active.",
+		dispatched[0].Message)
+
+	// Without a phone number the SMS preference cannot be honored, so email is used.
+	dispatched = notify(&models.Customer{
+		UserID:           uuid.New(),
+		Email:            "rui@example.com",
+		PreferredContact: "sms",
+	})
+	require.Len(t, dispatched, 1)
+	assert.Equal(t, NotificationChannelEmail, dispatched[0].Channel)
+	assert.Equal(t, "rui@example.com", dispatched[0].Recipient)
+	assert.Equal(t, "Invoice Overdue", dispatched[0].Subject)
+}
+
+// sentNotification is a message delivered through a recordingSender.
+type sentNotification struct {
+	recipient, subject, message string
+}
+
+// recordingSender records delivered notifications, failing the first failures attempts.
+type recordingSender struct {
+	mu       sync.Mutex
+	failures int
+	attempts int
+	sent     []sentNotification
+}
+
+func (s *recordingSender) Send(ctx context.Context, recipient, subject, message string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attempts++
+	if s.attempts <= s.failures {
+		return errors.New("gateway timeout")
+	}
+	s.sent = append(s.sent, sentNotification{recipient: recipient, subject: subject, message: message})
+	return nil
+}
+
+func (s *recordingSender) delivered() ([]sentNotification, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]sentNotification(nil), s.sent...), s.attempts
+}
+
+// newNotificationTestService creates a notification service over an in-memory job queue whose
+// registry injects the service into deserialized notification jobs.
+func newNotificationTestService(customers ...*models.Customer) (*NotificationService, *adapter.MemoryAdapter, *job.Registry) {
+	memory := adapter.NewMemoryAdapter()
+	registry := job.NewRegistry()
+	svc := NewNotificationService(logger.NewLogger("error", "json"), newFakeCustomerStore(customers...), nil, *job.NewDispatcher(memory, registry))
+	registry.Register("notificationjob", func() job.Job {
+		return &NotificationJob{Service: svc}
+	})
+	return svc, memory, registry
+}
+
+func TestNotificationJobSendsThroughChannelSender(t *testing.T) {
+	ctx := context.Background()
+	customer := &models.Customer{UserID: uuid.New(), Email: "ana@example.com", PreferredContact: "email"}
+	svc, memory, registry := newNotificationTestService(customer)
+	sender := &recordingSender{}
+	svc.SetSender(NotificationChannelEmail, sender)
+
+	_, err := svc.Notify(ctx, &Notification{
+		Type:       NotificationTypePolicyExpired,
+		UserID:     customer.UserID,
+		Data:       map[string]interface{}{"PolicyNumber": "POL-2026-0001", "ExpirationDate": "2026-10-16"},
+		Priority:   NotificationPriorityHigh,
+		MaxRetries: 5,
+	})
+	require.NoError(t, err)
+
+	serialized, err := memory.Dequeue(ctx, job.QueueNotifications)
+	require.NoError(t, err)
+	assert.Equal(t, NotificationPriorityHigh, serialized.Priority)
+	assert.Equal(t, 5, serialized.MaxRetries)
+
+	notificationJob, err := registry.Deserialize(serialized)
+	require.NoError(t, err)
+	require.NoError(t, notificationJob.Perform(ctx))
+
+	sent, _ := sender.delivered()
+	require.Len(t, sent, 1)
+	assert.Equal(t, sentNotification{
+		recipient: "ana@example.com",
+		subject:   "Your policy POL-2026-0001 has expired",
+		message:   "Your policy POL-2026-0001 expired on 2026-10-16 and no longer provides cover.",
+	}, sent[0])
+}
+
+func TestNotificationJobRetriesTransientSendError(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	customer := &models.Customer{UserID: uuid.New(), Phone: "+258840000001", PreferredContact: "sms"}
+	svc, memory, registry := newNotificationTestService(customer)
+	sender := &recordingSender{failures: 1}
+	svc.SetSender(NotificationChannelSMS, sender)
+
+	// Retries are delayed by the adapter's backoff, so the test moves the adapter's clock. The
+	// worker polls every second.
+	var clockMu sync.Mutex
+	now := time.Now()
+	memory.SetClock(func() time.Time {
+		clockMu.Lock()
+		defer clockMu.Unlock()
+		return now
+	})
+
+	worker := job.NewWorker(memory, registry, job.WorkerConfig{
+		Queues:      []string{job.QueueNotifications},
+		Concurrency: 1,
+	}, logger.NewLogger("error", "json"))
+	stopped := make(chan error, 1)
+	go func() { stopped <- worker.Start(ctx) }()
+	defer func() {
+		worker.Stop()
+		require.NoError(t, <-stopped)
+	}()
+
+	_, err := svc.Notify(ctx, &Notification{
+		Type:   NotificationTypePolicyExpired,
+		UserID: customer.UserID,
+		Data:   map[string]interface{}{"PolicyNumber": "POL-2026-0001", "ExpirationDate": "2026-10-16"},
+	})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		_, attempts := sender.delivered()
+		return attempts == 1
+	}, 3*time.Second, 10*time.Millisecond)
+
+	clockMu.Lock()
+	now = now.Add(time.Minute)
+	clockMu.Unlock()
+
+	require.Eventually(t, func() bool {
+		sent, _ := sender.delivered()
+		return len(sent) == 1
+	}, 3*time.Second, 10*time.Millisecond)
+	_, attempts := sender.delivered()
+	assert.Equal(t, 2, attempts)
+}
+
+func TestNotifyDefersNonCriticalNotificationsDuringQuietHours(t *testing.T) {
+	ctx := context.Background()
+	customer := &models.Customer{
+		UserID:   uuid.New(),
+		Email:    "ana@example.com",
+		Timezone: "Africa/Maputo",
+		NotificationPreferences: models.NotificationPreferences{
+			QuietHours: &models.QuietHours{Start: "22:00", End: "07:00"},
+		},
+	}
+	svc, memory, registry := newNotificationTestService(customer)
+
+	// 23:30 in Maputo (UTC+2) is inside the quiet hours, which end at 07:00 local time.
+	now := time.Date(2026, 10, 17, 21, 30, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+	memory.SetClock(func() time.Time { return now })
+
+	_, err := svc.Notify(ctx, &Notification{
+		Type:     NotificationTypeRenewalReminder,
+		UserID:   customer.UserID,
+		Data:     map[string]interface{}{"PolicyNumber": "POL-2026-0001", "ExpirationDate": "2026-11-01"},
+		Priority: NotificationPriorityLow,
+	})
+	require.NoError(t, err)
+	_, err = svc.Notify(ctx, &Notification{
+		Type:     NotificationTypeSecurityAlert,
+		UserID:   customer.UserID,
+		Data:     map[string]interface{}{"Detail": "Your password was changed."},
+		Priority: NotificationPriorityHigh,
+	})
+	require.NoError(t, err)
+
+	// Only the security alert is ready now.
+	serialized, err := memory.Dequeue(ctx, job.QueueNotifications)
+	require.NoError(t, err)
+	alert, err := registry.Deserialize(serialized)
+	require.NoError(t, err)
+	assert.Equal(t, NotificationTypeSecurityAlert, alert.(*NotificationJob).Type)
+	_, err = memory.Dequeue(ctx, job.QueueNotifications)
+	assert.Error(t, err, "renewal reminder was not deferred")
+
+	// The reminder is released when the quiet hours end.
+	now = time.Date(2026, 10, 18, 5, 0, 0, 0, time.UTC)
+	serialized, err = memory.Dequeue(ctx, job.QueueNotifications)
+	require.NoError(t, err)
+	reminder, err := registry.Deserialize(serialized)
+	require.NoError(t, err)
+	assert.Equal(t, NotificationTypeRenewalReminder, reminder.(*NotificationJob).Type)
+}
+
+func TestNotifyHonorsOptOuts(t *testing.T) {
+	ctx := context.Background()
+	customer := &models.Customer{
+		UserID: uuid.New(),
+		Email:  "ana@example.com",
+		NotificationPreferences: models.NotificationPreferences{
+			OptOuts: []string{NotificationTypeRenewalReminder, NotificationTypeSecurityAlert},
+		},
+	}
+	svc, _, _ := newNotificationTestService(customer)
+
+	channels, err := svc.Notify(ctx, &Notification{
+		Type:   NotificationTypeRenewalReminder,
+		UserID: customer.UserID,
+		Data:   map[string]interface{}{"PolicyNumber": "POL-2026-0001", "ExpirationDate": "2026-11-01"},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, channels)
+
+	// Critical notifications cannot be opted out of.
+	channels, err = svc.Notify(ctx, &Notification{
+		Type:     NotificationTypeSecurityAlert,
+		UserID:   customer.UserID,
+		Data:     map[string]interface{}{"Detail": "Your password was changed."},
+		Priority: NotificationPriorityHigh,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{NotificationChannelEmail}, channels)
+}
+
+func TestNotifyFailsWhenCustomerPreferencesCannotBeRead(t *testing.T) {
+	// The user has an email address, so falling back to it would send the notification
+	// without checking the customer's opt-outs.
+	user := &models.User{Email: "ana@example.com"}
+	user.ID = uuid.New()
+	svc := NewNotificationService(logger.NewLogger("error", "json"), &failingCustomerStore{newFakeCustomerStore()}, newFakeUserStore(user), *job.NewDispatcher(adapter.NewMemoryAdapter(), job.NewRegistry()))
+
+	channels, err := svc.Notify(context.Background(), &Notification{
+		Type:   NotificationTypeRenewalReminder,
+		UserID: user.ID,
+		Data:   map[string]interface{}{"PolicyNumber": "POL-2026-0001", "ExpirationDate": "2026-11-01"},
+	})
+	require.Error(t, err)
+	assert.Empty(t, channels)
+}
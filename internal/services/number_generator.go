@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/store"
+)
+
+// defaultNumberDigits is the sequence width used when a number format sets none.
+const defaultNumberDigits = 6
+
+// NumberGenerator issues sequential policy, claim and report numbers, such as
+// POL-2024-000123, in the formats set in the numbering configuration. Numbers are drawn from store sequences, so they
+// are unique across concurrent requests and application instances. They are not gap-free: a
+// number drawn for a policy or claim that then fails to save is skipped.
+type NumberGenerator struct {
+	configManager *config.Manager
+	sequences     store.SequenceStore
+	now           func() time.Time
+}
+
+// NewNumberGenerator creates a new NumberGenerator instance.
+func NewNumberGenerator(configManager *config.Manager, sequences store.SequenceStore) *NumberGenerator {
+	return &NumberGenerator{
+		configManager: configManager,
+		sequences:     sequences,
+		now:           time.Now,
+	}
+}
+
+// PolicyNumber returns the next policy number.
+func (g *NumberGenerator) PolicyNumber(ctx context.Context) (string, error) {
+	return g.next(ctx, g.configManager.GetConfig().Numbering.Policy, "POL")
+}
+
+// ClaimNumber returns the next claim number.
+func (g *NumberGenerator) ClaimNumber(ctx context.Context) (string, error) {
+	return g.next(ctx, g.configManager.GetConfig().Numbering.Claim, "CLM")
+}
+
+// SARNumber returns the next suspicious activity report number.
+func (g *NumberGenerator) SARNumber(ctx context.Context) (string, error) {
+	return g.next(ctx, g.configManager.GetConfig().Numbering.SAR, "SAR")
+}
+
+// next formats the next value of the format's sequence. Each prefix, and each year when the
+// year is included, has its own sequence.
+func (g *NumberGenerator) next(ctx context.Context, format config.NumberFormat, defaultPrefix string) (string, error) {
+	prefix := strings.TrimSpace(format.Prefix)
+	if prefix == "" {
+		prefix = defaultPrefix
+	}
+	digits := format.Digits
+	if digits <= 0 {
+		digits = defaultNumberDigits
+	}
+
+	base := prefix
+	if format.IncludeYear {
+		base = fmt.Sprintf("%s-%d", prefix, g.now().Year())
+	}
+
+	value, err := g.sequences.Next(ctx, base)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s-%0*d", base, digits, value), nil
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/edsonmichaque/bazaruto/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func newTestNumberGenerator(t *testing.T) *NumberGenerator {
+	t.Helper()
+
+	// A file database lets several connections draw numbers at once; the busy timeout makes
+	// writers wait for the sequence row lock instead of failing.
+	dsn := filepath.Join(t.TempDir(), "sequences.db") + "?_busy_timeout=10000&_journal_mode=WAL"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(8)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	require.NoError(t, db.AutoMigrate(&models.Sequence{}))
+
+	log := logger.NewLogger("error", "json")
+	return NewNumberGenerator(config.NewManager(log, filepath.Join(t.TempDir(), "business_rules.json")), store.NewSequenceStore(db))
+}
+
+func TestNumberGeneratorConcurrentPolicyNumbersAreUnique(t *testing.T) {
+	ctx := context.Background()
+	numbers := newTestNumberGenerator(t)
+	numbers.now = func() time.Time { return time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC) }
+
+	const count = 1000
+	generated := make([]string, count)
+	errs := make([]error, count)
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			generated[i], errs[i] = numbers.PolicyNumber(ctx)
+		}(i)
+	}
+	wg.Wait()
+
+	format := regexp.MustCompile(`^POL-2024-\d{6}$`)
+	seen := make(map[string]bool, count)
+	for i, number := range generated {
+		require.NoError(t, errs[i])
+		assert.Regexp(t, format, number)
+		assert.False(t, seen[number], "duplicate policy number %s", number)
+		seen[number] = true
+	}
+	assert.True(t, seen["POL-2024-000001"])
+	assert.True(t, seen["POL-2024-001000"])
+}
+
+func TestNumberGeneratorUsesConfiguredFormat(t *testing.T) {
+	ctx := context.Background()
+	numbers := newTestNumberGenerator(t)
+	numbers.now = func() time.Time { return time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC) }
+
+	rules := numbers.configManager.GetConfig()
+	rules.Numbering.Claim = config.NumberFormat{Prefix: "CL", Digits: 8}
+	require.NoError(t, numbers.configManager.UpdateConfig(ctx, rules))
+
+	first, err := numbers.ClaimNumber(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "CL-00000001", first)
+
+	second, err := numbers.ClaimNumber(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "CL-00000002", second)
+
+	// Policy numbers have their own yearly sequence.
+	policy, err := numbers.PolicyNumber(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "POL-2025-000001", policy)
+}
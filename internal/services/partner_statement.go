@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/events"
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/edsonmichaque/bazaruto/internal/store"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// StatementPeriod is the date range a partner statement covers, inclusive of both ends.
+type StatementPeriod struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// PartnerStatementService generates periodic commission statements for partners.
+type PartnerStatementService struct {
+	partnerStore      store.PartnerStore
+	commissionStore   store.CommissionStore
+	statementStore    store.PartnerStatementStore
+	currencyConverter CurrencyConverter
+	eventService      *EventService
+	logger            *logger.Logger
+}
+
+// NewPartnerStatementService creates a new PartnerStatementService instance.
+func NewPartnerStatementService(
+	logger *logger.Logger,
+	partnerStore store.PartnerStore,
+	commissionStore store.CommissionStore,
+	statementStore store.PartnerStatementStore,
+	currencyConverter CurrencyConverter,
+	eventService *EventService,
+) *PartnerStatementService {
+	return &PartnerStatementService{
+		partnerStore:      partnerStore,
+		commissionStore:   commissionStore,
+		statementStore:    statementStore,
+		currencyConverter: currencyConverter,
+		eventService:      eventService,
+		logger:            logger,
+	}
+}
+
+// GeneratePartnerStatement generates and stores a partner's statement for a period,
+// replacing any statement already generated for the same period.
+// The opening balance carries over from the closing balance of the partner's previous
+// statement. Commissions and clawbacks recorded in the period, and commissions paid in
+// the period, are converted to the partner's settlement currency; the closing balance is
+// what remains owed to the partner.
+func (s *PartnerStatementService) GeneratePartnerStatement(ctx context.Context, partnerID uuid.UUID, period StatementPeriod) (*models.PartnerStatement, error) {
+	if period.Start.IsZero() || !period.End.After(period.Start) {
+		return nil, fmt.Errorf("statement period end must be after its start")
+	}
+
+	partner, err := s.partnerStore.GetPartner(ctx, partnerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch partner: %w", err)
+	}
+
+	currency := partner.SettlementCurrency
+	if currency == "" {
+		currency = "USD"
+	}
+
+	statement := &models.PartnerStatement{
+		PartnerID:   partnerID,
+		PeriodStart: period.Start,
+		PeriodEnd:   period.End,
+		Currency:    currency,
+	}
+
+	previous, err := s.statementStore.GetLatestPartnerStatement(ctx, partnerID, period.Start)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch previous statement: %w", err)
+	}
+	if previous != nil {
+		opening, err := convertAmount(ctx, s.currencyConverter, previous.ClosingBalance, previous.Currency, currency)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert opening balance: %w", err)
+		}
+		statement.OpeningBalance = opening
+	}
+
+	recorded, err := s.commissionStore.ListCommissionsByPartner(ctx, partnerID, period.Start, period.End)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commissions: %w", err)
+	}
+	for _, commission := range recorded {
+		if commission.Status == models.CommissionStatusCancelled {
+			continue
+		}
+		amount, err := convertAmount(ctx, s.currencyConverter, commission.Amount, commission.Currency, currency)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert commission %s: %w", commission.ID, err)
+		}
+		if commission.CommissionType == models.CommissionTypeClawback {
+			statement.Clawbacks -= amount
+		} else {
+			statement.CommissionsEarned += amount
+		}
+		statement.CommissionCount++
+	}
+
+	paid, err := s.commissionStore.ListPaidCommissionsByPartner(ctx, partnerID, period.Start, period.End)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list paid commissions: %w", err)
+	}
+	for _, commission := range paid {
+		if commission.CommissionType == models.CommissionTypeClawback {
+			continue
+		}
+		amount, err := convertAmount(ctx, s.currencyConverter, commission.Amount, commission.Currency, currency)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert payment %s: %w", commission.ID, err)
+		}
+		statement.PaymentsMade += amount
+	}
+
+	statement.OpeningBalance = roundCurrency(statement.OpeningBalance)
+	statement.CommissionsEarned = roundCurrency(statement.CommissionsEarned)
+	statement.Clawbacks = roundCurrency(statement.Clawbacks)
+	statement.PaymentsMade = roundCurrency(statement.PaymentsMade)
+	statement.ClosingBalance = roundCurrency(statement.OpeningBalance + statement.CommissionsEarned - statement.Clawbacks - statement.PaymentsMade)
+
+	if err := s.statementStore.SavePartnerStatement(ctx, statement); err != nil {
+		return nil, fmt.Errorf("failed to save partner statement: %w", err)
+	}
+
+	s.logger.Info("Partner statement generated",
+		zap.String("statement_id", statement.ID.String()),
+		zap.String("partner_id", partnerID.String()),
+		zap.Float64("closing_balance", statement.ClosingBalance),
+		zap.String("currency", currency))
+
+	if s.eventService != nil {
+		statementEvent := events.NewPartnerStatementGeneratedEvent(
+			statement.ID,
+			partnerID,
+			statement.PeriodStart,
+			statement.PeriodEnd,
+			statement.ClosingBalance,
+			statement.Currency,
+			time.Now(),
+		)
+		if err := s.eventService.PublishEvent(ctx, statementEvent); err != nil {
+			s.logger.Error("Failed to publish statement generated event",
+				zap.Error(err),
+				zap.String("statement_id", statement.ID.String()))
+		}
+	}
+
+	return statement, nil
+}
+
+// GetPartnerStatement retrieves a generated partner statement.
+func (s *PartnerStatementService) GetPartnerStatement(ctx context.Context, statementID uuid.UUID) (*models.PartnerStatement, error) {
+	return s.statementStore.GetPartnerStatement(ctx, statementID)
+}
+
+// ListPartnerStatements retrieves a partner's statements, most recent period first.
+func (s *PartnerStatementService) ListPartnerStatements(ctx context.Context, partnerID uuid.UUID) ([]*models.PartnerStatement, error) {
+	return s.statementStore.ListPartnerStatements(ctx, partnerID)
+}
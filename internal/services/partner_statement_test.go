@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratePartnerStatementComputesClosingBalance(t *testing.T) {
+	ctx := context.Background()
+	partner := &models.Partner{Base: models.Base{ID: uuid.New()}, Name: "Agency", SettlementCurrency: "USD"}
+	periodStart := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	period := StatementPeriod{Start: periodStart, End: periodStart.AddDate(0, 1, 0).Add(-time.Nanosecond)}
+
+	statementStore := &fakePartnerStatementStore{}
+	require.NoError(t, statementStore.SavePartnerStatement(ctx, &models.PartnerStatement{
+		PartnerID:      partner.ID,
+		PeriodStart:    periodStart.AddDate(0, -1, 0),
+		PeriodEnd:      periodStart.Add(-time.Nanosecond),
+		Currency:       "USD",
+		ClosingBalance: 25,
+	}))
+
+	commissionStore := &fakeCommissionStore{}
+	earned := &models.Commission{
+		Base:           models.Base{CreatedAt: periodStart.AddDate(0, 0, 3)},
+		PartnerID:      partner.ID,
+		CommissionType: models.CommissionTypeInitial,
+		Amount:         150,
+		Currency:       "USD",
+		Status:         models.CommissionStatusCalculated,
+	}
+	clawback := &models.Commission{
+		Base:           models.Base{CreatedAt: periodStart.AddDate(0, 0, 10)},
+		PartnerID:      partner.ID,
+		CommissionType: models.CommissionTypeClawback,
+		Amount:         -60,
+		Currency:       "USD",
+		Status:         models.CommissionStatusCalculated,
+	}
+	require.NoError(t, commissionStore.CreateCommission(ctx, earned))
+	require.NoError(t, commissionStore.CreateCommission(ctx, clawback))
+
+	svc := NewPartnerStatementService(logger.NewLogger("error", "json"), newFakePartnerStore(partner), commissionStore, statementStore, nil, nil)
+
+	statement, err := svc.GeneratePartnerStatement(ctx, partner.ID, period)
+	require.NoError(t, err)
+	assert.InDelta(t, 25.0, statement.OpeningBalance, 0.001)
+	assert.InDelta(t, 150.0, statement.CommissionsEarned, 0.001)
+	assert.InDelta(t, 60.0, statement.Clawbacks, 0.001)
+	assert.Zero(t, statement.PaymentsMade)
+	assert.InDelta(t, 115.0, statement.ClosingBalance, 0.001)
+	assert.Equal(t, 2, statement.CommissionCount)
+
+	stored, err := svc.GetPartnerStatement(ctx, statement.ID)
+	require.NoError(t, err)
+	assert.Equal(t, statement.ClosingBalance, stored.ClosingBalance)
+}
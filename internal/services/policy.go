@@ -13,16 +13,50 @@ import (
 
 // PolicyService handles business logic for policies.
 type PolicyService struct {
-	store store.PolicyStore
+	store          store.PolicyStore
+	invoiceService *InvoiceService
+	kycService     *KYCService
+	beneficiaries  *BeneficiaryService
+	numbers        *NumberGenerator
+	quotes         store.QuoteStore
 }
 
 // NewPolicyService creates a new PolicyService instance.
-func NewPolicyService(store store.PolicyStore) *PolicyService {
+// The invoice service is optional; without it no invoice is generated on issuance.
+func NewPolicyService(store store.PolicyStore, invoiceService ...*InvoiceService) *PolicyService {
+	var invService *InvoiceService
+	if len(invoiceService) > 0 {
+		invService = invoiceService[0]
+	}
 	return &PolicyService{
-		store: store,
+		store:          store,
+		invoiceService: invService,
 	}
 }
 
+// SetKYCService sets the service used to block issuance to customers whose KYC has expired.
+func (s *PolicyService) SetKYCService(kycService *KYCService) {
+	s.kycService = kycService
+}
+
+// SetBeneficiaryService sets the service used to check, at issuance, the beneficiaries a
+// policy is issued with against the rules of its product.
+func (s *PolicyService) SetBeneficiaryService(beneficiaries *BeneficiaryService) {
+	s.beneficiaries = beneficiaries
+}
+
+// SetNumberGenerator sets the generator used to number new policies. Without one, policy
+// numbers are generated from a timestamp and a random component.
+func (s *PolicyService) SetNumberGenerator(numbers *NumberGenerator) {
+	s.numbers = numbers
+}
+
+// SetQuoteStore sets the store used to look up the quote a policy is issued from, so the
+// issuance invoice is itemized from the quote's pricing breakdown.
+func (s *PolicyService) SetQuoteStore(quotes store.QuoteStore) {
+	s.quotes = quotes
+}
+
 // CreatePolicy creates a new policy with business logic validation.
 func (s *PolicyService) CreatePolicy(ctx context.Context, policy *models.Policy) error {
 	// Validate required fields
@@ -58,7 +92,15 @@ func (s *PolicyService) CreatePolicy(ctx context.Context, policy *models.Policy)
 
 	// Generate policy number if not provided
 	if policy.PolicyNumber == "" {
-		policy.PolicyNumber = s.generatePolicyNumber()
+		if s.numbers != nil {
+			number, err := s.numbers.PolicyNumber(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to generate policy number: %w", err)
+			}
+			policy.PolicyNumber = number
+		} else {
+			policy.PolicyNumber = generatePolicyNumber()
+		}
 	}
 
 	// Validate effective date is not in the past
@@ -77,12 +119,63 @@ func (s *PolicyService) CreatePolicy(ctx context.Context, policy *models.Policy)
 		return fmt.Errorf("invalid payment frequency: %s", policy.PaymentFrequency)
 	}
 
+	// Customers must refresh expired KYC before new policies are issued
+	if s.kycService != nil {
+		if err := s.kycService.CheckPolicyIssuance(ctx, policy.UserID); err != nil {
+			return err
+		}
+	}
+
+	// Products such as life policies must be issued with a valid beneficiary designation
+	if s.beneficiaries != nil {
+		if err := s.beneficiaries.ValidateIssuance(ctx, policy); err != nil {
+			return err
+		}
+	}
+
 	// Set renewal date if auto-renew is enabled
 	if policy.AutoRenew && policy.RenewalDate == nil {
 		policy.RenewalDate = &policy.ExpirationDate
 	}
 
-	return s.store.CreatePolicy(ctx, policy)
+	if err := s.attachQuotedBreakdown(ctx, policy); err != nil {
+		return err
+	}
+
+	if s.invoiceService == nil {
+		return s.store.CreatePolicy(ctx, policy)
+	}
+
+	// Bill the initial premium in the same transaction that issues the policy
+	invoice, err := s.invoiceService.newPolicyInvoice(policy, models.InvoiceReasonIssuance, policy.PricingBreakdown)
+	if err != nil {
+		return fmt.Errorf("failed to generate policy invoice: %w", err)
+	}
+	if err := s.store.CreatePolicyWithInvoice(ctx, policy, invoice); err != nil {
+		return err
+	}
+	s.invoiceService.publishInvoiceCreated(ctx, invoice)
+
+	return nil
+}
+
+// attachQuotedBreakdown sets the policy's pricing breakdown from the quote it is issued from.
+// The breakdown is only kept when it accounts for the policy premium, since the premium may
+// have been changed after quoting; a breakdown supplied by the caller is never trusted.
+func (s *PolicyService) attachQuotedBreakdown(ctx context.Context, policy *models.Policy) error {
+	policy.PricingBreakdown = nil
+	if s.quotes == nil || policy.QuoteID == nil {
+		return nil
+	}
+
+	quote, err := s.quotes.GetQuote(ctx, *policy.QuoteID)
+	if err != nil {
+		return fmt.Errorf("failed to get quote: %w", err)
+	}
+	if quote.PricingBreakdown != nil && roundCurrency(quote.PricingBreakdown.Total()) == roundCurrency(policy.Premium) {
+		policy.PricingBreakdown = quote.PricingBreakdown
+	}
+	return nil
 }
 
 // GetPolicy retrieves a policy by ID.
@@ -96,18 +189,23 @@ func (s *PolicyService) GetPolicy(ctx context.Context, id uuid.UUID) (*models.Po
 		return nil, fmt.Errorf("failed to get policy: %w", err)
 	}
 
-	// Check if policy has expired
-	if policy.ExpirationDate.Before(time.Now()) && policy.Status == models.PolicyStatusActive {
-		// Auto-expire the policy
-		policy.Status = models.PolicyStatusExpired
-		if err := s.store.UpdatePolicy(ctx, policy); err != nil {
-			return nil, fmt.Errorf("failed to update expired policy: %w", err)
-		}
+	// Auto-expire the policy if it has passed its expiration date
+	policy, _, err = updatePolicyWithRetry(ctx, s.store, policy, expireIfLapsed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update expired policy: %w", err)
 	}
 
 	return policy, nil
 }
 
+// expireIfLapsed marks an active policy past its expiration date as expired.
+func expireIfLapsed(policy *models.Policy) (bool, error) {
+	if policy.Status != models.PolicyStatusActive || !policy.ExpirationDate.Before(time.Now()) {
+		return false, nil
+	}
+	return true, policy.TransitionTo(models.PolicyStatusExpired)
+}
+
 // GetPolicyByNumber retrieves a policy by policy number.
 func (s *PolicyService) GetPolicyByNumber(ctx context.Context, policyNumber string) (*models.Policy, error) {
 	if policyNumber == "" {
@@ -119,13 +217,10 @@ func (s *PolicyService) GetPolicyByNumber(ctx context.Context, policyNumber stri
 		return nil, fmt.Errorf("failed to get policy by number: %w", err)
 	}
 
-	// Check if policy has expired
-	if policy.ExpirationDate.Before(time.Now()) && policy.Status == models.PolicyStatusActive {
-		// Auto-expire the policy
-		policy.Status = models.PolicyStatusExpired
-		if err := s.store.UpdatePolicy(ctx, policy); err != nil {
-			return nil, fmt.Errorf("failed to update expired policy: %w", err)
-		}
+	// Auto-expire the policy if it has passed its expiration date
+	policy, _, err = updatePolicyWithRetry(ctx, s.store, policy, expireIfLapsed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update expired policy: %w", err)
 	}
 
 	return policy, nil
@@ -160,7 +255,7 @@ func (s *PolicyService) ListPolicies(ctx context.Context, opts *models.PolicyLis
 	now := time.Now()
 	for _, policy := range policies {
 		if policy.Status == models.PolicyStatusActive && policy.ExpirationDate.Before(now) {
-			policy.Status = models.PolicyStatusExpired
+			policy.Status = models.PolicyStatusExpired // active -> expired is always a valid transition
 			if err := s.store.UpdatePolicy(ctx, policy); err != nil {
 				// Log error but continue processing
 				continue
@@ -194,6 +289,11 @@ func (s *PolicyService) UpdatePolicy(ctx context.Context, policy *models.Policy)
 		return fmt.Errorf("cannot change policy number")
 	}
 
+	// Enforce the policy status state machine
+	if err := models.ValidatePolicyStatusTransition(existing.Status, policy.Status); err != nil {
+		return err
+	}
+
 	// Validate premium is not negative
 	if policy.Premium < 0 {
 		return fmt.Errorf("premium cannot be negative")
@@ -230,8 +330,8 @@ func (s *PolicyService) CountPolicies(ctx context.Context, opts *models.PolicyLi
 	return s.store.CountPolicies(ctx, opts.UserID, opts.ProductID, opts.Status)
 }
 
-// generatePolicyNumber generates a unique policy number.
-func (s *PolicyService) generatePolicyNumber() string {
+// generatePolicyNumber generates a policy number when no NumberGenerator is configured.
+func generatePolicyNumber() string {
 	// Generate a policy number with timestamp and random component
 	timestamp := time.Now().Format("20060102150405")
 	random := rand.Intn(9999)
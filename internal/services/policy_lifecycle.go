@@ -2,7 +2,10 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
+	"strings"
 	"time"
 
 	"github.com/edsonmichaque/bazaruto/internal/config"
@@ -14,13 +17,31 @@ import (
 	"go.uber.org/zap"
 )
 
+// errPaymentsNotConfigured is returned when a payment or refund is needed but no payment store is configured.
+var errPaymentsNotConfigured = errors.New("payment processing is not configured")
+
+// ErrPolicyAlreadyRenewed is returned when a policy that already has a renewal is renewed again.
+var ErrPolicyAlreadyRenewed = store.ErrAlreadyRenewed
+
 // PolicyLifecycleService handles policy renewal, cancellation, and lifecycle management.
+// The policy store, config manager and logger are required. The remaining dependencies
+// are optional: when one is nil its side effect (payments and refunds, endorsements,
+// invoicing, event publishing, commission clawback) is skipped and logged instead, and renewals fall back to
+// scaling the current premium when no pricing engine is configured.
 type PolicyLifecycleService struct {
 	policyStore       store.PolicyStore
 	paymentStore      store.PaymentStore
 	subscriptionStore store.SubscriptionStore
 	userStore         store.UserStore
+	endorsementStore  store.EndorsementStore
+	pricingService    *PricingEngineService
+	invoiceService    *InvoiceService
 	eventService      *EventService
+	commissionService *CommissionService
+	quoteService      *QuoteService
+	claimStore        store.ClaimStore
+	notifications     *NotificationService
+	numbers           *NumberGenerator
 	configManager     *config.Manager
 	logger            *logger.Logger
 }
@@ -33,23 +54,47 @@ func NewPolicyLifecycleService(
 	paymentStore store.PaymentStore,
 	subscriptionStore store.SubscriptionStore,
 	userStore store.UserStore,
+	endorsementStore store.EndorsementStore,
+	pricingService *PricingEngineService,
+	invoiceService *InvoiceService,
 	eventService *EventService,
+	commissionService *CommissionService,
+	quoteService *QuoteService,
+	claimStore store.ClaimStore,
 ) *PolicyLifecycleService {
 	return &PolicyLifecycleService{
 		policyStore:       policyStore,
 		paymentStore:      paymentStore,
 		subscriptionStore: subscriptionStore,
 		userStore:         userStore,
+		endorsementStore:  endorsementStore,
+		pricingService:    pricingService,
+		invoiceService:    invoiceService,
 		eventService:      eventService,
+		commissionService: commissionService,
+		quoteService:      quoteService,
+		claimStore:        claimStore,
 		configManager:     configManager,
 		logger:            logger,
 	}
 }
 
+// SetNotificationService sets the service used to notify customers of renewals and expiries.
+func (s *PolicyLifecycleService) SetNotificationService(notifications *NotificationService) {
+	s.notifications = notifications
+}
+
+// SetNumberGenerator sets the generator used to number renewal policies. Without one,
+// renewal numbers are generated from a timestamp and a random component.
+func (s *PolicyLifecycleService) SetNumberGenerator(numbers *NumberGenerator) {
+	s.numbers = numbers
+}
+
 // RenewalResult represents the result of a policy renewal attempt.
 type RenewalResult struct {
 	Success        bool                   `json:"success"`
 	NewPolicyID    *uuid.UUID             `json:"new_policy_id,omitempty"`
+	InvoiceID      *uuid.UUID             `json:"invoice_id,omitempty"`
 	RenewalDate    time.Time              `json:"renewal_date"`
 	Premium        float64                `json:"premium"`
 	Currency       string                 `json:"currency"`
@@ -80,8 +125,15 @@ func (s *PolicyLifecycleService) RenewPolicy(ctx context.Context, policyID uuid.
 		return nil, fmt.Errorf("failed to fetch policy: %w", err)
 	}
 
+	return s.renewPolicy(ctx, policy, renewalOptions, nil)
+}
+
+// renewPolicy renews policy for the term in renewalOptions. The renewal is charged the
+// price of offer, or of the policy's valid renewal offer for the same term when offer is
+// nil; only without one is the term priced afresh.
+func (s *PolicyLifecycleService) renewPolicy(ctx context.Context, policy *models.Policy, renewalOptions *RenewalOptions, offer *models.Quote) (*RenewalResult, error) {
 	// Validate policy is eligible for renewal
-	if err := s.validateRenewalEligibility(policy); err != nil {
+	if err := s.validateRenewalEligibility(ctx, policy); err != nil {
 		return &RenewalResult{
 			Success: false,
 			Status:  "failed",
@@ -94,24 +146,47 @@ func (s *PolicyLifecycleService) RenewPolicy(ctx context.Context, policyID uuid.
 		renewalOptions = s.getDefaultRenewalOptions(policy)
 	}
 
-	// Calculate new premium
-	newPremium, err := s.calculateRenewalPremium(ctx, policy, renewalOptions)
-	if err != nil {
-		return nil, fmt.Errorf("failed to calculate renewal premium: %w", err)
+	// Charge the locked offer price, or calculate a new premium
+	var err error
+	if offer == nil {
+		if offer, err = s.matchingRenewalOffer(ctx, policy, renewalOptions); err != nil {
+			return nil, fmt.Errorf("failed to get renewal offer: %w", err)
+		}
+	}
+	var newPremium float64
+	var breakdown *PricingBreakdown
+	if offer != nil {
+		newPremium = offer.FinalPrice
+		breakdown = offer.PricingBreakdown
+	} else {
+		pricing, err := s.calculateRenewalPremium(ctx, policy, renewalOptions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to calculate renewal premium: %w", err)
+		}
+		newPremium = pricing.Premium
+		breakdown = pricing.Breakdown
 	}
 
 	// Create new policy
 	newPolicy := &models.Policy{
 		ProductID:        policy.ProductID,
 		UserID:           policy.UserID,
+		PreviousPolicyID: &policy.ID,
 		Premium:          newPremium,
 		Currency:         policy.Currency,
 		CoverageAmount:   renewalOptions.CoverageAmount,
-		Status:           "pending",
+		CoverageTier:     policy.CoverageTier,
+		Deductible:       policy.Deductible,
+		Status:           models.PolicyStatusPending,
 		EffectiveDate:    renewalOptions.EffectiveDate,
 		ExpirationDate:   renewalOptions.ExpirationDate,
 		PaymentFrequency: renewalOptions.PaymentFrequency,
 		AutoRenew:        renewalOptions.AutoRenew,
+		Jurisdiction:     policy.Jurisdiction,
+		PricingBreakdown: breakdown,
+	}
+	if offer != nil {
+		newPolicy.QuoteID = &offer.ID
 	}
 
 	// Set renewal date if auto-renew is enabled
@@ -119,10 +194,50 @@ func (s *PolicyLifecycleService) RenewPolicy(ctx context.Context, policyID uuid.
 		newPolicy.RenewalDate = &newPolicy.ExpirationDate
 	}
 
-	// Create new policy in database
-	if err := s.policyStore.CreatePolicy(ctx, newPolicy); err != nil {
+	if s.numbers != nil {
+		number, err := s.numbers.PolicyNumber(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate renewal policy number: %w", err)
+		}
+		newPolicy.PolicyNumber = number
+	} else {
+		newPolicy.PolicyNumber = generatePolicyNumber()
+	}
+
+	// Create the new policy together with the invoice billing the renewal premium
+	var invoice *models.Invoice
+	if s.invoiceService != nil {
+		if invoice, err = s.invoiceService.newPolicyInvoice(newPolicy, models.InvoiceReasonRenewal, newPolicy.PricingBreakdown); err != nil {
+			return nil, fmt.Errorf("failed to generate renewal invoice: %w", err)
+		}
+		err = s.policyStore.CreatePolicyWithInvoice(ctx, newPolicy, invoice)
+	} else {
+		err = s.policyStore.CreatePolicy(ctx, newPolicy)
+	}
+	if errors.Is(err, ErrPolicyAlreadyRenewed) {
+		// Another caller renewed the policy after the eligibility check
+		return &RenewalResult{
+			Success: false,
+			Status:  "failed",
+			Message: ErrPolicyAlreadyRenewed.Error(),
+		}, nil
+	}
+	if err != nil {
 		return nil, fmt.Errorf("failed to create renewal policy: %w", err)
 	}
+	if invoice != nil {
+		s.invoiceService.publishInvoiceCreated(ctx, invoice)
+	}
+
+	// The accepted offer cannot be used again
+	if offer != nil {
+		offer.Status = models.QuoteStatusUsed
+		if err := s.quoteService.UpdateQuote(ctx, offer); err != nil {
+			s.logger.Error("Failed to mark renewal offer as used",
+				zap.String("quote_id", offer.ID.String()),
+				zap.Error(err))
+		}
+	}
 
 	// Handle payment for renewal
 	result := &RenewalResult{
@@ -133,30 +248,61 @@ func (s *PolicyLifecycleService) RenewPolicy(ctx context.Context, policyID uuid.
 		Metadata:    make(map[string]interface{}),
 	}
 
+	// The renewal is charged what it is invoiced, including tax and fees
+	amountDue := newPremium
+	if invoice != nil {
+		result.InvoiceID = &invoice.ID
+		amountDue = invoice.Total
+	}
+
 	if renewalOptions.PaymentMethod != "" {
 		// Process payment for renewal
-		_, err = s.processRenewalPayment(ctx, newPolicy, renewalOptions)
+		payment, err := s.processRenewalPayment(ctx, newPolicy, amountDue, renewalOptions)
 		if err != nil {
 			// Payment failed - set grace period
 			result.Success = false
 			result.Status = "pending_payment"
 			result.Message = "Renewal created but payment failed"
-			result.GracePeriodEnd = s.calculateGracePeriodEnd()
+			result.GracePeriodEnd = s.startGracePeriod(ctx, newPolicy, models.GracePeriodCausePaymentFailure)
 			result.Metadata["payment_error"] = err.Error()
 		} else {
 			// Payment successful
 			result.Success = true
 			result.Status = "renewed"
 			result.Message = "Policy renewed successfully"
-			newPolicy.Status = "active"
-			_ = s.policyStore.UpdatePolicy(ctx, newPolicy)
+			if result.InvoiceID != nil {
+				if _, err := s.invoiceService.MarkPaid(ctx, *result.InvoiceID, &payment.PaymentID); err != nil {
+					s.logger.Error("Failed to mark renewal invoice as paid",
+						zap.String("invoice_id", result.InvoiceID.String()),
+						zap.Error(err))
+					result.Metadata["invoice_error"] = err.Error()
+				}
+			}
+			activated, _, err := updatePolicyWithRetry(ctx, s.policyStore, newPolicy, func(policy *models.Policy) (bool, error) {
+				if policy.Status == models.PolicyStatusActive {
+					return false, nil
+				}
+				return true, policy.TransitionTo(models.PolicyStatusActive)
+			})
+			if err != nil {
+				// The renewal is paid for, so it is reported for follow-up rather than failed
+				s.logger.Error("Failed to activate paid renewal",
+					zap.String("policy_id", newPolicy.ID.String()),
+					zap.Error(err))
+				result.Success = false
+				result.Status = "pending_activation"
+				result.Message = "Renewal paid but the policy could not be activated"
+				result.Metadata["activation_error"] = err.Error()
+			} else {
+				newPolicy = activated
+			}
 		}
 	} else {
 		// No payment method specified - set grace period
 		result.Success = false
 		result.Status = "pending_payment"
 		result.Message = "Renewal created but payment method required"
-		result.GracePeriodEnd = s.calculateGracePeriodEnd()
+		result.GracePeriodEnd = s.startGracePeriod(ctx, newPolicy, models.GracePeriodCauseRenewal)
 	}
 
 	// Publish renewal event
@@ -168,6 +314,8 @@ func (s *PolicyLifecycleService) RenewPolicy(ctx context.Context, policyID uuid.
 			newPolicy.ProductID,
 			newPolicy.Premium,
 			newPolicy.Currency,
+			newPolicy.PaymentFrequency,
+			newPolicy.Jurisdiction,
 			newPolicy.EffectiveDate,
 			newPolicy.ExpirationDate,
 			time.Now(),
@@ -213,8 +361,9 @@ type RenewalOptions struct {
 	PaymentMethod    string    `json:"payment_method"`
 }
 
-// validateRenewalEligibility validates if a policy is eligible for renewal.
-func (s *PolicyLifecycleService) validateRenewalEligibility(policy *models.Policy) error {
+// validateRenewalEligibility validates if a policy is eligible for renewal. A policy that
+// already has a renewal is not renewed again.
+func (s *PolicyLifecycleService) validateRenewalEligibility(ctx context.Context, policy *models.Policy) error {
 	// Check if policy is active
 	if policy.Status != models.PolicyStatusActive {
 		return fmt.Errorf("policy is not active and cannot be renewed")
@@ -225,15 +374,19 @@ func (s *PolicyLifecycleService) validateRenewalEligibility(policy *models.Polic
 		return fmt.Errorf("policy has expired and cannot be renewed")
 	}
 
-	// Check if policy is not already cancelled
-	if policy.Status == models.PolicyStatusCancelled {
-		return fmt.Errorf("cancelled policy cannot be renewed")
+	// Check if renewal is within the configured advance renewal window
+	advanceDays := s.advanceRenewalDays()
+	daysUntilExpiration := time.Until(policy.ExpirationDate).Hours() / 24
+	if daysUntilExpiration > float64(advanceDays) {
+		return fmt.Errorf("policy cannot be renewed more than %d days before expiration", advanceDays)
 	}
 
-	// Check if renewal is within allowed timeframe (e.g., 30 days before expiration)
-	daysUntilExpiration := time.Until(policy.ExpirationDate).Hours() / 24
-	if daysUntilExpiration > 30 {
-		return fmt.Errorf("policy cannot be renewed more than 30 days before expiration")
+	renewed, err := s.policyStore.HasRenewal(ctx, policy.ID)
+	if err != nil {
+		return err
+	}
+	if renewed {
+		return ErrPolicyAlreadyRenewed
 	}
 
 	return nil
@@ -254,41 +407,114 @@ func (s *PolicyLifecycleService) getDefaultRenewalOptions(policy *models.Policy)
 	}
 }
 
-// calculateRenewalPremium calculates the premium for policy renewal.
-func (s *PolicyLifecycleService) calculateRenewalPremium(ctx context.Context, policy *models.Policy, options *RenewalOptions) (float64, error) {
-	// Base premium from existing policy
-	basePremium := policy.Premium
+// renewalPricing is the price of a renewal term.
+type renewalPricing struct {
+	Premium float64
+	// RateTableVersion is the rate table version the engine priced the term from, empty when
+	// the term was not priced by the engine.
+	RateTableVersion string
+	// Breakdown itemizes Premium; nil when the term was not priced by the engine.
+	Breakdown *PricingBreakdown
+}
+
+// calculateRenewalPremium calculates the premium for policy renewal. The renewal term is
+// re-priced by the pricing engine, which applies the no-claims bonus, loyalty discounts and
+// payment frequency adjustment, and the configured renewal rate increase is applied on top.
+// The increase scales every component of the engine's breakdown, so the breakdown still
+// accounts for the renewal premium.
+func (s *PolicyLifecycleService) calculateRenewalPremium(ctx context.Context, policy *models.Policy, options *RenewalOptions) (*renewalPricing, error) {
+	rules := s.configManager.GetConfig().PolicyLifecycle.RenewalRules
+
+	pricing, err := s.renewalBasePremium(ctx, policy, options)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply annual rate increase
+	factor := 1 + rules.RateIncreaseRate
+	unrounded := pricing.Premium * factor
+	pricing.Premium = roundCurrency(unrounded)
 
-	// Apply coverage amount adjustment
-	if options.CoverageAmount != policy.CoverageAmount {
-		coverageRatio := options.CoverageAmount / policy.CoverageAmount
-		basePremium *= coverageRatio
+	if pricing.Breakdown != nil {
+		breakdown := scaleBreakdown(*pricing.Breakdown, factor)
+		breakdown.RoundingAdjustment += pricing.Premium - unrounded
+		breakdown.TotalAdjustment = breakdown.Total() - breakdown.BaseRate
+		pricing.Breakdown = &breakdown
 	}
 
-	// Apply annual rate increase (e.g., 3% per year)
-	rateIncrease := 1.03 // 3% increase
-	basePremium *= rateIncrease
+	return pricing, nil
+}
+
+// scaleBreakdown scales every component of a pricing breakdown by factor.
+func scaleBreakdown(b PricingBreakdown, factor float64) PricingBreakdown {
+	return PricingBreakdown{
+		BaseRate:            b.BaseRate * factor,
+		CoverageAdjustment:  b.CoverageAdjustment * factor,
+		RiskAdjustment:      b.RiskAdjustment * factor,
+		DiscountAdjustment:  b.DiscountAdjustment * factor,
+		TaxAdjustment:       b.TaxAdjustment * factor,
+		FrequencyAdjustment: b.FrequencyAdjustment * factor,
+		MarketAdjustment:    b.MarketAdjustment * factor,
+		BoundsAdjustment:    b.BoundsAdjustment * factor,
+		RoundingAdjustment:  b.RoundingAdjustment * factor,
+		TotalAdjustment:     b.TotalAdjustment * factor,
+	}
+}
+
+// renewalBasePremium prices the renewal term with the pricing engine. Without a pricing
+// engine the current premium is scaled to the renewal coverage amount and the configured
+// payment frequency discount is applied, since no engine adjusted it for the frequency.
+func (s *PolicyLifecycleService) renewalBasePremium(ctx context.Context, policy *models.Policy, options *RenewalOptions) (*renewalPricing, error) {
+	if s.pricingService == nil {
+		basePremium := policy.Premium
+		if options.CoverageAmount != policy.CoverageAmount && policy.CoverageAmount > 0 {
+			basePremium *= options.CoverageAmount / policy.CoverageAmount
+		}
+
+		// Apply payment frequency adjustment; negative discounts are surcharges
+		rules := s.configManager.GetConfig().PolicyLifecycle.RenewalRules
+		basePremium *= 1 - rules.FrequencyDiscounts[options.PaymentFrequency]
+		return &renewalPricing{Premium: basePremium}, nil
+	}
 
-	// Apply payment frequency adjustment
-	switch options.PaymentFrequency {
-	case "annually":
-		basePremium *= 0.95 // 5% discount for annual payment
-	case "quarterly":
-		basePremium *= 1.02 // 2% surcharge for quarterly payment
-	case "monthly":
-		basePremium *= 1.05 // 5% surcharge for monthly payment
+	pricing, err := s.pricingService.CalculatePremium(ctx, &PricingRequest{
+		ProductID:         policy.ProductID,
+		UserID:            policy.UserID,
+		CoverageAmount:    options.CoverageAmount,
+		CoverageTier:      policy.CoverageTier,
+		Deductible:        policy.Deductible,
+		Currency:          policy.Currency,
+		PaymentFrequency:  options.PaymentFrequency,
+		EffectiveDate:     options.EffectiveDate,
+		ExpirationDate:    options.ExpirationDate,
+		Jurisdiction:      policy.Jurisdiction,
+		RenewalOfPolicyID: &policy.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to price renewal term: %w", err)
 	}
 
-	return basePremium, nil
+	breakdown := pricing.Breakdown
+	return &renewalPricing{
+		Premium:          pricing.FinalPremium,
+		RateTableVersion: pricing.RateTableVersion,
+		Breakdown:        &breakdown,
+	}, nil
 }
 
-// processRenewalPayment processes payment for policy renewal.
-func (s *PolicyLifecycleService) processRenewalPayment(ctx context.Context, policy *models.Policy, options *RenewalOptions) (*PaymentResult, error) {
+// processRenewalPayment charges amount for a policy renewal.
+func (s *PolicyLifecycleService) processRenewalPayment(ctx context.Context, policy *models.Policy, amount float64, options *RenewalOptions) (*PaymentResult, error) {
+	if s.paymentStore == nil {
+		s.logger.Warn("Skipping renewal payment: no payment store configured",
+			zap.String("policy_id", policy.ID.String()))
+		return nil, errPaymentsNotConfigured
+	}
+
 	// Create payment record
 	payment := &models.Payment{
 		UserID:          policy.UserID,
 		PolicyID:        &policy.ID,
-		Amount:          policy.Premium,
+		Amount:          amount,
 		Currency:        policy.Currency,
 		Status:          models.PaymentStatusPending,
 		PaymentMethod:   options.PaymentMethod,
@@ -313,6 +539,7 @@ func (s *PolicyLifecycleService) processRenewalPayment(ctx context.Context, poli
 
 	return &PaymentResult{
 		Success:       true,
+		PaymentID:     payment.ID,
 		TransactionID: payment.TransactionID,
 		Amount:        payment.Amount,
 		Currency:      payment.Currency,
@@ -321,19 +548,45 @@ func (s *PolicyLifecycleService) processRenewalPayment(ctx context.Context, poli
 
 // PaymentResult represents the result of a payment processing attempt.
 type PaymentResult struct {
-	Success       bool    `json:"success"`
-	TransactionID string  `json:"transaction_id"`
-	Amount        float64 `json:"amount"`
-	Currency      string  `json:"currency"`
-	Error         string  `json:"error,omitempty"`
+	Success       bool      `json:"success"`
+	PaymentID     uuid.UUID `json:"payment_id"`
+	TransactionID string    `json:"transaction_id"`
+	Amount        float64   `json:"amount"`
+	Currency      string    `json:"currency"`
+	Error         string    `json:"error,omitempty"`
 }
 
-// calculateGracePeriodEnd calculates when the grace period ends.
-func (s *PolicyLifecycleService) calculateGracePeriodEnd() *time.Time {
-	gracePeriodEnd := time.Now().Add(15 * 24 * time.Hour) // 15 days grace period
+// calculateGracePeriodEnd calculates when a grace period granted now for cause ends. The
+// cause selects the configured duration, falling back to DefaultDays when it is unset.
+func (s *PolicyLifecycleService) calculateGracePeriodEnd(cause string) *time.Time {
+	rules := s.configManager.GetConfig().PolicyLifecycle.GracePeriodRules
+	var days int
+	switch cause {
+	case models.GracePeriodCausePaymentFailure:
+		days = rules.PaymentFailureDays
+	case models.GracePeriodCauseRenewal:
+		days = rules.RenewalDays
+	}
+	if days <= 0 {
+		days = rules.DefaultDays
+	}
+	gracePeriodEnd := time.Now().AddDate(0, 0, days)
 	return &gracePeriodEnd
 }
 
+// startGracePeriod records a grace period granted for cause on a policy awaiting payment
+// and returns its end.
+func (s *PolicyLifecycleService) startGracePeriod(ctx context.Context, policy *models.Policy, cause string) *time.Time {
+	policy.GracePeriodEnd = s.calculateGracePeriodEnd(cause)
+	policy.GracePeriodCause = cause
+	if err := s.policyStore.UpdatePolicy(ctx, policy); err != nil {
+		s.logger.Error("Failed to record policy grace period",
+			zap.String("policy_id", policy.ID.String()),
+			zap.Error(err))
+	}
+	return policy.GracePeriodEnd
+}
+
 // CancelPolicy cancels an existing policy.
 func (s *PolicyLifecycleService) CancelPolicy(ctx context.Context, policyID uuid.UUID, cancellationOptions *CancellationOptions) (*CancellationResult, error) {
 	// Fetch existing policy
@@ -357,17 +610,23 @@ func (s *PolicyLifecycleService) CancelPolicy(ctx context.Context, policyID uuid
 	}
 
 	// Calculate refund amount
-	refundAmount, err := s.calculateRefundAmount(policy, cancellationOptions)
+	freeLook := s.withinFreeLookPeriod(policy, cancellationOptions)
+	refundAmount, err := s.calculateRefundAmount(ctx, policy, cancellationOptions, freeLook)
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate refund amount: %w", err)
 	}
 
-	// Update policy status
-	policy.Status = "cancelled"
+	// Update policy status, re-validating eligibility if a concurrent update intervenes
 	now := time.Now()
-	policy.UpdatedAt = now
-
-	if err := s.policyStore.UpdatePolicy(ctx, policy); err != nil {
+	policy, _, err = updatePolicyWithRetry(ctx, s.policyStore, policy, func(policy *models.Policy) (bool, error) {
+		if err := s.validateCancellationEligibility(policy); err != nil {
+			return false, err
+		}
+		policy.Status = models.PolicyStatusCancelled
+		policy.UpdatedAt = now
+		return true, nil
+	})
+	if err != nil {
 		return nil, fmt.Errorf("failed to update policy status: %w", err)
 	}
 
@@ -382,6 +641,9 @@ func (s *PolicyLifecycleService) CancelPolicy(ctx context.Context, policyID uuid
 		Message:          "Policy cancelled successfully",
 		Metadata:         make(map[string]interface{}),
 	}
+	if freeLook {
+		result.Metadata["free_look"] = true
+	}
 
 	// Process refund if applicable
 	if refundAmount > 0 {
@@ -397,17 +659,29 @@ func (s *PolicyLifecycleService) CancelPolicy(ctx context.Context, policyID uuid
 		}
 	}
 
-	// Publish cancellation event
-	if s.eventService != nil {
-		// Note: We would need a PolicyCancelledEvent in the events package
-		// For now, we'll log the cancellation
-		s.logger.Info("Policy cancelled",
-			zap.String("policy_id", policy.ID.String()),
-			zap.String("user_id", policy.UserID.String()),
-			zap.Float64("refund_amount", refundAmount),
-			zap.String("reason", cancellationOptions.Reason))
+	// Claw back commission paid on a policy cancelled early
+	if s.commissionService != nil {
+		clawbacks, err := s.commissionService.ClawbackCommission(ctx, policy.ID)
+		if err != nil {
+			s.logger.Error("Failed to claw back commission",
+				zap.String("policy_id", policy.ID.String()),
+				zap.Error(err))
+			result.Metadata["commission_clawback_error"] = err.Error()
+		} else if len(clawbacks) > 0 {
+			clawedBack := 0.0
+			for _, clawback := range clawbacks {
+				clawedBack -= clawback.Amount
+			}
+			result.Metadata["commission_clawback_amount"] = clawedBack
+		}
 	}
 
+	s.logger.Info("Policy cancelled",
+		zap.String("policy_id", policy.ID.String()),
+		zap.String("user_id", policy.UserID.String()),
+		zap.Float64("refund_amount", refundAmount),
+		zap.String("reason", cancellationOptions.Reason))
+
 	// Publish policy cancelled event
 	if s.eventService != nil {
 		policyCancelledEvent := events.NewPolicyCancelledEvent(
@@ -430,23 +704,29 @@ func (s *PolicyLifecycleService) CancelPolicy(ctx context.Context, policyID uuid
 	return result, nil
 }
 
+// Cancellation initiators.
+const (
+	CancellationInitiatorCustomer = "customer"
+	CancellationInitiatorInsurer  = "insurer"
+)
+
 // CancellationOptions represents options for policy cancellation.
 type CancellationOptions struct {
 	EffectiveDate time.Time `json:"effective_date"`
 	Reason        string    `json:"reason"`
 	RefundMethod  string    `json:"refund_method"`
+	// InitiatedBy is who asked for the cancellation; only customers may use the free-look period.
+	InitiatedBy string `json:"initiated_by"`
 }
 
 // validateCancellationEligibility validates if a policy can be cancelled.
 func (s *PolicyLifecycleService) validateCancellationEligibility(policy *models.Policy) error {
-	// Check if policy is active
-	if policy.Status != "active" {
-		return fmt.Errorf("only active policies can be cancelled")
+	if policy.Status == models.PolicyStatusCancelled {
+		return fmt.Errorf("policy has already been cancelled")
 	}
 
-	// Check if policy has not already been cancelled
-	if policy.Status == "cancelled" {
-		return fmt.Errorf("policy has already been cancelled")
+	if !policy.Status.CanTransitionTo(models.PolicyStatusCancelled) {
+		return fmt.Errorf("policy with status %s cannot be cancelled", policy.Status)
 	}
 
 	return nil
@@ -458,11 +738,21 @@ func (s *PolicyLifecycleService) getDefaultCancellationOptions(policy *models.Po
 		EffectiveDate: time.Now(),
 		Reason:        "Customer request",
 		RefundMethod:  "original_payment_method",
+		InitiatedBy:   CancellationInitiatorCustomer,
 	}
 }
 
-// calculateRefundAmount calculates the refund amount for policy cancellation.
-func (s *PolicyLifecycleService) calculateRefundAmount(policy *models.Policy, options *CancellationOptions) (float64, error) {
+// calculateRefundAmount calculates the refund amount for policy cancellation. A policy
+// cancelled within its free-look period is refunded everything paid for it, up to the premium.
+func (s *PolicyLifecycleService) calculateRefundAmount(ctx context.Context, policy *models.Policy, options *CancellationOptions, freeLook bool) (float64, error) {
+	if freeLook {
+		paid, err := s.amountPaid(ctx, policy)
+		if err != nil {
+			return 0, err
+		}
+		return math.Min(paid, policy.Premium), nil
+	}
+
 	// Calculate unused premium
 	policyDuration := policy.ExpirationDate.Sub(policy.EffectiveDate).Hours() / 24 / 365 // years
 	usedDuration := options.EffectiveDate.Sub(policy.EffectiveDate).Hours() / 24 / 365   // years
@@ -491,8 +781,56 @@ func (s *PolicyLifecycleService) calculateRefundAmount(policy *models.Policy, op
 	return refundAmount, nil
 }
 
+// amountPaid returns the net amount paid for a policy: its completed payments less any
+// refunds. Nothing is known to be paid when payments are not configured.
+func (s *PolicyLifecycleService) amountPaid(ctx context.Context, policy *models.Policy) (float64, error) {
+	if s.paymentStore == nil {
+		return 0, nil
+	}
+	payments, err := s.paymentStore.ListPayments(ctx, nil, &policy.ID, nil, models.PaymentStatusCompleted, 0, 0)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list policy payments: %w", err)
+	}
+
+	paid := 0.0
+	for _, payment := range payments {
+		paid += payment.Amount // Refunds are recorded with negative amounts
+	}
+	return math.Max(paid, 0), nil
+}
+
+// withinFreeLookPeriod reports whether a cancellation falls in the policy's free-look
+// period. The period only applies to new business cancelled at the customer's request, and
+// runs from issue for the days configured for the policy's jurisdiction.
+func (s *PolicyLifecycleService) withinFreeLookPeriod(policy *models.Policy, options *CancellationOptions) bool {
+	if options.InitiatedBy != CancellationInitiatorCustomer || policy.PreviousPolicyID != nil {
+		return false
+	}
+
+	rules := s.configManager.GetConfig().PolicyLifecycle.CancellationRules
+	days := rules.FreeLookDays
+	if override, ok := rules.JurisdictionFreeLookDays[strings.ToUpper(policy.Jurisdiction)]; ok {
+		days = override
+	}
+	if days <= 0 {
+		return false
+	}
+
+	issuedAt := policy.CreatedAt
+	if issuedAt.IsZero() {
+		issuedAt = policy.EffectiveDate
+	}
+	return !options.EffectiveDate.After(issuedAt.AddDate(0, 0, days))
+}
+
 // processRefund processes the refund for policy cancellation.
 func (s *PolicyLifecycleService) processRefund(ctx context.Context, policy *models.Policy, refundAmount float64, options *CancellationOptions) (*PaymentResult, error) {
+	if s.paymentStore == nil {
+		s.logger.Warn("Skipping cancellation refund: no payment store configured",
+			zap.String("policy_id", policy.ID.String()))
+		return nil, errPaymentsNotConfigured
+	}
+
 	// Create refund payment record
 	now := time.Now()
 	refund := &models.Payment{
@@ -514,35 +852,113 @@ func (s *PolicyLifecycleService) processRefund(ctx context.Context, policy *mode
 
 	return &PaymentResult{
 		Success:       true,
+		PaymentID:     refund.ID,
 		TransactionID: refund.TransactionID,
 		Amount:        refundAmount,
 		Currency:      policy.Currency,
 	}, nil
 }
 
-// ProcessExpiredPolicies processes policies that have expired.
-func (s *PolicyLifecycleService) ProcessExpiredPolicies(ctx context.Context) error {
-	s.logger.Info("Processing expired policies")
+// Lifecycle batch actions.
+const (
+	LifecycleActionExpire       = "expire"
+	LifecycleActionRenew        = "renew"
+	LifecycleActionManualReview = "manual_review"
+)
 
-	// Query for expired policies
-	// Note: GetExpiredPolicies method needs to be implemented in PolicyStore
-	// For now, we'll simulate with an empty slice
-	expiredPolicies := []*models.Policy{}
-	// expiredPolicies, err := s.policyStore.GetExpiredPolicies(ctx)
-	// if err != nil {
-	// 	return fmt.Errorf("failed to fetch expired policies: %w", err)
-	// }
+// LifecycleBatchOptions configures a batch lifecycle run.
+type LifecycleBatchOptions struct {
+	// DryRun reports the actions the run would take without writing to the store or publishing events.
+	DryRun bool `json:"dry_run"`
+}
+
+// LifecycleAction describes an action taken, or in a dry run planned, for a single policy.
+type LifecycleAction struct {
+	PolicyID     uuid.UUID `json:"policy_id"`
+	PolicyNumber string    `json:"policy_number"`
+	Action       string    `json:"action"`
+	// Premium is the renewal premium for renew actions.
+	Premium       float64  `json:"premium,omitempty"`
+	Notifications []string `json:"notifications,omitempty"`
+	// Reason explains why a policy was routed to manual review.
+	Reason string `json:"reason,omitempty"`
+}
+
+// LifecycleBatchResult summarizes a batch lifecycle run over a set of policies.
+type LifecycleBatchResult struct {
+	DryRun     bool `json:"dry_run"`
+	Candidates int  `json:"candidates"`
+	Processed  int  `json:"processed"`
+	Failed     int  `json:"failed"`
+	// ManualReview counts policies held back for manual review instead of being processed.
+	ManualReview int `json:"manual_review"`
+	// Skipped counts policies passed over because they already have a renewal.
+	Skipped int               `json:"skipped"`
+	Actions []LifecycleAction `json:"actions,omitempty"`
+	// Interrupted is set when the run stopped early because the context was cancelled.
+	Interrupted bool `json:"interrupted"`
+}
+
+// ProcessExpiredPolicies expires active policies past their expiration date. The context is
+// checked before each policy; on cancellation the partial result is returned with the context error.
+func (s *PolicyLifecycleService) ProcessExpiredPolicies(ctx context.Context, options *LifecycleBatchOptions) (*LifecycleBatchResult, error) {
+	if options == nil {
+		options = &LifecycleBatchOptions{}
+	}
+	s.logger.Info("Processing expired policies", zap.Bool("dry_run", options.DryRun))
+
+	result := &LifecycleBatchResult{DryRun: options.DryRun}
+	expiredPolicies, err := s.policyStore.ListExpiredPolicies(ctx, time.Now())
+	if err != nil {
+		return result, fmt.Errorf("failed to fetch expired policies: %w", err)
+	}
+	result.Candidates = len(expiredPolicies)
 
-	processedCount := 0
 	for _, policy := range expiredPolicies {
+		if err := ctx.Err(); err != nil {
+			result.Interrupted = true
+			s.logger.Warn("Expired policy processing interrupted",
+				zap.Int("processed", result.Processed),
+				zap.Int("candidates", result.Candidates),
+				zap.Error(err))
+			return result, err
+		}
+
+		if !policy.Status.CanTransitionTo(models.PolicyStatusExpired) {
+			s.logger.Warn("Skipping expiry of policy",
+				zap.String("policy_id", policy.ID.String()),
+				zap.String("status", string(policy.Status)))
+			result.Failed++
+			continue
+		}
+
+		action := LifecycleAction{
+			PolicyID:      policy.ID,
+			PolicyNumber:  policy.PolicyNumber,
+			Action:        LifecycleActionExpire,
+			Notifications: s.lifecycleNotifications(events.EventTypePolicyExpired),
+		}
+		if options.DryRun {
+			result.Actions = append(result.Actions, action)
+			result.Processed++
+			continue
+		}
+
 		// Update policy status to expired
-		policy.Status = "expired"
+		if err := policy.TransitionTo(models.PolicyStatusExpired); err != nil {
+			s.logger.Warn("Skipping expiry of policy",
+				zap.String("policy_id", policy.ID.String()),
+				zap.Error(err))
+			result.Failed++
+			continue
+		}
 		policy.UpdatedAt = time.Now()
 
 		if err := s.policyStore.UpdatePolicy(ctx, policy); err != nil {
 			s.logger.Error("Failed to update expired policy status",
 				zap.String("policy_id", policy.ID.String()),
 				zap.Error(err))
+			result.Failed++
 			continue
 		}
 
@@ -561,14 +977,50 @@ func (s *PolicyLifecycleService) ProcessExpiredPolicies(ctx context.Context) err
 					zap.Error(err))
 			}
 		}
+		s.notifyPolicyholder(ctx, policy, NotificationTypePolicyExpired, map[string]interface{}{})
 
-		processedCount++
+		result.Actions = append(result.Actions, action)
+		result.Processed++
 	}
 
 	s.logger.Info("Processed expired policies",
-		zap.Int("count", processedCount))
+		zap.Bool("dry_run", options.DryRun),
+		zap.Int("count", result.Processed),
+		zap.Int("failed", result.Failed))
 
-	return nil
+	return result, nil
+}
+
+// lifecycleNotifications returns the event types a lifecycle action publishes, or none
+// when no event service is configured.
+func (s *PolicyLifecycleService) lifecycleNotifications(eventTypes ...string) []string {
+	if s.eventService == nil {
+		return nil
+	}
+	return eventTypes
+}
+
+// notifyPolicyholder sends a notification about a policy to its holder. Failures are logged
+// and do not affect the lifecycle action.
+func (s *PolicyLifecycleService) notifyPolicyholder(ctx context.Context, policy *models.Policy, notificationType string, data map[string]interface{}) {
+	if s.notifications == nil {
+		return
+	}
+
+	data["PolicyNumber"] = policy.PolicyNumber
+	data["ExpirationDate"] = policy.ExpirationDate.Format("2006-01-02")
+	notification := &Notification{
+		Type:     notificationType,
+		UserID:   policy.UserID,
+		Data:     data,
+		DedupKey: fmt.Sprintf("%s:%s:%s", notificationType, policy.ID, time.Now().Format("2006-01-02")),
+	}
+	if _, err := s.notifications.Notify(ctx, notification); err != nil {
+		s.logger.Error("Failed to notify policyholder",
+			zap.String("policy_id", policy.ID.String()),
+			zap.String("type", notificationType),
+			zap.Error(err))
+	}
 }
 
 // ProcessGracePeriodExpirations processes policies whose grace periods have expired.
@@ -591,6 +1043,7 @@ func (s *PolicyLifecycleService) ProcessGracePeriodExpirations(ctx context.Conte
 			EffectiveDate: time.Now(),
 			Reason:        "Grace period expired",
 			RefundMethod:  "original_payment_method",
+			InitiatedBy:   CancellationInitiatorInsurer,
 		}
 
 		_, err := s.CancelPolicy(ctx, policy.ID, cancellationOptions)
@@ -603,11 +1056,15 @@ func (s *PolicyLifecycleService) ProcessGracePeriodExpirations(ctx context.Conte
 
 		// Publish grace period expiration event
 		if s.eventService != nil {
+			gracePeriodEnd := time.Now()
+			if policy.GracePeriodEnd != nil {
+				gracePeriodEnd = *policy.GracePeriodEnd
+			}
 			gracePeriodExpiredEvent := events.NewGracePeriodExpiredEvent(
 				policy.ID,
 				policy.UserID,
 				policy.ProductID,
-				time.Now().Add(-15*24*time.Hour), // Assume 15 days grace period
+				gracePeriodEnd,
 				time.Now(),
 			)
 			if err := s.eventService.PublishEvent(ctx, gracePeriodExpiredEvent); err != nil {
@@ -635,16 +1092,23 @@ func (s *PolicyLifecycleService) GetPolicyStatus(ctx context.Context, policyID u
 	}
 
 	// Calculate status information
+	now := time.Now()
 	status := &PolicyStatus{
 		PolicyID:        policy.ID,
 		Status:          policy.Status,
 		EffectiveDate:   policy.EffectiveDate,
 		ExpirationDate:  policy.ExpirationDate,
-		DaysUntilExpiry: int(time.Until(policy.ExpirationDate).Hours() / 24),
-		CanRenew:        s.canRenew(policy),
+		DaysUntilExpiry: int(policy.ExpirationDate.Sub(now).Hours() / 24),
+		CanRenew:        s.canRenew(ctx, policy),
 		CanCancel:       s.canCancel(policy),
 		AutoRenew:       policy.AutoRenew,
-		GracePeriodEnd:  s.calculateGracePeriodEnd(),
+		InGracePeriod:   policy.InGracePeriod(now),
+	}
+
+	// Only report a grace period end while the policy is actually in grace
+	if status.InGracePeriod {
+		status.GracePeriodEnd = policy.GracePeriodEnd
+		status.GracePeriodCause = policy.GracePeriodCause
 	}
 
 	return status, nil
@@ -652,103 +1116,185 @@ func (s *PolicyLifecycleService) GetPolicyStatus(ctx context.Context, policyID u
 
 // PolicyStatus represents the current status of a policy.
 type PolicyStatus struct {
-	PolicyID        uuid.UUID  `json:"policy_id"`
-	Status          string     `json:"status"`
-	EffectiveDate   time.Time  `json:"effective_date"`
-	ExpirationDate  time.Time  `json:"expiration_date"`
-	DaysUntilExpiry int        `json:"days_until_expiry"`
-	CanRenew        bool       `json:"can_renew"`
-	CanCancel       bool       `json:"can_cancel"`
-	AutoRenew       bool       `json:"auto_renew"`
-	GracePeriodEnd  *time.Time `json:"grace_period_end,omitempty"`
+	PolicyID         uuid.UUID           `json:"policy_id"`
+	Status           models.PolicyStatus `json:"status"`
+	EffectiveDate    time.Time           `json:"effective_date"`
+	ExpirationDate   time.Time           `json:"expiration_date"`
+	DaysUntilExpiry  int                 `json:"days_until_expiry"`
+	CanRenew         bool                `json:"can_renew"`
+	CanCancel        bool                `json:"can_cancel"`
+	AutoRenew        bool                `json:"auto_renew"`
+	InGracePeriod    bool                `json:"in_grace_period"`
+	GracePeriodEnd   *time.Time          `json:"grace_period_end,omitempty"`
+	GracePeriodCause string              `json:"grace_period_cause,omitempty"`
 }
 
 // canRenew checks if a policy can be renewed.
-func (s *PolicyLifecycleService) canRenew(policy *models.Policy) bool {
-	return policy.Status == "active" &&
-		policy.ExpirationDate.After(time.Now()) &&
-		time.Until(policy.ExpirationDate).Hours()/24 <= 30
+func (s *PolicyLifecycleService) canRenew(ctx context.Context, policy *models.Policy) bool {
+	return s.validateRenewalEligibility(ctx, policy) == nil
+}
+
+// advanceRenewalDays returns how many days before expiration a policy may be renewed.
+func (s *PolicyLifecycleService) advanceRenewalDays() int {
+	return s.configManager.GetConfig().PolicyLifecycle.RenewalRules.AdvanceRenewalDays
 }
 
 // canCancel checks if a policy can be cancelled.
 func (s *PolicyLifecycleService) canCancel(policy *models.Policy) bool {
-	return policy.Status == "active"
+	return s.validateCancellationEligibility(policy) == nil
 }
 
-// ProcessAutoRenewals processes policies that are eligible for auto-renewal.
-func (s *PolicyLifecycleService) ProcessAutoRenewals(ctx context.Context) error {
-	s.logger.Info("Processing auto-renewals")
+// ProcessAutoRenewals renews active auto-renew policies expiring within the advance renewal
+// window. The context is checked before each policy; on cancellation the partial result is
+// returned with the context error.
+func (s *PolicyLifecycleService) ProcessAutoRenewals(ctx context.Context, options *LifecycleBatchOptions) (*LifecycleBatchResult, error) {
+	if options == nil {
+		options = &LifecycleBatchOptions{}
+	}
+	s.logger.Info("Processing auto-renewals", zap.Bool("dry_run", options.DryRun))
 
-	// Query for policies eligible for auto-renewal
-	// Note: GetPoliciesEligibleForAutoRenewal method needs to be implemented in PolicyStore
-	// For now, we'll simulate with an empty slice
-	autoRenewalPolicies := []*models.Policy{}
-	// autoRenewalPolicies, err := s.policyStore.GetPoliciesEligibleForAutoRenewal(ctx)
-	// if err != nil {
-	// 	return fmt.Errorf("failed to fetch policies eligible for auto-renewal: %w", err)
-	// }
+	result := &LifecycleBatchResult{DryRun: options.DryRun}
+	now := time.Now()
+	autoRenewalPolicies, err := s.policyStore.ListPoliciesExpiringWithin(ctx, now, now.AddDate(0, 0, s.advanceRenewalDays()), true)
+	if err != nil {
+		return result, fmt.Errorf("failed to fetch policies eligible for auto-renewal: %w", err)
+	}
+	result.Candidates = len(autoRenewalPolicies)
 
-	processedCount := 0
 	for _, policy := range autoRenewalPolicies {
+		if err := ctx.Err(); err != nil {
+			result.Interrupted = true
+			s.logger.Warn("Auto-renewal processing interrupted",
+				zap.Int("processed", result.Processed),
+				zap.Int("candidates", result.Candidates),
+				zap.Error(err))
+			return result, err
+		}
+
+		// A policy renewed by an earlier run, or by hand, is not renewed again
+		renewed, err := s.policyStore.HasRenewal(ctx, policy.ID)
+		if err != nil {
+			s.logger.Error("Failed to check existing renewal for auto-renewal",
+				zap.String("policy_id", policy.ID.String()),
+				zap.Error(err))
+			result.Failed++
+			continue
+		}
+		if renewed {
+			result.Skipped++
+			continue
+		}
+
+		if err := s.checkAntiSelection(ctx, policy); err != nil {
+			if !errors.Is(err, ErrRenewalRequiresReview) {
+				s.logger.Error("Failed to check open claims for auto-renewal",
+					zap.String("policy_id", policy.ID.String()),
+					zap.Error(err))
+				result.Failed++
+				continue
+			}
+			s.logger.Warn("Auto-renewal routed to manual review",
+				zap.String("policy_id", policy.ID.String()),
+				zap.Error(err))
+			result.Actions = append(result.Actions, LifecycleAction{
+				PolicyID:     policy.ID,
+				PolicyNumber: policy.PolicyNumber,
+				Action:       LifecycleActionManualReview,
+				Reason:       err.Error(),
+			})
+			result.ManualReview++
+			continue
+		}
+
 		// Attempt auto-renewal
 		renewalOptions := s.getDefaultRenewalOptions(policy)
 		renewalOptions.AutoRenew = true
 
-		// Get user's default payment method
-		// Note: GetUser method needs to be implemented in UserStore
-		// For now, we'll skip the payment method lookup
-		// user, err := s.userStore.GetUser(ctx, policy.UserID)
-		// if err != nil {
-		// 	s.logger.Error("Failed to fetch user for auto-renewal",
-		// 		zap.String("policy_id", policy.ID.String()),
-		// 		zap.String("user_id", policy.UserID.String()),
-		// 		zap.Error(err))
-		// 	continue
-		// }
-
-		// Set default payment method if available
-		// if user.DefaultPaymentMethod != "" {
-		// 	renewalOptions.PaymentMethod = user.DefaultPaymentMethod
-		// }
-
-		result, err := s.RenewPolicy(ctx, policy.ID, renewalOptions)
+		if options.DryRun {
+			action, err := s.planAutoRenewal(ctx, policy, renewalOptions)
+			if err != nil {
+				s.logger.Warn("Policy would not be auto-renewed",
+					zap.String("policy_id", policy.ID.String()),
+					zap.Error(err))
+				result.Failed++
+				continue
+			}
+			result.Actions = append(result.Actions, *action)
+			result.Processed++
+			continue
+		}
+
+		renewal, err := s.RenewPolicy(ctx, policy.ID, renewalOptions)
 		if err != nil {
 			s.logger.Error("Failed to auto-renew policy",
 				zap.String("policy_id", policy.ID.String()),
 				zap.Error(err))
+			result.Failed++
 			continue
 		}
 
-		if result.Success {
+		if renewal.Success {
 			s.logger.Info("Policy auto-renewed successfully",
 				zap.String("old_policy_id", policy.ID.String()),
-				zap.String("new_policy_id", result.NewPolicyID.String()))
+				zap.String("new_policy_id", renewal.NewPolicyID.String()))
 		} else {
 			s.logger.Warn("Policy auto-renewal failed",
 				zap.String("policy_id", policy.ID.String()),
-				zap.String("status", result.Status),
-				zap.String("message", result.Message))
+				zap.String("status", renewal.Status),
+				zap.String("message", renewal.Message))
+		}
+		if renewal.NewPolicyID == nil {
+			result.Failed++
+			continue
 		}
 
-		processedCount++
+		result.Actions = append(result.Actions, LifecycleAction{
+			PolicyID:      policy.ID,
+			PolicyNumber:  policy.PolicyNumber,
+			Action:        LifecycleActionRenew,
+			Premium:       renewal.Premium,
+			Notifications: s.lifecycleNotifications(events.EventTypePolicyCreated, events.EventTypePolicyRenewed),
+		})
+		result.Processed++
 	}
 
 	s.logger.Info("Processed auto-renewals",
-		zap.Int("count", processedCount))
+		zap.Bool("dry_run", options.DryRun),
+		zap.Int("count", result.Processed),
+		zap.Int("failed", result.Failed),
+		zap.Int("skipped", result.Skipped),
+		zap.Int("manual_review", result.ManualReview))
 
-	return nil
+	return result, nil
+}
+
+// planAutoRenewal computes the renewal RenewPolicy would create for a policy without writing it.
+func (s *PolicyLifecycleService) planAutoRenewal(ctx context.Context, policy *models.Policy, renewalOptions *RenewalOptions) (*LifecycleAction, error) {
+	if err := s.validateRenewalEligibility(ctx, policy); err != nil {
+		return nil, err
+	}
+
+	pricing, err := s.calculateRenewalPremium(ctx, policy, renewalOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate renewal premium: %w", err)
+	}
+
+	return &LifecycleAction{
+		PolicyID:      policy.ID,
+		PolicyNumber:  policy.PolicyNumber,
+		Action:        LifecycleActionRenew,
+		Premium:       pricing.Premium,
+		Notifications: s.lifecycleNotifications(events.EventTypePolicyCreated, events.EventTypePolicyRenewed),
+	}, nil
 }
 
-// GetUpcomingRenewals retrieves policies that are coming up for renewal.
+// GetUpcomingRenewals retrieves active policies expiring within the specified number of days.
 func (s *PolicyLifecycleService) GetUpcomingRenewals(ctx context.Context, daysAhead int) ([]*models.Policy, error) {
-	// Query for policies expiring within the specified number of days
-	// Note: GetPoliciesExpiringWithin method needs to be implemented in PolicyStore
-	// For now, we'll simulate with an empty slice
-	upcomingRenewals := []*models.Policy{}
-	// upcomingRenewals, err := s.policyStore.GetPoliciesExpiringWithin(ctx, daysAhead)
-	// if err != nil {
-	// 	return nil, fmt.Errorf("failed to fetch upcoming renewals: %w", err)
-	// }
+	now := time.Now()
+	upcomingRenewals, err := s.policyStore.ListPoliciesExpiringWithin(ctx, now, now.AddDate(0, 0, daysAhead), false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch upcoming renewals: %w", err)
+	}
 
 	return upcomingRenewals, nil
 }
@@ -764,6 +1310,17 @@ func (s *PolicyLifecycleService) SendRenewalReminders(ctx context.Context, daysA
 
 	sentCount := 0
 	for _, policy := range upcomingRenewals {
+		var offer *RenewalOffer
+		if s.quoteService != nil {
+			offer, err = s.currentRenewalOffer(ctx, policy)
+			if err != nil {
+				s.logger.Error("Failed to get renewal offer",
+					zap.String("policy_id", policy.ID.String()),
+					zap.Error(err))
+				offer = nil
+			}
+		}
+
 		// Publish renewal reminder event
 		if s.eventService != nil {
 			renewalReminderEvent := events.NewRenewalReminderEvent(
@@ -774,6 +1331,13 @@ func (s *PolicyLifecycleService) SendRenewalReminders(ctx context.Context, daysA
 				policy.ExpirationDate,
 				time.Now(),
 			)
+			if offer != nil {
+				renewalReminderEvent.OfferQuoteID = &offer.QuoteID
+				renewalReminderEvent.OfferPremium = offer.Premium
+				renewalReminderEvent.OfferCurrency = offer.Currency
+				renewalReminderEvent.OfferValidUntil = &offer.ValidUntil
+				renewalReminderEvent.OfferURL = offer.AcceptURL
+			}
 			if err := s.eventService.PublishEvent(ctx, renewalReminderEvent); err != nil {
 				s.logger.Error("Failed to publish renewal reminder event",
 					zap.String("policy_id", policy.ID.String()),
@@ -782,6 +1346,14 @@ func (s *PolicyLifecycleService) SendRenewalReminders(ctx context.Context, daysA
 			}
 		}
 
+		data := map[string]interface{}{"DaysAhead": daysAhead}
+		if offer != nil {
+			data["OfferPremium"] = fmt.Sprintf("%.2f", offer.Premium)
+			data["OfferCurrency"] = offer.Currency
+			data["OfferURL"] = offer.AcceptURL
+		}
+		s.notifyPolicyholder(ctx, policy, NotificationTypeRenewalReminder, data)
+
 		sentCount++
 	}
 
@@ -0,0 +1,808 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/events"
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/edsonmichaque/bazaruto/pkg/event"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPolicyLifecycleService(configManager *config.Manager, policyStore *fakePolicyStore) *PolicyLifecycleService {
+	return NewPolicyLifecycleService(logger.NewLogger("error", "json"), configManager, policyStore, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+}
+
+func TestGetPolicyStatusReportsGracePeriodOnlyWhenInGrace(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	graceEnd := now.AddDate(0, 0, 10)
+	lapsedGraceEnd := now.AddDate(0, 0, -1)
+
+	inGrace := &models.Policy{
+		UserID:         uuid.New(),
+		Status:         models.PolicyStatusSuspended,
+		EffectiveDate:  now.AddDate(-1, 0, 0),
+		ExpirationDate: now.AddDate(0, 1, 0),
+		GracePeriodEnd: &graceEnd,
+	}
+	notInGrace := &models.Policy{
+		UserID:         uuid.New(),
+		Status:         models.PolicyStatusActive,
+		EffectiveDate:  now.AddDate(0, -1, 0),
+		ExpirationDate: now.AddDate(0, 11, 0),
+	}
+	graceLapsed := &models.Policy{
+		UserID:         uuid.New(),
+		Status:         models.PolicyStatusSuspended,
+		EffectiveDate:  now.AddDate(-1, 0, 0),
+		ExpirationDate: now.AddDate(0, 1, 0),
+		GracePeriodEnd: &lapsedGraceEnd,
+	}
+	svc := newTestPolicyLifecycleService(config.NewManager(logger.NewLogger("error", "json"), ""), newFakePolicyStore(inGrace, notInGrace, graceLapsed))
+
+	status, err := svc.GetPolicyStatus(ctx, inGrace.ID)
+	require.NoError(t, err)
+	assert.True(t, status.InGracePeriod)
+	require.NotNil(t, status.GracePeriodEnd)
+	assert.True(t, status.GracePeriodEnd.Equal(graceEnd))
+
+	status, err = svc.GetPolicyStatus(ctx, notInGrace.ID)
+	require.NoError(t, err)
+	assert.False(t, status.InGracePeriod)
+	assert.Nil(t, status.GracePeriodEnd)
+
+	status, err = svc.GetPolicyStatus(ctx, graceLapsed.ID)
+	require.NoError(t, err)
+	assert.False(t, status.InGracePeriod)
+	assert.Nil(t, status.GracePeriodEnd)
+}
+
+func TestRenewalWithoutPaymentRecordsGracePeriodOnPolicy(t *testing.T) {
+	ctx := context.Background()
+	configManager := config.NewManager(logger.NewLogger("error", "json"), "")
+	policy := &models.Policy{
+		ProductID:        uuid.New(),
+		UserID:           uuid.New(),
+		Premium:          1000,
+		Currency:         "USD",
+		CoverageAmount:   50000,
+		Status:           models.PolicyStatusActive,
+		EffectiveDate:    time.Now().AddDate(-1, 0, 10),
+		ExpirationDate:   time.Now().AddDate(0, 0, 10),
+		PaymentFrequency: "annually",
+	}
+	policyStore := newFakePolicyStore(policy)
+	svc := newTestPolicyLifecycleService(configManager, policyStore)
+
+	result, err := svc.RenewPolicy(ctx, policy.ID, nil)
+	require.NoError(t, err)
+	require.NotNil(t, result.GracePeriodEnd)
+
+	renewal, err := policyStore.GetPolicy(ctx, *result.NewPolicyID)
+	require.NoError(t, err)
+	require.NotNil(t, renewal.PreviousPolicyID)
+	assert.Equal(t, policy.ID, *renewal.PreviousPolicyID)
+
+	status, err := svc.GetPolicyStatus(ctx, *result.NewPolicyID)
+	require.NoError(t, err)
+	assert.True(t, status.InGracePeriod)
+	require.NotNil(t, status.GracePeriodEnd)
+
+	renewalDays := configManager.GetConfig().PolicyLifecycle.GracePeriodRules.RenewalDays
+	assert.WithinDuration(t, time.Now().AddDate(0, 0, renewalDays), *status.GracePeriodEnd, time.Minute)
+}
+
+func TestRenewalPoliciesAreNumbered(t *testing.T) {
+	ctx := context.Background()
+	first := &models.Policy{
+		ProductID:      uuid.New(),
+		UserID:         uuid.New(),
+		Premium:        1000,
+		Currency:       "USD",
+		Status:         models.PolicyStatusActive,
+		EffectiveDate:  time.Now().AddDate(-1, 0, 10),
+		ExpirationDate: time.Now().AddDate(0, 0, 10),
+	}
+	second := *first
+	second.ID = uuid.New()
+	policyStore := newFakePolicyStore(first, &second)
+	svc := newTestPolicyLifecycleService(config.NewManager(logger.NewLogger("error", "json"), ""), policyStore)
+	svc.SetNumberGenerator(newTestNumberGenerator(t))
+
+	numbers := make(map[string]bool)
+	for _, policy := range []*models.Policy{first, &second} {
+		result, err := svc.RenewPolicy(ctx, policy.ID, nil)
+		require.NoError(t, err)
+		renewal, err := policyStore.GetPolicy(ctx, *result.NewPolicyID)
+		require.NoError(t, err)
+		assert.Regexp(t, `^POL-\d{4}-\d{6}$`, renewal.PolicyNumber)
+		numbers[renewal.PolicyNumber] = true
+	}
+	assert.Len(t, numbers, 2)
+}
+
+func TestRenewalWindowFollowsConfiguredAdvanceDays(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	newPolicy := func() *models.Policy {
+		return &models.Policy{
+			ProductID:        uuid.New(),
+			UserID:           uuid.New(),
+			Premium:          1000,
+			Currency:         "USD",
+			CoverageAmount:   50000,
+			Status:           models.PolicyStatusActive,
+			EffectiveDate:    time.Now().AddDate(0, -10, -15),
+			ExpirationDate:   time.Now().AddDate(0, 0, 45),
+			PaymentFrequency: "annually",
+		}
+	}
+
+	// The default 30-day window rejects a renewal 45 days out.
+	defaultPolicy := newPolicy()
+	defaultSvc := newTestPolicyLifecycleService(config.NewManager(log, ""), newFakePolicyStore(defaultPolicy))
+	assert.False(t, defaultSvc.canRenew(ctx, defaultPolicy))
+	result, err := defaultSvc.RenewPolicy(ctx, defaultPolicy.ID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "failed", result.Status)
+
+	configManager := config.NewManager(log, filepath.Join(t.TempDir(), "business_rules.json"))
+	cfg := configManager.GetConfig()
+	cfg.PolicyLifecycle.RenewalRules.AdvanceRenewalDays = 60
+	require.NoError(t, configManager.UpdateConfig(ctx, cfg))
+
+	policy := newPolicy()
+	policy.Jurisdiction = "MZ"
+	policies := newFakePolicyStore(policy)
+	svc := newTestPolicyLifecycleService(configManager, policies)
+	assert.True(t, svc.canRenew(ctx, policy))
+	result, err = svc.RenewPolicy(ctx, policy.ID, nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, "failed", result.Status)
+	require.NotNil(t, result.NewPolicyID)
+
+	renewal, err := policies.GetPolicy(ctx, *result.NewPolicyID)
+	require.NoError(t, err)
+	assert.Equal(t, "MZ", renewal.Jurisdiction, "the renewal is taxed where the renewed policy was")
+}
+
+func TestRenewalPremiumAppliesConfiguredIncreaseToPricingEngineOutput(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	configManager := config.NewManager(log, filepath.Join(t.TempDir(), "business_rules.json"))
+	cfg := configManager.GetConfig()
+	cfg.PolicyLifecycle.RenewalRules.RateIncreaseRate = 0.05
+	cfg.PolicyLifecycle.RenewalRules.FrequencyDiscounts = map[string]float64{"annually": 0.02}
+	require.NoError(t, configManager.UpdateConfig(ctx, cfg))
+
+	product := &models.Product{Base: models.Base{ID: uuid.New()}, Category: "home"}
+	user := &models.User{Base: models.Base{ID: uuid.New(), CreatedAt: time.Now().AddDate(-3, 0, 0)}}
+	policy := &models.Policy{
+		ProductID:        product.ID,
+		UserID:           user.ID,
+		Premium:          1000,
+		Currency:         "USD",
+		CoverageAmount:   100000,
+		Status:           models.PolicyStatusActive,
+		EffectiveDate:    time.Now().AddDate(-1, 0, 10),
+		ExpirationDate:   time.Now().AddDate(0, 0, 10),
+		PaymentFrequency: "annually",
+	}
+	// The engine gets its own policy store so the multi-policy discount does not apply.
+	pricing := NewPricingEngineService(log, configManager, newFakeProductStore(product), newFakePolicyStore(), newFakeClaimStore(), newFakeUserStore(user))
+	svc := NewPolicyLifecycleService(log, configManager, newFakePolicyStore(policy), nil, nil, nil, nil, pricing, nil, nil, nil, nil, nil)
+
+	options := svc.getDefaultRenewalOptions(policy)
+	engine, err := pricing.CalculatePremium(ctx, &PricingRequest{
+		ProductID:        policy.ProductID,
+		UserID:           policy.UserID,
+		CoverageAmount:   options.CoverageAmount,
+		Currency:         policy.Currency,
+		PaymentFrequency: options.PaymentFrequency,
+		EffectiveDate:    options.EffectiveDate,
+		ExpirationDate:   options.ExpirationDate,
+	})
+	require.NoError(t, err)
+	require.Greater(t, engine.FinalPremium, 0.0)
+
+	renewal, err := svc.calculateRenewalPremium(ctx, policy, options)
+	require.NoError(t, err)
+	assert.InDelta(t, engine.FinalPremium*1.05, renewal.Premium, 0.01, "the engine already adjusted for the payment frequency")
+	require.NotNil(t, renewal.Breakdown)
+	assert.InDelta(t, renewal.Premium, renewal.Breakdown.Total(), 0.001, "the breakdown accounts for the increased premium")
+	assert.InDelta(t, engine.Breakdown.TaxAdjustment*1.05, renewal.Breakdown.TaxAdjustment, 0.01)
+
+	result, err := svc.RenewPolicy(ctx, policy.ID, nil)
+	require.NoError(t, err)
+	assert.InDelta(t, renewal.Premium, result.Premium, 0.01)
+
+	// Without a pricing engine the configured frequency discount is applied once.
+	unpriced := NewPolicyLifecycleService(log, configManager, newFakePolicyStore(), nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	renewal, err = unpriced.calculateRenewalPremium(ctx, policy, options)
+	require.NoError(t, err)
+	assert.InDelta(t, 1000*1.05*0.98, renewal.Premium, 0.01)
+	assert.Nil(t, renewal.Breakdown)
+}
+
+func TestRenewingOnlyPolicyEarnsNoMultiPolicyDiscount(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	configManager := config.NewManager(log, "")
+
+	product := &models.Product{Base: models.Base{ID: uuid.New()}, Category: "home"}
+	user := &models.User{Base: models.Base{ID: uuid.New(), CreatedAt: time.Now().AddDate(-3, 0, 0)}}
+	policy := &models.Policy{
+		ProductID:        product.ID,
+		UserID:           user.ID,
+		Premium:          1000,
+		Currency:         "USD",
+		CoverageAmount:   100000,
+		Status:           models.PolicyStatusActive,
+		EffectiveDate:    time.Now().AddDate(-1, 0, 10),
+		ExpirationDate:   time.Now().AddDate(0, 0, 10),
+		PaymentFrequency: "annually",
+	}
+	newLifecycle := func(enginePolicies *fakePolicyStore) *PolicyLifecycleService {
+		pricing := NewPricingEngineService(log, configManager, newFakeProductStore(product), enginePolicies, newFakeClaimStore(), newFakeUserStore(user))
+		return NewPolicyLifecycleService(log, configManager, enginePolicies, nil, nil, nil, nil, pricing, nil, nil, nil, nil, nil)
+	}
+
+	// The engine sees the customer's only policy, which is the one being renewed
+	withOnlyPolicy := newLifecycle(newFakePolicyStore(policy))
+	options := withOnlyPolicy.getDefaultRenewalOptions(policy)
+	renewal, err := withOnlyPolicy.calculateRenewalPremium(ctx, policy, options)
+	require.NoError(t, err)
+
+	withoutPolicies := newLifecycle(newFakePolicyStore())
+	expected, err := withoutPolicies.calculateRenewalPremium(ctx, policy, options)
+	require.NoError(t, err)
+	assert.InDelta(t, expected.Premium, renewal.Premium, 0.001)
+
+	// A second active policy earns the bundle discount on renewal
+	other := &models.Policy{UserID: user.ID, Status: models.PolicyStatusActive}
+	withTwoPolicies := newLifecycle(newFakePolicyStore(policy, other))
+	bundled, err := withTwoPolicies.calculateRenewalPremium(ctx, policy, options)
+	require.NoError(t, err)
+	assert.Less(t, bundled.Premium, renewal.Premium)
+}
+
+func TestLifecycleOperationsSkipMissingOptionalDependencies(t *testing.T) {
+	ctx := context.Background()
+	newPolicy := func() *models.Policy {
+		return &models.Policy{
+			ProductID:        uuid.New(),
+			UserID:           uuid.New(),
+			Premium:          1200,
+			Currency:         "USD",
+			CoverageAmount:   50000,
+			Status:           models.PolicyStatusActive,
+			EffectiveDate:    time.Now().AddDate(0, -11, -10),
+			ExpirationDate:   time.Now().AddDate(0, 0, 20),
+			PaymentFrequency: "annually",
+		}
+	}
+	renewing := newPolicy()
+	cancelling := newPolicy()
+	// Only the required dependencies are provided.
+	svc := newTestPolicyLifecycleService(config.NewManager(logger.NewLogger("error", "json"), ""), newFakePolicyStore(renewing, cancelling))
+
+	renewal, err := svc.RenewPolicy(ctx, renewing.ID, &RenewalOptions{
+		CoverageAmount:   renewing.CoverageAmount,
+		EffectiveDate:    renewing.ExpirationDate,
+		ExpirationDate:   renewing.ExpirationDate.AddDate(1, 0, 0),
+		PaymentFrequency: renewing.PaymentFrequency,
+		PaymentMethod:    "card",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "pending_payment", renewal.Status)
+	assert.NotNil(t, renewal.NewPolicyID)
+	assert.Equal(t, errPaymentsNotConfigured.Error(), renewal.Metadata["payment_error"])
+
+	cancellation, err := svc.CancelPolicy(ctx, cancelling.ID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "pending_refund", cancellation.Status)
+	assert.Greater(t, cancellation.RefundAmount, 0.0)
+	assert.Equal(t, models.PolicyStatusCancelled, cancelling.Status)
+}
+
+// failingUpdatePolicyStore creates policies but fails every policy update.
+type failingUpdatePolicyStore struct {
+	*fakePolicyStore
+}
+
+func (s *failingUpdatePolicyStore) UpdatePolicy(ctx context.Context, policy *models.Policy) error {
+	return errors.New("database unavailable")
+}
+
+func TestPaidRenewalThatCannotBeActivatedIsReportedPendingActivation(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	policy := &models.Policy{
+		ProductID:        uuid.New(),
+		UserID:           uuid.New(),
+		Premium:          1000,
+		Currency:         "USD",
+		CoverageAmount:   50000,
+		Status:           models.PolicyStatusActive,
+		EffectiveDate:    time.Now().AddDate(-1, 0, 10),
+		ExpirationDate:   time.Now().AddDate(0, 0, 10),
+		PaymentFrequency: "annually",
+	}
+	policyStore := &failingUpdatePolicyStore{fakePolicyStore: newFakePolicyStore(policy)}
+	svc := NewPolicyLifecycleService(log, config.NewManager(log, ""), policyStore, newFakePaymentStore(), nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	options := svc.getDefaultRenewalOptions(policy)
+	options.PaymentMethod = "card"
+	result, err := svc.RenewPolicy(ctx, policy.ID, options)
+	require.NoError(t, err)
+
+	assert.False(t, result.Success)
+	assert.Equal(t, "pending_activation", result.Status)
+	assert.Contains(t, result.Metadata, "activation_error")
+}
+
+// countingPolicyStore counts policy writes and optionally runs a hook after each one.
+type countingPolicyStore struct {
+	*fakePolicyStore
+	writes     int
+	afterWrite func(writes int)
+}
+
+func (s *countingPolicyStore) recordWrite() {
+	s.writes++
+	if s.afterWrite != nil {
+		s.afterWrite(s.writes)
+	}
+}
+
+func (s *countingPolicyStore) CreatePolicy(ctx context.Context, policy *models.Policy) error {
+	defer s.recordWrite()
+	return s.fakePolicyStore.CreatePolicy(ctx, policy)
+}
+
+func (s *countingPolicyStore) UpdatePolicy(ctx context.Context, policy *models.Policy) error {
+	defer s.recordWrite()
+	return s.fakePolicyStore.UpdatePolicy(ctx, policy)
+}
+
+// cancelAfterWrites returns a countingPolicyStore hook that cancels once n writes have happened.
+func cancelAfterWrites(cancel context.CancelFunc, n int) func(int) {
+	return func(writes int) {
+		if writes == n {
+			cancel()
+		}
+	}
+}
+
+func TestProcessExpiredPoliciesStopsWhenContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var policies []*models.Policy
+	for i := 0; i < 3; i++ {
+		policies = append(policies, &models.Policy{
+			UserID:         uuid.New(),
+			Status:         models.PolicyStatusActive,
+			EffectiveDate:  time.Now().AddDate(-1, 0, -i-1),
+			ExpirationDate: time.Now().AddDate(0, 0, -i-1),
+		})
+	}
+	policyStore := &countingPolicyStore{fakePolicyStore: newFakePolicyStore(policies...), afterWrite: cancelAfterWrites(cancel, 1)}
+	svc := NewPolicyLifecycleService(logger.NewLogger("error", "json"), config.NewManager(logger.NewLogger("error", "json"), ""), policyStore, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	result, err := svc.ProcessExpiredPolicies(ctx, nil)
+	assert.ErrorIs(t, err, context.Canceled)
+	require.NotNil(t, result)
+	assert.Equal(t, 3, result.Candidates)
+	assert.Equal(t, 1, result.Processed)
+	assert.True(t, result.Interrupted)
+	assert.Equal(t, 1, policyStore.writes)
+}
+
+func TestProcessExpiredPoliciesPublishesEventPerPolicy(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+
+	var policies []*models.Policy
+	for i := 0; i < 3; i++ {
+		policies = append(policies, &models.Policy{
+			UserID:         uuid.New(),
+			Status:         models.PolicyStatusActive,
+			EffectiveDate:  time.Now().AddDate(-1, 0, -i-1),
+			ExpirationDate: time.Now().AddDate(0, 0, -i-1),
+		})
+	}
+	policyStore := newFakePolicyStore(policies...)
+
+	bus := event.NewBus()
+	handler := &recordingHandler{}
+	require.NoError(t, bus.Subscribe(handler, events.EventTypePolicyExpired))
+	eventService := NewEventService(bus, log, nil)
+	svc := NewPolicyLifecycleService(log, config.NewManager(log, ""), policyStore, nil, nil, nil, nil, nil, nil, eventService, nil, nil, nil)
+
+	result, err := svc.ProcessExpiredPolicies(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Processed)
+
+	require.Eventually(t, func() bool {
+		handler.mu.Lock()
+		defer handler.mu.Unlock()
+		return len(handler.events) == len(policies)
+	}, time.Second, 10*time.Millisecond)
+
+	handler.mu.Lock()
+	defer handler.mu.Unlock()
+	expired := make(map[uuid.UUID]bool)
+	for _, e := range handler.events {
+		expiredEvent, ok := e.(*events.PolicyExpiredEvent)
+		require.True(t, ok)
+		expired[expiredEvent.PolicyID] = true
+	}
+	for _, policy := range policies {
+		assert.True(t, expired[policy.ID], "missing expired event for policy %s", policy.ID)
+	}
+}
+
+func TestRenewalReminderCarriesStoredOffer(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+
+	policy := &models.Policy{
+		ProductID:        uuid.New(),
+		UserID:           uuid.New(),
+		Premium:          1000,
+		Currency:         "USD",
+		CoverageAmount:   50000,
+		Status:           models.PolicyStatusActive,
+		PaymentFrequency: "annually",
+		EffectiveDate:    time.Now().AddDate(-1, 0, 10),
+		ExpirationDate:   time.Now().AddDate(0, 0, 10),
+	}
+	bus := event.NewBus()
+	handler := &recordingHandler{}
+	require.NoError(t, bus.Subscribe(handler, events.EventTypePolicyRenewalReminder))
+	quoteService := NewQuoteService(newFakeQuoteStore())
+	svc := NewPolicyLifecycleService(log, config.NewManager(log, ""), newFakePolicyStore(policy), nil, nil, nil, nil, nil, nil, NewEventService(bus, log, nil), nil, quoteService, nil)
+
+	require.NoError(t, svc.SendRenewalReminders(ctx, 30))
+	require.Eventually(t, func() bool {
+		handler.mu.Lock()
+		defer handler.mu.Unlock()
+		return len(handler.events) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	handler.mu.Lock()
+	reminder, ok := handler.events[0].(*events.RenewalReminderEvent)
+	handler.mu.Unlock()
+	require.True(t, ok)
+	require.NotNil(t, reminder.OfferQuoteID)
+
+	// Default rules: 3% renewal increase and 5% annual payment discount.
+	expectedPremium := roundCurrency(1000 * 1.03 * 0.95)
+	assert.Equal(t, expectedPremium, reminder.OfferPremium)
+	assert.Equal(t, "/v1/quotes/"+reminder.OfferQuoteID.String()+"/accept", reminder.OfferURL)
+
+	quote, err := quoteService.GetQuote(ctx, *reminder.OfferQuoteID)
+	require.NoError(t, err)
+	assert.Equal(t, expectedPremium, quote.FinalPrice)
+	assert.Equal(t, models.QuoteStatusActive, quote.Status)
+	assert.Equal(t, policy.ID, *quote.RenewalOfPolicyID)
+	assert.Equal(t, policy.CoverageAmount, quote.CoverageAmount)
+	assert.False(t, quote.ValidUntil.After(policy.ExpirationDate))
+	assert.True(t, quote.ValidUntil.After(time.Now()))
+
+	// A later reminder carries the same offer instead of quoting the renewal again
+	require.NoError(t, svc.SendRenewalReminders(ctx, 30))
+	require.Eventually(t, func() bool {
+		handler.mu.Lock()
+		defer handler.mu.Unlock()
+		return len(handler.events) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	handler.mu.Lock()
+	again := handler.events[1].(*events.RenewalReminderEvent)
+	handler.mu.Unlock()
+	assert.Equal(t, *reminder.OfferQuoteID, *again.OfferQuoteID)
+	assert.Equal(t, reminder.OfferPremium, again.OfferPremium)
+}
+
+func TestProcessAutoRenewalsStopsWhenContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var policies []*models.Policy
+	for i := 0; i < 3; i++ {
+		policies = append(policies, &models.Policy{
+			ProductID:        uuid.New(),
+			UserID:           uuid.New(),
+			Premium:          1000,
+			Currency:         "USD",
+			CoverageAmount:   50000,
+			Status:           models.PolicyStatusActive,
+			EffectiveDate:    time.Now().AddDate(-1, 0, 10+i),
+			ExpirationDate:   time.Now().AddDate(0, 0, 10+i),
+			PaymentFrequency: "annually",
+			AutoRenew:        true,
+		})
+	}
+	// Renewal creates the new policy and records its grace period, so cancel after the first renewal's writes.
+	policyStore := &countingPolicyStore{fakePolicyStore: newFakePolicyStore(policies...), afterWrite: cancelAfterWrites(cancel, 2)}
+	svc := NewPolicyLifecycleService(logger.NewLogger("error", "json"), config.NewManager(logger.NewLogger("error", "json"), ""), policyStore, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	result, err := svc.ProcessAutoRenewals(ctx, nil)
+	assert.ErrorIs(t, err, context.Canceled)
+	require.NotNil(t, result)
+	assert.Equal(t, 3, result.Candidates)
+	assert.Equal(t, 1, result.Processed)
+	assert.True(t, result.Interrupted)
+}
+
+func TestProcessAutoRenewalsSkipsPoliciesAlreadyRenewed(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	policy := &models.Policy{
+		ProductID:        uuid.New(),
+		UserID:           uuid.New(),
+		Premium:          1000,
+		Currency:         "USD",
+		CoverageAmount:   50000,
+		Status:           models.PolicyStatusActive,
+		EffectiveDate:    time.Now().AddDate(-1, 0, 10),
+		ExpirationDate:   time.Now().AddDate(0, 0, 10),
+		PaymentFrequency: "annually",
+		AutoRenew:        true,
+	}
+	policyStore := newFakePolicyStore(policy)
+	svc := NewPolicyLifecycleService(log, config.NewManager(log, ""), policyStore, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	first, err := svc.ProcessAutoRenewals(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, first.Processed)
+
+	second, err := svc.ProcessAutoRenewals(ctx, nil)
+	require.NoError(t, err)
+	assert.Zero(t, second.Processed)
+	assert.Equal(t, 1, second.Skipped)
+	assert.Len(t, policyStore.policies, 2, "the policy should be renewed once")
+
+	result, err := svc.RenewPolicy(ctx, policy.ID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "failed", result.Status)
+	assert.Contains(t, result.Message, ErrPolicyAlreadyRenewed.Error())
+}
+
+func TestAutoRenewalRoutesOpenHighValueClaimsToManualReview(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+
+	tests := []struct {
+		name        string
+		claimStatus string
+		wantAction  string
+	}{
+		{"open claim", models.ClaimStatusUnderReview, LifecycleActionManualReview},
+		{"closed claim", models.ClaimStatusPaid, LifecycleActionRenew},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &models.Policy{
+				ProductID:        uuid.New(),
+				UserID:           uuid.New(),
+				Premium:          1000,
+				Currency:         "USD",
+				CoverageAmount:   500000,
+				Status:           models.PolicyStatusActive,
+				PaymentFrequency: "annually",
+				AutoRenew:        true,
+				EffectiveDate:    time.Now().AddDate(-1, 0, 10),
+				ExpirationDate:   time.Now().AddDate(0, 0, 10),
+			}
+			policy.ID = uuid.New()
+			claims := newFakeClaimStore(&models.Claim{
+				ClaimNumber: "CLM-1",
+				PolicyID:    policy.ID,
+				UserID:      policy.UserID,
+				ClaimAmount: 100000,
+				Currency:    "USD",
+				Status:      tt.claimStatus,
+			})
+			policyStore := newFakePolicyStore(policy)
+			svc := NewPolicyLifecycleService(log, config.NewManager(log, ""), policyStore, nil, nil, nil, nil, nil, nil, nil, nil, nil, claims)
+
+			result, err := svc.ProcessAutoRenewals(ctx, nil)
+			require.NoError(t, err)
+			require.Len(t, result.Actions, 1)
+			assert.Equal(t, tt.wantAction, result.Actions[0].Action)
+
+			if tt.wantAction == LifecycleActionManualReview {
+				assert.Equal(t, 1, result.ManualReview)
+				assert.Zero(t, result.Processed)
+				assert.Len(t, policyStore.policies, 1, "no renewal policy should be created")
+			} else {
+				assert.Zero(t, result.ManualReview)
+				assert.Equal(t, 1, result.Processed)
+			}
+		})
+	}
+}
+
+func TestLifecycleDryRunReportsSameCandidatesWithoutWrites(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	newPolicies := func() []*models.Policy {
+		now := time.Now()
+		return []*models.Policy{
+			{PolicyNumber: "POL-EXPIRED", UserID: uuid.New(), Status: models.PolicyStatusActive,
+				EffectiveDate: now.AddDate(-1, 0, -3), ExpirationDate: now.AddDate(0, 0, -3)},
+			{PolicyNumber: "POL-AUTO", ProductID: uuid.New(), UserID: uuid.New(), Premium: 1000, Currency: "USD",
+				CoverageAmount: 50000, Status: models.PolicyStatusActive, PaymentFrequency: "annually", AutoRenew: true,
+				EffectiveDate: now.AddDate(-1, 0, 10), ExpirationDate: now.AddDate(0, 0, 10)},
+			{PolicyNumber: "POL-MANUAL", ProductID: uuid.New(), UserID: uuid.New(), Premium: 1000, Currency: "USD",
+				CoverageAmount: 50000, Status: models.PolicyStatusActive, PaymentFrequency: "annually",
+				EffectiveDate: now.AddDate(-1, 0, 10), ExpirationDate: now.AddDate(0, 0, 10)},
+		}
+	}
+	run := func(dryRun bool) (*LifecycleBatchResult, *LifecycleBatchResult, int) {
+		policyStore := &countingPolicyStore{fakePolicyStore: newFakePolicyStore(newPolicies()...)}
+		svc := NewPolicyLifecycleService(log, config.NewManager(log, ""), policyStore, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+		options := &LifecycleBatchOptions{DryRun: dryRun}
+
+		expired, err := svc.ProcessExpiredPolicies(ctx, options)
+		require.NoError(t, err)
+		renewed, err := svc.ProcessAutoRenewals(ctx, options)
+		require.NoError(t, err)
+		return expired, renewed, policyStore.writes
+	}
+	actionSummary := func(result *LifecycleBatchResult) []string {
+		var summary []string
+		for _, action := range result.Actions {
+			summary = append(summary, action.Action+":"+action.PolicyNumber)
+		}
+		return summary
+	}
+
+	dryExpired, dryRenewed, dryWrites := run(true)
+	expired, renewed, writes := run(false)
+
+	assert.Zero(t, dryWrites)
+	assert.Positive(t, writes)
+	assert.True(t, dryExpired.DryRun)
+	assert.Equal(t, []string{"expire:POL-EXPIRED"}, actionSummary(dryExpired))
+	assert.Equal(t, []string{"renew:POL-AUTO"}, actionSummary(dryRenewed))
+	assert.Equal(t, actionSummary(expired), actionSummary(dryExpired))
+	assert.Equal(t, actionSummary(renewed), actionSummary(dryRenewed))
+	assert.InDelta(t, renewed.Actions[0].Premium, dryRenewed.Actions[0].Premium, 0.01)
+}
+
+func TestCancelPolicyRefundsAmountPaidWithinFreeLookPeriod(t *testing.T) {
+	tests := []struct {
+		name         string
+		jurisdiction string
+		daysActive   int
+		paid         float64
+		initiatedBy  string
+		renewal      bool
+		wantFreeLook bool
+	}{
+		{name: "within default window", daysActive: 5, paid: 1200, wantFreeLook: true},
+		{name: "outside default window", daysActive: 60, paid: 1200},
+		{name: "within jurisdiction window", jurisdiction: "za", daysActive: 25, paid: 1200, wantFreeLook: true},
+		{name: "partly paid", daysActive: 5, paid: 100, wantFreeLook: true},
+		{name: "cancelled by the insurer", daysActive: 5, paid: 1200, initiatedBy: CancellationInitiatorInsurer},
+		{name: "renewal", daysActive: 5, paid: 1200, renewal: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			log := logger.NewLogger("error", "json")
+			configManager := config.NewManager(log, filepath.Join(t.TempDir(), "business_rules.json"))
+			cfg := configManager.GetConfig()
+			cfg.PolicyLifecycle.CancellationRules.JurisdictionFreeLookDays = map[string]int{"ZA": 30}
+			require.NoError(t, configManager.UpdateConfig(ctx, cfg))
+
+			now := time.Now()
+			policy := &models.Policy{
+				Base:           models.Base{ID: uuid.New(), CreatedAt: now.AddDate(0, 0, -tt.daysActive)},
+				UserID:         uuid.New(),
+				Status:         models.PolicyStatusActive,
+				Premium:        1200,
+				Currency:       "USD",
+				Jurisdiction:   tt.jurisdiction,
+				EffectiveDate:  now.AddDate(0, 0, -tt.daysActive),
+				ExpirationDate: now.AddDate(0, 0, 365-tt.daysActive),
+			}
+			if tt.renewal {
+				previous := uuid.New()
+				policy.PreviousPolicyID = &previous
+			}
+			payments := newFakePaymentStore(&models.Payment{PolicyID: &policy.ID, Amount: tt.paid, Status: models.PaymentStatusCompleted})
+			svc := NewPolicyLifecycleService(log, configManager, newFakePolicyStore(policy), payments, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+			options := svc.getDefaultCancellationOptions(policy)
+			if tt.initiatedBy != "" {
+				options.InitiatedBy = tt.initiatedBy
+			}
+			result, err := svc.CancelPolicy(ctx, policy.ID, options)
+			require.NoError(t, err)
+			require.True(t, result.Success)
+
+			if tt.wantFreeLook {
+				assert.InDelta(t, tt.paid, result.RefundAmount, 0.001)
+				assert.Equal(t, true, result.Metadata["free_look"])
+				return
+			}
+			unused := float64(365-tt.daysActive) / 365
+			assert.InDelta(t, 1200*unused*0.9, result.RefundAmount, 0.5)
+			assert.NotContains(t, result.Metadata, "free_look")
+		})
+	}
+}
+
+// failingPaymentStore rejects every payment.
+type failingPaymentStore struct {
+	*fakePaymentStore
+}
+
+func (s *failingPaymentStore) CreatePayment(ctx context.Context, payment *models.Payment) error {
+	return errors.New("card declined")
+}
+
+func TestRenewalGracePeriodDependsOnCause(t *testing.T) {
+	tests := []struct {
+		name          string
+		paymentMethod string
+		wantCause     string
+		wantDays      int
+	}{
+		{name: "awaiting renewal payment", wantCause: models.GracePeriodCauseRenewal, wantDays: 10},
+		{name: "payment failure", paymentMethod: "card", wantCause: models.GracePeriodCausePaymentFailure, wantDays: 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			log := logger.NewLogger("error", "json")
+			configManager := config.NewManager(log, filepath.Join(t.TempDir(), "business_rules.json"))
+			cfg := configManager.GetConfig()
+			cfg.PolicyLifecycle.GracePeriodRules = config.GracePeriodRules{DefaultDays: 15, PaymentFailureDays: 30, RenewalDays: 10}
+			require.NoError(t, configManager.UpdateConfig(ctx, cfg))
+
+			policy := &models.Policy{
+				ProductID:        uuid.New(),
+				UserID:           uuid.New(),
+				Premium:          1000,
+				Currency:         "USD",
+				CoverageAmount:   50000,
+				Status:           models.PolicyStatusActive,
+				EffectiveDate:    time.Now().AddDate(-1, 0, 10),
+				ExpirationDate:   time.Now().AddDate(0, 0, 10),
+				PaymentFrequency: "annually",
+			}
+			policyStore := newFakePolicyStore(policy)
+			payments := &failingPaymentStore{fakePaymentStore: newFakePaymentStore()}
+			svc := NewPolicyLifecycleService(log, configManager, policyStore, payments, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+			options := svc.getDefaultRenewalOptions(policy)
+			options.PaymentMethod = tt.paymentMethod
+			result, err := svc.RenewPolicy(ctx, policy.ID, options)
+			require.NoError(t, err)
+			require.NotNil(t, result.GracePeriodEnd)
+
+			renewal, err := policyStore.GetPolicy(ctx, *result.NewPolicyID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCause, renewal.GracePeriodCause)
+			require.NotNil(t, renewal.GracePeriodEnd)
+			assert.WithinDuration(t, time.Now().AddDate(0, 0, tt.wantDays), *renewal.GracePeriodEnd, time.Minute)
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/edsonmichaque/bazaruto/internal/store"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// PremiumTaxReportService reports the premium tax collected per jurisdiction.
+type PremiumTaxReportService struct {
+	invoiceStore store.InvoiceStore
+	policyStore  store.PolicyStore
+	reportStore  store.PremiumTaxReportStore
+	logger       *logger.Logger
+}
+
+// NewPremiumTaxReportService creates a new PremiumTaxReportService instance.
+func NewPremiumTaxReportService(
+	logger *logger.Logger,
+	invoiceStore store.InvoiceStore,
+	policyStore store.PolicyStore,
+	reportStore store.PremiumTaxReportStore,
+) *PremiumTaxReportService {
+	return &PremiumTaxReportService{
+		invoiceStore: invoiceStore,
+		policyStore:  policyStore,
+		reportStore:  reportStore,
+		logger:       logger,
+	}
+}
+
+// premiumTaxKey identifies a report line.
+type premiumTaxKey struct {
+	jurisdiction string
+	currency     string
+}
+
+// GeneratePremiumTaxReport aggregates the policy invoices paid in a period by the
+// jurisdiction of their policy and stores the report. The period includes its start and
+// excludes its end, so consecutive reports never count an invoice twice. Invoice tax is the tax component of
+// the pricing breakdown the invoice was raised from, so the report needs no re-pricing.
+// Lines are kept per currency since amounts are not converted.
+func (s *PremiumTaxReportService) GeneratePremiumTaxReport(ctx context.Context, period StatementPeriod) (*models.PremiumTaxReport, error) {
+	if period.Start.IsZero() || !period.End.After(period.Start) {
+		return nil, fmt.Errorf("report period end must be after its start")
+	}
+
+	invoices, err := s.invoiceStore.ListPolicyInvoicesPaid(ctx, period.Start, period.End)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invoices: %w", err)
+	}
+
+	lines := make(map[premiumTaxKey]*models.PremiumTaxReportLine)
+	linePolicies := make(map[premiumTaxKey]map[uuid.UUID]bool)
+	policies := make(map[uuid.UUID]*models.Policy)
+	for _, invoice := range invoices {
+		if invoice.PolicyID == nil {
+			continue
+		}
+		policy, ok := policies[*invoice.PolicyID]
+		if !ok {
+			policy, err = s.policyStore.GetPolicy(ctx, *invoice.PolicyID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch policy %s: %w", *invoice.PolicyID, err)
+			}
+			policies[policy.ID] = policy
+		}
+
+		key := premiumTaxKey{
+			jurisdiction: strings.ToUpper(strings.TrimSpace(policy.Jurisdiction)),
+			currency:     invoice.Currency,
+		}
+		if key.jurisdiction == "" {
+			key.jurisdiction = models.UnknownJurisdiction
+		}
+		if key.currency == "" {
+			key.currency = "USD"
+		}
+
+		line, ok := lines[key]
+		if !ok {
+			line = &models.PremiumTaxReportLine{Jurisdiction: key.jurisdiction, Currency: key.currency}
+			lines[key] = line
+			linePolicies[key] = make(map[uuid.UUID]bool)
+		}
+		line.InvoiceCount++
+		line.PremiumAmount += invoice.Amount
+		line.TaxAmount += invoice.Tax
+		linePolicies[key][policy.ID] = true
+	}
+
+	report := &models.PremiumTaxReport{
+		PeriodStart: period.Start,
+		PeriodEnd:   period.End,
+		PolicyCount: len(policies),
+		Lines:       make([]models.PremiumTaxReportLine, 0, len(lines)),
+	}
+	for key, line := range lines {
+		line.PolicyCount = len(linePolicies[key])
+		line.PremiumAmount = roundCurrency(line.PremiumAmount)
+		line.TaxAmount = roundCurrency(line.TaxAmount)
+		report.InvoiceCount += line.InvoiceCount
+		report.Lines = append(report.Lines, *line)
+	}
+	sort.Slice(report.Lines, func(i, j int) bool {
+		if report.Lines[i].Jurisdiction != report.Lines[j].Jurisdiction {
+			return report.Lines[i].Jurisdiction < report.Lines[j].Jurisdiction
+		}
+		return report.Lines[i].Currency < report.Lines[j].Currency
+	})
+
+	if err := s.reportStore.CreatePremiumTaxReport(ctx, report); err != nil {
+		return nil, fmt.Errorf("failed to create premium tax report: %w", err)
+	}
+
+	s.logger.Info("Premium tax report generated",
+		zap.String("report_id", report.ID.String()),
+		zap.Int("jurisdictions", len(report.Lines)),
+		zap.Int("invoice_count", report.InvoiceCount))
+
+	return report, nil
+}
+
+// GetPremiumTaxReport retrieves a generated premium tax report.
+func (s *PremiumTaxReportService) GetPremiumTaxReport(ctx context.Context, reportID uuid.UUID) (*models.PremiumTaxReport, error) {
+	return s.reportStore.GetPremiumTaxReport(ctx, reportID)
+}
+
+// ListPremiumTaxReports retrieves premium tax reports, most recent period first.
+func (s *PremiumTaxReportService) ListPremiumTaxReports(ctx context.Context, limit, offset int) ([]*models.PremiumTaxReport, error) {
+	return s.reportStore.ListPremiumTaxReports(ctx, limit, offset)
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratePremiumTaxReportGroupsByJurisdiction(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+
+	mozambique := &models.Policy{Base: models.Base{ID: uuid.New()}, UserID: uuid.New(), Premium: 1000, Currency: "USD", Jurisdiction: "MZ"}
+	southAfrica := &models.Policy{Base: models.Base{ID: uuid.New()}, UserID: uuid.New(), Premium: 2000, Currency: "USD", Jurisdiction: "za"}
+	policyStore := newFakePolicyStore(mozambique, southAfrica)
+	invoiceStore := newFakeInvoiceStore()
+	invoiceService := NewInvoiceService(log, config.NewManager(log, ""), invoiceStore, policyStore, nil)
+
+	for _, policy := range []*models.Policy{mozambique, southAfrica} {
+		invoice, err := invoiceService.GeneratePolicyInvoice(ctx, policy, models.InvoiceReasonIssuance,
+			&PricingBreakdown{BaseRate: policy.Premium, TaxAdjustment: policy.Premium * 0.15})
+		require.NoError(t, err)
+		_, err = invoiceService.MarkPaid(ctx, invoice.ID, nil)
+		require.NoError(t, err)
+	}
+	// An invoice that has not been paid carries no tax yet.
+	_, err := invoiceService.GeneratePolicyInvoice(ctx, mozambique, models.InvoiceReasonRenewal,
+		&PricingBreakdown{BaseRate: 1000, TaxAdjustment: 150})
+	require.NoError(t, err)
+
+	reportStore := &fakePremiumTaxReportStore{}
+	svc := NewPremiumTaxReportService(log, invoiceStore, policyStore, reportStore)
+
+	now := time.Now()
+	report, err := svc.GeneratePremiumTaxReport(ctx, StatementPeriod{Start: now.Add(-time.Hour), End: now.Add(time.Hour)})
+	require.NoError(t, err)
+
+	next, err := svc.GeneratePremiumTaxReport(ctx, StatementPeriod{Start: now.Add(time.Hour), End: now.Add(2 * time.Hour)})
+	require.NoError(t, err)
+	assert.Zero(t, next.InvoiceCount)
+
+	require.Len(t, report.Lines, 2)
+	assert.Equal(t, "MZ", report.Lines[0].Jurisdiction)
+	assert.InDelta(t, 150, report.Lines[0].TaxAmount, 0.001)
+	assert.InDelta(t, 1000, report.Lines[0].PremiumAmount, 0.001)
+	assert.Equal(t, 1, report.Lines[0].PolicyCount)
+	assert.Equal(t, "ZA", report.Lines[1].Jurisdiction)
+	assert.InDelta(t, 300, report.Lines[1].TaxAmount, 0.001)
+	assert.Equal(t, 2, report.PolicyCount)
+	assert.Equal(t, 2, report.InvoiceCount)
+
+	stored, err := svc.GetPremiumTaxReport(ctx, report.ID)
+	require.NoError(t, err)
+	assert.Len(t, stored.Lines, 2)
+}
+
+func TestPaidRenewalIsReportedInItsJurisdiction(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	configManager := config.NewManager(log, "")
+
+	policy := &models.Policy{
+		ProductID:        uuid.New(),
+		UserID:           uuid.New(),
+		Premium:          1000,
+		Currency:         "USD",
+		CoverageAmount:   50000,
+		Status:           models.PolicyStatusActive,
+		EffectiveDate:    time.Now().AddDate(-1, 0, 10),
+		ExpirationDate:   time.Now().AddDate(0, 0, 10),
+		PaymentFrequency: "annually",
+		Jurisdiction:     "MZ",
+	}
+	policyStore := newFakePolicyStore(policy)
+	invoiceStore := newFakeInvoiceStore()
+	policyStore.invoices = invoiceStore
+	invoiceService := NewInvoiceService(log, configManager, invoiceStore, policyStore, nil)
+	lifecycle := NewPolicyLifecycleService(log, configManager, policyStore, newFakePaymentStore(), nil, nil, nil, nil, invoiceService, nil, nil, nil, nil)
+
+	options := lifecycle.getDefaultRenewalOptions(policy)
+	options.PaymentMethod = "card"
+	result, err := lifecycle.RenewPolicy(ctx, policy.ID, options)
+	require.NoError(t, err)
+	require.NotNil(t, result.InvoiceID)
+	invoice, err := invoiceStore.GetInvoice(ctx, *result.InvoiceID)
+	require.NoError(t, err)
+
+	svc := NewPremiumTaxReportService(log, invoiceStore, policyStore, &fakePremiumTaxReportStore{})
+	now := time.Now()
+	report, err := svc.GeneratePremiumTaxReport(ctx, StatementPeriod{Start: now.Add(-time.Hour), End: now.Add(time.Hour)})
+	require.NoError(t, err)
+
+	require.Len(t, report.Lines, 1)
+	assert.Equal(t, "MZ", report.Lines[0].Jurisdiction)
+	assert.Equal(t, 1, report.InvoiceCount)
+	assert.InDelta(t, invoice.Amount, report.Lines[0].PremiumAmount, 0.001)
+	assert.InDelta(t, invoice.Tax, report.Lines[0].TaxAmount, 0.001)
+	assert.Positive(t, report.Lines[0].TaxAmount)
+}
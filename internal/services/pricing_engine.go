@@ -4,60 +4,65 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"strings"
 	"time"
 
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/logger"
 	"github.com/edsonmichaque/bazaruto/internal/models"
 	"github.com/edsonmichaque/bazaruto/internal/store"
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 )
 
 // PricingEngineService handles comprehensive pricing calculations with dynamic rate adjustments.
 type PricingEngineService struct {
-	productStore store.ProductStore
-	policyStore  store.PolicyStore
-	claimStore   store.ClaimStore
-	userStore    store.UserStore
+	configManager *config.Manager
+	productStore  store.ProductStore
+	policyStore   store.PolicyStore
+	claimStore    store.ClaimStore
+	userStore     store.UserStore
+	logger        *logger.Logger
 }
 
 // NewPricingEngineService creates a new PricingEngineService instance.
 func NewPricingEngineService(
+	logger *logger.Logger,
+	configManager *config.Manager,
 	productStore store.ProductStore,
 	policyStore store.PolicyStore,
 	claimStore store.ClaimStore,
 	userStore store.UserStore,
 ) *PricingEngineService {
 	return &PricingEngineService{
-		productStore: productStore,
-		policyStore:  policyStore,
-		claimStore:   claimStore,
-		userStore:    userStore,
+		configManager: configManager,
+		productStore:  productStore,
+		policyStore:   policyStore,
+		claimStore:    claimStore,
+		userStore:     userStore,
+		logger:        logger,
 	}
 }
 
 // PricingResult represents the result of a pricing calculation.
 type PricingResult struct {
-	BasePremium     float64                `json:"base_premium"`
-	AdjustedPremium float64                `json:"adjusted_premium"`
-	FinalPremium    float64                `json:"final_premium"`
-	Currency        string                 `json:"currency"`
-	Breakdown       PricingBreakdown       `json:"breakdown"`
-	Factors         []PricingFactor        `json:"factors"`
-	ValidUntil      time.Time              `json:"valid_until"`
-	QuoteID         *uuid.UUID             `json:"quote_id,omitempty"`
-	Metadata        map[string]interface{} `json:"metadata"`
+	BasePremium     float64          `json:"base_premium"`
+	AdjustedPremium float64          `json:"adjusted_premium"`
+	FinalPremium    float64          `json:"final_premium"`
+	Currency        string           `json:"currency"`
+	Breakdown       PricingBreakdown `json:"breakdown"`
+	Factors         []PricingFactor  `json:"factors"`
+	ValidUntil      time.Time        `json:"valid_until"`
+	QuoteID         *uuid.UUID       `json:"quote_id,omitempty"`
+	// RateTableVersion is the rate table version the base premium was priced from, empty
+	// when the built-in rates were used.
+	RateTableVersion string                 `json:"rate_table_version,omitempty"`
+	Metadata         map[string]interface{} `json:"metadata"`
 }
 
-// PricingBreakdown provides detailed breakdown of pricing components.
-type PricingBreakdown struct {
-	BaseRate            float64 `json:"base_rate"`
-	CoverageAdjustment  float64 `json:"coverage_adjustment"`
-	RiskAdjustment      float64 `json:"risk_adjustment"`
-	DiscountAdjustment  float64 `json:"discount_adjustment"`
-	TaxAdjustment       float64 `json:"tax_adjustment"`
-	FrequencyAdjustment float64 `json:"frequency_adjustment"`
-	MarketAdjustment    float64 `json:"market_adjustment"`
-	TotalAdjustment     float64 `json:"total_adjustment"`
-}
+// PricingBreakdown provides detailed breakdown of pricing components. It is defined in
+// models so quotes and policies can keep the breakdown they were priced with.
+type PricingBreakdown = models.PricingBreakdown
 
 // PricingFactor represents an individual factor affecting pricing.
 type PricingFactor struct {
@@ -80,6 +85,12 @@ func (s *PricingEngineService) CalculatePremium(ctx context.Context, request *Pr
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch product: %w", err)
 	}
+	if err := checkCoverageAmount(product, request.CoverageAmount); err != nil {
+		return nil, fmt.Errorf("invalid pricing request: %w", err)
+	}
+	if err := s.validateDeductible(product, request); err != nil {
+		return nil, fmt.Errorf("invalid pricing request: %w", err)
+	}
 
 	// Fetch user details for risk assessment
 	user, err := s.userStore.FindByID(ctx, request.UserID)
@@ -93,12 +104,19 @@ func (s *PricingEngineService) CalculatePremium(ctx context.Context, request *Pr
 		ValidUntil: time.Now().Add(24 * time.Hour), // Quote valid for 24 hours
 		Metadata:   make(map[string]interface{}),
 	}
+	// Stamp the pricing config version so the quote can be reproduced after a config change
+	result.Metadata["config_version"] = s.configManager.GetConfig().Pricing.Version
 
-	// Calculate base premium
-	basePremium, err := s.calculateBasePremium(product, request)
+	// Calculate base premium from the rate table version selected for the user
+	rateTableVersion, rates := s.SelectRateTable(request.UserID)
+	basePremium, err := s.calculateBasePremium(product, request, rates)
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate base premium: %w", err)
 	}
+	if rateTableVersion != "" {
+		result.RateTableVersion = rateTableVersion
+		result.Metadata["rate_table_version"] = rateTableVersion
+	}
 
 	result.BasePremium = basePremium
 	result.AdjustedPremium = basePremium
@@ -106,13 +124,15 @@ func (s *PricingEngineService) CalculatePremium(ctx context.Context, request *Pr
 	// Apply pricing factors
 	factors := []PricingFactor{
 		s.calculateCoverageFactor(request),
-		s.calculateRiskFactor(ctx, user, request),
-		s.calculateDiscountFactor(request),
+		s.calculateRiskFactor(ctx, user, request, basePremium),
+		s.calculateDiscountFactor(ctx, user, request, basePremium),
 		s.calculateTaxFactor(request),
 		s.calculateFrequencyFactor(request),
 		s.calculateMarketFactor(ctx, product, request),
 		s.calculateLoyaltyFactor(ctx, user, request),
 		s.calculateSeasonalFactor(request),
+		s.calculateNoClaimsBonusFactor(ctx, user, request, basePremium),
+		s.calculateDeductibleFactor(request, basePremium),
 	}
 
 	// Apply all factors to calculate final premium
@@ -146,32 +166,78 @@ func (s *PricingEngineService) CalculatePremium(ctx context.Context, request *Pr
 		breakdown.MarketAdjustment
 
 	// Calculate final premium
-	result.AdjustedPremium = basePremium + breakdown.TotalAdjustment
+	pricingConfig := s.configManager.GetConfig().Pricing
+	rules := pricingConfig.ValidationRules
+	unboundedPremium := basePremium + breakdown.TotalAdjustment
+	result.AdjustedPremium = unboundedPremium
+	if err := s.enforceAdjustmentBounds(result, rules); err != nil {
+		return nil, err
+	}
 	result.FinalPremium = math.Max(result.AdjustedPremium, 0) // Ensure non-negative
 	result.Breakdown = breakdown
+	technicalFloor, ok := technicalPremium(request, pricingConfig.TechnicalPremium)
+	if ok {
+		result.Metadata["technical_premium"] = technicalFloor
+	}
+	result.FinalPremium = s.applyPremiumRules(result, rules, technicalFloor)
+
+	// Account for bounds and rounding so the breakdown reconciles to the final premium.
+	result.Breakdown.BoundsAdjustment = result.FinalPremium - result.Breakdown.RoundingAdjustment - unboundedPremium
+	result.Breakdown.TotalAdjustment += result.Breakdown.BoundsAdjustment + result.Breakdown.RoundingAdjustment
+
+	if result.AdjustedPremium <= 0 {
+		s.logger.Warn("Pricing adjustments reduced premium to zero",
+			zap.String("product_id", request.ProductID.String()),
+			zap.String("user_id", request.UserID.String()),
+			zap.Float64("base_premium", basePremium),
+			zap.Float64("total_adjustment", breakdown.TotalAdjustment))
+	}
 	result.Factors = factors
 
 	// Store metadata
 	result.Metadata["product_id"] = request.ProductID.String()
 	result.Metadata["user_id"] = request.UserID.String()
 	result.Metadata["coverage_amount"] = request.CoverageAmount
-	result.Metadata["calculation_version"] = "2.0"
+	if request.Deductible > 0 {
+		result.Metadata["deductible"] = request.Deductible
+		result.Metadata["coverage_tier"] = coverageTier(request)
+	}
 
 	return result, nil
 }
 
 // PricingRequest represents a request for premium calculation.
 type PricingRequest struct {
-	ProductID        uuid.UUID              `json:"product_id"`
-	UserID           uuid.UUID              `json:"user_id"`
-	CoverageAmount   float64                `json:"coverage_amount"`
-	Currency         string                 `json:"currency"`
-	PaymentFrequency string                 `json:"payment_frequency"`
-	EffectiveDate    time.Time              `json:"effective_date"`
-	ExpirationDate   time.Time              `json:"expiration_date"`
-	RiskFactors      map[string]interface{} `json:"risk_factors"`
-	Discounts        []string               `json:"discounts"`
-	Options          map[string]interface{} `json:"options"`
+	ProductID        uuid.UUID `json:"product_id"`
+	UserID           uuid.UUID `json:"user_id"`
+	CoverageAmount   float64   `json:"coverage_amount"`
+	Currency         string    `json:"currency"`
+	PaymentFrequency string    `json:"payment_frequency"`
+	EffectiveDate    time.Time `json:"effective_date"`
+	ExpirationDate   time.Time `json:"expiration_date"`
+	Jurisdiction     string    `json:"jurisdiction,omitempty"`  // ISO country code of the insured risk
+	CoverageTier     string    `json:"coverage_tier,omitempty"` // basic, standard or premium; defaults to standard
+	Deductible       float64   `json:"deductible,omitempty"`    // Chosen deductible; 0 when none is chosen
+	// RenewalOfPolicyID is set when pricing the renewal of a policy. The policy being renewed
+	// does not count towards the multi-policy discount.
+	RenewalOfPolicyID *uuid.UUID             `json:"renewal_of_policy_id,omitempty"`
+	RiskFactors       map[string]interface{} `json:"risk_factors"`
+	// RiskProfile is a precomputed risk assessment of the applicant. When set, its premium
+	// adjustment replaces the engine's own risk assessment and its overall score drives the
+	// technical premium.
+	RiskProfile *RiskProfile           `json:"risk_profile,omitempty"`
+	Discounts   []string               `json:"discounts"`
+	Options     map[string]interface{} `json:"options"`
+}
+
+// ValidateCoverage returns ErrCoverageOutOfRange when a coverage amount is outside the
+// product's minimum and maximum coverage.
+func (s *PricingEngineService) ValidateCoverage(ctx context.Context, productID uuid.UUID, coverageAmount float64) error {
+	product, err := s.productStore.GetProduct(ctx, productID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch product: %w", err)
+	}
+	return checkCoverageAmount(product, coverageAmount)
 }
 
 // validatePricingRequest validates the pricing request.
@@ -204,24 +270,26 @@ func (s *PricingEngineService) validatePricingRequest(request *PricingRequest) e
 }
 
 // calculateBasePremium calculates the base premium for a product.
-func (s *PricingEngineService) calculateBasePremium(product *models.Product, request *PricingRequest) (float64, error) {
+func (s *PricingEngineService) calculateBasePremium(product *models.Product, request *PricingRequest, rates config.RateTable) (float64, error) {
 	// Base rate per $1000 of coverage
-	baseRate := 0.0
-
-	// Product-specific base rates (in real implementation, these would come from product configuration)
-	switch product.Category {
-	case "auto":
-		baseRate = 15.0 // $15 per $1000 coverage
-	case "home":
-		baseRate = 8.0 // $8 per $1000 coverage
-	case "life":
-		baseRate = 5.0 // $5 per $1000 coverage
-	case "health":
-		baseRate = 25.0 // $25 per $1000 coverage
-	case "business":
-		baseRate = 20.0 // $20 per $1000 coverage
-	default:
-		baseRate = 10.0 // Default rate
+	baseRate, ok := rates[product.Category]
+
+	if !ok {
+		// Built-in product-specific base rates, used when the rate table has no entry
+		switch product.Category {
+		case "auto":
+			baseRate = 15.0 // $15 per $1000 coverage
+		case "home":
+			baseRate = 8.0 // $8 per $1000 coverage
+		case "life":
+			baseRate = 5.0 // $5 per $1000 coverage
+		case "health":
+			baseRate = 25.0 // $25 per $1000 coverage
+		case "business":
+			baseRate = 20.0 // $20 per $1000 coverage
+		default:
+			baseRate = 10.0 // Default rate
+		}
 	}
 
 	// Calculate base premium
@@ -263,16 +331,27 @@ func (s *PricingEngineService) calculateCoverageFactor(request *PricingRequest)
 	return factor
 }
 
-// calculateRiskFactor calculates risk-based pricing adjustments.
-func (s *PricingEngineService) calculateRiskFactor(ctx context.Context, user *models.User, request *PricingRequest) PricingFactor {
+// calculateRiskFactor calculates risk-based pricing adjustments. When the request carries a
+// precomputed risk profile its premium adjustment, a percentage of the base premium, is
+// applied in place of the engine's own account age assessment.
+func (s *PricingEngineService) calculateRiskFactor(ctx context.Context, user *models.User, request *PricingRequest, basePremium float64) PricingFactor {
 	factor := PricingFactor{
 		Factor: "risk_assessment",
 		Type:   "rate",
 	}
 
-	// Account age factor
+	// Account age factor, unless a precomputed risk profile supersedes it
 	accountAge := time.Since(user.CreatedAt).Hours() / 24 / 365 // years
-	if accountAge < 0.5 {                                       // Less than 6 months
+	if profile := request.RiskProfile; profile != nil {
+		factor.Value = basePremium * profile.PremiumAdjustment / 100
+		factor.Description = fmt.Sprintf("Risk profile adjustment (%s risk, score %.1f)", profile.RiskLevel, profile.OverallScore)
+		factor.Impact = "neutral"
+		if factor.Value > 0 {
+			factor.Impact = "positive"
+		} else if factor.Value < 0 {
+			factor.Impact = "negative"
+		}
+	} else if accountAge < 0.5 { // Less than 6 months
 		factor.Value = request.CoverageAmount * 0.02 // 2% surcharge
 		factor.Description = "New customer risk surcharge"
 		factor.Impact = "positive"
@@ -302,18 +381,26 @@ func (s *PricingEngineService) calculateRiskFactor(ctx context.Context, user *mo
 }
 
 // calculateDiscountFactor calculates discount adjustments.
-func (s *PricingEngineService) calculateDiscountFactor(request *PricingRequest) PricingFactor {
+// The multi-policy bundle discount is applied automatically when the user already
+// holds active policies, whether or not the caller requested it. It is a share of the
+// base premium, so it can never exceed the premium it discounts.
+func (s *PricingEngineService) calculateDiscountFactor(ctx context.Context, user *models.User, request *PricingRequest, basePremium float64) PricingFactor {
 	factor := PricingFactor{
 		Factor: "discounts",
 		Type:   "discount",
 	}
 
+	if bundleDiscount, activePolicies := s.multiPolicyDiscountRate(ctx, user, request); bundleDiscount > 0 {
+		factor.Value += basePremium * -bundleDiscount
+		factor.Description += fmt.Sprintf("Multi-policy discount (%d active policies); ", activePolicies)
+	}
+
 	// Apply available discounts
 	for _, discount := range request.Discounts {
 		switch discount {
 		case "multi_policy":
-			factor.Value += request.CoverageAmount * -0.10 // 10% discount
-			factor.Description += "Multi-policy discount; "
+			// Determined from the user's active policies above
+			continue
 		case "loyalty":
 			factor.Value += request.CoverageAmount * -0.05 // 5% discount
 			factor.Description += "Loyalty discount; "
@@ -339,6 +426,39 @@ func (s *PricingEngineService) calculateDiscountFactor(request *PricingRequest)
 	return factor
 }
 
+// multiPolicyDiscountRate returns the bundle discount rate for a user and the number of
+// active policies it is based on. The rate grows by the configured step for each policy
+// beyond the first, up to the configured maximum and never beyond the whole premium. When
+// pricing a renewal, the policy being renewed is not counted, so a customer renewing their
+// only policy gets no bundle discount.
+func (s *PricingEngineService) multiPolicyDiscountRate(ctx context.Context, user *models.User, request *PricingRequest) (float64, int64) {
+	activePolicies, err := s.policyStore.CountActiveByUser(ctx, user.ID)
+	if err != nil {
+		return 0, 0
+	}
+	if request.RenewalOfPolicyID != nil {
+		renewed, err := s.policyStore.GetPolicy(ctx, *request.RenewalOfPolicyID)
+		if err != nil {
+			return 0, 0
+		}
+		if renewed.UserID == user.ID && renewed.Status == models.PolicyStatusActive {
+			activePolicies--
+		}
+	}
+	if activePolicies <= 0 {
+		return 0, 0
+	}
+
+	rules := s.configManager.GetConfig().Pricing.DiscountRules
+	rate := rules.MultiPolicyDiscount + rules.MultiPolicyStep*float64(activePolicies-1)
+	if rules.MultiPolicyMaxDiscount > 0 && rate > rules.MultiPolicyMaxDiscount {
+		rate = rules.MultiPolicyMaxDiscount
+	}
+	rate = math.Min(math.Max(rate, 0), 1)
+
+	return rate, activePolicies
+}
+
 // calculateTaxFactor calculates tax adjustments.
 func (s *PricingEngineService) calculateTaxFactor(request *PricingRequest) PricingFactor {
 	factor := PricingFactor{
@@ -430,23 +550,29 @@ func (s *PricingEngineService) calculateLoyaltyFactor(ctx context.Context, user
 	return factor
 }
 
-// calculateSeasonalFactor calculates seasonal pricing adjustments.
+// calculateSeasonalFactor calculates seasonal adjustments. Seasons follow the hemisphere of
+// the request's jurisdiction, so December to February is winter in the northern hemisphere
+// and summer in the southern hemisphere.
 func (s *PricingEngineService) calculateSeasonalFactor(request *PricingRequest) PricingFactor {
 	factor := PricingFactor{
 		Factor: "seasonal",
 		Type:   "rate",
 	}
 
-	// Seasonal adjustments based on effective date
+	rules := s.configManager.GetConfig().Pricing.SeasonalAdjustments
 	month := request.EffectiveDate.Month()
+	if seasonalHemisphere(rules, request.Jurisdiction) == hemisphereSouthern {
+		// Shift by six months to map southern-hemisphere dates onto northern seasons.
+		month = (month+5)%12 + 1
+	}
 
 	switch month {
 	case 12, 1, 2: // Winter months
-		factor.Value = request.CoverageAmount * 0.02 // 2% winter surcharge
+		factor.Value = request.CoverageAmount * rules.WinterSurcharge
 		factor.Description = "Winter season adjustment"
 		factor.Impact = "positive"
 	case 6, 7, 8: // Summer months
-		factor.Value = request.CoverageAmount * 0.01 // 1% summer surcharge
+		factor.Value = request.CoverageAmount * rules.SummerSurcharge
 		factor.Description = "Summer season adjustment"
 		factor.Impact = "positive"
 	default: // Spring/Fall
@@ -458,6 +584,96 @@ func (s *PricingEngineService) calculateSeasonalFactor(request *PricingRequest)
 	return factor
 }
 
+// Hemispheres used by the seasonal adjustment.
+const (
+	hemisphereNorthern = "northern"
+	hemisphereSouthern = "southern"
+)
+
+// seasonalHemisphere returns the hemisphere configured for a jurisdiction, falling back to
+// the default hemisphere and then to the northern hemisphere.
+func seasonalHemisphere(rules config.SeasonalAdjustments, jurisdiction string) string {
+	if hemisphere, ok := rules.JurisdictionHemispheres[strings.ToUpper(jurisdiction)]; ok {
+		return hemisphere
+	}
+	if rules.DefaultHemisphere != "" {
+		return rules.DefaultHemisphere
+	}
+	return hemisphereNorthern
+}
+
+// calculateNoClaimsBonusFactor calculates the claims-experience discount.
+// Unlike the coverage-based factors, the bonus is a share of the base premium; it escalates
+// along the configured ladder with each claim-free year and resets when a claim is paid.
+func (s *PricingEngineService) calculateNoClaimsBonusFactor(ctx context.Context, user *models.User, request *PricingRequest, basePremium float64) PricingFactor {
+	factor := PricingFactor{
+		Factor: "no_claims_bonus",
+		Type:   "discount",
+		Impact: "neutral",
+	}
+
+	rules := s.configManager.GetConfig().Pricing.NoClaimsBonus
+	if !rules.Enabled || len(rules.Ladder) == 0 {
+		factor.Description = "No-claims bonus not enabled"
+		return factor
+	}
+
+	claimFreeYears, err := s.claimFreeYears(ctx, user, request.EffectiveDate)
+	if err != nil {
+		factor.Description = "Claim history unavailable"
+		return factor
+	}
+
+	discount := 0.0
+	for _, step := range rules.Ladder {
+		if claimFreeYears >= step.ClaimFreeYears && step.Discount > discount {
+			discount = step.Discount
+		}
+	}
+
+	if discount == 0 {
+		factor.Description = "No claim-free years"
+		return factor
+	}
+
+	factor.Value = basePremium * -discount
+	factor.Description = fmt.Sprintf("No-claims bonus (%d claim-free years, %.0f%%)", claimFreeYears, discount*100)
+	factor.Impact = "negative"
+
+	return factor
+}
+
+// claimFreeYears counts the whole years up to asOf since the later of the account
+// creation and the user's most recently paid claim.
+func (s *PricingEngineService) claimFreeYears(ctx context.Context, user *models.User, asOf time.Time) (int, error) {
+	claims, err := s.claimStore.ListClaims(ctx, &user.ID, nil, "", 0, 0)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list claims: %w", err)
+	}
+
+	since := user.CreatedAt
+	for _, claim := range claims {
+		if claim.Status != models.ClaimStatusPaid && claim.PaidAmount <= 0 {
+			continue
+		}
+
+		paidAt := claim.ReportedDate
+		if claim.ResolvedDate != nil {
+			paidAt = *claim.ResolvedDate
+		}
+		if paidAt.After(since) {
+			since = paidAt
+		}
+	}
+
+	years := int(asOf.Sub(since).Hours() / 24 / 365)
+	if years < 0 {
+		years = 0
+	}
+
+	return years, nil
+}
+
 // UpdatePricingFactors updates pricing factors based on new data or market conditions.
 func (s *PricingEngineService) UpdatePricingFactors(ctx context.Context, productID uuid.UUID, factors map[string]interface{}) error {
 	// In a real implementation, this would update pricing factors in the database
@@ -524,6 +740,66 @@ func (s *PricingEngineService) ComparePricing(ctx context.Context, baseRequest *
 	return comparisons, nil
 }
 
+// Parameters that SimulatePricing can sweep.
+const (
+	SimulationParamCoverageAmount = "coverage_amount"
+	SimulationParamDeductible     = "deductible"
+)
+
+// maxSimulationPoints bounds the number of premiums calculated by one simulation.
+const maxSimulationPoints = 1000
+
+// PricingSimulationPoint is the premium at one value of a simulated parameter.
+type PricingSimulationPoint struct {
+	Value        float64 `json:"value"`
+	FinalPremium float64 `json:"final_premium"`
+	BasePremium  float64 `json:"base_premium"`
+}
+
+// SimulatePricing sweeps one parameter of the base request from from to to in steps of step
+// and returns the premium at each value, for example the premium curve for coverage from
+// 100,000 to 1,000,000. The base request is not modified.
+func (s *PricingEngineService) SimulatePricing(ctx context.Context, base *PricingRequest, param string, from, to, step float64) ([]PricingSimulationPoint, error) {
+	if step <= 0 {
+		return nil, fmt.Errorf("simulation step must be greater than 0")
+	}
+	if to < from {
+		return nil, fmt.Errorf("simulation range end must not be before its start")
+	}
+	if count := math.Floor((to-from)/step) + 1; count > maxSimulationPoints {
+		return nil, fmt.Errorf("simulation would calculate %.0f premiums, at most %d are allowed", count, maxSimulationPoints)
+	}
+
+	var points []PricingSimulationPoint
+	for i := 0; ; i++ {
+		value := from + float64(i)*step
+		if value > to {
+			break
+		}
+
+		request := *base
+		switch param {
+		case SimulationParamCoverageAmount:
+			request.CoverageAmount = value
+		case SimulationParamDeductible:
+			request.Deductible = value
+		default:
+			return nil, fmt.Errorf("unsupported simulation parameter %q", param)
+		}
+
+		result, err := s.CalculatePremium(ctx, &request)
+		if err != nil {
+			return nil, fmt.Errorf("failed to calculate premium at %s %.2f: %w", param, value, err)
+		}
+		points = append(points, PricingSimulationPoint{
+			Value:        value,
+			FinalPremium: result.FinalPremium,
+			BasePremium:  result.BasePremium,
+		})
+	}
+	return points, nil
+}
+
 // PricingComparison represents a comparison between different pricing scenarios.
 type PricingComparison struct {
 	Scenario         string         `json:"scenario"`
@@ -558,5 +834,12 @@ func (s *PricingEngineService) ValidatePricingResult(result *PricingResult) erro
 		return fmt.Errorf("valid until date must be in the future")
 	}
 
+	// The rounded breakdown total may differ from the final premium by at most one cent.
+	total := roundCurrency(result.Breakdown.Total())
+	if diffCents := math.Abs(math.Round((total - result.FinalPremium) * 100)); diffCents > 1 {
+		return fmt.Errorf("%w: breakdown totals %.2f but final premium is %.2f",
+			ErrPricingBreakdownMismatch, total, result.FinalPremium)
+	}
+
 	return nil
 }
@@ -0,0 +1,575 @@
+package services
+
+import (
+	"context"
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPricingEngine(policyStore *fakePolicyStore, claimStore *fakeClaimStore) *PricingEngineService {
+	log := logger.NewLogger("error", "json")
+	return NewPricingEngineService(log, config.NewManager(log, ""), nil, policyStore, claimStore, nil)
+}
+
+func TestNoClaimsBonusEscalatesWithClaimFreeYears(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	request := &PricingRequest{EffectiveDate: now}
+	svc := newTestPricingEngine(newFakePolicyStore(), newFakeClaimStore())
+
+	oneYear := &models.User{Base: models.Base{ID: uuid.New(), CreatedAt: now.AddDate(-1, -2, 0)}}
+	twoYears := &models.User{Base: models.Base{ID: uuid.New(), CreatedAt: now.AddDate(-2, -2, 0)}}
+
+	oneYearFactor := svc.calculateNoClaimsBonusFactor(ctx, oneYear, request, 1000)
+	twoYearFactor := svc.calculateNoClaimsBonusFactor(ctx, twoYears, request, 1000)
+
+	assert.Less(t, oneYearFactor.Value, 0.0)
+	assert.Less(t, twoYearFactor.Value, oneYearFactor.Value, "two claim-free years should discount more than one")
+}
+
+func TestNoClaimsBonusResetsOnPaidClaim(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	user := &models.User{Base: models.Base{ID: uuid.New(), CreatedAt: now.AddDate(-4, 0, 0)}}
+	resolved := now.AddDate(0, -3, 0)
+	claim := &models.Claim{
+		UserID:       user.ID,
+		Status:       models.ClaimStatusPaid,
+		PaidAmount:   2500,
+		ReportedDate: now.AddDate(0, -4, 0),
+		ResolvedDate: &resolved,
+	}
+	svc := newTestPricingEngine(newFakePolicyStore(), newFakeClaimStore(claim))
+
+	factor := svc.calculateNoClaimsBonusFactor(ctx, user, &PricingRequest{EffectiveDate: now}, 1000)
+
+	assert.Zero(t, factor.Value)
+	assert.Equal(t, "neutral", factor.Impact)
+}
+
+func TestBundleDiscountAppliedForExistingActivePolicies(t *testing.T) {
+	ctx := context.Background()
+	user := &models.User{Base: models.Base{ID: uuid.New(), CreatedAt: time.Now()}}
+	policies := newFakePolicyStore(
+		&models.Policy{UserID: user.ID, Status: models.PolicyStatusActive},
+		&models.Policy{UserID: user.ID, Status: models.PolicyStatusActive},
+		&models.Policy{UserID: user.ID, Status: models.PolicyStatusCancelled},
+	)
+	svc := newTestPricingEngine(policies, newFakeClaimStore())
+	request := &PricingRequest{CoverageAmount: 100000}
+
+	factor := svc.calculateDiscountFactor(ctx, user, request, 1000)
+
+	// 10% base bundle discount plus one 2.5% step for the second active policy, taken
+	// off the premium rather than the coverage
+	assert.InDelta(t, -125, factor.Value, 0.001)
+	assert.Equal(t, "negative", factor.Impact)
+
+	newcomer := &models.User{Base: models.Base{ID: uuid.New()}}
+	assert.Zero(t, svc.calculateDiscountFactor(ctx, newcomer, request, 1000).Value)
+}
+
+func TestBundleDiscountIsCappedForManyActivePolicies(t *testing.T) {
+	ctx := context.Background()
+	user := &models.User{Base: models.Base{ID: uuid.New(), CreatedAt: time.Now()}}
+	var active []*models.Policy
+	for i := 0; i < 12; i++ {
+		active = append(active, &models.Policy{UserID: user.ID, Status: models.PolicyStatusActive})
+	}
+	svc := newTestPricingEngine(newFakePolicyStore(active...), newFakeClaimStore())
+
+	factor := svc.calculateDiscountFactor(ctx, user, &PricingRequest{CoverageAmount: 100000}, 1000)
+
+	// The configured 20% maximum applies however many policies the user holds
+	assert.InDelta(t, -200, factor.Value, 0.001)
+}
+
+// priceWithValidationRules prices a year of home coverage under the given validation rules.
+func priceWithValidationRules(t *testing.T, coverageAmount float64, rules config.PricingValidationRules) (*PricingResult, error) {
+	return priceWithRiskFactors(t, coverageAmount, rules, nil)
+}
+
+// priceWithRiskFactors prices a year of home coverage for an applicant with the given risk
+// factors under the given validation rules, with the technical premium floor enabled.
+func priceWithRiskFactors(t *testing.T, coverageAmount float64, rules config.PricingValidationRules, riskFactors map[string]interface{}) (*PricingResult, error) {
+	return priceCategory(t, "home", coverageAmount, rules, riskFactors)
+}
+
+// priceCategory prices a year of coverage on a product of the given category, with the
+// technical premium floor enabled.
+func priceCategory(t *testing.T, category string, coverageAmount float64, rules config.PricingValidationRules, riskFactors map[string]interface{}) (*PricingResult, error) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	configManager := config.NewManager(log, filepath.Join(t.TempDir(), "business_rules.json"))
+	cfg := configManager.GetConfig()
+	cfg.Pricing.ValidationRules = rules
+	cfg.Pricing.TechnicalPremium.Enabled = true
+	require.NoError(t, configManager.UpdateConfig(ctx, cfg))
+
+	product := &models.Product{Base: models.Base{ID: uuid.New()}, Category: category}
+	user := &models.User{Base: models.Base{ID: uuid.New(), CreatedAt: time.Now().AddDate(-3, 0, 0)}}
+	svc := NewPricingEngineService(log, configManager, newFakeProductStore(product), newFakePolicyStore(), newFakeClaimStore(), newFakeUserStore(user))
+
+	now := time.Now()
+	return svc.CalculatePremium(ctx, &PricingRequest{
+		ProductID:        product.ID,
+		UserID:           user.ID,
+		CoverageAmount:   coverageAmount,
+		Currency:         "USD",
+		PaymentFrequency: "annually",
+		EffectiveDate:    now,
+		ExpirationDate:   now.AddDate(1, 0, 0),
+		RiskFactors:      riskFactors,
+	})
+}
+
+func TestCalculatePremiumRaisesPremiumToFloor(t *testing.T) {
+	unbounded, err := priceWithValidationRules(t, 100000, config.PricingValidationRules{})
+	require.NoError(t, err)
+
+	floor := math.Ceil(unbounded.FinalPremium) + 250
+	result, err := priceWithValidationRules(t, 100000, config.PricingValidationRules{MinPremium: floor})
+	require.NoError(t, err)
+
+	assert.Equal(t, floor, result.FinalPremium)
+	assert.Equal(t, floor, result.Metadata["premium_floor_applied"])
+}
+
+func TestCalculatePremiumRaisesHighRiskPremiumToTechnicalFloor(t *testing.T) {
+	unscored, err := priceWithValidationRules(t, 100000, config.PricingValidationRules{})
+	require.NoError(t, err)
+	assert.NotContains(t, unscored.Metadata, "technical_premium")
+
+	result, err := priceWithRiskFactors(t, 100000, config.PricingValidationRules{}, map[string]interface{}{RiskScoreFactor: 95.0})
+	require.NoError(t, err)
+
+	// 0.1% + 95% of the way to 5% loss probability on 100000 of coverage, loaded by 25%
+	technical := (0.001 + 0.049*0.95) * 100000 * 1.25
+	require.Greater(t, technical, unscored.FinalPremium)
+	assert.InDelta(t, technical, result.FinalPremium, 0.01)
+	assert.InDelta(t, technical, result.Metadata["technical_floor_applied"], 0.001)
+	assert.InDelta(t, result.FinalPremium, result.BasePremium+result.Breakdown.TotalAdjustment, 0.001)
+}
+
+func TestCalculatePremiumLeavesLowRiskPremiumAboveTechnicalFloor(t *testing.T) {
+	unscored, err := priceWithValidationRules(t, 100000, config.PricingValidationRules{})
+	require.NoError(t, err)
+
+	result, err := priceWithRiskFactors(t, 100000, config.PricingValidationRules{}, map[string]interface{}{RiskScoreFactor: 0.0})
+	require.NoError(t, err)
+
+	require.Less(t, result.Metadata["technical_premium"], unscored.FinalPremium)
+	assert.Equal(t, unscored.FinalPremium, result.FinalPremium)
+	assert.NotContains(t, result.Metadata, "technical_floor_applied")
+}
+
+func TestTechnicalFloorDoesNotBindAtModerateRisk(t *testing.T) {
+	for _, category := range []string{"home", "auto", "life"} {
+		t.Run(category, func(t *testing.T) {
+			unscored, err := priceCategory(t, category, 100000, config.PricingValidationRules{}, nil)
+			require.NoError(t, err)
+
+			for _, score := range []float64{30, 35, 40} {
+				result, err := priceCategory(t, category, 100000, config.PricingValidationRules{}, map[string]interface{}{RiskScoreFactor: score})
+				require.NoError(t, err)
+				assert.Equal(t, unscored.FinalPremium, result.FinalPremium, "risk score %.0f", score)
+				assert.NotContains(t, result.Metadata, "technical_floor_applied", "risk score %.0f", score)
+			}
+		})
+	}
+}
+
+func TestTechnicalFloorIsDisabledByDefault(t *testing.T) {
+	log := logger.NewLogger("error", "json")
+	assert.False(t, config.NewManager(log, "").GetConfig().Pricing.TechnicalPremium.Enabled)
+}
+
+func TestCalculatePremiumAppliesRoundingPolicy(t *testing.T) {
+	const coverageAmount = 123456.78
+	raw, err := priceWithValidationRules(t, coverageAmount, config.PricingValidationRules{})
+	require.NoError(t, err)
+	// The unrounded premium must have fractional cents for the rounding policies to differ.
+	require.NotEqual(t, math.Round(raw.AdjustedPremium*100)/100, raw.AdjustedPremium)
+
+	tests := []struct {
+		policy string
+		want   float64
+	}{
+		{PremiumRoundingNearestCent, math.Round(raw.AdjustedPremium*100) / 100},
+		{PremiumRoundingNearestDollar, math.Round(raw.AdjustedPremium)},
+		{PremiumRoundingUp, math.Ceil(math.Round(raw.AdjustedPremium*100) / 100)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			result, err := priceWithValidationRules(t, coverageAmount, config.PricingValidationRules{RoundingPolicy: tt.policy})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, result.FinalPremium)
+		})
+	}
+}
+
+func TestCalculatePremiumCapsPremiumAtMaximum(t *testing.T) {
+	unbounded, err := priceWithValidationRules(t, 100000, config.PricingValidationRules{})
+	require.NoError(t, err)
+
+	ceiling := math.Floor(unbounded.FinalPremium / 2)
+	result, err := priceWithValidationRules(t, 100000, config.PricingValidationRules{MaxPremium: ceiling})
+	require.NoError(t, err)
+
+	assert.Equal(t, ceiling, result.FinalPremium)
+	assert.Equal(t, ceiling, result.Metadata["premium_cap_applied"])
+}
+
+func TestCalculatePremiumEnforcesMaxAdjustment(t *testing.T) {
+	unbounded, err := priceWithValidationRules(t, 100000, config.PricingValidationRules{})
+	require.NoError(t, err)
+	// Allow half the adjustment the request actually needs.
+	maxAdjustment := 1 + (unbounded.AdjustedPremium/unbounded.BasePremium-1)/2
+	require.Greater(t, unbounded.AdjustedPremium/unbounded.BasePremium, maxAdjustment)
+
+	_, err = priceWithValidationRules(t, 100000, config.PricingValidationRules{MaxAdjustment: maxAdjustment})
+	assert.ErrorIs(t, err, ErrPremiumAdjustmentOutOfRange)
+
+	capped, err := priceWithValidationRules(t, 100000, config.PricingValidationRules{MaxAdjustment: maxAdjustment, CapAdjustments: true})
+	require.NoError(t, err)
+	assert.InDelta(t, unbounded.BasePremium*maxAdjustment, capped.FinalPremium, 0.01)
+	assert.Equal(t, maxAdjustment, capped.Metadata["adjustment_cap_applied"])
+}
+
+func TestPricingBreakdownReconcilesToFinalPremium(t *testing.T) {
+	unbounded, err := priceWithValidationRules(t, 100000, config.PricingValidationRules{})
+	require.NoError(t, err)
+	ratio := unbounded.AdjustedPremium / unbounded.BasePremium
+
+	tests := []struct {
+		name           string
+		coverageAmount float64
+		rules          config.PricingValidationRules
+	}{
+		{"defaults", 100000, config.PricingValidationRules{RoundingPolicy: PremiumRoundingNearestCent}},
+		{"fractional cents", 123456.78, config.PricingValidationRules{RoundingPolicy: PremiumRoundingNearestCent}},
+		{"nearest dollar", 123456.78, config.PricingValidationRules{RoundingPolicy: PremiumRoundingNearestDollar}},
+		{"round up", 123456.78, config.PricingValidationRules{RoundingPolicy: PremiumRoundingUp}},
+		{"premium floor", 100000, config.PricingValidationRules{MinPremium: unbounded.FinalPremium + 99.99}},
+		{"premium cap", 100000, config.PricingValidationRules{MaxPremium: unbounded.FinalPremium / 3}},
+		{"adjustment cap", 100000, config.PricingValidationRules{MaxAdjustment: (1 + ratio) / 2, CapAdjustments: true}},
+	}
+	validator := newTestPricingEngine(newFakePolicyStore(), newFakeClaimStore())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := priceWithValidationRules(t, tt.coverageAmount, tt.rules)
+			require.NoError(t, err)
+
+			assert.NoError(t, validator.ValidatePricingResult(result))
+			assert.InDelta(t, result.FinalPremium, result.Breakdown.BaseRate+result.Breakdown.TotalAdjustment, 0.005)
+		})
+	}
+
+	t.Run("diverging breakdown", func(t *testing.T) {
+		result, err := priceWithValidationRules(t, 100000, config.PricingValidationRules{})
+		require.NoError(t, err)
+		result.Breakdown.TaxAdjustment += 0.05
+
+		assert.ErrorIs(t, validator.ValidatePricingResult(result), ErrPricingBreakdownMismatch)
+	})
+}
+
+func TestSeasonalFactorFollowsJurisdictionHemisphere(t *testing.T) {
+	svc := newTestPricingEngine(newFakePolicyStore(), newFakeClaimStore())
+	january := time.Date(2025, time.January, 15, 0, 0, 0, 0, time.UTC)
+	july := time.Date(2025, time.July, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		jurisdiction string
+		date         time.Time
+		want         float64
+		description  string
+	}{
+		{"northern january", "US", january, 2000, "Winter season adjustment"},
+		{"southern january", "ZA", january, 1000, "Summer season adjustment"},
+		{"southern july", "mz", july, 2000, "Winter season adjustment"},
+		{"unknown jurisdiction", "", july, 1000, "Summer season adjustment"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factor := svc.calculateSeasonalFactor(&PricingRequest{
+				CoverageAmount: 100000,
+				EffectiveDate:  tt.date,
+				Jurisdiction:   tt.jurisdiction,
+			})
+			assert.InDelta(t, tt.want, factor.Value, 0.001)
+			assert.Equal(t, tt.description, factor.Description)
+		})
+	}
+}
+
+func TestCalculatePremiumRejectsCoverageOutsideProductLimits(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	product := &models.Product{
+		Base:              models.Base{ID: uuid.New()},
+		Name:              "Home Plus",
+		Category:          "home",
+		MinCoverageAmount: 50000,
+		MaxCoverageAmount: 500000,
+	}
+	user := &models.User{Base: models.Base{ID: uuid.New(), CreatedAt: time.Now().AddDate(-3, 0, 0)}}
+	svc := NewPricingEngineService(log, config.NewManager(log, ""), newFakeProductStore(product), newFakePolicyStore(), newFakeClaimStore(), newFakeUserStore(user))
+
+	price := func(coverageAmount float64) error {
+		now := time.Now()
+		_, err := svc.CalculatePremium(ctx, &PricingRequest{
+			ProductID:        product.ID,
+			UserID:           user.ID,
+			CoverageAmount:   coverageAmount,
+			Currency:         "USD",
+			PaymentFrequency: "annually",
+			EffectiveDate:    now,
+			ExpirationDate:   now.AddDate(1, 0, 0),
+		})
+		return err
+	}
+
+	err := price(10000)
+	require.ErrorIs(t, err, ErrCoverageOutOfRange)
+	assert.Contains(t, err.Error(), "below the Home Plus minimum of 50000.00")
+
+	err = price(750000)
+	require.ErrorIs(t, err, ErrCoverageOutOfRange)
+	assert.Contains(t, err.Error(), "above the Home Plus maximum of 500000.00")
+
+	assert.NoError(t, price(50000))
+	assert.NoError(t, price(500000))
+}
+
+func TestCalculatePremiumValidatesDeductibleAgainstMatrix(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	product := &models.Product{Base: models.Base{ID: uuid.New()}, Category: "home"}
+	user := &models.User{Base: models.Base{ID: uuid.New(), CreatedAt: time.Now().AddDate(-3, 0, 0)}}
+	svc := NewPricingEngineService(log, config.NewManager(log, ""), newFakeProductStore(product), newFakePolicyStore(), newFakeClaimStore(), newFakeUserStore(user))
+
+	price := func(tier string, deductible float64) (*PricingResult, error) {
+		now := time.Now()
+		return svc.CalculatePremium(ctx, &PricingRequest{
+			ProductID:        product.ID,
+			UserID:           user.ID,
+			CoverageAmount:   200000,
+			Currency:         "USD",
+			PaymentFrequency: "annually",
+			EffectiveDate:    now,
+			ExpirationDate:   now.AddDate(1, 0, 0),
+			CoverageTier:     tier,
+			Deductible:       deductible,
+		})
+	}
+
+	result, err := price("premium", 250)
+	require.NoError(t, err)
+	assert.Equal(t, 250.0, result.Metadata["deductible"])
+
+	// The default tier is standard, which offers 500, 1000 and 2500.
+	_, err = price("", 500)
+	require.NoError(t, err)
+
+	_, err = price("", 750)
+	require.ErrorIs(t, err, ErrDeductibleNotAllowed)
+	assert.Contains(t, err.Error(), "valid options are 500.00, 1000.00, 2500.00")
+
+	_, err = price("platinum", 500)
+	assert.ErrorContains(t, err, "unknown coverage tier")
+}
+
+func TestHigherDeductibleEarnsLargerCredit(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	product := &models.Product{Base: models.Base{ID: uuid.New()}, Category: "home"}
+	user := &models.User{Base: models.Base{ID: uuid.New(), CreatedAt: time.Now().AddDate(-3, 0, 0)}}
+	svc := NewPricingEngineService(log, config.NewManager(log, ""), newFakeProductStore(product), newFakePolicyStore(), newFakeClaimStore(), newFakeUserStore(user))
+
+	price := func(deductible float64) *PricingResult {
+		now := time.Now()
+		result, err := svc.CalculatePremium(ctx, &PricingRequest{
+			ProductID:        product.ID,
+			UserID:           user.ID,
+			CoverageAmount:   200000,
+			Currency:         "USD",
+			PaymentFrequency: "annually",
+			EffectiveDate:    now,
+			ExpirationDate:   now.AddDate(1, 0, 0),
+			Deductible:       deductible,
+		})
+		require.NoError(t, err)
+		return result
+	}
+	credit := func(result *PricingResult) float64 {
+		for _, factor := range result.Factors {
+			if factor.Factor == "deductible" {
+				return factor.Value
+			}
+		}
+		t.Fatal("no deductible factor")
+		return 0
+	}
+
+	none, low, high := price(0), price(500), price(2500)
+
+	// The credit is twice the deductible's share of the coverage, taken off the base premium.
+	assert.Zero(t, credit(none))
+	assert.InDelta(t, low.BasePremium*-0.005, credit(low), 0.001)
+	assert.InDelta(t, high.BasePremium*-0.025, credit(high), 0.001)
+	assert.Less(t, low.FinalPremium, none.FinalPremium)
+	assert.Less(t, high.FinalPremium, low.FinalPremium)
+}
+
+func TestSimulatePricingCoverageCurveIsMonotonic(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	product := &models.Product{Base: models.Base{ID: uuid.New()}, Category: "home"}
+	user := &models.User{Base: models.Base{ID: uuid.New(), CreatedAt: time.Now().AddDate(-3, 0, 0)}}
+	svc := NewPricingEngineService(log, config.NewManager(log, ""), newFakeProductStore(product), newFakePolicyStore(), newFakeClaimStore(), newFakeUserStore(user))
+
+	now := time.Now()
+	base := &PricingRequest{
+		ProductID:        product.ID,
+		UserID:           user.ID,
+		CoverageAmount:   250000,
+		Currency:         "USD",
+		PaymentFrequency: "annually",
+		EffectiveDate:    now,
+		ExpirationDate:   now.AddDate(1, 0, 0),
+	}
+
+	curve, err := svc.SimulatePricing(ctx, base, SimulationParamCoverageAmount, 100000, 1000000, 100000)
+	require.NoError(t, err)
+	require.Len(t, curve, 10)
+	assert.Equal(t, 100000.0, curve[0].Value)
+	assert.Equal(t, 1000000.0, curve[9].Value)
+	for i := 1; i < len(curve); i++ {
+		assert.Greater(t, curve[i].FinalPremium, curve[i-1].FinalPremium, "premium at %.0f", curve[i].Value)
+	}
+	assert.Equal(t, 250000.0, base.CoverageAmount, "the base request is not modified")
+
+	_, err = svc.SimulatePricing(ctx, base, "age", 18, 65, 1)
+	assert.ErrorContains(t, err, "unsupported simulation parameter")
+	_, err = svc.SimulatePricing(ctx, base, SimulationParamCoverageAmount, 1, 1000000, 1)
+	assert.ErrorContains(t, err, "at most 1000")
+}
+
+func TestSelectRateTableSplitsRolloutDeterministically(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	configManager := config.NewManager(log, filepath.Join(t.TempDir(), "business_rules.json"))
+	cfg := configManager.GetConfig()
+	cfg.Pricing.RateTables = map[string]config.RateTable{
+		"A": {"home": 8.0},
+		"B": {"home": 9.0},
+	}
+	assigned := uuid.New()
+	cfg.Pricing.RateTableRollout = config.RateTableRollout{
+		Control:           "A",
+		Variant:           "B",
+		VariantPercentage: 50,
+		Assignments:       map[string]string{assigned.String(): "B"},
+	}
+	require.NoError(t, configManager.UpdateConfig(ctx, cfg))
+	svc := NewPricingEngineService(log, configManager, nil, nil, nil, nil)
+
+	variant := 0
+	for i := 0; i < 1000; i++ {
+		userID := uuid.New()
+		version, _ := svc.SelectRateTable(userID)
+		again, _ := svc.SelectRateTable(userID)
+		require.Equal(t, version, again, "a user must keep the same rate table version")
+		if version == "B" {
+			variant++
+		}
+	}
+	assert.InDelta(t, 500, variant, 75, "about half of users should get the variant")
+
+	version, table := svc.SelectRateTable(assigned)
+	assert.Equal(t, "B", version)
+	assert.Equal(t, 9.0, table["home"])
+}
+
+func TestCalculatePremiumRecordsRateTableVersion(t *testing.T) {
+	result, err := priceWithValidationRules(t, 100000, config.PricingValidationRules{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "v1", result.RateTableVersion)
+	assert.Equal(t, "v1", result.Metadata["rate_table_version"])
+	assert.Equal(t, "1.0", result.Metadata["config_version"])
+	assert.InDelta(t, 800, result.BasePremium, 0.01)
+}
+
+func TestQuotePremiumRecordsRateTableVersion(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	product := &models.Product{Base: models.Base{ID: uuid.New()}, Category: "home"}
+	user := &models.User{Base: models.Base{ID: uuid.New(), CreatedAt: time.Now().AddDate(-3, 0, 0)}}
+	pricing := NewPricingEngineService(log, config.NewManager(log, ""), newFakeProductStore(product), newFakePolicyStore(), newFakeClaimStore(), newFakeUserStore(user))
+
+	quotes := NewQuoteService(newFakeQuoteStore())
+	quotes.SetPricingService(pricing)
+	quote := &models.Quote{
+		ProductID:      product.ID,
+		UserID:         user.ID,
+		BasePrice:      500,
+		FinalPrice:     500,
+		Currency:       "USD",
+		CoverageAmount: 100000,
+		ValidUntil:     time.Now().AddDate(0, 0, 30),
+	}
+	require.NoError(t, quotes.CreateQuote(ctx, quote))
+
+	priced, err := quotes.CalculatePremium(ctx, quote.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "v1", priced.RateTableVersion)
+	assert.Greater(t, priced.FinalPrice, 0.0)
+}
+
+func TestCalculatePremiumAppliesRiskProfileAdjustmentOnce(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	product := &models.Product{Base: models.Base{ID: uuid.New()}, Category: "home"}
+	user := &models.User{Base: models.Base{ID: uuid.New(), CreatedAt: time.Now().AddDate(-3, 0, 0)}}
+	svc := NewPricingEngineService(log, config.NewManager(log, ""), newFakeProductStore(product), newFakePolicyStore(), newFakeClaimStore(), newFakeUserStore(user))
+
+	price := func(adjustment float64) *PricingResult {
+		now := time.Now()
+		result, err := svc.CalculatePremium(ctx, &PricingRequest{
+			ProductID:        product.ID,
+			UserID:           user.ID,
+			CoverageAmount:   100000,
+			Currency:         "USD",
+			PaymentFrequency: "annually",
+			EffectiveDate:    now,
+			ExpirationDate:   now.AddDate(1, 0, 0),
+			RiskProfile:      &RiskProfile{OverallScore: 30, RiskLevel: "medium", PremiumAdjustment: adjustment},
+		})
+		require.NoError(t, err)
+		return result
+	}
+	neutral := price(0)
+	loaded := price(10)
+
+	var riskFactors []PricingFactor
+	for _, factor := range loaded.Factors {
+		if factor.Factor == "risk_assessment" {
+			riskFactors = append(riskFactors, factor)
+		}
+	}
+	require.Len(t, riskFactors, 1)
+	assert.InDelta(t, loaded.BasePremium*0.10, riskFactors[0].Value, 0.001)
+	assert.InDelta(t, loaded.BasePremium*0.10, loaded.FinalPremium-neutral.FinalPremium, 0.01)
+}
@@ -0,0 +1,197 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+)
+
+// ErrPremiumAdjustmentOutOfRange is returned when pricing adjustments move the premium
+// outside the configured range relative to the base premium.
+var ErrPremiumAdjustmentOutOfRange = errors.New("premium adjustment out of range")
+
+// ErrPricingBreakdownMismatch is returned when a pricing breakdown does not reconcile
+// to the final premium.
+var ErrPricingBreakdownMismatch = errors.New("pricing breakdown does not reconcile to final premium")
+
+// ErrDeductibleNotAllowed is returned when a requested deductible is not offered for the
+// product category and coverage tier.
+var ErrDeductibleNotAllowed = errors.New("deductible not allowed")
+
+// DefaultCoverageTier is the coverage tier priced when a request names none.
+const DefaultCoverageTier = "standard"
+
+// Premium rounding policies for PricingValidationRules.RoundingPolicy.
+const (
+	PremiumRoundingNearestCent   = "nearest_cent"
+	PremiumRoundingNearestDollar = "nearest_dollar"
+	// PremiumRoundingUp rounds up to the next whole currency unit.
+	PremiumRoundingUp = "round_up"
+)
+
+// enforceAdjustmentBounds checks that the adjusted premium stays within MinAdjustment and
+// MaxAdjustment times the base premium. Out-of-range premiums are capped to the nearest
+// bound when CapAdjustments is set and rejected with ErrPremiumAdjustmentOutOfRange
+// otherwise. Zero bounds are not enforced.
+func (s *PricingEngineService) enforceAdjustmentBounds(result *PricingResult, rules config.PricingValidationRules) error {
+	if result.BasePremium <= 0 {
+		return nil
+	}
+
+	ratio := result.AdjustedPremium / result.BasePremium
+	bound := 0.0
+	switch {
+	case rules.MaxAdjustment > 0 && ratio > rules.MaxAdjustment:
+		bound = rules.MaxAdjustment
+	case rules.MinAdjustment > 0 && ratio < rules.MinAdjustment:
+		bound = rules.MinAdjustment
+	default:
+		return nil
+	}
+
+	if !rules.CapAdjustments {
+		return fmt.Errorf("%w: adjusted premium %.2f is %.2fx the base premium %.2f, allowed range is %.2fx to %.2fx",
+			ErrPremiumAdjustmentOutOfRange, result.AdjustedPremium, ratio, result.BasePremium, rules.MinAdjustment, rules.MaxAdjustment)
+	}
+
+	result.Metadata["adjustment_cap_applied"] = bound
+	result.AdjustedPremium = result.BasePremium * bound
+	return nil
+}
+
+// RiskScoreFactor is the PricingRequest.RiskFactors key holding the applicant's risk score
+// (0-100, higher means higher risk) used to compute the technical premium.
+const RiskScoreFactor = "risk_score"
+
+// technicalPremium returns the minimum technical premium for a request: the expected loss
+// implied by its risk score, loaded for expenses. The score of the request's risk profile
+// takes precedence over the RiskScoreFactor risk factor. It returns false when the rules
+// are disabled or the request carries no risk score.
+func technicalPremium(request *PricingRequest, rules config.TechnicalPremiumRules) (float64, bool) {
+	if !rules.Enabled {
+		return 0, false
+	}
+	score, ok := request.RiskFactors[RiskScoreFactor].(float64)
+	if request.RiskProfile != nil {
+		score, ok = request.RiskProfile.OverallScore, true
+	}
+	if !ok {
+		return 0, false
+	}
+	score = math.Min(math.Max(score, 0), 100)
+
+	lossProbability := rules.MinLossProbability + (rules.MaxLossProbability-rules.MinLossProbability)*score/100
+	exposure := math.Max(request.CoverageAmount-request.Deductible, 0)
+	return lossProbability * exposure * (1 + rules.ExpenseLoading), true
+}
+
+// applyPremiumRules raises the final premium to the configured minimum and to the technical
+// premium, caps it at the configured maximum and rounds it according to the rounding
+// policy. Zero bounds are not enforced, and the maximum wins over both floors. Any floor or
+// cap applied is recorded in the result metadata and the rounding difference in the breakdown.
+func (s *PricingEngineService) applyPremiumRules(result *PricingResult, rules config.PricingValidationRules, technicalFloor float64) float64 {
+	premium := result.FinalPremium
+
+	if rules.MinPremium > 0 && premium < rules.MinPremium {
+		result.Metadata["premium_floor_applied"] = rules.MinPremium
+		premium = rules.MinPremium
+	}
+	if technicalFloor > 0 && premium < technicalFloor {
+		result.Metadata["technical_floor_applied"] = technicalFloor
+		premium = technicalFloor
+	}
+	if rules.MaxPremium > 0 && premium > rules.MaxPremium {
+		result.Metadata["premium_cap_applied"] = rules.MaxPremium
+		premium = rules.MaxPremium
+	}
+
+	result.Metadata["rounding_policy"] = rules.RoundingPolicy
+	rounded := roundPremium(premium, rules.RoundingPolicy)
+	result.Breakdown.RoundingAdjustment = rounded - premium
+	return rounded
+}
+
+// roundPremium rounds a premium according to a rounding policy. Unknown or empty
+// policies round to the nearest cent.
+func roundPremium(premium float64, policy string) float64 {
+	switch policy {
+	case PremiumRoundingNearestDollar:
+		return math.Round(premium)
+	case PremiumRoundingUp:
+		// Round to the cent first so float noise such as 100.0000001 does not bump a whole unit.
+		return math.Ceil(roundCurrency(premium))
+	default:
+		return roundCurrency(premium)
+	}
+}
+
+// validateDeductible returns ErrDeductibleNotAllowed when the request's deductible is not in
+// the deductible matrix for the product's category and the requested coverage tier.
+func (s *PricingEngineService) validateDeductible(product *models.Product, request *PricingRequest) error {
+	if request.Deductible < 0 {
+		return fmt.Errorf("deductible cannot be negative")
+	}
+	if request.Deductible == 0 {
+		return nil
+	}
+
+	tiers, ok := s.configManager.GetConfig().Pricing.Deductibles[product.Category]
+	if !ok {
+		return nil
+	}
+	tier := coverageTier(request)
+	allowed, ok := tiers[tier]
+	if !ok {
+		return fmt.Errorf("unknown coverage tier %q for %s products", tier, product.Category)
+	}
+
+	options := make([]string, len(allowed))
+	for i, deductible := range allowed {
+		if deductible == request.Deductible {
+			return nil
+		}
+		options[i] = fmt.Sprintf("%.2f", deductible)
+	}
+	return fmt.Errorf("%w: %.2f is not offered for %s %s coverage, valid options are %s",
+		ErrDeductibleNotAllowed, request.Deductible, tier, product.Category, strings.Join(options, ", "))
+}
+
+// coverageTier returns the request's coverage tier, or DefaultCoverageTier when it names none.
+func coverageTier(request *PricingRequest) string {
+	if request.CoverageTier == "" {
+		return DefaultCoverageTier
+	}
+	return request.CoverageTier
+}
+
+// calculateDeductibleFactor calculates the credit for the request's deductible. Like the
+// no-claims bonus it is a share of the base premium, growing with the deductible's share
+// of the coverage amount up to the configured maximum.
+func (s *PricingEngineService) calculateDeductibleFactor(request *PricingRequest, basePremium float64) PricingFactor {
+	factor := PricingFactor{
+		Factor: "deductible",
+		Type:   "discount",
+		Impact: "neutral",
+	}
+
+	rules := s.configManager.GetConfig().Pricing.DeductibleCredits
+	if request.Deductible <= 0 || request.CoverageAmount <= 0 || rules.CreditRate <= 0 {
+		factor.Description = "No deductible credit"
+		return factor
+	}
+
+	credit := math.Min(rules.CreditRate*request.Deductible/request.CoverageAmount, 1)
+	if rules.MaxCredit > 0 {
+		credit = math.Min(credit, rules.MaxCredit)
+	}
+
+	factor.Value = basePremium * -credit
+	factor.Description = fmt.Sprintf("Deductible credit (%.2f deductible, %.1f%%)", request.Deductible, credit*100)
+	factor.Impact = "negative"
+
+	return factor
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -10,6 +11,10 @@ import (
 	"github.com/google/uuid"
 )
 
+// ErrCoverageOutOfRange is returned when a requested coverage amount is outside the product's
+// minimum and maximum coverage.
+var ErrCoverageOutOfRange = errors.New("coverage amount out of range")
+
 // ProductService handles business logic for products.
 type ProductService struct {
 	store store.ProductStore
@@ -43,6 +48,9 @@ func (s *ProductService) CreateProduct(ctx context.Context, product *models.Prod
 	if product.CoveragePeriod <= 0 {
 		return fmt.Errorf("coverage period must be greater than 0")
 	}
+	if err := validateCoverageLimits(product); err != nil {
+		return err
+	}
 
 	// Set defaults
 	if product.Currency == "" {
@@ -147,6 +155,9 @@ func (s *ProductService) UpdateProduct(ctx context.Context, product *models.Prod
 	if product.BasePrice <= 0 {
 		return fmt.Errorf("base price must be greater than 0")
 	}
+	if err := validateCoverageLimits(product); err != nil {
+		return err
+	}
 
 	// Get existing product to validate changes
 	existing, err := s.store.GetProduct(ctx, product.ID)
@@ -184,3 +195,26 @@ func (s *ProductService) CountProducts(ctx context.Context, opts *models.Product
 	}
 	return s.store.CountProducts(ctx, opts.PartnerID, opts.Category)
 }
+
+// validateCoverageLimits checks that a product's coverage limits are consistent.
+func validateCoverageLimits(product *models.Product) error {
+	if product.MinCoverageAmount < 0 || product.MaxCoverageAmount < 0 {
+		return fmt.Errorf("coverage limits cannot be negative")
+	}
+	if product.MaxCoverageAmount > 0 && product.MinCoverageAmount > product.MaxCoverageAmount {
+		return fmt.Errorf("minimum coverage cannot exceed maximum coverage")
+	}
+	return nil
+}
+
+// checkCoverageAmount returns ErrCoverageOutOfRange when a coverage amount is outside the
+// product's limits.
+func checkCoverageAmount(product *models.Product, amount float64) error {
+	if product.CoverageWithinLimits(amount) {
+		return nil
+	}
+	if product.MinCoverageAmount > 0 && amount < product.MinCoverageAmount {
+		return fmt.Errorf("%w: %.2f is below the %s minimum of %.2f", ErrCoverageOutOfRange, amount, product.Name, product.MinCoverageAmount)
+	}
+	return fmt.Errorf("%w: %.2f is above the %s maximum of %.2f", ErrCoverageOutOfRange, amount, product.Name, product.MaxCoverageAmount)
+}
@@ -15,8 +15,9 @@ import (
 
 // QuoteService handles business logic for quotes.
 type QuoteService struct {
-	store        store.QuoteStore
-	eventService *EventService
+	store          store.QuoteStore
+	eventService   *EventService
+	pricingService *PricingEngineService
 }
 
 // NewQuoteService creates a new QuoteService instance.
@@ -31,6 +32,11 @@ func NewQuoteService(store store.QuoteStore, eventService ...*EventService) *Quo
 	}
 }
 
+// SetPricingService sets the pricing engine quotes with a coverage amount are priced with.
+func (s *QuoteService) SetPricingService(pricingService *PricingEngineService) {
+	s.pricingService = pricingService
+}
+
 // CreateQuote creates a new quote with business logic validation.
 func (s *QuoteService) CreateQuote(ctx context.Context, quote *models.Quote) error {
 	// Validate required fields
@@ -239,6 +245,12 @@ func (s *QuoteService) ExpireQuote(ctx context.Context, id uuid.UUID) error {
 	return s.store.ExpireQuote(ctx, id)
 }
 
+// GetActiveRenewalOffer retrieves a policy's renewal offer quote that is still valid,
+// or nil if it has none.
+func (s *QuoteService) GetActiveRenewalOffer(ctx context.Context, policyID uuid.UUID) (*models.Quote, error) {
+	return s.store.GetActiveRenewalOffer(ctx, policyID, time.Now())
+}
+
 // CountQuotes returns the total number of quotes with filtering.
 func (s *QuoteService) CountQuotes(ctx context.Context, opts *models.QuoteListOptions) (int64, error) {
 	if opts == nil {
@@ -284,8 +296,36 @@ func (s *QuoteService) CalculatePremium(ctx context.Context, quoteID uuid.UUID)
 	return quote, nil
 }
 
-// calculatePremium performs the actual premium calculation using risk assessment
+// calculatePremium performs the actual premium calculation using risk assessment. A quote
+// with a coverage amount is priced by the pricing engine for an annual term starting now,
+// and is stamped with the rate table version and pricing breakdown it was priced from.
 func (s *QuoteService) calculatePremium(ctx context.Context, quote *models.Quote) (float64, error) {
+	if s.pricingService != nil && quote.CoverageAmount > 0 {
+		now := time.Now()
+		pricing, err := s.pricingService.CalculatePremium(ctx, &PricingRequest{
+			ProductID:        quote.ProductID,
+			UserID:           quote.UserID,
+			CoverageAmount:   quote.CoverageAmount,
+			CoverageTier:     quote.CoverageTier,
+			Deductible:       quote.Deductible,
+			Currency:         quote.Currency,
+			PaymentFrequency: "annually",
+			EffectiveDate:    now,
+			ExpirationDate:   now.AddDate(1, 0, 0),
+		})
+		if err != nil {
+			return 0, err
+		}
+		quote.RateTableVersion = pricing.RateTableVersion
+		breakdown := pricing.Breakdown
+		quote.PricingBreakdown = &breakdown
+		return roundCurrency(pricing.FinalPremium), nil
+	}
+
+	// Quotes priced without the engine do not use a rate table or carry a breakdown
+	quote.RateTableVersion = ""
+	quote.PricingBreakdown = nil
+
 	// Base premium calculation
 	basePremium := s.calculateBasePremium(quote)
 
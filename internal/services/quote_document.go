@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/edsonmichaque/bazaruto/pkg/pdf"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// DocumentBrand is the brand name printed in the header of generated documents.
+const DocumentBrand = "Bazaruto Insurance"
+
+// pdfContentType is the content type of generated PDF documents.
+const pdfContentType = "application/pdf"
+
+// GeneratedDocument describes a rendered document stored in the blob store.
+type GeneratedDocument struct {
+	Key         string `json:"key"`
+	URL         string `json:"url"`
+	ContentType string `json:"content_type"`
+	Size        int    `json:"size"`
+}
+
+// QuoteDocumentService renders quotes to PDF and stores them for download.
+type QuoteDocumentService struct {
+	quoteService *QuoteService
+	blobStore    BlobStore
+	logger       *logger.Logger
+}
+
+// NewQuoteDocumentService creates a new QuoteDocumentService instance.
+func NewQuoteDocumentService(logger *logger.Logger, quoteService *QuoteService, blobStore BlobStore) *QuoteDocumentService {
+	return &QuoteDocumentService{
+		quoteService: quoteService,
+		blobStore:    blobStore,
+		logger:       logger,
+	}
+}
+
+// GenerateQuotePDF renders a quote's pricing breakdown to PDF, stores it and records the
+// download URL on the quote. When result is nil the pricing stored on the quote is used.
+func (s *QuoteDocumentService) GenerateQuotePDF(ctx context.Context, quoteID uuid.UUID, result *PricingResult) (*GeneratedDocument, error) {
+	quote, err := s.quoteService.GetQuote(ctx, quoteID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quote: %w", err)
+	}
+
+	if result == nil {
+		result = pricingResultFromQuote(quote)
+	}
+
+	data, err := RenderQuotePDF(quote, result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render quote PDF: %w", err)
+	}
+
+	key := fmt.Sprintf("quotes/%s/%s.pdf", quote.ID, quote.QuoteNumber)
+	url, err := s.blobStore.Put(ctx, key, pdfContentType, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store quote PDF: %w", err)
+	}
+
+	quote.DocumentURL = url
+	if err := s.quoteService.UpdateQuote(ctx, quote); err != nil {
+		return nil, fmt.Errorf("failed to record quote PDF: %w", err)
+	}
+
+	s.logger.Info("Quote PDF generated",
+		zap.String("quote_id", quote.ID.String()),
+		zap.String("url", url),
+		zap.Int("size", len(data)))
+
+	return &GeneratedDocument{
+		Key:         key,
+		URL:         url,
+		ContentType: pdfContentType,
+		Size:        len(data),
+	}, nil
+}
+
+// RenderQuotePDF renders a quote and its pricing result to a PDF document listing the base
+// premium, each adjustment, each pricing factor, the final premium and the quote's validity.
+func RenderQuotePDF(quote *models.Quote, result *PricingResult) ([]byte, error) {
+	currency := result.Currency
+	if currency == "" {
+		currency = quote.Currency
+	}
+	validUntil := result.ValidUntil
+	if validUntil.IsZero() {
+		validUntil = quote.ValidUntil
+	}
+	issued := quote.CreatedAt
+	if issued.IsZero() {
+		issued = time.Now()
+	}
+
+	doc := pdf.New(DocumentBrand, "Insurance Quote")
+	doc.Row("Quote number", quote.QuoteNumber)
+	doc.Row("Issued", issued.Format("2006-01-02"))
+	doc.Row("Valid until", validUntil.Format("2006-01-02"))
+
+	doc.Heading("Premium breakdown")
+	doc.Row("Base premium", formatMoney(result.BasePremium, currency))
+	breakdown := result.Breakdown
+	for _, line := range []struct {
+		label  string
+		amount float64
+	}{
+		{"Coverage adjustment", breakdown.CoverageAdjustment},
+		{"Risk adjustment", breakdown.RiskAdjustment},
+		{"Discounts", breakdown.DiscountAdjustment},
+		{"Payment frequency", breakdown.FrequencyAdjustment},
+		{"Market adjustment", breakdown.MarketAdjustment},
+		{"Taxes", breakdown.TaxAdjustment},
+		{"Premium limits", breakdown.BoundsAdjustment},
+		{"Rounding", breakdown.RoundingAdjustment},
+	} {
+		if line.amount != 0 {
+			doc.Row(line.label, formatMoney(line.amount, currency))
+		}
+	}
+	doc.TotalRow("Final premium", formatMoney(result.FinalPremium, currency))
+
+	doc.Heading("Pricing factors")
+	for _, factor := range result.Factors {
+		doc.Row(factorLabel(factor.Factor), fmt.Sprintf("%s %.2f", factor.Type, factor.Value))
+	}
+
+	doc.Heading("Validity")
+	doc.Text(fmt.Sprintf("This quote is valid until %s. The premium may change if the information "+
+		"it is based on changes before the policy is issued.", validUntil.Format("2006-01-02")))
+
+	return doc.Bytes()
+}
+
+// pricingResultFromQuote rebuilds the pricing a stored quote was issued with.
+func pricingResultFromQuote(quote *models.Quote) *PricingResult {
+	result := &PricingResult{
+		BasePremium:     quote.BasePrice,
+		AdjustedPremium: quote.FinalPrice,
+		FinalPremium:    quote.FinalPrice,
+		Currency:        quote.Currency,
+		ValidUntil:      quote.ValidUntil,
+		QuoteID:         &quote.ID,
+		Breakdown: PricingBreakdown{
+			BaseRate:           quote.BasePrice,
+			DiscountAdjustment: -quote.Discount,
+			TaxAdjustment:      quote.Tax,
+		},
+	}
+	for _, riskFactor := range quote.RiskFactors {
+		result.Factors = append(result.Factors, PricingFactor{
+			Factor:      riskFactor.Factor,
+			Type:        "rate",
+			Value:       riskFactor.Impact,
+			Description: riskFactor.Value,
+		})
+	}
+	return result
+}
+
+// formatMoney formats an amount with its currency for documents.
+func formatMoney(amount float64, currency string) string {
+	return fmt.Sprintf("%.2f %s", roundCurrency(amount), currency)
+}
+
+// factorLabel turns a factor name such as "payment_frequency" into "Payment frequency".
+func factorLabel(name string) string {
+	label := strings.ReplaceAll(name, "_", " ")
+	if label == "" {
+		return label
+	}
+	return strings.ToUpper(label[:1]) + label[1:]
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/edsonmichaque/bazaruto/pkg/pdf"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateQuotePDFRendersPricingBreakdown(t *testing.T) {
+	ctx := context.Background()
+	quoteService := NewQuoteService(newFakeQuoteStore())
+	blobStore := newFakeBlobStore()
+	documents := NewQuoteDocumentService(logger.NewLogger("error", "json"), quoteService, blobStore)
+
+	quote := &models.Quote{
+		ProductID:  uuid.New(),
+		UserID:     uuid.New(),
+		BasePrice:  1000,
+		FinalPrice: 1234.56,
+		Currency:   "USD",
+	}
+	require.NoError(t, quoteService.CreateQuote(ctx, quote))
+
+	result := &PricingResult{
+		BasePremium:  1000,
+		FinalPremium: 1234.56,
+		Currency:     "USD",
+		ValidUntil:   time.Date(2026, 11, 16, 0, 0, 0, 0, time.UTC),
+		Breakdown: PricingBreakdown{
+			BaseRate:       1000,
+			RiskAdjustment: 150,
+			TaxAdjustment:  84.56,
+		},
+		Factors: []PricingFactor{
+			{Factor: "coverage_amount", Type: "rate", Value: 1},
+			{Factor: "risk_assessment", Type: "rate", Value: 1.15},
+			{Factor: "taxes", Type: "tax", Value: 84.56},
+		},
+	}
+
+	document, err := documents.GenerateQuotePDF(ctx, quote.ID, result)
+	require.NoError(t, err)
+	assert.Equal(t, "https://files.test/"+document.Key, document.URL)
+
+	stored, err := quoteService.GetQuote(ctx, quote.ID)
+	require.NoError(t, err)
+	assert.Equal(t, document.URL, stored.DocumentURL)
+
+	data, err := blobStore.Get(ctx, document.Key)
+	require.NoError(t, err)
+	lines, err := pdf.ExtractText(data)
+	require.NoError(t, err)
+
+	assert.Contains(t, lines, "Final premium 1234.56 USD")
+	assert.Contains(t, lines, "Valid until 2026-11-16")
+	assert.Len(t, sectionLines(lines, "Pricing factors", "Validity"), len(result.Factors))
+}
+
+// sectionLines returns the lines between a heading and the next heading.
+func sectionLines(lines []string, heading, nextHeading string) []string {
+	var section []string
+	inSection := false
+	for _, line := range lines {
+		switch {
+		case line == heading:
+			inSection = true
+		case line == nextHeading:
+			return section
+		case inSection:
+			section = append(section, line)
+		}
+	}
+	return section
+}
@@ -0,0 +1,37 @@
+package services
+
+import (
+	"hash/fnv"
+
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/google/uuid"
+)
+
+// SelectRateTable returns the rate table version used to price quotes for a user and the
+// table itself. Explicit assignments win over the percentage rollout, which buckets users
+// by a stable hash of their ID so a user keeps the same version across quotes. An empty
+// version means no configured table applies and the built-in rates are used.
+func (s *PricingEngineService) SelectRateTable(userID uuid.UUID) (string, config.RateTable) {
+	pricing := s.configManager.GetConfig().Pricing
+	rollout := pricing.RateTableRollout
+
+	version := rollout.Control
+	if assigned, ok := rollout.Assignments[userID.String()]; ok {
+		version = assigned
+	} else if rollout.Variant != "" && rateTableBucket(userID) < rollout.VariantPercentage {
+		version = rollout.Variant
+	}
+
+	table, ok := pricing.RateTables[version]
+	if !ok {
+		return "", nil
+	}
+	return version, table
+}
+
+// rateTableBucket maps a user ID to a stable bucket in [0, 100).
+func rateTableBucket(userID uuid.UUID) int {
+	h := fnv.New32a()
+	_, _ = h.Write(userID[:])
+	return int(h.Sum32() % 100)
+}
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/events"
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/edsonmichaque/bazaruto/internal/store"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// RecoveryService tracks subrogation and salvage recoveries against settled claims.
+type RecoveryService struct {
+	recoveryStore store.ClaimRecoveryStore
+	claimStore    store.ClaimStore
+	eventService  *EventService
+	logger        *logger.Logger
+}
+
+// NewRecoveryService creates a new RecoveryService instance.
+func NewRecoveryService(
+	logger *logger.Logger,
+	recoveryStore store.ClaimRecoveryStore,
+	claimStore store.ClaimStore,
+	eventService *EventService,
+) *RecoveryService {
+	return &RecoveryService{
+		recoveryStore: recoveryStore,
+		claimStore:    claimStore,
+		eventService:  eventService,
+		logger:        logger,
+	}
+}
+
+// NetClaimCost represents the cost of a claim after post-payout recoveries.
+type NetClaimCost struct {
+	ClaimID             uuid.UUID `json:"claim_id"`
+	PaidAmount          float64   `json:"paid_amount"`
+	ExpectedRecoveries  float64   `json:"expected_recoveries"`
+	RecoveredAmount     float64   `json:"recovered_amount"`
+	OutstandingRecovery float64   `json:"outstanding_recovery"`
+	NetCost             float64   `json:"net_cost"`
+	Currency            string    `json:"currency"`
+}
+
+// RecordExpectedRecovery registers an expected subrogation or salvage recovery against a settled claim.
+func (s *RecoveryService) RecordExpectedRecovery(ctx context.Context, claimID uuid.UUID, recoveryType string, expectedAmount float64, counterparty string) (*models.ClaimRecovery, error) {
+	if recoveryType != models.RecoveryTypeSubrogation && recoveryType != models.RecoveryTypeSalvage {
+		return nil, fmt.Errorf("invalid recovery type: %s", recoveryType)
+	}
+	if expectedAmount <= 0 {
+		return nil, fmt.Errorf("expected recovery amount must be greater than 0")
+	}
+
+	claim, err := s.claimStore.GetClaim(ctx, claimID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch claim: %w", err)
+	}
+
+	if claim.Status != models.ClaimStatusPaid {
+		return nil, fmt.Errorf("recoveries can only be recorded against settled claims")
+	}
+
+	recovery := &models.ClaimRecovery{
+		ClaimID:        claimID,
+		RecoveryType:   recoveryType,
+		ExpectedAmount: expectedAmount,
+		Currency:       claim.Currency,
+		Status:         models.RecoveryStatusExpected,
+		Counterparty:   counterparty,
+	}
+
+	if err := s.recoveryStore.CreateRecovery(ctx, recovery); err != nil {
+		return nil, fmt.Errorf("failed to create recovery: %w", err)
+	}
+
+	return recovery, nil
+}
+
+// RecordRecoveryReceived records an amount actually received against an expected recovery.
+// Partial receipts accumulate until the expected amount is met.
+func (s *RecoveryService) RecordRecoveryReceived(ctx context.Context, recoveryID uuid.UUID, amount float64) (*models.ClaimRecovery, error) {
+	if amount <= 0 {
+		return nil, fmt.Errorf("recovered amount must be greater than 0")
+	}
+
+	recovery, err := s.recoveryStore.GetRecovery(ctx, recoveryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch recovery: %w", err)
+	}
+
+	if recovery.Status == models.RecoveryStatusWrittenOff {
+		return nil, fmt.Errorf("recovery has been written off")
+	}
+
+	now := time.Now()
+	recovery.RecoveredAmount += amount
+	recovery.LastRecoveredAt = &now
+	if recovery.RecoveredAmount >= recovery.ExpectedAmount {
+		recovery.Status = models.RecoveryStatusRecovered
+	} else {
+		recovery.Status = models.RecoveryStatusPartial
+	}
+
+	if err := s.recoveryStore.UpdateRecovery(ctx, recovery); err != nil {
+		return nil, fmt.Errorf("failed to update recovery: %w", err)
+	}
+
+	if s.eventService != nil {
+		recoveryEvent := events.NewClaimRecoveryReceivedEvent(
+			recovery.ClaimID,
+			recovery.ID,
+			recovery.RecoveryType,
+			amount,
+			recovery.RecoveredAmount,
+			recovery.Currency,
+			recovery.Status,
+			now,
+		)
+
+		if err := s.eventService.PublishEvent(ctx, recoveryEvent); err != nil {
+			s.logger.Error("Failed to publish claim recovery received event",
+				zap.Error(err),
+				zap.String("recovery_id", recoveryID.String()))
+		}
+	}
+
+	return recovery, nil
+}
+
+// WriteOffRecovery marks the outstanding portion of a recovery as unrecoverable.
+func (s *RecoveryService) WriteOffRecovery(ctx context.Context, recoveryID uuid.UUID, notes string) (*models.ClaimRecovery, error) {
+	recovery, err := s.recoveryStore.GetRecovery(ctx, recoveryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch recovery: %w", err)
+	}
+
+	recovery.Status = models.RecoveryStatusWrittenOff
+	recovery.Notes = notes
+
+	if err := s.recoveryStore.UpdateRecovery(ctx, recovery); err != nil {
+		return nil, fmt.Errorf("failed to update recovery: %w", err)
+	}
+
+	return recovery, nil
+}
+
+// CalculateNetClaimCost computes the paid amount of a claim less recoveries received.
+func (s *RecoveryService) CalculateNetClaimCost(ctx context.Context, claimID uuid.UUID) (*NetClaimCost, error) {
+	claim, err := s.claimStore.GetClaim(ctx, claimID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch claim: %w", err)
+	}
+
+	recoveries, err := s.recoveryStore.ListRecoveriesByClaim(ctx, claimID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recoveries: %w", err)
+	}
+
+	cost := &NetClaimCost{
+		ClaimID:    claimID,
+		PaidAmount: claim.PaidAmount,
+		Currency:   claim.Currency,
+	}
+
+	for _, recovery := range recoveries {
+		cost.ExpectedRecoveries += recovery.ExpectedAmount
+		cost.RecoveredAmount += recovery.RecoveredAmount
+		if recovery.Status == models.RecoveryStatusExpected || recovery.Status == models.RecoveryStatusPartial {
+			cost.OutstandingRecovery += recovery.ExpectedAmount - recovery.RecoveredAmount
+		}
+	}
+
+	cost.NetCost = cost.PaidAmount - cost.RecoveredAmount
+	if cost.NetCost < 0 {
+		cost.NetCost = 0
+	}
+
+	return cost, nil
+}
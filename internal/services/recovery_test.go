@@ -0,0 +1,37 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetClaimCostAfterPartialSubrogation(t *testing.T) {
+	ctx := context.Background()
+	claim := &models.Claim{ClaimAmount: 12000, PaidAmount: 10000, Currency: "USD", Status: models.ClaimStatusPaid}
+	svc := NewRecoveryService(logger.NewLogger("error", "json"), newFakeClaimRecoveryStore(), newFakeClaimStore(claim), nil)
+
+	recovery, err := svc.RecordExpectedRecovery(ctx, claim.ID, models.RecoveryTypeSubrogation, 4000, "Third-party insurer")
+	require.NoError(t, err)
+
+	recovery, err = svc.RecordRecoveryReceived(ctx, recovery.ID, 1500)
+	require.NoError(t, err)
+	assert.Equal(t, models.RecoveryStatusPartial, recovery.Status)
+
+	cost, err := svc.CalculateNetClaimCost(ctx, claim.ID)
+	require.NoError(t, err)
+	assert.InDelta(t, 8500, cost.NetCost, 0.001)
+	assert.InDelta(t, 2500, cost.OutstandingRecovery, 0.001)
+}
+
+func TestRecoveryRequiresSettledClaim(t *testing.T) {
+	claim := &models.Claim{ClaimAmount: 5000, Status: models.ClaimStatusApproved}
+	svc := NewRecoveryService(logger.NewLogger("error", "json"), newFakeClaimRecoveryStore(), newFakeClaimStore(claim), nil)
+
+	_, err := svc.RecordExpectedRecovery(context.Background(), claim.ID, models.RecoveryTypeSalvage, 1000, "")
+	assert.Error(t, err)
+}
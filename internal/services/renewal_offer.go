@@ -0,0 +1,284 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// defaultRenewalOfferValidityDays is used when RenewalRules.OfferValidityDays is not set.
+const defaultRenewalOfferValidityDays = 30
+
+// ErrRenewalOfferUnavailable is returned when accepting a quote that is not a renewal offer
+// or that is no longer valid.
+var ErrRenewalOfferUnavailable = errors.New("renewal offer is not available")
+
+// RenewalOffer is a priced renewal term, stored as a quote, that the customer can accept.
+type RenewalOffer struct {
+	QuoteID        uuid.UUID `json:"quote_id"`
+	QuoteNumber    string    `json:"quote_number"`
+	PolicyID       uuid.UUID `json:"policy_id"`
+	Premium        float64   `json:"premium"`
+	Currency       string    `json:"currency"`
+	EffectiveDate  time.Time `json:"effective_date"`
+	ExpirationDate time.Time `json:"expiration_date"`
+	ValidUntil     time.Time `json:"valid_until"`
+	AcceptURL      string    `json:"accept_url"`
+}
+
+// GenerateRenewalOffer prices the next term of a policy and locks the price by storing
+// it as an active quote. The quote stays valid for RenewalRules.OfferValidityDays, but
+// never past the current term's expiration date.
+func (s *PolicyLifecycleService) GenerateRenewalOffer(ctx context.Context, policyID uuid.UUID) (*RenewalOffer, error) {
+	if s.quoteService == nil {
+		return nil, fmt.Errorf("quote service is not configured")
+	}
+
+	policy, err := s.policyStore.GetPolicy(ctx, policyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get policy: %w", err)
+	}
+
+	if err := s.validateRenewalEligibility(ctx, policy); err != nil {
+		return nil, fmt.Errorf("policy not eligible for renewal: %w", err)
+	}
+
+	options := s.getDefaultRenewalOptions(policy)
+	pricing, err := s.calculateRenewalPremium(ctx, policy, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate renewal premium: %w", err)
+	}
+
+	validityDays := s.configManager.GetConfig().PolicyLifecycle.RenewalRules.OfferValidityDays
+	if validityDays <= 0 {
+		validityDays = defaultRenewalOfferValidityDays
+	}
+	validUntil := time.Now().AddDate(0, 0, validityDays)
+	if validUntil.After(policy.ExpirationDate) {
+		validUntil = policy.ExpirationDate
+	}
+
+	quote := &models.Quote{
+		ProductID:         policy.ProductID,
+		UserID:            policy.UserID,
+		BasePrice:         pricing.Premium,
+		FinalPrice:        pricing.Premium,
+		Currency:          policy.Currency,
+		Status:            models.QuoteStatusActive,
+		ValidUntil:        validUntil,
+		RenewalOfPolicyID: &policy.ID,
+		RateTableVersion:  pricing.RateTableVersion,
+		PricingBreakdown:  pricing.Breakdown,
+		CoverageAmount:    options.CoverageAmount,
+		CoverageTier:      policy.CoverageTier,
+		Deductible:        policy.Deductible,
+	}
+	if err := s.quoteService.CreateQuote(ctx, quote); err != nil {
+		return nil, fmt.Errorf("failed to store renewal offer: %w", err)
+	}
+
+	s.logger.Info("Renewal offer generated",
+		zap.String("policy_id", policy.ID.String()),
+		zap.String("quote_id", quote.ID.String()),
+		zap.Float64("premium", pricing.Premium))
+
+	return newRenewalOffer(quote, options), nil
+}
+
+// newRenewalOffer describes a stored renewal offer quote for the term in options.
+func newRenewalOffer(quote *models.Quote, options *RenewalOptions) *RenewalOffer {
+	return &RenewalOffer{
+		QuoteID:        quote.ID,
+		QuoteNumber:    quote.QuoteNumber,
+		PolicyID:       *quote.RenewalOfPolicyID,
+		Premium:        quote.FinalPrice,
+		Currency:       quote.Currency,
+		EffectiveDate:  options.EffectiveDate,
+		ExpirationDate: options.ExpirationDate,
+		ValidUntil:     quote.ValidUntil,
+		AcceptURL:      fmt.Sprintf("/v1/quotes/%s/accept", quote.ID),
+	}
+}
+
+// currentRenewalOffer returns the policy's renewal offer that is still valid, generating
+// one only when the policy has none, so repeated reminders quote the same locked price.
+func (s *PolicyLifecycleService) currentRenewalOffer(ctx context.Context, policy *models.Policy) (*RenewalOffer, error) {
+	existing, err := s.quoteService.GetActiveRenewalOffer(ctx, policy.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return newRenewalOffer(existing, s.getDefaultRenewalOptions(policy)), nil
+	}
+	return s.GenerateRenewalOffer(ctx, policy.ID)
+}
+
+// matchingRenewalOffer returns the policy's valid renewal offer when it was priced for the
+// term in options, or nil when the term must be priced afresh.
+func (s *PolicyLifecycleService) matchingRenewalOffer(ctx context.Context, policy *models.Policy, options *RenewalOptions) (*models.Quote, error) {
+	if s.quoteService == nil {
+		return nil, nil
+	}
+	offer, err := s.quoteService.GetActiveRenewalOffer(ctx, policy.ID)
+	if err != nil || offer == nil {
+		return nil, err
+	}
+
+	// Offers are priced for the default renewal term of the policy.
+	offered := s.getDefaultRenewalOptions(policy)
+	if offer.CoverageAmount != options.CoverageAmount ||
+		offered.PaymentFrequency != options.PaymentFrequency ||
+		!offered.EffectiveDate.Equal(options.EffectiveDate) ||
+		!offered.ExpirationDate.Equal(options.ExpirationDate) {
+		return nil, nil
+	}
+	return offer, nil
+}
+
+// AcceptRenewalOffer renews a policy at the price locked by its renewal offer quote. The
+// renewal is paid with paymentMethod when one is given; otherwise it awaits payment.
+func (s *PolicyLifecycleService) AcceptRenewalOffer(ctx context.Context, quoteID uuid.UUID, paymentMethod string) (*RenewalResult, error) {
+	if s.quoteService == nil {
+		return nil, fmt.Errorf("quote service is not configured")
+	}
+
+	quote, err := s.quoteService.GetQuote(ctx, quoteID)
+	if err != nil {
+		return nil, err
+	}
+	if quote.RenewalOfPolicyID == nil || quote.Status != models.QuoteStatusActive || !quote.ValidUntil.After(time.Now()) {
+		return nil, ErrRenewalOfferUnavailable
+	}
+
+	policy, err := s.policyStore.GetPolicy(ctx, *quote.RenewalOfPolicyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch policy: %w", err)
+	}
+
+	options := s.getDefaultRenewalOptions(policy)
+	if quote.CoverageAmount > 0 {
+		options.CoverageAmount = quote.CoverageAmount
+	}
+	options.PaymentMethod = paymentMethod
+	return s.renewPolicy(ctx, policy, options, quote)
+}
+
+// RenewalOfferBatchResult is the outcome of generating a renewal offer for a single policy.
+type RenewalOfferBatchResult struct {
+	PolicyID uuid.UUID     `json:"policy_id"`
+	Offer    *RenewalOffer `json:"offer,omitempty"`
+	// ExistingQuoteID is set when the policy was skipped because it already had a valid offer.
+	ExistingQuoteID *uuid.UUID `json:"existing_quote_id,omitempty"`
+	Error           string     `json:"error,omitempty"`
+}
+
+// RenewalOfferBatchReport aggregates the results of a batch renewal offer run.
+type RenewalOfferBatchReport struct {
+	Results   []RenewalOfferBatchResult `json:"results"` // in the order the policies were listed
+	Generated int                       `json:"generated"`
+	Skipped   int                       `json:"skipped"`
+	Failed    int                       `json:"failed"`
+}
+
+// GenerateRenewalOffersBatch generates renewal offers for the active policies expiring
+// within daysAhead using a worker pool sized by RenewalRules.OfferBatchConcurrency.
+// Policies that already have a valid offer are skipped, and a failure on one policy is
+// reported in its result without affecting the others. If the context is cancelled,
+// policies not yet processed are reported as failed and the context error is returned
+// with the report.
+func (s *PolicyLifecycleService) GenerateRenewalOffersBatch(ctx context.Context, daysAhead int) (*RenewalOfferBatchReport, error) {
+	if s.quoteService == nil {
+		return nil, fmt.Errorf("quote service is not configured")
+	}
+
+	policies, err := s.GetUpcomingRenewals(ctx, daysAhead)
+	if err != nil {
+		return nil, err
+	}
+
+	workers := s.configManager.GetConfig().PolicyLifecycle.RenewalRules.OfferBatchConcurrency
+	if workers <= 0 {
+		workers = config.DefaultRenewalOfferBatchConcurrency
+	}
+	if workers > len(policies) {
+		workers = len(policies)
+	}
+
+	results := make([]RenewalOfferBatchResult, len(policies))
+	jobs := make(chan int, len(policies))
+	for i := range policies {
+		jobs <- i
+	}
+	close(jobs)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = s.generateBatchRenewalOffer(ctx, policies[i].ID)
+			}
+		}()
+	}
+	wg.Wait()
+
+	report := &RenewalOfferBatchReport{Results: results}
+	for _, result := range results {
+		switch {
+		case result.Error != "":
+			report.Failed++
+		case result.ExistingQuoteID != nil:
+			report.Skipped++
+		default:
+			report.Generated++
+		}
+	}
+
+	s.logger.Info("Batch renewal offer generation completed",
+		zap.Int("policies", len(policies)),
+		zap.Int("generated", report.Generated),
+		zap.Int("skipped", report.Skipped),
+		zap.Int("failed", report.Failed))
+
+	return report, ctx.Err()
+}
+
+// generateBatchRenewalOffer generates one policy's offer of a batch unless it already has a
+// valid one, capturing any error in the result.
+func (s *PolicyLifecycleService) generateBatchRenewalOffer(ctx context.Context, policyID uuid.UUID) RenewalOfferBatchResult {
+	result := RenewalOfferBatchResult{PolicyID: policyID}
+	if err := ctx.Err(); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	existing, err := s.quoteService.GetActiveRenewalOffer(ctx, policyID)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	if existing != nil {
+		result.ExistingQuoteID = &existing.ID
+		return result
+	}
+
+	offer, err := s.GenerateRenewalOffer(ctx, policyID)
+	if err != nil {
+		s.logger.Warn("Batch renewal offer generation failed for policy",
+			zap.String("policy_id", policyID.String()),
+			zap.Error(err))
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Offer = offer
+	return result
+}
@@ -0,0 +1,216 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// concurrentQuoteStore records the peak number of quotes being created at once.
+type concurrentQuoteStore struct {
+	*fakeQuoteStore
+	mu       sync.Mutex
+	inFlight int
+	peak     int
+}
+
+func (s *concurrentQuoteStore) CreateQuote(ctx context.Context, quote *models.Quote) error {
+	s.mu.Lock()
+	s.inFlight++
+	if s.inFlight > s.peak {
+		s.peak = s.inFlight
+	}
+	s.mu.Unlock()
+
+	time.Sleep(time.Millisecond)
+
+	s.mu.Lock()
+	s.inFlight--
+	s.mu.Unlock()
+	return s.fakeQuoteStore.CreateQuote(ctx, quote)
+}
+
+func TestGenerateRenewalOffersBatchSkipsPoliciesWithValidOffers(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+
+	var policies []*models.Policy
+	for i := 0; i < 200; i++ {
+		policies = append(policies, &models.Policy{
+			ProductID:        uuid.New(),
+			UserID:           uuid.New(),
+			Premium:          1000,
+			Currency:         "USD",
+			CoverageAmount:   50000,
+			Status:           models.PolicyStatusActive,
+			PaymentFrequency: "annually",
+			EffectiveDate:    time.Now().AddDate(-1, 0, 10),
+			ExpirationDate:   time.Now().AddDate(0, 0, 10),
+		})
+	}
+	policyStore := newFakePolicyStore(policies...)
+
+	quoteStore := &concurrentQuoteStore{fakeQuoteStore: newFakeQuoteStore()}
+	quoteService := NewQuoteService(quoteStore)
+	for _, policy := range policies[:20] {
+		require.NoError(t, quoteStore.fakeQuoteStore.CreateQuote(ctx, &models.Quote{
+			ProductID:         policy.ProductID,
+			UserID:            policy.UserID,
+			FinalPrice:        1000,
+			Status:            models.QuoteStatusActive,
+			ValidUntil:        time.Now().AddDate(0, 0, 5),
+			RenewalOfPolicyID: &policy.ID,
+		}))
+	}
+
+	svc := NewPolicyLifecycleService(log, config.NewManager(log, ""), policyStore, nil, nil, nil, nil, nil, nil, nil, nil, quoteService, nil)
+
+	report, err := svc.GenerateRenewalOffersBatch(ctx, 30)
+	require.NoError(t, err)
+	require.Len(t, report.Results, 200)
+	assert.Equal(t, 180, report.Generated)
+	assert.Equal(t, 20, report.Skipped)
+	assert.Zero(t, report.Failed)
+	assert.Greater(t, quoteStore.peak, 1)
+
+	skipped := make(map[uuid.UUID]bool)
+	for _, policy := range policies[:20] {
+		skipped[policy.ID] = true
+	}
+	for _, result := range report.Results {
+		if skipped[result.PolicyID] {
+			assert.NotNil(t, result.ExistingQuoteID)
+			assert.Nil(t, result.Offer)
+			continue
+		}
+		require.NotNil(t, result.Offer)
+		assert.Equal(t, result.PolicyID, result.Offer.PolicyID)
+	}
+
+	again, err := svc.GenerateRenewalOffersBatch(ctx, 30)
+	require.NoError(t, err)
+	assert.Zero(t, again.Generated)
+	assert.Equal(t, 200, again.Skipped)
+}
+
+func TestGenerateRenewalOfferRecordsPricedRateTableVersion(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	configManager := config.NewManager(log, "")
+
+	product := &models.Product{Base: models.Base{ID: uuid.New()}, Category: "home"}
+	user := &models.User{Base: models.Base{ID: uuid.New(), CreatedAt: time.Now().AddDate(-3, 0, 0)}}
+	policy := &models.Policy{
+		ProductID:        product.ID,
+		UserID:           user.ID,
+		Premium:          1000,
+		Currency:         "USD",
+		CoverageAmount:   100000,
+		Status:           models.PolicyStatusActive,
+		PaymentFrequency: "annually",
+		EffectiveDate:    time.Now().AddDate(-1, 0, 10),
+		ExpirationDate:   time.Now().AddDate(0, 0, 10),
+	}
+	pricing := NewPricingEngineService(log, configManager, newFakeProductStore(product), newFakePolicyStore(), newFakeClaimStore(), newFakeUserStore(user))
+	quoteStore := newFakeQuoteStore()
+	svc := NewPolicyLifecycleService(log, configManager, newFakePolicyStore(policy), nil, nil, nil, nil, pricing, nil, nil, nil, NewQuoteService(quoteStore), nil)
+
+	offer, err := svc.GenerateRenewalOffer(ctx, policy.ID)
+	require.NoError(t, err)
+
+	quote, err := quoteStore.GetQuote(ctx, offer.QuoteID)
+	require.NoError(t, err)
+	assert.Equal(t, "v1", quote.RateTableVersion)
+}
+
+func TestAcceptRenewalOfferRenewsAtTheOfferedPrice(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+
+	policy := &models.Policy{
+		ProductID:        uuid.New(),
+		UserID:           uuid.New(),
+		Premium:          1000,
+		Currency:         "USD",
+		CoverageAmount:   50000,
+		Status:           models.PolicyStatusActive,
+		PaymentFrequency: "annually",
+		EffectiveDate:    time.Now().AddDate(-1, 0, 10),
+		ExpirationDate:   time.Now().AddDate(0, 0, 10),
+	}
+	policyStore := newFakePolicyStore(policy)
+	quoteStore := newFakeQuoteStore()
+	svc := NewPolicyLifecycleService(log, config.NewManager(log, ""), policyStore, nil, nil, nil, nil, nil, nil, nil, nil, NewQuoteService(quoteStore), nil)
+
+	offer, err := svc.GenerateRenewalOffer(ctx, policy.ID)
+	require.NoError(t, err)
+	// The locked price holds even though the term would now be priced differently
+	quote, err := quoteStore.GetQuote(ctx, offer.QuoteID)
+	require.NoError(t, err)
+	quote.FinalPrice = 900
+
+	result, err := svc.AcceptRenewalOffer(ctx, offer.QuoteID, "")
+	require.NoError(t, err)
+	require.NotNil(t, result.NewPolicyID)
+	assert.Equal(t, 900.0, result.Premium)
+
+	renewal, err := policyStore.GetPolicy(ctx, *result.NewPolicyID)
+	require.NoError(t, err)
+	assert.Equal(t, 900.0, renewal.Premium)
+	assert.Equal(t, policy.CoverageAmount, renewal.CoverageAmount)
+	require.NotNil(t, renewal.QuoteID)
+	assert.Equal(t, offer.QuoteID, *renewal.QuoteID)
+	assert.Equal(t, models.QuoteStatusUsed, quote.Status)
+
+	_, err = svc.AcceptRenewalOffer(ctx, offer.QuoteID, "")
+	assert.ErrorIs(t, err, ErrRenewalOfferUnavailable)
+}
+
+func TestRenewPolicyChargesTheValidRenewalOffer(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+
+	policy := &models.Policy{
+		ProductID:        uuid.New(),
+		UserID:           uuid.New(),
+		Premium:          1000,
+		Currency:         "USD",
+		CoverageAmount:   50000,
+		CoverageTier:     "premium",
+		Deductible:       500,
+		Status:           models.PolicyStatusActive,
+		PaymentFrequency: "annually",
+		EffectiveDate:    time.Now().AddDate(-1, 0, 10),
+		ExpirationDate:   time.Now().AddDate(0, 0, 10),
+	}
+	policyStore := newFakePolicyStore(policy)
+	quoteStore := newFakeQuoteStore()
+	svc := NewPolicyLifecycleService(log, config.NewManager(log, ""), policyStore, nil, nil, nil, nil, nil, nil, nil, nil, NewQuoteService(quoteStore), nil)
+
+	offer, err := svc.GenerateRenewalOffer(ctx, policy.ID)
+	require.NoError(t, err)
+	quote, err := quoteStore.GetQuote(ctx, offer.QuoteID)
+	require.NoError(t, err)
+	assert.Equal(t, "premium", quote.CoverageTier)
+	assert.Equal(t, 500.0, quote.Deductible)
+	quote.FinalPrice = 900
+
+	result, err := svc.RenewPolicy(ctx, policy.ID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 900.0, result.Premium)
+	assert.Equal(t, models.QuoteStatusUsed, quote.Status)
+
+	// The renewal keeps the coverage tier and deductible of the policy it renews.
+	renewed, err := policyStore.GetPolicy(ctx, *result.NewPolicyID)
+	require.NoError(t, err)
+	assert.Equal(t, "premium", renewed.CoverageTier)
+	assert.Equal(t, 500.0, renewed.Deductible)
+}
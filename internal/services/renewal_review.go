@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"go.uber.org/zap"
+)
+
+// ErrRenewalRequiresReview is returned when a policy must not be auto-renewed and needs
+// an underwriter to review it first.
+var ErrRenewalRequiresReview = errors.New("renewal requires manual review")
+
+// openClaimStatuses are the claim statuses that still carry an unresolved liability.
+var openClaimStatuses = []string{
+	models.ClaimStatusSubmitted,
+	models.ClaimStatusUnderReview,
+	models.ClaimStatusApproved,
+	models.ClaimStatusUnderAppeal,
+}
+
+// checkAntiSelection guards auto-renewal against anti-selection: a policy with an open
+// claim of at least RenewalRules.OpenClaimReviewThreshold returns ErrRenewalRequiresReview.
+// The check is skipped when no claim store is configured or the threshold is zero.
+func (s *PolicyLifecycleService) checkAntiSelection(ctx context.Context, policy *models.Policy) error {
+	threshold := s.configManager.GetConfig().PolicyLifecycle.RenewalRules.OpenClaimReviewThreshold
+	if s.claimStore == nil || threshold <= 0 {
+		return nil
+	}
+
+	filter := models.ClaimFilter{PolicyID: &policy.ID, Statuses: openClaimStatuses}
+	page := models.CursorPage{Limit: 100}
+	for {
+		claims, err := s.claimStore.List(ctx, &filter, &page)
+		if err != nil {
+			return fmt.Errorf("failed to list open claims: %w", err)
+		}
+		for _, claim := range claims.Claims {
+			if claim.ClaimAmount >= threshold {
+				s.logger.Info("Open high-value claim blocks auto-renewal",
+					zap.String("policy_id", policy.ID.String()),
+					zap.String("claim_id", claim.ID.String()),
+					zap.Float64("claim_amount", claim.ClaimAmount))
+				return fmt.Errorf("%w: open claim %s of %.2f %s", ErrRenewalRequiresReview, claim.ClaimNumber, claim.ClaimAmount, claim.Currency)
+			}
+		}
+		if claims.NextCursor == "" {
+			return nil
+		}
+		page.Cursor = claims.NextCursor
+	}
+}
@@ -5,27 +5,42 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/logger"
 	"github.com/edsonmichaque/bazaruto/internal/models"
 	"github.com/edsonmichaque/bazaruto/internal/store"
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 )
 
 // RiskAssessmentService handles comprehensive risk assessment for insurance applications and policies.
 type RiskAssessmentService struct {
-	userStore   store.UserStore
-	policyStore store.PolicyStore
-	claimStore  store.ClaimStore
+	configManager *config.Manager
+	userStore     store.UserStore
+	policyStore   store.PolicyStore
+	claimStore    store.ClaimStore
+	cache         *riskProfileCache
+	logger        *logger.Logger
 }
 
 // NewRiskAssessmentService creates a new RiskAssessmentService instance.
-func NewRiskAssessmentService(userStore store.UserStore, policyStore store.PolicyStore, claimStore store.ClaimStore) *RiskAssessmentService {
+func NewRiskAssessmentService(logger *logger.Logger, configManager *config.Manager, userStore store.UserStore, policyStore store.PolicyStore, claimStore store.ClaimStore) *RiskAssessmentService {
 	return &RiskAssessmentService{
-		userStore:   userStore,
-		policyStore: policyStore,
-		claimStore:  claimStore,
+		logger:        logger,
+		configManager: configManager,
+		userStore:     userStore,
+		policyStore:   policyStore,
+		claimStore:    claimStore,
+		cache:         newRiskProfileCache(DefaultRiskCacheCapacity),
 	}
 }
 
+// RiskAssessmentOptions controls how a risk assessment is performed.
+type RiskAssessmentOptions struct {
+	// Force bypasses the cache and recomputes the profile; the fresh profile replaces any cached one.
+	Force bool `json:"force"`
+}
+
 // RiskProfile represents a comprehensive risk assessment result.
 type RiskProfile struct {
 	OverallScore      float64                `json:"overall_score"`      // 0-100, higher means higher risk
@@ -56,7 +71,42 @@ type RiskAssessment struct {
 }
 
 // AssessRisk performs comprehensive risk assessment for a user and product combination.
+// Profiles are cached until ValidUntil for identical inputs and configuration version.
 func (s *RiskAssessmentService) AssessRisk(ctx context.Context, userID uuid.UUID, productID uuid.UUID, coverageAmount float64) (*RiskProfile, error) {
+	return s.AssessRiskWithOptions(ctx, userID, productID, coverageAmount, nil)
+}
+
+// AssessRiskWithOptions performs a risk assessment, consulting the cache unless opts.Force is set.
+func (s *RiskAssessmentService) AssessRiskWithOptions(ctx context.Context, userID uuid.UUID, productID uuid.UUID, coverageAmount float64, opts *RiskAssessmentOptions) (*RiskProfile, error) {
+	configVersion := s.configVersion()
+	key := riskCacheKey(userID, productID, coverageAmount, configVersion)
+
+	if opts == nil || !opts.Force {
+		if cached, ok := s.cache.get(key, configVersion, time.Now()); ok {
+			return cached.withCacheHit(true), nil
+		}
+	}
+
+	profile, err := s.assessRisk(ctx, userID, productID, coverageAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	profile.Metadata["config_version"] = configVersion
+	s.cache.put(key, configVersion, profile)
+
+	s.logger.Info("Risk assessed",
+		zap.String("user_id", userID.String()),
+		zap.String("product_id", productID.String()),
+		zap.Float64("overall_score", profile.OverallScore),
+		zap.String("risk_level", profile.RiskLevel),
+		zap.String("approval_status", profile.ApprovalStatus))
+
+	return profile.withCacheHit(false), nil
+}
+
+// assessRisk computes a risk profile without consulting the cache.
+func (s *RiskAssessmentService) assessRisk(ctx context.Context, userID uuid.UUID, productID uuid.UUID, coverageAmount float64) (*RiskProfile, error) {
 	// Fetch user details
 	user, err := s.userStore.FindByID(ctx, userID)
 	if err != nil {
@@ -111,7 +161,6 @@ func (s *RiskAssessmentService) AssessRisk(ctx context.Context, userID uuid.UUID
 	profile.Metadata["user_id"] = userID.String()
 	profile.Metadata["product_id"] = productID.String()
 	profile.Metadata["coverage_amount"] = coverageAmount
-	profile.Metadata["assessment_version"] = "2.0"
 
 	return profile, nil
 }
@@ -455,7 +504,7 @@ func (s *RiskAssessmentService) UpdateRiskAssessment(ctx context.Context, userID
 		coverageAmount = amount
 	}
 
-	return s.AssessRisk(ctx, userID, productID, coverageAmount)
+	return s.AssessRiskWithOptions(ctx, userID, productID, coverageAmount, &RiskAssessmentOptions{Force: true})
 }
 
 // GetRiskAssessment retrieves a previously calculated risk assessment.
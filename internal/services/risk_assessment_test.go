@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssessRiskCachesIdenticalInputs(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	configManager := config.NewManager(log, filepath.Join(t.TempDir(), "business_rules.json"))
+
+	user := &models.User{}
+	user.CreatedAt = time.Now().AddDate(-3, 0, 0)
+	userStore := newFakeUserStore(user)
+	svc := NewRiskAssessmentService(log, configManager, userStore, nil, nil)
+	productID := uuid.New()
+
+	first, err := svc.AssessRisk(ctx, user.ID, productID, 50000)
+	require.NoError(t, err)
+	assert.Equal(t, false, first.Metadata["cache_hit"])
+
+	second, err := svc.AssessRisk(ctx, user.ID, productID, 50000)
+	require.NoError(t, err)
+	assert.Equal(t, true, second.Metadata["cache_hit"])
+	assert.Equal(t, first.OverallScore, second.OverallScore)
+	assert.Equal(t, 1, userStore.lookups)
+
+	// A different coverage amount is a different key
+	_, err = svc.AssessRisk(ctx, user.ID, productID, 75000)
+	require.NoError(t, err)
+	assert.Equal(t, 2, userStore.lookups)
+
+	// Forcing bypasses the cache
+	forced, err := svc.AssessRiskWithOptions(ctx, user.ID, productID, 50000, &RiskAssessmentOptions{Force: true})
+	require.NoError(t, err)
+	assert.Equal(t, false, forced.Metadata["cache_hit"])
+	assert.Equal(t, 3, userStore.lookups)
+}
+
+func TestAssessRiskCacheMissesOnConfigVersionBump(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	configManager := config.NewManager(log, filepath.Join(t.TempDir(), "business_rules.json"))
+
+	user := &models.User{}
+	userStore := newFakeUserStore(user)
+	svc := NewRiskAssessmentService(log, configManager, userStore, nil, nil)
+	productID := uuid.New()
+
+	_, err := svc.AssessRisk(ctx, user.ID, productID, 50000)
+	require.NoError(t, err)
+
+	cfg := configManager.GetConfig()
+	cfg.RiskAssessment.Version = "1.1"
+	require.NoError(t, configManager.UpdateConfig(ctx, cfg))
+
+	profile, err := svc.AssessRisk(ctx, user.ID, productID, 50000)
+	require.NoError(t, err)
+	assert.Equal(t, false, profile.Metadata["cache_hit"])
+	assert.Equal(t, "1.1", profile.Metadata["config_version"])
+	assert.Equal(t, 2, userStore.lookups)
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultRiskCacheCapacity is the maximum number of risk profiles kept in memory.
+const DefaultRiskCacheCapacity = 1000
+
+// riskProfileCache is a thread-safe LRU cache of risk profiles.
+// Entries expire at the profile's ValidUntil and the whole cache is purged
+// when the risk assessment configuration version changes.
+type riskProfileCache struct {
+	mu            sync.Mutex
+	capacity      int
+	configVersion string
+	entries       map[string]*list.Element
+	order         *list.List
+}
+
+// riskCacheEntry is a single cached risk profile.
+type riskCacheEntry struct {
+	key       string
+	profile   *RiskProfile
+	expiresAt time.Time
+}
+
+// newRiskProfileCache creates an empty risk profile cache.
+func newRiskProfileCache(capacity int) *riskProfileCache {
+	if capacity <= 0 {
+		capacity = DefaultRiskCacheCapacity
+	}
+	return &riskProfileCache{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// riskCacheKey hashes the inputs that determine a risk profile.
+func riskCacheKey(userID, productID uuid.UUID, coverageAmount float64, configVersion string) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%.2f|%s", userID, productID, coverageAmount, configVersion)))
+	return hex.EncodeToString(sum[:])
+}
+
+// withCacheHit returns a copy of the profile marked with whether it was served from the cache.
+// Copying keeps callers from mutating the cached profile's metadata.
+func (p *RiskProfile) withCacheHit(hit bool) *RiskProfile {
+	profile := *p
+	profile.Metadata = make(map[string]interface{}, len(p.Metadata)+1)
+	for k, v := range p.Metadata {
+		profile.Metadata[k] = v
+	}
+	profile.Metadata["cache_hit"] = hit
+	return &profile
+}
+
+// get returns an unexpired cached profile for the key.
+func (c *riskProfileCache) get(key, configVersion string, now time.Time) (*RiskProfile, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.syncVersion(configVersion)
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*riskCacheEntry)
+	if !now.Before(entry.expiresAt) {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+		return nil, false
+	}
+
+	c.order.MoveToFront(elem)
+	return entry.profile, true
+}
+
+// put stores a profile, evicting the least recently used entry when full.
+func (c *riskProfileCache) put(key, configVersion string, profile *RiskProfile) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.syncVersion(configVersion)
+
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*riskCacheEntry)
+		entry.profile = profile
+		entry.expiresAt = profile.ValidUntil
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&riskCacheEntry{
+		key:       key,
+		profile:   profile,
+		expiresAt: profile.ValidUntil,
+	})
+
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*riskCacheEntry).key)
+	}
+}
+
+// syncVersion purges the cache when the configuration version changes. Callers must hold mu.
+func (c *riskProfileCache) syncVersion(configVersion string) {
+	if c.configVersion == configVersion {
+		return
+	}
+	c.configVersion = configVersion
+	c.entries = make(map[string]*list.Element)
+	c.order.Init()
+}
+
+// configVersion returns the risk assessment configuration version used to key the cache.
+func (s *RiskAssessmentService) configVersion() string {
+	return s.configManager.GetConfig().RiskAssessment.Version
+}
@@ -0,0 +1,288 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/edsonmichaque/bazaruto/internal/store"
+	"github.com/edsonmichaque/bazaruto/pkg/job"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// SARSubmitter files suspicious activity reports with the regulator, for example through the
+// financial intelligence unit's reporting API.
+type SARSubmitter interface {
+	// Submit files a report and returns the filing reference.
+	Submit(ctx context.Context, report *models.SuspiciousActivityReport) (string, error)
+}
+
+// SARService generates suspicious activity reports from AML and fraud findings and tracks them
+// from draft through filing to acknowledgement.
+type SARService struct {
+	sarStore      store.SARStore
+	customerStore store.CustomerStore
+	dispatcher    job.Dispatcher
+	submitter     SARSubmitter
+	numbers       *NumberGenerator
+	logger        *logger.Logger
+}
+
+// NewSARService creates a new SARService instance. The customer store is optional and links
+// reports to the user's customer profile.
+func NewSARService(logger *logger.Logger, sarStore store.SARStore, customerStore store.CustomerStore, dispatcher job.Dispatcher) *SARService {
+	return &SARService{
+		sarStore:      sarStore,
+		customerStore: customerStore,
+		dispatcher:    dispatcher,
+		logger:        logger,
+	}
+}
+
+// SetSubmitter sets the submitter reports are filed through.
+func (s *SARService) SetSubmitter(submitter SARSubmitter) {
+	s.submitter = submitter
+}
+
+// SetNumberGenerator sets the generator used to number new reports. Without one, report
+// numbers are generated from a timestamp and a random component.
+func (s *SARService) SetNumberGenerator(numbers *NumberGenerator) {
+	s.numbers = numbers
+}
+
+// CreateFromComplianceCheck drafts a report for a user whose compliance check found critical
+// AML violations. It returns nil when there are none. While the user has a draft report, new
+// findings are added to it instead of starting another one.
+func (s *SARService) CreateFromComplianceCheck(ctx context.Context, user *models.User, check *ComplianceCheck) (*models.SuspiciousActivityReport, error) {
+	var indicators []models.SARIndicator
+	amount := 0.0
+	for _, violation := range check.Violations {
+		if violation.Severity != "critical" || !strings.HasPrefix(violation.Code, "AML_") {
+			continue
+		}
+		indicators = append(indicators, models.SARIndicator{
+			Code:        violation.Code,
+			Severity:    violation.Severity,
+			Description: violation.Description,
+		})
+		if total, ok := violation.Metadata["payment_total"].(float64); ok && total > amount {
+			amount = total
+		}
+	}
+	if len(indicators) == 0 {
+		return nil, nil
+	}
+
+	report := &models.SuspiciousActivityReport{
+		UserID:      user.ID,
+		SubjectName: user.FullName,
+		Reason:      "Critical AML findings in compliance check",
+		Indicators:  indicators,
+		Amount:      amount,
+		Metadata: map[string]interface{}{
+			"compliance_score":  check.Score,
+			"compliance_status": check.Status,
+			"check_date":        check.CheckDate,
+		},
+	}
+	return s.createOrUpdateDraft(ctx, report)
+}
+
+// CreateFromFraudScore drafts a report for a claim flagged by fraud analysis. The claim's
+// fraud factors are recorded as the report's indicators; analysing the claim again adds new
+// factors to its draft.
+func (s *SARService) CreateFromFraudScore(ctx context.Context, claim *models.Claim, score *FraudScore) (*models.SuspiciousActivityReport, error) {
+	indicators := make([]models.SARIndicator, 0, len(score.Factors))
+	for _, factor := range score.Factors {
+		indicators = append(indicators, models.SARIndicator{
+			Code:        strings.ToUpper(factor.Factor),
+			Severity:    factor.Severity,
+			Description: factor.Description,
+		})
+	}
+
+	claimID := claim.ID
+	report := &models.SuspiciousActivityReport{
+		UserID:     claim.UserID,
+		ClaimID:    &claimID,
+		Reason:     fmt.Sprintf("Claim %s flagged with %s fraud risk", claim.ClaimNumber, score.RiskLevel),
+		Indicators: indicators,
+		Amount:     claim.ClaimAmount,
+		Currency:   claim.Currency,
+		Metadata: map[string]interface{}{
+			"fraud_score": score.Score,
+			"risk_level":  score.RiskLevel,
+		},
+	}
+	return s.createOrUpdateDraft(ctx, report)
+}
+
+// File queues a draft report for submission to the regulator.
+func (s *SARService) File(ctx context.Context, reportID uuid.UUID) error {
+	report, err := s.sarStore.GetReport(ctx, reportID)
+	if err != nil {
+		return fmt.Errorf("failed to get suspicious activity report: %w", err)
+	}
+	if report.Status != models.SARStatusDraft {
+		return fmt.Errorf("suspicious activity report %s is %s, only drafts can be filed", report.ReportNumber, report.Status)
+	}
+
+	if err := s.dispatcher.PerformWithContext(ctx, &SubmitSARJob{ID: uuid.New(), ReportID: report.ID}); err != nil {
+		return fmt.Errorf("failed to dispatch suspicious activity report submission: %w", err)
+	}
+	return nil
+}
+
+// Submit files a draft report through the submitter and records the filing reference. Reports
+// that are no longer drafts have already been filed and are left unchanged.
+func (s *SARService) Submit(ctx context.Context, reportID uuid.UUID) error {
+	if s.submitter == nil {
+		return fmt.Errorf("no suspicious activity report submitter configured")
+	}
+
+	report, err := s.sarStore.GetReport(ctx, reportID)
+	if err != nil {
+		return fmt.Errorf("failed to get suspicious activity report: %w", err)
+	}
+	if report.Status != models.SARStatusDraft {
+		return nil
+	}
+
+	reference, err := s.submitter.Submit(ctx, report)
+	if err != nil {
+		return fmt.Errorf("failed to submit suspicious activity report %s: %w", report.ReportNumber, err)
+	}
+
+	now := time.Now()
+	report.Status = models.SARStatusFiled
+	report.FiledAt = &now
+	report.FilingReference = reference
+	if err := s.sarStore.UpdateReport(ctx, report); err != nil {
+		return fmt.Errorf("failed to update suspicious activity report: %w", err)
+	}
+
+	s.logger.Info("Suspicious activity report filed",
+		zap.String("report_id", report.ID.String()),
+		zap.String("report_number", report.ReportNumber),
+		zap.String("filing_reference", reference))
+	return nil
+}
+
+// Acknowledge records the regulator's acknowledgement of a filed report.
+func (s *SARService) Acknowledge(ctx context.Context, reportID uuid.UUID, acknowledgement string) (*models.SuspiciousActivityReport, error) {
+	report, err := s.sarStore.GetReport(ctx, reportID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get suspicious activity report: %w", err)
+	}
+	if report.Status != models.SARStatusFiled {
+		return nil, fmt.Errorf("suspicious activity report %s is %s, only filed reports can be acknowledged", report.ReportNumber, report.Status)
+	}
+
+	now := time.Now()
+	report.Status = models.SARStatusAcknowledged
+	report.AcknowledgedAt = &now
+	report.Acknowledgement = acknowledgement
+	if err := s.sarStore.UpdateReport(ctx, report); err != nil {
+		return nil, fmt.Errorf("failed to update suspicious activity report: %w", err)
+	}
+	return report, nil
+}
+
+// createOrUpdateDraft adds the report's indicators to the open draft it belongs to, or creates
+// the report when there is none. The store merges and creates drafts atomically, so concurrent
+// findings for the same user or claim end up on a single draft.
+func (s *SARService) createOrUpdateDraft(ctx context.Context, report *models.SuspiciousActivityReport) (*models.SuspiciousActivityReport, error) {
+	if err := s.prepareReport(ctx, report); err != nil {
+		return nil, err
+	}
+
+	draft, err := s.sarStore.SaveDraft(ctx, report, func(draft *models.SuspiciousActivityReport) {
+		known := make(map[string]bool, len(draft.Indicators))
+		for _, indicator := range draft.Indicators {
+			known[indicator.Code] = true
+		}
+		for _, indicator := range report.Indicators {
+			if !known[indicator.Code] {
+				draft.Indicators = append(draft.Indicators, indicator)
+			}
+		}
+		if report.Amount > draft.Amount {
+			draft.Amount = report.Amount
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save suspicious activity report: %w", err)
+	}
+
+	if draft == report {
+		s.logger.Warn("Suspicious activity report drafted",
+			zap.String("report_id", report.ID.String()),
+			zap.String("report_number", report.ReportNumber),
+			zap.String("user_id", report.UserID.String()),
+			zap.String("reason", report.Reason))
+	}
+	return draft, nil
+}
+
+// prepareReport numbers a new draft report and links it to the user's customer profile.
+func (s *SARService) prepareReport(ctx context.Context, report *models.SuspiciousActivityReport) error {
+	if s.numbers != nil {
+		number, err := s.numbers.SARNumber(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to generate suspicious activity report number: %w", err)
+		}
+		report.ReportNumber = number
+	} else {
+		report.ReportNumber = generateSARNumber()
+	}
+	report.Status = models.SARStatusDraft
+	if report.Currency == "" {
+		report.Currency = "USD"
+	}
+	if s.customerStore != nil {
+		if customer, err := s.customerStore.GetByUserID(ctx, report.UserID); err == nil {
+			report.CustomerID = &customer.ID
+			if report.SubjectName == "" {
+				report.SubjectName = customer.GetFullName()
+			}
+		}
+	}
+	return nil
+}
+
+// generateSARNumber generates a report number with a timestamp and random component.
+func generateSARNumber() string {
+	timestamp := time.Now().Format("20060102150405")
+	random := rand.Intn(9999)
+	return fmt.Sprintf("SAR-%s-%04d", timestamp, random)
+}
+
+// SubmitSARJob files a suspicious activity report with the regulator.
+type SubmitSARJob struct {
+	ID        uuid.UUID   `json:"id"`
+	ReportID  uuid.UUID   `json:"report_id"`
+	Service   *SARService `json:"-"` // Injected dependency
+	Attempts  int         `json:"attempts"`
+	RunAtTime time.Time   `json:"run_at_time"`
+}
+
+// Perform submits the report.
+func (j *SubmitSARJob) Perform(ctx context.Context) error {
+	if j.Service == nil {
+		return fmt.Errorf("suspicious activity report service is not configured")
+	}
+	return j.Service.Submit(ctx, j.ReportID)
+}
+
+// SubmitSARJob interface methods
+func (j *SubmitSARJob) Queue() string               { return job.QueueCompliance }
+func (j *SubmitSARJob) MaxRetries() int             { return 5 } // Regulatory filings must not be dropped
+func (j *SubmitSARJob) RetryBackoff() time.Duration { return time.Minute }
+func (j *SubmitSARJob) Priority() int               { return job.PriorityHigh }
+func (j *SubmitSARJob) Timeout() time.Duration      { return time.Minute }
+func (j *SubmitSARJob) UniqueKey() string           { return "sar:" + j.ReportID.String() }
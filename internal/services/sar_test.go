@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/edsonmichaque/bazaruto/pkg/job"
+	"github.com/edsonmichaque/bazaruto/pkg/job/adapter"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubSARSubmitter accepts every report with a fixed filing reference.
+type stubSARSubmitter struct {
+	submitted []string
+}
+
+func (s *stubSARSubmitter) Submit(ctx context.Context, report *models.SuspiciousActivityReport) (string, error) {
+	s.submitted = append(s.submitted, report.ReportNumber)
+	return "FIU-2026-000123", nil
+}
+
+func TestCriticalAMLViolationDraftsSARForCustomer(t *testing.T) {
+	ctx := context.Background()
+	user := newComplianceTestUser()
+	user.ID = uuid.New()
+	customer := &models.Customer{UserID: user.ID, FirstName: "Ivan", LastName: "Petrov"}
+
+	sarStore := &fakeSARStore{}
+	memory := adapter.NewMemoryAdapter()
+	sars := NewSARService(logger.NewLogger("error", "json"), sarStore, newFakeCustomerStore(customer), *job.NewDispatcher(memory, job.NewRegistry()))
+
+	compliance := newComplianceTestService(t, user)
+	compliance.SetSanctionsScreener(&stubSanctionsScreener{result: ScreeningResult{Match: true, Lists: []string{"OFAC SDN"}}}, 0)
+	compliance.SetSARService(sars)
+
+	check, err := compliance.ValidateUserCompliance(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, "failed", check.Status)
+
+	require.Len(t, sarStore.reports, 1)
+	report := sarStore.reports[0]
+	assert.Equal(t, models.SARStatusDraft, report.Status)
+	assert.Equal(t, user.ID, report.UserID)
+	require.NotNil(t, report.CustomerID)
+	assert.Equal(t, customer.ID, *report.CustomerID)
+	assert.NotEmpty(t, report.ReportNumber)
+	require.Len(t, report.Indicators, 1)
+	assert.Equal(t, "AML_003", report.Indicators[0].Code)
+	assert.Equal(t, report.ID.String(), check.Metadata["sar_id"])
+
+	// A later check adds to the open draft rather than starting another report.
+	_, err = compliance.ValidateUserCompliance(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Len(t, sarStore.reports, 1)
+}
+
+func TestSARLifecycleFromDraftToAcknowledged(t *testing.T) {
+	ctx := context.Background()
+	sarStore := &fakeSARStore{}
+	memory := adapter.NewMemoryAdapter()
+	registry := job.NewRegistry()
+	sars := NewSARService(logger.NewLogger("error", "json"), sarStore, nil, *job.NewDispatcher(memory, registry))
+	registry.Register("submitsarjob", func() job.Job {
+		return &SubmitSARJob{Service: sars}
+	})
+	submitter := &stubSARSubmitter{}
+	sars.SetSubmitter(submitter)
+
+	report, err := sars.CreateFromComplianceCheck(ctx, &models.User{Base: models.Base{ID: uuid.New()}}, &ComplianceCheck{
+		Violations: []ComplianceViolation{{Code: "AML_006", Severity: "critical", Metadata: map[string]interface{}{"payment_total": 30000.0}}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 30000.0, report.Amount)
+
+	_, err = sars.Acknowledge(ctx, report.ID, "ACK-1")
+	assert.Error(t, err, "drafts cannot be acknowledged")
+
+	require.NoError(t, sars.File(ctx, report.ID))
+	serialized, err := memory.Dequeue(ctx, job.QueueCompliance)
+	require.NoError(t, err)
+	submitJob, err := registry.Deserialize(serialized)
+	require.NoError(t, err)
+	require.NoError(t, submitJob.Perform(ctx))
+
+	assert.Equal(t, models.SARStatusFiled, report.Status)
+	assert.Equal(t, "FIU-2026-000123", report.FilingReference)
+	assert.NotNil(t, report.FiledAt)
+	assert.Error(t, sars.File(ctx, report.ID), "filed reports cannot be filed again")
+
+	// Running the submission again does not file the report twice.
+	require.NoError(t, submitJob.Perform(ctx))
+	assert.Len(t, submitter.submitted, 1)
+
+	acknowledged, err := sars.Acknowledge(ctx, report.ID, "ACK-1")
+	require.NoError(t, err)
+	assert.Equal(t, models.SARStatusAcknowledged, acknowledged.Status)
+	assert.Equal(t, "ACK-1", acknowledged.Acknowledgement)
+}
+
+func TestFraudScoreDraftsOneNumberedSARPerClaim(t *testing.T) {
+	ctx := context.Background()
+	sarStore := &fakeSARStore{}
+	sars := NewSARService(logger.NewLogger("error", "json"), sarStore, nil, *job.NewDispatcher(adapter.NewMemoryAdapter(), job.NewRegistry()))
+	sars.SetNumberGenerator(newTestNumberGenerator(t))
+
+	claim := &models.Claim{Base: models.Base{ID: uuid.New()}, UserID: uuid.New(), ClaimNumber: "CLM-1", ClaimAmount: 5000}
+	score := &FraudScore{Score: 85, RiskLevel: "critical", Factors: []FraudFactor{{Factor: "timing", Severity: "high"}}}
+
+	report, err := sars.CreateFromFraudScore(ctx, claim, score)
+	require.NoError(t, err)
+	assert.Regexp(t, `^SAR-\d{4}-000001$`, report.ReportNumber)
+
+	// Analysing the claim again adds new factors to the claim's draft.
+	score.Factors = append(score.Factors, FraudFactor{Factor: "amount", Severity: "high"})
+	again, err := sars.CreateFromFraudScore(ctx, claim, score)
+	require.NoError(t, err)
+	assert.Equal(t, report.ID, again.ID)
+	assert.Len(t, again.Indicators, 2)
+	assert.Len(t, sarStore.reports, 1)
+}
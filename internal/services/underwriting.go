@@ -5,41 +5,70 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/edsonmichaque/bazaruto/internal/authorization"
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
 	"github.com/edsonmichaque/bazaruto/internal/store"
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 )
 
 // UnderwritingService handles automated underwriting decisions and policy approval/rejection.
 type UnderwritingService struct {
+	configManager  *config.Manager
 	userStore      store.UserStore
 	policyStore    store.PolicyStore
 	claimStore     store.ClaimStore
 	riskService    *RiskAssessmentService
 	fraudService   *FraudDetectionService
 	pricingService *PricingEngineService
+	authorizer     authorization.Authorizer
+	auditService   *AuditService
+	decisionStore  store.UnderwritingDecisionStore
+	logger         *logger.Logger
 }
 
 // NewUnderwritingService creates a new UnderwritingService instance.
 func NewUnderwritingService(
+	logger *logger.Logger,
+	configManager *config.Manager,
 	userStore store.UserStore,
 	policyStore store.PolicyStore,
 	claimStore store.ClaimStore,
 	riskService *RiskAssessmentService,
 	fraudService *FraudDetectionService,
 	pricingService *PricingEngineService,
+	authorizer authorization.Authorizer,
+	auditService ...*AuditService,
 ) *UnderwritingService {
-	return &UnderwritingService{
+	service := &UnderwritingService{
+		configManager:  configManager,
 		userStore:      userStore,
 		policyStore:    policyStore,
 		claimStore:     claimStore,
 		riskService:    riskService,
 		fraudService:   fraudService,
 		pricingService: pricingService,
+		authorizer:     authorizer,
+		logger:         logger,
 	}
+	if len(auditService) > 0 {
+		service.auditService = auditService[0]
+	}
+	return service
+}
+
+// SetDecisionStore sets the store underwriting decisions are recorded in, so they can
+// later be appealed and reviewed against the decision actually made.
+func (s *UnderwritingService) SetDecisionStore(decisionStore store.UnderwritingDecisionStore) {
+	s.decisionStore = decisionStore
 }
 
 // UnderwritingDecision represents the result of an underwriting decision.
 type UnderwritingDecision struct {
+	ID              uuid.UUID               `json:"id"`              // ID of the recorded decision
+	ApplicationID   uuid.UUID               `json:"application_id"`  // Application the decision was made on
 	Decision        string                  `json:"decision"`        // approved, declined, conditional, pending_review
 	Confidence      float64                 `json:"confidence"`      // 0-1, confidence in the decision
 	RiskScore       float64                 `json:"risk_score"`      // 0-100, overall risk score
@@ -63,13 +92,17 @@ type UnderwritingCondition struct {
 
 // UnderwritingRequest represents a request for underwriting decision.
 type UnderwritingRequest struct {
+	ApplicationID    uuid.UUID              `json:"application_id"`
 	UserID           uuid.UUID              `json:"user_id"`
 	ProductID        uuid.UUID              `json:"product_id"`
 	CoverageAmount   float64                `json:"coverage_amount"`
+	CoverageTier     string                 `json:"coverage_tier,omitempty"` // basic, standard or premium; defaults to standard
+	Deductible       float64                `json:"deductible,omitempty"`    // Chosen deductible; 0 when none is chosen
 	Currency         string                 `json:"currency"`
 	PaymentFrequency string                 `json:"payment_frequency"`
 	EffectiveDate    time.Time              `json:"effective_date"`
 	ExpirationDate   time.Time              `json:"expiration_date"`
+	Jurisdiction     string                 `json:"jurisdiction,omitempty"` // ISO country code of the insured risk
 	ApplicationData  map[string]interface{} `json:"application_data"`
 	RiskFactors      map[string]interface{} `json:"risk_factors"`
 	Options          map[string]interface{} `json:"options"`
@@ -81,6 +114,9 @@ func (s *UnderwritingService) ProcessUnderwriting(ctx context.Context, request *
 	if err := s.validateUnderwritingRequest(request); err != nil {
 		return nil, fmt.Errorf("invalid underwriting request: %w", err)
 	}
+	if err := s.pricingService.ValidateCoverage(ctx, request.ProductID, request.CoverageAmount); err != nil {
+		return nil, fmt.Errorf("invalid underwriting request: %w", err)
+	}
 
 	// Initialize decision
 	decision := &UnderwritingDecision{
@@ -88,6 +124,8 @@ func (s *UnderwritingService) ProcessUnderwriting(ctx context.Context, request *
 		ValidUntil: time.Now().Add(30 * 24 * time.Hour), // Valid for 30 days
 		Metadata:   make(map[string]interface{}),
 	}
+	// Stamp the underwriting config version so the decision can be reproduced after a config change
+	decision.Metadata["config_version"] = s.configManager.GetConfig().Underwriting.Version
 
 	// Perform risk assessment
 	riskProfile, err := s.riskService.AssessRisk(ctx, request.UserID, request.ProductID, request.CoverageAmount)
@@ -97,16 +135,20 @@ func (s *UnderwritingService) ProcessUnderwriting(ctx context.Context, request *
 
 	decision.RiskScore = riskProfile.OverallScore
 
-	// Calculate premium
+	// Calculate premium, pricing risk from the profile just assessed
 	pricingRequest := &PricingRequest{
 		ProductID:        request.ProductID,
 		UserID:           request.UserID,
 		CoverageAmount:   request.CoverageAmount,
+		CoverageTier:     request.CoverageTier,
+		Deductible:       request.Deductible,
 		Currency:         request.Currency,
 		PaymentFrequency: request.PaymentFrequency,
 		EffectiveDate:    request.EffectiveDate,
 		ExpirationDate:   request.ExpirationDate,
+		Jurisdiction:     request.Jurisdiction,
 		RiskFactors:      request.RiskFactors,
+		RiskProfile:      riskProfile,
 		Options:          request.Options,
 	}
 
@@ -130,9 +172,63 @@ func (s *UnderwritingService) ProcessUnderwriting(ctx context.Context, request *
 	decision.Metadata["coverage_amount"] = request.CoverageAmount
 	decision.Metadata["underwriting_version"] = "1.0"
 
+	fields := []zap.Field{
+		zap.String("user_id", request.UserID.String()),
+		zap.String("product_id", request.ProductID.String()),
+		zap.String("decision", decision.Decision),
+		zap.Float64("risk_score", decision.RiskScore),
+		zap.Float64("premium", decision.Premium),
+	}
+	if decision.Decision == "declined" {
+		s.logger.Warn("Underwriting application declined", append(fields, zap.Strings("reasons", decision.Reasons))...)
+	} else {
+		s.logger.Info("Underwriting decision made", fields...)
+	}
+
+	if err := s.recordDecision(ctx, request, decision); err != nil {
+		return nil, err
+	}
+
 	return decision, nil
 }
 
+// recordDecision stores a decision against its application. A request without an
+// application ID starts a new application.
+func (s *UnderwritingService) recordDecision(ctx context.Context, request *UnderwritingRequest, decision *UnderwritingDecision) error {
+	decision.ApplicationID = request.ApplicationID
+	if decision.ApplicationID == uuid.Nil {
+		decision.ApplicationID = uuid.New()
+	}
+	if s.decisionStore == nil {
+		return nil
+	}
+
+	record := &models.UnderwritingDecision{
+		ApplicationID: decision.ApplicationID,
+		UserID:        request.UserID,
+		ProductID:     request.ProductID,
+		Decision:      decision.Decision,
+		RiskScore:     decision.RiskScore,
+		Premium:       decision.Premium,
+		Currency:      decision.Currency,
+		Reasons:       decision.Reasons,
+		ValidUntil:    decision.ValidUntil,
+	}
+	if err := s.decisionStore.CreateDecision(ctx, record); err != nil {
+		return fmt.Errorf("failed to record underwriting decision: %w", err)
+	}
+	decision.ID = record.ID
+	return nil
+}
+
+// GetApplicationDecision retrieves the decision currently in force for an application.
+func (s *UnderwritingService) GetApplicationDecision(ctx context.Context, applicationID uuid.UUID) (*models.UnderwritingDecision, error) {
+	if s.decisionStore == nil {
+		return nil, fmt.Errorf("underwriting decisions are not recorded")
+	}
+	return s.decisionStore.GetLatestDecision(ctx, applicationID)
+}
+
 // validateUnderwritingRequest validates the underwriting request.
 func (s *UnderwritingService) validateUnderwritingRequest(request *UnderwritingRequest) error {
 	if request.UserID == uuid.Nil {
@@ -360,45 +456,102 @@ func (s *UnderwritingService) calculateDeadline(days int) *time.Time {
 }
 
 // ReviewUnderwritingDecision allows manual review and override of automated underwriting decisions.
+// The reviewer is the actor in context. The override is recorded as a new decision on the
+// application that supersedes the stored decision under review.
 func (s *UnderwritingService) ReviewUnderwritingDecision(ctx context.Context, decisionID string, review *UnderwritingReview) (*UnderwritingDecision, error) {
-	// In a real implementation, this would retrieve the original decision and apply the review
-	// For now, we'll simulate the review process
-
 	// Validate review
 	if review == nil {
 		return nil, fmt.Errorf("underwriting review cannot be nil")
 	}
 
-	if review.ReviewerID == uuid.Nil {
-		return nil, fmt.Errorf("reviewer ID is required")
-	}
-
 	if review.Decision == "" {
 		return nil, fmt.Errorf("review decision is required")
 	}
 
-	// Create updated decision based on review
+	originalID, err := uuid.Parse(decisionID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid decision ID: %w", err)
+	}
+
+	if s.authorizer != nil {
+		if err := s.authorizer.Authorize(ctx, authorization.PermissionUnderwritingOverride); err != nil {
+			return nil, err
+		}
+	}
+	reviewer, ok := authorization.ActorFromContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("%w: no actor in context", authorization.ErrUnauthorized)
+	}
+
+	if s.decisionStore == nil {
+		return nil, fmt.Errorf("underwriting decisions are not recorded")
+	}
+	original, err := s.decisionStore.GetDecision(ctx, originalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get underwriting decision: %w", err)
+	}
+
+	// Record the review as a new decision superseding the original
+	reviewerID := reviewer.ID
+	now := time.Now()
+	record := &models.UnderwritingDecision{
+		ApplicationID: original.ApplicationID,
+		UserID:        original.UserID,
+		ProductID:     original.ProductID,
+		Decision:      review.Decision,
+		RiskScore:     original.RiskScore,
+		Premium:       original.Premium,
+		Currency:      original.Currency,
+		Reasons:       []string{review.Reason},
+		ValidUntil:    now.Add(30 * 24 * time.Hour),
+		ReviewedBy:    &reviewerID,
+		SupersedesID:  &original.ID,
+	}
+	if err := s.decisionStore.CreateDecision(ctx, record); err != nil {
+		return nil, fmt.Errorf("failed to record underwriting decision: %w", err)
+	}
+
 	updatedDecision := &UnderwritingDecision{
-		Decision:   review.Decision,
-		Confidence: 1.0, // Manual review has high confidence
-		Reasons:    []string{review.Reason},
-		ValidUntil: time.Now().Add(30 * 24 * time.Hour),
+		ID:            record.ID,
+		ApplicationID: record.ApplicationID,
+		Decision:      record.Decision,
+		Confidence:    1.0, // Manual review has high confidence
+		RiskScore:     record.RiskScore,
+		Premium:       record.Premium,
+		Currency:      record.Currency,
+		Reasons:       record.Reasons,
+		ValidUntil:    record.ValidUntil,
 		Metadata: map[string]interface{}{
-			"reviewer_id":          review.ReviewerID.String(),
-			"review_date":          time.Now(),
+			"reviewer_id":          reviewerID.String(),
+			"review_date":          now,
 			"original_decision_id": decisionID,
 		},
 	}
 
+	// Record the override in the audit trail
+	if s.auditService != nil {
+		entry := &models.AuditLog{
+			EntityType: models.AuditEntityUnderwritingDecision,
+			EntityID:   decisionID,
+			Action:     models.AuditActionUnderwritingOverride,
+			ActorID:    &reviewerID,
+			Before:     map[string]interface{}{"decision": original.Decision},
+			After:      map[string]interface{}{"decision": review.Decision, "comments": review.Comments, "decision_id": record.ID.String()},
+			Reason:     review.Reason,
+		}
+		if err := s.auditService.Record(ctx, entry); err != nil {
+			return nil, fmt.Errorf("failed to record underwriting override: %w", err)
+		}
+	}
+
 	return updatedDecision, nil
 }
 
 // UnderwritingReview represents a manual review of an underwriting decision.
 type UnderwritingReview struct {
-	ReviewerID uuid.UUID `json:"reviewer_id"`
-	Decision   string    `json:"decision"` // approved, declined, conditional
-	Reason     string    `json:"reason"`   // Reason for the review decision
-	Comments   string    `json:"comments"` // Additional comments
+	Decision string `json:"decision"` // approved, declined, conditional
+	Reason   string `json:"reason"`   // Reason for the review decision
+	Comments string `json:"comments"` // Additional comments
 }
 
 // GetUnderwritingHistory retrieves underwriting history for a user.
@@ -0,0 +1,188 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/authorization"
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestReviewUnderwritingDecisionRecordsAuditEntry(t *testing.T) {
+	log := logger.NewLogger("error", "json")
+	auditService := NewAuditService(log, &fakeAuditLogStore{})
+	svc := NewUnderwritingService(log, nil, nil, nil, nil, nil, nil, nil, nil, auditService)
+	decisions := &fakeDecisionStore{}
+	svc.SetDecisionStore(decisions)
+
+	original := &models.UnderwritingDecision{ApplicationID: uuid.New(), UserID: uuid.New(), Decision: "declined", Premium: 1200}
+	require.NoError(t, decisions.CreateDecision(context.Background(), original))
+
+	reviewer := &authorization.Actor{ID: uuid.New(), Role: authorization.RoleSeniorUnderwriter}
+	ctx := authorization.WithActor(context.Background(), reviewer)
+	decision, err := svc.ReviewUnderwritingDecision(ctx, original.ID.String(), &UnderwritingReview{
+		Decision: "approved",
+		Reason:   "Additional medical evidence provided",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "approved", decision.Decision)
+	assert.Equal(t, original.ApplicationID, decision.ApplicationID)
+
+	current, err := svc.GetApplicationDecision(ctx, original.ApplicationID)
+	require.NoError(t, err)
+	assert.Equal(t, decision.ID, current.ID)
+	require.NotNil(t, current.SupersedesID)
+	assert.Equal(t, original.ID, *current.SupersedesID)
+	require.NotNil(t, current.ReviewedBy)
+	assert.Equal(t, reviewer.ID, *current.ReviewedBy)
+
+	trail, err := auditService.GetAuditTrail(ctx, models.AuditEntityUnderwritingDecision, original.ID.String(), 10, 0)
+	require.NoError(t, err)
+	require.Len(t, trail, 1)
+
+	entry := trail[0]
+	assert.Equal(t, models.AuditActionUnderwritingOverride, entry.Action)
+	require.NotNil(t, entry.ActorID)
+	assert.Equal(t, reviewer.ID, *entry.ActorID)
+	assert.Equal(t, "declined", entry.Before["decision"], "the previous decision is the stored one")
+	assert.Equal(t, "approved", entry.After["decision"])
+	assert.Equal(t, "Additional medical evidence provided", entry.Reason)
+	assert.False(t, entry.OccurredAt.IsZero())
+}
+
+func TestReviewUnderwritingDecisionRequiresSeniorUnderwriter(t *testing.T) {
+	authorizer := authorization.NewRBACAuthorizer(authorization.NewRBACService())
+	svc := NewUnderwritingService(logger.NewLogger("error", "json"), nil, nil, nil, nil, nil, nil, nil, authorizer)
+	decisions := &fakeDecisionStore{}
+	svc.SetDecisionStore(decisions)
+
+	original := &models.UnderwritingDecision{ApplicationID: uuid.New(), Decision: "declined"}
+	require.NoError(t, decisions.CreateDecision(context.Background(), original))
+	review := &UnderwritingReview{Decision: "approved", Reason: "Manual review"}
+
+	agentCtx := authorization.WithActor(context.Background(), &authorization.Actor{ID: uuid.New(), Role: authorization.RoleAgent})
+	_, err := svc.ReviewUnderwritingDecision(agentCtx, original.ID.String(), review)
+	assert.ErrorIs(t, err, authorization.ErrUnauthorized)
+
+	_, err = svc.ReviewUnderwritingDecision(context.Background(), original.ID.String(), review)
+	assert.ErrorIs(t, err, authorization.ErrUnauthorized)
+
+	underwriterCtx := authorization.WithActor(context.Background(), &authorization.Actor{ID: uuid.New(), Role: authorization.RoleSeniorUnderwriter})
+	decision, err := svc.ReviewUnderwritingDecision(underwriterCtx, original.ID.String(), review)
+	require.NoError(t, err)
+	assert.Equal(t, "approved", decision.Decision)
+}
+
+func TestDeclinedUnderwritingDecisionIsLoggedWithUserID(t *testing.T) {
+	ctx := context.Background()
+	quiet := logger.NewLogger("error", "json")
+	configManager := config.NewManager(quiet, "")
+	core, logs := observer.New(zap.InfoLevel)
+
+	// An inactive account is a critical compliance risk, which always declines.
+	user := &models.User{Status: "suspended"}
+	user.CreatedAt = time.Now().AddDate(-2, 0, 0)
+	userStore := newFakeUserStore(user)
+	product := &models.Product{Category: "home"}
+	riskService := NewRiskAssessmentService(quiet, configManager, userStore, nil, nil)
+	pricingService := NewPricingEngineService(quiet, configManager, newFakeProductStore(product), newFakePolicyStore(), newFakeClaimStore(), userStore)
+	svc := NewUnderwritingService(&logger.Logger{Logger: zap.New(core)}, configManager, userStore, nil, nil, riskService, nil, pricingService, nil)
+
+	decision, err := svc.ProcessUnderwriting(ctx, &UnderwritingRequest{
+		UserID:           user.ID,
+		ProductID:        product.ID,
+		CoverageAmount:   100000,
+		Currency:         "USD",
+		PaymentFrequency: "annually",
+		EffectiveDate:    time.Now(),
+		ExpirationDate:   time.Now().AddDate(1, 0, 0),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "declined", decision.Decision)
+
+	declines := logs.FilterMessage("Underwriting application declined").All()
+	require.Len(t, declines, 1)
+	fields := declines[0].ContextMap()
+	assert.Equal(t, user.ID.String(), fields["user_id"])
+	assert.Equal(t, "declined", fields["decision"])
+}
+
+func TestProcessUnderwritingRejectsCoverageOutsideProductLimits(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	configManager := config.NewManager(log, "")
+
+	user := &models.User{Status: models.StatusActive}
+	user.CreatedAt = time.Now().AddDate(-2, 0, 0)
+	userStore := newFakeUserStore(user)
+	product := &models.Product{Name: "Term Life", Category: "life", MinCoverageAmount: 25000, MaxCoverageAmount: 1000000}
+	riskService := NewRiskAssessmentService(log, configManager, userStore, nil, nil)
+	pricingService := NewPricingEngineService(log, configManager, newFakeProductStore(product), newFakePolicyStore(), newFakeClaimStore(), userStore)
+	svc := NewUnderwritingService(log, configManager, userStore, nil, nil, riskService, nil, pricingService, nil)
+
+	for _, coverageAmount := range []float64{10000, 2000000} {
+		_, err := svc.ProcessUnderwriting(ctx, &UnderwritingRequest{
+			UserID:           user.ID,
+			ProductID:        product.ID,
+			CoverageAmount:   coverageAmount,
+			Currency:         "USD",
+			PaymentFrequency: "annually",
+			EffectiveDate:    time.Now(),
+			ExpirationDate:   time.Now().AddDate(1, 0, 0),
+		})
+		assert.ErrorIs(t, err, ErrCoverageOutOfRange, "coverage of %.0f", coverageAmount)
+	}
+}
+
+func TestProcessUnderwritingPricesFromAssessedRiskProfile(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("error", "json")
+	configManager := config.NewManager(log, "")
+
+	user := &models.User{Status: models.StatusActive}
+	user.CreatedAt = time.Now().AddDate(-2, 0, 0)
+	userStore := newFakeUserStore(user)
+	product := &models.Product{Category: "home"}
+	riskService := NewRiskAssessmentService(log, configManager, userStore, nil, nil)
+	pricingService := NewPricingEngineService(log, configManager, newFakeProductStore(product), newFakePolicyStore(), newFakeClaimStore(), userStore)
+	svc := NewUnderwritingService(log, configManager, userStore, nil, nil, riskService, nil, pricingService, nil)
+
+	effective := time.Now()
+	decision, err := svc.ProcessUnderwriting(ctx, &UnderwritingRequest{
+		UserID:           user.ID,
+		ProductID:        product.ID,
+		CoverageAmount:   100000,
+		Currency:         "USD",
+		PaymentFrequency: "annually",
+		EffectiveDate:    effective,
+		ExpirationDate:   effective.AddDate(1, 0, 0),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, configManager.GetConfig().Underwriting.Version, decision.Metadata["config_version"])
+
+	// The profile is cached, so this is the profile underwriting priced with.
+	profile, err := riskService.AssessRisk(ctx, user.ID, product.ID, 100000)
+	require.NoError(t, err)
+	require.NotZero(t, profile.PremiumAdjustment)
+	expected, err := pricingService.CalculatePremium(ctx, &PricingRequest{
+		ProductID:        product.ID,
+		UserID:           user.ID,
+		CoverageAmount:   100000,
+		Currency:         "USD",
+		PaymentFrequency: "annually",
+		EffectiveDate:    effective,
+		ExpirationDate:   effective.AddDate(1, 0, 0),
+		RiskProfile:      profile,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, expected.FinalPremium, decision.Premium)
+	assert.Equal(t, profile.OverallScore, decision.RiskScore)
+}
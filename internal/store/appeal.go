@@ -0,0 +1,97 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AppealStore defines the interface for appeal data operations.
+type AppealStore interface {
+	CreateAppeal(ctx context.Context, appeal *models.Appeal) error
+	GetAppeal(ctx context.Context, id uuid.UUID) (*models.Appeal, error)
+	ListAppealsBySubject(ctx context.Context, subjectType string, subjectID uuid.UUID) ([]*models.Appeal, error)
+	UpdateAppeal(ctx context.Context, appeal *models.Appeal) error
+	FileClaimAppeal(ctx context.Context, claim *models.Claim, appeal *models.Appeal) error
+	ResolveClaimAppeal(ctx context.Context, claim *models.Claim, appeal *models.Appeal) error
+}
+
+// appealStore implements AppealStore interface.
+type appealStore struct {
+	db *gorm.DB
+}
+
+// NewAppealStore creates a new AppealStore instance.
+func NewAppealStore(db *gorm.DB) AppealStore {
+	return &appealStore{db: db}
+}
+
+// CreateAppeal creates a new appeal.
+func (s *appealStore) CreateAppeal(ctx context.Context, appeal *models.Appeal) error {
+	if err := s.db.WithContext(ctx).Create(appeal).Error; err != nil {
+		return fmt.Errorf("failed to create appeal: %w", err)
+	}
+	return nil
+}
+
+// GetAppeal retrieves an appeal by ID.
+func (s *appealStore) GetAppeal(ctx context.Context, id uuid.UUID) (*models.Appeal, error) {
+	var appeal models.Appeal
+	if err := readDB(ctx, s.db).First(&appeal, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("appeal not found")
+		}
+		return nil, fmt.Errorf("failed to get appeal: %w", err)
+	}
+	return &appeal, nil
+}
+
+// ListAppealsBySubject retrieves all appeals filed against a claim or application, oldest first.
+func (s *appealStore) ListAppealsBySubject(ctx context.Context, subjectType string, subjectID uuid.UUID) ([]*models.Appeal, error) {
+	var appeals []*models.Appeal
+	if err := readDB(ctx, s.db).
+		Where("subject_type = ? AND subject_id = ?", subjectType, subjectID).
+		Order("sequence ASC").
+		Find(&appeals).Error; err != nil {
+		return nil, fmt.Errorf("failed to list appeals: %w", err)
+	}
+	return appeals, nil
+}
+
+// UpdateAppeal updates an existing appeal.
+func (s *appealStore) UpdateAppeal(ctx context.Context, appeal *models.Appeal) error {
+	if err := s.db.WithContext(ctx).Save(appeal).Error; err != nil {
+		return fmt.Errorf("failed to update appeal: %w", err)
+	}
+	return nil
+}
+
+// FileClaimAppeal creates an appeal and saves the claim it moves under appeal in one
+// transaction, so a claim is never left under appeal without one.
+func (s *appealStore) FileClaimAppeal(ctx context.Context, claim *models.Claim, appeal *models.Appeal) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := updateClaim(tx, claim); err != nil {
+			return err
+		}
+		if err := tx.Create(appeal).Error; err != nil {
+			return fmt.Errorf("failed to create appeal: %w", err)
+		}
+		return nil
+	})
+}
+
+// ResolveClaimAppeal saves a resolved appeal and the claim its outcome moves in one transaction.
+func (s *appealStore) ResolveClaimAppeal(ctx context.Context, claim *models.Claim, appeal *models.Appeal) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := updateClaim(tx, claim); err != nil {
+			return err
+		}
+		if err := tx.Save(appeal).Error; err != nil {
+			return fmt.Errorf("failed to update appeal: %w", err)
+		}
+		return nil
+	})
+}
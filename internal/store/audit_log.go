@@ -0,0 +1,47 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"gorm.io/gorm"
+)
+
+// AuditLogStore defines the interface for audit log data operations.
+type AuditLogStore interface {
+	CreateAuditLog(ctx context.Context, entry *models.AuditLog) error
+	ListAuditLogsByEntity(ctx context.Context, entityType, entityID string, limit, offset int) ([]*models.AuditLog, error)
+}
+
+// auditLogStore implements AuditLogStore interface.
+type auditLogStore struct {
+	db *gorm.DB
+}
+
+// NewAuditLogStore creates a new AuditLogStore instance.
+func NewAuditLogStore(db *gorm.DB) AuditLogStore {
+	return &auditLogStore{db: db}
+}
+
+// CreateAuditLog appends an entry to the audit log.
+func (s *auditLogStore) CreateAuditLog(ctx context.Context, entry *models.AuditLog) error {
+	if err := s.db.WithContext(ctx).Create(entry).Error; err != nil {
+		return fmt.Errorf("failed to create audit log: %w", err)
+	}
+	return nil
+}
+
+// ListAuditLogsByEntity retrieves the audit trail of an entity, oldest first.
+func (s *auditLogStore) ListAuditLogsByEntity(ctx context.Context, entityType, entityID string, limit, offset int) ([]*models.AuditLog, error) {
+	var entries []*models.AuditLog
+	if err := readDB(ctx, s.db).
+		Where("entity_type = ? AND entity_id = ?", entityType, entityID).
+		Order("occurred_at ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&entries).Error; err != nil {
+		return nil, fmt.Errorf("failed to list audit logs: %w", err)
+	}
+	return entries, nil
+}
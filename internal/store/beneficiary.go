@@ -16,6 +16,7 @@ type BeneficiaryStore interface {
 	ListBeneficiaries(ctx context.Context, policyID *uuid.UUID, limit, offset int) ([]*models.Beneficiary, error)
 	UpdateBeneficiary(ctx context.Context, beneficiary *models.Beneficiary) error
 	DeleteBeneficiary(ctx context.Context, id uuid.UUID) error
+	ReplaceBeneficiaries(ctx context.Context, policyID uuid.UUID, beneficiaries []*models.Beneficiary) error
 	CountBeneficiaries(ctx context.Context, policyID *uuid.UUID) (int64, error)
 }
 
@@ -40,7 +41,7 @@ func (s *beneficiaryStore) CreateBeneficiary(ctx context.Context, beneficiary *m
 // GetBeneficiary retrieves a beneficiary by ID.
 func (s *beneficiaryStore) GetBeneficiary(ctx context.Context, id uuid.UUID) (*models.Beneficiary, error) {
 	var beneficiary models.Beneficiary
-	if err := s.db.WithContext(ctx).Preload("Policy").First(&beneficiary, "id = ?", id).Error; err != nil {
+	if err := readDB(ctx, s.db).Preload("Policy").First(&beneficiary, "id = ?", id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("beneficiary not found")
 		}
@@ -52,7 +53,7 @@ func (s *beneficiaryStore) GetBeneficiary(ctx context.Context, id uuid.UUID) (*m
 // ListBeneficiaries retrieves a list of beneficiaries with optional filtering.
 func (s *beneficiaryStore) ListBeneficiaries(ctx context.Context, policyID *uuid.UUID, limit, offset int) ([]*models.Beneficiary, error) {
 	var beneficiaries []*models.Beneficiary
-	query := s.db.WithContext(ctx).Model(&models.Beneficiary{}).Preload("Policy")
+	query := readDB(ctx, s.db).Model(&models.Beneficiary{}).Preload("Policy")
 
 	if policyID != nil {
 		query = query.Where("policy_id = ?", *policyID)
@@ -87,10 +88,31 @@ func (s *beneficiaryStore) DeleteBeneficiary(ctx context.Context, id uuid.UUID)
 	return nil
 }
 
+// ReplaceBeneficiaries replaces a policy's beneficiaries with the given ones in a single
+// transaction, so the policy is never left with a partial designation.
+func (s *beneficiaryStore) ReplaceBeneficiaries(ctx context.Context, policyID uuid.UUID, beneficiaries []*models.Beneficiary) error {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.Beneficiary{}, "policy_id = ?", policyID).Error; err != nil {
+			return err
+		}
+		for _, beneficiary := range beneficiaries {
+			beneficiary.PolicyID = policyID
+			if err := tx.Create(beneficiary).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to replace beneficiaries: %w", err)
+	}
+	return nil
+}
+
 // CountBeneficiaries returns the total number of beneficiaries with optional filtering.
 func (s *beneficiaryStore) CountBeneficiaries(ctx context.Context, policyID *uuid.UUID) (int64, error) {
 	var count int64
-	query := s.db.WithContext(ctx).Model(&models.Beneficiary{})
+	query := readDB(ctx, s.db).Model(&models.Beneficiary{})
 
 	if policyID != nil {
 		query = query.Where("policy_id = ?", *policyID)
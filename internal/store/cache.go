@@ -0,0 +1,199 @@
+package store
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/google/uuid"
+)
+
+// ttlCache is a thread-safe map whose entries expire after a fixed TTL. When maxEntries is
+// set, adding an entry to a full cache first drops the expired entries and then, if it is
+// still full, the entry closest to expiring.
+type ttlCache[V any] struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	entries    map[uuid.UUID]ttlCacheEntry[V]
+	generation uint64
+	now        func() time.Time
+}
+
+// ttlCacheEntry is a single cached value.
+type ttlCacheEntry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// newTTLCache creates an empty cache whose entries live for ttl. A maxEntries of zero
+// leaves the cache unbounded.
+func newTTLCache[V any](ttl time.Duration, maxEntries int) *ttlCache[V] {
+	return &ttlCache[V]{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[uuid.UUID]ttlCacheEntry[V]),
+		now:        time.Now,
+	}
+}
+
+// get returns an unexpired value for the key.
+func (c *ttlCache[V]) get(key uuid.UUID) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	if !c.now().Before(entry.expiresAt) {
+		delete(c.entries, key)
+		var zero V
+		return zero, false
+	}
+	return entry.value, true
+}
+
+// snapshot returns the cache's current generation, to be passed to set after loading a
+// value from the underlying store.
+func (c *ttlCache[V]) snapshot() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generation
+}
+
+// set stores a value for the key unless the cache was invalidated since generation was
+// taken, so a value loaded before a concurrent update or delete is not cached.
+func (c *ttlCache[V]) set(key uuid.UUID, value V, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if generation != c.generation {
+		return
+	}
+	if _, ok := c.entries[key]; !ok && c.maxEntries > 0 && len(c.entries) >= c.maxEntries {
+		c.evict()
+	}
+	c.entries[key] = ttlCacheEntry[V]{value: value, expiresAt: c.now().Add(c.ttl)}
+}
+
+// evict drops the expired entries and, if none had expired, the entry closest to expiring.
+// The caller must hold the lock.
+func (c *ttlCache[V]) evict() {
+	now := c.now()
+	var oldest uuid.UUID
+	var oldestExpiry time.Time
+	for key, entry := range c.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(c.entries, key)
+			continue
+		}
+		if oldestExpiry.IsZero() || entry.expiresAt.Before(oldestExpiry) {
+			oldest, oldestExpiry = key, entry.expiresAt
+		}
+	}
+	if len(c.entries) >= c.maxEntries {
+		delete(c.entries, oldest)
+	}
+}
+
+// invalidate removes the key from the cache.
+func (c *ttlCache[V]) invalidate(key uuid.UUID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+	c.generation++
+}
+
+// cachedProductStore caches products by ID in front of another ProductStore.
+// Updates and deletes through the store invalidate the cached product.
+type cachedProductStore struct {
+	ProductStore
+	cache *ttlCache[models.Product]
+}
+
+// NewCachedProductStore wraps a ProductStore with a cache of products by ID that expire after ttl.
+// The cache holds at most maxEntries products; zero leaves it unbounded.
+func NewCachedProductStore(inner ProductStore, ttl time.Duration, maxEntries int) ProductStore {
+	return &cachedProductStore{
+		ProductStore: inner,
+		cache:        newTTLCache[models.Product](ttl, maxEntries),
+	}
+}
+
+// GetProduct retrieves a product by ID, serving it from the cache when present. Deleted
+// products are never cached. Each caller receives its own shallow copy of the product.
+func (s *cachedProductStore) GetProduct(ctx context.Context, id uuid.UUID) (*models.Product, error) {
+	if product, ok := s.cache.get(id); ok {
+		return &product, nil
+	}
+
+	generation := s.cache.snapshot()
+	product, err := s.ProductStore.GetProduct(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !product.DeletedAt.Valid {
+		s.cache.set(id, *product, generation)
+	}
+	return product, nil
+}
+
+// UpdateProduct updates a product and invalidates its cached copy.
+func (s *cachedProductStore) UpdateProduct(ctx context.Context, product *models.Product) error {
+	defer s.cache.invalidate(product.ID)
+	return s.ProductStore.UpdateProduct(ctx, product)
+}
+
+// DeleteProduct deletes a product and invalidates its cached copy.
+func (s *cachedProductStore) DeleteProduct(ctx context.Context, id uuid.UUID) error {
+	defer s.cache.invalidate(id)
+	return s.ProductStore.DeleteProduct(ctx, id)
+}
+
+// cachedPartnerStore caches partners by ID in front of another PartnerStore.
+// Updates and deletes through the store invalidate the cached partner.
+type cachedPartnerStore struct {
+	PartnerStore
+	cache *ttlCache[models.Partner]
+}
+
+// NewCachedPartnerStore wraps a PartnerStore with a cache of partners by ID that expire after ttl.
+// The cache holds at most maxEntries partners; zero leaves it unbounded.
+func NewCachedPartnerStore(inner PartnerStore, ttl time.Duration, maxEntries int) PartnerStore {
+	return &cachedPartnerStore{
+		PartnerStore: inner,
+		cache:        newTTLCache[models.Partner](ttl, maxEntries),
+	}
+}
+
+// GetPartner retrieves a partner by ID, serving it from the cache when present. Deleted
+// partners are never cached. Each caller receives its own shallow copy of the partner.
+func (s *cachedPartnerStore) GetPartner(ctx context.Context, id uuid.UUID) (*models.Partner, error) {
+	if partner, ok := s.cache.get(id); ok {
+		return &partner, nil
+	}
+
+	generation := s.cache.snapshot()
+	partner, err := s.PartnerStore.GetPartner(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !partner.DeletedAt.Valid {
+		s.cache.set(id, *partner, generation)
+	}
+	return partner, nil
+}
+
+// UpdatePartner updates a partner and invalidates its cached copy.
+func (s *cachedPartnerStore) UpdatePartner(ctx context.Context, partner *models.Partner) error {
+	defer s.cache.invalidate(partner.ID)
+	return s.PartnerStore.UpdatePartner(ctx, partner)
+}
+
+// DeletePartner deletes a partner and invalidates its cached copy.
+func (s *cachedPartnerStore) DeletePartner(ctx context.Context, id uuid.UUID) error {
+	defer s.cache.invalidate(id)
+	return s.PartnerStore.DeletePartner(ctx, id)
+}
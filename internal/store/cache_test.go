@@ -0,0 +1,150 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// countingProductStore counts product reads that reach the underlying store.
+type countingProductStore struct {
+	ProductStore
+	reads int
+}
+
+func (s *countingProductStore) GetProduct(ctx context.Context, id uuid.UUID) (*models.Product, error) {
+	s.reads++
+	return s.ProductStore.GetProduct(ctx, id)
+}
+
+func TestCachedProductStoreServesRepeatReadsAndInvalidatesOnUpdate(t *testing.T) {
+	ctx := context.Background()
+	db := &countingProductStore{ProductStore: NewProductStore(newTestDB(t))}
+	products := NewCachedProductStore(db, time.Minute, 0)
+
+	product := &models.Product{Name: "Home", Category: "home", PartnerID: uuid.New(), BasePrice: 100}
+	require.NoError(t, products.CreateProduct(ctx, product))
+
+	first, err := products.GetProduct(ctx, product.ID)
+	require.NoError(t, err)
+	second, err := products.GetProduct(ctx, product.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, db.reads)
+	assert.Equal(t, first.Name, second.Name)
+
+	// Mutating a returned product does not change the cached copy.
+	second.Name = "Changed"
+	third, err := products.GetProduct(ctx, product.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Home", third.Name)
+
+	third.BasePrice = 150
+	require.NoError(t, products.UpdateProduct(ctx, third))
+
+	updated, err := products.GetProduct(ctx, product.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, db.reads)
+	assert.Equal(t, 150.0, updated.BasePrice)
+}
+
+func TestCachedProductStoreExpiresEntries(t *testing.T) {
+	ctx := context.Background()
+	db := &countingProductStore{ProductStore: NewProductStore(newTestDB(t))}
+	products := NewCachedProductStore(db, time.Minute, 0).(*cachedProductStore)
+	now := time.Now()
+	products.cache.now = func() time.Time { return now }
+
+	product := &models.Product{Name: "Home", Category: "home", PartnerID: uuid.New(), BasePrice: 100}
+	require.NoError(t, products.CreateProduct(ctx, product))
+
+	_, err := products.GetProduct(ctx, product.ID)
+	require.NoError(t, err)
+	now = now.Add(time.Minute)
+	_, err = products.GetProduct(ctx, product.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, db.reads)
+}
+
+func TestCachedProductStoreEvictsWhenFull(t *testing.T) {
+	ctx := context.Background()
+	db := &countingProductStore{ProductStore: NewProductStore(newTestDB(t))}
+	products := NewCachedProductStore(db, time.Minute, 2).(*cachedProductStore)
+	now := time.Now()
+	products.cache.now = func() time.Time { return now }
+
+	var ids []uuid.UUID
+	for _, name := range []string{"Home", "Auto", "Life"} {
+		product := &models.Product{Name: name, Category: "home", PartnerID: uuid.New(), BasePrice: 100}
+		require.NoError(t, products.CreateProduct(ctx, product))
+		_, err := products.GetProduct(ctx, product.ID)
+		require.NoError(t, err)
+		ids = append(ids, product.ID)
+		now = now.Add(time.Second)
+	}
+	assert.Len(t, products.cache.entries, 2)
+
+	// The first product, closest to expiring, made room for the third.
+	_, err := products.GetProduct(ctx, ids[2])
+	require.NoError(t, err)
+	assert.Equal(t, 3, db.reads)
+	_, err = products.GetProduct(ctx, ids[0])
+	require.NoError(t, err)
+	assert.Equal(t, 4, db.reads)
+}
+
+// deletingProductStore deletes each product it reads after loading it, as a concurrent
+// request deleting the product through the cache would.
+type deletingProductStore struct {
+	ProductStore
+	cache *cachedProductStore
+}
+
+func (s *deletingProductStore) GetProduct(ctx context.Context, id uuid.UUID) (*models.Product, error) {
+	product, err := s.ProductStore.GetProduct(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return product, s.cache.DeleteProduct(ctx, id)
+}
+
+func TestCachedProductStoreSkipsDeletedProducts(t *testing.T) {
+	ctx := context.Background()
+	inner := NewProductStore(newTestDB(t))
+
+	// A product deleted while it was being loaded is not cached.
+	deleting := &deletingProductStore{ProductStore: inner}
+	products := NewCachedProductStore(deleting, time.Minute, 0).(*cachedProductStore)
+	deleting.cache = products
+
+	product := &models.Product{Name: "Home", Category: "home", PartnerID: uuid.New(), BasePrice: 100}
+	require.NoError(t, inner.CreateProduct(ctx, product))
+	_, err := products.GetProduct(ctx, product.ID)
+	require.NoError(t, err)
+	assert.Empty(t, products.cache.entries)
+
+	// Nor is a soft-deleted product returned by the underlying store.
+	deleted := &models.Product{Name: "Auto", Category: "auto"}
+	deleted.ID = uuid.New()
+	deleted.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	products = NewCachedProductStore(&stubProductStore{product: deleted}, time.Minute, 0).(*cachedProductStore)
+	_, err = products.GetProduct(ctx, deleted.ID)
+	require.NoError(t, err)
+	assert.Empty(t, products.cache.entries)
+}
+
+// stubProductStore returns a fixed product.
+type stubProductStore struct {
+	ProductStore
+	product *models.Product
+}
+
+func (s *stubProductStore) GetProduct(ctx context.Context, id uuid.UUID) (*models.Product, error) {
+	product := *s.product
+	return &product, nil
+}
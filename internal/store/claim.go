@@ -2,11 +2,21 @@ package store
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/edsonmichaque/bazaruto/internal/models"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Claim page sizes for List.
+const (
+	defaultClaimPageSize = 30
+	maxClaimPageSize     = 100
 )
 
 // ClaimStore defines the interface for claim data operations.
@@ -15,9 +25,11 @@ type ClaimStore interface {
 	GetClaim(ctx context.Context, id uuid.UUID) (*models.Claim, error)
 	GetClaimByNumber(ctx context.Context, claimNumber string) (*models.Claim, error)
 	ListClaims(ctx context.Context, userID *uuid.UUID, policyID *uuid.UUID, status string, limit, offset int) ([]*models.Claim, error)
+	List(ctx context.Context, filter *models.ClaimFilter, page *models.CursorPage) (*models.ClaimPage, error)
 	UpdateClaim(ctx context.Context, claim *models.Claim) error
 	DeleteClaim(ctx context.Context, id uuid.UUID) error
 	CountClaims(ctx context.Context, userID *uuid.UUID, policyID *uuid.UUID, status string) (int64, error)
+	CountUsersByDevice(ctx context.Context, deviceFingerprint string, excludeUserID uuid.UUID) (int64, error)
 }
 
 // claimStore implements ClaimStore interface.
@@ -41,7 +53,7 @@ func (s *claimStore) CreateClaim(ctx context.Context, claim *models.Claim) error
 // GetClaim retrieves a claim by ID.
 func (s *claimStore) GetClaim(ctx context.Context, id uuid.UUID) (*models.Claim, error) {
 	var claim models.Claim
-	if err := s.db.WithContext(ctx).Preload("Policy").Preload("User").First(&claim, "id = ?", id).Error; err != nil {
+	if err := readDB(ctx, s.db).Preload("Policy").Preload("User").First(&claim, "id = ?", id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("claim not found")
 		}
@@ -53,7 +65,7 @@ func (s *claimStore) GetClaim(ctx context.Context, id uuid.UUID) (*models.Claim,
 // GetClaimByNumber retrieves a claim by claim number.
 func (s *claimStore) GetClaimByNumber(ctx context.Context, claimNumber string) (*models.Claim, error) {
 	var claim models.Claim
-	if err := s.db.WithContext(ctx).Preload("Policy").Preload("User").First(&claim, "claim_number = ?", claimNumber).Error; err != nil {
+	if err := readDB(ctx, s.db).Preload("Policy").Preload("User").First(&claim, "claim_number = ?", claimNumber).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("claim not found")
 		}
@@ -65,7 +77,7 @@ func (s *claimStore) GetClaimByNumber(ctx context.Context, claimNumber string) (
 // ListClaims retrieves a list of claims with optional filtering.
 func (s *claimStore) ListClaims(ctx context.Context, userID *uuid.UUID, policyID *uuid.UUID, status string, limit, offset int) ([]*models.Claim, error) {
 	var claims []*models.Claim
-	query := s.db.WithContext(ctx).Model(&models.Claim{}).Preload("Policy").Preload("User")
+	query := readDB(ctx, s.db).Model(&models.Claim{}).Preload("Policy").Preload("User")
 
 	if userID != nil {
 		query = query.Where("user_id = ?", *userID)
@@ -90,10 +102,126 @@ func (s *claimStore) ListClaims(ctx context.Context, userID *uuid.UUID, policyID
 	return claims, nil
 }
 
+// List retrieves a page of claims matching the filter, most recently reported first.
+// Pages are keyed on reported date and ID, so a cursor resumes correctly even when
+// claims are added between requests.
+func (s *claimStore) List(ctx context.Context, filter *models.ClaimFilter, page *models.CursorPage) (*models.ClaimPage, error) {
+	if filter == nil {
+		filter = &models.ClaimFilter{}
+	}
+	if page == nil {
+		page = &models.CursorPage{}
+	}
+
+	limit := page.Limit
+	if limit <= 0 {
+		limit = defaultClaimPageSize
+	}
+	if limit > maxClaimPageSize {
+		limit = maxClaimPageSize
+	}
+
+	query := readDB(ctx, s.db).Model(&models.Claim{}).Preload("Policy").Preload("User")
+
+	if filter.UserID != nil {
+		query = query.Where("user_id = ?", *filter.UserID)
+	}
+	if filter.PolicyID != nil {
+		query = query.Where("policy_id = ?", *filter.PolicyID)
+	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN ?", filter.Statuses)
+	}
+	if filter.ReportedFrom != nil {
+		query = query.Where("reported_date >= ?", *filter.ReportedFrom)
+	}
+	if filter.ReportedTo != nil {
+		query = query.Where("reported_date <= ?", *filter.ReportedTo)
+	}
+
+	if page.Cursor != "" {
+		reportedDate, id, err := decodeClaimCursor(page.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("reported_date < ? OR (reported_date = ? AND id < ?)", reportedDate, reportedDate, id)
+	}
+
+	var claims []*models.Claim
+	if err := query.Order("reported_date DESC").Order("id DESC").Limit(limit + 1).Find(&claims).Error; err != nil {
+		return nil, fmt.Errorf("failed to list claims: %w", err)
+	}
+
+	result := &models.ClaimPage{Claims: claims}
+	if len(claims) > limit {
+		result.Claims = claims[:limit]
+		last := result.Claims[limit-1]
+		result.NextCursor = encodeClaimCursor(last.ReportedDate, last.ID)
+	}
+	return result, nil
+}
+
+// encodeClaimCursor encodes the position of a claim in the reported date ordering.
+func encodeClaimCursor(reportedDate time.Time, id uuid.UUID) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(reportedDate.UTC().Format(time.RFC3339Nano) + "|" + id.String()))
+}
+
+// decodeClaimCursor decodes a cursor produced by encodeClaimCursor.
+func decodeClaimCursor(cursor string) (time.Time, uuid.UUID, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, uuid.Nil, fmt.Errorf("invalid claim cursor: %w", err)
+	}
+	reportedPart, idPart, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return time.Time{}, uuid.Nil, fmt.Errorf("invalid claim cursor")
+	}
+	reportedDate, err := time.Parse(time.RFC3339Nano, reportedPart)
+	if err != nil {
+		return time.Time{}, uuid.Nil, fmt.Errorf("invalid claim cursor: %w", err)
+	}
+	id, err := uuid.Parse(idPart)
+	if err != nil {
+		return time.Time{}, uuid.Nil, fmt.Errorf("invalid claim cursor: %w", err)
+	}
+	return reportedDate, id, nil
+}
+
 // UpdateClaim updates an existing claim.
+// The update only applies if the stored version matches claim.Version; otherwise
+// ErrVersionConflict is returned, or ErrNotFound if the claim does not exist. On success
+// claim.Version is incremented.
 func (s *claimStore) UpdateClaim(ctx context.Context, claim *models.Claim) error {
-	if err := s.db.WithContext(ctx).Save(claim).Error; err != nil {
-		return fmt.Errorf("failed to update claim: %w", err)
+	return updateClaim(s.db.WithContext(ctx), claim)
+}
+
+// updateClaim saves a claim on db if its version is unchanged since it was read, bumping
+// the version. It is shared by the stores that update a claim inside their own transaction.
+func updateClaim(db *gorm.DB, claim *models.Claim) error {
+	version := claim.Version
+	claim.Version = version + 1
+
+	result := db.
+		Model(claim).
+		Where("version = ?", version).
+		Select("*").
+		Omit(clause.Associations).
+		Updates(claim)
+	if result.Error != nil {
+		claim.Version = version
+		return fmt.Errorf("failed to update claim: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		claim.Version = version
+		// Nothing matched either because the version moved on or because the claim is gone.
+		var count int64
+		if err := db.Model(&models.Claim{}).Where("id = ?", claim.ID).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to update claim: %w", err)
+		}
+		if count == 0 {
+			return fmt.Errorf("claim %w", ErrNotFound)
+		}
+		return fmt.Errorf("failed to update claim %s: %w", claim.ID, ErrVersionConflict)
 	}
 	return nil
 }
@@ -109,7 +237,7 @@ func (s *claimStore) DeleteClaim(ctx context.Context, id uuid.UUID) error {
 // CountClaims returns the total number of claims with optional filtering.
 func (s *claimStore) CountClaims(ctx context.Context, userID *uuid.UUID, policyID *uuid.UUID, status string) (int64, error) {
 	var count int64
-	query := s.db.WithContext(ctx).Model(&models.Claim{})
+	query := readDB(ctx, s.db).Model(&models.Claim{})
 
 	if userID != nil {
 		query = query.Where("user_id = ?", *userID)
@@ -126,3 +254,15 @@ func (s *claimStore) CountClaims(ctx context.Context, userID *uuid.UUID, policyI
 	}
 	return count, nil
 }
+
+// CountUsersByDevice returns the number of other users who submitted claims from a device.
+func (s *claimStore) CountUsersByDevice(ctx context.Context, deviceFingerprint string, excludeUserID uuid.UUID) (int64, error) {
+	var count int64
+	if err := readDB(ctx, s.db).Model(&models.Claim{}).
+		Where("device_fingerprint = ? AND user_id <> ?", deviceFingerprint, excludeUserID).
+		Distinct("user_id").
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count users by device: %w", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,65 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ClaimRecoveryStore defines the interface for claim recovery data operations.
+type ClaimRecoveryStore interface {
+	CreateRecovery(ctx context.Context, recovery *models.ClaimRecovery) error
+	GetRecovery(ctx context.Context, id uuid.UUID) (*models.ClaimRecovery, error)
+	ListRecoveriesByClaim(ctx context.Context, claimID uuid.UUID) ([]*models.ClaimRecovery, error)
+	UpdateRecovery(ctx context.Context, recovery *models.ClaimRecovery) error
+}
+
+// claimRecoveryStore implements ClaimRecoveryStore interface.
+type claimRecoveryStore struct {
+	db *gorm.DB
+}
+
+// NewClaimRecoveryStore creates a new ClaimRecoveryStore instance.
+func NewClaimRecoveryStore(db *gorm.DB) ClaimRecoveryStore {
+	return &claimRecoveryStore{db: db}
+}
+
+// CreateRecovery creates a new claim recovery.
+func (s *claimRecoveryStore) CreateRecovery(ctx context.Context, recovery *models.ClaimRecovery) error {
+	if err := s.db.WithContext(ctx).Create(recovery).Error; err != nil {
+		return fmt.Errorf("failed to create claim recovery: %w", err)
+	}
+	return nil
+}
+
+// GetRecovery retrieves a claim recovery by ID.
+func (s *claimRecoveryStore) GetRecovery(ctx context.Context, id uuid.UUID) (*models.ClaimRecovery, error) {
+	var recovery models.ClaimRecovery
+	if err := readDB(ctx, s.db).First(&recovery, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("claim recovery not found")
+		}
+		return nil, fmt.Errorf("failed to get claim recovery: %w", err)
+	}
+	return &recovery, nil
+}
+
+// ListRecoveriesByClaim retrieves all recoveries recorded against a claim.
+func (s *claimRecoveryStore) ListRecoveriesByClaim(ctx context.Context, claimID uuid.UUID) ([]*models.ClaimRecovery, error) {
+	var recoveries []*models.ClaimRecovery
+	if err := readDB(ctx, s.db).Where("claim_id = ?", claimID).Order("created_at ASC").Find(&recoveries).Error; err != nil {
+		return nil, fmt.Errorf("failed to list claim recoveries: %w", err)
+	}
+	return recoveries, nil
+}
+
+// UpdateRecovery updates an existing claim recovery.
+func (s *claimRecoveryStore) UpdateRecovery(ctx context.Context, recovery *models.ClaimRecovery) error {
+	if err := s.db.WithContext(ctx).Save(recovery).Error; err != nil {
+		return fmt.Errorf("failed to update claim recovery: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ClaimReserveStore defines the interface for claim reserve data operations.
+type ClaimReserveStore interface {
+	CreateReserve(ctx context.Context, reserve *models.ClaimReserve) error
+	GetReserveByClaimID(ctx context.Context, claimID uuid.UUID) (*models.ClaimReserve, error)
+	UpdateReserve(ctx context.Context, reserve *models.ClaimReserve) error
+}
+
+// claimReserveStore implements ClaimReserveStore interface.
+type claimReserveStore struct {
+	db *gorm.DB
+}
+
+// NewClaimReserveStore creates a new ClaimReserveStore instance.
+func NewClaimReserveStore(db *gorm.DB) ClaimReserveStore {
+	return &claimReserveStore{db: db}
+}
+
+// CreateReserve creates a new claim reserve.
+func (s *claimReserveStore) CreateReserve(ctx context.Context, reserve *models.ClaimReserve) error {
+	if err := s.db.WithContext(ctx).Create(reserve).Error; err != nil {
+		return fmt.Errorf("failed to create claim reserve: %w", err)
+	}
+	return nil
+}
+
+// GetReserveByClaimID retrieves the reserve held against a claim.
+func (s *claimReserveStore) GetReserveByClaimID(ctx context.Context, claimID uuid.UUID) (*models.ClaimReserve, error) {
+	var reserve models.ClaimReserve
+	if err := readDB(ctx, s.db).First(&reserve, "claim_id = ?", claimID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("claim reserve %w", ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get claim reserve: %w", err)
+	}
+	return &reserve, nil
+}
+
+// UpdateReserve updates an existing claim reserve.
+func (s *claimReserveStore) UpdateReserve(ctx context.Context, reserve *models.ClaimReserve) error {
+	if err := s.db.WithContext(ctx).Save(reserve).Error; err != nil {
+		return fmt.Errorf("failed to update claim reserve: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListClaimsFiltersAndResumesFromCursor(t *testing.T) {
+	ctx := context.Background()
+	claimStore := NewClaimStore(newTestDB(t))
+
+	userID := uuid.New()
+	base := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	create := func(number string, status string, reported time.Time) *models.Claim {
+		claim := &models.Claim{
+			ClaimNumber:  number,
+			PolicyID:     uuid.New(),
+			UserID:       userID,
+			Title:        "Claim",
+			Description:  "Claim description",
+			ClaimAmount:  1000,
+			Status:       status,
+			IncidentDate: reported.AddDate(0, 0, -1),
+			ReportedDate: reported,
+		}
+		require.NoError(t, claimStore.CreateClaim(ctx, claim))
+		return claim
+	}
+
+	// Two claims share a reported date so the cursor has to break the tie on ID.
+	var want []*models.Claim
+	for i, days := range []int{1, 2, 2, 4, 5} {
+		want = append(want, create(fmt.Sprintf("CLM-%d", i), models.ClaimStatusSubmitted, base.AddDate(0, 0, days)))
+	}
+	create("CLM-approved", models.ClaimStatusApproved, base.AddDate(0, 0, 3))
+	create("CLM-early", models.ClaimStatusSubmitted, base.AddDate(0, 0, -10))
+	create("CLM-late", models.ClaimStatusSubmitted, base.AddDate(0, 0, 30))
+
+	from, to := base, base.AddDate(0, 0, 10)
+	filter := &models.ClaimFilter{
+		UserID:       &userID,
+		Statuses:     []string{models.ClaimStatusSubmitted},
+		ReportedFrom: &from,
+		ReportedTo:   &to,
+	}
+
+	var got []*models.Claim
+	page := &models.CursorPage{Limit: 2}
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 5, "cursor did not terminate")
+		result, err := claimStore.List(ctx, filter, page)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(result.Claims), 2)
+		got = append(got, result.Claims...)
+		if result.NextCursor == "" {
+			break
+		}
+		page = &models.CursorPage{Cursor: result.NextCursor, Limit: 2}
+	}
+
+	require.Len(t, got, len(want))
+	seen := make(map[uuid.UUID]bool)
+	for i, claim := range got {
+		assert.Equal(t, models.ClaimStatusSubmitted, claim.Status)
+		assert.False(t, seen[claim.ID], "claim %s returned twice", claim.ClaimNumber)
+		seen[claim.ID] = true
+		if i > 0 {
+			assert.False(t, claim.ReportedDate.After(got[i-1].ReportedDate), "claims not ordered newest first")
+		}
+	}
+
+	_, err := claimStore.List(ctx, filter, &models.CursorPage{Cursor: "not-a-cursor"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,65 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ClaimWorkflowStore defines the interface for claim workflow state data operations.
+type ClaimWorkflowStore interface {
+	// GetWorkflow returns the workflow state of a claim. It returns an error wrapping
+	// ErrNotFound when no workflow has been saved for the claim.
+	GetWorkflow(ctx context.Context, claimID uuid.UUID) (*models.ClaimWorkflowState, error)
+	// SaveWorkflow creates or replaces the workflow state of its claim.
+	SaveWorkflow(ctx context.Context, state *models.ClaimWorkflowState) error
+}
+
+// claimWorkflowStore implements ClaimWorkflowStore interface.
+type claimWorkflowStore struct {
+	db *gorm.DB
+}
+
+// NewClaimWorkflowStore creates a new ClaimWorkflowStore instance.
+func NewClaimWorkflowStore(db *gorm.DB) ClaimWorkflowStore {
+	return &claimWorkflowStore{db: db}
+}
+
+// GetWorkflow retrieves the workflow state of a claim. The state is read from the primary so
+// a workflow is never resumed from a stale replica.
+func (s *claimWorkflowStore) GetWorkflow(ctx context.Context, claimID uuid.UUID) (*models.ClaimWorkflowState, error) {
+	var state models.ClaimWorkflowState
+	if err := s.db.WithContext(ctx).First(&state, "claim_id = ?", claimID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("claim workflow %w", ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get claim workflow: %w", err)
+	}
+	return &state, nil
+}
+
+// SaveWorkflow creates the claim's workflow state, or replaces the one already stored.
+func (s *claimWorkflowStore) SaveWorkflow(ctx context.Context, state *models.ClaimWorkflowState) error {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing models.ClaimWorkflowState
+		err := tx.Select("id", "created_at").First(&existing, "claim_id = ?", state.ClaimID).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return tx.Create(state).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		state.ID = existing.ID
+		state.CreatedAt = existing.CreatedAt
+		return tx.Save(state).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save claim workflow: %w", err)
+	}
+	return nil
+}
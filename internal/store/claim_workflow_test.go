@@ -0,0 +1,33 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveWorkflowReplacesTheClaimsWorkflow(t *testing.T) {
+	ctx := context.Background()
+	workflowStore := NewClaimWorkflowStore(newTestDB(t))
+
+	claimID := uuid.New()
+	_, err := workflowStore.GetWorkflow(ctx, claimID)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, workflowStore.SaveWorkflow(ctx, &models.ClaimWorkflowState{
+		ClaimID: claimID, Status: "in_progress", CurrentStage: "initial_review", Workflow: `{"status":"in_progress"}`,
+	}))
+	require.NoError(t, workflowStore.SaveWorkflow(ctx, &models.ClaimWorkflowState{
+		ClaimID: claimID, Status: "failed", CurrentStage: "fraud_detection", Workflow: `{"status":"failed"}`,
+	}))
+
+	state, err := workflowStore.GetWorkflow(ctx, claimID)
+	require.NoError(t, err)
+	assert.Equal(t, "failed", state.Status)
+	assert.Equal(t, "fraud_detection", state.CurrentStage)
+	assert.JSONEq(t, `{"status":"failed"}`, state.Workflow)
+}
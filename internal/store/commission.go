@@ -0,0 +1,111 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CommissionStore defines the interface for commission data operations.
+type CommissionStore interface {
+	CreateCommission(ctx context.Context, commission *models.Commission) error
+	CreateCommissions(ctx context.Context, commissions []*models.Commission) error
+	GetCommission(ctx context.Context, id uuid.UUID) (*models.Commission, error)
+	ListCommissionsByPolicy(ctx context.Context, policyID uuid.UUID) ([]*models.Commission, error)
+	ListCommissionsByPartner(ctx context.Context, partnerID uuid.UUID, from, to time.Time) ([]*models.Commission, error)
+	ListPaidCommissionsByPartner(ctx context.Context, partnerID uuid.UUID, from, to time.Time) ([]*models.Commission, error)
+	UpdateCommission(ctx context.Context, commission *models.Commission) error
+}
+
+// commissionStore implements CommissionStore interface.
+type commissionStore struct {
+	db *gorm.DB
+}
+
+// NewCommissionStore creates a new CommissionStore instance.
+func NewCommissionStore(db *gorm.DB) CommissionStore {
+	return &commissionStore{db: db}
+}
+
+// CreateCommission creates a new commission.
+func (s *commissionStore) CreateCommission(ctx context.Context, commission *models.Commission) error {
+	if err := s.db.WithContext(ctx).Create(commission).Error; err != nil {
+		return fmt.Errorf("failed to create commission: %w", err)
+	}
+	return nil
+}
+
+// CreateCommissions creates several commissions in one transaction, so either all of them
+// are stored or none is.
+func (s *commissionStore) CreateCommissions(ctx context.Context, commissions []*models.Commission) error {
+	if len(commissions) == 0 {
+		return nil
+	}
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Create(commissions).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create commissions: %w", err)
+	}
+	return nil
+}
+
+// GetCommission retrieves a commission by ID.
+func (s *commissionStore) GetCommission(ctx context.Context, id uuid.UUID) (*models.Commission, error) {
+	var commission models.Commission
+	if err := readDB(ctx, s.db).First(&commission, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("commission not found")
+		}
+		return nil, fmt.Errorf("failed to get commission: %w", err)
+	}
+	return &commission, nil
+}
+
+// ListCommissionsByPolicy retrieves all commissions recorded on a policy, oldest first.
+func (s *commissionStore) ListCommissionsByPolicy(ctx context.Context, policyID uuid.UUID) ([]*models.Commission, error) {
+	var commissions []*models.Commission
+	if err := readDB(ctx, s.db).
+		Where("policy_id = ?", policyID).
+		Order("created_at ASC").
+		Find(&commissions).Error; err != nil {
+		return nil, fmt.Errorf("failed to list commissions: %w", err)
+	}
+	return commissions, nil
+}
+
+// ListCommissionsByPartner retrieves the commissions recorded for a partner between from and to inclusive.
+func (s *commissionStore) ListCommissionsByPartner(ctx context.Context, partnerID uuid.UUID, from, to time.Time) ([]*models.Commission, error) {
+	var commissions []*models.Commission
+	if err := readDB(ctx, s.db).
+		Where("partner_id = ? AND created_at >= ? AND created_at <= ?", partnerID, from, to).
+		Order("created_at ASC").
+		Find(&commissions).Error; err != nil {
+		return nil, fmt.Errorf("failed to list partner commissions: %w", err)
+	}
+	return commissions, nil
+}
+
+// ListPaidCommissionsByPartner retrieves the commissions paid to a partner between from and to inclusive.
+func (s *commissionStore) ListPaidCommissionsByPartner(ctx context.Context, partnerID uuid.UUID, from, to time.Time) ([]*models.Commission, error) {
+	var commissions []*models.Commission
+	if err := readDB(ctx, s.db).
+		Where("partner_id = ? AND status = ? AND paid_at >= ? AND paid_at <= ?", partnerID, models.CommissionStatusPaid, from, to).
+		Order("paid_at ASC").
+		Find(&commissions).Error; err != nil {
+		return nil, fmt.Errorf("failed to list paid commissions: %w", err)
+	}
+	return commissions, nil
+}
+
+// UpdateCommission updates an existing commission.
+func (s *commissionStore) UpdateCommission(ctx context.Context, commission *models.Commission) error {
+	if err := s.db.WithContext(ctx).Save(commission).Error; err != nil {
+		return fmt.Errorf("failed to update commission: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateCommissionsStoresAllOrNone(t *testing.T) {
+	ctx := context.Background()
+	commissions := NewCommissionStore(newTestDB(t))
+
+	policyID := uuid.New()
+	commission := func(role string) *models.Commission {
+		return &models.Commission{PolicyID: policyID, PartnerID: uuid.New(), CommissionType: models.CommissionTypeInitial, Role: role, Amount: 100}
+	}
+
+	existing := commission("writing")
+	require.NoError(t, commissions.CreateCommission(ctx, existing))
+
+	// The override reuses a stored ID, so the batch fails and the writing commission is not kept
+	writing := commission("writing")
+	override := commission("override")
+	override.ID = existing.ID
+	assert.Error(t, commissions.CreateCommissions(ctx, []*models.Commission{writing, override}))
+
+	stored, err := commissions.ListCommissionsByPolicy(ctx, policyID)
+	require.NoError(t, err)
+	assert.Len(t, stored, 1)
+
+	require.NoError(t, commissions.CreateCommissions(ctx, []*models.Commission{commission("writing"), commission("override")}))
+	stored, err = commissions.ListCommissionsByPolicy(ctx, policyID)
+	require.NoError(t, err)
+	assert.Len(t, stored, 3)
+}
@@ -0,0 +1,72 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"gorm.io/gorm"
+)
+
+// ConfigSnapshotStore persists the business rules configuration history.
+type ConfigSnapshotStore interface {
+	config.SnapshotStore
+}
+
+// configSnapshotStore implements ConfigSnapshotStore interface.
+type configSnapshotStore struct {
+	db *gorm.DB
+}
+
+// NewConfigSnapshotStore creates a new ConfigSnapshotStore instance.
+func NewConfigSnapshotStore(db *gorm.DB) ConfigSnapshotStore {
+	return &configSnapshotStore{db: db}
+}
+
+// SaveSnapshot stores a configuration snapshot. The sequence is unique, so a snapshot
+// another instance stored first under the same sequence makes the save fail.
+func (s *configSnapshotStore) SaveSnapshot(ctx context.Context, snapshot *config.ConfigSnapshot) error {
+	encoded, err := json.Marshal(snapshot.Config)
+	if err != nil {
+		return fmt.Errorf("failed to encode config snapshot: %w", err)
+	}
+
+	record := &models.ConfigSnapshot{
+		Sequence: snapshot.Sequence,
+		Version:  snapshot.Version,
+		Hash:     snapshot.Hash,
+		LoadedAt: snapshot.LoadedAt,
+		Config:   string(encoded),
+	}
+	if err := s.db.WithContext(ctx).Create(record).Error; err != nil {
+		return fmt.Errorf("failed to save config snapshot: %w", err)
+	}
+	return nil
+}
+
+// ListSnapshots returns the stored configuration snapshots ordered by sequence. The history
+// is read from the primary so a new snapshot is never numbered from a stale replica.
+func (s *configSnapshotStore) ListSnapshots(ctx context.Context) ([]config.ConfigSnapshot, error) {
+	var records []models.ConfigSnapshot
+	if err := s.db.WithContext(ctx).Order("sequence ASC").Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to list config snapshots: %w", err)
+	}
+
+	snapshots := make([]config.ConfigSnapshot, 0, len(records))
+	for _, record := range records {
+		var rules config.BusinessRulesConfig
+		if err := json.Unmarshal([]byte(record.Config), &rules); err != nil {
+			return nil, fmt.Errorf("failed to decode config snapshot %d: %w", record.Sequence, err)
+		}
+		snapshots = append(snapshots, config.ConfigSnapshot{
+			Sequence: record.Sequence,
+			Version:  record.Version,
+			Hash:     record.Hash,
+			LoadedAt: record.LoadedAt,
+			Config:   &rules,
+		})
+	}
+	return snapshots, nil
+}
@@ -0,0 +1,34 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/config"
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigSnapshotsAreListedInOrderAndSequencesAreUnique(t *testing.T) {
+	ctx := context.Background()
+	snapshotStore := NewConfigSnapshotStore(newTestDB(t))
+
+	rules := config.NewManager(logger.NewLogger("error", "json"), "").GetConfig()
+	for i, version := range []string{"1.0", "1.1"} {
+		require.NoError(t, snapshotStore.SaveSnapshot(ctx, &config.ConfigSnapshot{
+			Sequence: i + 1, Version: version, Hash: "hash-" + version, LoadedAt: time.Now(), Config: rules,
+		}))
+	}
+	assert.Error(t, snapshotStore.SaveSnapshot(ctx, &config.ConfigSnapshot{
+		Sequence: 2, Version: "1.2", Hash: "hash-1.2", LoadedAt: time.Now(), Config: rules,
+	}), "another instance already stored the second snapshot")
+
+	snapshots, err := snapshotStore.ListSnapshots(ctx)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+	assert.Equal(t, "1.0", snapshots[0].Version)
+	assert.Equal(t, "1.1", snapshots[1].Version)
+	assert.Equal(t, rules.FraudDetection.RiskThresholds.High, snapshots[1].Config.FraudDetection.RiskThresholds.High)
+}
@@ -40,7 +40,7 @@ func (s *coverageStore) CreateCoverage(ctx context.Context, coverage *models.Cov
 // GetCoverage retrieves a coverage by ID.
 func (s *coverageStore) GetCoverage(ctx context.Context, id uuid.UUID) (*models.Coverage, error) {
 	var coverage models.Coverage
-	if err := s.db.WithContext(ctx).Preload("Product").First(&coverage, "id = ?", id).Error; err != nil {
+	if err := readDB(ctx, s.db).Preload("Product").First(&coverage, "id = ?", id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("coverage not found")
 		}
@@ -52,7 +52,7 @@ func (s *coverageStore) GetCoverage(ctx context.Context, id uuid.UUID) (*models.
 // ListCoverages retrieves a list of coverages with optional filtering.
 func (s *coverageStore) ListCoverages(ctx context.Context, productID *uuid.UUID, coverageType string, limit, offset int) ([]*models.Coverage, error) {
 	var coverages []*models.Coverage
-	query := s.db.WithContext(ctx).Model(&models.Coverage{}).Preload("Product")
+	query := readDB(ctx, s.db).Model(&models.Coverage{}).Preload("Product")
 
 	if productID != nil {
 		query = query.Where("product_id = ?", *productID)
@@ -95,7 +95,7 @@ func (s *coverageStore) DeleteCoverage(ctx context.Context, id uuid.UUID) error
 // CountCoverages returns the total number of coverages with optional filtering.
 func (s *coverageStore) CountCoverages(ctx context.Context, productID *uuid.UUID, coverageType string) (int64, error) {
 	var count int64
-	query := s.db.WithContext(ctx).Model(&models.Coverage{})
+	query := readDB(ctx, s.db).Model(&models.Coverage{})
 
 	if productID != nil {
 		query = query.Where("product_id = ?", *productID)
@@ -3,6 +3,7 @@ package store
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/edsonmichaque/bazaruto/internal/models"
 	"github.com/google/uuid"
@@ -100,6 +101,9 @@ type CustomerStore interface {
 
 	// GetCustomersRequiringAML retrieves customers requiring AML review.
 	GetCustomersRequiringAML(ctx context.Context, limit, offset int) ([]*models.Customer, error)
+
+	// GetCustomersWithKYCVerifiedBefore retrieves verified customers whose KYC was last verified before a time.
+	GetCustomersWithKYCVerifiedBefore(ctx context.Context, before time.Time, limit int) ([]*models.Customer, error)
 }
 
 // customerStore implements CustomerStore interface.
@@ -123,9 +127,9 @@ func (s *customerStore) Create(ctx context.Context, customer *models.Customer) e
 // GetByID retrieves a customer by ID.
 func (s *customerStore) GetByID(ctx context.Context, id uuid.UUID) (*models.Customer, error) {
 	var customer models.Customer
-	if err := s.db.WithContext(ctx).First(&customer, "id = ?", id).Error; err != nil {
+	if err := readDB(ctx, s.db).First(&customer, "id = ?", id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("customer not found")
+			return nil, fmt.Errorf("customer %w", ErrNotFound)
 		}
 		return nil, fmt.Errorf("failed to get customer: %w", err)
 	}
@@ -135,9 +139,9 @@ func (s *customerStore) GetByID(ctx context.Context, id uuid.UUID) (*models.Cust
 // GetByUserID retrieves a customer by user ID.
 func (s *customerStore) GetByUserID(ctx context.Context, userID uuid.UUID) (*models.Customer, error) {
 	var customer models.Customer
-	if err := s.db.WithContext(ctx).First(&customer, "user_id = ?", userID).Error; err != nil {
+	if err := readDB(ctx, s.db).First(&customer, "user_id = ?", userID).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("customer not found")
+			return nil, fmt.Errorf("customer %w", ErrNotFound)
 		}
 		return nil, fmt.Errorf("failed to get customer by user ID: %w", err)
 	}
@@ -147,9 +151,9 @@ func (s *customerStore) GetByUserID(ctx context.Context, userID uuid.UUID) (*mod
 // GetByCustomerNumber retrieves a customer by customer number.
 func (s *customerStore) GetByCustomerNumber(ctx context.Context, customerNumber string) (*models.Customer, error) {
 	var customer models.Customer
-	if err := s.db.WithContext(ctx).First(&customer, "customer_number = ?", customerNumber).Error; err != nil {
+	if err := readDB(ctx, s.db).First(&customer, "customer_number = ?", customerNumber).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("customer not found")
+			return nil, fmt.Errorf("customer %w", ErrNotFound)
 		}
 		return nil, fmt.Errorf("failed to get customer by number: %w", err)
 	}
@@ -159,9 +163,9 @@ func (s *customerStore) GetByCustomerNumber(ctx context.Context, customerNumber
 // GetByEmail retrieves a customer by email.
 func (s *customerStore) GetByEmail(ctx context.Context, email string) (*models.Customer, error) {
 	var customer models.Customer
-	if err := s.db.WithContext(ctx).First(&customer, "email = ?", email).Error; err != nil {
+	if err := readDB(ctx, s.db).First(&customer, "email = ?", email).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("customer not found")
+			return nil, fmt.Errorf("customer %w", ErrNotFound)
 		}
 		return nil, fmt.Errorf("failed to get customer by email: %w", err)
 	}
@@ -189,7 +193,7 @@ func (s *customerStore) List(ctx context.Context, opts *models.CustomerListOptio
 	var customers []*models.Customer
 	var total int64
 
-	query := s.db.WithContext(ctx).Model(&models.Customer{})
+	query := readDB(ctx, s.db).Model(&models.Customer{})
 
 	// Apply filters
 	if opts != nil {
@@ -239,7 +243,7 @@ func (s *customerStore) List(ctx context.Context, opts *models.CustomerListOptio
 // Count returns the total number of customers with optional filtering.
 func (s *customerStore) Count(ctx context.Context, opts *models.CustomerListOptions) (int64, error) {
 	var count int64
-	query := s.db.WithContext(ctx).Model(&models.Customer{})
+	query := readDB(ctx, s.db).Model(&models.Customer{})
 
 	// Apply filters
 	if opts != nil {
@@ -316,8 +320,18 @@ func (s *customerStore) RemoveDocument(ctx context.Context, customerID uuid.UUID
 	return nil
 }
 
+// UpdateKYCStatus updates a customer's KYC status. Moving a customer to verified stamps
+// the verification time, which starts a new re-verification window.
 func (s *customerStore) UpdateKYCStatus(ctx context.Context, customerID uuid.UUID, status string) error {
-	return s.db.WithContext(ctx).Model(&models.Customer{}).Where("id = ?", customerID).Update("kyc_status", status).Error
+	now := time.Now()
+	updates := map[string]interface{}{"kyc_status": status, "last_kyc_update": now}
+	if status == models.KYCStatusVerified {
+		updates["kyc_verified_at"] = now
+	}
+	if err := s.db.WithContext(ctx).Model(&models.Customer{}).Where("id = ?", customerID).Updates(updates).Error; err != nil {
+		return fmt.Errorf("failed to update KYC status: %w", err)
+	}
+	return nil
 }
 
 func (s *customerStore) UpdateAMLStatus(ctx context.Context, customerID uuid.UUID, status string) error {
@@ -334,7 +348,7 @@ func (s *customerStore) UpdateCustomerTier(ctx context.Context, customerID uuid.
 
 func (s *customerStore) GetCustomersByRiskProfile(ctx context.Context, riskProfile string, limit, offset int) ([]*models.Customer, error) {
 	var customers []*models.Customer
-	if err := s.db.WithContext(ctx).Where("risk_profile = ?", riskProfile).Limit(limit).Offset(offset).Find(&customers).Error; err != nil {
+	if err := readDB(ctx, s.db).Where("risk_profile = ?", riskProfile).Limit(limit).Offset(offset).Find(&customers).Error; err != nil {
 		return nil, fmt.Errorf("failed to get customers by risk profile: %w", err)
 	}
 	return customers, nil
@@ -342,7 +356,7 @@ func (s *customerStore) GetCustomersByRiskProfile(ctx context.Context, riskProfi
 
 func (s *customerStore) GetCustomersByTier(ctx context.Context, tier string, limit, offset int) ([]*models.Customer, error) {
 	var customers []*models.Customer
-	if err := s.db.WithContext(ctx).Where("tier = ?", tier).Limit(limit).Offset(offset).Find(&customers).Error; err != nil {
+	if err := readDB(ctx, s.db).Where("tier = ?", tier).Limit(limit).Offset(offset).Find(&customers).Error; err != nil {
 		return nil, fmt.Errorf("failed to get customers by tier: %w", err)
 	}
 	return customers, nil
@@ -350,7 +364,7 @@ func (s *customerStore) GetCustomersByTier(ctx context.Context, tier string, lim
 
 func (s *customerStore) GetHighRiskCustomers(ctx context.Context, limit, offset int) ([]*models.Customer, error) {
 	var customers []*models.Customer
-	if err := s.db.WithContext(ctx).Where("risk_profile = ?", "high").Limit(limit).Offset(offset).Find(&customers).Error; err != nil {
+	if err := readDB(ctx, s.db).Where("risk_profile = ?", "high").Limit(limit).Offset(offset).Find(&customers).Error; err != nil {
 		return nil, fmt.Errorf("failed to get high-risk customers: %w", err)
 	}
 	return customers, nil
@@ -358,7 +372,7 @@ func (s *customerStore) GetHighRiskCustomers(ctx context.Context, limit, offset
 
 func (s *customerStore) GetCustomersRequiringKYC(ctx context.Context, limit, offset int) ([]*models.Customer, error) {
 	var customers []*models.Customer
-	if err := s.db.WithContext(ctx).Where("kyc_status = ?", "pending").Limit(limit).Offset(offset).Find(&customers).Error; err != nil {
+	if err := readDB(ctx, s.db).Where("kyc_status = ?", "pending").Limit(limit).Offset(offset).Find(&customers).Error; err != nil {
 		return nil, fmt.Errorf("failed to get customers requiring KYC: %w", err)
 	}
 	return customers, nil
@@ -366,8 +380,17 @@ func (s *customerStore) GetCustomersRequiringKYC(ctx context.Context, limit, off
 
 func (s *customerStore) GetCustomersRequiringAML(ctx context.Context, limit, offset int) ([]*models.Customer, error) {
 	var customers []*models.Customer
-	if err := s.db.WithContext(ctx).Where("aml_status = ?", "pending").Limit(limit).Offset(offset).Find(&customers).Error; err != nil {
+	if err := readDB(ctx, s.db).Where("aml_status = ?", "pending").Limit(limit).Offset(offset).Find(&customers).Error; err != nil {
 		return nil, fmt.Errorf("failed to get customers requiring AML: %w", err)
 	}
 	return customers, nil
 }
+
+func (s *customerStore) GetCustomersWithKYCVerifiedBefore(ctx context.Context, before time.Time, limit int) ([]*models.Customer, error) {
+	var customers []*models.Customer
+	if err := readDB(ctx, s.db).Where("kyc_status = ? AND kyc_verified_at < ?", models.KYCStatusVerified, before).
+		Order("kyc_verified_at ASC").Limit(limit).Find(&customers).Error; err != nil {
+		return nil, fmt.Errorf("failed to get customers with expired KYC: %w", err)
+	}
+	return customers, nil
+}
@@ -0,0 +1,155 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// eventInsertBatchSize is the number of event records inserted per statement by CreateEvents.
+const eventInsertBatchSize = 50
+
+// EventStore defines the interface for event log data operations.
+type EventStore interface {
+	CreateEvent(ctx context.Context, record *models.EventRecord) error
+	CreateEvents(ctx context.Context, records []*models.EventRecord) error
+	GetEventByEventID(ctx context.Context, eventID uuid.UUID) (*models.EventRecord, error)
+	ListEventsByAggregate(ctx context.Context, aggregateID uuid.UUID, eventType string, limit, offset int) ([]*models.EventRecord, error)
+	ListEventsInRange(ctx context.Context, from, to time.Time, eventTypes []string, after *models.EventRecord, limit int) ([]*models.EventRecord, error)
+	CountEventsByAggregate(ctx context.Context, aggregateID uuid.UUID, eventType string) (int64, error)
+	CreateDeadLetter(ctx context.Context, deadLetter *models.EventDeadLetter) error
+	ListDeadLetters(ctx context.Context, handlerName string, limit, offset int) ([]*models.EventDeadLetter, error)
+}
+
+// eventStore implements EventStore interface.
+type eventStore struct {
+	db *gorm.DB
+}
+
+// NewEventStore creates a new EventStore instance.
+func NewEventStore(db *gorm.DB) EventStore {
+	return &eventStore{db: db}
+}
+
+// CreateEvent appends an event to the event log.
+func (s *eventStore) CreateEvent(ctx context.Context, record *models.EventRecord) error {
+	if err := s.db.WithContext(ctx).Create(record).Error; err != nil {
+		return fmt.Errorf("failed to create event record: %w", err)
+	}
+	return nil
+}
+
+// CreateEvents appends a batch of events to the event log in a single transaction.
+// Either every record is stored or, on failure, none is.
+func (s *eventStore) CreateEvents(ctx context.Context, records []*models.EventRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.CreateInBatches(records, eventInsertBatchSize).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create event records: %w", err)
+	}
+	return nil
+}
+
+// GetEventByEventID retrieves a persisted event by the ID of the published event.
+func (s *eventStore) GetEventByEventID(ctx context.Context, eventID uuid.UUID) (*models.EventRecord, error) {
+	var record models.EventRecord
+	if err := readDB(ctx, s.db).First(&record, "event_id = ?", eventID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("event not found")
+		}
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+	return &record, nil
+}
+
+// ListEventsByAggregate retrieves the event history of an aggregate, oldest first.
+// An empty eventType matches all event types.
+func (s *eventStore) ListEventsByAggregate(ctx context.Context, aggregateID uuid.UUID, eventType string, limit, offset int) ([]*models.EventRecord, error) {
+	var records []*models.EventRecord
+	query := readDB(ctx, s.db).Model(&models.EventRecord{}).Where("aggregate_id = ?", aggregateID)
+
+	if eventType != "" {
+		query = query.Where("event_type = ?", eventType)
+	}
+
+	if err := query.Order("occurred_at ASC").Order("id ASC").Limit(limit).Offset(offset).Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+	return records, nil
+}
+
+// ListEventsInRange retrieves events that occurred within [from, to), ordered by occurrence
+// and ID. An empty eventTypes slice matches all event types. Pages are keyed on the last
+// record of the previous page, so events sharing an occurrence time are neither skipped
+// nor repeated; a nil after starts from the beginning of the window.
+func (s *eventStore) ListEventsInRange(ctx context.Context, from, to time.Time, eventTypes []string, after *models.EventRecord, limit int) ([]*models.EventRecord, error) {
+	var records []*models.EventRecord
+	query := readDB(ctx, s.db).Model(&models.EventRecord{}).
+		Where("occurred_at >= ? AND occurred_at < ?", from, to)
+
+	if len(eventTypes) > 0 {
+		query = query.Where("event_type IN ?", eventTypes)
+	}
+
+	if after != nil {
+		query = query.Where("occurred_at > ? OR (occurred_at = ? AND id > ?)", after.OccurredAt, after.OccurredAt, after.ID)
+	}
+
+	if err := query.Order("occurred_at ASC").Order("id ASC").Limit(limit).Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to list events in range: %w", err)
+	}
+	return records, nil
+}
+
+// CountEventsByAggregate counts the events recorded for an aggregate.
+func (s *eventStore) CountEventsByAggregate(ctx context.Context, aggregateID uuid.UUID, eventType string) (int64, error) {
+	var count int64
+	query := readDB(ctx, s.db).Model(&models.EventRecord{}).Where("aggregate_id = ?", aggregateID)
+
+	if eventType != "" {
+		query = query.Where("event_type = ?", eventType)
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count events: %w", err)
+	}
+	return count, nil
+}
+
+// CreateDeadLetter records an event a handler failed to process.
+func (s *eventStore) CreateDeadLetter(ctx context.Context, deadLetter *models.EventDeadLetter) error {
+	if err := s.db.WithContext(ctx).Create(deadLetter).Error; err != nil {
+		return fmt.Errorf("failed to create event dead letter: %w", err)
+	}
+	return nil
+}
+
+// ListDeadLetters retrieves dead-lettered events, most recent failure first. An empty
+// handlerName lists them for all handlers.
+func (s *eventStore) ListDeadLetters(ctx context.Context, handlerName string, limit, offset int) ([]*models.EventDeadLetter, error) {
+	var deadLetters []*models.EventDeadLetter
+	query := readDB(ctx, s.db).Model(&models.EventDeadLetter{}).Order("failed_at DESC")
+
+	if handlerName != "" {
+		query = query.Where("handler_name = ?", handlerName)
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+
+	if err := query.Find(&deadLetters).Error; err != nil {
+		return nil, fmt.Errorf("failed to list event dead letters: %w", err)
+	}
+	return deadLetters, nil
+}
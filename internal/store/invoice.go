@@ -3,6 +3,7 @@ package store
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/edsonmichaque/bazaruto/internal/models"
 	"github.com/google/uuid"
@@ -18,6 +19,8 @@ type InvoiceStore interface {
 	UpdateInvoice(ctx context.Context, invoice *models.Invoice) error
 	DeleteInvoice(ctx context.Context, id uuid.UUID) error
 	CountInvoices(ctx context.Context, userID *uuid.UUID, policyID *uuid.UUID, subscriptionID *uuid.UUID, status string) (int64, error)
+	GetOverdue(ctx context.Context, asOf time.Time, limit, offset int) ([]*models.Invoice, error)
+	ListPolicyInvoicesPaid(ctx context.Context, from, to time.Time) ([]*models.Invoice, error)
 }
 
 // invoiceStore implements InvoiceStore interface.
@@ -41,7 +44,7 @@ func (s *invoiceStore) CreateInvoice(ctx context.Context, invoice *models.Invoic
 // GetInvoice retrieves an invoice by ID.
 func (s *invoiceStore) GetInvoice(ctx context.Context, id uuid.UUID) (*models.Invoice, error) {
 	var invoice models.Invoice
-	if err := s.db.WithContext(ctx).Preload("User").Preload("Policy").Preload("Subscription").Preload("Payment").First(&invoice, "id = ?", id).Error; err != nil {
+	if err := readDB(ctx, s.db).Preload("User").Preload("Policy").Preload("Subscription").Preload("Payment").First(&invoice, "id = ?", id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("invoice not found")
 		}
@@ -53,7 +56,7 @@ func (s *invoiceStore) GetInvoice(ctx context.Context, id uuid.UUID) (*models.In
 // GetInvoiceByNumber retrieves an invoice by invoice number.
 func (s *invoiceStore) GetInvoiceByNumber(ctx context.Context, invoiceNumber string) (*models.Invoice, error) {
 	var invoice models.Invoice
-	if err := s.db.WithContext(ctx).Preload("User").Preload("Policy").Preload("Subscription").Preload("Payment").First(&invoice, "invoice_number = ?", invoiceNumber).Error; err != nil {
+	if err := readDB(ctx, s.db).Preload("User").Preload("Policy").Preload("Subscription").Preload("Payment").First(&invoice, "invoice_number = ?", invoiceNumber).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("invoice not found")
 		}
@@ -65,7 +68,7 @@ func (s *invoiceStore) GetInvoiceByNumber(ctx context.Context, invoiceNumber str
 // ListInvoices retrieves a list of invoices with optional filtering.
 func (s *invoiceStore) ListInvoices(ctx context.Context, userID *uuid.UUID, policyID *uuid.UUID, subscriptionID *uuid.UUID, status string, limit, offset int) ([]*models.Invoice, error) {
 	var invoices []*models.Invoice
-	query := s.db.WithContext(ctx).Model(&models.Invoice{}).Preload("User").Preload("Policy").Preload("Subscription")
+	query := readDB(ctx, s.db).Model(&models.Invoice{}).Preload("User").Preload("Policy").Preload("Subscription")
 
 	if userID != nil {
 		query = query.Where("user_id = ?", *userID)
@@ -112,7 +115,7 @@ func (s *invoiceStore) DeleteInvoice(ctx context.Context, id uuid.UUID) error {
 // CountInvoices returns the total number of invoices with optional filtering.
 func (s *invoiceStore) CountInvoices(ctx context.Context, userID *uuid.UUID, policyID *uuid.UUID, subscriptionID *uuid.UUID, status string) (int64, error) {
 	var count int64
-	query := s.db.WithContext(ctx).Model(&models.Invoice{})
+	query := readDB(ctx, s.db).Model(&models.Invoice{})
 
 	if userID != nil {
 		query = query.Where("user_id = ?", *userID)
@@ -132,3 +135,40 @@ func (s *invoiceStore) CountInvoices(ctx context.Context, userID *uuid.UUID, pol
 	}
 	return count, nil
 }
+
+// GetOverdue retrieves unpaid invoices whose due date is before asOf, oldest due date first.
+// Invoices already marked overdue are included so callers can escalate them.
+func (s *invoiceStore) GetOverdue(ctx context.Context, asOf time.Time, limit, offset int) ([]*models.Invoice, error) {
+	var invoices []*models.Invoice
+	query := readDB(ctx, s.db).Model(&models.Invoice{}).
+		Where("status IN ?", []string{models.InvoiceStatusDraft, models.InvoiceStatusSent, models.InvoiceStatusOverdue}).
+		Where("due_date < ?", asOf).
+		Order("due_date ASC")
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+
+	if err := query.Find(&invoices).Error; err != nil {
+		return nil, fmt.Errorf("failed to get overdue invoices: %w", err)
+	}
+	return invoices, nil
+}
+
+// ListPolicyInvoicesPaid retrieves the policy invoices paid from from up to, but excluding,
+// to, oldest payment first. Consecutive periods therefore never share an invoice.
+func (s *invoiceStore) ListPolicyInvoicesPaid(ctx context.Context, from, to time.Time) ([]*models.Invoice, error) {
+	var invoices []*models.Invoice
+	if err := readDB(ctx, s.db).
+		Where("policy_id IS NOT NULL").
+		Where("status = ?", models.InvoiceStatusPaid).
+		Where("paid_at >= ? AND paid_at < ?", from, to).
+		Order("paid_at ASC").
+		Find(&invoices).Error; err != nil {
+		return nil, fmt.Errorf("failed to list paid policy invoices: %w", err)
+	}
+	return invoices, nil
+}
@@ -41,7 +41,7 @@ func (s *partnerStore) CreatePartner(ctx context.Context, partner *models.Partne
 // GetPartner retrieves a partner by ID.
 func (s *partnerStore) GetPartner(ctx context.Context, id uuid.UUID) (*models.Partner, error) {
 	var partner models.Partner
-	if err := s.db.WithContext(ctx).First(&partner, "id = ?", id).Error; err != nil {
+	if err := readDB(ctx, s.db).First(&partner, "id = ?", id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("partner not found")
 		}
@@ -53,7 +53,7 @@ func (s *partnerStore) GetPartner(ctx context.Context, id uuid.UUID) (*models.Pa
 // GetPartnerByLicense retrieves a partner by license number.
 func (s *partnerStore) GetPartnerByLicense(ctx context.Context, licenseNumber string) (*models.Partner, error) {
 	var partner models.Partner
-	if err := s.db.WithContext(ctx).First(&partner, "license_number = ?", licenseNumber).Error; err != nil {
+	if err := readDB(ctx, s.db).First(&partner, "license_number = ?", licenseNumber).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("partner not found")
 		}
@@ -65,7 +65,7 @@ func (s *partnerStore) GetPartnerByLicense(ctx context.Context, licenseNumber st
 // ListPartners retrieves a list of partners with pagination.
 func (s *partnerStore) ListPartners(ctx context.Context, limit, offset int) ([]*models.Partner, error) {
 	var partners []*models.Partner
-	query := s.db.WithContext(ctx).Model(&models.Partner{})
+	query := readDB(ctx, s.db).Model(&models.Partner{})
 
 	if limit > 0 {
 		query = query.Limit(limit)
@@ -99,7 +99,7 @@ func (s *partnerStore) DeletePartner(ctx context.Context, id uuid.UUID) error {
 // CountPartners returns the total number of partners.
 func (s *partnerStore) CountPartners(ctx context.Context) (int64, error) {
 	var count int64
-	if err := s.db.WithContext(ctx).Model(&models.Partner{}).Count(&count).Error; err != nil {
+	if err := readDB(ctx, s.db).Model(&models.Partner{}).Count(&count).Error; err != nil {
 		return 0, fmt.Errorf("failed to count partners: %w", err)
 	}
 	return count, nil
@@ -0,0 +1,96 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PartnerStatementStore defines the interface for partner statement data operations.
+type PartnerStatementStore interface {
+	SavePartnerStatement(ctx context.Context, statement *models.PartnerStatement) error
+	GetPartnerStatement(ctx context.Context, id uuid.UUID) (*models.PartnerStatement, error)
+	GetLatestPartnerStatement(ctx context.Context, partnerID uuid.UUID, endingBy time.Time) (*models.PartnerStatement, error)
+	ListPartnerStatements(ctx context.Context, partnerID uuid.UUID) ([]*models.PartnerStatement, error)
+}
+
+// partnerStatementStore implements PartnerStatementStore interface.
+type partnerStatementStore struct {
+	db *gorm.DB
+}
+
+// NewPartnerStatementStore creates a new PartnerStatementStore instance.
+func NewPartnerStatementStore(db *gorm.DB) PartnerStatementStore {
+	return &partnerStatementStore{db: db}
+}
+
+// SavePartnerStatement creates the partner's statement for the statement's period, or
+// replaces the one already stored for that period, keeping its ID.
+func (s *partnerStatementStore) SavePartnerStatement(ctx context.Context, statement *models.PartnerStatement) error {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing models.PartnerStatement
+		err := tx.Unscoped().Select("id", "created_at").
+			First(&existing, "partner_id = ? AND period_start = ? AND period_end = ?", statement.PartnerID, statement.PeriodStart, statement.PeriodEnd).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return tx.Create(statement).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		statement.ID = existing.ID
+		statement.CreatedAt = existing.CreatedAt
+		statement.DeletedAt = gorm.DeletedAt{}
+		return tx.Unscoped().Save(statement).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save partner statement: %w", err)
+	}
+	return nil
+}
+
+// GetPartnerStatement retrieves a partner statement by ID.
+func (s *partnerStatementStore) GetPartnerStatement(ctx context.Context, id uuid.UUID) (*models.PartnerStatement, error) {
+	var statement models.PartnerStatement
+	if err := readDB(ctx, s.db).First(&statement, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("partner statement not found")
+		}
+		return nil, fmt.Errorf("failed to get partner statement: %w", err)
+	}
+	return &statement, nil
+}
+
+// GetLatestPartnerStatement retrieves the partner's most recent statement whose period ended
+// by endingBy. It returns nil without an error when the partner has no such statement.
+func (s *partnerStatementStore) GetLatestPartnerStatement(ctx context.Context, partnerID uuid.UUID, endingBy time.Time) (*models.PartnerStatement, error) {
+	var statements []*models.PartnerStatement
+	if err := readDB(ctx, s.db).
+		Where("partner_id = ? AND period_end <= ?", partnerID, endingBy).
+		Order("period_end DESC").
+		Limit(1).
+		Find(&statements).Error; err != nil {
+		return nil, fmt.Errorf("failed to get latest partner statement: %w", err)
+	}
+	if len(statements) == 0 {
+		return nil, nil
+	}
+	return statements[0], nil
+}
+
+// ListPartnerStatements retrieves a partner's statements, most recent period first.
+func (s *partnerStatementStore) ListPartnerStatements(ctx context.Context, partnerID uuid.UUID) ([]*models.PartnerStatement, error) {
+	var statements []*models.PartnerStatement
+	if err := readDB(ctx, s.db).
+		Where("partner_id = ?", partnerID).
+		Order("period_end DESC").
+		Find(&statements).Error; err != nil {
+		return nil, fmt.Errorf("failed to list partner statements: %w", err)
+	}
+	return statements, nil
+}
@@ -0,0 +1,39 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSavePartnerStatementReplacesStatementForSamePeriod(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	statements := NewPartnerStatementStore(db)
+
+	partnerID := uuid.New()
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0).Add(-time.Second)
+	statement := func(closing float64) *models.PartnerStatement {
+		return &models.PartnerStatement{PartnerID: partnerID, PeriodStart: start, PeriodEnd: end, Currency: "USD", ClosingBalance: closing}
+	}
+
+	first := statement(100)
+	require.NoError(t, statements.SavePartnerStatement(ctx, first))
+	regenerated := statement(140)
+	require.NoError(t, statements.SavePartnerStatement(ctx, regenerated))
+	assert.Equal(t, first.ID, regenerated.ID)
+
+	stored, err := statements.ListPartnerStatements(ctx, partnerID)
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+	assert.Equal(t, 140.0, stored[0].ClosingBalance)
+
+	// The period is unique per partner even for writes that bypass the store.
+	assert.Error(t, db.Create(statement(90)).Error)
+}
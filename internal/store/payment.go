@@ -3,6 +3,7 @@ package store
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/edsonmichaque/bazaruto/internal/models"
 	"github.com/google/uuid"
@@ -18,6 +19,7 @@ type PaymentStore interface {
 	UpdatePayment(ctx context.Context, payment *models.Payment) error
 	DeletePayment(ctx context.Context, id uuid.UUID) error
 	CountPayments(ctx context.Context, userID *uuid.UUID, policyID *uuid.UUID, subscriptionID *uuid.UUID, status string) (int64, error)
+	ListCompletedPaymentsSince(ctx context.Context, userID uuid.UUID, since time.Time, limit, offset int) ([]*models.Payment, error)
 }
 
 // paymentStore implements PaymentStore interface.
@@ -41,7 +43,7 @@ func (s *paymentStore) CreatePayment(ctx context.Context, payment *models.Paymen
 // GetPayment retrieves a payment by ID.
 func (s *paymentStore) GetPayment(ctx context.Context, id uuid.UUID) (*models.Payment, error) {
 	var payment models.Payment
-	if err := s.db.WithContext(ctx).Preload("User").Preload("Policy").Preload("Subscription").First(&payment, "id = ?", id).Error; err != nil {
+	if err := readDB(ctx, s.db).Preload("User").Preload("Policy").Preload("Subscription").First(&payment, "id = ?", id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("payment not found")
 		}
@@ -53,7 +55,7 @@ func (s *paymentStore) GetPayment(ctx context.Context, id uuid.UUID) (*models.Pa
 // GetPaymentByNumber retrieves a payment by payment number.
 func (s *paymentStore) GetPaymentByNumber(ctx context.Context, paymentNumber string) (*models.Payment, error) {
 	var payment models.Payment
-	if err := s.db.WithContext(ctx).Preload("User").Preload("Policy").Preload("Subscription").First(&payment, "payment_number = ?", paymentNumber).Error; err != nil {
+	if err := readDB(ctx, s.db).Preload("User").Preload("Policy").Preload("Subscription").First(&payment, "payment_number = ?", paymentNumber).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("payment not found")
 		}
@@ -65,7 +67,7 @@ func (s *paymentStore) GetPaymentByNumber(ctx context.Context, paymentNumber str
 // ListPayments retrieves a list of payments with optional filtering.
 func (s *paymentStore) ListPayments(ctx context.Context, userID *uuid.UUID, policyID *uuid.UUID, subscriptionID *uuid.UUID, status string, limit, offset int) ([]*models.Payment, error) {
 	var payments []*models.Payment
-	query := s.db.WithContext(ctx).Model(&models.Payment{}).Preload("User").Preload("Policy").Preload("Subscription")
+	query := readDB(ctx, s.db).Model(&models.Payment{}).Preload("User").Preload("Policy").Preload("Subscription")
 
 	if userID != nil {
 		query = query.Where("user_id = ?", *userID)
@@ -112,7 +114,7 @@ func (s *paymentStore) DeletePayment(ctx context.Context, id uuid.UUID) error {
 // CountPayments returns the total number of payments with optional filtering.
 func (s *paymentStore) CountPayments(ctx context.Context, userID *uuid.UUID, policyID *uuid.UUID, subscriptionID *uuid.UUID, status string) (int64, error) {
 	var count int64
-	query := s.db.WithContext(ctx).Model(&models.Payment{})
+	query := readDB(ctx, s.db).Model(&models.Payment{})
 
 	if userID != nil {
 		query = query.Where("user_id = ?", *userID)
@@ -132,3 +134,25 @@ func (s *paymentStore) CountPayments(ctx context.Context, userID *uuid.UUID, pol
 	}
 	return count, nil
 }
+
+// ListCompletedPaymentsSince retrieves a user's completed payments processed at or after
+// since, falling back to the creation time for payments without a processing date.
+func (s *paymentStore) ListCompletedPaymentsSince(ctx context.Context, userID uuid.UUID, since time.Time, limit, offset int) ([]*models.Payment, error) {
+	var payments []*models.Payment
+	query := readDB(ctx, s.db).
+		Where("user_id = ? AND status = ?", userID, models.PaymentStatusCompleted).
+		Where("COALESCE(processed_at, created_at) >= ?", since).
+		Order("id")
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+
+	if err := query.Find(&payments).Error; err != nil {
+		return nil, fmt.Errorf("failed to list completed payments: %w", err)
+	}
+	return payments, nil
+}
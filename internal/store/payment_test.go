@@ -0,0 +1,52 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListCompletedPaymentsSinceSkipsOlderAndIncompletePayments(t *testing.T) {
+	ctx := context.Background()
+	paymentStore := NewPaymentStore(newTestDB(t))
+
+	userID := uuid.New()
+	now := time.Now().UTC()
+	since := now.AddDate(0, 0, -30)
+	create := func(i int, user uuid.UUID, status string, processedAt *time.Time) *models.Payment {
+		payment := &models.Payment{
+			PaymentNumber: fmt.Sprintf("PAY-%d", i),
+			UserID:        user,
+			Amount:        100,
+			Currency:      "USD",
+			Status:        status,
+			PaymentMethod: "credit_card",
+			ProcessedAt:   processedAt,
+		}
+		require.NoError(t, paymentStore.CreatePayment(ctx, payment))
+		return payment
+	}
+
+	recent := now.AddDate(0, 0, -5)
+	old := now.AddDate(0, 0, -90)
+	processed := create(1, userID, models.PaymentStatusCompleted, &recent)
+	unprocessed := create(2, userID, models.PaymentStatusCompleted, nil)
+	create(3, userID, models.PaymentStatusCompleted, &old)
+	create(4, userID, models.PaymentStatusFailed, &recent)
+	create(5, uuid.New(), models.PaymentStatusCompleted, &recent)
+
+	payments, err := paymentStore.ListCompletedPaymentsSince(ctx, userID, since, 0, 0)
+	require.NoError(t, err)
+
+	var ids []uuid.UUID
+	for _, payment := range payments {
+		ids = append(ids, payment.ID)
+	}
+	assert.ElementsMatch(t, []uuid.UUID{processed.ID, unprocessed.ID}, ids)
+}
@@ -3,21 +3,30 @@ package store
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/edsonmichaque/bazaruto/internal/models"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // PolicyStore defines the interface for policy data operations.
 type PolicyStore interface {
 	CreatePolicy(ctx context.Context, policy *models.Policy) error
+	CreatePolicyWithInvoice(ctx context.Context, policy *models.Policy, invoice *models.Invoice) error
 	GetPolicy(ctx context.Context, id uuid.UUID) (*models.Policy, error)
 	GetPolicyByNumber(ctx context.Context, policyNumber string) (*models.Policy, error)
 	ListPolicies(ctx context.Context, userID *uuid.UUID, productID *uuid.UUID, status string, limit, offset int) ([]*models.Policy, error)
 	UpdatePolicy(ctx context.Context, policy *models.Policy) error
 	DeletePolicy(ctx context.Context, id uuid.UUID) error
 	CountPolicies(ctx context.Context, userID *uuid.UUID, productID *uuid.UUID, status string) (int64, error)
+	CountActiveByUser(ctx context.Context, userID uuid.UUID) (int64, error)
+	GetRenewalChain(ctx context.Context, policyID uuid.UUID) ([]*models.Policy, error)
+	HasRenewal(ctx context.Context, policyID uuid.UUID) (bool, error)
+	ListExpiredPolicies(ctx context.Context, asOf time.Time) ([]*models.Policy, error)
+	ListPoliciesExpiringWithin(ctx context.Context, from, to time.Time, autoRenewOnly bool) ([]*models.Policy, error)
+	ListPoliciesInTerm(ctx context.Context, productID *uuid.UUID, statuses []models.PolicyStatus, from, to time.Time, limit, offset int) ([]*models.Policy, error)
 }
 
 // policyStore implements PolicyStore interface.
@@ -33,15 +42,43 @@ func NewPolicyStore(db *gorm.DB) PolicyStore {
 // CreatePolicy creates a new policy.
 func (s *policyStore) CreatePolicy(ctx context.Context, policy *models.Policy) error {
 	if err := s.db.WithContext(ctx).Create(policy).Error; err != nil {
-		return fmt.Errorf("failed to create policy: %w", err)
+		return s.createPolicyError(ctx, policy, err)
 	}
 	return nil
 }
 
+// CreatePolicyWithInvoice creates a new policy together with the invoice billing it, so a
+// policy is never issued without its invoice.
+func (s *policyStore) CreatePolicyWithInvoice(ctx context.Context, policy *models.Policy, invoice *models.Invoice) error {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(policy).Error; err != nil {
+			return err
+		}
+		invoice.PolicyID = &policy.ID
+		return tx.Create(invoice).Error
+	})
+	if err != nil {
+		return s.createPolicyError(ctx, policy, err)
+	}
+	return nil
+}
+
+// createPolicyError wraps a failed policy insert. A renewal rejected because its previous
+// policy already has one, which the unique previous_policy_id index enforces when two
+// callers race past HasRenewal, is reported as ErrAlreadyRenewed.
+func (s *policyStore) createPolicyError(ctx context.Context, policy *models.Policy, err error) error {
+	if policy.PreviousPolicyID != nil {
+		if renewed, checkErr := s.HasRenewal(ctx, *policy.PreviousPolicyID); checkErr == nil && renewed {
+			return fmt.Errorf("failed to create policy: %w", ErrAlreadyRenewed)
+		}
+	}
+	return fmt.Errorf("failed to create policy: %w", err)
+}
+
 // GetPolicy retrieves a policy by ID.
 func (s *policyStore) GetPolicy(ctx context.Context, id uuid.UUID) (*models.Policy, error) {
 	var policy models.Policy
-	if err := s.db.WithContext(ctx).Preload("Product").Preload("User").Preload("Quote").Preload("Beneficiaries").First(&policy, "id = ?", id).Error; err != nil {
+	if err := readDB(ctx, s.db).Preload("Product").Preload("User").Preload("Quote").Preload("Beneficiaries").First(&policy, "id = ?", id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("policy not found")
 		}
@@ -53,7 +90,7 @@ func (s *policyStore) GetPolicy(ctx context.Context, id uuid.UUID) (*models.Poli
 // GetPolicyByNumber retrieves a policy by policy number.
 func (s *policyStore) GetPolicyByNumber(ctx context.Context, policyNumber string) (*models.Policy, error) {
 	var policy models.Policy
-	if err := s.db.WithContext(ctx).Preload("Product").Preload("User").Preload("Quote").Preload("Beneficiaries").First(&policy, "policy_number = ?", policyNumber).Error; err != nil {
+	if err := readDB(ctx, s.db).Preload("Product").Preload("User").Preload("Quote").Preload("Beneficiaries").First(&policy, "policy_number = ?", policyNumber).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("policy not found")
 		}
@@ -65,7 +102,7 @@ func (s *policyStore) GetPolicyByNumber(ctx context.Context, policyNumber string
 // ListPolicies retrieves a list of policies with optional filtering.
 func (s *policyStore) ListPolicies(ctx context.Context, userID *uuid.UUID, productID *uuid.UUID, status string, limit, offset int) ([]*models.Policy, error) {
 	var policies []*models.Policy
-	query := s.db.WithContext(ctx).Model(&models.Policy{}).Preload("Product").Preload("User")
+	query := readDB(ctx, s.db).Model(&models.Policy{}).Preload("Product").Preload("User")
 
 	if userID != nil {
 		query = query.Where("user_id = ?", *userID)
@@ -84,16 +121,47 @@ func (s *policyStore) ListPolicies(ctx context.Context, userID *uuid.UUID, produ
 		query = query.Offset(offset)
 	}
 
-	if err := query.Find(&policies).Error; err != nil {
+	// A total order keeps pages from skipping or repeating policies
+	if err := query.Order("created_at DESC").Order("id DESC").Find(&policies).Error; err != nil {
 		return nil, fmt.Errorf("failed to list policies: %w", err)
 	}
 	return policies, nil
 }
 
 // UpdatePolicy updates an existing policy.
+// The update only applies if the stored version matches policy.Version; otherwise
+// ErrVersionConflict is returned, or ErrNotFound if the policy does not exist. On success
+// policy.Version is incremented.
 func (s *policyStore) UpdatePolicy(ctx context.Context, policy *models.Policy) error {
-	if err := s.db.WithContext(ctx).Save(policy).Error; err != nil {
-		return fmt.Errorf("failed to update policy: %w", err)
+	return updatePolicyVersion(s.db.WithContext(ctx), policy)
+}
+
+// updatePolicyVersion saves policy if its stored version matches policy.Version.
+func updatePolicyVersion(db *gorm.DB, policy *models.Policy) error {
+	version := policy.Version
+	policy.Version = version + 1
+
+	result := db.
+		Model(policy).
+		Where("version = ?", version).
+		Select("*").
+		Omit(clause.Associations).
+		Updates(policy)
+	if result.Error != nil {
+		policy.Version = version
+		return fmt.Errorf("failed to update policy: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		policy.Version = version
+		// Nothing matched either because the version moved on or because the policy is gone.
+		var count int64
+		if err := db.Model(&models.Policy{}).Where("id = ?", policy.ID).Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to update policy: %w", err)
+		}
+		if count == 0 {
+			return fmt.Errorf("policy %w", ErrNotFound)
+		}
+		return fmt.Errorf("failed to update policy %s: %w", policy.ID, ErrVersionConflict)
 	}
 	return nil
 }
@@ -109,7 +177,7 @@ func (s *policyStore) DeletePolicy(ctx context.Context, id uuid.UUID) error {
 // CountPolicies returns the total number of policies with optional filtering.
 func (s *policyStore) CountPolicies(ctx context.Context, userID *uuid.UUID, productID *uuid.UUID, status string) (int64, error) {
 	var count int64
-	query := s.db.WithContext(ctx).Model(&models.Policy{})
+	query := readDB(ctx, s.db).Model(&models.Policy{})
 
 	if userID != nil {
 		query = query.Where("user_id = ?", *userID)
@@ -126,3 +194,123 @@ func (s *policyStore) CountPolicies(ctx context.Context, userID *uuid.UUID, prod
 	}
 	return count, nil
 }
+
+// CountActiveByUser returns the number of active policies held by a user.
+func (s *policyStore) CountActiveByUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var count int64
+	if err := readDB(ctx, s.db).Model(&models.Policy{}).
+		Where("user_id = ? AND status = ?", userID, models.PolicyStatusActive).
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count active policies: %w", err)
+	}
+	return count, nil
+}
+
+// GetRenewalChain returns the renewal chain containing a policy, ordered from the original
+// policy to its latest renewal.
+func (s *policyStore) GetRenewalChain(ctx context.Context, policyID uuid.UUID) ([]*models.Policy, error) {
+	var current models.Policy
+	if err := readDB(ctx, s.db).First(&current, "id = ?", policyID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("policy not found")
+		}
+		return nil, fmt.Errorf("failed to get policy: %w", err)
+	}
+
+	seen := map[uuid.UUID]bool{current.ID: true}
+	chain := []*models.Policy{&current}
+
+	// Walk back to the original policy
+	for previousID := current.PreviousPolicyID; previousID != nil && !seen[*previousID]; {
+		var previous models.Policy
+		if err := readDB(ctx, s.db).First(&previous, "id = ?", *previousID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				break
+			}
+			return nil, fmt.Errorf("failed to get previous policy: %w", err)
+		}
+		seen[previous.ID] = true
+		chain = append([]*models.Policy{&previous}, chain...)
+		previousID = previous.PreviousPolicyID
+	}
+
+	// Walk forward through successive renewals
+	for last := chain[len(chain)-1]; ; {
+		var next models.Policy
+		if err := readDB(ctx, s.db).Where("previous_policy_id = ?", last.ID).Order("created_at ASC").First(&next).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				break
+			}
+			return nil, fmt.Errorf("failed to get renewal policy: %w", err)
+		}
+		if seen[next.ID] {
+			break
+		}
+		seen[next.ID] = true
+		chain = append(chain, &next)
+		last = &next
+	}
+
+	return chain, nil
+}
+
+// HasRenewal reports whether a policy has a renewal that was not cancelled. It reads from
+// the primary so a renewal created moments ago is seen even when replicas lag.
+func (s *policyStore) HasRenewal(ctx context.Context, policyID uuid.UUID) (bool, error) {
+	var count int64
+	if err := s.db.WithContext(ctx).Model(&models.Policy{}).
+		Where("previous_policy_id = ? AND status <> ?", policyID, models.PolicyStatusCancelled).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check policy renewals: %w", err)
+	}
+	return count > 0, nil
+}
+
+// ListExpiredPolicies retrieves active policies whose expiration date is before asOf.
+func (s *policyStore) ListExpiredPolicies(ctx context.Context, asOf time.Time) ([]*models.Policy, error) {
+	var policies []*models.Policy
+	if err := readDB(ctx, s.db).
+		Where("status = ? AND expiration_date < ?", models.PolicyStatusActive, asOf).
+		Order("expiration_date ASC").
+		Find(&policies).Error; err != nil {
+		return nil, fmt.Errorf("failed to list expired policies: %w", err)
+	}
+	return policies, nil
+}
+
+// ListPoliciesExpiringWithin retrieves active policies expiring between from and to,
+// optionally restricted to policies with auto-renewal enabled.
+func (s *policyStore) ListPoliciesExpiringWithin(ctx context.Context, from, to time.Time, autoRenewOnly bool) ([]*models.Policy, error) {
+	var policies []*models.Policy
+	query := readDB(ctx, s.db).
+		Where("status = ? AND expiration_date >= ? AND expiration_date <= ?", models.PolicyStatusActive, from, to)
+	if autoRenewOnly {
+		query = query.Where("auto_renew = ?", true)
+	}
+	if err := query.Order("expiration_date ASC").Find(&policies).Error; err != nil {
+		return nil, fmt.Errorf("failed to list policies expiring within window: %w", err)
+	}
+	return policies, nil
+}
+
+// ListPoliciesInTerm retrieves policies in one of the given statuses whose term overlaps
+// the half-open period [from, to), optionally limited to a product, ordered by ID.
+func (s *policyStore) ListPoliciesInTerm(ctx context.Context, productID *uuid.UUID, statuses []models.PolicyStatus, from, to time.Time, limit, offset int) ([]*models.Policy, error) {
+	var policies []*models.Policy
+	query := readDB(ctx, s.db).Model(&models.Policy{}).Preload("User").
+		Where("status IN ? AND effective_date < ? AND expiration_date > ?", statuses, to, from)
+	if productID != nil {
+		query = query.Where("product_id = ?", *productID)
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+
+	if err := query.Order("id ASC").Find(&policies).Error; err != nil {
+		return nil, fmt.Errorf("failed to list policies in term: %w", err)
+	}
+	return policies, nil
+}
@@ -0,0 +1,72 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EndorsementStore defines the interface for policy endorsement data operations.
+type EndorsementStore interface {
+	CreateEndorsement(ctx context.Context, endorsement *models.PolicyEndorsement) error
+	EndorsePolicy(ctx context.Context, policy *models.Policy, endorsement *models.PolicyEndorsement) error
+	ListEndorsementsByPolicy(ctx context.Context, policyID uuid.UUID) ([]*models.PolicyEndorsement, error)
+	ListEndorsementsEffectiveBetween(ctx context.Context, policyID uuid.UUID, from, to time.Time) ([]*models.PolicyEndorsement, error)
+}
+
+// endorsementStore implements EndorsementStore interface.
+type endorsementStore struct {
+	db *gorm.DB
+}
+
+// NewEndorsementStore creates a new EndorsementStore instance.
+func NewEndorsementStore(db *gorm.DB) EndorsementStore {
+	return &endorsementStore{db: db}
+}
+
+// CreateEndorsement records a new policy endorsement.
+func (s *endorsementStore) CreateEndorsement(ctx context.Context, endorsement *models.PolicyEndorsement) error {
+	if err := s.db.WithContext(ctx).Create(endorsement).Error; err != nil {
+		return fmt.Errorf("failed to create policy endorsement: %w", err)
+	}
+	return nil
+}
+
+// EndorsePolicy saves the endorsed policy and records its endorsement in one transaction,
+// so a policy is never changed without the endorsement that changed it. The policy is
+// saved under the same version check as UpdatePolicy.
+func (s *endorsementStore) EndorsePolicy(ctx context.Context, policy *models.Policy, endorsement *models.PolicyEndorsement) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := updatePolicyVersion(tx, policy); err != nil {
+			return err
+		}
+		if err := tx.Create(endorsement).Error; err != nil {
+			return fmt.Errorf("failed to create policy endorsement: %w", err)
+		}
+		return nil
+	})
+}
+
+// ListEndorsementsByPolicy retrieves all endorsements recorded against a policy in effective order.
+func (s *endorsementStore) ListEndorsementsByPolicy(ctx context.Context, policyID uuid.UUID) ([]*models.PolicyEndorsement, error) {
+	var endorsements []*models.PolicyEndorsement
+	if err := readDB(ctx, s.db).Where("policy_id = ?", policyID).Order("effective_date ASC, created_at ASC").Find(&endorsements).Error; err != nil {
+		return nil, fmt.Errorf("failed to list policy endorsements: %w", err)
+	}
+	return endorsements, nil
+}
+
+// ListEndorsementsEffectiveBetween retrieves a policy's endorsements that took effect between
+// from and to, inclusive, in effective order.
+func (s *endorsementStore) ListEndorsementsEffectiveBetween(ctx context.Context, policyID uuid.UUID, from, to time.Time) ([]*models.PolicyEndorsement, error) {
+	var endorsements []*models.PolicyEndorsement
+	if err := readDB(ctx, s.db).Where("policy_id = ? AND effective_date BETWEEN ? AND ?", policyID, from, to).
+		Order("effective_date ASC, created_at ASC").Find(&endorsements).Error; err != nil {
+		return nil, fmt.Errorf("failed to list policy endorsements: %w", err)
+	}
+	return endorsements, nil
+}
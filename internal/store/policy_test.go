@@ -0,0 +1,373 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/database"
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	// A single connection keeps every query on the same in-memory database.
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+
+	require.NoError(t, database.RunMigrations(db))
+	return db
+}
+
+func TestUpdatePolicyDetectsConcurrentUpdate(t *testing.T) {
+	ctx := context.Background()
+	policyStore := NewPolicyStore(newTestDB(t))
+
+	policy := &models.Policy{
+		PolicyNumber:   "POL-1",
+		ProductID:      uuid.New(),
+		UserID:         uuid.New(),
+		Premium:        100,
+		CoverageAmount: 10000,
+		Status:         models.PolicyStatusActive,
+		EffectiveDate:  time.Now(),
+		ExpirationDate: time.Now().AddDate(1, 0, 0),
+	}
+	require.NoError(t, policyStore.CreatePolicy(ctx, policy))
+	require.Equal(t, 1, policy.Version)
+
+	// Both writers read the same version.
+	cancelled := *policy
+	cancelled.Status = models.PolicyStatusCancelled
+	renewed := *policy
+	renewed.AutoRenew = true
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i, update := range []*models.Policy{&cancelled, &renewed} {
+		wg.Add(1)
+		go func(i int, update *models.Policy) {
+			defer wg.Done()
+			errs[i] = policyStore.UpdatePolicy(ctx, update)
+		}(i, update)
+	}
+	wg.Wait()
+
+	successes, conflicts := 0, 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			successes++
+		case errors.Is(err, ErrVersionConflict):
+			conflicts++
+		default:
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	assert.Equal(t, 1, successes)
+	assert.Equal(t, 1, conflicts)
+
+	stored, err := policyStore.GetPolicy(ctx, policy.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stored.Version)
+}
+
+func TestUpdatePolicyReportsMissingPolicyAsNotFound(t *testing.T) {
+	ctx := context.Background()
+	policyStore := NewPolicyStore(newTestDB(t))
+
+	policy := &models.Policy{
+		PolicyNumber:   "POL-GONE",
+		ProductID:      uuid.New(),
+		UserID:         uuid.New(),
+		Premium:        100,
+		CoverageAmount: 10000,
+		Status:         models.PolicyStatusActive,
+		EffectiveDate:  time.Now(),
+		ExpirationDate: time.Now().AddDate(1, 0, 0),
+	}
+	require.NoError(t, policyStore.CreatePolicy(ctx, policy))
+	require.NoError(t, policyStore.DeletePolicy(ctx, policy.ID))
+
+	err := policyStore.UpdatePolicy(ctx, policy)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.NotErrorIs(t, err, ErrVersionConflict)
+	assert.Equal(t, 1, policy.Version)
+}
+
+func TestSoftDeletedPolicyRetrievableWithDeleted(t *testing.T) {
+	ctx := context.Background()
+	policyStore := NewPolicyStore(newTestDB(t))
+
+	userID := uuid.New()
+	policy := &models.Policy{
+		PolicyNumber:   "POL-2",
+		ProductID:      uuid.New(),
+		UserID:         userID,
+		Premium:        100,
+		CoverageAmount: 10000,
+		Status:         models.PolicyStatusCancelled,
+		EffectiveDate:  time.Now(),
+		ExpirationDate: time.Now().AddDate(1, 0, 0),
+	}
+	require.NoError(t, policyStore.CreatePolicy(ctx, policy))
+	require.NoError(t, policyStore.DeletePolicy(ctx, policy.ID))
+
+	_, err := policyStore.GetPolicy(ctx, policy.ID)
+	assert.EqualError(t, err, "policy not found")
+
+	policies, err := policyStore.ListPolicies(ctx, &userID, nil, "", 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, policies)
+
+	deletedCtx := WithDeleted(ctx)
+	deleted, err := policyStore.GetPolicy(deletedCtx, policy.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.PolicyStatusCancelled, deleted.Status)
+	assert.True(t, deleted.DeletedAt.Valid)
+
+	count, err := policyStore.CountPolicies(deletedCtx, &userID, nil, "")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestGetRenewalChainReturnsPoliciesInOrder(t *testing.T) {
+	ctx := context.Background()
+	policyStore := NewPolicyStore(newTestDB(t))
+
+	userID := uuid.New()
+	productID := uuid.New()
+	var previousID *uuid.UUID
+	var chain []*models.Policy
+	for i := 0; i < 3; i++ {
+		effective := time.Now().AddDate(i-2, 0, 0)
+		policy := &models.Policy{
+			PolicyNumber:     fmt.Sprintf("POL-CHAIN-%d", i),
+			ProductID:        productID,
+			UserID:           userID,
+			PreviousPolicyID: previousID,
+			Premium:          100,
+			CoverageAmount:   10000,
+			Status:           models.PolicyStatusActive,
+			EffectiveDate:    effective,
+			ExpirationDate:   effective.AddDate(1, 0, 0),
+		}
+		require.NoError(t, policyStore.CreatePolicy(ctx, policy))
+		chain = append(chain, policy)
+		previousID = &policy.ID
+	}
+
+	for _, member := range chain {
+		got, err := policyStore.GetRenewalChain(ctx, member.ID)
+		require.NoError(t, err)
+		require.Len(t, got, 3)
+		for i := range chain {
+			assert.Equal(t, chain[i].ID, got[i].ID)
+		}
+	}
+}
+
+func TestListExpiredAndExpiringPolicies(t *testing.T) {
+	ctx := context.Background()
+	policyStore := NewPolicyStore(newTestDB(t))
+	now := time.Now()
+
+	newPolicy := func(number string, status models.PolicyStatus, expiresIn int, autoRenew bool) *models.Policy {
+		policy := &models.Policy{
+			PolicyNumber:   number,
+			ProductID:      uuid.New(),
+			UserID:         uuid.New(),
+			Premium:        100,
+			CoverageAmount: 10000,
+			Status:         status,
+			EffectiveDate:  now.AddDate(-1, 0, expiresIn),
+			ExpirationDate: now.AddDate(0, 0, expiresIn),
+			AutoRenew:      autoRenew,
+		}
+		require.NoError(t, policyStore.CreatePolicy(ctx, policy))
+		return policy
+	}
+
+	expired := newPolicy("POL-EXPIRED", models.PolicyStatusActive, -2, false)
+	newPolicy("POL-CANCELLED", models.PolicyStatusCancelled, -2, false)
+	autoRenewing := newPolicy("POL-AUTO", models.PolicyStatusActive, 10, true)
+	manual := newPolicy("POL-MANUAL", models.PolicyStatusActive, 20, false)
+	newPolicy("POL-LATER", models.PolicyStatusActive, 90, true)
+
+	policies, err := policyStore.ListExpiredPolicies(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, policies, 1)
+	assert.Equal(t, expired.ID, policies[0].ID)
+
+	policies, err = policyStore.ListPoliciesExpiringWithin(ctx, now, now.AddDate(0, 0, 30), false)
+	require.NoError(t, err)
+	require.Len(t, policies, 2)
+	assert.Equal(t, autoRenewing.ID, policies[0].ID)
+	assert.Equal(t, manual.ID, policies[1].ID)
+
+	policies, err = policyStore.ListPoliciesExpiringWithin(ctx, now, now.AddDate(0, 0, 30), true)
+	require.NoError(t, err)
+	require.Len(t, policies, 1)
+	assert.Equal(t, autoRenewing.ID, policies[0].ID)
+}
+
+func TestListPoliciesInTermSelectsIssuedPoliciesOverlappingThePeriod(t *testing.T) {
+	ctx := context.Background()
+	policyStore := NewPolicyStore(newTestDB(t))
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(1, 0, 0)
+
+	newPolicy := func(number string, status models.PolicyStatus, effective time.Time) *models.Policy {
+		policy := &models.Policy{
+			PolicyNumber:   number,
+			ProductID:      uuid.New(),
+			UserID:         uuid.New(),
+			Premium:        100,
+			CoverageAmount: 10000,
+			Status:         status,
+			EffectiveDate:  effective,
+			ExpirationDate: effective.AddDate(1, 0, 0),
+		}
+		require.NoError(t, policyStore.CreatePolicy(ctx, policy))
+		return policy
+	}
+
+	overlapping := newPolicy("POL-OVERLAP", models.PolicyStatusActive, from.AddDate(0, -6, 0))
+	expired := newPolicy("POL-EXPIRED", models.PolicyStatusExpired, from)
+	newPolicy("POL-PENDING", models.PolicyStatusPending, from)
+	newPolicy("POL-BEFORE", models.PolicyStatusExpired, from.AddDate(-1, 0, 0))
+	newPolicy("POL-AFTER", models.PolicyStatusActive, to)
+
+	policies, err := policyStore.ListPoliciesInTerm(ctx, nil, []models.PolicyStatus{models.PolicyStatusActive, models.PolicyStatusExpired}, from, to, 0, 0)
+	require.NoError(t, err)
+	var ids []uuid.UUID
+	for _, policy := range policies {
+		ids = append(ids, policy.ID)
+	}
+	assert.ElementsMatch(t, []uuid.UUID{overlapping.ID, expired.ID}, ids)
+}
+
+func TestCreatePolicyWithInvoiceRollsBackThePolicyWhenTheInvoiceFails(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	policyStore := NewPolicyStore(db)
+	invoiceStore := NewInvoiceStore(db)
+
+	newPolicy := func(number string) *models.Policy {
+		return &models.Policy{
+			PolicyNumber:   number,
+			ProductID:      uuid.New(),
+			UserID:         uuid.New(),
+			Premium:        100,
+			CoverageAmount: 10000,
+			Status:         models.PolicyStatusActive,
+			EffectiveDate:  time.Now(),
+			ExpirationDate: time.Now().AddDate(1, 0, 0),
+		}
+	}
+	newInvoice := func(policy *models.Policy) *models.Invoice {
+		return &models.Invoice{InvoiceNumber: "INV-1", UserID: policy.UserID, Amount: 100, Total: 100, DueDate: time.Now()}
+	}
+
+	issued := newPolicy("POL-1")
+	invoice := newInvoice(issued)
+	require.NoError(t, policyStore.CreatePolicyWithInvoice(ctx, issued, invoice))
+	stored, err := invoiceStore.GetInvoice(ctx, invoice.ID)
+	require.NoError(t, err)
+	require.NotNil(t, stored.PolicyID)
+	assert.Equal(t, issued.ID, *stored.PolicyID)
+
+	// The invoice number is taken, so the policy is not issued either.
+	rejected := newPolicy("POL-2")
+	assert.Error(t, policyStore.CreatePolicyWithInvoice(ctx, rejected, newInvoice(rejected)))
+	_, err = policyStore.GetPolicyByNumber(ctx, "POL-2")
+	assert.Error(t, err)
+}
+
+func TestEndorsePolicyRecordsNothingOnVersionConflict(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	policyStore := NewPolicyStore(db)
+	endorsementStore := NewEndorsementStore(db)
+
+	policy := &models.Policy{
+		PolicyNumber:   "POL-1",
+		ProductID:      uuid.New(),
+		UserID:         uuid.New(),
+		Premium:        100,
+		CoverageAmount: 10000,
+		Status:         models.PolicyStatusActive,
+		EffectiveDate:  time.Now(),
+		ExpirationDate: time.Now().AddDate(1, 0, 0),
+	}
+	require.NoError(t, policyStore.CreatePolicy(ctx, policy))
+	newEndorsement := func() *models.PolicyEndorsement {
+		return &models.PolicyEndorsement{PolicyID: policy.ID, EffectiveDate: time.Now(), PreviousCoverage: 10000, NewCoverage: 20000, Currency: "USD"}
+	}
+
+	stale := *policy
+	policy.CoverageAmount = 20000
+	require.NoError(t, endorsementStore.EndorsePolicy(ctx, policy, newEndorsement()))
+
+	stale.CoverageAmount = 30000
+	err := endorsementStore.EndorsePolicy(ctx, &stale, newEndorsement())
+	assert.ErrorIs(t, err, ErrVersionConflict)
+
+	endorsements, err := endorsementStore.ListEndorsementsByPolicy(ctx, policy.ID)
+	require.NoError(t, err)
+	assert.Len(t, endorsements, 1)
+	stored, err := policyStore.GetPolicy(ctx, policy.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 20000.0, stored.CoverageAmount)
+}
+
+func TestCreatePolicyRejectsSecondRenewalOfTheSamePolicy(t *testing.T) {
+	ctx := context.Background()
+	policyStore := NewPolicyStore(newTestDB(t))
+
+	effective := time.Now().AddDate(-1, 0, 0)
+	newPolicy := func(number string, previousID *uuid.UUID) *models.Policy {
+		return &models.Policy{
+			PolicyNumber:     number,
+			ProductID:        uuid.New(),
+			UserID:           uuid.New(),
+			PreviousPolicyID: previousID,
+			Premium:          100,
+			CoverageAmount:   10000,
+			Status:           models.PolicyStatusActive,
+			EffectiveDate:    effective,
+			ExpirationDate:   effective.AddDate(1, 0, 0),
+		}
+	}
+
+	original := newPolicy("POL-RENEW-0", nil)
+	require.NoError(t, policyStore.CreatePolicy(ctx, original))
+
+	first := newPolicy("POL-RENEW-1", &original.ID)
+	require.NoError(t, policyStore.CreatePolicy(ctx, first))
+
+	err := policyStore.CreatePolicy(ctx, newPolicy("POL-RENEW-2", &original.ID))
+	assert.ErrorIs(t, err, ErrAlreadyRenewed)
+
+	invoice := &models.Invoice{InvoiceNumber: "INV-RENEW-3", UserID: original.UserID, Amount: 100, Total: 100, DueDate: time.Now()}
+	err = policyStore.CreatePolicyWithInvoice(ctx, newPolicy("POL-RENEW-3", &original.ID), invoice)
+	assert.ErrorIs(t, err, ErrAlreadyRenewed)
+
+	// A cancelled renewal no longer blocks renewing the policy again
+	first.Status = models.PolicyStatusCancelled
+	require.NoError(t, policyStore.UpdatePolicy(ctx, first))
+	require.NoError(t, policyStore.CreatePolicy(ctx, newPolicy("POL-RENEW-4", &original.ID)))
+}
@@ -0,0 +1,65 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PremiumTaxReportStore defines the interface for premium tax report data operations.
+type PremiumTaxReportStore interface {
+	CreatePremiumTaxReport(ctx context.Context, report *models.PremiumTaxReport) error
+	GetPremiumTaxReport(ctx context.Context, id uuid.UUID) (*models.PremiumTaxReport, error)
+	ListPremiumTaxReports(ctx context.Context, limit, offset int) ([]*models.PremiumTaxReport, error)
+}
+
+// premiumTaxReportStore implements PremiumTaxReportStore interface.
+type premiumTaxReportStore struct {
+	db *gorm.DB
+}
+
+// NewPremiumTaxReportStore creates a new PremiumTaxReportStore instance.
+func NewPremiumTaxReportStore(db *gorm.DB) PremiumTaxReportStore {
+	return &premiumTaxReportStore{db: db}
+}
+
+// CreatePremiumTaxReport creates a new premium tax report.
+func (s *premiumTaxReportStore) CreatePremiumTaxReport(ctx context.Context, report *models.PremiumTaxReport) error {
+	if err := s.db.WithContext(ctx).Create(report).Error; err != nil {
+		return fmt.Errorf("failed to create premium tax report: %w", err)
+	}
+	return nil
+}
+
+// GetPremiumTaxReport retrieves a premium tax report by ID.
+func (s *premiumTaxReportStore) GetPremiumTaxReport(ctx context.Context, id uuid.UUID) (*models.PremiumTaxReport, error) {
+	var report models.PremiumTaxReport
+	if err := readDB(ctx, s.db).First(&report, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("premium tax report not found")
+		}
+		return nil, fmt.Errorf("failed to get premium tax report: %w", err)
+	}
+	return &report, nil
+}
+
+// ListPremiumTaxReports retrieves premium tax reports, most recent period first.
+func (s *premiumTaxReportStore) ListPremiumTaxReports(ctx context.Context, limit, offset int) ([]*models.PremiumTaxReport, error) {
+	var reports []*models.PremiumTaxReport
+	query := readDB(ctx, s.db).Order("period_end DESC")
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if offset > 0 {
+		query = query.Offset(offset)
+	}
+
+	if err := query.Find(&reports).Error; err != nil {
+		return nil, fmt.Errorf("failed to list premium tax reports: %w", err)
+	}
+	return reports, nil
+}
@@ -40,7 +40,7 @@ func (s *productStore) CreateProduct(ctx context.Context, product *models.Produc
 // GetProduct retrieves a product by ID.
 func (s *productStore) GetProduct(ctx context.Context, id uuid.UUID) (*models.Product, error) {
 	var product models.Product
-	if err := s.db.WithContext(ctx).Preload("Partner").Preload("Coverages").First(&product, "id = ?", id).Error; err != nil {
+	if err := readDB(ctx, s.db).Preload("Partner").Preload("Coverages").First(&product, "id = ?", id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("product not found")
 		}
@@ -52,7 +52,7 @@ func (s *productStore) GetProduct(ctx context.Context, id uuid.UUID) (*models.Pr
 // ListProducts retrieves a list of products with optional filtering.
 func (s *productStore) ListProducts(ctx context.Context, partnerID *uuid.UUID, category string, limit, offset int) ([]*models.Product, error) {
 	var products []*models.Product
-	query := s.db.WithContext(ctx).Model(&models.Product{}).Preload("Partner")
+	query := readDB(ctx, s.db).Model(&models.Product{}).Preload("Partner")
 
 	if partnerID != nil {
 		query = query.Where("partner_id = ?", *partnerID)
@@ -93,7 +93,7 @@ func (s *productStore) DeleteProduct(ctx context.Context, id uuid.UUID) error {
 // CountProducts returns the total number of products with optional filtering.
 func (s *productStore) CountProducts(ctx context.Context, partnerID *uuid.UUID, category string) (int64, error) {
 	var count int64
-	query := s.db.WithContext(ctx).Model(&models.Product{})
+	query := readDB(ctx, s.db).Model(&models.Product{})
 
 	if partnerID != nil {
 		query = query.Where("partner_id = ?", *partnerID)
@@ -3,6 +3,7 @@ package store
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/edsonmichaque/bazaruto/internal/models"
 	"github.com/google/uuid"
@@ -19,6 +20,7 @@ type QuoteStore interface {
 	DeleteQuote(ctx context.Context, id uuid.UUID) error
 	ExpireQuote(ctx context.Context, id uuid.UUID) error
 	CountQuotes(ctx context.Context, userID *uuid.UUID, productID *uuid.UUID, status string) (int64, error)
+	GetActiveRenewalOffer(ctx context.Context, policyID uuid.UUID, asOf time.Time) (*models.Quote, error)
 }
 
 // quoteStore implements QuoteStore interface.
@@ -42,7 +44,7 @@ func (s *quoteStore) CreateQuote(ctx context.Context, quote *models.Quote) error
 // GetQuote retrieves a quote by ID.
 func (s *quoteStore) GetQuote(ctx context.Context, id uuid.UUID) (*models.Quote, error) {
 	var quote models.Quote
-	if err := s.db.WithContext(ctx).Preload("Product").Preload("User").First(&quote, "id = ?", id).Error; err != nil {
+	if err := readDB(ctx, s.db).Preload("Product").Preload("User").First(&quote, "id = ?", id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("quote not found")
 		}
@@ -54,7 +56,7 @@ func (s *quoteStore) GetQuote(ctx context.Context, id uuid.UUID) (*models.Quote,
 // GetQuoteByNumber retrieves a quote by quote number.
 func (s *quoteStore) GetQuoteByNumber(ctx context.Context, quoteNumber string) (*models.Quote, error) {
 	var quote models.Quote
-	if err := s.db.WithContext(ctx).Preload("Product").Preload("User").First(&quote, "quote_number = ?", quoteNumber).Error; err != nil {
+	if err := readDB(ctx, s.db).Preload("Product").Preload("User").First(&quote, "quote_number = ?", quoteNumber).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("quote not found")
 		}
@@ -66,7 +68,7 @@ func (s *quoteStore) GetQuoteByNumber(ctx context.Context, quoteNumber string) (
 // ListQuotes retrieves a list of quotes with optional filtering.
 func (s *quoteStore) ListQuotes(ctx context.Context, userID *uuid.UUID, productID *uuid.UUID, status string, limit, offset int) ([]*models.Quote, error) {
 	var quotes []*models.Quote
-	query := s.db.WithContext(ctx).Model(&models.Quote{}).Preload("Product").Preload("User")
+	query := readDB(ctx, s.db).Model(&models.Quote{}).Preload("Product").Preload("User")
 
 	if userID != nil {
 		query = query.Where("user_id = ?", *userID)
@@ -118,7 +120,7 @@ func (s *quoteStore) ExpireQuote(ctx context.Context, id uuid.UUID) error {
 // CountQuotes returns the total number of quotes with optional filtering.
 func (s *quoteStore) CountQuotes(ctx context.Context, userID *uuid.UUID, productID *uuid.UUID, status string) (int64, error) {
 	var count int64
-	query := s.db.WithContext(ctx).Model(&models.Quote{})
+	query := readDB(ctx, s.db).Model(&models.Quote{})
 
 	if userID != nil {
 		query = query.Where("user_id = ?", *userID)
@@ -135,3 +137,22 @@ func (s *quoteStore) CountQuotes(ctx context.Context, userID *uuid.UUID, product
 	}
 	return count, nil
 }
+
+// GetActiveRenewalOffer retrieves the latest active renewal offer for a policy that is
+// still valid at asOf. It returns nil without an error when the policy has no such offer.
+// It reads from the primary so an offer created moments ago is seen even when replicas
+// lag, which keeps callers from generating a duplicate offer.
+func (s *quoteStore) GetActiveRenewalOffer(ctx context.Context, policyID uuid.UUID, asOf time.Time) (*models.Quote, error) {
+	var quotes []*models.Quote
+	if err := s.db.WithContext(ctx).
+		Where("renewal_of_policy_id = ? AND status = ? AND valid_until > ?", policyID, models.QuoteStatusActive, asOf).
+		Order("valid_until DESC").
+		Limit(1).
+		Find(&quotes).Error; err != nil {
+		return nil, fmt.Errorf("failed to get active renewal offer: %w", err)
+	}
+	if len(quotes) == 0 {
+		return nil, nil
+	}
+	return quotes[0], nil
+}
@@ -0,0 +1,75 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SequenceStore defines the interface for named sequence operations.
+type SequenceStore interface {
+	// Next increments the named sequence and returns its new value. Sequences start at 1
+	// and each value is returned at most once, including under concurrent callers.
+	Next(ctx context.Context, name string) (int64, error)
+}
+
+// sequenceStore implements SequenceStore interface.
+type sequenceStore struct {
+	db *gorm.DB
+}
+
+// NewSequenceStore creates a new SequenceStore instance.
+func NewSequenceStore(db *gorm.DB) SequenceStore {
+	return &sequenceStore{db: db}
+}
+
+// Next increments the named sequence and returns its new value. The increment takes a row
+// lock for the rest of the transaction, so concurrent callers are serialized on the sequence.
+// A missing sequence is created; when two callers race to create it, the loser increments
+// the winner's row.
+func (s *sequenceStore) Next(ctx context.Context, name string) (int64, error) {
+	var value int64
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		incremented, err := s.increment(tx, name)
+		if err != nil {
+			return err
+		}
+
+		if !incremented {
+			created := tx.Clauses(clause.OnConflict{DoNothing: true}).
+				Create(&models.Sequence{Name: name, Value: 1, UpdatedAt: time.Now()})
+			if created.Error != nil {
+				return created.Error
+			}
+			if created.RowsAffected == 1 {
+				value = 1
+				return nil
+			}
+			if _, err := s.increment(tx, name); err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&models.Sequence{}).Where("name = ?", name).Pluck("value", &value).Error
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get next value of sequence %s: %w", name, err)
+	}
+	return value, nil
+}
+
+// increment adds one to the named sequence and reports whether it exists.
+func (s *sequenceStore) increment(tx *gorm.DB, name string) (bool, error) {
+	result := tx.Model(&models.Sequence{}).Where("name = ?", name).Updates(map[string]interface{}{
+		"value":      gorm.Expr("value + 1"),
+		"updated_at": time.Now(),
+	})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
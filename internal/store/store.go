@@ -1,9 +1,41 @@
 package store
 
 import (
+	"context"
+	"errors"
+
 	"gorm.io/gorm"
 )
 
+// ErrVersionConflict is returned when an update loses an optimistic concurrency check
+// because the record was modified since it was read.
+var ErrVersionConflict = errors.New("version conflict")
+
+// ErrNotFound is returned when a requested record does not exist.
+var ErrNotFound = errors.New("not found")
+
+// ErrAlreadyRenewed is returned when a renewal is created for a policy that already has a
+// renewal that was not cancelled.
+var ErrAlreadyRenewed = errors.New("policy has already been renewed")
+
+// withDeletedKey marks a context whose store reads include soft-deleted records.
+type withDeletedKey struct{}
+
+// WithDeleted returns a context under which store reads (Get, List, Count and Find methods)
+// include soft-deleted records, such as cancelled policies kept for audit. Writes are unaffected.
+func WithDeleted(ctx context.Context) context.Context {
+	return context.WithValue(ctx, withDeletedKey{}, true)
+}
+
+// readDB returns db bound to ctx for a read, including soft-deleted rows under WithDeleted.
+func readDB(ctx context.Context, db *gorm.DB) *gorm.DB {
+	db = db.WithContext(ctx)
+	if withDeleted, _ := ctx.Value(withDeletedKey{}).(bool); withDeleted {
+		db = db.Unscoped()
+	}
+	return db
+}
+
 // Stores aggregates all store interfaces for dependency injection.
 type Stores struct {
 	Users         UserStore
@@ -13,12 +45,26 @@ type Stores struct {
 	Quotes        QuoteStore
 	Policies      PolicyStore
 	Claims        ClaimStore
+	Workflows     ClaimWorkflowStore
 	Subscriptions SubscriptionStore
 	Payments      PaymentStore
 	Invoices      InvoiceStore
 	Beneficiaries BeneficiaryStore
 	Coverages     CoverageStore
 	Webhooks      WebhookStore
+	Events        EventStore
+	ClaimReserves ClaimReserveStore
+	Recoveries    ClaimRecoveryStore
+	AuditLogs     AuditLogStore
+	Endorsements  EndorsementStore
+	Appeals       AppealStore
+	Commissions   CommissionStore
+	Statements    PartnerStatementStore
+	TaxReports    PremiumTaxReportStore
+	SARs          SARStore
+	Sequences     SequenceStore
+	Decisions     UnderwritingDecisionStore
+	Configs       ConfigSnapshotStore
 }
 
 // NewStores creates a new Stores instance with all store implementations.
@@ -31,11 +77,25 @@ func NewStores(db *gorm.DB) *Stores {
 		Quotes:        NewQuoteStore(db),
 		Policies:      NewPolicyStore(db),
 		Claims:        NewClaimStore(db),
+		Workflows:     NewClaimWorkflowStore(db),
 		Subscriptions: NewSubscriptionStore(db),
 		Payments:      NewPaymentStore(db),
 		Invoices:      NewInvoiceStore(db),
 		Beneficiaries: NewBeneficiaryStore(db),
 		Coverages:     NewCoverageStore(db),
 		Webhooks:      NewWebhookStore(db),
+		Events:        NewEventStore(db),
+		ClaimReserves: NewClaimReserveStore(db),
+		Recoveries:    NewClaimRecoveryStore(db),
+		AuditLogs:     NewAuditLogStore(db),
+		Endorsements:  NewEndorsementStore(db),
+		Appeals:       NewAppealStore(db),
+		Commissions:   NewCommissionStore(db),
+		Statements:    NewPartnerStatementStore(db),
+		TaxReports:    NewPremiumTaxReportStore(db),
+		SARs:          NewSARStore(db),
+		Sequences:     NewSequenceStore(db),
+		Decisions:     NewUnderwritingDecisionStore(db),
+		Configs:       NewConfigSnapshotStore(db),
 	}
 }
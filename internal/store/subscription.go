@@ -41,7 +41,7 @@ func (s *subscriptionStore) CreateSubscription(ctx context.Context, subscription
 // GetSubscription retrieves a subscription by ID.
 func (s *subscriptionStore) GetSubscription(ctx context.Context, id uuid.UUID) (*models.Subscription, error) {
 	var subscription models.Subscription
-	if err := s.db.WithContext(ctx).Preload("User").Preload("Policy").First(&subscription, "id = ?", id).Error; err != nil {
+	if err := readDB(ctx, s.db).Preload("User").Preload("Policy").First(&subscription, "id = ?", id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("subscription not found")
 		}
@@ -53,7 +53,7 @@ func (s *subscriptionStore) GetSubscription(ctx context.Context, id uuid.UUID) (
 // GetSubscriptionByNumber retrieves a subscription by subscription number.
 func (s *subscriptionStore) GetSubscriptionByNumber(ctx context.Context, subscriptionNumber string) (*models.Subscription, error) {
 	var subscription models.Subscription
-	if err := s.db.WithContext(ctx).Preload("User").Preload("Policy").First(&subscription, "subscription_number = ?", subscriptionNumber).Error; err != nil {
+	if err := readDB(ctx, s.db).Preload("User").Preload("Policy").First(&subscription, "subscription_number = ?", subscriptionNumber).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("subscription not found")
 		}
@@ -65,7 +65,7 @@ func (s *subscriptionStore) GetSubscriptionByNumber(ctx context.Context, subscri
 // ListSubscriptions retrieves a list of subscriptions with optional filtering.
 func (s *subscriptionStore) ListSubscriptions(ctx context.Context, userID *uuid.UUID, policyID *uuid.UUID, status string, limit, offset int) ([]*models.Subscription, error) {
 	var subscriptions []*models.Subscription
-	query := s.db.WithContext(ctx).Model(&models.Subscription{}).Preload("User").Preload("Policy")
+	query := readDB(ctx, s.db).Model(&models.Subscription{}).Preload("User").Preload("Policy")
 
 	if userID != nil {
 		query = query.Where("user_id = ?", *userID)
@@ -109,7 +109,7 @@ func (s *subscriptionStore) DeleteSubscription(ctx context.Context, id uuid.UUID
 // CountSubscriptions returns the total number of subscriptions with optional filtering.
 func (s *subscriptionStore) CountSubscriptions(ctx context.Context, userID *uuid.UUID, policyID *uuid.UUID, status string) (int64, error) {
 	var count int64
-	query := s.db.WithContext(ctx).Model(&models.Subscription{})
+	query := readDB(ctx, s.db).Model(&models.Subscription{})
 
 	if userID != nil {
 		query = query.Where("user_id = ?", *userID)
@@ -0,0 +1,136 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SARStore defines the interface for suspicious activity report data operations.
+type SARStore interface {
+	CreateReport(ctx context.Context, report *models.SuspiciousActivityReport) error
+	GetReport(ctx context.Context, id uuid.UUID) (*models.SuspiciousActivityReport, error)
+	ListReportsByUser(ctx context.Context, userID uuid.UUID, status string) ([]*models.SuspiciousActivityReport, error)
+	UpdateReport(ctx context.Context, report *models.SuspiciousActivityReport) error
+	// SaveDraft merges a new report into the open draft it belongs to, or creates the report
+	// when there is none, and returns the stored draft. Reports with a claim share the claim's
+	// draft; other reports share the user's.
+	SaveDraft(ctx context.Context, report *models.SuspiciousActivityReport, merge func(draft *models.SuspiciousActivityReport)) (*models.SuspiciousActivityReport, error)
+}
+
+// sarStore implements SARStore interface.
+type sarStore struct {
+	db *gorm.DB
+}
+
+// NewSARStore creates a new SARStore instance.
+func NewSARStore(db *gorm.DB) SARStore {
+	return &sarStore{db: db}
+}
+
+// CreateReport creates a new suspicious activity report.
+func (s *sarStore) CreateReport(ctx context.Context, report *models.SuspiciousActivityReport) error {
+	if err := s.db.WithContext(ctx).Create(report).Error; err != nil {
+		return fmt.Errorf("failed to create suspicious activity report: %w", err)
+	}
+	return nil
+}
+
+// GetReport retrieves a suspicious activity report by ID.
+func (s *sarStore) GetReport(ctx context.Context, id uuid.UUID) (*models.SuspiciousActivityReport, error) {
+	var report models.SuspiciousActivityReport
+	if err := readDB(ctx, s.db).First(&report, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("suspicious activity report not found")
+		}
+		return nil, fmt.Errorf("failed to get suspicious activity report: %w", err)
+	}
+	return &report, nil
+}
+
+// ListReportsByUser retrieves a user's suspicious activity reports, optionally filtered by status.
+func (s *sarStore) ListReportsByUser(ctx context.Context, userID uuid.UUID, status string) ([]*models.SuspiciousActivityReport, error) {
+	var reports []*models.SuspiciousActivityReport
+	query := readDB(ctx, s.db).Where("user_id = ?", userID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if err := query.Order("created_at ASC").Find(&reports).Error; err != nil {
+		return nil, fmt.Errorf("failed to list suspicious activity reports: %w", err)
+	}
+	return reports, nil
+}
+
+// UpdateReport updates an existing suspicious activity report.
+func (s *sarStore) UpdateReport(ctx context.Context, report *models.SuspiciousActivityReport) error {
+	if err := s.db.WithContext(ctx).Save(report).Error; err != nil {
+		return fmt.Errorf("failed to update suspicious activity report: %w", err)
+	}
+	return nil
+}
+
+// SaveDraft merges a new report into its open draft, or creates it, in one transaction. The
+// draft is read under a row lock so concurrent findings are merged one after the other. When
+// two callers race to create the draft, the unique draft index keeps one and the loser merges
+// into the winner's.
+func (s *sarStore) SaveDraft(ctx context.Context, report *models.SuspiciousActivityReport, merge func(draft *models.SuspiciousActivityReport)) (*models.SuspiciousActivityReport, error) {
+	var saved *models.SuspiciousActivityReport
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		draft, err := s.lockDraft(tx, report)
+		if err != nil {
+			return err
+		}
+
+		if draft == nil {
+			created := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(report)
+			if created.Error != nil {
+				return created.Error
+			}
+			if created.RowsAffected == 1 {
+				saved = report
+				return nil
+			}
+			if draft, err = s.lockDraft(tx, report); err != nil {
+				return err
+			}
+			if draft == nil {
+				return fmt.Errorf("draft suspicious activity report was not created")
+			}
+		}
+
+		merge(draft)
+		if err := tx.Save(draft).Error; err != nil {
+			return err
+		}
+		saved = draft
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save draft suspicious activity report: %w", err)
+	}
+	return saved, nil
+}
+
+// lockDraft returns the open draft a report belongs to, locked for the rest of the
+// transaction, or nil when there is none.
+func (s *sarStore) lockDraft(tx *gorm.DB, report *models.SuspiciousActivityReport) (*models.SuspiciousActivityReport, error) {
+	query := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("status = ?", models.SARStatusDraft)
+	if report.ClaimID != nil {
+		query = query.Where("claim_id = ?", *report.ClaimID)
+	} else {
+		query = query.Where("user_id = ? AND claim_id IS NULL", report.UserID)
+	}
+
+	var drafts []*models.SuspiciousActivityReport
+	if err := query.Limit(1).Find(&drafts).Error; err != nil {
+		return nil, err
+	}
+	if len(drafts) == 0 {
+		return nil, nil
+	}
+	return drafts[0], nil
+}
@@ -0,0 +1,63 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveDraftMergesIntoTheOpenDraft(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	sarStore := NewSARStore(db)
+
+	userID := uuid.New()
+	claimID := uuid.New()
+	newReport := func(number string, claim *uuid.UUID) *models.SuspiciousActivityReport {
+		return &models.SuspiciousActivityReport{
+			ReportNumber: number,
+			UserID:       userID,
+			ClaimID:      claim,
+			Reason:       "Suspicious activity",
+			Status:       models.SARStatusDraft,
+		}
+	}
+	merges := 0
+	merge := func(draft *models.SuspiciousActivityReport) {
+		merges++
+		draft.Amount += 100
+	}
+
+	first, err := sarStore.SaveDraft(ctx, newReport("SAR-1", nil), merge)
+	require.NoError(t, err)
+	second, err := sarStore.SaveDraft(ctx, newReport("SAR-2", nil), merge)
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, second.ID)
+	assert.Equal(t, 1, merges)
+
+	// Fraud findings on a claim have their own draft.
+	claimDraft, err := sarStore.SaveDraft(ctx, newReport("SAR-3", &claimID), merge)
+	require.NoError(t, err)
+	assert.NotEqual(t, first.ID, claimDraft.ID)
+
+	// Creating a second open draft directly is rejected by the draft index.
+	assert.Error(t, sarStore.CreateReport(ctx, newReport("SAR-4", nil)))
+
+	// Once the draft is filed, new findings start another draft.
+	second.Status = models.SARStatusFiled
+	require.NoError(t, sarStore.UpdateReport(ctx, second))
+	next, err := sarStore.SaveDraft(ctx, newReport("SAR-5", nil), merge)
+	require.NoError(t, err)
+	assert.NotEqual(t, first.ID, next.ID)
+
+	reports, err := sarStore.ListReportsByUser(ctx, userID, "")
+	require.NoError(t, err)
+	assert.Len(t, reports, 3)
+	stored, err := sarStore.GetReport(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 100.0, stored.Amount)
+}
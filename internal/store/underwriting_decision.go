@@ -0,0 +1,63 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/edsonmichaque/bazaruto/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// UnderwritingDecisionStore defines the interface for underwriting decision data operations.
+type UnderwritingDecisionStore interface {
+	CreateDecision(ctx context.Context, decision *models.UnderwritingDecision) error
+	GetDecision(ctx context.Context, id uuid.UUID) (*models.UnderwritingDecision, error)
+	GetLatestDecision(ctx context.Context, applicationID uuid.UUID) (*models.UnderwritingDecision, error)
+}
+
+// underwritingDecisionStore implements UnderwritingDecisionStore interface.
+type underwritingDecisionStore struct {
+	db *gorm.DB
+}
+
+// NewUnderwritingDecisionStore creates a new UnderwritingDecisionStore instance.
+func NewUnderwritingDecisionStore(db *gorm.DB) UnderwritingDecisionStore {
+	return &underwritingDecisionStore{db: db}
+}
+
+// CreateDecision creates a new underwriting decision.
+func (s *underwritingDecisionStore) CreateDecision(ctx context.Context, decision *models.UnderwritingDecision) error {
+	if err := s.db.WithContext(ctx).Create(decision).Error; err != nil {
+		return fmt.Errorf("failed to create underwriting decision: %w", err)
+	}
+	return nil
+}
+
+// GetDecision retrieves an underwriting decision by ID.
+func (s *underwritingDecisionStore) GetDecision(ctx context.Context, id uuid.UUID) (*models.UnderwritingDecision, error) {
+	var decision models.UnderwritingDecision
+	if err := readDB(ctx, s.db).First(&decision, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("underwriting decision not found")
+		}
+		return nil, fmt.Errorf("failed to get underwriting decision: %w", err)
+	}
+	return &decision, nil
+}
+
+// GetLatestDecision retrieves the decision currently in force for an application, which is
+// the most recent one.
+func (s *underwritingDecisionStore) GetLatestDecision(ctx context.Context, applicationID uuid.UUID) (*models.UnderwritingDecision, error) {
+	var decision models.UnderwritingDecision
+	if err := readDB(ctx, s.db).
+		Where("application_id = ?", applicationID).
+		Order("created_at DESC").
+		First(&decision).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("underwriting decision not found")
+		}
+		return nil, fmt.Errorf("failed to get underwriting decision: %w", err)
+	}
+	return &decision, nil
+}
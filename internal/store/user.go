@@ -43,7 +43,7 @@ func (s *userStore) Create(ctx context.Context, user *models.User) error {
 // GetUser retrieves a user by ID.
 func (s *userStore) FindByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
 	var user models.User
-	if err := s.db.WithContext(ctx).First(&user, "id = ?", id).Error; err != nil {
+	if err := readDB(ctx, s.db).First(&user, "id = ?", id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("user not found")
 		}
@@ -55,7 +55,7 @@ func (s *userStore) FindByID(ctx context.Context, id uuid.UUID) (*models.User, e
 // GetUserByEmail retrieves a user by email.
 func (s *userStore) FindByEmail(ctx context.Context, email string) (*models.User, error) {
 	var user models.User
-	if err := s.db.WithContext(ctx).First(&user, "email = ?", email).Error; err != nil {
+	if err := readDB(ctx, s.db).First(&user, "email = ?", email).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("user not found")
 		}
@@ -67,7 +67,7 @@ func (s *userStore) FindByEmail(ctx context.Context, email string) (*models.User
 // FindByVerifyToken retrieves a user by verification token.
 func (s *userStore) FindByVerifyToken(ctx context.Context, token string) (*models.User, error) {
 	var user models.User
-	if err := s.db.WithContext(ctx).First(&user, "verify_token = ?", token).Error; err != nil {
+	if err := readDB(ctx, s.db).First(&user, "verify_token = ?", token).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("user not found for verification token")
 		}
@@ -79,7 +79,7 @@ func (s *userStore) FindByVerifyToken(ctx context.Context, token string) (*model
 // FindByResetToken retrieves a user by reset token.
 func (s *userStore) FindByResetToken(ctx context.Context, token string) (*models.User, error) {
 	var user models.User
-	if err := s.db.WithContext(ctx).First(&user, "reset_token = ?", token).Error; err != nil {
+	if err := readDB(ctx, s.db).First(&user, "reset_token = ?", token).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("user not found for reset token")
 		}
@@ -91,7 +91,7 @@ func (s *userStore) FindByResetToken(ctx context.Context, token string) (*models
 // List retrieves a list of users with pagination.
 func (s *userStore) List(ctx context.Context, limit, offset int) ([]*models.User, error) {
 	var users []*models.User
-	query := s.db.WithContext(ctx).Model(&models.User{})
+	query := readDB(ctx, s.db).Model(&models.User{})
 
 	if limit > 0 {
 		query = query.Limit(limit)
@@ -125,7 +125,7 @@ func (s *userStore) Delete(ctx context.Context, id uuid.UUID) error {
 // Count returns the total number of users.
 func (s *userStore) Count(ctx context.Context) (int64, error) {
 	var count int64
-	if err := s.db.WithContext(ctx).Model(&models.User{}).Count(&count).Error; err != nil {
+	if err := readDB(ctx, s.db).Model(&models.User{}).Count(&count).Error; err != nil {
 		return 0, fmt.Errorf("failed to count users: %w", err)
 	}
 	return count, nil
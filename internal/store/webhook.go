@@ -44,7 +44,7 @@ func (s *webhookStore) CreateWebhookConfig(ctx context.Context, config *models.W
 // GetWebhookConfig retrieves a webhook configuration by ID.
 func (s *webhookStore) GetWebhookConfig(ctx context.Context, id uuid.UUID) (*models.WebhookConfig, error) {
 	var config models.WebhookConfig
-	err := s.db.WithContext(ctx).
+	err := readDB(ctx, s.db).
 		Preload("Partner").
 		Where("id = ?", id).
 		First(&config).Error
@@ -67,7 +67,7 @@ func (s *webhookStore) DeleteWebhookConfig(ctx context.Context, id uuid.UUID) er
 // ListWebhookConfigs retrieves webhook configurations with optional filtering.
 func (s *webhookStore) ListWebhookConfigs(ctx context.Context, partnerID *uuid.UUID, isActive *bool, limit, offset int) ([]*models.WebhookConfig, error) {
 	var configs []*models.WebhookConfig
-	query := s.db.WithContext(ctx).Preload("Partner")
+	query := readDB(ctx, s.db).Preload("Partner")
 
 	if partnerID != nil {
 		query = query.Where("partner_id = ?", *partnerID)
@@ -85,7 +85,7 @@ func (s *webhookStore) GetWebhookConfigsForEvent(ctx context.Context, eventType
 	var configs []*models.WebhookConfig
 
 	// Use JSONB contains operator to check if eventType is in the event_types array
-	err := s.db.WithContext(ctx).
+	err := readDB(ctx, s.db).
 		Where("is_active = ? AND event_types @> ?", true, `["`+eventType+`"]`).
 		Find(&configs).Error
 
@@ -100,7 +100,7 @@ func (s *webhookStore) CreateWebhookDelivery(ctx context.Context, delivery *mode
 // GetWebhookDelivery retrieves a webhook delivery by ID.
 func (s *webhookStore) GetWebhookDelivery(ctx context.Context, id uuid.UUID) (*models.WebhookDelivery, error) {
 	var delivery models.WebhookDelivery
-	err := s.db.WithContext(ctx).
+	err := readDB(ctx, s.db).
 		Preload("WebhookConfig").
 		Where("id = ?", id).
 		First(&delivery).Error
@@ -118,7 +118,7 @@ func (s *webhookStore) UpdateWebhookDelivery(ctx context.Context, delivery *mode
 // GetWebhookDeliveries retrieves webhook delivery records with optional filtering.
 func (s *webhookStore) GetWebhookDeliveries(ctx context.Context, webhookConfigID *uuid.UUID, status string, limit, offset int) ([]*models.WebhookDelivery, error) {
 	var deliveries []*models.WebhookDelivery
-	query := s.db.WithContext(ctx).Preload("WebhookConfig")
+	query := readDB(ctx, s.db).Preload("WebhookConfig")
 
 	if webhookConfigID != nil {
 		query = query.Where("webhook_config_id = ?", *webhookConfigID)
@@ -134,7 +134,7 @@ func (s *webhookStore) GetWebhookDeliveries(ctx context.Context, webhookConfigID
 // CountWebhookDeliveries returns the total number of webhook deliveries with optional filtering.
 func (s *webhookStore) CountWebhookDeliveries(ctx context.Context, webhookConfigID *uuid.UUID, status string) (int64, error) {
 	var count int64
-	query := s.db.WithContext(ctx).Model(&models.WebhookDelivery{})
+	query := readDB(ctx, s.db).Model(&models.WebhookDelivery{})
 
 	if webhookConfigID != nil {
 		query = query.Where("webhook_config_id = ?", *webhookConfigID)
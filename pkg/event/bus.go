@@ -4,25 +4,96 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 )
 
-// Bus implements an in-memory event bus with async publishing.
+// Default delivery retry policy for a handler that returns an error or panics.
+const (
+	DefaultHandlerMaxAttempts  = 3
+	DefaultHandlerRetryBackoff = 100 * time.Millisecond
+)
+
+// DeliveryFailure describes an event a handler failed to process after all its attempts.
+type DeliveryFailure struct {
+	HandlerName string
+	Event       Event
+	Err         error
+	Attempts    int
+	FailedAt    time.Time
+}
+
+// DeadLetterFunc receives deliveries that exhausted their retries.
+type DeadLetterFunc func(ctx context.Context, failure DeliveryFailure)
+
+// AsyncDeliveryFunc hands the delivery of an event to an asynchronously subscribed handler
+// off to run elsewhere, such as a job queue. The receiver performs the delivery later with
+// Bus.Deliver.
+type AsyncDeliveryFunc func(ctx context.Context, handlerName string, event Event) error
+
+// subscription is a handler registered for an event type and how it is delivered.
+type subscription struct {
+	handler EventHandler
+	mode    DeliveryMode
+}
+
+// Bus implements an in-memory event bus with async publishing. Each handler is delivered
+// to independently: an error or panic in one handler is retried for that handler alone
+// and never affects delivery to the others.
 type Bus struct {
-	handlers map[string][]EventHandler
-	mutex    sync.RWMutex
+	handlers     map[string][]subscription
+	mutex        sync.RWMutex
+	maxAttempts  int
+	retryBackoff time.Duration
+	deadLetter   DeadLetterFunc
+	async        AsyncDeliveryFunc
 }
 
 // NewBus creates a new in-memory event bus.
 func NewBus() *Bus {
 	return &Bus{
-		handlers: make(map[string][]EventHandler),
+		handlers:     make(map[string][]subscription),
+		maxAttempts:  DefaultHandlerMaxAttempts,
+		retryBackoff: DefaultHandlerRetryBackoff,
 	}
 }
 
-// Publish publishes an event to all registered handlers asynchronously.
+// SetRetryPolicy sets how many times a failing handler is tried per event and the delay
+// between attempts, which doubles after each failure.
+func (b *Bus) SetRetryPolicy(maxAttempts int, backoff time.Duration) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	b.maxAttempts = maxAttempts
+	b.retryBackoff = backoff
+}
+
+// SetDeadLetterHandler sets the function that receives deliveries that exhausted their
+// retries. Without one, such deliveries are dropped.
+func (b *Bus) SetDeadLetterHandler(deadLetter DeadLetterFunc) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.deadLetter = deadLetter
+}
+
+// SetAsyncDelivery sets the function asynchronous subscriptions are handed off to. Without
+// one, or when it fails, they are delivered in the background of this process.
+func (b *Bus) SetAsyncDelivery(async AsyncDeliveryFunc) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.async = async
+}
+
+// Publish publishes an event to all registered handlers. Asynchronous subscriptions are
+// handed off to the async delivery function, or delivered concurrently in the background
+// without one; synchronous ones are delivered in order before Publish returns. Handler
+// failures in either mode are retried and dead-lettered rather than returned to the
+// publisher.
 func (b *Bus) Publish(ctx context.Context, event Event) error {
 	b.mutex.RLock()
-	handlers, exists := b.handlers[event.Type()]
+	subscriptions, exists := b.handlers[event.Type()]
+	maxAttempts, backoff, deadLetter, async := b.maxAttempts, b.retryBackoff, b.deadLetter, b.async
 	b.mutex.RUnlock()
 
 	if !exists {
@@ -30,50 +101,134 @@ func (b *Bus) Publish(ctx context.Context, event Event) error {
 		return nil
 	}
 
-	// Publish asynchronously - don't wait for handlers to complete
-	go func() {
-		// Process handlers concurrently
-		var wg sync.WaitGroup
-
-		for _, handler := range handlers {
-			wg.Add(1)
-			go func(h EventHandler) {
-				defer wg.Done()
-				// Create a new context for each handler to avoid cancellation
-				handlerCtx := context.Background()
-				if err := h.Handle(handlerCtx, event); err != nil {
-					// Log error but don't fail the entire operation
-					// In a real implementation, you might want to use a logger here
-					_ = err // Suppress unused variable warning
-				}
-			}(handler)
+	var syncHandlers, asyncHandlers []EventHandler
+	for _, sub := range subscriptions {
+		if sub.mode == DeliveryModeSync {
+			syncHandlers = append(syncHandlers, sub.handler)
+			continue
+		}
+		// Hand asynchronous deliveries off when possible, falling back to delivering them here
+		if async != nil && async(ctx, sub.handler.HandlerName(), event) == nil {
+			continue
 		}
+		asyncHandlers = append(asyncHandlers, sub.handler)
+	}
 
-		wg.Wait()
-	}()
+	// Publish asynchronously - don't wait for handlers to complete
+	if len(asyncHandlers) > 0 {
+		go func() {
+			// Process handlers concurrently
+			var wg sync.WaitGroup
+
+			for _, handler := range asyncHandlers {
+				wg.Add(1)
+				go func(h EventHandler) {
+					defer wg.Done()
+					// Create a new context for each handler to avoid cancellation
+					deliverOrDeadLetter(context.Background(), h, event, maxAttempts, backoff, deadLetter)
+				}(handler)
+			}
+
+			wg.Wait()
+		}()
+	}
+
+	// Synchronous handlers keep the publisher's context values but not its cancellation
+	for _, handler := range syncHandlers {
+		deliverOrDeadLetter(context.WithoutCancel(ctx), handler, event, maxAttempts, backoff, deadLetter)
+	}
 
 	return nil
 }
 
-// Subscribe registers an event handler for specific event types.
+// Deliver delivers an event to the handler with the given name that is subscribed to the
+// event's type, retrying and dead-lettering it like Publish. It is how an asynchronous
+// delivery handed off with SetAsyncDelivery is performed.
+func (b *Bus) Deliver(ctx context.Context, handlerName string, event Event) error {
+	b.mutex.RLock()
+	var handler EventHandler
+	for _, sub := range b.handlers[event.Type()] {
+		if sub.handler.HandlerName() == handlerName {
+			handler = sub.handler
+			break
+		}
+	}
+	maxAttempts, backoff, deadLetter := b.maxAttempts, b.retryBackoff, b.deadLetter
+	b.mutex.RUnlock()
+
+	if handler == nil {
+		return fmt.Errorf("handler %s is not subscribed to event type %s", handlerName, event.Type())
+	}
+	deliverOrDeadLetter(ctx, handler, event, maxAttempts, backoff, deadLetter)
+	return nil
+}
+
+// deliverOrDeadLetter delivers an event to a handler and hands it to the dead-letter
+// function if every attempt fails.
+func deliverOrDeadLetter(ctx context.Context, h EventHandler, event Event, maxAttempts int, backoff time.Duration, deadLetter DeadLetterFunc) {
+	attempts, err := deliver(ctx, h, event, maxAttempts, backoff)
+	if err != nil && deadLetter != nil {
+		deadLetter(ctx, DeliveryFailure{
+			HandlerName: h.HandlerName(),
+			Event:       event,
+			Err:         err,
+			Attempts:    attempts,
+			FailedAt:    time.Now(),
+		})
+	}
+}
+
+// deliver hands an event to a handler, retrying with exponential backoff until it
+// succeeds or maxAttempts is reached. It returns the attempts made and the last error.
+func deliver(ctx context.Context, h EventHandler, event Event, maxAttempts int, backoff time.Duration) (int, error) {
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = handleSafely(ctx, h, event); err == nil {
+			return attempt, nil
+		}
+		if attempt >= maxAttempts {
+			return attempt, err
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// handleSafely calls the handler, converting a panic into an error.
+func handleSafely(ctx context.Context, h EventHandler, event Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("handler %s panicked: %v", h.HandlerName(), r)
+		}
+	}()
+	return h.Handle(ctx, event)
+}
+
+// Subscribe registers an event handler for specific event types with asynchronous delivery.
 func (b *Bus) Subscribe(handler EventHandler, eventTypes ...string) error {
+	return b.SubscribeWithMode(handler, DeliveryModeAsync, eventTypes...)
+}
+
+// SubscribeWithMode registers an event handler for specific event types with the given
+// delivery mode.
+func (b *Bus) SubscribeWithMode(handler EventHandler, mode DeliveryMode, eventTypes ...string) error {
+	if mode != DeliveryModeSync && mode != DeliveryModeAsync {
+		return fmt.Errorf("unknown delivery mode %q", mode)
+	}
+
 	b.mutex.Lock()
 	defer b.mutex.Unlock()
 
 	for _, eventType := range eventTypes {
-		if b.handlers[eventType] == nil {
-			b.handlers[eventType] = make([]EventHandler, 0)
-		}
-
 		// Check if handler is already registered for this event type
-		for _, existingHandler := range b.handlers[eventType] {
-			if existingHandler.HandlerName() == handler.HandlerName() {
+		for _, existing := range b.handlers[eventType] {
+			if existing.handler.HandlerName() == handler.HandlerName() {
 				return fmt.Errorf("handler %s is already registered for event type %s",
 					handler.HandlerName(), eventType)
 			}
 		}
 
-		b.handlers[eventType] = append(b.handlers[eventType], handler)
+		b.handlers[eventType] = append(b.handlers[eventType], subscription{handler: handler, mode: mode})
 	}
 
 	return nil
@@ -85,16 +240,16 @@ func (b *Bus) Unsubscribe(handlerName string) error {
 	defer b.mutex.Unlock()
 
 	removed := false
-	for eventType, handlers := range b.handlers {
-		newHandlers := make([]EventHandler, 0, len(handlers))
-		for _, handler := range handlers {
-			if handler.HandlerName() != handlerName {
-				newHandlers = append(newHandlers, handler)
+	for eventType, subscriptions := range b.handlers {
+		remaining := make([]subscription, 0, len(subscriptions))
+		for _, sub := range subscriptions {
+			if sub.handler.HandlerName() != handlerName {
+				remaining = append(remaining, sub)
 			} else {
 				removed = true
 			}
 		}
-		b.handlers[eventType] = newHandlers
+		b.handlers[eventType] = remaining
 	}
 
 	if !removed {
@@ -109,7 +264,7 @@ func (b *Bus) Close() error {
 	b.mutex.Lock()
 	defer b.mutex.Unlock()
 
-	b.handlers = make(map[string][]EventHandler)
+	b.handlers = make(map[string][]subscription)
 	return nil
 }
 
@@ -0,0 +1,199 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingHandler collects every event delivered to it.
+type recordingHandler struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (h *recordingHandler) Handle(ctx context.Context, e Event) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = append(h.events, e)
+	return nil
+}
+
+func (h *recordingHandler) CanHandle(eventType string) bool { return true }
+
+func (h *recordingHandler) HandlerName() string { return "recording_handler" }
+
+func (h *recordingHandler) count() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.events)
+}
+
+// panickingHandler panics on every delivery and counts its attempts.
+type panickingHandler struct {
+	mu       sync.Mutex
+	attempts int
+}
+
+func (h *panickingHandler) Handle(ctx context.Context, e Event) error {
+	h.mu.Lock()
+	h.attempts++
+	h.mu.Unlock()
+	panic("handler exploded")
+}
+
+func (h *panickingHandler) CanHandle(eventType string) bool { return true }
+
+func (h *panickingHandler) HandlerName() string { return "panicking_handler" }
+
+// flakyHandler fails its first failures deliveries and then succeeds.
+type flakyHandler struct {
+	mu       sync.Mutex
+	failures int
+	attempts int
+}
+
+func (h *flakyHandler) Handle(ctx context.Context, e Event) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.attempts++
+	if h.attempts <= h.failures {
+		return errors.New("temporarily unavailable")
+	}
+	return nil
+}
+
+func (h *flakyHandler) CanHandle(eventType string) bool { return true }
+
+func (h *flakyHandler) HandlerName() string { return "flaky_handler" }
+
+// blockingHandler blocks each delivery until released.
+type blockingHandler struct {
+	name    string
+	started chan struct{}
+	release chan struct{}
+}
+
+func newBlockingHandler(name string) *blockingHandler {
+	return &blockingHandler{name: name, started: make(chan struct{}, 1), release: make(chan struct{})}
+}
+
+func (h *blockingHandler) Handle(ctx context.Context, e Event) error {
+	h.started <- struct{}{}
+	<-h.release
+	return nil
+}
+
+func (h *blockingHandler) CanHandle(eventType string) bool { return true }
+
+func (h *blockingHandler) HandlerName() string { return h.name }
+
+// deadLetters collects the deliveries a bus dead-letters.
+type deadLetters struct {
+	mu       sync.Mutex
+	failures []DeliveryFailure
+}
+
+func (d *deadLetters) record(ctx context.Context, failure DeliveryFailure) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.failures = append(d.failures, failure)
+}
+
+func (d *deadLetters) list() []DeliveryFailure {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]DeliveryFailure(nil), d.failures...)
+}
+
+func TestPanickingHandlerDoesNotBlockOtherHandlers(t *testing.T) {
+	bus := NewBus()
+	bus.SetRetryPolicy(2, time.Millisecond)
+	dead := &deadLetters{}
+	bus.SetDeadLetterHandler(dead.record)
+
+	failing := &panickingHandler{}
+	recording := &recordingHandler{}
+	require.NoError(t, bus.Subscribe(failing, "policy.created"))
+	require.NoError(t, bus.Subscribe(recording, "policy.created"))
+
+	published := NewBaseEvent("policy.created", "policy", uuid.New(), 1, nil)
+	require.NoError(t, bus.Publish(context.Background(), published))
+
+	require.Eventually(t, func() bool { return recording.count() == 1 }, time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool { return len(dead.list()) == 1 }, time.Second, 10*time.Millisecond)
+
+	failure := dead.list()[0]
+	assert.Equal(t, failing.HandlerName(), failure.HandlerName)
+	assert.Equal(t, published.ID(), failure.Event.ID())
+	assert.Equal(t, 2, failure.Attempts)
+	assert.ErrorContains(t, failure.Err, "handler exploded")
+
+	failing.mu.Lock()
+	defer failing.mu.Unlock()
+	assert.Equal(t, 2, failing.attempts)
+}
+
+func TestFailingHandlerIsRetriedUntilItSucceeds(t *testing.T) {
+	bus := NewBus()
+	bus.SetRetryPolicy(3, time.Millisecond)
+	dead := &deadLetters{}
+	bus.SetDeadLetterHandler(dead.record)
+
+	flaky := &flakyHandler{failures: 2}
+	require.NoError(t, bus.SubscribeWithMode(flaky, DeliveryModeSync, "policy.created"))
+	require.NoError(t, bus.Publish(context.Background(), NewBaseEvent("policy.created", "policy", uuid.New(), 1, nil)))
+
+	flaky.mu.Lock()
+	defer flaky.mu.Unlock()
+	assert.Equal(t, 3, flaky.attempts)
+	assert.Empty(t, dead.list())
+}
+
+func TestPublishWaitsOnlyForSyncHandlers(t *testing.T) {
+	ctx := context.Background()
+	bus := NewBus()
+
+	async := newBlockingHandler("async_handler")
+	sync := newBlockingHandler("sync_handler")
+	require.NoError(t, bus.SubscribeWithMode(async, DeliveryModeAsync, "policy.created"))
+	require.NoError(t, bus.SubscribeWithMode(sync, DeliveryModeSync, "policy.renewed"))
+
+	// Publish returns while the async handler is still blocked.
+	published := make(chan error, 1)
+	go func() {
+		published <- bus.Publish(ctx, NewBaseEvent("policy.created", "policy", uuid.New(), 1, nil))
+	}()
+	select {
+	case err := <-published:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Publish waited for the async handler")
+	}
+	<-async.started
+	close(async.release)
+
+	// The sync handler must finish before Publish returns.
+	go func() {
+		published <- bus.Publish(ctx, NewBaseEvent("policy.renewed", "policy", uuid.New(), 1, nil))
+	}()
+	<-sync.started
+	select {
+	case <-published:
+		t.Fatal("Publish returned before the sync handler finished")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(sync.release)
+	select {
+	case err := <-published:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Publish did not return after the sync handler finished")
+	}
+}
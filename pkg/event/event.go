@@ -120,17 +120,49 @@ type EventHandler interface {
 	HandlerName() string
 }
 
+// DeliveryMode controls whether a subscription is delivered before Publish returns.
+type DeliveryMode string
+
+// Delivery modes.
+const (
+	// DeliveryModeSync delivers the event to the handler before Publish returns, so the
+	// publisher waits for it. Use it for in-process handlers that must have run.
+	DeliveryModeSync DeliveryMode = "sync"
+	// DeliveryModeAsync delivers the event in the background without blocking the publisher.
+	DeliveryModeAsync DeliveryMode = "async"
+)
+
 // EventBus defines the interface for publishing and subscribing to events.
 type EventBus interface {
 	// Publish publishes an event to all registered handlers
 	Publish(ctx context.Context, event Event) error
 
-	// Subscribe registers an event handler for specific event types
+	// Subscribe registers an event handler for specific event types with asynchronous delivery
 	Subscribe(handler EventHandler, eventTypes ...string) error
 
+	// SubscribeWithMode registers an event handler for specific event types with the given delivery mode
+	SubscribeWithMode(handler EventHandler, mode DeliveryMode, eventTypes ...string) error
+
 	// Unsubscribe removes an event handler
 	Unsubscribe(handlerName string) error
 
 	// Close closes the event bus and cleans up resources
 	Close() error
 }
+
+// Metadata keys set on events that are re-delivered by a replay.
+const (
+	MetadataReplayed   = "replayed"
+	MetadataReplayedAt = "replayed_at"
+)
+
+// IsReplayed reports whether the event is a replayed delivery of a previously
+// published event. Idempotent handlers can use it together with ID() to dedup.
+func IsReplayed(e Event) bool {
+	metadata := e.Metadata()
+	if metadata == nil {
+		return false
+	}
+	replayed, ok := metadata[MetadataReplayed].(bool)
+	return ok && replayed
+}
@@ -8,11 +8,13 @@ import (
 	"github.com/google/uuid"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // DatabaseAdapter implements a database-based job queue using GORM
 type DatabaseAdapter struct {
-	db *gorm.DB
+	db           *gorm.DB
+	uniqueWindow time.Duration
 }
 
 // NewDatabaseAdapter creates a new database adapter
@@ -23,12 +25,18 @@ func NewDatabaseAdapter(config DatabaseAdapterConfig) (*DatabaseAdapter, error)
 	}
 
 	// Auto-migrate job tables
-	if err := db.AutoMigrate(&SerializedJob{}); err != nil {
+	if err := db.AutoMigrate(&SerializedJob{}, &JobUniqueKey{}); err != nil {
 		return nil, fmt.Errorf("failed to migrate job tables: %w", err)
 	}
 
+	uniqueWindow := config.UniqueWindow
+	if uniqueWindow == 0 {
+		uniqueWindow = DefaultUniqueWindow
+	}
+
 	return &DatabaseAdapter{
-		db: db,
+		db:           db,
+		uniqueWindow: uniqueWindow,
 	}, nil
 }
 
@@ -43,7 +51,34 @@ func (d *DatabaseAdapter) EnqueueAt(ctx context.Context, job *SerializedJob, at
 	job.CreatedAt = time.Now()
 	job.UpdatedAt = time.Now()
 
-	return d.db.WithContext(ctx).Create(job).Error
+	if job.UniqueKey == "" || d.uniqueWindow <= 0 {
+		return d.db.WithContext(ctx).Create(job).Error
+	}
+
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		// Release an expired hold so the key can be taken again
+		if err := tx.Where("key = ? AND expires_at <= ?", job.UniqueKey, now).
+			Delete(&JobUniqueKey{}).Error; err != nil {
+			return fmt.Errorf("failed to release expired unique key: %w", err)
+		}
+
+		hold := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&JobUniqueKey{
+			Key:       job.UniqueKey,
+			JobID:     job.ID,
+			ExpiresAt: now.Add(d.uniqueWindow),
+		})
+		if hold.Error != nil {
+			return fmt.Errorf("failed to hold job unique key: %w", hold.Error)
+		}
+		if hold.RowsAffected == 0 {
+			// A duplicate is still inside the dedup window
+			return nil
+		}
+
+		return tx.Create(job).Error
+	})
 }
 
 // Dequeue retrieves the next job from the queue using SKIP LOCKED
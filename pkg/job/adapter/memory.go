@@ -10,75 +10,141 @@ import (
 	"github.com/google/uuid"
 )
 
-// MemoryAdapter implements an in-memory job queue using channels and priority queues
+// MemoryAdapter implements an in-memory job queue using priority queues. Each queue keeps
+// jobs that are due in a ready heap ordered by priority, and jobs scheduled for later in a
+// delayed heap ordered by run time; delayed jobs move to the ready heap once they are due.
 type MemoryAdapter struct {
-	queues    map[string]*priorityQueue
-	mu        sync.RWMutex
-	shutdown  chan struct{}
-	waitGroup sync.WaitGroup
+	queues       map[string]*memoryQueue
+	uniqueKeys   map[string]*uniqueKeyHold
+	uniqueWindow time.Duration
+	now          func() time.Time
+	sequence     uint64
+	mu           sync.RWMutex
+	shutdown     chan struct{}
+	waitGroup    sync.WaitGroup
+}
+
+// memoryQueue holds the ready and delayed jobs of a single queue.
+type memoryQueue struct {
+	ready   *jobHeap
+	delayed *jobHeap
+}
+
+// uniqueKeyHold records the job holding a unique key and when the hold expires.
+type uniqueKeyHold struct {
+	jobID     uuid.UUID
+	expiresAt time.Time
 }
 
 // NewMemoryAdapter creates a new in-memory adapter
 func NewMemoryAdapter() *MemoryAdapter {
 	return &MemoryAdapter{
-		queues:   make(map[string]*priorityQueue),
-		shutdown: make(chan struct{}),
+		queues:       make(map[string]*memoryQueue),
+		uniqueKeys:   make(map[string]*uniqueKeyHold),
+		uniqueWindow: DefaultUniqueWindow,
+		now:          time.Now,
+		shutdown:     make(chan struct{}),
 	}
 }
 
+// SetUniqueWindow sets how long a unique key blocks duplicate enqueues.
+// A non-positive window disables deduplication.
+func (m *MemoryAdapter) SetUniqueWindow(window time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.uniqueWindow = window
+}
+
+// SetClock replaces the adapter's time source, which decides when delayed jobs are due.
+func (m *MemoryAdapter) SetClock(now func() time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.now = now
+}
+
 // Enqueue adds a job to the queue for immediate processing
 func (m *MemoryAdapter) Enqueue(ctx context.Context, job *SerializedJob) error {
-	return m.EnqueueAt(ctx, job, time.Now())
+	return m.EnqueueAt(ctx, job, m.clock())
 }
 
-// EnqueueAt schedules a job to be processed at a specific time
+// EnqueueAt schedules a job to be processed at a specific time. Jobs due later than
+// now wait in the queue's delayed heap.
 func (m *MemoryAdapter) EnqueueAt(ctx context.Context, job *SerializedJob, at time.Time) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	// Drop duplicates of a unique job still inside the dedup window
+	if !m.holdUniqueKey(job) {
+		return nil
+	}
+
 	// Set the run time
 	job.RunAt = at
 
 	// Get or create queue
 	queue, exists := m.queues[job.Queue]
 	if !exists {
-		queue = &priorityQueue{}
-		heap.Init(queue)
+		queue = newMemoryQueue()
 		m.queues[job.Queue] = queue
 	}
 
-	// Add job to priority queue
-	heap.Push(queue, &jobItem{
+	m.sequence++
+	item := &jobItem{
 		job:      job,
 		priority: job.Priority,
 		runAt:    at,
-	})
+		sequence: m.sequence,
+	}
+
+	if at.After(m.now()) {
+		heap.Push(queue.delayed, item)
+	} else {
+		heap.Push(queue.ready, item)
+	}
 
 	return nil
 }
 
+// holdUniqueKey reports whether job may be enqueued, taking its unique key for the dedup
+// window. A job re-enqueued for retry keeps the key it already holds. Callers must hold mu.
+func (m *MemoryAdapter) holdUniqueKey(job *SerializedJob) bool {
+	if job.UniqueKey == "" || m.uniqueWindow <= 0 {
+		return true
+	}
+
+	now := m.now()
+	if hold, ok := m.uniqueKeys[job.UniqueKey]; ok && hold.jobID != job.ID && now.Before(hold.expiresAt) {
+		return false
+	}
+
+	m.uniqueKeys[job.UniqueKey] = &uniqueKeyHold{
+		jobID:     job.ID,
+		expiresAt: now.Add(m.uniqueWindow),
+	}
+	return true
+}
+
 // Dequeue retrieves the next job from the queue
 func (m *MemoryAdapter) Dequeue(ctx context.Context, queueName string) (*SerializedJob, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	queue, exists := m.queues[queueName]
-	if !exists || queue.Len() == 0 {
+	if !exists || queue.len() == 0 {
 		return nil, fmt.Errorf("no jobs available in queue %s", queueName)
 	}
 
-	// Check if the next job is ready to run
-	item := (*queue)[0]
-	if item.runAt.After(time.Now()) {
+	now := m.now()
+	queue.promoteDue(now)
+	if queue.ready.Len() == 0 {
 		return nil, fmt.Errorf("no jobs ready to run in queue %s", queueName)
 	}
 
 	// Remove job from queue
-	jobItem := heap.Pop(queue).(*jobItem)
+	jobItem := heap.Pop(queue.ready).(*jobItem)
 	job := jobItem.job
 
 	// Mark as locked
-	now := time.Now()
 	job.LockedAt = &now
 	job.LockedBy = "memory-worker"
 
@@ -99,7 +165,7 @@ func (m *MemoryAdapter) Retry(ctx context.Context, job *SerializedJob) error {
 
 	// Calculate backoff delay (exponential backoff with jitter)
 	delay := time.Duration(job.Attempts*job.Attempts) * time.Second
-	job.RunAt = time.Now().Add(delay)
+	job.RunAt = m.clock().Add(delay)
 
 	// Clear lock
 	job.LockedAt = nil
@@ -114,7 +180,7 @@ func (m *MemoryAdapter) Retry(ctx context.Context, job *SerializedJob) error {
 // Dead moves a job to the dead letter queue
 func (m *MemoryAdapter) Dead(ctx context.Context, job *SerializedJob) error {
 	// In memory adapter, we just mark it as failed
-	now := time.Now()
+	now := m.clock()
 	job.FailedAt = &now
 	job.LockedAt = nil
 	job.LockedBy = ""
@@ -129,15 +195,13 @@ func (m *MemoryAdapter) Stats(ctx context.Context) (map[string]*QueueStats, erro
 	defer m.mu.RUnlock()
 
 	stats := make(map[string]*QueueStats)
+	now := m.now()
 
 	for queueName, queue := range m.queues {
-		ready := 0
-		scheduled := 0
-
-		for _, item := range *queue {
-			if item.runAt.After(time.Now()) {
-				scheduled++
-			} else {
+		// Delayed jobs that are already due count as pending
+		ready := queue.ready.Len()
+		for _, item := range queue.delayed.items {
+			if !item.runAt.After(now) {
 				ready++
 			}
 		}
@@ -161,7 +225,7 @@ func (m *MemoryAdapter) Clear(ctx context.Context, queue string) error {
 
 	if queue == "" {
 		// Clear all queues
-		m.queues = make(map[string]*priorityQueue)
+		m.queues = make(map[string]*memoryQueue)
 	} else {
 		delete(m.queues, queue)
 	}
@@ -176,46 +240,82 @@ func (m *MemoryAdapter) Close() error {
 	return nil
 }
 
-// jobItem represents an item in the priority queue
+// clock returns the current time from the adapter's time source.
+func (m *MemoryAdapter) clock() time.Time {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.now()
+}
+
+// jobItem represents an item in a job heap
 type jobItem struct {
 	job      *SerializedJob
 	priority int
 	runAt    time.Time
+	sequence uint64
 	index    int
 }
 
-// priorityQueue implements heap.Interface for priority-based job scheduling
-type priorityQueue []*jobItem
+// newMemoryQueue creates an empty queue with ready and delayed heaps.
+func newMemoryQueue() *memoryQueue {
+	return &memoryQueue{
+		// Ready jobs by priority (higher priority first), then in enqueue order
+		ready: &jobHeap{less: func(a, b *jobItem) bool {
+			if a.priority != b.priority {
+				return a.priority > b.priority
+			}
+			return a.sequence < b.sequence
+		}},
+		// Delayed jobs by run time, then in enqueue order
+		delayed: &jobHeap{less: func(a, b *jobItem) bool {
+			if !a.runAt.Equal(b.runAt) {
+				return a.runAt.Before(b.runAt)
+			}
+			return a.sequence < b.sequence
+		}},
+	}
+}
 
-func (pq priorityQueue) Len() int { return len(pq) }
+// len returns the number of ready and delayed jobs.
+func (q *memoryQueue) len() int {
+	return q.ready.Len() + q.delayed.Len()
+}
 
-func (pq priorityQueue) Less(i, j int) bool {
-	// First by run time, then by priority (higher priority first)
-	if pq[i].runAt.Equal(pq[j].runAt) {
-		return pq[i].priority > pq[j].priority
+// promoteDue moves delayed jobs whose run time has arrived to the ready heap.
+func (q *memoryQueue) promoteDue(now time.Time) {
+	for q.delayed.Len() > 0 && !q.delayed.items[0].runAt.After(now) {
+		heap.Push(q.ready, heap.Pop(q.delayed))
 	}
-	return pq[i].runAt.Before(pq[j].runAt)
 }
 
-func (pq priorityQueue) Swap(i, j int) {
-	pq[i], pq[j] = pq[j], pq[i]
-	pq[i].index = i
-	pq[j].index = j
+// jobHeap implements heap.Interface over job items using a configurable ordering
+type jobHeap struct {
+	items []*jobItem
+	less  func(a, b *jobItem) bool
+}
+
+func (h *jobHeap) Len() int { return len(h.items) }
+
+func (h *jobHeap) Less(i, j int) bool { return h.less(h.items[i], h.items[j]) }
+
+func (h *jobHeap) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.items[i].index = i
+	h.items[j].index = j
 }
 
-func (pq *priorityQueue) Push(x interface{}) {
-	n := len(*pq)
+func (h *jobHeap) Push(x interface{}) {
 	item := x.(*jobItem)
-	item.index = n
-	*pq = append(*pq, item)
+	item.index = len(h.items)
+	h.items = append(h.items, item)
 }
 
-func (pq *priorityQueue) Pop() interface{} {
-	old := *pq
+func (h *jobHeap) Pop() interface{} {
+	old := h.items
 	n := len(old)
 	item := old[n-1]
 	old[n-1] = nil
 	item.index = -1
-	*pq = old[0 : n-1]
+	h.items = old[0 : n-1]
 	return item
 }
@@ -12,8 +12,9 @@ import (
 
 // RedisAdapter implements a Redis-based job queue using sorted sets and lists
 type RedisAdapter struct {
-	client *redis.Client
-	prefix string
+	client       *redis.Client
+	prefix       string
+	uniqueWindow time.Duration
 }
 
 // NewRedisAdapter creates a new Redis adapter
@@ -37,9 +38,15 @@ func NewRedisAdapter(config RedisAdapterConfig) (*RedisAdapter, error) {
 		prefix = "bazaruto:jobs"
 	}
 
+	uniqueWindow := config.UniqueWindow
+	if uniqueWindow == 0 {
+		uniqueWindow = DefaultUniqueWindow
+	}
+
 	return &RedisAdapter{
-		client: client,
-		prefix: prefix,
+		client:       client,
+		prefix:       prefix,
+		uniqueWindow: uniqueWindow,
 	}, nil
 }
 
@@ -50,6 +57,15 @@ func (r *RedisAdapter) Enqueue(ctx context.Context, job *SerializedJob) error {
 
 // EnqueueAt schedules a job to be processed at a specific time
 func (r *RedisAdapter) EnqueueAt(ctx context.Context, job *SerializedJob, at time.Time) error {
+	// Drop duplicates of a unique job still inside the dedup window
+	held, err := r.holdUniqueKey(ctx, job)
+	if err != nil {
+		return err
+	}
+	if !held {
+		return nil
+	}
+
 	// Serialize job data
 	jobData, err := json.Marshal(job)
 	if err != nil {
@@ -79,6 +95,33 @@ func (r *RedisAdapter) EnqueueAt(ctx context.Context, job *SerializedJob, at tim
 	return nil
 }
 
+// holdUniqueKey reports whether job may be enqueued, taking its unique key for the dedup
+// window. A job re-enqueued for retry keeps the key it already holds.
+func (r *RedisAdapter) holdUniqueKey(ctx context.Context, job *SerializedJob) (bool, error) {
+	if job.UniqueKey == "" || r.uniqueWindow <= 0 {
+		return true, nil
+	}
+
+	key := r.uniqueKey(job.UniqueKey)
+	held, err := r.client.SetNX(ctx, key, job.ID.String(), r.uniqueWindow).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to hold job unique key: %w", err)
+	}
+	if held {
+		return true, nil
+	}
+
+	holder, err := r.client.Get(ctx, key).Result()
+	if err == redis.Nil {
+		// The hold expired between SETNX and GET
+		return r.holdUniqueKey(ctx, job)
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read job unique key: %w", err)
+	}
+	return holder == job.ID.String(), nil
+}
+
 // Dequeue retrieves the next job from the queue
 func (r *RedisAdapter) Dequeue(ctx context.Context, queueName string) (*SerializedJob, error) {
 	queueKey := r.queueKey(queueName)
@@ -263,6 +306,10 @@ func (r *RedisAdapter) jobKey(jobID uuid.UUID) string {
 	return fmt.Sprintf("%s:data:%s", r.prefix, jobID.String())
 }
 
+func (r *RedisAdapter) uniqueKey(key string) string {
+	return fmt.Sprintf("%s:unique:%s", r.prefix, key)
+}
+
 func (r *RedisAdapter) deadJobsKey() string {
 	return fmt.Sprintf("%s:dead", r.prefix)
 }
@@ -17,6 +17,7 @@ type SerializedJob struct {
 	Priority   int             `json:"priority" gorm:"not null;default:0"`
 	MaxRetries int             `json:"max_retries" gorm:"not null;default:3"`
 	Attempts   int             `json:"attempts" gorm:"not null;default:0"`
+	UniqueKey  string          `json:"unique_key,omitempty" gorm:"index"`
 	RunAt      time.Time       `json:"run_at" gorm:"not null;default:now()"`
 	LockedAt   *time.Time      `json:"locked_at" gorm:"index"`
 	LockedBy   string          `json:"locked_by"`
@@ -31,6 +32,21 @@ func (SerializedJob) TableName() string {
 	return "jobs"
 }
 
+// DefaultUniqueWindow is how long a job's unique key blocks duplicate enqueues.
+const DefaultUniqueWindow = 24 * time.Hour
+
+// JobUniqueKey records the job holding a unique key until the dedup window expires.
+type JobUniqueKey struct {
+	Key       string    `json:"key" gorm:"primary_key"`
+	JobID     uuid.UUID `json:"job_id" gorm:"type:uuid;not null"`
+	ExpiresAt time.Time `json:"expires_at" gorm:"not null;index"`
+}
+
+// TableName returns the table name for GORM
+func (JobUniqueKey) TableName() string {
+	return "job_unique_keys"
+}
+
 // QueueStats provides statistics about a queue
 type QueueStats struct {
 	Queue      string `json:"queue"`
@@ -98,9 +114,13 @@ type RedisAdapterConfig struct {
 	Password string
 	DB       int
 	Prefix   string
+	// UniqueWindow is the dedup window for unique jobs. Zero uses DefaultUniqueWindow.
+	UniqueWindow time.Duration
 }
 
 // DatabaseAdapterConfig contains configuration for Database adapter
 type DatabaseAdapterConfig struct {
 	DSN string
+	// UniqueWindow is the dedup window for unique jobs. Zero uses DefaultUniqueWindow.
+	UniqueWindow time.Duration
 }
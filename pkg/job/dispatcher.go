@@ -2,12 +2,16 @@ package job
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// ErrDispatcherNotConfigured is returned when dispatching through a zero-value Dispatcher.
+var ErrDispatcherNotConfigured = errors.New("job dispatcher is not configured")
+
 // Dispatcher provides a high-level API for dispatching jobs
 type Dispatcher struct {
 	adapter  Adapter
@@ -27,8 +31,19 @@ func (d *Dispatcher) Perform(job Job) error {
 	return d.PerformWithContext(context.Background(), job)
 }
 
-// PerformWithContext dispatches a job for immediate execution with context
+// PerformWithContext dispatches a job for immediate execution with context. A ScheduledJob
+// with a run time is dispatched for execution at that time instead.
 func (d *Dispatcher) PerformWithContext(ctx context.Context, job Job) error {
+	if scheduled, ok := job.(ScheduledJob); ok {
+		if runAt := scheduled.GetRunAt(); !runAt.IsZero() {
+			return d.PerformAtWithContext(ctx, job, runAt)
+		}
+	}
+
+	if d.adapter == nil || d.registry == nil {
+		return ErrDispatcherNotConfigured
+	}
+
 	serializedJob, err := d.registry.Serialize(job)
 	if err != nil {
 		return fmt.Errorf("failed to serialize job: %w", err)
@@ -60,6 +75,10 @@ func (d *Dispatcher) PerformAt(job Job, at time.Time) error {
 
 // PerformAtWithContext dispatches a job to be executed at a specific time with context
 func (d *Dispatcher) PerformAtWithContext(ctx context.Context, job Job, at time.Time) error {
+	if d.adapter == nil || d.registry == nil {
+		return ErrDispatcherNotConfigured
+	}
+
 	serializedJob, err := d.registry.Serialize(job)
 	if err != nil {
 		return fmt.Errorf("failed to serialize job: %w", err)
@@ -18,6 +18,27 @@ type Job interface {
 	Priority() int               // Higher = processed first
 }
 
+// UniqueJob is implemented by jobs that must not be enqueued twice within the adapter's
+// dedup window. Enqueuing a job whose key is already held by another job is a no-op.
+type UniqueJob interface {
+	Job
+	UniqueKey() string // Empty disables deduplication for this instance
+}
+
+// ScheduledJob is implemented by jobs that carry their own run time. The dispatcher holds
+// such a job in the adapter's delayed queue until its run time arrives.
+type ScheduledJob interface {
+	Job
+	GetRunAt() time.Time // Zero runs the job immediately
+}
+
+// TimeoutJob is implemented by jobs that need an execution limit other than the worker's
+// default, such as long PDF generation or short notifications.
+type TimeoutJob interface {
+	Job
+	Timeout() time.Duration // Non-positive falls back to the default timeout
+}
+
 // SerializedJob represents a job that can be persisted and transmitted
 type SerializedJob = adapter.SerializedJob
 
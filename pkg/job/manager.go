@@ -35,6 +35,13 @@ type ManagerConfig struct {
 	MaxRetries   int
 	Timeout      int64
 
+	// QueueConcurrency reserves dedicated workers per queue.
+	QueueConcurrency map[string]int
+	// QueueWeights sets the relative polling priority of each queue.
+	QueueWeights map[string]int
+	// UniqueWindow is how long a unique job's key blocks duplicates. Zero uses the adapter default.
+	UniqueWindow time.Duration
+
 	// Redis-specific
 	Redis RedisAdapterConfig
 
@@ -58,9 +65,11 @@ func NewManager(config ManagerConfig, logger *logger.Logger, metrics *metrics.Me
 
 	// Create worker
 	workerConfig := WorkerConfig{
-		Queues:       config.Queues,
-		Concurrency:  config.Concurrency,
-		PollInterval: time.Duration(config.PollInterval) * time.Second,
+		Queues:           config.Queues,
+		Concurrency:      config.Concurrency,
+		PollInterval:     time.Duration(config.PollInterval) * time.Second,
+		QueueConcurrency: config.QueueConcurrency,
+		QueueWeights:     config.QueueWeights,
 	}
 
 	worker := NewWorker(adapter, registry, workerConfig, logger)
@@ -149,18 +158,24 @@ func (m *Manager) Close() error {
 func createAdapter(config ManagerConfig) (Adapter, error) {
 	switch config.Adapter {
 	case "memory":
-		return adapter.NewMemoryAdapter(), nil
+		memoryAdapter := adapter.NewMemoryAdapter()
+		if config.UniqueWindow != 0 {
+			memoryAdapter.SetUniqueWindow(config.UniqueWindow)
+		}
+		return memoryAdapter, nil
 	case "redis":
 		redisConfig := adapter.RedisAdapterConfig{
-			Addr:     config.Redis.Addr,
-			Password: config.Redis.Password,
-			DB:       config.Redis.DB,
-			Prefix:   "bazaruto:jobs",
+			Addr:         config.Redis.Addr,
+			Password:     config.Redis.Password,
+			DB:           config.Redis.DB,
+			Prefix:       "bazaruto:jobs",
+			UniqueWindow: config.UniqueWindow,
 		}
 		return adapter.NewRedisAdapter(redisConfig)
 	case "database":
 		databaseConfig := adapter.DatabaseAdapterConfig{
-			DSN: config.Database.DSN,
+			DSN:          config.Database.DSN,
+			UniqueWindow: config.UniqueWindow,
 		}
 		return adapter.NewDatabaseAdapter(databaseConfig)
 	default:
@@ -61,10 +61,16 @@ func RetryMiddleware(maxRetries int) Middleware {
 	}
 }
 
-// TimeoutMiddleware adds a timeout to job execution
-func TimeoutMiddleware(timeout time.Duration) Middleware {
+// TimeoutMiddleware adds a timeout to job execution. Jobs implementing TimeoutJob run
+// under their own timeout; other jobs use defaultTimeout.
+func TimeoutMiddleware(defaultTimeout time.Duration) Middleware {
 	return func(next Handler) Handler {
 		return func(ctx context.Context, job Job) error {
+			timeout := defaultTimeout
+			if timed, ok := job.(TimeoutJob); ok && timed.Timeout() > 0 {
+				timeout = timed.Timeout()
+			}
+
 			ctx, cancel := context.WithTimeout(ctx, timeout)
 			defer cancel()
 
@@ -51,13 +51,18 @@ func (r *Registry) Serialize(job Job) (*SerializedJob, error) {
 		return nil, fmt.Errorf("failed to marshal job payload: %w", err)
 	}
 
-	return &SerializedJob{
+	serialized := &SerializedJob{
 		Type:       typeName,
 		Payload:    payload,
 		Queue:      job.Queue(),
 		Priority:   job.Priority(),
 		MaxRetries: job.MaxRetries(),
-	}, nil
+	}
+	if unique, ok := job.(UniqueJob); ok {
+		serialized.UniqueKey = unique.UniqueKey()
+	}
+
+	return serialized, nil
 }
 
 // Deserialize deserializes a SerializedJob back to a Job
@@ -0,0 +1,76 @@
+package job
+
+import (
+	"sort"
+	"sync"
+)
+
+// queueScheduler decides which queue a worker polls next. Queues are picked by smooth
+// weighted round-robin, so a queue with weight 10 is offered ten polls for every poll of
+// a queue with weight 1 without starving the lighter queue. The sequence is deterministic
+// for a given set of weights.
+type queueScheduler struct {
+	mu      sync.Mutex
+	queues  []string
+	weights []int
+	current []int
+	total   int
+}
+
+// newQueueScheduler creates a scheduler over queues. Queues without a positive weight get weight 1.
+func newQueueScheduler(queues []string, weights map[string]int) *queueScheduler {
+	s := &queueScheduler{
+		queues:  make([]string, len(queues)),
+		weights: make([]int, len(queues)),
+		current: make([]int, len(queues)),
+	}
+	copy(s.queues, queues)
+
+	// Order by weight so the fallback order after the selected queue is by priority.
+	sort.SliceStable(s.queues, func(i, j int) bool {
+		return queueWeight(weights, s.queues[i]) > queueWeight(weights, s.queues[j])
+	})
+	for i, queue := range s.queues {
+		s.weights[i] = queueWeight(weights, queue)
+		s.total += s.weights[i]
+	}
+
+	return s
+}
+
+// next returns the queues to poll in order: the queue selected for this turn first,
+// followed by the remaining queues from highest to lowest weight.
+func (s *queueScheduler) next() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.queues) == 0 {
+		return nil
+	}
+
+	selected := 0
+	for i := range s.queues {
+		s.current[i] += s.weights[i]
+		if s.current[i] > s.current[selected] {
+			selected = i
+		}
+	}
+	s.current[selected] -= s.total
+
+	order := make([]string, 0, len(s.queues))
+	order = append(order, s.queues[selected])
+	for i, queue := range s.queues {
+		if i != selected {
+			order = append(order, queue)
+		}
+	}
+	return order
+}
+
+// queueWeight returns the configured weight of a queue, defaulting to 1.
+func queueWeight(weights map[string]int, queue string) int {
+	if weight := weights[queue]; weight > 0 {
+		return weight
+	}
+	return 1
+}
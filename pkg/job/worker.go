@@ -2,6 +2,7 @@ package job
 
 import (
 	"context"
+	"sort"
 	"sync"
 	"time"
 
@@ -17,16 +18,20 @@ const (
 	workerIDKey contextKey = "worker_id"
 )
 
-// Worker processes jobs from queues using a pool of goroutines
+// Worker processes jobs from queues using a pool of goroutines. The shared pool polls
+// every queue in weighted order; queues with a dedicated concurrency also get their own
+// workers that poll only that queue.
 type Worker struct {
-	adapter     Adapter
-	registry    *Registry
-	queues      []string
-	concurrency int
-	middleware  []Middleware
-	shutdown    chan struct{}
-	waitGroup   sync.WaitGroup
-	logger      *logger.Logger
+	adapter          Adapter
+	registry         *Registry
+	queues           []string
+	concurrency      int
+	queueConcurrency map[string]int
+	scheduler        *queueScheduler
+	middleware       []Middleware
+	shutdown         chan struct{}
+	waitGroup        sync.WaitGroup
+	logger           *logger.Logger
 }
 
 // WorkerConfig contains configuration for the worker
@@ -34,6 +39,12 @@ type WorkerConfig struct {
 	Queues       []string
 	Concurrency  int
 	PollInterval time.Duration
+
+	// QueueConcurrency reserves dedicated workers for a queue in addition to the shared pool.
+	QueueConcurrency map[string]int
+	// QueueWeights sets how often the shared pool polls each queue relative to the others.
+	// Queues without a weight default to 1.
+	QueueWeights map[string]int
 }
 
 // NewWorker creates a new worker instance
@@ -47,13 +58,15 @@ func NewWorker(adapter Adapter, registry *Registry, config WorkerConfig, logger
 	}
 
 	return &Worker{
-		adapter:     adapter,
-		registry:    registry,
-		queues:      config.Queues,
-		concurrency: config.Concurrency,
-		middleware:  make([]Middleware, 0),
-		shutdown:    make(chan struct{}),
-		logger:      logger,
+		adapter:          adapter,
+		registry:         registry,
+		queues:           config.Queues,
+		concurrency:      config.Concurrency,
+		queueConcurrency: config.QueueConcurrency,
+		scheduler:        newQueueScheduler(config.Queues, config.QueueWeights),
+		middleware:       make([]Middleware, 0),
+		shutdown:         make(chan struct{}),
+		logger:           logger,
 	}
 }
 
@@ -68,10 +81,26 @@ func (w *Worker) Start(ctx context.Context) error {
 		zap.Strings("queues", w.queues),
 		zap.Int("concurrency", w.concurrency))
 
-	// Start worker goroutines
-	for i := 0; i < w.concurrency; i++ {
+	// Start the shared worker pool
+	workerID := 0
+	for ; workerID < w.concurrency; workerID++ {
 		w.waitGroup.Add(1)
-		go w.workerLoop(ctx, i)
+		go w.workerLoop(ctx, workerID, w.scheduler)
+	}
+
+	// Start dedicated workers for queues with their own concurrency
+	queues := make([]string, 0, len(w.queueConcurrency))
+	for queue := range w.queueConcurrency {
+		queues = append(queues, queue)
+	}
+	sort.Strings(queues)
+	for _, queue := range queues {
+		scheduler := newQueueScheduler([]string{queue}, nil)
+		for i := 0; i < w.queueConcurrency[queue]; i++ {
+			w.waitGroup.Add(1)
+			go w.workerLoop(ctx, workerID, scheduler)
+			workerID++
+		}
 	}
 
 	// Wait for shutdown signal
@@ -91,7 +120,7 @@ func (w *Worker) Stop() {
 }
 
 // workerLoop is the main loop for each worker goroutine
-func (w *Worker) workerLoop(ctx context.Context, workerID int) {
+func (w *Worker) workerLoop(ctx context.Context, workerID int, scheduler *queueScheduler) {
 	defer w.waitGroup.Done()
 
 	log := &logger.Logger{Logger: w.logger.With(zap.Int("worker_id", workerID))}
@@ -109,35 +138,52 @@ func (w *Worker) workerLoop(ctx context.Context, workerID int) {
 			log.Info("Worker context cancelled", zap.Error(ctx.Err()))
 			return
 		case <-ticker.C:
-			w.processJobs(ctx, workerID, log)
+			w.processJobs(ctx, workerID, log, scheduler)
 		}
 	}
 }
 
-// processJobs attempts to process jobs from all configured queues
-func (w *Worker) processJobs(ctx context.Context, workerID int, log *logger.Logger) {
-	for _, queue := range w.queues {
+// processJobs processes jobs until none of the scheduler's queues has a job ready.
+// Each job is taken from the queues in the order the scheduler gives for that turn.
+func (w *Worker) processJobs(ctx context.Context, workerID int, log *logger.Logger, scheduler *queueScheduler) {
+	for {
 		select {
 		case <-w.shutdown:
 			return
 		case <-ctx.Done():
 			return
 		default:
-			if err := w.processQueue(ctx, queue, workerID, log); err != nil {
-				// Log error but continue processing other queues
-				log.Error("Error processing queue", zap.Error(err), zap.String("queue", queue))
-			}
+		}
+
+		if !w.processNext(ctx, workerID, log, scheduler) {
+			return
+		}
+	}
+}
+
+// processNext processes one job from the first queue in the scheduler's order that has
+// one ready, reporting whether a job was found.
+func (w *Worker) processNext(ctx context.Context, workerID int, log *logger.Logger, scheduler *queueScheduler) bool {
+	for _, queue := range scheduler.next() {
+		found, err := w.processQueue(ctx, queue, workerID, log)
+		if err != nil {
+			// Log error but continue processing other queues
+			log.Error("Error processing queue", zap.Error(err), zap.String("queue", queue))
+		}
+		if found {
+			return true
 		}
 	}
+	return false
 }
 
-// processQueue processes a single job from the specified queue
-func (w *Worker) processQueue(ctx context.Context, queue string, workerID int, log *logger.Logger) error {
+// processQueue processes a single job from the specified queue, reporting whether a job was dequeued
+func (w *Worker) processQueue(ctx context.Context, queue string, workerID int, log *logger.Logger) (bool, error) {
 	// Try to dequeue a job
 	serializedJob, err := w.adapter.Dequeue(ctx, queue)
 	if err != nil {
 		// No jobs available, this is normal
-		return nil
+		return false, nil
 	}
 
 	// Deserialize the job
@@ -146,7 +192,7 @@ func (w *Worker) processQueue(ctx context.Context, queue string, workerID int, l
 		log.Error("Failed to deserialize job", zap.Error(err), zap.String("job_id", serializedJob.ID.String()))
 		// Move to dead letter queue
 		_ = w.adapter.Dead(ctx, serializedJob)
-		return err
+		return true, err
 	}
 
 	// Create execution context with job ID
@@ -187,7 +233,7 @@ func (w *Worker) processQueue(ctx context.Context, queue string, workerID int, l
 		}
 	}
 
-	return nil
+	return true, nil
 }
 
 // buildHandler builds the middleware chain for job execution
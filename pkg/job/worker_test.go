@@ -0,0 +1,202 @@
+package job
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edsonmichaque/bazaruto/internal/logger"
+	"github.com/edsonmichaque/bazaruto/pkg/job/adapter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// executions records the queue of every recordingJob performed, in order.
+var executions struct {
+	sync.Mutex
+	queues []string
+}
+
+// recordingJob records its queue when performed.
+type recordingJob struct {
+	QueueName string    `json:"queue_name"`
+	Key       string    `json:"key,omitempty"`
+	RunAt     time.Time `json:"run_at,omitempty"`
+}
+
+func (j *recordingJob) Perform(ctx context.Context) error {
+	executions.Lock()
+	defer executions.Unlock()
+	executions.queues = append(executions.queues, j.QueueName)
+	return nil
+}
+
+func (j *recordingJob) Queue() string               { return j.QueueName }
+func (j *recordingJob) MaxRetries() int             { return 0 }
+func (j *recordingJob) RetryBackoff() time.Duration { return time.Second }
+func (j *recordingJob) Priority() int               { return PriorityNormal }
+func (j *recordingJob) UniqueKey() string           { return j.Key }
+func (j *recordingJob) GetRunAt() time.Time         { return j.RunAt }
+
+// resetExecutions clears the recorded executions.
+func resetExecutions() {
+	executions.Lock()
+	defer executions.Unlock()
+	executions.queues = nil
+}
+
+// recordedCount returns the number of recorded executions.
+func recordedCount() int {
+	executions.Lock()
+	defer executions.Unlock()
+	return len(executions.queues)
+}
+
+// newTestWorker creates a worker and dispatcher over a fresh memory adapter.
+func newTestWorker(config WorkerConfig) (*Worker, *Dispatcher, *adapter.MemoryAdapter) {
+	memory := adapter.NewMemoryAdapter()
+	registry := NewRegistry()
+	registry.RegisterJob(&recordingJob{})
+	return NewWorker(memory, registry, config, logger.NewLogger("error", "json")), NewDispatcher(memory, registry), memory
+}
+
+func TestQueueSchedulerIsWeightedAndDeterministic(t *testing.T) {
+	scheduler := newQueueScheduler([]string{QueueMailers, QueuePayments}, map[string]int{QueuePayments: 3})
+
+	var picks []string
+	for i := 0; i < 8; i++ {
+		order := scheduler.next()
+		require.Len(t, order, 2)
+		picks = append(picks, order[0])
+	}
+
+	assert.Equal(t, []string{
+		QueuePayments, QueuePayments, QueueMailers, QueuePayments,
+		QueuePayments, QueuePayments, QueueMailers, QueuePayments,
+	}, picks)
+}
+
+func TestWorkerDrainsHighPriorityQueueFirst(t *testing.T) {
+	resetExecutions()
+
+	ctx := context.Background()
+	worker, dispatcher, _ := newTestWorker(WorkerConfig{
+		Queues:       []string{QueueMailers, QueuePayments},
+		Concurrency:  1,
+		QueueWeights: map[string]int{QueuePayments: 10, QueueMailers: 1},
+	})
+
+	// Enqueue the bulk mailers first so they are older than the payments.
+	for i := 0; i < 5; i++ {
+		require.NoError(t, dispatcher.PerformWithContext(ctx, &recordingJob{QueueName: QueueMailers}))
+	}
+	for i := 0; i < 5; i++ {
+		require.NoError(t, dispatcher.PerformWithContext(ctx, &recordingJob{QueueName: QueuePayments}))
+	}
+
+	worker.processJobs(ctx, 0, worker.logger, worker.scheduler)
+
+	executions.Lock()
+	defer executions.Unlock()
+	require.Len(t, executions.queues, 10)
+	for i, queue := range executions.queues[:5] {
+		assert.Equal(t, QueuePayments, queue, "execution %d", i)
+	}
+	for i, queue := range executions.queues[5:] {
+		assert.Equal(t, QueueMailers, queue, "execution %d", i+5)
+	}
+}
+
+func TestDuplicateUniqueJobWithinWindowRunsOnce(t *testing.T) {
+	resetExecutions()
+
+	ctx := context.Background()
+	worker, dispatcher, _ := newTestWorker(WorkerConfig{Queues: []string{QueueNotifications}, Concurrency: 1})
+
+	// A batch rerun enqueues the same reminder twice
+	reminder := &recordingJob{QueueName: QueueNotifications, Key: "invoice_overdue_reminder:inv-1:2026-10-17"}
+	require.NoError(t, dispatcher.PerformWithContext(ctx, reminder))
+	require.NoError(t, dispatcher.PerformWithContext(ctx, reminder))
+
+	// A different key is not affected
+	require.NoError(t, dispatcher.PerformWithContext(ctx, &recordingJob{QueueName: QueueNotifications, Key: "invoice_overdue_reminder:inv-2:2026-10-17"}))
+
+	worker.processJobs(ctx, 0, worker.logger, worker.scheduler)
+
+	executions.Lock()
+	defer executions.Unlock()
+	assert.Len(t, executions.queues, 2)
+}
+
+func TestScheduledJobWaitsForRunAt(t *testing.T) {
+	resetExecutions()
+
+	ctx := context.Background()
+	worker, dispatcher, memory := newTestWorker(WorkerConfig{Queues: []string{QueueMailers}, Concurrency: 1})
+
+	now := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	memory.SetClock(func() time.Time { return now })
+
+	require.NoError(t, dispatcher.PerformWithContext(ctx, &recordingJob{QueueName: QueueMailers, RunAt: now.Add(time.Hour)}))
+
+	worker.processJobs(ctx, 0, worker.logger, worker.scheduler)
+	assert.Equal(t, 0, recordedCount(), "job ran before its run time")
+
+	now = now.Add(time.Hour)
+	worker.processJobs(ctx, 0, worker.logger, worker.scheduler)
+	assert.Equal(t, 1, recordedCount())
+}
+
+// slowJob blocks until its context is cancelled.
+type slowJob struct {
+	Limit time.Duration `json:"limit"`
+}
+
+func (j *slowJob) Perform(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (j *slowJob) Queue() string               { return QueueHeavy }
+func (j *slowJob) MaxRetries() int             { return 3 }
+func (j *slowJob) RetryBackoff() time.Duration { return time.Second }
+func (j *slowJob) Priority() int               { return PriorityNormal }
+func (j *slowJob) Timeout() time.Duration      { return j.Limit }
+
+func TestJobExceedingItsOwnTimeoutIsCancelledAndRetried(t *testing.T) {
+	ctx := context.Background()
+	memory := adapter.NewMemoryAdapter()
+	registry := NewRegistry()
+	registry.RegisterJob(&slowJob{})
+	dispatcher := NewDispatcher(memory, registry)
+
+	worker := NewWorker(memory, registry, WorkerConfig{Queues: []string{QueueHeavy}, Concurrency: 1}, logger.NewLogger("error", "json"))
+	// The worker default would let the job run for an hour
+	worker.Use(TimeoutMiddleware(time.Hour))
+
+	now := time.Now()
+	memory.SetClock(func() time.Time { return now })
+
+	require.NoError(t, dispatcher.PerformWithContext(ctx, &slowJob{Limit: 20 * time.Millisecond}))
+
+	done := make(chan struct{})
+	go func() {
+		worker.processJobs(ctx, 0, worker.logger, worker.scheduler)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("job was not cancelled by its own timeout")
+	}
+
+	// The cancelled job is back in the queue, waiting out its retry backoff
+	_, err := memory.Dequeue(ctx, QueueHeavy)
+	require.Error(t, err)
+
+	now = now.Add(time.Minute)
+	retried, err := memory.Dequeue(ctx, QueueHeavy)
+	require.NoError(t, err)
+	assert.Equal(t, 1, retried.Attempts)
+}
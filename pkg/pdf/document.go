@@ -0,0 +1,223 @@
+// Package pdf renders simple branded text documents, such as quotes and letters, to PDF.
+//
+// Documents use the standard Helvetica fonts with WinAnsi encoding and uncompressed content
+// streams, so no font files are embedded and the text can be read back with ExtractText.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Page geometry in points (A4).
+const (
+	pageWidth    = 595.0
+	pageHeight   = 842.0
+	marginX      = 50.0
+	marginBottom = 60.0
+	headerHeight = 70.0
+	valueX       = 360.0
+)
+
+// Font sizes and line heights in points.
+const (
+	titleSize   = 18.0
+	headingSize = 13.0
+	textSize    = 10.5
+	lineHeight  = 16.0
+)
+
+// Color is an RGB color with components between 0 and 1.
+type Color struct {
+	R, G, B float64
+}
+
+// DefaultBrandColor is the header band color used when none is set.
+var DefaultBrandColor = Color{R: 0.05, G: 0.32, B: 0.45}
+
+// Document is a PDF document built line by line. Text flows down the page and continues
+// on a new page when the current one is full. Every page starts with a header band showing
+// the brand name.
+type Document struct {
+	brand      string
+	title      string
+	brandColor Color
+	pages      []*bytes.Buffer
+	y          float64
+}
+
+// New creates a document whose pages carry brand in the header band and title below it.
+func New(brand, title string) *Document {
+	d := &Document{
+		brand:      brand,
+		title:      title,
+		brandColor: DefaultBrandColor,
+	}
+	d.newPage()
+	return d
+}
+
+// SetBrandColor sets the header band color for pages added after the call.
+func (d *Document) SetBrandColor(color Color) {
+	d.brandColor = color
+}
+
+// Heading writes a bold section heading preceded by a blank line.
+func (d *Document) Heading(text string) {
+	d.ensureSpace(lineHeight * 2)
+	d.y -= lineHeight / 2
+	d.writeLine(marginX, "F2", headingSize, text)
+	d.y -= lineHeight * 1.25
+}
+
+// Text writes a line of regular text. Long text is wrapped to the page width.
+func (d *Document) Text(text string) {
+	for _, line := range wrap(text, textSize, pageWidth-2*marginX) {
+		d.ensureSpace(lineHeight)
+		d.writeLine(marginX, "F1", textSize, line)
+		d.y -= lineHeight
+	}
+}
+
+// Row writes a label and its value on the same line, with values aligned in a column.
+func (d *Document) Row(label, value string) {
+	d.row("F1", label, value)
+}
+
+// TotalRow writes a label and value in bold, for totals.
+func (d *Document) TotalRow(label, value string) {
+	d.row("F2", label, value)
+}
+
+// Space adds a blank line.
+func (d *Document) Space() {
+	d.y -= lineHeight
+}
+
+// Bytes returns the encoded PDF.
+func (d *Document) Bytes() ([]byte, error) {
+	var out bytes.Buffer
+	offsets := []int{}
+
+	writeObject := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Objects 1-4 are the catalog, page tree and fonts; each page then takes two objects.
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+i*2)
+	}
+	writeObject("<< /Type /Catalog /Pages 2 0 R >>")
+	writeObject(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	writeObject("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	writeObject("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, content := range d.pages {
+		writeObject(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] "+
+			"/Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, 6+i*2))
+		writeObject(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return out.Bytes(), nil
+}
+
+// row writes a label and value with the given font.
+func (d *Document) row(font, label, value string) {
+	d.ensureSpace(lineHeight)
+	page := d.page()
+	fmt.Fprintf(page, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj %.2f 0 Td (%s) Tj ET\n",
+		font, textSize, marginX, d.y, encode(label), valueX-marginX, encode(value))
+	d.y -= lineHeight
+}
+
+// writeLine writes a single line of text at the current vertical position.
+func (d *Document) writeLine(x float64, font string, size float64, text string) {
+	fmt.Fprintf(d.page(), "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, d.y, encode(text))
+}
+
+// ensureSpace starts a new page when fewer than height points remain on the current one.
+func (d *Document) ensureSpace(height float64) {
+	if d.y-height < marginBottom {
+		d.newPage()
+	}
+}
+
+// newPage starts a page with the brand header band and document title.
+func (d *Document) newPage() {
+	page := &bytes.Buffer{}
+	d.pages = append(d.pages, page)
+
+	c := d.brandColor
+	fmt.Fprintf(page, "q %.3f %.3f %.3f rg 0 %.2f %.2f %.2f re f Q\n",
+		c.R, c.G, c.B, pageHeight-headerHeight, pageWidth, headerHeight)
+	fmt.Fprintf(page, "q 1 1 1 rg BT /F2 %.1f Tf %.2f %.2f Td (%s) Tj ET Q\n",
+		titleSize, marginX, pageHeight-headerHeight/2-titleSize/3, encode(d.brand))
+
+	d.y = pageHeight - headerHeight - 2*lineHeight
+	if d.title != "" {
+		d.writeLine(marginX, "F2", titleSize, d.title)
+		d.y -= lineHeight * 2
+	}
+}
+
+// page returns the page being written.
+func (d *Document) page() *bytes.Buffer {
+	return d.pages[len(d.pages)-1]
+}
+
+// encode escapes text for a PDF string literal in WinAnsi encoding. Characters outside
+// Latin-1 are replaced with '?'.
+func encode(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\t':
+			b.WriteByte(' ')
+		case r >= 0x20 && r < 0x7f:
+			b.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+// wrap splits text into lines that fit width at the given font size, using Helvetica's
+// average character width.
+func wrap(text string, size, width float64) []string {
+	maxChars := int(width / (size * 0.5))
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return []string{""}
+	}
+
+	var lines []string
+	line := words[0]
+	for _, word := range words[1:] {
+		if len(line)+1+len(word) > maxChars {
+			lines = append(lines, line)
+			line = word
+			continue
+		}
+		line += " " + word
+	}
+	return append(lines, line)
+}
@@ -0,0 +1,50 @@
+package pdf
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractTextReadsBackDocumentText(t *testing.T) {
+	doc := New("Bazaruto", "Letter (draft)")
+	doc.Heading("Décision")
+	doc.Row("Amount", "1,250.00 USD")
+	doc.Text(`Backslash \ and parentheses (kept)`)
+
+	data, err := doc.Bytes()
+	require.NoError(t, err)
+
+	lines, err := ExtractText(data)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"Bazaruto",
+		"Letter (draft)",
+		"Décision",
+		"Amount 1,250.00 USD",
+		`Backslash \ and parentheses (kept)`,
+	}, lines)
+}
+
+func TestDocumentContinuesOnNewPage(t *testing.T) {
+	doc := New("Bazaruto", "")
+	for i := 0; i < 80; i++ {
+		doc.Row(fmt.Sprintf("Line %d", i), "value")
+	}
+	require.Greater(t, len(doc.pages), 1)
+
+	data, err := doc.Bytes()
+	require.NoError(t, err)
+	lines, err := ExtractText(data)
+	require.NoError(t, err)
+
+	rows := 0
+	for _, line := range lines {
+		if line != "Bazaruto" {
+			rows++
+		}
+	}
+	assert.Equal(t, 80, rows)
+}
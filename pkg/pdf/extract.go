@@ -0,0 +1,129 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// ExtractText returns the text lines of a PDF written by Document, in drawing order.
+// Each text object becomes one line; a row's label and value are joined by a space.
+// Compressed streams and fonts other than the simple encodings Document uses are not
+// supported.
+func ExtractText(data []byte) ([]string, error) {
+	if !bytes.HasPrefix(data, []byte("%PDF-")) {
+		return nil, fmt.Errorf("not a PDF document")
+	}
+
+	var lines []string
+	rest := data
+	for {
+		start := bytes.Index(rest, []byte("stream\n"))
+		if start < 0 {
+			break
+		}
+		rest = rest[start+len("stream\n"):]
+		end := bytes.Index(rest, []byte("endstream"))
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated content stream")
+		}
+
+		streamLines, err := extractStreamText(rest[:end])
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, streamLines...)
+		rest = rest[end+len("endstream"):]
+	}
+
+	return lines, nil
+}
+
+// extractStreamText collects the strings shown between each BT and ET operator pair.
+func extractStreamText(content []byte) ([]string, error) {
+	var lines []string
+	var parts []string
+	inText := false
+
+	for i := 0; i < len(content); i++ {
+		switch c := content[i]; {
+		case c == '(':
+			text, next, err := readString(content, i+1)
+			if err != nil {
+				return nil, err
+			}
+			if inText {
+				parts = append(parts, text)
+			}
+			i = next
+		case isOperator(content, i, "BT"):
+			inText = true
+			parts = parts[:0]
+			i++
+		case isOperator(content, i, "ET"):
+			if inText && len(parts) > 0 {
+				lines = append(lines, strings.Join(parts, " "))
+			}
+			inText = false
+			i++
+		}
+	}
+
+	return lines, nil
+}
+
+// isOperator reports whether the two-letter operator op starts at i as a separate token.
+func isOperator(content []byte, i int, op string) bool {
+	if !bytes.HasPrefix(content[i:], []byte(op)) {
+		return false
+	}
+	before := i == 0 || isSpace(content[i-1])
+	after := i+len(op) == len(content) || isSpace(content[i+len(op)])
+	return before && after
+}
+
+// isSpace reports whether c is PDF whitespace.
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\r' || c == '\t'
+}
+
+// readString decodes a string literal starting after its opening parenthesis, returning
+// the text and the index of the closing parenthesis. Bytes are decoded as Latin-1.
+func readString(content []byte, i int) (string, int, error) {
+	var b strings.Builder
+	for ; i < len(content); i++ {
+		c := content[i]
+		switch c {
+		case ')':
+			return b.String(), i, nil
+		case '\\':
+			i++
+			if i >= len(content) {
+				return "", i, fmt.Errorf("unterminated string escape")
+			}
+			if content[i] >= '0' && content[i] <= '7' {
+				value := 0
+				for n := 0; n < 3 && i < len(content) && content[i] >= '0' && content[i] <= '7'; n++ {
+					value = value*8 + int(content[i]-'0')
+					i++
+				}
+				i--
+				b.WriteRune(rune(value))
+				continue
+			}
+			switch content[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			default:
+				b.WriteByte(content[i])
+			}
+		default:
+			b.WriteRune(rune(c))
+		}
+	}
+	return "", i, fmt.Errorf("unterminated string")
+}